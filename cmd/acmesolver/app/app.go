@@ -18,22 +18,45 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/go-logr/logr"
+
 	"github.com/spf13/cobra"
 
 	"github.com/jetstack/cert-manager/cmd/util"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/http/solver"
+	tlsalpnsolver "github.com/jetstack/cert-manager/pkg/issuer/acme/tlsalpn/solver"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
 
+// challengeServer is implemented by the servers answering validation
+// requests for each supported challenge type.
+type challengeServer interface {
+	Listen(log logr.Logger) error
+	Shutdown(ctx context.Context) error
+}
+
 func NewACMESolverCommand(stopCh <-chan struct{}) *cobra.Command {
-	s := new(solver.HTTP01Solver)
+	var challengeType string
+	var listenPort int
+	var domain, token, key string
 
 	cmd := &cobra.Command{
 		Use:   "acmesolver",
-		Short: "HTTP server used to solve ACME challenges.",
+		Short: "HTTP and TLS server used to solve ACME challenges.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			var s challengeServer
+			switch challengeType {
+			case "http-01":
+				s = &solver.HTTP01Solver{ListenPort: listenPort, Domain: domain, Token: token, Key: key}
+			case "tls-alpn-01":
+				s = &tlsalpnsolver.TLSALPN01Solver{ListenPort: listenPort, Domain: domain, Key: key}
+			default:
+				return fmt.Errorf("unsupported challenge type %q", challengeType)
+			}
+
 			rootCtx := util.ContextWithStopCh(context.Background(), stopCh)
 			rootCtx = logf.NewContext(rootCtx, nil, "acmesolver")
 			log := logf.FromContext(rootCtx)
@@ -61,10 +84,11 @@ func NewACMESolverCommand(stopCh <-chan struct{}) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().IntVar(&s.ListenPort, "listen-port", 8089, "the port number to listen on for connections")
-	cmd.Flags().StringVar(&s.Domain, "domain", "", "the domain name to verify")
-	cmd.Flags().StringVar(&s.Token, "token", "", "the challenge token to verify against")
-	cmd.Flags().StringVar(&s.Key, "key", "", "the challenge key to respond with")
+	cmd.Flags().StringVar(&challengeType, "challenge-type", "http-01", "the type of challenge to solve, either http-01 or tls-alpn-01")
+	cmd.Flags().IntVar(&listenPort, "listen-port", 8089, "the port number to listen on for connections")
+	cmd.Flags().StringVar(&domain, "domain", "", "the domain name to verify")
+	cmd.Flags().StringVar(&token, "token", "", "the challenge token to verify against (http-01 only)")
+	cmd.Flags().StringVar(&key, "key", "", "the challenge key to respond with")
 
 	return cmd
 }
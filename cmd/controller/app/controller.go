@@ -20,11 +20,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 	"time"
 
+	"github.com/go-logr/logr"
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -48,13 +50,16 @@ import (
 	"github.com/jetstack/cert-manager/cmd/controller/app/options"
 	cmdutil "github.com/jetstack/cert-manager/cmd/util"
 	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	clientset "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	intscheme "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/scheme"
 	informers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
 	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/cacrl"
 	"github.com/jetstack/cert-manager/pkg/controller/clusterissuers"
 	"github.com/jetstack/cert-manager/pkg/feature"
 	dnsutil "github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/ocspresponder"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/metrics"
 	"github.com/jetstack/cert-manager/pkg/util"
@@ -65,7 +70,7 @@ const controllerAgentName = "cert-manager"
 
 // This sets the informer's resync period to 10 hours
 // following the controller-runtime defaults
-//and following discussion: https://github.com/kubernetes-sigs/controller-runtime/pull/88#issuecomment-408500629
+// and following discussion: https://github.com/kubernetes-sigs/controller-runtime/pull/88#issuecomment-408500629
 const resyncPeriod = 10 * time.Hour
 
 func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
@@ -191,6 +196,30 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 		})
 	}
 
+	// The CRL and OCSP servers must be constructed before the informer
+	// factories are started so that their listers are registered.
+	servers := []struct {
+		name    string
+		address string
+		handler func() http.Handler
+	}{
+		{name: "CRL", address: opts.CRLServerListenAddress, handler: func() http.Handler { return cacrl.NewServer(ctx) }},
+		{name: "OCSP", address: opts.OCSPServerListenAddress, handler: func() http.Handler { return ocspresponder.NewResponder(ctx) }},
+	}
+	for _, s := range servers {
+		if s.address == "" {
+			continue
+		}
+		if err := serveHTTP(rootCtx, g, log, s.name, s.address, s.handler()); err != nil {
+			cancelContext()
+			err2 := g.Wait() // Don't process errors, we already have an error
+			if err2 != nil {
+				return utilerrors.NewAggregate([]error{err, err2})
+			}
+			return err
+		}
+	}
+
 	log.V(logf.DebugLevel).Info("starting shared informer factories")
 	ctx.SharedInformerFactory.Start(rootCtx.Done())
 	ctx.KubeSharedInformerFactory.Start(rootCtx.Done())
@@ -208,6 +237,39 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 	return nil
 }
 
+// serveHTTP starts an HTTP server for the given handler in the error group.
+// The server is shut down once ctx is cancelled.
+func serveHTTP(ctx context.Context, g *errgroup.Group, log logr.Logger, name, address string, handler http.Handler) error {
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s server address %s: %v", name, address, err)
+	}
+	server := &http.Server{
+		Addr:           ln.Addr().String(),
+		ReadTimeout:    8 * time.Second,
+		WriteTimeout:   8 * time.Second,
+		MaxHeaderBytes: 1 << 20, // 1 MiB
+		Handler:        handler,
+	}
+
+	g.Go(func() error {
+		<-ctx.Done()
+		// allow a timeout for graceful shutdown
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		return server.Shutdown(ctx)
+	})
+	g.Go(func() error {
+		log.V(logf.InfoLevel).Info(fmt.Sprintf("starting %s server", name), "address", ln.Addr())
+		if err := server.Serve(ln); err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	})
+	return nil
+}
+
 func buildControllerContext(ctx context.Context, opts *options.ControllerOptions) (*controller.Context, *rest.Config, error) {
 	log := logf.FromContext(ctx, "build-context")
 	// Load the users Kubernetes config
@@ -267,6 +329,14 @@ func buildControllerContext(ctx context.Context, opts *options.ControllerOptions
 	}
 	log.V(logf.InfoLevel).WithValues("nameservers", nameservers).Info("configured acme dns01 nameservers")
 
+	var approvalWebhookCABundle []byte
+	if opts.ApprovalWebhookCAFile != "" {
+		approvalWebhookCABundle, err = ioutil.ReadFile(opts.ApprovalWebhookCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading approval webhook CA file: %s", err.Error())
+		}
+	}
+
 	HTTP01SolverResourceRequestCPU, err := resource.ParseQuantity(opts.ACMEHTTP01SolverResourceRequestCPU)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error parsing ACMEHTTP01SolverResourceRequestCPU: %s", err.Error())
@@ -302,7 +372,9 @@ func buildControllerContext(ctx context.Context, opts *options.ControllerOptions
 	kubeSharedInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(cl, resyncPeriod, kubeinformers.WithNamespace(opts.Namespace))
 	gwSharedInformerFactory := gwinformers.NewSharedInformerFactoryWithOptions(gwcl, resyncPeriod, gwinformers.WithNamespace(opts.Namespace))
 
-	acmeAccountRegistry := accounts.NewDefaultRegistry()
+	controllerMetrics := metrics.New(log, clock.RealClock{})
+
+	acmeAccountRegistry := accounts.NewDefaultRegistry(acmecl.NewRateLimits(clock.RealClock{}, controllerMetrics))
 
 	return &controller.Context{
 		RootContext:               ctx,
@@ -319,7 +391,8 @@ func buildControllerContext(ctx context.Context, opts *options.ControllerOptions
 		GatewaySolverEnabled:      gatewayAvailable,
 		Namespace:                 opts.Namespace,
 		Clock:                     clock.RealClock{},
-		Metrics:                   metrics.New(log, clock.RealClock{}),
+		Metrics:                   controllerMetrics,
+		VaultTokenCache:           controller.NewVaultTokenCache(clock.RealClock{}, controllerMetrics),
 		ACMEOptions: controller.ACMEOptions{
 			HTTP01SolverImage:                 opts.ACMEHTTP01SolverImage,
 			HTTP01SolverResourceRequestCPU:    HTTP01SolverResourceRequestCPU,
@@ -343,12 +416,20 @@ func buildControllerContext(ctx context.Context, opts *options.ControllerOptions
 			DefaultAutoCertificateAnnotations: opts.DefaultAutoCertificateAnnotations,
 		},
 		CertificateOptions: controller.CertificateOptions{
-			EnableOwnerRef:           opts.EnableCertificateOwnerRef,
-			CopiedAnnotationPrefixes: opts.CopiedAnnotationPrefixes,
+			EnableOwnerRef:                opts.EnableCertificateOwnerRef,
+			CopiedAnnotationPrefixes:      opts.CopiedAnnotationPrefixes,
+			IssuerCARotationReissueWindow: opts.IssuerCARotationReissueWindow,
+			IssuerCARotationReissueQPS:    opts.IssuerCARotationReissueQPS,
 		},
 		SchedulerOptions: controller.SchedulerOptions{
 			MaxConcurrentChallenges: opts.MaxConcurrentChallenges,
 		},
+		ApprovalOptions: controller.ApprovalOptions{
+			WebhookURL:          opts.ApprovalWebhookURL,
+			WebhookCABundle:     approvalWebhookCABundle,
+			WebhookTimeout:      opts.ApprovalWebhookTimeout,
+			WebhookPollInterval: opts.ApprovalWebhookPollInterval,
+		},
 	}, kubeCfg, nil
 }
 
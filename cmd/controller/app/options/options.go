@@ -19,6 +19,7 @@ package options
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"strings"
 	"time"
 
@@ -28,23 +29,32 @@ import (
 	cm "github.com/jetstack/cert-manager/pkg/apis/certmanager"
 	challengescontroller "github.com/jetstack/cert-manager/pkg/controller/acmechallenges"
 	orderscontroller "github.com/jetstack/cert-manager/pkg/controller/acmeorders"
+	bundlescontroller "github.com/jetstack/cert-manager/pkg/controller/bundles"
+	cacrlcontroller "github.com/jetstack/cert-manager/pkg/controller/cacrl"
 	shimgatewaycontroller "github.com/jetstack/cert-manager/pkg/controller/certificate-shim/gateways"
 	shimingresscontroller "github.com/jetstack/cert-manager/pkg/controller/certificate-shim/ingresses"
 	cracmecontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/acme"
 	crapprovercontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/approver"
 	crcacontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/ca"
+	crcmpcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/cmp"
+	crestcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/est"
+	crscepcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/scep"
 	crselfsignedcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/selfsigned"
 	crvaultcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/vault"
 	crvenaficontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/venafi"
 	"github.com/jetstack/cert-manager/pkg/controller/certificates/issuing"
 	"github.com/jetstack/cert-manager/pkg/controller/certificates/keymanager"
 	certificatesmetricscontroller "github.com/jetstack/cert-manager/pkg/controller/certificates/metrics"
+	"github.com/jetstack/cert-manager/pkg/controller/certificates/ocspstapling"
 	"github.com/jetstack/cert-manager/pkg/controller/certificates/readiness"
+	"github.com/jetstack/cert-manager/pkg/controller/certificates/renewalinfo"
 	"github.com/jetstack/cert-manager/pkg/controller/certificates/requestmanager"
 	"github.com/jetstack/cert-manager/pkg/controller/certificates/revisionmanager"
+	"github.com/jetstack/cert-manager/pkg/controller/certificates/revocation"
 	"github.com/jetstack/cert-manager/pkg/controller/certificates/trigger"
 	csracmecontroller "github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/acme"
 	csrcacontroller "github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/ca"
+	csrestcontroller "github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/est"
 	csrselfsignedcontroller "github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/selfsigned"
 	csrvaultcontroller "github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/vault"
 	csrvenaficontroller "github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/venafi"
@@ -105,12 +115,33 @@ type ControllerOptions struct {
 	// the HTTP listener.
 	EnablePprof bool
 
+	// The host and port address, separated by a ':', that CRLs published by
+	// CA issuers should be served on. The server is disabled if empty.
+	CRLServerListenAddress string
+	// The host and port address, separated by a ':', that the OCSP responder
+	// for CA issuers should listen on. The responder is disabled if empty.
+	OCSPServerListenAddress string
+
 	DNS01CheckRetryPeriod time.Duration
 
 	// Annotations copied Certificate -> CertificateRequest,
 	// CertificateRequest -> Order. Slice of string literals that are
 	// treated as prefixes for annotation keys.
 	CopiedAnnotationPrefixes []string
+
+	// The duration over which re-issuance of certificates is spread out
+	// after the CA of their issuer has changed, and the maximum number of
+	// such re-issuances per second.
+	IssuerCARotationReissueWindow time.Duration
+	IssuerCARotationReissueQPS    float32
+
+	// The URL CertificateRequests are sent to for approval, the CA bundle
+	// used to verify it, the timeout of requests to it, and the interval at
+	// which pending reviews are polled.
+	ApprovalWebhookURL          string
+	ApprovalWebhookCAFile       string
+	ApprovalWebhookTimeout      time.Duration
+	ApprovalWebhookPollInterval time.Duration
 }
 
 const (
@@ -143,6 +174,12 @@ const (
 	defaultPrometheusMetricsServerAddress = "0.0.0.0:9402"
 
 	defaultDNS01CheckRetryPeriod = 10 * time.Second
+
+	defaultIssuerCARotationReissueWindow         = time.Hour
+	defaultIssuerCARotationReissueQPS    float32 = 1
+
+	defaultApprovalWebhookTimeout      = 10 * time.Second
+	defaultApprovalWebhookPollInterval = time.Minute
 )
 
 var (
@@ -157,6 +194,7 @@ var (
 	allControllers = []string{
 		issuerscontroller.ControllerName,
 		clusterissuerscontroller.ControllerName,
+		cacrlcontroller.ControllerName,
 		certificatesmetricscontroller.ControllerName,
 		shimingresscontroller.ControllerName,
 		shimgatewaycontroller.ControllerName,
@@ -168,6 +206,9 @@ var (
 		crselfsignedcontroller.CRControllerName,
 		crvaultcontroller.CRControllerName,
 		crvenaficontroller.CRControllerName,
+		crestcontroller.CRControllerName,
+		crscepcontroller.CRControllerName,
+		crcmpcontroller.CRControllerName,
 		// certificate controllers
 		trigger.ControllerName,
 		issuing.ControllerName,
@@ -175,11 +216,17 @@ var (
 		requestmanager.ControllerName,
 		readiness.ControllerName,
 		revisionmanager.ControllerName,
+		revocation.ControllerName,
+		renewalinfo.ControllerName,
+		ocspstapling.ControllerName,
+		// trust bundle controllers
+		bundlescontroller.ControllerName,
 	}
 
 	defaultEnabledControllers = []string{
 		issuerscontroller.ControllerName,
 		clusterissuerscontroller.ControllerName,
+		cacrlcontroller.ControllerName,
 		certificatesmetricscontroller.ControllerName,
 		shimingresscontroller.ControllerName,
 		orderscontroller.ControllerName,
@@ -190,6 +237,9 @@ var (
 		crselfsignedcontroller.CRControllerName,
 		crvaultcontroller.CRControllerName,
 		crvenaficontroller.CRControllerName,
+		crestcontroller.CRControllerName,
+		crscepcontroller.CRControllerName,
+		crcmpcontroller.CRControllerName,
 		// certificate controllers
 		trigger.ControllerName,
 		issuing.ControllerName,
@@ -197,6 +247,8 @@ var (
 		requestmanager.ControllerName,
 		readiness.ControllerName,
 		revisionmanager.ControllerName,
+		revocation.ControllerName,
+		ocspstapling.ControllerName,
 	}
 
 	experimentalCertificateSigningRequestControllers = []string{
@@ -205,6 +257,7 @@ var (
 		csrselfsignedcontroller.CSRControllerName,
 		csrvenaficontroller.CSRControllerName,
 		csrvaultcontroller.CSRControllerName,
+		csrestcontroller.CSRControllerName,
 	}
 	// Annotations that will be copied from Certificate to CertificateRequest and to Order.
 	// By default, copy all annotations except for the ones applied by kubectl, fluxcd, argocd.
@@ -241,6 +294,10 @@ func NewControllerOptions() *ControllerOptions {
 		MetricsListenAddress:              defaultPrometheusMetricsServerAddress,
 		DNS01CheckRetryPeriod:             defaultDNS01CheckRetryPeriod,
 		EnablePprof:                       false,
+		IssuerCARotationReissueWindow:     defaultIssuerCARotationReissueWindow,
+		IssuerCARotationReissueQPS:        defaultIssuerCARotationReissueQPS,
+		ApprovalWebhookTimeout:            defaultApprovalWebhookTimeout,
+		ApprovalWebhookPollInterval:       defaultApprovalWebhookPollInterval,
 	}
 }
 
@@ -341,6 +398,25 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"from Certificate to CertificateRequest and Order, as well as from CertificateSigningRequest to Order, by passing a list of annotation key prefixes."+
 		"A prefix starting with a dash(-) specifies an annotation that shouldn't be copied. Example: '*,-kubectl.kuberenetes.io/'- all annotations"+
 		"will be copied apart from the ones where the key is prefixed with 'kubectl.kubernetes.io/'.")
+	fs.DurationVar(&s.IssuerCARotationReissueWindow, "issuer-ca-rotation-reissue-window", defaultIssuerCARotationReissueWindow, ""+
+		"The duration over which certificates are re-issued after the CA of their CA issuer has changed. "+
+		"Each certificate is re-issued at a point in this window derived from its name. "+
+		"Only used if the ReissueOnIssuerCARotation feature gate is enabled.")
+	fs.Float32Var(&s.IssuerCARotationReissueQPS, "issuer-ca-rotation-reissue-qps", defaultIssuerCARotationReissueQPS, ""+
+		"The maximum number of certificates per second that are re-issued because the CA of their CA issuer has changed. "+
+		"Only used if the ReissueOnIssuerCARotation feature gate is enabled.")
+	fs.StringVar(&s.ApprovalWebhookURL, "approval-webhook-url", "", ""+
+		"The URL of a webhook that CertificateRequests are sent to for approval by the "+
+		"certificaterequests-approver controller. A CertificateRequestReview is POSTed to "+
+		"the webhook for each CertificateRequest awaiting approval. No webhook is used if not set.")
+	fs.StringVar(&s.ApprovalWebhookCAFile, "approval-webhook-ca-file", "", ""+
+		"Path to a PEM encoded bundle of CA certificates used to verify the serving certificate "+
+		"of the approval webhook. The system trust store is used if not set.")
+	fs.DurationVar(&s.ApprovalWebhookTimeout, "approval-webhook-timeout", defaultApprovalWebhookTimeout, ""+
+		"The timeout of requests to the approval webhook.")
+	fs.DurationVar(&s.ApprovalWebhookPollInterval, "approval-webhook-poll-interval", defaultApprovalWebhookPollInterval, ""+
+		"The interval at which CertificateRequests are sent to the approval webhook again "+
+		"while the webhook responds that their review is pending.")
 
 	fs.IntVar(&s.MaxConcurrentChallenges, "max-concurrent-challenges", defaultMaxConcurrentChallenges, ""+
 		"The maximum number of challenges that can be scheduled as 'processing' at once.")
@@ -352,6 +428,14 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"The host and port that the metrics endpoint should listen on.")
 	fs.BoolVar(&s.EnablePprof, "enable-profiling", false, ""+
 		"Enable profiling for controller.")
+	fs.StringVar(&s.CRLServerListenAddress, "crl-server-listen-address", "", ""+
+		"The host and port that CRLs published by CA issuers should be served on, "+
+		"at /issuers/<namespace>/<name>.crl and /clusterissuers/<name>.crl. "+
+		"The CRL server is disabled if not set.")
+	fs.StringVar(&s.OCSPServerListenAddress, "ocsp-server-listen-address", "", ""+
+		"The host and port that the OCSP responder for CA issuers should listen on. "+
+		"Requests are answered at /issuers/<namespace>/<name> and /clusterissuers/<name>. "+
+		"The OCSP responder is disabled if not set.")
 }
 
 func (o *ControllerOptions) Validate() error {
@@ -374,6 +458,32 @@ func (o *ControllerOptions) Validate() error {
 		return fmt.Errorf("invalid value for kube-api-burst: %v must be higher or equal to kube-api-qps: %v", o.KubernetesAPIQPS, o.KubernetesAPIQPS)
 	}
 
+	if o.IssuerCARotationReissueWindow < 0 {
+		return fmt.Errorf("invalid value for issuer-ca-rotation-reissue-window: %v must not be negative", o.IssuerCARotationReissueWindow)
+	}
+
+	if o.IssuerCARotationReissueQPS <= 0 {
+		return fmt.Errorf("invalid value for issuer-ca-rotation-reissue-qps: %v must be higher than 0", o.IssuerCARotationReissueQPS)
+	}
+
+	if o.ApprovalWebhookURL != "" {
+		u, err := url.Parse(o.ApprovalWebhookURL)
+		if err != nil {
+			return fmt.Errorf("invalid value for approval-webhook-url: %v", err)
+		}
+		if u.Scheme != "https" && u.Scheme != "http" {
+			return fmt.Errorf("invalid value for approval-webhook-url: %q must be an http or https URL", o.ApprovalWebhookURL)
+		}
+	}
+
+	if o.ApprovalWebhookTimeout <= 0 {
+		return fmt.Errorf("invalid value for approval-webhook-timeout: %v must be higher than 0", o.ApprovalWebhookTimeout)
+	}
+
+	if o.ApprovalWebhookPollInterval <= 0 {
+		return fmt.Errorf("invalid value for approval-webhook-poll-interval: %v must be higher than 0", o.ApprovalWebhookPollInterval)
+	}
+
 	for _, server := range o.DNS01RecursiveNameservers {
 		// ensure all servers have a port number
 		_, _, err := net.SplitHostPort(server)
@@ -429,5 +539,10 @@ func (o *ControllerOptions) EnabledControllers() sets.String {
 		enabled = enabled.Insert(shimgatewaycontroller.ControllerName)
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ACMERenewalInfo) {
+		logf.Log.Info("enabling ACME renewal information checks for certificates")
+		enabled = enabled.Insert(renewalinfo.ControllerName)
+	}
+
 	return enabled
 }
@@ -79,6 +79,7 @@ helm upgrade \
     --set "controller.service.clusterIP=${SERVICE_IP_PREFIX}.15"\
     --set controller.service.type=ClusterIP \
     --set controller.config.no-tls-redirect-locations="" \
+    --set controller.extraArgs.enable-ssl-passthrough=true \
     --set admissionWebhooks.enabled=false \
     --set controller.admissionWebhooks.enabled=false \
     "$RELEASE_NAME" \
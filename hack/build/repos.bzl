@@ -4444,6 +4444,15 @@ def go_repositories():
         version = "v1.1.2",
     )
 
+    go_repository(
+        name = "org_mozilla_go_pkcs7",
+        build_file_generation = "on",
+        build_file_proto_mode = "disable",
+        importpath = "go.mozilla.org/pkcs7",
+        sum = "h1:yM4/HS9dYv7ri2biPtxt8ikvB37a980dg69/pKmS+eI=",
+        version = "v0.9.0",
+    )
+
     go_repository(
         name = "org_uber_go_atomic",
         build_file_generation = "on",
@@ -45,6 +45,7 @@ deepcopy_inputs=(
   pkg/apis/acme/v1beta1 \
   pkg/apis/acme/v1 \
   pkg/internal/apis/acme \
+  pkg/apis/experimental/v1alpha1 \
   pkg/apis/meta/v1 \
   pkg/internal/apis/meta \
   pkg/webhook/handlers/testdata/apis/testgroup/v2 \
@@ -65,6 +66,7 @@ client_inputs=(
   pkg/apis/acme/v1alpha3 \
   pkg/apis/acme/v1beta1 \
   pkg/apis/acme/v1 \
+  pkg/apis/experimental/v1alpha1 \
 )
 
 # Generate defaulting functions to be used by the mutating webhook
@@ -39,12 +39,14 @@ var _ NewClientFunc = NewClient
 
 // NewClient is an implementation of NewClientFunc that returns a real ACME client.
 func NewClient(client *http.Client, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) acmecl.Interface {
-	return &acmeapi.Client{
-		Key:          privateKey,
-		HTTPClient:   client,
-		DirectoryURL: config.Server,
-		UserAgent:    util.CertManagerUserAgent,
-		RetryBackoff: acmeutil.RetryBackoff,
+	return &acmecl.Client{
+		Client: &acmeapi.Client{
+			Key:          privateKey,
+			HTTPClient:   client,
+			DirectoryURL: config.Server,
+			UserAgent:    util.CertManagerUserAgent,
+			RetryBackoff: acmeutil.RetryBackoff,
+		},
 	}
 }
 
@@ -23,6 +23,7 @@ import (
 	"sync"
 
 	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	"github.com/jetstack/cert-manager/pkg/acme/client/middleware"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 )
 
@@ -34,8 +35,8 @@ var ErrNotFound = errors.New("ACME client for issuer not initialised/available")
 // This is used as a shared cache of ACME clients across various controllers.
 type Registry interface {
 	// AddClient will ensure the registry has a stored ACME client for the Issuer
-	// object with the given UID, configuration and private key.
-	AddClient(client *http.Client, uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey)
+	// object with the given UID, account URI, configuration and private key.
+	AddClient(client *http.Client, uid, accountURI string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey)
 
 	// RemoveClient will remove a registered client using the UID of the Issuer
 	// resource that constructed it.
@@ -59,9 +60,12 @@ type Getter interface {
 }
 
 // NewDefaultRegistry returns a new default instantiation of a client registry.
-func NewDefaultRegistry() Registry {
+// The requests made by the registered clients honour the rate limits signalled
+// by ACME servers, which are tracked in the given RateLimits.
+func NewDefaultRegistry(rateLimits *acmecl.RateLimits) Registry {
 	return &registry{
-		clients: make(map[string]clientWithMeta),
+		clients:    make(map[string]clientWithMeta),
+		rateLimits: rateLimits,
 	}
 }
 
@@ -71,6 +75,10 @@ type registry struct {
 
 	// a map of an issuer's 'uid' to an ACME client with metadata
 	clients map[string]clientWithMeta
+
+	// rateLimits is shared by all registered clients, so that clients of
+	// issuers using the same ACME account are held back together
+	rateLimits *acmecl.RateLimits
 }
 
 // stableOptions contains data about an ACME client that can be used to compare
@@ -80,6 +88,7 @@ type stableOptions struct {
 	serverURL     string
 	skipVerifyTLS bool
 	issuerUID     string
+	accountURI    string
 	publicKey     string
 	exponent      int
 }
@@ -88,13 +97,14 @@ func (c stableOptions) equalTo(c2 stableOptions) bool {
 	return c == c2
 }
 
-func newStableOptions(uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) stableOptions {
+func newStableOptions(uid, accountURI string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) stableOptions {
 	// Encoding a big.Int cannot fail
 	publicNBytes, _ := privateKey.PublicKey.N.GobEncode()
 	return stableOptions{
 		serverURL:     config.Server,
 		skipVerifyTLS: config.SkipTLSVerify,
 		issuerUID:     uid,
+		accountURI:    accountURI,
 		publicKey:     string(publicNBytes),
 		exponent:      privateKey.PublicKey.E,
 	}
@@ -109,10 +119,10 @@ type clientWithMeta struct {
 }
 
 // AddClient will ensure the registry has a stored ACME client for the Issuer
-// object with the given UID, configuration and private key.
-func (r *registry) AddClient(client *http.Client, uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) {
+// object with the given UID, account URI, configuration and private key.
+func (r *registry) AddClient(client *http.Client, uid, accountURI string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) {
 	// ensure the client is up to date for the current configuration
-	r.ensureClient(client, uid, config, privateKey)
+	r.ensureClient(client, uid, accountURI, config, privateKey)
 }
 
 // ensureClient will ensure an ACME client with the given parameters is registered.
@@ -120,21 +130,23 @@ func (r *registry) AddClient(client *http.Client, uid string, config cmacme.ACME
 // the client will NOT be mutated or replaced, allowing this method to be called
 // even if the client does not need replacing/updating without causing issues for
 // consumers of the registry.
-func (r *registry) ensureClient(client *http.Client, uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) {
+func (r *registry) ensureClient(client *http.Client, uid, accountURI string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) {
 	// acquire a read-write lock even if we hit the fast-path where the client
 	// is already present to avoid having to RLock, RUnlock and Lock again,
 	// which could itself cause a race
 	r.lock.Lock()
 	defer r.lock.Unlock()
-	newOpts := newStableOptions(uid, config, privateKey)
+	newOpts := newStableOptions(uid, accountURI, config, privateKey)
 	// fast-path if there is nothing to do
 	if meta, ok := r.clients[uid]; ok && meta.equalTo(newOpts) {
 		return
 	}
 	// create a new client if one is not registered or if the
-	// 'metadata' does not match
+	// 'metadata' does not match.
+	// Rate limits are tracked by account URI, which unlike the account key
+	// does not change when the key is rolled over.
 	r.clients[uid] = clientWithMeta{
-		Interface:     NewClient(client, config, privateKey),
+		Interface:     middleware.NewRateLimiter(NewClient(client, config, privateKey), r.rateLimits, config.Server, accountURI),
 		stableOptions: newOpts,
 	}
 }
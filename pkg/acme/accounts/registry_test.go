@@ -17,22 +17,27 @@ limitations under the License.
 package accounts
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"k8s.io/utils/clock"
+
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
 func TestRegistry_AddClient(t *testing.T) {
-	r := NewDefaultRegistry()
+	r := NewDefaultRegistry(acmecl.NewRateLimits(clock.RealClock{}, nil))
 	pk, err := pki.GenerateRSAPrivateKey(2048)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	// Register a new client
-	r.AddClient(http.DefaultClient, "abc", cmacme.ACMEIssuer{}, pk)
+	r.AddClient(http.DefaultClient, "abc", "", cmacme.ACMEIssuer{}, pk)
 
 	c, err := r.GetClient("abc")
 	if err != nil {
@@ -44,14 +49,14 @@ func TestRegistry_AddClient(t *testing.T) {
 }
 
 func TestRegistry_RemoveClient(t *testing.T) {
-	r := NewDefaultRegistry()
+	r := NewDefaultRegistry(acmecl.NewRateLimits(clock.RealClock{}, nil))
 	pk, err := pki.GenerateRSAPrivateKey(2048)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	// Register a new client
-	r.AddClient(http.DefaultClient, "abc", cmacme.ACMEIssuer{}, pk)
+	r.AddClient(http.DefaultClient, "abc", "", cmacme.ACMEIssuer{}, pk)
 
 	c, err := r.GetClient("abc")
 	if err != nil {
@@ -72,7 +77,7 @@ func TestRegistry_RemoveClient(t *testing.T) {
 }
 
 func TestRegistry_RemoveClient_EmptyRegistry(t *testing.T) {
-	r := NewDefaultRegistry()
+	r := NewDefaultRegistry(acmecl.NewRateLimits(clock.RealClock{}, nil))
 	r.RemoveClient("abc")
 	c, err := r.GetClient("abc")
 	if err != ErrNotFound {
@@ -84,21 +89,21 @@ func TestRegistry_RemoveClient_EmptyRegistry(t *testing.T) {
 }
 
 func TestRegistry_ListClients(t *testing.T) {
-	r := NewDefaultRegistry()
+	r := NewDefaultRegistry(acmecl.NewRateLimits(clock.RealClock{}, nil))
 	pk, err := pki.GenerateRSAPrivateKey(2048)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	// Register a new client
-	r.AddClient(http.DefaultClient, "abc", cmacme.ACMEIssuer{}, pk)
+	r.AddClient(http.DefaultClient, "abc", "", cmacme.ACMEIssuer{}, pk)
 	l := r.ListClients()
 	if len(l) != 1 {
 		t.Errorf("expected ListClients to have 1 item but it has %d", len(l))
 	}
 
 	// Register a second client
-	r.AddClient(http.DefaultClient, "abc2", cmacme.ACMEIssuer{}, pk)
+	r.AddClient(http.DefaultClient, "abc2", "", cmacme.ACMEIssuer{}, pk)
 	l = r.ListClients()
 	if len(l) != 2 {
 		t.Errorf("expected ListClients to have 2 items but it has %d", len(l))
@@ -106,14 +111,14 @@ func TestRegistry_ListClients(t *testing.T) {
 
 	// Register a third client with the same options as the second, meaning
 	// it should be de-duplicated
-	r.AddClient(http.DefaultClient, "abc2", cmacme.ACMEIssuer{}, pk)
+	r.AddClient(http.DefaultClient, "abc2", "", cmacme.ACMEIssuer{}, pk)
 	l = r.ListClients()
 	if len(l) != 2 {
 		t.Errorf("expected ListClients to have 2 items but it has %d", len(l))
 	}
 
 	// Update the second client with a new server URL
-	r.AddClient(http.DefaultClient, "abc2", cmacme.ACMEIssuer{Server: "abc.com"}, pk)
+	r.AddClient(http.DefaultClient, "abc2", "", cmacme.ACMEIssuer{Server: "abc.com"}, pk)
 	l = r.ListClients()
 	if len(l) != 2 {
 		t.Errorf("expected ListClients to have 2 items but it has %d", len(l))
@@ -121,7 +126,7 @@ func TestRegistry_ListClients(t *testing.T) {
 }
 
 func TestRegistry_AddClient_UpdatesExistingWhenPrivateKeyChanges(t *testing.T) {
-	r := NewDefaultRegistry()
+	r := NewDefaultRegistry(acmecl.NewRateLimits(clock.RealClock{}, nil))
 	pk, err := pki.GenerateRSAPrivateKey(2048)
 	if err != nil {
 		t.Fatal(err)
@@ -132,16 +137,75 @@ func TestRegistry_AddClient_UpdatesExistingWhenPrivateKeyChanges(t *testing.T) {
 	}
 
 	// Register a new client
-	r.AddClient(http.DefaultClient, "abc", cmacme.ACMEIssuer{}, pk)
+	r.AddClient(http.DefaultClient, "abc", "", cmacme.ACMEIssuer{}, pk)
 	l := r.ListClients()
 	if len(l) != 1 {
 		t.Errorf("expected ListClients to have 1 item but it has %d", len(l))
 	}
 
 	// Update the client with a new private key
-	r.AddClient(http.DefaultClient, "abc", cmacme.ACMEIssuer{}, pk2)
+	r.AddClient(http.DefaultClient, "abc", "", cmacme.ACMEIssuer{}, pk2)
 	l = r.ListClients()
 	if len(l) != 1 {
 		t.Errorf("expected ListClients to have 1 item but it has %d", len(l))
 	}
 }
+
+func TestRegistry_ClientsShareAccountRateLimits(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"type":"urn:ietf:params:acme:error:rateLimited","detail":"too many new orders recently"}`))
+	}))
+	defer srv.Close()
+
+	r := NewDefaultRegistry(acmecl.NewRateLimits(clock.RealClock{}, nil))
+	pk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk2, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Register two issuers using the same account, one of which has not
+	// observed the rollover of the account key yet, and one using another
+	// account
+	r.AddClient(srv.Client(), "abc", srv.URL+"/acct/1", cmacme.ACMEIssuer{Server: srv.URL}, pk)
+	r.AddClient(srv.Client(), "abc2", srv.URL+"/acct/1", cmacme.ACMEIssuer{Server: srv.URL}, pk2)
+	r.AddClient(srv.Client(), "abc3", srv.URL+"/acct/2", cmacme.ACMEIssuer{Server: srv.URL}, pk)
+
+	discover := func(uid string) error {
+		c, err := r.GetClient(uid)
+		if err != nil {
+			t.Fatalf("unexpected error getting client: %v", err)
+		}
+		_, err = c.Discover(context.Background())
+		return err
+	}
+
+	if _, ok := acmecl.RateLimitedUntil(discover("abc")); !ok {
+		t.Errorf("expected the first request to be rate limited")
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request to be made to the ACME server, got %d", requests)
+	}
+
+	// The rate limit of the account is shared by all of its clients
+	if _, ok := acmecl.RateLimitedUntil(discover("abc2")); !ok {
+		t.Errorf("expected the request of the second issuer to be held back")
+	}
+	if requests != 1 {
+		t.Errorf("expected the request of the second issuer not to be sent, got %d requests", requests)
+	}
+
+	// Other accounts are not held back
+	discover("abc3")
+	if requests != 2 {
+		t.Errorf("expected the request of the other account to be sent, got %d requests", requests)
+	}
+}
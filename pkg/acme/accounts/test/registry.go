@@ -30,14 +30,14 @@ var _ accounts.Registry = &FakeRegistry{}
 
 // FakeRegistry implements the accounts.Registry interface using stub functions
 type FakeRegistry struct {
-	AddClientFunc    func(uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey)
+	AddClientFunc    func(uid, accountURI string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey)
 	RemoveClientFunc func(uid string)
 	GetClientFunc    func(uid string) (acmecl.Interface, error)
 	ListClientsFunc  func() map[string]acmecl.Interface
 }
 
-func (f *FakeRegistry) AddClient(client *http.Client, uid string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) {
-	f.AddClientFunc(uid, config, privateKey)
+func (f *FakeRegistry) AddClient(client *http.Client, uid, accountURI string, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) {
+	f.AddClientFunc(uid, accountURI, config, privateKey)
 }
 
 func (f *FakeRegistry) RemoveClient(uid string) {
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/jetstack/cert-manager/pkg/util"
+)
+
+// maxResponseSize is the maximum size of a response body that will be read
+// from the ACME server.
+const maxResponseSize = 1024 * 1024
+
+// Client is the implementation of Interface used to talk to ACME servers. It
+// adds the requests that are not implemented by acme.Client to it.
+type Client struct {
+	*acme.Client
+
+	// dirMu guards dir, the cached directory of the ACME server
+	dirMu sync.Mutex
+	dir   *directory
+}
+
+// directory holds the endpoints of the ACME server's directory that are not
+// included in acme.Directory.
+type directory struct {
+	RenewalInfo string `json:"renewalInfo"`
+}
+
+// discover returns the directory of the ACME server. Like the directory of
+// acme.Client, it is only fetched once and cached for the lifetime of the
+// Client.
+func (c *Client) discover(ctx context.Context) (*directory, error) {
+	c.dirMu.Lock()
+	defer c.dirMu.Unlock()
+
+	if c.dir != nil {
+		return c.dir, nil
+	}
+
+	dir := &directory{}
+	if _, err := getJSON(ctx, c.httpClient(), c.directoryURL(), dir); err != nil {
+		return nil, err
+	}
+	c.dir = dir
+	return dir, nil
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) directoryURL() string {
+	if c.DirectoryURL != "" {
+		return c.DirectoryURL
+	}
+	return acme.LetsEncryptURL
+}
+
+// getJSON decodes the body of the response to a GET request to url into v,
+// and returns the headers of the response. Responses other than 200 OK are
+// returned as *acme.Error.
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", util.CertManagerUserAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v); err != nil {
+		return nil, err
+	}
+	return resp.Header, nil
+}
+
+// responseError returns an *acme.Error built from the problem document in
+// the body of the given response.
+func responseError(resp *http.Response) error {
+	b, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	var problem struct {
+		Type   string `json:"type"`
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal(b, &problem); err != nil {
+		problem.Detail = string(b)
+	}
+	return &acme.Error{
+		StatusCode:  resp.StatusCode,
+		ProblemType: problem.Type,
+		Detail:      problem.Detail,
+		Header:      resp.Header,
+	}
+}
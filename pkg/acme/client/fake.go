@@ -18,6 +18,8 @@ package client
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
 	"fmt"
 
 	"golang.org/x/crypto/acme"
@@ -44,6 +46,10 @@ type FakeACME struct {
 	FakeDNS01ChallengeRecord    func(token string) (string, error)
 	FakeDiscover                func(ctx context.Context) (acme.Directory, error)
 	FakeUpdateReg               func(ctx context.Context, a *acme.Account) (*acme.Account, error)
+	FakeRevokeCert              func(ctx context.Context, key crypto.Signer, cert []byte, reason acme.CRLReasonCode) error
+	FakeDeactivateReg           func(ctx context.Context) error
+	FakeAccountKeyRollover      func(ctx context.Context, newKey crypto.Signer) error
+	FakeRenewalInfo             func(ctx context.Context, cert *x509.Certificate) (*RenewalInfo, error)
 }
 
 var _ Interface = &FakeACME{}
@@ -162,3 +168,31 @@ func (f *FakeACME) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Accoun
 	}
 	return nil, fmt.Errorf("UpdateReg not implemented")
 }
+
+func (f *FakeACME) RevokeCert(ctx context.Context, key crypto.Signer, cert []byte, reason acme.CRLReasonCode) error {
+	if f.FakeRevokeCert != nil {
+		return f.FakeRevokeCert(ctx, key, cert, reason)
+	}
+	return fmt.Errorf("RevokeCert not implemented")
+}
+
+func (f *FakeACME) DeactivateReg(ctx context.Context) error {
+	if f.FakeDeactivateReg != nil {
+		return f.FakeDeactivateReg(ctx)
+	}
+	return fmt.Errorf("DeactivateReg not implemented")
+}
+
+func (f *FakeACME) AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error {
+	if f.FakeAccountKeyRollover != nil {
+		return f.FakeAccountKeyRollover(ctx, newKey)
+	}
+	return fmt.Errorf("AccountKeyRollover not implemented")
+}
+
+func (f *FakeACME) RenewalInfo(ctx context.Context, cert *x509.Certificate) (*RenewalInfo, error) {
+	if f.FakeRenewalInfo != nil {
+		return f.FakeRenewalInfo(ctx, cert)
+	}
+	return nil, fmt.Errorf("RenewalInfo not implemented")
+}
@@ -18,6 +18,8 @@ package client
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
 
 	acmeutil "github.com/jetstack/cert-manager/pkg/acme/util"
 
@@ -46,8 +48,14 @@ type Interface interface {
 	DNS01ChallengeRecord(token string) (string, error)
 	Discover(ctx context.Context) (acme.Directory, error)
 	UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account, error)
+	RevokeCert(ctx context.Context, key crypto.Signer, cert []byte, reason acme.CRLReasonCode) error
+	DeactivateReg(ctx context.Context) error
+	AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error
+	RenewalInfo(ctx context.Context, cert *x509.Certificate) (*RenewalInfo, error)
 }
 
-var _ Interface = &acme.Client{
-	RetryBackoff: acmeutil.RetryBackoff,
+var _ Interface = &Client{
+	Client: &acme.Client{
+		RetryBackoff: acmeutil.RetryBackoff,
+	},
 }
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/jetstack/cert-manager/pkg/util"
+)
+
+// AccountKeyRollover changes the key of the ACME account identified by c.Key
+// to newKey, as described in RFC 8555 section 7.3.5, and then sets c.Key to
+// newKey. Errors returned by the ACME server are returned as *acme.Error.
+// Only RSA keys are supported.
+//
+// The version of golang.org/x/crypto/acme currently in use does not
+// implement acme.Client.AccountKeyRollover, which this method is to be
+// replaced by. Until then, only the signing of the key change request is
+// implemented here.
+func (c *Client) AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error {
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return err
+	}
+	if dir.NonceURL == "" || dir.KeyChangeURL == "" {
+		return errors.New("ACME server does not support account key rollover")
+	}
+
+	acct, err := c.GetReg(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	oldJWK, err := jwkEncode(c.Key.Public())
+	if err != nil {
+		return err
+	}
+	newJWK, err := jwkEncode(newKey.Public())
+	if err != nil {
+		return err
+	}
+
+	// The inner JWS is signed with the new key and proves its possession.
+	innerPayload, err := json.Marshal(struct {
+		Account string          `json:"account"`
+		OldKey  json.RawMessage `json:"oldKey"`
+	}{
+		Account: acct.URI,
+		OldKey:  json.RawMessage(oldJWK),
+	})
+	if err != nil {
+		return err
+	}
+	inner, err := jwsEncode(newKey, fmt.Sprintf(`{"alg":"RS256","jwk":%s,"url":%q}`, newJWK, dir.KeyChangeURL), innerPayload)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := fetchNonce(ctx, c.httpClient(), dir.NonceURL)
+	if err != nil {
+		return err
+	}
+
+	// The outer JWS is signed with the current key of the account. The
+	// request is retried once if the server rejects the nonce, as required
+	// by RFC 8555 section 6.5.
+	for attempt := 0; ; attempt++ {
+		outer, err := jwsEncode(c.Key, fmt.Sprintf(`{"alg":"RS256","kid":%q,"nonce":%q,"url":%q}`, acct.URI, nonce, dir.KeyChangeURL), inner)
+		if err != nil {
+			return err
+		}
+
+		err = postJWS(ctx, c.httpClient(), dir.KeyChangeURL, outer)
+		var acmeErr *acme.Error
+		if attempt == 0 && errors.As(err, &acmeErr) && acmeErr.ProblemType == "urn:ietf:params:acme:error:badNonce" {
+			if nonce = acmeErr.Header.Get("Replay-Nonce"); nonce != "" {
+				continue
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		c.Key = newKey
+		return nil
+	}
+}
+
+// jwkEncode returns the JSON Web Key of the given public key with its members
+// in lexicographic order, as required for JWK thumbprints.
+func jwkEncode(pub crypto.PublicKey) (string, error) {
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("unsupported account key type %T, only RSA keys are supported", pub)
+	}
+	return fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`,
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaPub.E)).Bytes()),
+		base64.RawURLEncoding.EncodeToString(rsaPub.N.Bytes()),
+	), nil
+}
+
+// jwsEncode returns the flattened JSON serialization of a JSON Web Signature
+// of payload with the given protected header, signed by key using RS256.
+func jwsEncode(key crypto.Signer, protected string, payload []byte) ([]byte, error) {
+	if _, ok := key.Public().(*rsa.PublicKey); !ok {
+		return nil, fmt.Errorf("unsupported account key type %T, only RSA keys are supported", key.Public())
+	}
+
+	phead := base64.RawURLEncoding.EncodeToString([]byte(protected))
+	payloadEnc := base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(phead + "." + payloadEnc))
+	sig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+		Signature string `json:"signature"`
+	}{
+		Protected: phead,
+		Payload:   payloadEnc,
+		Signature: base64.RawURLEncoding.EncodeToString(sig),
+	})
+}
+
+func fetchNonce(ctx context.Context, client *http.Client, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", util.CertManagerUserAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	nonce := resp.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		return "", errors.New("ACME server did not return a nonce")
+	}
+	return nonce, nil
+}
+
+func postJWS(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/jose+json")
+	req.Header.Set("User-Agent", util.CertManagerUserAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
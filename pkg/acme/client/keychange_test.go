@@ -0,0 +1,206 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/crypto/acme"
+)
+
+type jws struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// verifyJWS verifies the signature of the given JWS with key, and decodes its
+// protected header and payload.
+func verifyJWS(t *testing.T, raw []byte, key *rsa.PublicKey, header interface{}) []byte {
+	var sig jws
+	if err := json.Unmarshal(raw, &sig); err != nil {
+		t.Fatalf("invalid JWS: %v", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		t.Fatalf("invalid JWS signature encoding: %v", err)
+	}
+	digest := sha256.Sum256([]byte(sig.Protected + "." + sig.Payload))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("invalid JWS signature: %v", err)
+	}
+	protected, err := base64.RawURLEncoding.DecodeString(sig.Protected)
+	if err != nil {
+		t.Fatalf("invalid JWS header encoding: %v", err)
+	}
+	if err := json.Unmarshal(protected, header); err != nil {
+		t.Fatalf("invalid JWS header: %v", err)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(sig.Payload)
+	if err != nil {
+		t.Fatalf("invalid JWS payload encoding: %v", err)
+	}
+	return payload
+}
+
+func TestAccountKeyRollover(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldJWK, err := jwkEncode(oldKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	const accountURL = "https://acme.example.com/acct/1"
+
+	tests := map[string]struct {
+		// badNonces is the number of requests whose nonce is rejected
+		badNonces int
+		// keyChangeStatus is returned on a valid key change request
+		keyChangeStatus int
+		wantsErr        bool
+		wantsProblem    string
+	}{
+		"changes the account key": {
+			keyChangeStatus: http.StatusOK,
+		},
+		"retries once if the nonce is rejected": {
+			badNonces:       1,
+			keyChangeStatus: http.StatusOK,
+		},
+		"does not retry more than once if the nonce is rejected": {
+			badNonces:       2,
+			keyChangeStatus: http.StatusOK,
+			wantsErr:        true,
+			wantsProblem:    "urn:ietf:params:acme:error:badNonce",
+		},
+		"returns the problem if the server rejects the key change": {
+			keyChangeStatus: http.StatusConflict,
+			wantsErr:        true,
+			wantsProblem:    "urn:ietf:params:acme:error:malformed",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			nonces := 0
+			badNonces := test.badNonces
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nonces++
+				w.Header().Set("Replay-Nonce", fmt.Sprintf("nonce-%d", nonces))
+				switch r.URL.Path {
+				case "/directory":
+					fmt.Fprintf(w, `{"newNonce":"%[1]s/new-nonce","newAccount":"%[1]s/new-account","newOrder":"%[1]s/new-order","keyChange":"%[1]s/key-change"}`, server.URL)
+				case "/new-nonce":
+				case "/new-account":
+					w.Header().Set("Location", accountURL)
+					fmt.Fprint(w, `{"status":"valid"}`)
+				case "/key-change":
+					if ct := r.Header.Get("Content-Type"); ct != "application/jose+json" {
+						t.Errorf("unexpected content type %q", ct)
+					}
+					body, err := ioutil.ReadAll(r.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					var outerHeader struct {
+						Alg, Kid, Nonce, URL string
+					}
+					innerRaw := verifyJWS(t, body, &oldKey.PublicKey, &outerHeader)
+					if outerHeader.Kid != accountURL || outerHeader.URL != server.URL+"/key-change" || outerHeader.Alg != "RS256" {
+						t.Errorf("unexpected outer JWS header %+v", outerHeader)
+					}
+
+					var innerHeader struct {
+						Alg string
+						JWK json.RawMessage
+						URL string
+					}
+					innerPayload := verifyJWS(t, innerRaw, &newKey.PublicKey, &innerHeader)
+					if innerHeader.URL != outerHeader.URL {
+						t.Errorf("inner JWS URL %q does not match outer JWS URL %q", innerHeader.URL, outerHeader.URL)
+					}
+					var keyChange struct {
+						Account string
+						OldKey  json.RawMessage
+					}
+					if err := json.Unmarshal(innerPayload, &keyChange); err != nil {
+						t.Fatal(err)
+					}
+					if keyChange.Account != accountURL || string(keyChange.OldKey) != oldJWK {
+						t.Errorf("unexpected key change payload %s", innerPayload)
+					}
+
+					if badNonces > 0 {
+						badNonces--
+						w.WriteHeader(http.StatusBadRequest)
+						fmt.Fprint(w, `{"type":"urn:ietf:params:acme:error:badNonce","detail":"bad nonce"}`)
+						return
+					}
+					w.WriteHeader(test.keyChangeStatus)
+					if test.keyChangeStatus != http.StatusOK {
+						fmt.Fprint(w, `{"type":"urn:ietf:params:acme:error:malformed","detail":"key in use"}`)
+					}
+				default:
+					t.Errorf("unexpected request path %q", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			cl := &Client{Client: &acme.Client{
+				Key:          oldKey,
+				HTTPClient:   server.Client(),
+				DirectoryURL: server.URL + "/directory",
+			}}
+			err := cl.AccountKeyRollover(context.Background(), newKey)
+			if (err != nil) != test.wantsErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.wantsErr, err)
+			}
+			expectedKey := crypto.Signer(newKey)
+			if test.wantsErr {
+				expectedKey = oldKey
+			}
+			if cl.Key != expectedKey {
+				t.Errorf("unexpected client key after the rollover, exp new key=%t", !test.wantsErr)
+			}
+			var acmeErr *acme.Error
+			if test.wantsProblem != "" && (!errors.As(err, &acmeErr) || acmeErr.ProblemType != test.wantsProblem) {
+				t.Errorf("unexpected error, exp problem=%s got=%v", test.wantsProblem, err)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package middleware
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -181,3 +183,39 @@ func (l *Logger) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account,
 
 	return l.baseCl.UpdateReg(ctx, a)
 }
+
+func (l *Logger) RevokeCert(ctx context.Context, key crypto.Signer, cert []byte, reason acme.CRLReasonCode) error {
+	l.log.V(logf.TraceLevel).Info("Calling RevokeCert")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return l.baseCl.RevokeCert(ctx, key, cert, reason)
+}
+
+func (l *Logger) DeactivateReg(ctx context.Context) error {
+	l.log.V(logf.TraceLevel).Info("Calling DeactivateReg")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return l.baseCl.DeactivateReg(ctx)
+}
+
+func (l *Logger) AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error {
+	l.log.V(logf.TraceLevel).Info("Calling AccountKeyRollover")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return l.baseCl.AccountKeyRollover(ctx, newKey)
+}
+
+func (l *Logger) RenewalInfo(ctx context.Context, cert *x509.Certificate) (*client.RenewalInfo, error) {
+	l.log.V(logf.TraceLevel).Info("Calling RenewalInfo")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return l.baseCl.RenewalInfo(ctx, cert)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/jetstack/cert-manager/pkg/acme/client"
+)
+
+// NewRateLimiter returns an ACME client that holds back requests while the
+// ACME server is rate limiting the given account, or all requests made to the
+// server, and records any rate limits signalled in responses to the requests
+// it makes in the given RateLimits. The account is identified by its URI.
+func NewRateLimiter(baseCl client.Interface, limits *client.RateLimits, server, account string) client.Interface {
+	return &RateLimiter{
+		baseCl:  baseCl,
+		limits:  limits,
+		server:  server,
+		account: account,
+	}
+}
+
+// RateLimiter is a middleware for an ACME client that honours the rate limits
+// signalled by the ACME server.
+// Requests that are held back, or that are rejected by the ACME server due to
+// rate limiting, return a *client.RateLimitedError.
+type RateLimiter struct {
+	baseCl  client.Interface
+	limits  *client.RateLimits
+	server  string
+	account string
+}
+
+var _ client.Interface = &RateLimiter{}
+
+func (r *RateLimiter) AuthorizeOrder(ctx context.Context, id []acme.AuthzID, opt ...acme.OrderOption) (*acme.Order, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	o, err := r.baseCl.AuthorizeOrder(ctx, id, opt...)
+	return o, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) GetOrder(ctx context.Context, url string) (*acme.Order, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	o, err := r.baseCl.GetOrder(ctx, url)
+	return o, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) FetchCert(ctx context.Context, url string, bundle bool) ([][]byte, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	der, err := r.baseCl.FetchCert(ctx, url, bundle)
+	return der, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) FetchCertAlternatives(ctx context.Context, url string, bundle bool) ([][][]byte, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	der, err := r.baseCl.FetchCertAlternatives(ctx, url, bundle)
+	return der, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) WaitOrder(ctx context.Context, url string) (*acme.Order, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	o, err := r.baseCl.WaitOrder(ctx, url)
+	return o, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) CreateOrderCert(ctx context.Context, finalizeURL string, csr []byte, bundle bool) (der [][]byte, certURL string, err error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, "", err
+	}
+	der, certURL, err = r.baseCl.CreateOrderCert(ctx, finalizeURL, csr, bundle)
+	return der, certURL, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) Accept(ctx context.Context, chal *acme.Challenge) (*acme.Challenge, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	c, err := r.baseCl.Accept(ctx, chal)
+	return c, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) GetChallenge(ctx context.Context, url string) (*acme.Challenge, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	c, err := r.baseCl.GetChallenge(ctx, url)
+	return c, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) GetAuthorization(ctx context.Context, url string) (*acme.Authorization, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	a, err := r.baseCl.GetAuthorization(ctx, url)
+	return a, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) WaitAuthorization(ctx context.Context, url string) (*acme.Authorization, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	a, err := r.baseCl.WaitAuthorization(ctx, url)
+	return a, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) Register(ctx context.Context, a *acme.Account, prompt func(tosURL string) bool) (*acme.Account, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	acc, err := r.baseCl.Register(ctx, a, prompt)
+	return acc, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) GetReg(ctx context.Context, url string) (*acme.Account, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	acc, err := r.baseCl.GetReg(ctx, url)
+	return acc, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) HTTP01ChallengeResponse(token string) (string, error) {
+	return r.baseCl.HTTP01ChallengeResponse(token)
+}
+
+func (r *RateLimiter) DNS01ChallengeRecord(token string) (string, error) {
+	return r.baseCl.DNS01ChallengeRecord(token)
+}
+
+func (r *RateLimiter) Discover(ctx context.Context) (acme.Directory, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return acme.Directory{}, err
+	}
+	dir, err := r.baseCl.Discover(ctx)
+	return dir, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	acc, err := r.baseCl.UpdateReg(ctx, a)
+	return acc, r.limits.Observe(r.server, r.account, err)
+}
+
+func (r *RateLimiter) RevokeCert(ctx context.Context, key crypto.Signer, cert []byte, reason acme.CRLReasonCode) error {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return err
+	}
+	return r.limits.Observe(r.server, r.account, r.baseCl.RevokeCert(ctx, key, cert, reason))
+}
+
+func (r *RateLimiter) DeactivateReg(ctx context.Context) error {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return err
+	}
+	return r.limits.Observe(r.server, r.account, r.baseCl.DeactivateReg(ctx))
+}
+
+func (r *RateLimiter) AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return err
+	}
+	return r.limits.Observe(r.server, r.account, r.baseCl.AccountKeyRollover(ctx, newKey))
+}
+
+func (r *RateLimiter) RenewalInfo(ctx context.Context, cert *x509.Certificate) (*client.RenewalInfo, error) {
+	if err := r.limits.Check(r.server, r.account); err != nil {
+		return nil, err
+	}
+	info, err := r.baseCl.RenewalInfo(ctx, cert)
+	return info, r.limits.Observe(r.server, r.account, err)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	"k8s.io/utils/clock"
+
+	"github.com/jetstack/cert-manager/pkg/metrics"
+)
+
+// DefaultRateLimitDelay is the period for which requests are held back when
+// the ACME server signals a rate limit without a valid Retry-After header.
+const DefaultRateLimitDelay = 5 * time.Minute
+
+// RateLimitedError is returned by rate limited ACME clients when a request
+// has been, or would have been, rejected by the ACME server due to rate
+// limiting.
+type RateLimitedError struct {
+	// Until is the time until which requests will be held back.
+	Until time.Time
+
+	// Err is the error returned by the ACME server, if any. It is nil if the
+	// request was held back without contacting the ACME server.
+	Err error
+}
+
+func (e *RateLimitedError) Error() string {
+	msg := fmt.Sprintf("rate limited by the ACME server until %s", e.Until.UTC().Format(time.RFC3339))
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+	return msg
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return e.Err
+}
+
+// RateLimitedUntil returns the time until which requests are held back if the
+// given error is, or wraps, a RateLimitedError.
+func RateLimitedUntil(err error) (time.Time, bool) {
+	var rlErr *RateLimitedError
+	if errors.As(err, &rlErr) {
+		return rlErr.Until, true
+	}
+	return time.Time{}, false
+}
+
+// RateLimits keeps track of the rate limits signalled by ACME servers, both
+// for all requests made to a server and for the requests made by a single
+// account. It is safe for concurrent use, and a single instance is meant to be
+// shared by all ACME clients so that one Order hitting a rate limit holds back
+// the requests of every other Order using the same server or account.
+type RateLimits struct {
+	clock   clock.Clock
+	metrics *metrics.Metrics
+
+	lock   sync.Mutex
+	limits map[rateLimitKey]time.Time
+}
+
+// rateLimitKey identifies the scope of a rate limit. The account is empty for
+// rate limits that apply to all requests made to the server.
+type rateLimitKey struct {
+	server  string
+	account string
+}
+
+// NewRateLimits returns an empty RateLimits. The time until which each server
+// or account is rate limited is exposed through the given metrics, if any.
+func NewRateLimits(clock clock.Clock, metrics *metrics.Metrics) *RateLimits {
+	return &RateLimits{
+		clock:   clock,
+		metrics: metrics,
+		limits:  make(map[rateLimitKey]time.Time),
+	}
+}
+
+// Check returns a RateLimitedError if requests made by the given account to
+// the given ACME server are currently being held back.
+func (r *RateLimits) Check(server, account string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+	var until time.Time
+	for _, key := range []rateLimitKey{{server: server}, {server: server, account: account}} {
+		t, ok := r.limits[key]
+		if !ok {
+			continue
+		}
+		if !now.Before(t) {
+			delete(r.limits, key)
+			continue
+		}
+		if t.After(until) {
+			until = t
+		}
+	}
+	if until.IsZero() {
+		return nil
+	}
+	return &RateLimitedError{Until: until}
+}
+
+// Observe records any rate limit signalled by the given error, returned by a
+// request made by the given account to the given ACME server.
+// If the error signals a rate limit, a RateLimitedError wrapping it is
+// returned. Otherwise the error is returned unchanged.
+//
+// `rateLimited` problem documents are scoped to the account, whereas any other
+// 429 Too Many Requests response, or a 503 Service Unavailable response with a
+// Retry-After header, holds back all requests made to the server.
+func (r *RateLimits) Observe(server, account string, err error) error {
+	acmeErr, ok := err.(*acme.Error)
+	if !ok {
+		return err
+	}
+
+	now := r.clock.Now()
+	key := rateLimitKey{server: server, account: account}
+	delay := RetryAfter(acmeErr.Header, now)
+	if _, limited := acme.RateLimit(err); !limited {
+		key.account = ""
+		switch {
+		case acmeErr.StatusCode == http.StatusTooManyRequests:
+		case acmeErr.StatusCode == http.StatusServiceUnavailable && delay > 0:
+		default:
+			return err
+		}
+	}
+	if delay <= 0 {
+		delay = DefaultRateLimitDelay
+	}
+	until := now.Add(delay)
+
+	r.lock.Lock()
+	if until.After(r.limits[key]) {
+		r.limits[key] = until
+	} else {
+		until = r.limits[key]
+	}
+	r.lock.Unlock()
+
+	if r.metrics != nil {
+		r.metrics.SetACMERateLimitExpiration(until, serverHost(server), key.account)
+	}
+
+	return &RateLimitedError{Until: until, Err: err}
+}
+
+// RetryAfter returns the delay requested by the Retry-After header, which is
+// either a number of seconds or an HTTP date. It returns 0 if the header is
+// missing, invalid or in the past.
+func RetryAfter(header http.Header, now time.Time) time.Duration {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	t, err := http.ParseTime(v)
+	if err != nil || !t.After(now) {
+		return 0
+	}
+	return t.Sub(now)
+}
+
+// serverHost returns the host of the given ACME directory URL, as used to
+// label the ACME client metrics.
+func serverHost(server string) string {
+	u, err := url.Parse(server)
+	if err != nil || u.Host == "" {
+		return server
+	}
+	return u.Host
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestRateLimits(t *testing.T) {
+	const (
+		server      = "https://acme.example.com/directory"
+		account     = "account"
+		otherServer = "https://other.example.com/directory"
+	)
+	now := time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	rateLimited := func(retryAfter string) error {
+		header := http.Header{}
+		if retryAfter != "" {
+			header.Set("Retry-After", retryAfter)
+		}
+		return &acme.Error{
+			StatusCode:  http.StatusTooManyRequests,
+			ProblemType: "urn:ietf:params:acme:error:rateLimited",
+			Header:      header,
+		}
+	}
+
+	tests := map[string]struct {
+		err error
+
+		// whether the error is expected to be wrapped in a RateLimitedError
+		expectedLimited bool
+		// the expected time until which requests are held back, if any
+		expectedUntil time.Time
+		// whether other accounts of the same server are expected to be held back
+		expectedServerLimited bool
+	}{
+		"ignores successful requests": {},
+		"ignores non ACME errors": {
+			err: errors.New("connection refused"),
+		},
+		"ignores ACME errors not caused by rate limiting": {
+			err: &acme.Error{StatusCode: http.StatusBadRequest, ProblemType: "urn:ietf:params:acme:error:malformed"},
+		},
+		"ignores 503 responses without Retry-After header": {
+			err: &acme.Error{StatusCode: http.StatusServiceUnavailable},
+		},
+		"holds back the account until the Retry-After delay for rateLimited problems": {
+			err:             rateLimited("3600"),
+			expectedLimited: true,
+			expectedUntil:   now.Add(time.Hour),
+		},
+		"holds back the account until the Retry-After date for rateLimited problems": {
+			err:             rateLimited(now.Add(2 * time.Hour).Format(http.TimeFormat)),
+			expectedLimited: true,
+			expectedUntil:   now.Add(2 * time.Hour),
+		},
+		"holds back the account for the default delay if Retry-After is missing": {
+			err:             rateLimited(""),
+			expectedLimited: true,
+			expectedUntil:   now.Add(DefaultRateLimitDelay),
+		},
+		"holds back all accounts for 429 responses without rateLimited problem": {
+			err: &acme.Error{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"60"}},
+			},
+			expectedLimited:       true,
+			expectedUntil:         now.Add(time.Minute),
+			expectedServerLimited: true,
+		},
+		"holds back all accounts for 503 responses with Retry-After header": {
+			err: &acme.Error{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{"Retry-After": []string{"120"}},
+			},
+			expectedLimited:       true,
+			expectedUntil:         now.Add(2 * time.Minute),
+			expectedServerLimited: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			clock := fakeclock.NewFakeClock(now)
+			r := NewRateLimits(clock, nil)
+
+			err := r.Observe(server, account, test.err)
+			until, limited := RateLimitedUntil(err)
+			if limited != test.expectedLimited {
+				t.Fatalf("unexpected rate limited error, exp=%t got=%v", test.expectedLimited, err)
+			}
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected the returned error to wrap the original error, got=%v", err)
+			}
+			if !until.Equal(test.expectedUntil) {
+				t.Errorf("unexpected rate limit expiration, exp=%s got=%s", test.expectedUntil, until)
+			}
+
+			checkLimited := func(server, account string, exp bool) {
+				err := r.Check(server, account)
+				until, limited := RateLimitedUntil(err)
+				if limited != exp {
+					t.Errorf("unexpected rate limit for account %q of %s, exp=%t got=%v", account, server, exp, err)
+				}
+				if limited && !until.Equal(test.expectedUntil) {
+					t.Errorf("unexpected rate limit expiration, exp=%s got=%s", test.expectedUntil, until)
+				}
+			}
+			checkLimited(server, account, test.expectedLimited)
+			checkLimited(server, "other-account", test.expectedServerLimited)
+			checkLimited(otherServer, account, false)
+
+			// requests are no longer held back once the rate limit expired
+			clock.SetTime(test.expectedUntil)
+			checkLimited(server, account, false)
+			checkLimited(server, "other-account", false)
+		})
+	}
+}
+
+func TestRateLimitsKeepsLatestExpiration(t *testing.T) {
+	const server = "https://acme.example.com/directory"
+	now := time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC)
+	r := NewRateLimits(fakeclock.NewFakeClock(now), nil)
+
+	r.Observe(server, "account", &acme.Error{
+		StatusCode:  http.StatusTooManyRequests,
+		ProblemType: "urn:ietf:params:acme:error:rateLimited",
+		Header:      http.Header{"Retry-After": []string{"3600"}},
+	})
+	err := r.Observe(server, "account", &acme.Error{
+		StatusCode:  http.StatusTooManyRequests,
+		ProblemType: "urn:ietf:params:acme:error:rateLimited",
+		Header:      http.Header{"Retry-After": []string{"60"}},
+	})
+	if until, _ := RateLimitedUntil(err); !until.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected the latest rate limit expiration to be kept, got=%s", until)
+	}
+	if until, _ := RateLimitedUntil(r.Check(server, "account")); !until.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected the latest rate limit expiration to be kept, got=%s", until)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"21600":                         6 * time.Hour,
+		"-10":                           0,
+		"Tue, 01 Jun 2021 13:00:00 GMT": time.Hour,
+		"Tue, 01 Jun 2021 11:00:00 GMT": 0,
+		"soon":                          0,
+	}
+	for value, exp := range tests {
+		header := http.Header{}
+		if value != "" {
+			header.Set("Retry-After", value)
+		}
+		if got := RetryAfter(header, now); got != exp {
+			t.Errorf("unexpected duration for %q, exp=%s got=%s", value, exp, got)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrRenewalInfoNotSupported is returned by RenewalInfo if the ACME server
+// does not implement the ACME Renewal Information (ARI) extension.
+var ErrRenewalInfoNotSupported = errors.New("ACME server does not support renewal information")
+
+// RenewalInfo is the renewal window suggested by an ACME server for a
+// certificate.
+type RenewalInfo struct {
+	// SuggestedWindowStart and SuggestedWindowEnd bound the window in which
+	// the certificate should be renewed.
+	SuggestedWindowStart time.Time
+	SuggestedWindowEnd   time.Time
+
+	// ExplanationURL is a URL explaining the suggested window, if any.
+	ExplanationURL string
+
+	// RetryAfter is the delay after which the renewal information should be
+	// requested again, or 0 if the ACME server did not request one.
+	RetryAfter time.Duration
+}
+
+// RenewalInfo retrieves the renewal window suggested by the ACME server for
+// the given certificate from the renewalInfo endpoint defined by the ACME
+// Renewal Information (ARI) extension. ErrRenewalInfoNotSupported is
+// returned if the ACME server does not implement the extension.
+func (c *Client) RenewalInfo(ctx context.Context, cert *x509.Certificate) (*RenewalInfo, error) {
+	id, err := renewalInfoCertID(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dir.RenewalInfo == "" {
+		return nil, ErrRenewalInfoNotSupported
+	}
+
+	var resp struct {
+		SuggestedWindow struct {
+			Start time.Time `json:"start"`
+			End   time.Time `json:"end"`
+		} `json:"suggestedWindow"`
+		ExplanationURL string `json:"explanationURL"`
+	}
+	header, err := getJSON(ctx, c.httpClient(), strings.TrimSuffix(dir.RenewalInfo, "/")+"/"+id, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := resp.SuggestedWindow.Start, resp.SuggestedWindow.End
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return nil, fmt.Errorf("invalid suggested renewal window from %s to %s", start, end)
+	}
+
+	return &RenewalInfo{
+		SuggestedWindowStart: start,
+		SuggestedWindowEnd:   end,
+		ExplanationURL:       resp.ExplanationURL,
+		RetryAfter:           RetryAfter(header, time.Now()),
+	}, nil
+}
+
+// renewalInfoCertID returns the identifier of cert used in requests to the
+// renewalInfo endpoint: the base64url encoded key identifier of its
+// authority key identifier extension and the base64url encoded DER
+// representation of its serial number, separated by a period.
+func renewalInfoCertID(cert *x509.Certificate) (string, error) {
+	if len(cert.AuthorityKeyId) == 0 {
+		return "", errors.New("certificate does not have an authority key identifier")
+	}
+
+	serial := cert.SerialNumber.Bytes()
+	// the DER encoding of a positive INTEGER has a leading zero byte if its
+	// most significant bit is set
+	if len(serial) == 0 || serial[0]&0x80 != 0 {
+		serial = append([]byte{0}, serial...)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(cert.AuthorityKeyId) + "." +
+		base64.RawURLEncoding.EncodeToString(serial), nil
+}
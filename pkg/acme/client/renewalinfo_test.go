@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+func TestRenewalInfoCertID(t *testing.T) {
+	// example from RFC 9773, section 4.1
+	cert := &x509.Certificate{
+		AuthorityKeyId: []byte{0x69, 0x88, 0x5b, 0x6b, 0x87, 0x46, 0x40, 0x41, 0xe1, 0xb3,
+			0x7b, 0x84, 0x7b, 0xa0, 0xae, 0x2c, 0xde, 0x01, 0xc8, 0xd4},
+		SerialNumber: big.NewInt(0x87654321),
+	}
+
+	id, err := renewalInfoCertID(cert)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp := "aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE"; id != exp {
+		t.Errorf("unexpected certificate ID, exp=%s got=%s", exp, id)
+	}
+
+	if _, err := renewalInfoCertID(&x509.Certificate{SerialNumber: big.NewInt(1)}); err == nil {
+		t.Errorf("expected an error for a certificate without an authority key identifier")
+	}
+}
+
+func TestRenewalInfo(t *testing.T) {
+	cert := &x509.Certificate{
+		AuthorityKeyId: []byte{0x01, 0x02, 0x03},
+		SerialNumber:   big.NewInt(0x1234),
+	}
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+	validWindow := fmt.Sprintf(`{"suggestedWindow":{"start":%q,"end":%q},"explanationURL":"https://example.com/incident"}`,
+		start.Format(time.RFC3339), end.Format(time.RFC3339))
+
+	tests := map[string]struct {
+		noRenewalInfo bool
+		status        int
+		body          string
+		retryAfter    string
+		expected      *RenewalInfo
+		expectedErr   error
+		wantsErr      bool
+	}{
+		"returns the suggested window": {
+			status:     http.StatusOK,
+			body:       validWindow,
+			retryAfter: "3600",
+			expected: &RenewalInfo{
+				SuggestedWindowStart: start,
+				SuggestedWindowEnd:   end,
+				ExplanationURL:       "https://example.com/incident",
+				RetryAfter:           time.Hour,
+			},
+		},
+		"returns ErrRenewalInfoNotSupported if the directory has no renewalInfo endpoint": {
+			noRenewalInfo: true,
+			expectedErr:   ErrRenewalInfoNotSupported,
+			wantsErr:      true,
+		},
+		"returns an error if the server responds with an error": {
+			status:   http.StatusNotFound,
+			body:     `{"type":"urn:ietf:params:acme:error:malformed"}`,
+			wantsErr: true,
+		},
+		"returns an error if the window ends before it starts": {
+			status: http.StatusOK,
+			body: fmt.Sprintf(`{"suggestedWindow":{"start":%q,"end":%q}}`,
+				end.Format(time.RFC3339), start.Format(time.RFC3339)),
+			wantsErr: true,
+		},
+		"returns an error if the response has no window": {
+			status:   http.StatusOK,
+			body:     `{}`,
+			wantsErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/directory":
+					if test.noRenewalInfo {
+						fmt.Fprint(w, `{"newOrder":"https://example.com/new-order"}`)
+						return
+					}
+					fmt.Fprintf(w, `{"renewalInfo":"%s/renewal-info"}`, server.URL)
+				case "/renewal-info/AQID.EjQ":
+					if test.retryAfter != "" {
+						w.Header().Set("Retry-After", test.retryAfter)
+					}
+					w.WriteHeader(test.status)
+					fmt.Fprint(w, test.body)
+				default:
+					t.Errorf("unexpected request path %q", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			cl := &Client{Client: &acme.Client{
+				HTTPClient:   server.Client(),
+				DirectoryURL: server.URL + "/directory",
+			}}
+			info, err := cl.RenewalInfo(context.Background(), cert)
+			if (err != nil) != test.wantsErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.wantsErr, err)
+			}
+			if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+				t.Errorf("unexpected error, exp=%v got=%v", test.expectedErr, err)
+			}
+			if test.expected == nil {
+				return
+			}
+			if !info.SuggestedWindowStart.Equal(test.expected.SuggestedWindowStart) ||
+				!info.SuggestedWindowEnd.Equal(test.expected.SuggestedWindowEnd) ||
+				info.ExplanationURL != test.expected.ExplanationURL ||
+				info.RetryAfter != test.expected.RetryAfter {
+				t.Errorf("unexpected renewal info, exp=%+v got=%+v", test.expected, info)
+			}
+		})
+	}
+}
+
+func TestRenewalInfoCachesDirectory(t *testing.T) {
+	cert := &x509.Certificate{
+		AuthorityKeyId: []byte{0x01, 0x02, 0x03},
+		SerialNumber:   big.NewInt(0x1234),
+	}
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	directoryRequests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/directory":
+			directoryRequests++
+			fmt.Fprintf(w, `{"renewalInfo":"%s/renewal-info"}`, server.URL)
+		case "/renewal-info/AQID.EjQ":
+			fmt.Fprintf(w, `{"suggestedWindow":{"start":%q,"end":%q}}`,
+				start.Format(time.RFC3339), start.Add(time.Hour).Format(time.RFC3339))
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cl := &Client{Client: &acme.Client{
+		HTTPClient:   server.Client(),
+		DirectoryURL: server.URL + "/directory",
+	}}
+	for i := 0; i < 2; i++ {
+		if _, err := cl.RenewalInfo(context.Background(), cert); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if directoryRequests != 1 {
+		t.Errorf("expected the directory to be fetched once, got %d requests", directoryRequests)
+	}
+}
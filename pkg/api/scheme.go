@@ -35,6 +35,7 @@ import (
 	cmapiv1alpha2 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmapiv1alpha3 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha3"
 	cmapiv1beta1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1beta1"
+	cmexperimental "github.com/jetstack/cert-manager/pkg/apis/experimental/v1alpha1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 )
 
@@ -59,6 +60,7 @@ var localSchemeBuilder = runtime.SchemeBuilder{
 	cmacmev1alpha3.AddToScheme,
 	cmacmev1beta1.AddToScheme,
 	cmacmev1.AddToScheme,
+	cmexperimental.AddToScheme,
 	cmmeta.AddToScheme,
 	whapi.AddToScheme,
 	kscheme.AddToScheme,
@@ -19,6 +19,10 @@ package util
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 )
@@ -34,6 +38,12 @@ const (
 	IssuerSelfSigned string = "selfsigned"
 	// IssuerVenafi uses Venafi Trust Protection Platform and Venafi Cloud
 	IssuerVenafi string = "venafi"
+	// IssuerEST obtains certificates from an Enrollment over Secure Transport server
+	IssuerEST string = "est"
+	// IssuerSCEP obtains certificates from a Simple Certificate Enrollment Protocol server
+	IssuerSCEP string = "scep"
+	// IssuerCMP obtains certificates from a Certificate Management Protocol server
+	IssuerCMP string = "cmp"
 )
 
 // NameForIssuer determines the name of the Issuer implementation given an
@@ -50,6 +60,12 @@ func NameForIssuer(i cmapi.GenericIssuer) (string, error) {
 		return IssuerSelfSigned, nil
 	case i.GetSpec().Venafi != nil:
 		return IssuerVenafi, nil
+	case i.GetSpec().EST != nil:
+		return IssuerEST, nil
+	case i.GetSpec().SCEP != nil:
+		return IssuerSCEP, nil
+	case i.GetSpec().CMP != nil:
+		return IssuerCMP, nil
 	}
 	return "", fmt.Errorf("no issuer specified for Issuer '%s/%s'", i.GetObjectMeta().Namespace, i.GetObjectMeta().Name)
 }
@@ -61,3 +77,29 @@ func IssuerKind(ref cmmeta.ObjectReference) string {
 	}
 	return ref.Kind
 }
+
+// IssuerAllowsNamespace returns true if Certificates and CertificateRequests
+// in the given namespace may reference the issuer, as configured by the
+// namespace access restrictions of a ClusterIssuer. Issuers without such
+// restrictions may be used from any namespace.
+func IssuerAllowsNamespace(iss cmapi.GenericIssuer, namespace *corev1.Namespace) (bool, error) {
+	access := iss.GetSpec().NamespaceAccess
+	if access == nil {
+		return true, nil
+	}
+
+	for _, name := range access.Namespaces {
+		if name == namespace.Name {
+			return true, nil
+		}
+	}
+
+	if access.NamespaceSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(access.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector: %v", err)
+	}
+	return selector.Matches(labels.Set(namespace.Labels)), nil
+}
@@ -47,6 +47,11 @@ const (
 	// SolverIdentificationLabelKey is added to the labels of a Pod serving an ACME challenge.
 	// Its value will be the "true" if the Pod is an HTTP-01 solver.
 	SolverIdentificationLabelKey = "acme.cert-manager.io/http01-solver"
+
+	// AccountDeactivationFinalizer is added to Issuers and ClusterIssuers
+	// that have `spec.acme.deactivateAccountOnDeletion` set, so that the ACME
+	// account can be deactivated before the issuer is removed.
+	AccountDeactivationFinalizer = "finalizer.acme.cert-manager.io/account-deactivation"
 )
 
 const (
@@ -71,7 +71,7 @@ type ChallengeSpec struct {
 	Wildcard bool `json:"wildcard"`
 
 	// Type is the type of ACME challenge this resource represents.
-	// One of "http-01" or "dns-01".
+	// One of "http-01", "dns-01" or "tls-alpn-01".
 	Type ACMEChallengeType `json:"type"`
 
 	// Token is the ACME challenge token for this challenge.
@@ -99,8 +99,8 @@ type ChallengeSpec struct {
 	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
 }
 
-// The type of ACME challenge. Only http-01 and dns-01 are supported.
-// +kubebuilder:validation:Enum=http-01;dns-01
+// The type of ACME challenge. Only http-01, dns-01 and tls-alpn-01 are supported.
+// +kubebuilder:validation:Enum=http-01;dns-01;tls-alpn-01
 type ACMEChallengeType string
 
 const (
@@ -111,6 +111,10 @@ const (
 	// ACMEChallengeTypeDNS01 denotes a Challenge is of type dns-01
 	// More info: https://letsencrypt.org/docs/challenge-types/#dns-01-challenge
 	ACMEChallengeTypeDNS01 ACMEChallengeType = "dns-01"
+
+	// ACMEChallengeTypeTLSALPN01 denotes a Challenge is of type tls-alpn-01
+	// More info: https://letsencrypt.org/docs/challenge-types/#tls-alpn-01
+	ACMEChallengeTypeTLSALPN01 ACMEChallengeType = "tls-alpn-01"
 )
 
 type ChallengeStatus struct {
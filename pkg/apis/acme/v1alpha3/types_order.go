@@ -122,6 +122,47 @@ type OrderStatus struct {
 	// This is used to influence garbage collection and back-off.
 	// +optional
 	FailureTime *metav1.Time `json:"failureTime,omitempty"`
+
+	// RateLimitedUntil is set while requests for this Order are rejected by the
+	// ACME server due to rate limiting. It stores the time until which the
+	// Order will not be retried.
+	// +optional
+	RateLimitedUntil *metav1.Time `json:"rateLimitedUntil,omitempty"`
+
+	// SelectedChain describes the certificate chain selected among the chains
+	// offered by the ACME server. It is only set if the issuer configures
+	// chain selection.
+	// +optional
+	SelectedChain *ACMESelectedChain `json:"selectedChain,omitempty"`
+}
+
+// ACMESelectedChain describes the certificate chain selected among the chains
+// offered by the ACME server for an Order.
+type ACMESelectedChain struct {
+	// Index is the position of the selected chain among the chains offered
+	// by the ACME server, 0 being its default chain and the alternate chains
+	// following in the order they were offered.
+	Index int `json:"index"`
+
+	// Certificates describes the certificates of the selected chain following
+	// the issued certificate, in the order they were served.
+	// +optional
+	Certificates []ACMEChainCertificate `json:"certificates,omitempty"`
+}
+
+// ACMEChainCertificate identifies a certificate of a certificate chain.
+type ACMEChainCertificate struct {
+	// CommonName is the subject common name of the certificate.
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// SHA256 is the hex encoded SHA-256 fingerprint of the DER encoded
+	// certificate.
+	SHA256 string `json:"sha256"`
+
+	// SPKISHA256 is the hex encoded SHA-256 hash of the DER encoded
+	// SubjectPublicKeyInfo of the certificate.
+	SPKISHA256 string `json:"spkiSHA256"`
 }
 
 // ACMEAuthorization contains data returned from the ACME server on an
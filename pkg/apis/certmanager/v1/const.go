@@ -30,6 +30,21 @@ const (
 
 	// Deprecated: the default is now 2/3 of Certificate's duration
 	DefaultRenewBefore = time.Hour * 24 * 30
+
+	// minimum permitted interval between revocation checks of a certificate
+	MinimumRevocationCheckInterval = time.Minute * 5
+
+	// default interval between revocation checks if
+	// Certificate.spec.revocationCheck.interval is not set
+	DefaultRevocationCheckInterval = time.Hour
+
+	// minimum permitted length in bytes of the serial numbers generated by
+	// CA issuers, which ensures they contain at least 63 bits of entropy
+	MinimumCASerialNumberLength = 8
+
+	// maximum permitted length in bytes of the serial numbers generated by
+	// CA issuers, as defined in RFC 5280, section 4.1.2.2
+	MaximumCASerialNumberLength = 20
 )
 
 const (
@@ -40,4 +55,14 @@ const (
 	// (/v1/auth/kubernetes). The endpoint will then be called at `/login`, so
 	// left as the default, `/v1/auth/kubernetes/login` will be called.
 	DefaultVaultKubernetesAuthMountPath = "/v1/auth/kubernetes"
+
+	// Default mount path location for JWT/OIDC authentication (/v1/auth/jwt).
+	// The endpoint will then be called at `/login`, so left as the default,
+	// `/v1/auth/jwt/login` will be called.
+	DefaultVaultJWTAuthMountPath = "/v1/auth/jwt"
+
+	// Default mount path location for TLS client certificate authentication
+	// (/v1/auth/cert). The endpoint will then be called at `/login`, so left as
+	// the default, `/v1/auth/cert/login` will be called.
+	DefaultVaultClientCertificateAuthMountPath = "/v1/auth/cert"
 )
@@ -94,6 +94,15 @@ const (
 	// stored in the target Secret resource whilst the real Issuer is processing
 	// the certificate request.
 	IssueTemporaryCertificateAnnotation = "cert-manager.io/issue-temporary-certificate"
+
+	// RevokeCertificateAnnotation is an annotation that can be added to
+	// Certificate resources to request that the certificate currently stored
+	// in `spec.secretName` is revoked by the issuer.
+	// The value of the annotation is the RFC 5280 revocation reason to use,
+	// for example `keyCompromise`. An empty value is treated as `unspecified`.
+	// The annotation is removed by cert-manager once the request has been
+	// processed. Revoking a certificate does not cause it to be re-issued.
+	RevokeCertificateAnnotation = "cert-manager.io/revoke"
 )
 
 // Common/known resource kinds.
@@ -141,6 +150,17 @@ const (
 	// Venafi Pickup ID of a certificate signing request that has been submitted
 	// to the Venafi API for collection later.
 	VenafiPickupIDAnnotationKey = "venafi.cert-manager.io/pickup-id"
+
+	// SCEPTransactionIDAnnotationKey is the annotation key used to record the
+	// SCEP transaction ID of a certificate signing request that has been
+	// accepted by a SCEP server but not yet issued, so that it can be polled
+	// for later.
+	SCEPTransactionIDAnnotationKey = "scep.cert-manager.io/transaction-id"
+
+	// VaultRoleAnnotationKey is the annotation that selects the Vault PKI role
+	// used to sign a certificate request, instead of the role in the path of
+	// the Vault issuer. The role must be allowed by the issuer.
+	VaultRoleAnnotationKey = "vault.cert-manager.io/role"
 )
 
 // KeyUsage specifies valid usage contexts for keys.
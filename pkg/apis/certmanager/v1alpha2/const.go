@@ -40,4 +40,14 @@ const (
 	// (/v1/auth/kubernetes). The endpoint will then be called at `/login`, so
 	// left as the default, `/v1/auth/kubernetes/login` will be called.
 	DefaultVaultKubernetesAuthMountPath = "/v1/auth/kubernetes"
+
+	// Default mount path location for JWT/OIDC authentication (/v1/auth/jwt).
+	// The endpoint will then be called at `/login`, so left as the default,
+	// `/v1/auth/jwt/login` will be called.
+	DefaultVaultJWTAuthMountPath = "/v1/auth/jwt"
+
+	// Default mount path location for TLS client certificate authentication
+	// (/v1/auth/cert). The endpoint will then be called at `/login`, so left as
+	// the default, `/v1/auth/cert/login` will be called.
+	DefaultVaultClientCertificateAuthMountPath = "/v1/auth/cert"
 )
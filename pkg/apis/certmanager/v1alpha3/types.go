@@ -92,6 +92,15 @@ const (
 	// stored in the target Secret resource whilst the real Issuer is processing
 	// the certificate request.
 	IssueTemporaryCertificateAnnotation = "cert-manager.io/issue-temporary-certificate"
+
+	// RevokeCertificateAnnotation is an annotation that can be added to
+	// Certificate resources to request that the certificate currently stored
+	// in `spec.secretName` is revoked by the issuer.
+	// The value of the annotation is the RFC 5280 revocation reason to use,
+	// for example `keyCompromise`. An empty value is treated as `unspecified`.
+	// The annotation is removed by cert-manager once the request has been
+	// processed. Revoking a certificate does not cause it to be re-issued.
+	RevokeCertificateAnnotation = "cert-manager.io/revoke"
 )
 
 // Common/known resource kinds.
@@ -205,4 +205,11 @@ const (
 	// `False`, and cannot be modified once set. Cannot be set alongside
 	// `Approved`.
 	CertificateRequestConditionDenied CertificateRequestConditionType = "Denied"
+
+	// CertificateRequestConditionRevoked indicates that the certificate
+	// stored in the CertificateRequest's `status.certificate` field has been
+	// revoked by cert-manager. A status of `False` indicates that cert-manager
+	// will not attempt to revoke the certificate, for example because the
+	// issuer does not support revocation or rejected the request.
+	CertificateRequestConditionRevoked CertificateRequestConditionType = "Revoked"
 )
@@ -51,4 +51,10 @@ const (
 	// used to record the Venafi Pickup ID of a certificate signing request that
 	// has been submitted to the Venafi API for collection later.
 	CertificateSigningRequestVenafiPickupIDAnnotationKey = "venafi.experimental.cert-manager.io/pickup-id"
+
+	// CertificateSigningRequestVaultRoleAnnotationKey is the annotation that
+	// selects the Vault PKI role used to sign a certificate signing request,
+	// instead of the role in the path of the Vault issuer. The role must be
+	// allowed by the issuer.
+	CertificateSigningRequestVaultRoleAnnotationKey = "vault.experimental.cert-manager.io/role"
 )
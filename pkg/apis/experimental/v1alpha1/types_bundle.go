@@ -0,0 +1,230 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	// BundleLabelKey is the label added to ConfigMaps written by the bundles
+	// controller. Its value is the name of the Bundle the ConfigMap was
+	// written for.
+	BundleLabelKey = "experimental.cert-manager.io/bundle"
+
+	// DefaultBundleTruststorePassword is the password used to encode JKS and
+	// PKCS#12 truststores if none is specified.
+	DefaultBundleTruststorePassword = "changeit"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A Bundle aggregates CA certificates from a number of sources into a single
+// trust bundle, and distributes it as a ConfigMap to every namespace matching
+// its target namespace selector.
+// The ConfigMaps written have the same name as the Bundle.
+type Bundle struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Desired state of the Bundle resource.
+	Spec BundleSpec `json:"spec"`
+
+	// Status of the Bundle. This is set and managed automatically.
+	// +optional
+	Status BundleStatus `json:"status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BundleList is a list of Bundles
+type BundleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Bundle `json:"items"`
+}
+
+// BundleSpec defines the sources and target of a Bundle.
+type BundleSpec struct {
+	// Sources is the list of sources that CA certificates are read from.
+	// Certificates found in more than one source are only included once in
+	// the bundle.
+	Sources []BundleSource `json:"sources"`
+
+	// Target is where the bundle is written to.
+	Target BundleTarget `json:"target"`
+}
+
+// BundleSource is a source of CA certificates. Exactly one of the fields
+// must be set.
+type BundleSource struct {
+	// Secret is a key of a Secret containing PEM encoded CA certificates.
+	// The Secret must be in the cluster resource namespace of cert-manager.
+	// The key defaults to `ca.crt`.
+	// +optional
+	Secret *cmmeta.SecretKeySelector `json:"secret,omitempty"`
+
+	// ConfigMap is a key of a ConfigMap containing PEM encoded CA
+	// certificates. The ConfigMap must be in the cluster resource namespace
+	// of cert-manager.
+	// +optional
+	ConfigMap *BundleConfigMapKeySelector `json:"configMap,omitempty"`
+
+	// Issuer is a reference to a CA issuer, whose root CA certificate is
+	// included in the bundle.
+	// +optional
+	Issuer *BundleIssuerReference `json:"issuer,omitempty"`
+
+	// InLine is a PEM encoded list of CA certificates.
+	// +optional
+	InLine *string `json:"inLine,omitempty"`
+}
+
+// BundleConfigMapKeySelector selects a key of a ConfigMap.
+type BundleConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key of the entry in the ConfigMap's `data` field to be used.
+	Key string `json:"key"`
+}
+
+// BundleIssuerReference is a reference to an Issuer or ClusterIssuer.
+type BundleIssuerReference struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+
+	// Kind of the issuer, either `Issuer` or `ClusterIssuer`. Defaults to
+	// `Issuer`.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Namespace of the Issuer. Must be set if the kind is `Issuer`, and must
+	// not be set if the kind is `ClusterIssuer`.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// BundleTarget is the destination of a Bundle.
+type BundleTarget struct {
+	// ConfigMap is the key in the target ConfigMaps that the PEM encoded
+	// bundle is written to.
+	ConfigMap BundleKeySelector `json:"configMap"`
+
+	// AdditionalFormats are additional encodings of the bundle that are
+	// written to the target ConfigMaps.
+	// +optional
+	AdditionalFormats *BundleAdditionalFormats `json:"additionalFormats,omitempty"`
+
+	// NamespaceSelector selects the namespaces that the bundle is written
+	// to. If not set, the bundle is written to all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// BundleKeySelector selects a key of the target ConfigMaps.
+type BundleKeySelector struct {
+	// Key of the entry in the target ConfigMaps.
+	Key string `json:"key"`
+}
+
+// BundleAdditionalFormats configures the truststore formats that a bundle
+// is written in, in addition to PEM.
+type BundleAdditionalFormats struct {
+	// JKS writes the bundle as a JKS truststore to the `binaryData` field of
+	// the target ConfigMaps.
+	// +optional
+	JKS *BundleTruststore `json:"jks,omitempty"`
+
+	// PKCS12 writes the bundle as a PKCS#12 truststore to the `binaryData`
+	// field of the target ConfigMaps.
+	// +optional
+	PKCS12 *BundleTruststore `json:"pkcs12,omitempty"`
+}
+
+// BundleTruststore configures a truststore encoding of a bundle.
+type BundleTruststore struct {
+	// Key of the entry in the target ConfigMaps that the truststore is
+	// written to.
+	Key string `json:"key"`
+
+	// Password used to encode the truststore. As truststores only contain
+	// public data, the password is only used for integrity checks.
+	// Defaults to `changeit`.
+	// +optional
+	Password *string `json:"password,omitempty"`
+}
+
+// BundleStatus defines the observed state of a Bundle.
+type BundleStatus struct {
+	// List of status conditions to indicate the status of a Bundle.
+	// Known condition types are `Ready`.
+	// +optional
+	Conditions []BundleCondition `json:"conditions,omitempty"`
+
+	// Namespaces is the number of namespaces that the bundle has been
+	// written to.
+	// +optional
+	Namespaces int32 `json:"namespaces,omitempty"`
+}
+
+// BundleCondition contains condition information for a Bundle.
+type BundleCondition struct {
+	// Type of the condition, known values are (`Ready`).
+	Type BundleConditionType `json:"type"`
+
+	// Status of the condition, one of (`True`, `False`, `Unknown`).
+	Status cmmeta.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// If set, this represents the .metadata.generation that the condition was
+	// set based upon.
+	// For instance, if .metadata.generation is currently 12, but the
+	// .status.condition[x].observedGeneration is 9, the condition is out of date
+	// with respect to the current state of the Bundle.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// BundleConditionType represents a Bundle condition value.
+type BundleConditionType string
+
+const (
+	// BundleConditionReady indicates that the bundle has been built from all
+	// of its sources and written to all target namespaces.
+	BundleConditionReady BundleConditionType = "Ready"
+)
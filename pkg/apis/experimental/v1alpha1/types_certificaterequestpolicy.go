@@ -0,0 +1,198 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A CertificateRequestPolicy declares the constraints that CertificateRequests
+// referencing a set of issuers must satisfy in order to be approved.
+// If any CertificateRequestPolicy applies to a CertificateRequest, the
+// request is approved if at least one of the applicable policies allows it,
+// and denied otherwise. Requests that no policy applies to are approved.
+type CertificateRequestPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Desired state of the CertificateRequestPolicy resource.
+	Spec CertificateRequestPolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateRequestPolicyList is a list of CertificateRequestPolicies
+type CertificateRequestPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CertificateRequestPolicy `json:"items"`
+}
+
+// CertificateRequestPolicySpec defines the CertificateRequests a policy
+// applies to, and the constraints they must satisfy.
+// All patterns may contain the wildcard character `*`, which matches any
+// sequence of characters. Constraints that are not set are not enforced,
+// except for the identity constraints dnsNames, uris, ipAddresses and
+// emailAddresses: if any of them is set, requests for identities of a type
+// whose constraint is not set are denied.
+type CertificateRequestPolicySpec struct {
+	// IssuerRefs is the list of issuers this policy applies to.
+	// If empty, the policy applies to CertificateRequests referencing any
+	// issuer.
+	// +optional
+	IssuerRefs []CertificateRequestPolicyIssuerRef `json:"issuerRefs,omitempty"`
+
+	// Requesters restricts the identities that may create CertificateRequests
+	// allowed by this policy.
+	// +optional
+	Requesters *CertificateRequestPolicyRequesters `json:"requesters,omitempty"`
+
+	// DNSNames is the list of patterns that every DNS name requested must
+	// match at least one of. If subject.commonName is not set, the requested
+	// common name must also match one of these patterns.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// URIs is the list of patterns that every URI requested must match at
+	// least one of.
+	// +optional
+	URIs []string `json:"uris,omitempty"`
+
+	// IPAddresses is the list of patterns that every IP address requested
+	// must match at least one of.
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// EmailAddresses is the list of patterns that every email address
+	// requested must match at least one of.
+	// +optional
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// MaxDuration is the maximum duration that may be requested.
+	// Requests that do not specify a duration are considered to request the
+	// default certificate duration.
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+
+	// KeyAlgorithms is the list of key algorithms and sizes that requested
+	// certificates may use.
+	// +optional
+	KeyAlgorithms []CertificateRequestPolicyKeyAlgorithm `json:"keyAlgorithms,omitempty"`
+
+	// Usages is the list of key usages that may be requested.
+	// Requests that do not specify any usages are considered to request the
+	// default usages.
+	// +optional
+	Usages []cmapi.KeyUsage `json:"usages,omitempty"`
+
+	// AllowCA allows CertificateRequests with isCA set to be approved.
+	// Defaults to false.
+	// +optional
+	AllowCA bool `json:"allowCA,omitempty"`
+
+	// Subject restricts the subject fields that may be requested.
+	// +optional
+	Subject *CertificateRequestPolicySubject `json:"subject,omitempty"`
+}
+
+// CertificateRequestPolicyIssuerRef selects the issuers a policy applies to.
+// Empty fields match any value.
+type CertificateRequestPolicyIssuerRef struct {
+	// Name is a pattern matching the name of the issuer.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Kind is a pattern matching the kind of the issuer. CertificateRequests
+	// that do not specify a kind reference an `Issuer`.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group is a pattern matching the group of the issuer.
+	// CertificateRequests that do not specify a group reference the
+	// `cert-manager.io` group.
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// CertificateRequestPolicyRequesters restricts the identities of the users
+// that may create CertificateRequests. A request is allowed if its username
+// matches one of the username patterns, or one of its groups matches one of
+// the group patterns.
+type CertificateRequestPolicyRequesters struct {
+	// Usernames is a list of patterns matching the username of the requester.
+	// +optional
+	Usernames []string `json:"usernames,omitempty"`
+
+	// Groups is a list of patterns matching the groups of the requester.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// CertificateRequestPolicyKeyAlgorithm is a key algorithm, and the range of
+// key sizes, that requested certificates may use.
+type CertificateRequestPolicyKeyAlgorithm struct {
+	// Algorithm is the private key algorithm.
+	Algorithm cmapi.PrivateKeyAlgorithm `json:"algorithm"`
+
+	// MinSize is the minimum key size in bits. For ECDSA keys, this is the
+	// size of the curve. Not enforced if zero.
+	// +optional
+	MinSize int `json:"minSize,omitempty"`
+
+	// MaxSize is the maximum key size in bits. For ECDSA keys, this is the
+	// size of the curve. Not enforced if zero.
+	// +optional
+	MaxSize int `json:"maxSize,omitempty"`
+}
+
+// CertificateRequestPolicySubject restricts the subject fields that may be
+// requested. Each value requested must match one of the patterns for its
+// field. Fields that are not set are not enforced.
+type CertificateRequestPolicySubject struct {
+	// CommonName is a pattern matching the requested common name.
+	// +optional
+	CommonName *string `json:"commonName,omitempty"`
+
+	// Organizations is a list of patterns matching requested organizations.
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+
+	// OrganizationalUnits is a list of patterns matching requested
+	// organizational units.
+	// +optional
+	OrganizationalUnits []string `json:"organizationalUnits,omitempty"`
+
+	// Countries is a list of patterns matching requested countries.
+	// +optional
+	Countries []string `json:"countries,omitempty"`
+
+	// Localities is a list of patterns matching requested localities.
+	// +optional
+	Localities []string `json:"localities,omitempty"`
+
+	// Provinces is a list of patterns matching requested provinces.
+	// +optional
+	Provinces []string `json:"provinces,omitempty"`
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// CertificateRequestReviewStatus is the outcome of the review of a
+// CertificateRequest by an approval webhook.
+type CertificateRequestReviewStatus string
+
+const (
+	// CertificateRequestReviewApproved means the CertificateRequest has been
+	// approved and may be signed.
+	CertificateRequestReviewApproved CertificateRequestReviewStatus = "Approved"
+
+	// CertificateRequestReviewDenied means the CertificateRequest has been
+	// denied and must not be signed.
+	CertificateRequestReviewDenied CertificateRequestReviewStatus = "Denied"
+
+	// CertificateRequestReviewPending means the CertificateRequest has not
+	// been decided on yet. The review will be sent again later.
+	CertificateRequestReviewPending CertificateRequestReviewStatus = "Pending"
+)
+
+// CertificateRequestReview is sent to approval webhooks as the body of a POST
+// request for each CertificateRequest awaiting approval. Webhooks respond with
+// a CertificateRequestReview which has its Response set.
+// The same review, with the same UID, is sent again to poll webhooks which
+// responded with a Pending status.
+type CertificateRequestReview struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Request describes the CertificateRequest under review. It is set in
+	// reviews sent to webhooks.
+	// +optional
+	Request *CertificateRequestReviewRequest `json:"request,omitempty"`
+
+	// Response is the decision of the webhook. It is set in reviews returned
+	// by webhooks.
+	// +optional
+	Response *CertificateRequestReviewResponse `json:"response,omitempty"`
+}
+
+// CertificateRequestReviewRequest describes a CertificateRequest, and the
+// certificate request it contains, to an approval webhook.
+type CertificateRequestReviewRequest struct {
+	// UID is the UID of the CertificateRequest. It is the same in every review
+	// sent for the CertificateRequest.
+	UID types.UID `json:"uid"`
+
+	// Name of the CertificateRequest.
+	Name string `json:"name"`
+
+	// Namespace of the CertificateRequest.
+	Namespace string `json:"namespace"`
+
+	// IssuerRef is the reference to the issuer the CertificateRequest is for.
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+
+	// UserInfo is the identity of the user that created the
+	// CertificateRequest.
+	UserInfo authenticationv1.UserInfo `json:"userInfo"`
+
+	// Duration is the requested duration of the certificate.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// IsCA is true if a CA certificate is requested.
+	// +optional
+	IsCA bool `json:"isCA,omitempty"`
+
+	// Usages is the set of key usages requested.
+	// +optional
+	Usages []cmapi.KeyUsage `json:"usages,omitempty"`
+
+	// Request is the PEM encoded x509 certificate signing request.
+	Request []byte `json:"request"`
+
+	// CommonName is the common name of the certificate signing request.
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// Subject is the subject of the certificate signing request, excluding
+	// the common name.
+	// +optional
+	Subject *cmapi.X509Subject `json:"subject,omitempty"`
+
+	// DNSNames are the DNS names of the certificate signing request.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// IPAddresses are the IP addresses of the certificate signing request.
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// URIs are the URIs of the certificate signing request.
+	// +optional
+	URIs []string `json:"uris,omitempty"`
+
+	// EmailAddresses are the email addresses of the certificate signing
+	// request.
+	// +optional
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// KeyAlgorithm is the algorithm of the public key of the certificate
+	// signing request.
+	KeyAlgorithm cmapi.PrivateKeyAlgorithm `json:"keyAlgorithm"`
+
+	// KeySize is the size of the public key in bits. For ECDSA keys, this is
+	// the size of the curve.
+	KeySize int `json:"keySize"`
+}
+
+// CertificateRequestReviewResponse is the decision of an approval webhook.
+type CertificateRequestReviewResponse struct {
+	// UID is the UID of the CertificateRequest the decision is for. It must
+	// match the UID of the request.
+	UID types.UID `json:"uid"`
+
+	// Status is the decision of the webhook.
+	Status CertificateRequestReviewStatus `json:"status"`
+
+	// Message is a human readable explanation of the decision. It is used as
+	// the message of the Approved or Denied condition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
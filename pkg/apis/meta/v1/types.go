@@ -76,4 +76,8 @@ type SecretKeySelector struct {
 const (
 	// Used as a data key in Secret resources to store a CA certificate.
 	TLSCAKey = "ca.crt"
+
+	// Used as a data key in Secret resources to store a DER encoded OCSP
+	// response for the certificate, suitable for OCSP stapling.
+	TLSOCSPStapleKey = "tls.ocsp-staple"
 )
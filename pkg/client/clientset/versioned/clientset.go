@@ -29,6 +29,7 @@ import (
 	certmanagerv1alpha2 "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1alpha2"
 	certmanagerv1alpha3 "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1alpha3"
 	certmanagerv1beta1 "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1beta1"
+	experimentalv1alpha1 "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/experimental/v1alpha1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -44,20 +45,22 @@ type Interface interface {
 	CertmanagerV1alpha3() certmanagerv1alpha3.CertmanagerV1alpha3Interface
 	CertmanagerV1beta1() certmanagerv1beta1.CertmanagerV1beta1Interface
 	CertmanagerV1() certmanagerv1.CertmanagerV1Interface
+	ExperimentalV1alpha1() experimentalv1alpha1.ExperimentalV1alpha1Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
 // version included in a Clientset.
 type Clientset struct {
 	*discovery.DiscoveryClient
-	acmeV1alpha2        *acmev1alpha2.AcmeV1alpha2Client
-	acmeV1alpha3        *acmev1alpha3.AcmeV1alpha3Client
-	acmeV1beta1         *acmev1beta1.AcmeV1beta1Client
-	acmeV1              *acmev1.AcmeV1Client
-	certmanagerV1alpha2 *certmanagerv1alpha2.CertmanagerV1alpha2Client
-	certmanagerV1alpha3 *certmanagerv1alpha3.CertmanagerV1alpha3Client
-	certmanagerV1beta1  *certmanagerv1beta1.CertmanagerV1beta1Client
-	certmanagerV1       *certmanagerv1.CertmanagerV1Client
+	acmeV1alpha2         *acmev1alpha2.AcmeV1alpha2Client
+	acmeV1alpha3         *acmev1alpha3.AcmeV1alpha3Client
+	acmeV1beta1          *acmev1beta1.AcmeV1beta1Client
+	acmeV1               *acmev1.AcmeV1Client
+	certmanagerV1alpha2  *certmanagerv1alpha2.CertmanagerV1alpha2Client
+	certmanagerV1alpha3  *certmanagerv1alpha3.CertmanagerV1alpha3Client
+	certmanagerV1beta1   *certmanagerv1beta1.CertmanagerV1beta1Client
+	certmanagerV1        *certmanagerv1.CertmanagerV1Client
+	experimentalV1alpha1 *experimentalv1alpha1.ExperimentalV1alpha1Client
 }
 
 // AcmeV1alpha2 retrieves the AcmeV1alpha2Client
@@ -100,6 +103,11 @@ func (c *Clientset) CertmanagerV1() certmanagerv1.CertmanagerV1Interface {
 	return c.certmanagerV1
 }
 
+// ExperimentalV1alpha1 retrieves the ExperimentalV1alpha1Client
+func (c *Clientset) ExperimentalV1alpha1() experimentalv1alpha1.ExperimentalV1alpha1Interface {
+	return c.experimentalV1alpha1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -153,6 +161,10 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.experimentalV1alpha1, err = experimentalv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
@@ -173,6 +185,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 	cs.certmanagerV1alpha3 = certmanagerv1alpha3.NewForConfigOrDie(c)
 	cs.certmanagerV1beta1 = certmanagerv1beta1.NewForConfigOrDie(c)
 	cs.certmanagerV1 = certmanagerv1.NewForConfigOrDie(c)
+	cs.experimentalV1alpha1 = experimentalv1alpha1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
 	return &cs
@@ -189,6 +202,7 @@ func New(c rest.Interface) *Clientset {
 	cs.certmanagerV1alpha3 = certmanagerv1alpha3.New(c)
 	cs.certmanagerV1beta1 = certmanagerv1beta1.New(c)
 	cs.certmanagerV1 = certmanagerv1.New(c)
+	cs.experimentalV1alpha1 = experimentalv1alpha1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -36,6 +36,8 @@ import (
 	fakecertmanagerv1alpha3 "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1alpha3/fake"
 	certmanagerv1beta1 "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1beta1"
 	fakecertmanagerv1beta1 "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1beta1/fake"
+	experimentalv1alpha1 "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/experimental/v1alpha1"
+	fakeexperimentalv1alpha1 "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/experimental/v1alpha1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -129,3 +131,8 @@ func (c *Clientset) CertmanagerV1beta1() certmanagerv1beta1.CertmanagerV1beta1In
 func (c *Clientset) CertmanagerV1() certmanagerv1.CertmanagerV1Interface {
 	return &fakecertmanagerv1.FakeCertmanagerV1{Fake: &c.Fake}
 }
+
+// ExperimentalV1alpha1 retrieves the ExperimentalV1alpha1Client
+func (c *Clientset) ExperimentalV1alpha1() experimentalv1alpha1.ExperimentalV1alpha1Interface {
+	return &fakeexperimentalv1alpha1.FakeExperimentalV1alpha1{Fake: &c.Fake}
+}
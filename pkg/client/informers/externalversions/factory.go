@@ -26,6 +26,7 @@ import (
 	versioned "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	acme "github.com/jetstack/cert-manager/pkg/client/informers/externalversions/acme"
 	certmanager "github.com/jetstack/cert-manager/pkg/client/informers/externalversions/certmanager"
+	experimental "github.com/jetstack/cert-manager/pkg/client/informers/externalversions/experimental"
 	internalinterfaces "github.com/jetstack/cert-manager/pkg/client/informers/externalversions/internalinterfaces"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -175,6 +176,7 @@ type SharedInformerFactory interface {
 
 	Acme() acme.Interface
 	Certmanager() certmanager.Interface
+	Experimental() experimental.Interface
 }
 
 func (f *sharedInformerFactory) Acme() acme.Interface {
@@ -184,3 +186,7 @@ func (f *sharedInformerFactory) Acme() acme.Interface {
 func (f *sharedInformerFactory) Certmanager() certmanager.Interface {
 	return certmanager.New(f, f.namespace, f.tweakListOptions)
 }
+
+func (f *sharedInformerFactory) Experimental() experimental.Interface {
+	return experimental.New(f, f.namespace, f.tweakListOptions)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/issuer"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/http"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/tlsalpn"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 )
 
 type controller struct {
@@ -57,8 +59,9 @@ type controller struct {
 	// ACME challenge solvers are instantiated once at the time of controller
 	// construction.
 	// This also allows for easy mocking of the different challenge mechanisms.
-	dnsSolver  solver
-	httpSolver solver
+	dnsSolver     solver
+	httpSolver    solver
+	tlsalpnSolver solver
 	// scheduler marks challenges as Processing=true if they can be scheduled
 	// for processing. This job runs periodically every N seconds, so it cannot
 	// be constructed as a traditional controller.
@@ -69,6 +72,8 @@ type controller struct {
 	// clientset used to update cert-manager API resources
 	cmClient cmclient.Interface
 
+	clock clock.Clock
+
 	// maintain a reference to the workqueue for this controller
 	// so the handleOwnedResource method can enqueue resources
 	queue workqueue.RateLimitingInterface
@@ -116,6 +121,8 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	if ctx.GatewaySolverEnabled {
 		gwAPIHTTPRouteInformer := ctx.GWShared.Networking().V1alpha1().HTTPRoutes()
 		mustSync = append(mustSync, gwAPIHTTPRouteInformer.Informer().HasSynced)
+		gwAPITLSRouteInformer := ctx.GWShared.Networking().V1alpha1().TLSRoutes()
+		mustSync = append(mustSync, gwAPITLSRouteInformer.Informer().HasSynced)
 	}
 
 	// set all the references to the listers for used by the Sync function
@@ -138,6 +145,7 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.scheduler = scheduler.New(logf.NewContext(ctx.RootContext, c.log), c.challengeLister, ctx.SchedulerOptions.MaxConcurrentChallenges)
 	c.recorder = ctx.Recorder
 	c.cmClient = ctx.CMClient
+	c.clock = ctx.Clock
 	c.accountRegistry = ctx.ACMEOptions.AccountRegistry
 
 	c.httpSolver, err = http.NewSolver(ctx)
@@ -148,6 +156,10 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	if err != nil {
 		return nil, nil, err
 	}
+	c.tlsalpnSolver, err = tlsalpn.NewSolver(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// read options from context
 	c.dns01Nameservers = ctx.ACMEOptions.DNS01Nameservers
@@ -19,6 +19,7 @@ package acmechallenges
 import (
 	"context"
 	"fmt"
+	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
@@ -81,6 +82,14 @@ func (c *controller) Sync(ctx context.Context, ch *cmacme.Challenge) (err error)
 		}
 	}()
 
+	// Requests held back or rejected due to rate limiting are retried once
+	// the rate limit has expired, rather than after applying back-off.
+	defer func() {
+		if until, ok := acmecl.RateLimitedUntil(err); ok {
+			err = c.requeueRateLimited(ch, until, err)
+		}
+	}()
+
 	// bail out early on if processing=false, as this challenge has not been
 	// scheduled yet.
 	if !ch.Status.Processing {
@@ -204,6 +213,23 @@ func (c *controller) Sync(ctx context.Context, ch *cmacme.Challenge) (err error)
 	return nil
 }
 
+// requeueRateLimited records on the challenge that requests to the ACME
+// server are held back due to rate limiting, and re-queues the challenge to be
+// processed again once the rate limit has expired.
+func (c *controller) requeueRateLimited(ch *cmacme.Challenge, until time.Time, err error) error {
+	ch.Status.Reason = err.Error()
+
+	key, keyErr := controllerpkg.KeyFunc(ch)
+	// This is an unexpected edge case and should never occur
+	if keyErr != nil {
+		return err
+	}
+
+	c.queue.AddAfter(key, until.Sub(c.clock.Now()))
+
+	return nil
+}
+
 // handleError will handle ACME error types, updating the challenge resource
 // with any new information found whilst inspecting the error response.
 // This may include marking the challenge as expired.
@@ -392,6 +418,8 @@ func (c *controller) solverFor(challengeType cmacme.ACMEChallengeType) (solver,
 		return c.httpSolver, nil
 	case cmacme.ACMEChallengeTypeDNS01:
 		return c.dnsSolver, nil
+	case cmacme.ACMEChallengeTypeTLSALPN01:
+		return c.tlsalpnSolver, nil
 	}
 	return nil, fmt.Errorf("no solver for %q implemented", challengeType)
 }
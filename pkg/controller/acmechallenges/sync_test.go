@@ -19,7 +19,9 @@ package acmechallenges
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -402,6 +404,54 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 		},
+		"record the rate limit on the challenge if accepting it is rate limited by the acme server": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(true),
+			),
+			httpSolver: &fakeSolver{
+				fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(true),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePresented(true),
+							gen.SetChallengeReason("rate limited by the ACME server until 2021-07-01T13:00:00Z: 429 urn:ietf:params:acme:error:rateLimited: too many failed authorizations recently"),
+						))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAccept: func(context.Context, *acmeapi.Challenge) (*acmeapi.Challenge, error) {
+					return nil, &acmecl.RateLimitedError{
+						Until: time.Date(2021, 7, 1, 13, 0, 0, 0, time.UTC),
+						Err: &acmeapi.Error{
+							StatusCode:  http.StatusTooManyRequests,
+							ProblemType: "urn:ietf:params:acme:error:rateLimited",
+							Detail:      "too many failed authorizations recently",
+						},
+					}
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
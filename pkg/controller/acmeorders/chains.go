@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+)
+
+// certificateChain is one of the certificate chains offered by the ACME
+// server for an Order: the issued certificate followed by its chain.
+type certificateChain struct {
+	// index is the position of the chain among the offered chains, 0 being
+	// the default chain.
+	index int
+	der   [][]byte
+	certs []*x509.Certificate
+}
+
+// parseCertificateChains parses the default chain and the alternate chains
+// returned by the ACME server.
+func parseCertificateChains(defaultChain [][]byte, altChains [][][]byte) ([]certificateChain, error) {
+	chains := make([]certificateChain, 0, len(altChains)+1)
+	for i, der := range append([][][]byte{defaultChain}, altChains...) {
+		certs := make([]*x509.Certificate, len(der))
+		for j, certDER := range der {
+			cert, err := x509.ParseCertificate(certDER)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing certificate %d of chain %d: %w", j, i, err)
+			}
+			certs[j] = cert
+		}
+		chains = append(chains, certificateChain{index: i, der: der, certs: certs})
+	}
+	return chains, nil
+}
+
+// matches returns true if the chain contains a certificate matching the
+// selector. The issued certificate itself is never matched, but the root
+// certificate that the last certificate of the chain is issued by is matched
+// by its common name, as ACME servers usually do not serve it.
+func (ch *certificateChain) matches(sel cmacme.ACMEChainCertificateSelector) bool {
+	if len(ch.certs) == 0 {
+		return false
+	}
+	if sel.CommonName != "" && ch.certs[len(ch.certs)-1].Issuer.CommonName == sel.CommonName {
+		return true
+	}
+	for _, cert := range ch.certs[1:] {
+		switch {
+		case sel.CommonName != "" && cert.Subject.CommonName == sel.CommonName:
+			return true
+		case sel.SHA256 != "" && strings.EqualFold(sha256Hex(cert.Raw), sel.SHA256):
+			return true
+		case sel.SPKISHA256 != "" && strings.EqualFold(sha256Hex(cert.RawSubjectPublicKeyInfo), sel.SPKISHA256):
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPreferredChain implements the legacy ACMEIssuer.PreferredChain
+// matching: an alternate chain is preferred if any of its certificates is
+// issued by a certificate with the given common name.
+func (ch *certificateChain) matchesPreferredChain(name string) bool {
+	if ch.index == 0 {
+		return false
+	}
+	for _, cert := range ch.certs {
+		if cert.Issuer.CommonName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// selectChain selects the chain to store on the Order among the chains
+// offered by the ACME server. The chains matching any of the exclude
+// selectors are discarded first. The remaining chains are then narrowed down
+// to the ones matching the first preferred selector that matches any of them,
+// and otherwise to the ones matching the legacy preferredChain. Finally,
+// either the shortest or the first of these chains is selected.
+// It returns nil if all chains are excluded.
+func selectChain(chains []certificateChain, sel *cmacme.ACMEChainSelection, preferredChain string) *certificateChain {
+	if sel == nil {
+		sel = &cmacme.ACMEChainSelection{}
+	}
+
+	var candidates []*certificateChain
+	for i := range chains {
+		excluded := false
+		for _, ex := range sel.Exclude {
+			if chains[i].matches(ex) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			candidates = append(candidates, &chains[i])
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	preferred := func(match func(*certificateChain) bool) bool {
+		var matched []*certificateChain
+		for _, ch := range candidates {
+			if match(ch) {
+				matched = append(matched, ch)
+			}
+		}
+		if len(matched) == 0 {
+			return false
+		}
+		candidates = matched
+		return true
+	}
+	found := false
+	for _, pref := range sel.Preferred {
+		pref := pref
+		if found = preferred(func(ch *certificateChain) bool { return ch.matches(pref) }); found {
+			break
+		}
+	}
+	if !found && preferredChain != "" {
+		preferred(func(ch *certificateChain) bool { return ch.matchesPreferredChain(preferredChain) })
+	}
+
+	selected := candidates[0]
+	if sel.PreferShortest {
+		for _, ch := range candidates[1:] {
+			if len(ch.certs) < len(selected.certs) {
+				selected = ch
+			}
+		}
+	}
+	return selected
+}
+
+// selectedChainStatus describes the selected chain for the Order status.
+func selectedChainStatus(ch *certificateChain) *cmacme.ACMESelectedChain {
+	status := &cmacme.ACMESelectedChain{Index: ch.index}
+	if len(ch.certs) < 2 {
+		return status
+	}
+	for _, cert := range ch.certs[1:] {
+		status.Certificates = append(status.Certificates, cmacme.ACMEChainCertificate{
+			CommonName: cert.Subject.CommonName,
+			SHA256:     sha256Hex(cert.Raw),
+			SPKISHA256: sha256Hex(cert.RawSubjectPublicKeyInfo),
+		})
+	}
+	return status
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+func mustSignCertificate(t *testing.T, cn string, isCA bool, pub crypto.PublicKey, parent *x509.Certificate, signer crypto.Signer) *x509.Certificate {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if parent == nil {
+		parent = template
+	}
+	_, cert, err := pki.SignCertificate(template, parent, pub, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func mustGenerateKey(t *testing.T) crypto.Signer {
+	t.Helper()
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func derChain(certs ...*x509.Certificate) [][]byte {
+	var der [][]byte
+	for _, cert := range certs {
+		der = append(der, cert.Raw)
+	}
+	return der
+}
+
+func TestSelectChain(t *testing.T) {
+	rootAKey, rootBKey, intKey, leafKey := mustGenerateKey(t), mustGenerateKey(t), mustGenerateKey(t), mustGenerateKey(t)
+	rootA := mustSignCertificate(t, "Root A", true, rootAKey.Public(), nil, rootAKey)
+	rootB := mustSignCertificate(t, "Root B", true, rootBKey.Public(), nil, rootBKey)
+	// rootA cross-signed by rootB
+	rootACross := mustSignCertificate(t, "Root A", true, rootAKey.Public(), rootB, rootBKey)
+	// the same intermediate key signed by both roots
+	intA := mustSignCertificate(t, "Intermediate", true, intKey.Public(), rootA, rootAKey)
+	intB := mustSignCertificate(t, "Intermediate", true, intKey.Public(), rootB, rootBKey)
+	leaf := mustSignCertificate(t, "example.com", false, leafKey.Public(), intA, intKey)
+
+	chains, err := parseCertificateChains(derChain(leaf, intA, rootACross), [][][]byte{
+		derChain(leaf, intA),
+		derChain(leaf, intB),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		selection      *cmacme.ACMEChainSelection
+		preferredChain string
+		expectedIndex  int
+		expectNone     bool
+	}{
+		"selects the default chain if nothing is configured": {
+			expectedIndex: 0,
+		},
+		"selects the first alternate chain matching the preferred chain": {
+			preferredChain: "Root A",
+			expectedIndex:  1,
+		},
+		"falls back to the default chain if no chain matches the preferred chain": {
+			preferredChain: "Root C",
+			expectedIndex:  0,
+		},
+		"selects the shortest chain": {
+			selection:     &cmacme.ACMEChainSelection{PreferShortest: true},
+			expectedIndex: 1,
+		},
+		"selects the chain containing the certificate fingerprint": {
+			selection: &cmacme.ACMEChainSelection{
+				Preferred: []cmacme.ACMEChainCertificateSelector{{SHA256: sha256Hex(intB.Raw)}},
+			},
+			expectedIndex: 2,
+		},
+		"selects the chains containing the SPKI fingerprint": {
+			selection: &cmacme.ACMEChainSelection{
+				Preferred:      []cmacme.ACMEChainCertificateSelector{{SPKISHA256: sha256Hex(intA.RawSubjectPublicKeyInfo)}},
+				PreferShortest: true,
+			},
+			expectedIndex: 1,
+		},
+		"matches the root certificate that is not served by its common name": {
+			selection: &cmacme.ACMEChainSelection{
+				Preferred: []cmacme.ACMEChainCertificateSelector{{CommonName: "Root B"}},
+			},
+			expectedIndex: 0,
+		},
+		"uses the first preferred selector matching any chain": {
+			selection: &cmacme.ACMEChainSelection{
+				Preferred: []cmacme.ACMEChainCertificateSelector{
+					{CommonName: "Root C"},
+					{SHA256: sha256Hex(intB.Raw)},
+					{CommonName: "Root A"},
+				},
+			},
+			expectedIndex: 2,
+		},
+		"only considers the preferred chain if no preferred selector matches": {
+			selection: &cmacme.ACMEChainSelection{
+				Preferred: []cmacme.ACMEChainCertificateSelector{{CommonName: "Root C"}},
+			},
+			preferredChain: "Root B",
+			expectedIndex:  2,
+		},
+		"does not select excluded chains": {
+			selection: &cmacme.ACMEChainSelection{
+				Exclude: []cmacme.ACMEChainCertificateSelector{{SHA256: sha256Hex(rootACross.Raw)}},
+			},
+			expectedIndex: 1,
+		},
+		"does not select excluded chains even if preferred": {
+			selection: &cmacme.ACMEChainSelection{
+				Preferred: []cmacme.ACMEChainCertificateSelector{{CommonName: "Root B"}},
+				Exclude:   []cmacme.ACMEChainCertificateSelector{{SHA256: sha256Hex(intB.Raw)}},
+			},
+			expectedIndex: 0,
+		},
+		"selects no chain if all chains are excluded": {
+			selection: &cmacme.ACMEChainSelection{
+				Exclude: []cmacme.ACMEChainCertificateSelector{{SPKISHA256: sha256Hex(intA.RawSubjectPublicKeyInfo)}},
+			},
+			expectNone: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			selected := selectChain(chains, test.selection, test.preferredChain)
+			if test.expectNone {
+				if selected != nil {
+					t.Errorf("expected no chain to be selected, got chain %d", selected.index)
+				}
+				return
+			}
+			if selected == nil {
+				t.Fatalf("expected chain %d to be selected, got none", test.expectedIndex)
+			}
+			if selected.index != test.expectedIndex {
+				t.Errorf("expected chain %d to be selected, got chain %d", test.expectedIndex, selected.index)
+			}
+		})
+	}
+}
+
+func TestSelectedChainStatus(t *testing.T) {
+	rootKey, leafKey := mustGenerateKey(t), mustGenerateKey(t)
+	root := mustSignCertificate(t, "Root", true, rootKey.Public(), nil, rootKey)
+	leaf := mustSignCertificate(t, "example.com", false, leafKey.Public(), root, rootKey)
+
+	chains, err := parseCertificateChains(derChain(leaf), [][][]byte{derChain(leaf, root)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &cmacme.ACMESelectedChain{
+		Index: 1,
+		Certificates: []cmacme.ACMEChainCertificate{
+			{
+				CommonName: "Root",
+				SHA256:     sha256Hex(root.Raw),
+				SPKISHA256: sha256Hex(root.RawSubjectPublicKeyInfo),
+			},
+		},
+	}
+	if status := selectedChainStatus(&chains[1]); !reflect.DeepEqual(status, expected) {
+		t.Errorf("unexpected selected chain status, exp=%+v got=%+v", expected, status)
+	}
+	if status := selectedChainStatus(&chains[0]); !reflect.DeepEqual(status, &cmacme.ACMESelectedChain{}) {
+		t.Errorf("expected no certificates for the default chain, got=%+v", status)
+	}
+}
@@ -19,7 +19,6 @@ package acmeorders
 import (
 	"bytes"
 	"context"
-	"crypto/x509"
 	"encoding/pem"
 	"fmt"
 	"time"
@@ -42,8 +41,9 @@ import (
 )
 
 const (
-	reasonSolver  = "Solver"
-	reasonCreated = "Created"
+	reasonSolver      = "Solver"
+	reasonCreated     = "Created"
+	reasonRateLimited = "RateLimited"
 )
 
 var (
@@ -74,6 +74,15 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		dbg.Info("updated Order resource status successfully")
 	}()
 
+	// Requests held back or rejected due to rate limiting are retried once
+	// the rate limit has expired rather than marking the Order as failed.
+	defer func() {
+		if until, ok := acmecl.RateLimitedUntil(err); ok {
+			c.setOrderRateLimited(ctx, o, until, err)
+			err = nil
+		}
+	}()
+
 	genericIssuer, err := c.helper.GetGenericIssuer(o.Spec.IssuerRef, o.Namespace)
 	if err != nil {
 		return fmt.Errorf("error reading (cluster)issuer %q: %v", o.Spec.IssuerRef.Name, err)
@@ -83,6 +92,16 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		return err
 	}
 
+	if o.Status.RateLimitedUntil != nil {
+		if until := o.Status.RateLimitedUntil.Time; c.clock.Now().Before(until) {
+			log.V(logf.DebugLevel).Info("Not processing Order as requests to the ACME server are rate limited", "until", until)
+			c.scheduleOrder(ctx, o, until.Sub(c.clock.Now()))
+			return nil
+		}
+		o.Status.RateLimitedUntil = nil
+		o.Status.Reason = ""
+	}
+
 	switch {
 	case o.Status.URL == "":
 		log.V(logf.DebugLevel).Info("Creating new ACME order as status.url is not set")
@@ -108,7 +127,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		return nil
 	case o.Status.State == cmacme.Valid && o.Status.Certificate == nil:
 		log.V(logf.DebugLevel).Info("Order is in a Valid state but the Certificate data is empty, fetching existing Certificate")
-		return c.fetchCertificateData(ctx, cl, o)
+		return c.fetchCertificateData(ctx, cl, o, genericIssuer)
 	case o.Status.State == cmacme.Valid && len(o.Status.Certificate) > 0:
 		log.V(logf.DebugLevel).Info("Order has already been completed, cleaning up any owned Challenge resources")
 		// if the Order is valid and the certificate data has been set, clean
@@ -268,7 +287,7 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 		}
 	}
 	if err != nil {
-		return fmt.Errorf("error creating new order: %v", err)
+		return fmt.Errorf("error creating new order: %w", err)
 	}
 	log.V(logf.DebugLevel).Info("submitted Order to ACME server")
 
@@ -315,6 +334,39 @@ func (c *controller) setOrderState(o *cmacme.OrderStatus, s string) {
 	}
 }
 
+// setOrderRateLimited records that requests for the given Order are held
+// back due to rate limiting until the given time, and schedules the Order to
+// be processed again once the rate limit has expired.
+func (c *controller) setOrderRateLimited(ctx context.Context, o *cmacme.Order, until time.Time, err error) {
+	log := logf.FromContext(ctx)
+
+	// the API only stores whole seconds, so round up to avoid retrying before
+	// the rate limit has expired
+	if rounded := until.Truncate(time.Second); rounded.Before(until) {
+		until = rounded.Add(time.Second)
+	}
+
+	log.V(logf.InfoLevel).Info("Requests to the ACME server are rate limited, waiting before retrying the Order", "until", until)
+	if o.Status.RateLimitedUntil == nil || !o.Status.RateLimitedUntil.Time.Equal(until) {
+		c.recorder.Eventf(o, corev1.EventTypeWarning, reasonRateLimited, "Rate limited by the ACME server until %s", until.UTC().Format(time.RFC3339))
+	}
+
+	o.Status.RateLimitedUntil = &metav1.Time{Time: until}
+	o.Status.Reason = err.Error()
+	c.scheduleOrder(ctx, o, until.Sub(c.clock.Now()))
+}
+
+// scheduleOrder schedules the given Order to be processed again after the
+// given duration.
+func (c *controller) scheduleOrder(ctx context.Context, o *cmacme.Order, after time.Duration) {
+	key, err := cache.MetaNamespaceKeyFunc(o)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to construct key for Order")
+		return
+	}
+	c.scheduledWorkQueue.Add(key, after)
+}
+
 // constructAuthorizations will construct a slice of ACMEAuthorizations must be
 // completed for the given ACME order.
 // It does *not* perform a query against the ACME server for each authorization
@@ -523,38 +575,49 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 		}
 	}
 	if errUpdate != nil {
-		return fmt.Errorf("error syncing order status: %v", errUpdate)
+		return fmt.Errorf("error syncing order status: %w", errUpdate)
 	}
 	// check for errors from FinalizeOrder
 	if err != nil {
-		return fmt.Errorf("error finalizing order: %v", err)
+		return fmt.Errorf("error finalizing order: %w", err)
 	}
 
-	if issuer.GetSpec().ACME != nil && issuer.GetSpec().ACME.PreferredChain != "" {
-		altBundles, err := cl.FetchCertAlternatives(ctx, certURL, true)
-		if err != nil {
-			return fmt.Errorf("error fetching alternate certificates: %w", err)
-		}
-		for _, altBundle := range altBundles {
-			for _, certPEM := range altBundle {
-				cert, err := x509.ParseCertificate(certPEM)
-				if err != nil {
-					return fmt.Errorf("error parsing alternate certificates: %w", err)
-				}
-
-				log.V(logf.DebugLevel).WithValues("Issuer CN", cert.Issuer.CommonName).Info("Found alternative ACME bundle")
-				if cert.Issuer.CommonName == issuer.GetSpec().ACME.PreferredChain {
-					// if the issuer's CN matched the preferred chain it means this bundle is
-					// signed by the requested chain
-					return c.storeCertificateOnStatus(ctx, o, altBundle)
-				}
-			}
-		}
-		// if no match is found we return to the actual cert
-		// it is a *preferred* chain after all
+	return c.storeSelectedChainOnStatus(ctx, cl, o, issuer, certURL, certSlice)
+}
+
+// storeSelectedChainOnStatus stores the certificate chain selected by the
+// issuer's chain selection and preferred chain among the default chain and
+// the alternate chains offered by the ACME server on the Order status.
+func (c *controller) storeSelectedChainOnStatus(ctx context.Context, cl acmecl.Interface, o *cmacme.Order, issuer cmapi.GenericIssuer, certURL string, certSlice [][]byte) error {
+	log := logf.FromContext(ctx)
+
+	acmeSpec := issuer.GetSpec().ACME
+	if acmeSpec == nil || (acmeSpec.ChainSelection == nil && acmeSpec.PreferredChain == "") {
+		return c.storeCertificateOnStatus(ctx, o, certSlice)
 	}
 
-	return c.storeCertificateOnStatus(ctx, o, certSlice)
+	altBundles, err := cl.FetchCertAlternatives(ctx, certURL, true)
+	if err != nil {
+		return fmt.Errorf("error fetching alternate certificates: %w", err)
+	}
+	chains, err := parseCertificateChains(certSlice, altBundles)
+	if err != nil {
+		return fmt.Errorf("error parsing alternate certificates: %w", err)
+	}
+
+	selected := selectChain(chains, acmeSpec.ChainSelection, acmeSpec.PreferredChain)
+	if selected == nil {
+		log.V(logf.WarnLevel).Info("all certificate chains offered by the ACME server are excluded by the issuer's chain selection")
+		c.setOrderState(&o.Status, string(cmacme.Errored))
+		o.Status.Reason = "All certificate chains offered by the ACME server are excluded by the issuer's chain selection"
+		return nil
+	}
+	log.V(logf.DebugLevel).Info("Selected ACME certificate chain", "index", selected.index, "chains", len(chains))
+
+	if acmeSpec.ChainSelection != nil {
+		o.Status.SelectedChain = selectedChainStatus(selected)
+	}
+	return c.storeCertificateOnStatus(ctx, o, selected.der)
 }
 
 func (c *controller) storeCertificateOnStatus(ctx context.Context, o *cmacme.Order, certs [][]byte) error {
@@ -577,7 +640,7 @@ func (c *controller) storeCertificateOnStatus(ctx context.Context, o *cmacme.Ord
 	return nil
 }
 
-func (c *controller) fetchCertificateData(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) error {
+func (c *controller) fetchCertificateData(ctx context.Context, cl acmecl.Interface, o *cmacme.Order, issuer cmapi.GenericIssuer) error {
 	log := logf.FromContext(ctx)
 	acmeOrder, err := c.updateOrderStatus(ctx, cl, o)
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
@@ -616,7 +679,7 @@ func (c *controller) fetchCertificateData(ctx context.Context, cl acmecl.Interfa
 		return err
 	}
 
-	err = c.storeCertificateOnStatus(ctx, o, certs)
+	err = c.storeSelectedChainOnStatus(ctx, cl, o, issuer, acmeOrder.CertURL, certs)
 	if err != nil {
 		return err
 	}
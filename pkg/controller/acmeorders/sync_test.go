@@ -18,9 +18,11 @@ package acmeorders
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -81,6 +83,43 @@ func TestSyncHappyPath(t *testing.T) {
 		},
 	}))
 
+	testIssuerHTTP01TestComChainSelection := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		ChainSelection: &cmacme.ACMEChainSelection{
+			Preferred: []cmacme.ACMEChainCertificateSelector{
+				// SPKI of "Let's Encrypt Authority X3"
+				{SPKISHA256: "60b87575447dcba2a36b7d11ac09fb24a9db406fee12d2cc90180517616e8a18"},
+			},
+		},
+		Solvers: []cmacme.ACMEChallengeSolver{
+			{
+				Selector: &cmacme.CertificateDNSNameSelector{
+					DNSNames: []string{"test.com"},
+				},
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				},
+			},
+		},
+	}))
+
+	testIssuerHTTP01TestComChainSelectionExcludeAll := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		ChainSelection: &cmacme.ACMEChainSelection{
+			Exclude: []cmacme.ACMEChainCertificateSelector{
+				{CommonName: "ISRG Root X1"},
+			},
+		},
+		Solvers: []cmacme.ACMEChallengeSolver{
+			{
+				Selector: &cmacme.CertificateDNSNameSelector{
+					DNSNames: []string{"test.com"},
+				},
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				},
+			},
+		},
+	}))
+
 	testOrder := gen.Order("testorder",
 		gen.SetOrderCommonName("test.com"),
 		gen.SetOrderIssuer(cmmeta.ObjectReference{
@@ -162,6 +201,25 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	testOrderValidAltCert.Status.State = cmacme.Valid
 	testOrderValidAltCert.Status.Certificate = testCert
 
+	testOrderValidSelectedChain := gen.OrderFrom(testOrder, gen.SetOrderStatus(pendingStatus))
+	testOrderValidSelectedChain.Status.State = cmacme.Valid
+	testOrderValidSelectedChain.Status.Certificate = append(append([]byte{}, testCert...), testCert...)
+	testOrderValidSelectedChain.Status.SelectedChain = &cmacme.ACMESelectedChain{
+		Index: 1,
+		Certificates: []cmacme.ACMEChainCertificate{
+			{
+				CommonName: "Let's Encrypt Authority X3",
+				SHA256:     fmt.Sprintf("%x", sha256.Sum256(rawTestCert.Bytes)),
+				SPKISHA256: "60b87575447dcba2a36b7d11ac09fb24a9db406fee12d2cc90180517616e8a18",
+			},
+		},
+	}
+
+	testOrderAllChainsExcluded := gen.OrderFrom(testOrder, gen.SetOrderStatus(pendingStatus))
+	testOrderAllChainsExcluded.Status.State = cmacme.Errored
+	testOrderAllChainsExcluded.Status.FailureTime = &nowMetaTime
+	testOrderAllChainsExcluded.Status.Reason = "All certificate chains offered by the ACME server are excluded by the issuer's chain selection"
+
 	fakeHTTP01ACMECl := &acmecl.FakeACME{
 		FakeHTTP01ChallengeResponse: func(s string) (string, error) {
 			// TODO: assert s = "token"
@@ -216,7 +274,80 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	*testACMEOrderInvalid = *testACMEOrderPending
 	testACMEOrderInvalid.Status = acmeapi.StatusInvalid
 
+	rateLimitedUntil := metav1.NewTime(nowTime.Truncate(time.Second).Add(time.Hour))
+	rateLimitedErr := &acmecl.RateLimitedError{
+		Until: rateLimitedUntil.Time,
+		Err: &acmeapi.Error{
+			StatusCode:  http.StatusTooManyRequests,
+			ProblemType: "urn:ietf:params:acme:error:rateLimited",
+			Detail:      "too many new orders recently",
+		},
+	}
+	testOrderRateLimited := gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+		RateLimitedUntil: &rateLimitedUntil,
+		Reason:           fmt.Sprintf("error creating new order: %v", rateLimitedErr),
+	}))
+	testOrderRateLimitExpired := gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+		RateLimitedUntil: &metav1.Time{Time: nowTime.Add(-time.Minute)},
+		Reason:           fmt.Sprintf("error creating new order: %v", rateLimitedErr),
+	}))
+
 	tests := map[string]testT{
+		"mark the order as rate limited and re-queue it if the acme server rate limits creating the order": {
+			order: testOrder,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderRateLimited.Namespace, testOrderRateLimited)),
+				},
+				ExpectedEvents: []string{
+					fmt.Sprintf("Warning RateLimited Rate limited by the ACME server until %s", rateLimitedUntil.UTC().Format(time.RFC3339)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, rateLimitedErr
+				},
+			},
+			shouldSchedule: true,
+		},
+		"do not call the acme server and re-queue the order while it is rate limited": {
+			order: testOrderRateLimited,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderRateLimited},
+				ExpectedActions:    []testpkg.Action{},
+			},
+			acmeClient:     &acmecl.FakeACME{},
+			shouldSchedule: true,
+		},
+		"clear the rate limit and create the order once the rate limit has expired": {
+			order: testOrderRateLimitExpired,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderRateLimitExpired},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Pending,
+							URL:         "http://testurl.com/abcde",
+							FinalizeURL: "http://testurl.com/abcde/finalize",
+							Authorizations: []cmacme.ACMEAuthorization{
+								{
+									URL: "http://authzurl",
+								},
+							},
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return testACMEOrderPending, nil
+				},
+			},
+		},
 		"create a new order with the acme server, set the order url on the status resource and return nil to avoid cache timing issues": {
 			order: testOrder,
 			builder: &testpkg.Builder{
@@ -526,8 +657,7 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 					return testACMEOrderValid, nil
 				},
 				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
-					testData := []byte("test")
-					return [][]byte{testData}, "http://testurl", nil
+					return [][]byte{rawTestCert.Bytes}, "http://testurl", nil
 				},
 				FakeFetchCertAlternatives: func(_ context.Context, url string, bundle bool) ([][][]byte, error) {
 					if url != "http://testurl" {
@@ -542,6 +672,59 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"call FinalizeOrder and store the chain selected by the issuer chain selection": {
+			order: testOrderReady.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestComChainSelection, testOrderReady, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderValid.Namespace, testOrderValidSelectedChain)),
+				},
+				ExpectedEvents: []string{
+					"Normal Complete Order completed successfully",
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderValid, nil
+				},
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					return [][]byte{rawTestCert.Bytes}, "http://testurl", nil
+				},
+				FakeFetchCertAlternatives: func(_ context.Context, url string, bundle bool) ([][][]byte, error) {
+					return [][][]byte{{rawTestCert.Bytes, rawTestCert.Bytes}}, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
+		"call FinalizeOrder and mark the order as errored if all chains are excluded": {
+			order: testOrderReady.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestComChainSelectionExcludeAll, testOrderReady, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderValid.Namespace, testOrderAllChainsExcluded)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderValid, nil
+				},
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					return [][]byte{rawTestCert.Bytes}, "http://testurl", nil
+				},
+				FakeFetchCertAlternatives: func(_ context.Context, url string, bundle bool) ([][][]byte, error) {
+					return [][][]byte{{rawTestCert.Bytes}}, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
 		"call GetOrder and update the order state if the challenge is 'failed'": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
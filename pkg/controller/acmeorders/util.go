@@ -108,6 +108,8 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 				return &ch
 			case ch.Type == "dns-01" && solver.DNS01 != nil:
 				return &ch
+			case ch.Type == "tls-alpn-01" && solver.TLSALPN01 != nil:
+				return &ch
 			}
 		}
 		return nil
@@ -252,8 +254,8 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 	}
 
 	// It should never be possible for this case to be hit as earlier in this
-	// method we already assert that the challenge type is one of 'http-01'
-	// or 'dns-01'.
+	// method we already assert that the challenge type is one of 'http-01',
+	// 'dns-01' or 'tls-alpn-01'.
 	chType, err := challengeType(selectedChallenge.Type)
 	if err != nil {
 		return nil, err
@@ -291,6 +293,8 @@ func challengeType(t string) (cmacme.ACMEChallengeType, error) {
 		return cmacme.ACMEChallengeTypeHTTP01, nil
 	case "dns-01":
 		return cmacme.ACMEChallengeTypeDNS01, nil
+	case "tls-alpn-01":
+		return cmacme.ACMEChallengeTypeTLSALPN01, nil
 	default:
 		return "", fmt.Errorf("unsupported challenge type: %v", t)
 	}
@@ -328,6 +332,11 @@ func keyForChallenge(cl acmecl.Interface, token string, chType cmacme.ACMEChalle
 		return cl.HTTP01ChallengeResponse(token)
 	case cmacme.ACMEChallengeTypeDNS01:
 		return cl.DNS01ChallengeRecord(token)
+	case cmacme.ACMEChallengeTypeTLSALPN01:
+		// the TLS-ALPN-01 challenge certificate is built from the same key
+		// authorization as the HTTP01 challenge response, see RFC 8737
+		// section 3.
+		return cl.HTTP01ChallengeResponse(token)
 	default:
 		return "", fmt.Errorf("unsupported challenge type: %v", chType)
 	}
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/kr/pretty"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
@@ -56,6 +57,13 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 			},
 		},
 	}
+	emptySelectorSolverTLSALPN01 := cmacme.ACMEChallengeSolver{
+		TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{
+			Service: &cmacme.ACMEChallengeSolverTLSALPN01Service{
+				Type: corev1.ServiceTypeLoadBalancer,
+			},
+		},
+	}
 	nonMatchingSelectorSolver := cmacme.ACMEChallengeSolver{
 		Selector: &cmacme.CertificateDNSNameSelector{
 			MatchLabels: map[string]string{
@@ -88,6 +96,10 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 		Type:  "dns-01",
 		Token: "dns-01-token",
 	}
+	acmeChallengeTLSALPN01 := &cmacme.ACMEChallenge{
+		Type:  "tls-alpn-01",
+		Token: "tls-alpn-01-token",
+	}
 
 	tests := map[string]struct {
 		acmeClient acmecl.Interface
@@ -371,6 +383,37 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 				Solver:  emptySelectorSolverDNS01,
 			},
 		},
+		"should use TLSALPN01 solver if challenge is of type TLSALPN01": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								emptySelectorSolverHTTP01,
+								emptySelectorSolverTLSALPN01,
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeTLSALPN01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeTLSALPN01,
+				DNSName: "example.com",
+				Token:   acmeChallengeTLSALPN01.Token,
+				Key:     "http01",
+				Solver:  emptySelectorSolverTLSALPN01,
+			},
+		},
 		"should return an error if none match": {
 			acmeClient: basicACMEClient,
 			issuer: &v1.Issuer{
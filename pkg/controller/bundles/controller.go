@@ -0,0 +1,287 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundles
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmexperimental "github.com/jetstack/cert-manager/pkg/apis/experimental/v1alpha1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	cmexperimentallisters "github.com/jetstack/cert-manager/pkg/client/listers/experimental/v1alpha1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+)
+
+const ControllerName = "bundles"
+
+// This controller builds trust bundles from the sources listed on Bundle
+// resources, and writes them to a ConfigMap named after the Bundle in every
+// namespace selected by the Bundle.
+// Bundles are re-synced whenever one of their sources, a namespace, or one of
+// the ConfigMaps they manage changes.
+type controller struct {
+	bundleLister        cmexperimentallisters.BundleLister
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	secretLister        corelisters.SecretLister
+	configMapLister     corelisters.ConfigMapLister
+	namespaceLister     corelisters.NamespaceLister
+	kubeClient          kubernetes.Interface
+	cmClient            cmclient.Interface
+	recorder            record.EventRecorder
+	issuerOptions       controllerpkg.IssuerOptions
+
+	log   logr.Logger
+	queue workqueue.RateLimitingInterface
+}
+
+// NewController returns a Bundle controller. ClusterIssuers can only be
+// used as sources if watchClusterIssuers is true, i.e. when cert-manager has
+// not been scoped to a single namespace.
+func NewController(
+	log logr.Logger,
+	kubeClient kubernetes.Interface,
+	cmClient cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	issuerOptions controllerpkg.IssuerOptions,
+	watchClusterIssuers bool,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	bundleInformer := cmFactory.Experimental().V1alpha1().Bundles()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	secretsInformer := factory.Core().V1().Secrets()
+	configMapsInformer := factory.Core().V1().ConfigMaps()
+	namespacesInformer := factory.Core().V1().Namespaces()
+
+	c := &controller{
+		bundleLister:    bundleInformer.Lister(),
+		issuerLister:    issuerInformer.Lister(),
+		secretLister:    secretsInformer.Lister(),
+		configMapLister: configMapsInformer.Lister(),
+		namespaceLister: namespacesInformer.Lister(),
+		kubeClient:      kubeClient,
+		cmClient:        cmClient,
+		recorder:        recorder,
+		issuerOptions:   issuerOptions,
+		log:             log,
+		queue:           queue,
+	}
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		bundleInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+		configMapsInformer.Informer().HasSynced,
+		namespacesInformer.Informer().HasSynced,
+	}
+
+	bundleInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
+	if watchClusterIssuers {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
+		c.clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleSecret})
+	configMapsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleConfigMap})
+	// Namespaces being created or relabelled may change the set of target
+	// namespaces of any Bundle.
+	namespacesInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: func(interface{}) {
+		c.enqueueBundles(func(*cmexperimental.Bundle) bool { return true })
+	}})
+
+	return c, queue, mustSync
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	bundle, err := c.bundleLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("bundle not found for key")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithResource(log, bundle))
+	return c.sync(ctx, bundle)
+}
+
+// handleGenericIssuer enqueues all Bundles that use the given Issuer or
+// ClusterIssuer as a source.
+func (c *controller) handleGenericIssuer(obj interface{}) {
+	iss, ok := obj.(cmapi.GenericIssuer)
+	if !ok {
+		c.log.Error(nil, "object does not implement GenericIssuer")
+		return
+	}
+	_, isClusterIssuer := iss.(*cmapi.ClusterIssuer)
+
+	c.enqueueBundles(func(bundle *cmexperimental.Bundle) bool {
+		for _, source := range bundle.Spec.Sources {
+			ref := source.Issuer
+			if ref == nil || ref.Name != iss.GetObjectMeta().Name {
+				continue
+			}
+			if isClusterIssuer == (ref.Kind == cmapi.ClusterIssuerKind) && ref.Namespace == iss.GetObjectMeta().Namespace {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// handleSecret enqueues all Bundles that use the given Secret as a source,
+// either directly or as the CA of an issuer.
+func (c *controller) handleSecret(obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		c.log.Error(nil, "object is not a Secret")
+		return
+	}
+
+	c.enqueueBundles(func(bundle *cmexperimental.Bundle) bool {
+		for _, source := range bundle.Spec.Sources {
+			switch {
+			case source.Secret != nil:
+				if secret.Namespace == c.issuerOptions.ClusterResourceNamespace && secret.Name == source.Secret.Name {
+					return true
+				}
+			case source.Issuer != nil:
+				iss, err := c.issuerForSource(source.Issuer)
+				if err != nil || iss.GetSpec().CA == nil {
+					continue
+				}
+				if secret.Namespace == c.issuerOptions.ResourceNamespace(iss) && kube.CAIssuerUsesSecret(iss.GetSpec().CA, secret.Name) {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+// handleConfigMap enqueues the Bundle that manages the given ConfigMap, if
+// any, and all Bundles that use it as a source.
+func (c *controller) handleConfigMap(obj interface{}) {
+	configMap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		c.log.Error(nil, "object is not a ConfigMap")
+		return
+	}
+
+	if name, ok := configMap.Labels[cmexperimental.BundleLabelKey]; ok {
+		c.queue.Add(name)
+	}
+
+	if configMap.Namespace != c.issuerOptions.ClusterResourceNamespace {
+		return
+	}
+	c.enqueueBundles(func(bundle *cmexperimental.Bundle) bool {
+		for _, source := range bundle.Spec.Sources {
+			if source.ConfigMap != nil && source.ConfigMap.Name == configMap.Name {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// enqueueBundles enqueues all Bundles for which the given function returns
+// true.
+func (c *controller) enqueueBundles(match func(*cmexperimental.Bundle) bool) {
+	bundles, err := c.bundleLister.List(labels.Everything())
+	if err != nil {
+		c.log.Error(err, "failed listing Bundle resources")
+		return
+	}
+	for _, bundle := range bundles {
+		if !match(bundle) {
+			continue
+		}
+		key, err := controllerpkg.KeyFunc(bundle)
+		if err != nil {
+			c.log.Error(err, "error determining 'key' for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.Client,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.IssuerOptions,
+		ctx.Namespace == "",
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
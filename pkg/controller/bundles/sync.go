@@ -0,0 +1,446 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundles
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmexperimental "github.com/jetstack/cert-manager/pkg/apis/experimental/v1alpha1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	cmerrors "github.com/jetstack/cert-manager/pkg/util/errors"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	reasonSynced       = "Synced"
+	reasonSourceError  = "SourceError"
+	reasonTargetError  = "TargetError"
+	reasonEncodeFailed = "EncodeFailed"
+)
+
+// bundleHashAnnotationKey is the annotation on target ConfigMaps that
+// records a hash of the bundle and truststore configuration they were
+// written with. Truststores are not encoded deterministically, so this is
+// used to decide whether a ConfigMap is up to date.
+const bundleHashAnnotationKey = "experimental.cert-manager.io/bundle-hash"
+
+// sourceError is returned when a source of a Bundle cannot be read because
+// it is missing or invalid. The Bundle will be re-synced once the source
+// changes.
+type sourceError struct {
+	err error
+}
+
+func (e sourceError) Error() string {
+	return e.err.Error()
+}
+
+func (c *controller) sync(ctx context.Context, bundle *cmexperimental.Bundle) error {
+	log := logf.FromContext(ctx)
+
+	bundlePEM, err := c.buildBundle(ctx, bundle)
+	if _, ok := err.(sourceError); ok {
+		c.recorder.Eventf(bundle, corev1.EventTypeWarning, reasonSourceError, "Failed to build bundle: %v", err)
+		return c.updateStatus(ctx, bundle, cmmeta.ConditionFalse, reasonSourceError, fmt.Sprintf("Failed to build bundle: %v", err), bundle.Status.Namespaces)
+	}
+	if err != nil {
+		return err
+	}
+
+	configMap, err := encodeBundle(bundle, bundlePEM)
+	if err != nil {
+		c.recorder.Eventf(bundle, corev1.EventTypeWarning, reasonEncodeFailed, "Failed to encode bundle: %v", err)
+		return c.updateStatus(ctx, bundle, cmmeta.ConditionFalse, reasonEncodeFailed, fmt.Sprintf("Failed to encode bundle: %v", err), bundle.Status.Namespaces)
+	}
+
+	namespaces, err := c.targetNamespaces(bundle)
+	if err != nil {
+		c.recorder.Eventf(bundle, corev1.EventTypeWarning, reasonTargetError, "Invalid namespace selector: %v", err)
+		return c.updateStatus(ctx, bundle, cmmeta.ConditionFalse, reasonTargetError, fmt.Sprintf("Invalid namespace selector: %v", err), bundle.Status.Namespaces)
+	}
+
+	var conflicts []string
+	written := 0
+	for _, namespace := range namespaces {
+		updated, err := c.writeConfigMap(ctx, bundle, namespace, configMap)
+		if _, ok := err.(conflictError); ok {
+			conflicts = append(conflicts, namespace)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if updated {
+			written++
+		}
+	}
+
+	if err := c.removeStaleConfigMaps(ctx, bundle, namespaces); err != nil {
+		return err
+	}
+
+	if written > 0 {
+		log.V(logf.InfoLevel).Info("wrote bundle to namespaces", "count", written)
+		c.recorder.Eventf(bundle, corev1.EventTypeNormal, reasonSynced, "Wrote bundle with %d certificate(s) to %d namespace(s)", bytes.Count(bundlePEM, []byte("-----BEGIN CERTIFICATE-----")), written)
+	}
+
+	synced := int32(len(namespaces) - len(conflicts))
+	if len(conflicts) > 0 {
+		message := fmt.Sprintf("ConfigMap %q exists and is not managed by this Bundle in namespace(s): %s", bundle.Name, strings.Join(conflicts, ", "))
+		c.recorder.Event(bundle, corev1.EventTypeWarning, reasonTargetError, message)
+		return c.updateStatus(ctx, bundle, cmmeta.ConditionFalse, reasonTargetError, message, synced)
+	}
+
+	return c.updateStatus(ctx, bundle, cmmeta.ConditionTrue, reasonSynced, fmt.Sprintf("Bundle written to %d namespace(s)", synced), synced)
+}
+
+// buildBundle reads the CA certificates from all sources of the Bundle and
+// returns them PEM encoded, without duplicates, in the order of the sources.
+func (c *controller) buildBundle(ctx context.Context, bundle *cmexperimental.Bundle) ([]byte, error) {
+	var bundlePEM []byte
+	seen := make(map[[sha256.Size]byte]bool)
+	for i, source := range bundle.Spec.Sources {
+		sourceCerts, err := c.sourceCertificates(ctx, source)
+		if err != nil {
+			if _, ok := err.(sourceError); ok {
+				return nil, sourceError{fmt.Errorf("spec.sources[%d]: %v", i, err)}
+			}
+			return nil, err
+		}
+		for _, cert := range sourceCerts {
+			fingerprint := sha256.Sum256(cert.Raw)
+			if seen[fingerprint] {
+				continue
+			}
+			seen[fingerprint] = true
+			certPEM, err := pki.EncodeX509(cert)
+			if err != nil {
+				return nil, err
+			}
+			bundlePEM = append(bundlePEM, certPEM...)
+		}
+	}
+	if len(bundlePEM) == 0 {
+		return nil, sourceError{fmt.Errorf("no certificates found in sources")}
+	}
+
+	return bundlePEM, nil
+}
+
+// sourceCertificates returns the CA certificates of a single source.
+func (c *controller) sourceCertificates(ctx context.Context, source cmexperimental.BundleSource) ([]*x509.Certificate, error) {
+	set := 0
+	for _, isSet := range []bool{source.Secret != nil, source.ConfigMap != nil, source.Issuer != nil, source.InLine != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, sourceError{fmt.Errorf("exactly one of secret, configMap, issuer or inLine must be specified")}
+	}
+
+	namespace := c.issuerOptions.ClusterResourceNamespace
+	switch {
+	case source.Secret != nil:
+		key := source.Secret.Key
+		if key == "" {
+			key = cmmeta.TLSCAKey
+		}
+		secret, err := c.secretLister.Secrets(namespace).Get(source.Secret.Name)
+		if apierrors.IsNotFound(err) {
+			return nil, sourceError{fmt.Errorf("secret %s/%s not found", namespace, source.Secret.Name)}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return decodeSourceCertificates(secret.Data[key], fmt.Sprintf("key %q of secret %s/%s", key, namespace, source.Secret.Name))
+
+	case source.ConfigMap != nil:
+		configMap, err := c.configMapLister.ConfigMaps(namespace).Get(source.ConfigMap.Name)
+		if apierrors.IsNotFound(err) {
+			return nil, sourceError{fmt.Errorf("configmap %s/%s not found", namespace, source.ConfigMap.Name)}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return decodeSourceCertificates([]byte(configMap.Data[source.ConfigMap.Key]), fmt.Sprintf("key %q of configmap %s/%s", source.ConfigMap.Key, namespace, source.ConfigMap.Name))
+
+	case source.Issuer != nil:
+		return c.issuerCertificates(ctx, source.Issuer)
+
+	default:
+		return decodeSourceCertificates([]byte(*source.InLine), "inLine")
+	}
+}
+
+// issuerCertificates returns the root CA certificate of a CA issuer, that
+// is the last certificate of the chain it appends to signed certificates.
+func (c *controller) issuerCertificates(ctx context.Context, ref *cmexperimental.BundleIssuerReference) ([]*x509.Certificate, error) {
+	iss, err := c.issuerForSource(ref)
+	if apierrors.IsNotFound(err) {
+		return nil, sourceError{fmt.Errorf("%s not found", describeIssuer(ref))}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if iss.GetSpec().CA == nil {
+		return nil, sourceError{fmt.Errorf("%s is not a CA issuer", describeIssuer(ref))}
+	}
+
+	namespace := c.issuerOptions.ResourceNamespace(iss)
+	secretName := iss.GetSpec().CA.SecretName
+	certs, err := kube.SecretCAIssuerChain(ctx, c.secretLister, namespace, iss.GetSpec().CA)
+	if apierrors.IsNotFound(err) || cmerrors.IsInvalidData(err) {
+		return nil, sourceError{fmt.Errorf("failed to read CA of %s from secret %s/%s: %v", describeIssuer(ref), namespace, secretName, err)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := pki.ParseSingleCertificateChain(certs)
+	if err != nil {
+		return nil, sourceError{fmt.Errorf("invalid CA of %s in secret %s/%s: %v", describeIssuer(ref), namespace, secretName, err)}
+	}
+	return pki.DecodeX509CertificateChainBytes(bundle.CAPEM)
+}
+
+// issuerForSource returns the Issuer or ClusterIssuer referenced by a source.
+func (c *controller) issuerForSource(ref *cmexperimental.BundleIssuerReference) (cmapi.GenericIssuer, error) {
+	if ref.Kind == cmapi.ClusterIssuerKind {
+		if c.clusterIssuerLister == nil {
+			return nil, sourceError{fmt.Errorf("cannot get ClusterIssuer named %q as cert-manager is scoped to a single namespace", ref.Name)}
+		}
+		return c.clusterIssuerLister.Get(ref.Name)
+	}
+	return c.issuerLister.Issuers(ref.Namespace).Get(ref.Name)
+}
+
+// describeIssuer returns a human readable description of the issuer
+// referenced by a source, for use in messages.
+func describeIssuer(ref *cmexperimental.BundleIssuerReference) string {
+	if ref.Kind == cmapi.ClusterIssuerKind {
+		return fmt.Sprintf("%s %q", cmapi.ClusterIssuerKind, ref.Name)
+	}
+	return fmt.Sprintf("%s %q", cmapi.IssuerKind, ref.Namespace+"/"+ref.Name)
+}
+
+// decodeSourceCertificates decodes the PEM encoded certificates read from a
+// source, described by desc.
+func decodeSourceCertificates(data []byte, desc string) ([]*x509.Certificate, error) {
+	if len(data) == 0 {
+		return nil, sourceError{fmt.Errorf("no data for %s", desc)}
+	}
+	certs, err := pki.DecodeX509CertificateChainBytes(data)
+	if err != nil {
+		return nil, sourceError{fmt.Errorf("failed to decode certificates in %s: %v", desc, err)}
+	}
+	return certs, nil
+}
+
+// encodeBundle returns the ConfigMap data for the given PEM encoded bundle,
+// including the truststores configured on the Bundle. The hash annotation of
+// the returned ConfigMap is set.
+func encodeBundle(bundle *cmexperimental.Bundle, bundlePEM []byte) (*corev1.ConfigMap, error) {
+	target := bundle.Spec.Target
+	configMap := &corev1.ConfigMap{
+		Data: map[string]string{target.ConfigMap.Key: string(bundlePEM)},
+	}
+
+	hash := sha256.New()
+	hash.Write(bundlePEM)
+
+	if formats := target.AdditionalFormats; formats != nil {
+		if formats.JKS != nil {
+			password := truststorePassword(formats.JKS)
+			data, err := pki.EncodeJKSTruststore([]byte(password), bundlePEM)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode JKS truststore: %v", err)
+			}
+			setBinaryData(configMap, formats.JKS.Key, data)
+			fmt.Fprintf(hash, "\x00jks\x00%s\x00%s", formats.JKS.Key, password)
+		}
+		if formats.PKCS12 != nil {
+			password := truststorePassword(formats.PKCS12)
+			data, err := pki.EncodePKCS12Truststore(password, bundlePEM)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode PKCS#12 truststore: %v", err)
+			}
+			setBinaryData(configMap, formats.PKCS12.Key, data)
+			fmt.Fprintf(hash, "\x00pkcs12\x00%s\x00%s", formats.PKCS12.Key, password)
+		}
+	}
+
+	configMap.Annotations = map[string]string{bundleHashAnnotationKey: hex.EncodeToString(hash.Sum(nil))}
+	return configMap, nil
+}
+
+func setBinaryData(configMap *corev1.ConfigMap, key string, data []byte) {
+	if configMap.BinaryData == nil {
+		configMap.BinaryData = make(map[string][]byte)
+	}
+	configMap.BinaryData[key] = data
+}
+
+func truststorePassword(truststore *cmexperimental.BundleTruststore) string {
+	if truststore.Password == nil {
+		return cmexperimental.DefaultBundleTruststorePassword
+	}
+	return *truststore.Password
+}
+
+// targetNamespaces returns the sorted names of the namespaces selected by
+// the Bundle.
+func (c *controller) targetNamespaces(bundle *cmexperimental.Bundle) ([]string, error) {
+	selector := labels.Everything()
+	if bundle.Spec.Target.NamespaceSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(bundle.Spec.Target.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	namespaces, err := c.namespaceLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, namespace := range namespaces {
+		if namespace.DeletionTimestamp != nil {
+			continue
+		}
+		names = append(names, namespace.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// conflictError is returned when a ConfigMap with the name of a Bundle
+// exists in a target namespace but is not managed by the Bundle.
+type conflictError struct{}
+
+func (conflictError) Error() string {
+	return "configmap is not managed by this bundle"
+}
+
+// writeConfigMap creates or updates the ConfigMap of the Bundle in the given
+// namespace, unless it is up to date. It returns whether the ConfigMap was
+// written.
+func (c *controller) writeConfigMap(ctx context.Context, bundle *cmexperimental.Bundle, namespace string, desired *corev1.ConfigMap) (bool, error) {
+	existing, err := c.configMapLister.ConfigMaps(namespace).Get(bundle.Name)
+	if apierrors.IsNotFound(err) {
+		configMap := desired.DeepCopy()
+		configMap.ObjectMeta = metav1.ObjectMeta{
+			Name:            bundle.Name,
+			Namespace:       namespace,
+			Labels:          map[string]string{cmexperimental.BundleLabelKey: bundle.Name},
+			Annotations:     desired.Annotations,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(bundle, cmexperimental.SchemeGroupVersion.WithKind("Bundle"))},
+		}
+		_, err = c.kubeClient.CoreV1().ConfigMaps(namespace).Create(ctx, configMap, metav1.CreateOptions{})
+		return err == nil, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if existing.Labels[cmexperimental.BundleLabelKey] != bundle.Name {
+		return false, conflictError{}
+	}
+	if existing.Annotations[bundleHashAnnotationKey] == desired.Annotations[bundleHashAnnotationKey] &&
+		apiequality.Semantic.DeepEqual(existing.Data, desired.Data) &&
+		hasKeys(existing.BinaryData, desired.BinaryData) {
+		return false, nil
+	}
+
+	configMap := existing.DeepCopy()
+	if configMap.Annotations == nil {
+		configMap.Annotations = make(map[string]string)
+	}
+	configMap.Annotations[bundleHashAnnotationKey] = desired.Annotations[bundleHashAnnotationKey]
+	configMap.Data = desired.Data
+	configMap.BinaryData = desired.BinaryData
+	_, err = c.kubeClient.CoreV1().ConfigMaps(namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	return err == nil, err
+}
+
+// hasKeys returns true if existing has exactly the keys of desired.
+func hasKeys(existing, desired map[string][]byte) bool {
+	if len(existing) != len(desired) {
+		return false
+	}
+	for key := range desired {
+		if len(existing[key]) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// removeStaleConfigMaps deletes the ConfigMaps of the Bundle in namespaces
+// that are no longer selected by it.
+func (c *controller) removeStaleConfigMaps(ctx context.Context, bundle *cmexperimental.Bundle, namespaces []string) error {
+	selected := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		selected[namespace] = true
+	}
+
+	configMaps, err := c.configMapLister.List(labels.SelectorFromSet(labels.Set{cmexperimental.BundleLabelKey: bundle.Name}))
+	if err != nil {
+		return err
+	}
+	for _, configMap := range configMaps {
+		if selected[configMap.Namespace] || configMap.Name != bundle.Name {
+			continue
+		}
+		err := c.kubeClient.CoreV1().ConfigMaps(configMap.Namespace).Delete(ctx, configMap.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateStatus sets the Ready condition and number of namespaces of the
+// Bundle, and updates it if its status has changed.
+func (c *controller) updateStatus(ctx context.Context, bundle *cmexperimental.Bundle, status cmmeta.ConditionStatus, reason, message string, namespaces int32) error {
+	updated := bundle.DeepCopy()
+	apiutil.SetBundleCondition(updated, updated.Generation, cmexperimental.BundleConditionReady, status, reason, message)
+	updated.Status.Namespaces = namespaces
+	if apiequality.Semantic.DeepEqual(bundle.Status, updated.Status) {
+		return nil
+	}
+	_, err := c.cmClient.ExperimentalV1alpha1().Bundles().UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
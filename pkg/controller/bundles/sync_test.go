@@ -0,0 +1,371 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundles
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmexperimental "github.com/jetstack/cert-manager/pkg/apis/experimental/v1alpha1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+const clusterResourceNamespace = "cert-manager"
+
+// mustCreateCertificate returns a PEM encoded CA certificate with the given
+// common name, signed by parent if set or self-signed otherwise.
+func mustCreateCertificate(t *testing.T, commonName string, parent *x509.Certificate, parentKey interface{}) ([]byte, *x509.Certificate, interface{}) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		Version:               3,
+		BasicConstraintsValid: true,
+		SerialNumber:          big.NewInt(1),
+		PublicKeyAlgorithm:    x509.ECDSA,
+		PublicKey:             key.Public(),
+		IsCA:                  true,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             fixedClockStart.Add(-time.Hour),
+		NotAfter:              fixedClockStart.Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	certPEM, cert, err := pki.SignCertificate(template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM, cert, key
+}
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func bundle(sources []cmexperimental.BundleSource, mods ...func(*cmexperimental.Bundle)) *cmexperimental.Bundle {
+	b := &cmexperimental.Bundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-bundle", Generation: 1},
+		Spec: cmexperimental.BundleSpec{
+			Sources: sources,
+			Target: cmexperimental.BundleTarget{
+				ConfigMap: cmexperimental.BundleKeySelector{Key: "ca.crt"},
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"trust": "enabled"},
+				},
+			},
+		},
+	}
+	for _, mod := range mods {
+		mod(b)
+	}
+	return b
+}
+
+func withReadyCondition(status cmmeta.ConditionStatus, reason, message string, namespaces int32) func(*cmexperimental.Bundle) {
+	return func(b *cmexperimental.Bundle) {
+		b.Status.Namespaces = namespaces
+		b.Status.Conditions = []cmexperimental.BundleCondition{{
+			Type:               cmexperimental.BundleConditionReady,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: &metav1.Time{Time: fixedClockStart},
+			ObservedGeneration: 1,
+		}}
+	}
+}
+
+// bundleConfigMap returns the ConfigMap that the controller writes for b in
+// the given namespace.
+func bundleConfigMap(t *testing.T, b *cmexperimental.Bundle, namespace string, bundlePEM []byte) *corev1.ConfigMap {
+	configMap, err := encodeBundle(b, bundlePEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configMap.ObjectMeta = metav1.ObjectMeta{
+		Name:            b.Name,
+		Namespace:       namespace,
+		Labels:          map[string]string{cmexperimental.BundleLabelKey: b.Name},
+		Annotations:     configMap.Annotations,
+		OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(b, cmexperimental.SchemeGroupVersion.WithKind("Bundle"))},
+	}
+	return configMap
+}
+
+func statusUpdate(b *cmexperimental.Bundle) testpkg.Action {
+	return testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmexperimental.SchemeGroupVersion.WithResource("bundles"), "status", "", b))
+}
+
+func TestProcessItem(t *testing.T) {
+	rootPEM, root, rootKey := mustCreateCertificate(t, "root", nil, nil)
+	intermediatePEM, _, intermediateKey := mustCreateCertificate(t, "intermediate", root, rootKey)
+	otherPEM, _, _ := mustCreateCertificate(t, "other", nil, nil)
+	intermediateKeyPEM, err := pki.EncodePrivateKey(intermediateKey, cmapi.PKCS1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inLine := func(pem []byte) cmexperimental.BundleSource {
+		s := string(pem)
+		return cmexperimental.BundleSource{InLine: &s}
+	}
+	rootSource := []cmexperimental.BundleSource{inLine(rootPEM)}
+	bothPEM := append(append([]byte{}, rootPEM...), otherPEM...)
+
+	selected := namespace("selected", map[string]string{"trust": "enabled"})
+	notSelected := namespace("not-selected", nil)
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: gen.DefaultTestNamespace},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       append(append([]byte{}, intermediatePEM...), rootPEM...),
+			corev1.TLSPrivateKeyKey: intermediateKeyPEM,
+		},
+	}
+
+	synced := withReadyCondition(cmmeta.ConditionTrue, reasonSynced, "Bundle written to 1 namespace(s)", 1)
+
+	tests := map[string]struct {
+		key                string
+		kubeObjects        []runtime.Object
+		certManagerObjects []runtime.Object
+		expectedActions    []testpkg.Action
+		expectedEvents     []string
+	}{
+		"do nothing if the bundle does not exist": {
+			key: "test-bundle",
+		},
+		"write the bundle to selected namespaces": {
+			key:                "test-bundle",
+			kubeObjects:        []runtime.Object{selected, notSelected},
+			certManagerObjects: []runtime.Object{bundle(rootSource)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("configmaps"), "selected",
+					bundleConfigMap(t, bundle(rootSource), "selected", rootPEM))),
+				statusUpdate(bundle(rootSource, synced)),
+			},
+			expectedEvents: []string{
+				"Normal Synced Wrote bundle with 1 certificate(s) to 1 namespace(s)",
+			},
+		},
+		"deduplicate certificates across sources": {
+			key:         "test-bundle",
+			kubeObjects: []runtime.Object{selected},
+			certManagerObjects: []runtime.Object{bundle([]cmexperimental.BundleSource{
+				inLine(rootPEM), inLine(bothPEM),
+			})},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("configmaps"), "selected", nil),
+					func(exp, act coretesting.Action) error {
+						configMap := act.(coretesting.CreateAction).GetObject().(*corev1.ConfigMap)
+						if configMap.Data["ca.crt"] != string(bothPEM) {
+							return fmt.Errorf("unexpected bundle, exp=%s got=%s", bothPEM, configMap.Data["ca.crt"])
+						}
+						return nil
+					}),
+				testpkg.NewCustomMatch(coretesting.NewUpdateSubresourceAction(cmexperimental.SchemeGroupVersion.WithResource("bundles"), "status", "", nil),
+					func(exp, act coretesting.Action) error { return nil }),
+			},
+			expectedEvents: []string{
+				"Normal Synced Wrote bundle with 2 certificate(s) to 1 namespace(s)",
+			},
+		},
+		"read the root CA of a CA issuer": {
+			key:         "test-bundle",
+			kubeObjects: []runtime.Object{selected, caSecret},
+			certManagerObjects: []runtime.Object{
+				gen.Issuer("test-issuer", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"})),
+				bundle([]cmexperimental.BundleSource{{Issuer: &cmexperimental.BundleIssuerReference{
+					Name: "test-issuer", Kind: cmapi.IssuerKind, Namespace: gen.DefaultTestNamespace,
+				}}}),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("configmaps"), "selected", nil),
+					func(exp, act coretesting.Action) error {
+						configMap := act.(coretesting.CreateAction).GetObject().(*corev1.ConfigMap)
+						if configMap.Data["ca.crt"] != string(rootPEM) {
+							return fmt.Errorf("unexpected bundle, exp=%s got=%s", rootPEM, configMap.Data["ca.crt"])
+						}
+						return nil
+					}),
+				testpkg.NewCustomMatch(coretesting.NewUpdateSubresourceAction(cmexperimental.SchemeGroupVersion.WithResource("bundles"), "status", "", nil),
+					func(exp, act coretesting.Action) error { return nil }),
+			},
+			expectedEvents: []string{
+				"Normal Synced Wrote bundle with 1 certificate(s) to 1 namespace(s)",
+			},
+		},
+		"write truststores in additional formats": {
+			key:         "test-bundle",
+			kubeObjects: []runtime.Object{selected},
+			certManagerObjects: []runtime.Object{bundle(rootSource, func(b *cmexperimental.Bundle) {
+				b.Spec.Target.AdditionalFormats = &cmexperimental.BundleAdditionalFormats{
+					JKS:    &cmexperimental.BundleTruststore{Key: "truststore.jks"},
+					PKCS12: &cmexperimental.BundleTruststore{Key: "truststore.p12"},
+				}
+			})},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("configmaps"), "selected", nil),
+					func(exp, act coretesting.Action) error {
+						configMap := act.(coretesting.CreateAction).GetObject().(*corev1.ConfigMap)
+						for _, key := range []string{"truststore.jks", "truststore.p12"} {
+							if len(configMap.BinaryData[key]) == 0 {
+								return fmt.Errorf("expected truststore in key %q", key)
+							}
+						}
+						return nil
+					}),
+				testpkg.NewCustomMatch(coretesting.NewUpdateSubresourceAction(cmexperimental.SchemeGroupVersion.WithResource("bundles"), "status", "", nil),
+					func(exp, act coretesting.Action) error { return nil }),
+			},
+			expectedEvents: []string{
+				"Normal Synced Wrote bundle with 1 certificate(s) to 1 namespace(s)",
+			},
+		},
+		"do nothing if the bundle is up to date": {
+			key:                "test-bundle",
+			kubeObjects:        []runtime.Object{selected, notSelected, bundleConfigMap(t, bundle(rootSource), "selected", rootPEM)},
+			certManagerObjects: []runtime.Object{bundle(rootSource, synced)},
+		},
+		"update the bundle if a source has changed": {
+			key:                "test-bundle",
+			kubeObjects:        []runtime.Object{selected, bundleConfigMap(t, bundle(rootSource), "selected", otherPEM)},
+			certManagerObjects: []runtime.Object{bundle(rootSource, synced)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("configmaps"), "selected",
+					bundleConfigMap(t, bundle(rootSource), "selected", rootPEM))),
+			},
+			expectedEvents: []string{
+				"Normal Synced Wrote bundle with 1 certificate(s) to 1 namespace(s)",
+			},
+		},
+		"remove the bundle from namespaces that are no longer selected": {
+			key:                "test-bundle",
+			kubeObjects:        []runtime.Object{selected, notSelected, bundleConfigMap(t, bundle(rootSource), "selected", rootPEM), bundleConfigMap(t, bundle(rootSource), "not-selected", rootPEM)},
+			certManagerObjects: []runtime.Object{bundle(rootSource, synced)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(corev1.SchemeGroupVersion.WithResource("configmaps"), "not-selected", "test-bundle")),
+			},
+		},
+		"do not overwrite a ConfigMap not managed by the bundle": {
+			key: "test-bundle",
+			kubeObjects: []runtime.Object{selected, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-bundle", Namespace: "selected"},
+			}},
+			certManagerObjects: []runtime.Object{bundle(rootSource)},
+			expectedActions: []testpkg.Action{
+				statusUpdate(bundle(rootSource, withReadyCondition(cmmeta.ConditionFalse, reasonTargetError,
+					`ConfigMap "test-bundle" exists and is not managed by this Bundle in namespace(s): selected`, 0))),
+			},
+			expectedEvents: []string{
+				`Warning TargetError ConfigMap "test-bundle" exists and is not managed by this Bundle in namespace(s): selected`,
+			},
+		},
+		"set the bundle not ready if a source does not exist": {
+			key:         "test-bundle",
+			kubeObjects: []runtime.Object{selected},
+			certManagerObjects: []runtime.Object{bundle([]cmexperimental.BundleSource{
+				inLine(rootPEM),
+				{Secret: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "missing"}}},
+			})},
+			expectedActions: []testpkg.Action{
+				statusUpdate(bundle([]cmexperimental.BundleSource{
+					inLine(rootPEM),
+					{Secret: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "missing"}}},
+				}, withReadyCondition(cmmeta.ConditionFalse, reasonSourceError,
+					"Failed to build bundle: spec.sources[1]: secret cert-manager/missing not found", 0))),
+			},
+			expectedEvents: []string{
+				"Warning SourceError Failed to build bundle: spec.sources[1]: secret cert-manager/missing not found",
+			},
+		},
+		"set the bundle not ready if a source issuer is not a CA issuer": {
+			key:         "test-bundle",
+			kubeObjects: []runtime.Object{selected},
+			certManagerObjects: []runtime.Object{
+				gen.ClusterIssuer("test-clusterissuer", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
+				bundle([]cmexperimental.BundleSource{{Issuer: &cmexperimental.BundleIssuerReference{
+					Name: "test-clusterissuer", Kind: cmapi.ClusterIssuerKind,
+				}}}),
+			},
+			expectedActions: []testpkg.Action{
+				statusUpdate(bundle([]cmexperimental.BundleSource{{Issuer: &cmexperimental.BundleIssuerReference{
+					Name: "test-clusterissuer", Kind: cmapi.ClusterIssuerKind,
+				}}}, withReadyCondition(cmmeta.ConditionFalse, reasonSourceError,
+					`Failed to build bundle: spec.sources[0]: ClusterIssuer "test-clusterissuer" is not a CA issuer`, 0))),
+			},
+			expectedEvents: []string{
+				`Warning SourceError Failed to build bundle: spec.sources[0]: ClusterIssuer "test-clusterissuer" is not a CA issuer`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				KubeObjects:        test.kubeObjects,
+				CertManagerObjects: test.certManagerObjects,
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+
+			c, _, _ := NewController(logf.Log,
+				builder.Client,
+				builder.CMClient,
+				builder.KubeSharedInformerFactory,
+				builder.SharedInformerFactory,
+				builder.Recorder,
+				controllerpkg.IssuerOptions{ClusterResourceNamespace: clusterResourceNamespace},
+				true,
+			)
+			builder.Start()
+			defer builder.Stop()
+
+			err := c.ProcessItem(context.Background(), test.key)
+			builder.CheckAndFinish(err)
+		})
+	}
+}
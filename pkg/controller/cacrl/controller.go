@@ -0,0 +1,459 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cacrl
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/revocation"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/scheduler"
+	cmerrors "github.com/jetstack/cert-manager/pkg/util/errors"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const ControllerName = "ca-crl"
+
+// DefaultCRLDuration is the validity period of CRLs generated for CA issuers
+// that do not specify `spec.ca.revocation.crl.duration`.
+const DefaultCRLDuration = 24 * time.Hour
+
+const (
+	reasonCRLIssued        = "CRLIssued"
+	reasonCRLSigningFailed = "CRLSigningFailed"
+)
+
+// This controller generates and publishes certificate revocation lists for
+// CA issuers that have `spec.ca.revocation.crl` set. A new CRL is signed
+// whenever the set of revoked certificates in the issuer's revocation
+// database changes, when the CA changes, and once two thirds of the validity
+// period of the current CRL have elapsed.
+//
+// Issuers are queued using their namespace/name key and ClusterIssuers
+// using their name, so the namespace of a key tells the two apart.
+type controller struct {
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	secretLister        corelisters.SecretLister
+	configMapLister     corelisters.ConfigMapLister
+	client              kubernetes.Interface
+	recorder            record.EventRecorder
+	clock               clock.Clock
+	queue               workqueue.RateLimitingInterface
+	scheduledWorkQueue  scheduler.ScheduledWorkQueue
+	issuerOptions       controllerpkg.IssuerOptions
+}
+
+// NewController returns a CRL controller. ClusterIssuers are only watched
+// if watchClusterIssuers is true, i.e. when cert-manager has not been scoped
+// to a single namespace.
+func NewController(
+	log logr.Logger,
+	client kubernetes.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	issuerOptions controllerpkg.IssuerOptions,
+	watchClusterIssuers bool,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	secretsInformer := factory.Core().V1().Secrets()
+	configMapsInformer := factory.Core().V1().ConfigMaps()
+
+	c := &controller{
+		issuerLister:       issuerInformer.Lister(),
+		secretLister:       secretsInformer.Lister(),
+		configMapLister:    configMapsInformer.Lister(),
+		client:             client,
+		recorder:           recorder,
+		clock:              clock,
+		queue:              queue,
+		scheduledWorkQueue: scheduler.NewScheduledWorkQueue(clock, queue.Add),
+		issuerOptions:      issuerOptions,
+	}
+
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		issuerInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+		configMapsInformer.Informer().HasSynced,
+	}
+
+	if watchClusterIssuers {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+		c.clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	// When the CA Secret, revocation database, or a published CRL changes,
+	// enqueue the issuers that reference it.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.enqueueIssuersForResource(log)})
+	configMapsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.enqueueIssuersForResource(log)})
+
+	return c, queue, mustSync
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	var issuer cmapi.GenericIssuer
+	if namespace == "" {
+		if c.clusterIssuerLister == nil {
+			return nil
+		}
+		issuer, err = c.clusterIssuerLister.Get(name)
+	} else {
+		issuer, err = c.issuerLister.Issuers(namespace).Get(name)
+	}
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("issuer not found for key")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ca := issuer.GetSpec().CA
+	if ca == nil || ca.Revocation == nil || ca.Revocation.CRL == nil {
+		return nil
+	}
+
+	return c.sync(ctx, key, issuer)
+}
+
+func (c *controller) sync(ctx context.Context, key string, issuer cmapi.GenericIssuer) error {
+	log := logf.FromContext(ctx)
+	ca := issuer.GetSpec().CA
+	crlSpec := ca.Revocation.CRL
+	resourceNamespace := c.issuerOptions.ResourceNamespace(issuer)
+
+	duration := DefaultCRLDuration
+	if crlSpec.Duration != nil {
+		duration = crlSpec.Duration.Duration
+	}
+
+	caCerts, caKey, err := kube.SecretCAIssuerKeyPair(ctx, c.secretLister, resourceNamespace, ca)
+	if apierrors.IsNotFound(err) || cmerrors.IsInvalidData(err) {
+		// The CA Secret being fixed will trigger a resync.
+		c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonCRLSigningFailed,
+			"Failed to read CA key pair from secret %s/%s: %v", resourceNamespace, ca.SecretName, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	caCert := caCerts[0]
+	if caCert.KeyUsage&x509.KeyUsageCRLSign == 0 {
+		// The CA Secret being updated will trigger a resync.
+		c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonCRLSigningFailed,
+			"CA certificate in secret %s/%s cannot be used to sign CRLs as it does not have the 'crl sign' key usage", resourceNamespace, ca.SecretName)
+		return nil
+	}
+
+	db, err := revocation.ReadDatabase(c.secretLister.Secrets(resourceNamespace), ca.Revocation.SecretName)
+	if err != nil {
+		c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonCRLSigningFailed,
+			"Failed to read revocation database %s/%s: %v", resourceNamespace, ca.Revocation.SecretName, err)
+		return nil
+	}
+
+	now := c.clock.Now()
+	revoked, err := db.RevokedCertificates(now)
+	if err != nil {
+		c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonCRLSigningFailed,
+			"Invalid revocation database %s/%s: %v", resourceNamespace, ca.Revocation.SecretName, err)
+		return nil
+	}
+
+	number := big.NewInt(1)
+	current, err := c.publishedCRL(resourceNamespace, crlSpec)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		if renewal, ok := upToDate(current, caCert, revoked, duration); ok && now.Before(renewal) {
+			log.V(logf.DebugLevel).Info("published CRL is up to date, scheduling renewal", "renewal_time", renewal)
+			c.scheduledWorkQueue.Add(key, renewal.Sub(now))
+			return nil
+		}
+		if currentNumber, err := pki.CRLNumber(current); err == nil && currentNumber != nil {
+			number.Add(currentNumber, big.NewInt(1))
+		}
+	}
+
+	thisUpdate := now.UTC().Truncate(time.Second)
+	crlPEM, err := pki.SignCRL(&x509.RevocationList{
+		Number:              number,
+		ThisUpdate:          thisUpdate,
+		NextUpdate:          thisUpdate.Add(duration),
+		RevokedCertificates: revoked,
+	}, caCert, caKey)
+	if err != nil {
+		c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonCRLSigningFailed, "Failed to sign CRL: %v", err)
+		return err
+	}
+
+	kind, name, err := c.publish(ctx, resourceNamespace, crlSpec, crlPEM)
+	if err != nil {
+		return err
+	}
+
+	c.recorder.Eventf(issuer, corev1.EventTypeNormal, reasonCRLIssued,
+		"Published CRL number %s with %d revoked certificate(s) to %s %s/%s", number, len(revoked), kind, resourceNamespace, name)
+	c.scheduledWorkQueue.Add(key, renewalDuration(duration))
+
+	return nil
+}
+
+// publishedCRL returns the CRL currently published for the issuer, or nil if
+// none has been published or it cannot be decoded.
+func (c *controller) publishedCRL(namespace string, crlSpec *cmapi.CACRL) (*pkix.CertificateList, error) {
+	crlPEM, err := publishedCRLData(c.secretLister, c.configMapLister, namespace, crlSpec)
+	if err != nil || crlPEM == nil {
+		return nil, err
+	}
+	crl, err := pki.DecodeX509CRLBytes(crlPEM)
+	if err != nil {
+		// an invalid CRL will be overwritten
+		return nil, nil
+	}
+	return crl, nil
+}
+
+// publish writes the PEM encoded CRL to the Secret or ConfigMap named on the
+// issuer, creating it if required. It returns the kind and name of the
+// resource the CRL was published to.
+func (c *controller) publish(ctx context.Context, namespace string, crlSpec *cmapi.CACRL, crlPEM []byte) (string, string, error) {
+	if crlSpec.SecretName != "" {
+		secret, err := c.secretLister.Secrets(namespace).Get(crlSpec.SecretName)
+		if apierrors.IsNotFound(err) {
+			_, err = c.client.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: crlSpec.SecretName, Namespace: namespace},
+				Type:       corev1.SecretTypeOpaque,
+				Data:       map[string][]byte{revocation.CRLKey: crlPEM},
+			}, metav1.CreateOptions{})
+			return "Secret", crlSpec.SecretName, err
+		}
+		if err != nil {
+			return "", "", err
+		}
+
+		secret = secret.DeepCopy()
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[revocation.CRLKey] = crlPEM
+		_, err = c.client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return "Secret", crlSpec.SecretName, err
+	}
+
+	configMap, err := c.configMapLister.ConfigMaps(namespace).Get(crlSpec.ConfigMapName)
+	if apierrors.IsNotFound(err) {
+		_, err = c.client.CoreV1().ConfigMaps(namespace).Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: crlSpec.ConfigMapName, Namespace: namespace},
+			Data:       map[string]string{revocation.CRLKey: string(crlPEM)},
+		}, metav1.CreateOptions{})
+		return "ConfigMap", crlSpec.ConfigMapName, err
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	configMap = configMap.DeepCopy()
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[revocation.CRLKey] = string(crlPEM)
+	_, err = c.client.CoreV1().ConfigMaps(namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	return "ConfigMap", crlSpec.ConfigMapName, err
+}
+
+// publishedCRLData returns the PEM encoded CRL published to the Secret or
+// ConfigMap named in crlSpec, or nil if there is none.
+func publishedCRLData(secretLister corelisters.SecretLister, configMapLister corelisters.ConfigMapLister, namespace string, crlSpec *cmapi.CACRL) ([]byte, error) {
+	if crlSpec.SecretName != "" {
+		secret, err := secretLister.Secrets(namespace).Get(crlSpec.SecretName)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return secret.Data[revocation.CRLKey], nil
+	}
+
+	configMap, err := configMapLister.ConfigMaps(namespace).Get(crlSpec.ConfigMapName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if data, ok := configMap.Data[revocation.CRLKey]; ok {
+		return []byte(data), nil
+	}
+	return nil, nil
+}
+
+// upToDate returns true if the given CRL was signed by the CA, has the
+// expected validity period, and lists exactly the given revoked
+// certificates. It also returns the time at which the CRL should be renewed.
+func upToDate(crl *pkix.CertificateList, caCert *x509.Certificate, revoked []pkix.RevokedCertificate, duration time.Duration) (time.Time, bool) {
+	tbs := crl.TBSCertList
+	if err := caCert.CheckCRLSignature(crl); err != nil {
+		return time.Time{}, false
+	}
+	if tbs.NextUpdate.Sub(tbs.ThisUpdate) != duration {
+		return time.Time{}, false
+	}
+	if len(tbs.RevokedCertificates) != len(revoked) {
+		return time.Time{}, false
+	}
+	// both lists are sorted by serial number
+	for i := range revoked {
+		if tbs.RevokedCertificates[i].SerialNumber.Cmp(revoked[i].SerialNumber) != 0 {
+			return time.Time{}, false
+		}
+	}
+	return tbs.ThisUpdate.Add(renewalDuration(duration)), true
+}
+
+// renewalDuration returns how long after its thisUpdate time a CRL with the
+// given validity period should be replaced.
+func renewalDuration(duration time.Duration) time.Duration {
+	return duration * 2 / 3
+}
+
+// enqueueIssuersForResource returns a function that queues all issuers that
+// reference the given Secret or ConfigMap by name.
+func (c *controller) enqueueIssuersForResource(log logr.Logger) func(obj interface{}) {
+	return func(obj interface{}) {
+		object, ok := obj.(metav1.Object)
+		if !ok {
+			log.Error(nil, "object is not a metav1.Object")
+			return
+		}
+		namespace, name := object.GetNamespace(), object.GetName()
+
+		issuers, err := c.issuerLister.Issuers(namespace).List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed to list issuers")
+			return
+		}
+		for _, issuer := range issuers {
+			if referencesResource(issuer, name) {
+				c.queue.Add(namespace + "/" + issuer.Name)
+			}
+		}
+
+		if c.clusterIssuerLister == nil || namespace != c.issuerOptions.ClusterResourceNamespace {
+			return
+		}
+		clusterIssuers, err := c.clusterIssuerLister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed to list clusterissuers")
+			return
+		}
+		for _, issuer := range clusterIssuers {
+			if referencesResource(issuer, name) {
+				c.queue.Add(issuer.Name)
+			}
+		}
+	}
+}
+
+// referencesResource returns true if the issuer publishes a CRL and names a
+// resource with the given name as one of its CA Secrets, a shard of its
+// revocation database or its CRL.
+func referencesResource(issuer cmapi.GenericIssuer, name string) bool {
+	ca := issuer.GetSpec().CA
+	if ca == nil || ca.Revocation == nil || ca.Revocation.CRL == nil {
+		return false
+	}
+	crl := ca.Revocation.CRL
+	return kube.CAIssuerUsesSecret(ca, name) || revocation.IsShardSecretName(ca.Revocation.SecretName, name) ||
+		name == crl.SecretName || name == crl.ConfigMapName
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.IssuerOptions,
+		ctx.Namespace == "",
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,386 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cacrl
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/revocation"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+const clusterResourceNamespace = "cert-manager"
+
+type testCA struct {
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	secret *corev1.Secret
+}
+
+func mustCreateCA(t *testing.T, namespace string, keyUsage x509.KeyUsage) *testCA {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		Version:               3,
+		BasicConstraintsValid: true,
+		SerialNumber:          big.NewInt(1),
+		PublicKeyAlgorithm:    x509.ECDSA,
+		PublicKey:             key.Public(),
+		IsCA:                  true,
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             fixedClockStart.Add(-time.Hour),
+		NotAfter:              fixedClockStart.Add(365 * 24 * time.Hour),
+		KeyUsage:              keyUsage,
+	}
+	certPEM, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodeECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCA{
+		cert: cert,
+		key:  key,
+		secret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: namespace},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: keyPEM,
+			},
+		},
+	}
+}
+
+// databaseSecrets returns the shards of the revocation database named
+// "revocations" holding the entries of db.
+func databaseSecrets(t *testing.T, namespace string, db *revocation.Database) []runtime.Object {
+	shards := make(map[string]*revocation.Database)
+	var names []string
+	for _, entry := range db.Certificates {
+		serialNumber, _ := new(big.Int).SetString(entry.SerialNumber, 16)
+		name := revocation.ShardSecretName("revocations", serialNumber)
+		if shards[name] == nil {
+			shards[name] = &revocation.Database{}
+			names = append(names, name)
+		}
+		shards[name].Certificates = append(shards[name].Certificates, entry)
+	}
+
+	var secrets []runtime.Object
+	for _, name := range names {
+		data, err := shards[name].Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		secrets = append(secrets, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       map[string][]byte{revocation.DatabaseKey: data},
+		})
+	}
+	return secrets
+}
+
+func mustSignCRL(t *testing.T, ca *testCA, number int64, thisUpdate time.Time, duration time.Duration, serials ...int64) []byte {
+	var revoked []pkix.RevokedCertificate
+	for _, serial := range serials {
+		entry, err := pki.RevokedCertificate(big.NewInt(serial), fixedClockStart, pki.RevocationReasonKeyCompromise)
+		if err != nil {
+			t.Fatal(err)
+		}
+		revoked = append(revoked, entry)
+	}
+	crlPEM, err := pki.SignCRL(&x509.RevocationList{
+		Number:              big.NewInt(number),
+		ThisUpdate:          thisUpdate,
+		NextUpdate:          thisUpdate.Add(duration),
+		RevokedCertificates: revoked,
+	}, ca.cert, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crlPEM
+}
+
+// crlMatches returns an ActionMatchFn which checks that the published CRL is
+// signed by the CA and has the expected number and revoked serial numbers.
+func crlMatches(ca *testCA, number int64, serials ...int64) testpkg.ActionMatchFn {
+	return func(exp, act coretesting.Action) error {
+		if exp.GetVerb() != act.GetVerb() || exp.GetResource() != act.GetResource() || exp.GetNamespace() != act.GetNamespace() {
+			return fmt.Errorf("unexpected action %v", act)
+		}
+
+		var crlPEM []byte
+		switch obj := act.(coretesting.CreateAction).GetObject().(type) {
+		case *corev1.Secret:
+			crlPEM = obj.Data[revocation.CRLKey]
+		case *corev1.ConfigMap:
+			crlPEM = []byte(obj.Data[revocation.CRLKey])
+		}
+
+		crl, err := pki.DecodeX509CRLBytes(crlPEM)
+		if err != nil {
+			return err
+		}
+		if err := ca.cert.CheckCRLSignature(crl); err != nil {
+			return err
+		}
+		gotNumber, err := pki.CRLNumber(crl)
+		if err != nil {
+			return err
+		}
+		if gotNumber.Int64() != number {
+			return fmt.Errorf("unexpected CRL number, exp=%d got=%s", number, gotNumber)
+		}
+		if !crl.TBSCertList.ThisUpdate.Equal(fixedClockStart) || !crl.TBSCertList.NextUpdate.Equal(fixedClockStart.Add(DefaultCRLDuration)) {
+			return fmt.Errorf("unexpected CRL validity %s - %s", crl.TBSCertList.ThisUpdate, crl.TBSCertList.NextUpdate)
+		}
+		revoked := crl.TBSCertList.RevokedCertificates
+		if len(revoked) != len(serials) {
+			return fmt.Errorf("unexpected revoked certificates, exp=%v got=%v", serials, revoked)
+		}
+		for i, serial := range serials {
+			if revoked[i].SerialNumber.Int64() != serial {
+				return fmt.Errorf("unexpected revoked certificates, exp=%v got=%v", serials, revoked)
+			}
+		}
+		return nil
+	}
+}
+
+func TestProcessItem(t *testing.T) {
+	ca := mustCreateCA(t, gen.DefaultTestNamespace, x509.KeyUsageCertSign|x509.KeyUsageCRLSign)
+	noCRLSignCA := mustCreateCA(t, gen.DefaultTestNamespace, x509.KeyUsageCertSign)
+	clusterCA := mustCreateCA(t, clusterResourceNamespace, x509.KeyUsageCertSign|x509.KeyUsageCRLSign)
+
+	db := &revocation.Database{}
+	db.AddIssued(&x509.Certificate{SerialNumber: big.NewInt(0x10), NotAfter: fixedClockStart.Add(time.Hour)})
+	db.Revoke(&x509.Certificate{SerialNumber: big.NewInt(0x20), NotAfter: fixedClockStart.Add(time.Hour)}, pki.RevocationReasonKeyCompromise, fixedClockStart)
+	// expired revoked certificates are not listed
+	db.Revoke(&x509.Certificate{SerialNumber: big.NewInt(0x30), NotAfter: fixedClockStart.Add(-time.Hour)}, pki.RevocationReasonKeyCompromise, fixedClockStart)
+
+	dbWithNewRevocation := &revocation.Database{Certificates: append([]revocation.Entry{}, db.Certificates...)}
+	dbWithNewRevocation.Revoke(&x509.Certificate{SerialNumber: big.NewInt(0x10), NotAfter: fixedClockStart.Add(time.Hour)}, pki.RevocationReasonSuperseded, fixedClockStart)
+
+	crlConfigMapIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerCA(cmapi.CAIssuer{
+			SecretName: "ca",
+			Revocation: &cmapi.CARevocation{
+				SecretName: "revocations",
+				CRL:        &cmapi.CACRL{ConfigMapName: "crl"},
+			},
+		}),
+	)
+	crlSecretIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerCA(cmapi.CAIssuer{
+			SecretName: "ca",
+			Revocation: &cmapi.CARevocation{
+				SecretName: "revocations",
+				CRL:        &cmapi.CACRL{SecretName: "crl"},
+			},
+		}),
+	)
+	crlSecret := func(crlPEM []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "crl", Namespace: gen.DefaultTestNamespace},
+			Data:       map[string][]byte{revocation.CRLKey: crlPEM},
+		}
+	}
+
+	tests := map[string]struct {
+		key                string
+		kubeObjects        []runtime.Object
+		certManagerObjects []runtime.Object
+		expectedActions    []testpkg.Action
+		expectedEvents     []string
+	}{
+		"do nothing if the issuer does not exist": {
+			key: gen.DefaultTestNamespace + "/test-issuer",
+		},
+		"do nothing if the issuer does not publish a CRL": {
+			key:         gen.DefaultTestNamespace + "/test-issuer",
+			kubeObjects: []runtime.Object{ca.secret},
+			certManagerObjects: []runtime.Object{gen.Issuer("test-issuer",
+				gen.SetIssuerCA(cmapi.CAIssuer{
+					SecretName: "ca",
+					Revocation: &cmapi.CARevocation{SecretName: "revocations"},
+				}),
+			)},
+		},
+		"publish a new CRL to a ConfigMap": {
+			key:                gen.DefaultTestNamespace + "/test-issuer",
+			kubeObjects:        append(databaseSecrets(t, gen.DefaultTestNamespace, db), ca.secret),
+			certManagerObjects: []runtime.Object{crlConfigMapIssuer},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("configmaps"), gen.DefaultTestNamespace, nil),
+					crlMatches(ca, 1, 0x20)),
+			},
+			expectedEvents: []string{
+				"Normal CRLIssued Published CRL number 1 with 1 revoked certificate(s) to ConfigMap default-unit-test-ns/crl",
+			},
+		},
+		"publish an empty CRL if no certificates have been signed yet": {
+			key:                gen.DefaultTestNamespace + "/test-issuer",
+			kubeObjects:        []runtime.Object{ca.secret},
+			certManagerObjects: []runtime.Object{crlSecretIssuer},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("secrets"), gen.DefaultTestNamespace, nil),
+					crlMatches(ca, 1)),
+			},
+			expectedEvents: []string{
+				"Normal CRLIssued Published CRL number 1 with 0 revoked certificate(s) to Secret default-unit-test-ns/crl",
+			},
+		},
+		"do nothing if the published CRL is up to date": {
+			key: gen.DefaultTestNamespace + "/test-issuer",
+			kubeObjects: append(databaseSecrets(t, gen.DefaultTestNamespace, db), ca.secret,
+				crlSecret(mustSignCRL(t, ca, 3, fixedClockStart.Add(-time.Hour), DefaultCRLDuration, 0x20))),
+			certManagerObjects: []runtime.Object{crlSecretIssuer},
+		},
+		"publish a new CRL once a certificate has been revoked": {
+			key: gen.DefaultTestNamespace + "/test-issuer",
+			kubeObjects: append(databaseSecrets(t, gen.DefaultTestNamespace, dbWithNewRevocation), ca.secret,
+				crlSecret(mustSignCRL(t, ca, 3, fixedClockStart.Add(-time.Hour), DefaultCRLDuration, 0x20))),
+			certManagerObjects: []runtime.Object{crlSecretIssuer},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), gen.DefaultTestNamespace, nil),
+					crlMatches(ca, 4, 0x10, 0x20)),
+			},
+			expectedEvents: []string{
+				"Normal CRLIssued Published CRL number 4 with 2 revoked certificate(s) to Secret default-unit-test-ns/crl",
+			},
+		},
+		"publish a new CRL once two thirds of the current CRL's validity have elapsed": {
+			key: gen.DefaultTestNamespace + "/test-issuer",
+			kubeObjects: append(databaseSecrets(t, gen.DefaultTestNamespace, db), ca.secret,
+				crlSecret(mustSignCRL(t, ca, 3, fixedClockStart.Add(-17*time.Hour), DefaultCRLDuration, 0x20))),
+			certManagerObjects: []runtime.Object{crlSecretIssuer},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), gen.DefaultTestNamespace, nil),
+					crlMatches(ca, 4, 0x20)),
+			},
+			expectedEvents: []string{
+				"Normal CRLIssued Published CRL number 4 with 1 revoked certificate(s) to Secret default-unit-test-ns/crl",
+			},
+		},
+		"publish a new CRL if the published CRL was signed by a different CA": {
+			key: gen.DefaultTestNamespace + "/test-issuer",
+			kubeObjects: append(databaseSecrets(t, gen.DefaultTestNamespace, db), ca.secret,
+				crlSecret(mustSignCRL(t, clusterCA, 3, fixedClockStart, DefaultCRLDuration, 0x20))),
+			certManagerObjects: []runtime.Object{crlSecretIssuer},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), gen.DefaultTestNamespace, nil),
+					crlMatches(ca, 4, 0x20)),
+			},
+			expectedEvents: []string{
+				"Normal CRLIssued Published CRL number 4 with 1 revoked certificate(s) to Secret default-unit-test-ns/crl",
+			},
+		},
+		"fire an event if the CA cannot sign CRLs": {
+			key:                gen.DefaultTestNamespace + "/test-issuer",
+			kubeObjects:        []runtime.Object{noCRLSignCA.secret},
+			certManagerObjects: []runtime.Object{crlSecretIssuer},
+			expectedEvents: []string{
+				"Warning CRLSigningFailed CA certificate in secret default-unit-test-ns/ca cannot be used to sign CRLs as it does not have the 'crl sign' key usage",
+			},
+		},
+		"fire an event if the CA secret does not exist": {
+			key:                gen.DefaultTestNamespace + "/test-issuer",
+			certManagerObjects: []runtime.Object{crlSecretIssuer},
+			expectedEvents: []string{
+				`Warning CRLSigningFailed Failed to read CA key pair from secret default-unit-test-ns/ca: secret "ca" not found`,
+			},
+		},
+		"publish a CRL for a ClusterIssuer to the cluster resource namespace": {
+			key:         "test-clusterissuer",
+			kubeObjects: []runtime.Object{clusterCA.secret},
+			certManagerObjects: []runtime.Object{gen.ClusterIssuer("test-clusterissuer",
+				gen.SetIssuerCA(cmapi.CAIssuer{
+					SecretName: "ca",
+					Revocation: &cmapi.CARevocation{
+						SecretName: "revocations",
+						CRL:        &cmapi.CACRL{SecretName: "crl"},
+					},
+				}),
+			)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("secrets"), clusterResourceNamespace, nil),
+					crlMatches(clusterCA, 1)),
+			},
+			expectedEvents: []string{
+				"Normal CRLIssued Published CRL number 1 with 0 revoked certificate(s) to Secret cert-manager/crl",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				KubeObjects:        test.kubeObjects,
+				CertManagerObjects: test.certManagerObjects,
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+
+			c, _, _ := NewController(logf.Log,
+				builder.Client,
+				builder.KubeSharedInformerFactory,
+				builder.SharedInformerFactory,
+				builder.Recorder,
+				builder.Clock,
+				controllerpkg.IssuerOptions{ClusterResourceNamespace: clusterResourceNamespace},
+				true,
+			)
+			builder.Start()
+			defer builder.Stop()
+
+			err := c.ProcessItem(context.Background(), test.key)
+			builder.CheckAndFinish(err)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cacrl
+
+import (
+	"encoding/pem"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	issuersPathPrefix        = "/issuers/"
+	clusterIssuersPathPrefix = "/clusterissuers/"
+	crlPathSuffix            = ".crl"
+)
+
+// Server serves the CRLs published by CA issuers over HTTP so that they can
+// be fetched by in-cluster relying parties using the URLs listed in
+// `spec.ca.crlDistributionPoints`. CRLs are served DER encoded, as required
+// by RFC 5280, at:
+//
+//   /issuers/<namespace>/<name>.crl
+//   /clusterissuers/<name>.crl
+type Server struct {
+	log                 logr.Logger
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	secretLister        corelisters.SecretLister
+	configMapLister     corelisters.ConfigMapLister
+	issuerOptions       controllerpkg.IssuerOptions
+}
+
+// NewServer returns a Server which reads CRLs using the listers of the given
+// controller context. It must be called before the context's informer
+// factories are started.
+func NewServer(ctx *controllerpkg.Context) *Server {
+	s := &Server{
+		log:             logf.FromContext(ctx.RootContext, "crl-server"),
+		issuerLister:    ctx.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
+		secretLister:    ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		configMapLister: ctx.KubeSharedInformerFactory.Core().V1().ConfigMaps().Lister(),
+		issuerOptions:   ctx.IssuerOptions,
+	}
+	// ClusterIssuers are not watched when scoped to a single namespace
+	if ctx.Namespace == "" {
+		s.clusterIssuerLister = ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister()
+	}
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	issuer, err := s.issuerForPath(r.URL.Path)
+	if apierrors.IsNotFound(err) || (err == nil && issuer == nil) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		s.log.Error(err, "failed to get issuer", "path", r.URL.Path)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	ca := issuer.GetSpec().CA
+	if ca == nil || ca.Revocation == nil || ca.Revocation.CRL == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	crlPEM, err := publishedCRLData(s.secretLister, s.configMapLister, s.issuerOptions.ResourceNamespace(issuer), ca.Revocation.CRL)
+	if err != nil {
+		s.log.Error(err, "failed to get published CRL", "path", r.URL.Path)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	block, _ := pem.Decode(crlPEM)
+	if block == nil {
+		// the CRL has not been published yet
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pkix-crl")
+	if _, err := w.Write(block.Bytes); err != nil {
+		s.log.Error(err, "failed to write response", "path", r.URL.Path)
+	}
+}
+
+// issuerForPath returns the issuer named by the request path, or nil if the
+// path is not a CRL path.
+func (s *Server) issuerForPath(path string) (cmapi.GenericIssuer, error) {
+	if !strings.HasSuffix(path, crlPathSuffix) {
+		return nil, nil
+	}
+	path = strings.TrimSuffix(path, crlPathSuffix)
+
+	switch {
+	case strings.HasPrefix(path, issuersPathPrefix):
+		parts := strings.Split(strings.TrimPrefix(path, issuersPathPrefix), "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, nil
+		}
+		return s.issuerLister.Issuers(parts[0]).Get(parts[1])
+	case strings.HasPrefix(path, clusterIssuersPathPrefix) && s.clusterIssuerLister != nil:
+		name := strings.TrimPrefix(path, clusterIssuersPathPrefix)
+		if name == "" || strings.Contains(name, "/") {
+			return nil, nil
+		}
+		return s.clusterIssuerLister.Get(name)
+	}
+	return nil, nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cacrl
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/revocation"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestServer(t *testing.T) {
+	ca := mustCreateCA(t, gen.DefaultTestNamespace, x509.KeyUsageCertSign|x509.KeyUsageCRLSign)
+	crlPEM := mustSignCRL(t, ca, 1, fixedClockStart, DefaultCRLDuration, 0x20)
+	block, _ := pem.Decode(crlPEM)
+
+	crlIssuer := func(name string) cmapi.CAIssuer {
+		return cmapi.CAIssuer{
+			SecretName: "ca",
+			Revocation: &cmapi.CARevocation{
+				SecretName: "revocations",
+				CRL:        &cmapi.CACRL{ConfigMapName: name},
+			},
+		}
+	}
+
+	builder := &testpkg.Builder{
+		T: t,
+		KubeObjects: []runtime.Object{
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "crl", Namespace: gen.DefaultTestNamespace},
+				Data:       map[string]string{revocation.CRLKey: string(crlPEM)},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "crl", Namespace: clusterResourceNamespace},
+				Data:       map[string]string{revocation.CRLKey: string(crlPEM)},
+			},
+		},
+		CertManagerObjects: []runtime.Object{
+			gen.Issuer("published", gen.SetIssuerCA(crlIssuer("crl"))),
+			gen.Issuer("unpublished", gen.SetIssuerCA(crlIssuer("missing"))),
+			gen.Issuer("no-crl", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"})),
+			gen.ClusterIssuer("published", gen.SetIssuerCA(crlIssuer("crl"))),
+		},
+	}
+	builder.Init()
+
+	server := &Server{
+		log:                 logf.Log,
+		issuerLister:        builder.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
+		clusterIssuerLister: builder.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister(),
+		secretLister:        builder.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		configMapLister:     builder.KubeSharedInformerFactory.Core().V1().ConfigMaps().Lister(),
+		issuerOptions:       controllerpkg.IssuerOptions{ClusterResourceNamespace: clusterResourceNamespace},
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	tests := map[string]struct {
+		method     string
+		path       string
+		expCode    int
+		expCRLBody bool
+	}{
+		"serves the CRL of an Issuer": {
+			path:       "/issuers/" + gen.DefaultTestNamespace + "/published.crl",
+			expCode:    http.StatusOK,
+			expCRLBody: true,
+		},
+		"serves the CRL of a ClusterIssuer": {
+			path:       "/clusterissuers/published.crl",
+			expCode:    http.StatusOK,
+			expCRLBody: true,
+		},
+		"not found if the issuer does not exist": {
+			path:    "/issuers/" + gen.DefaultTestNamespace + "/missing.crl",
+			expCode: http.StatusNotFound,
+		},
+		"not found if the CRL has not been published yet": {
+			path:    "/issuers/" + gen.DefaultTestNamespace + "/unpublished.crl",
+			expCode: http.StatusNotFound,
+		},
+		"not found if the issuer does not publish a CRL": {
+			path:    "/issuers/" + gen.DefaultTestNamespace + "/no-crl.crl",
+			expCode: http.StatusNotFound,
+		},
+		"not found for paths that are not CRLs": {
+			path:    "/issuers/" + gen.DefaultTestNamespace + "/published",
+			expCode: http.StatusNotFound,
+		},
+		"not found for malformed issuer paths": {
+			path:    "/issuers/published.crl",
+			expCode: http.StatusNotFound,
+		},
+		"method not allowed for POST requests": {
+			method:  http.MethodPost,
+			path:    "/clusterissuers/published.crl",
+			expCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, httptest.NewRequest(method, test.path, nil))
+
+			if rec.Code != test.expCode {
+				t.Errorf("unexpected status code, exp=%d got=%d", test.expCode, rec.Code)
+			}
+			if !test.expCRLBody {
+				return
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != "application/pkix-crl" {
+				t.Errorf("unexpected content type %q", contentType)
+			}
+			if !bytes.Equal(rec.Body.Bytes(), block.Bytes) {
+				t.Errorf("expected the DER encoded CRL to be served")
+			}
+		})
+	}
+}
@@ -20,10 +20,13 @@ import (
 	"context"
 	"crypto/x509"
 	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 
 	"github.com/jetstack/cert-manager/pkg/acme"
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
@@ -45,6 +48,9 @@ const (
 	// this controller when enabling or disabling it from
 	// command line flags.
 	CRControllerName = "certificaterequests-issuer-acme"
+
+	reasonOrderRateLimited = "OrderRateLimited"
+	reasonRateLimited      = "RateLimited"
 )
 
 // ACME is a controller that implements `certificaterequests.Issuer`.
@@ -57,6 +63,7 @@ type ACME struct {
 	acmeClientV cmacmeclientset.AcmeV1Interface
 
 	reporter *crutil.Reporter
+	clock    clock.Clock
 }
 
 func init() {
@@ -79,6 +86,7 @@ func NewACME(ctx *controllerpkg.Context) *ACME {
 		orderLister:   ctx.SharedInformerFactory.Acme().V1().Orders().Lister(),
 		acmeClientV:   ctx.CMClient.AcmeV1(),
 		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		clock:         ctx.Clock,
 	}
 }
 
@@ -175,6 +183,17 @@ func (a *ACME) Sign(ctx context.Context, cr *v1.CertificateRequest, issuer v1.Ge
 		return nil, nil
 	}
 
+	if until := order.Status.RateLimitedUntil; until != nil && a.clock.Now().Before(until.Time) {
+		message := fmt.Sprintf("Waiting on certificate issuance from order %s/%s: rate limited by the ACME server until %s",
+			expectedOrder.Namespace, order.Name, until.UTC().Format(time.RFC3339))
+		a.recordRateLimitedOnCertificate(cr, until.Time, message)
+		a.reporter.Pending(cr, nil, reasonOrderRateLimited, message)
+
+		log.V(logf.DebugLevel).Info("acme Order resource is rate limited by the ACME server, waiting...", "until", until.Time)
+
+		return nil, nil
+	}
+
 	if order.Status.State != cmacme.Valid {
 		// We update here to just pending while we wait for the order to be resolved.
 		a.reporter.Pending(cr, nil, "OrderPending",
@@ -278,3 +297,27 @@ func buildOrder(cr *v1.CertificateRequest, csr *x509.CertificateRequest, enableD
 		Spec: spec,
 	}, nil
 }
+
+// recordRateLimitedOnCertificate records an Event on the Certificate owning the
+// given CertificateRequest, if any, the first time that the issuance of the
+// request is found to be rate limited with the given message.
+func (a *ACME) recordRateLimitedOnCertificate(cr *v1.CertificateRequest, until time.Time, message string) {
+	if cond := apiutil.GetCertificateRequestCondition(cr, v1.CertificateRequestConditionReady); cond != nil && cond.Message == message {
+		return
+	}
+
+	owner := metav1.GetControllerOf(cr)
+	if owner == nil || owner.Kind != v1.CertificateKind {
+		return
+	}
+
+	crt := &v1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      owner.Name,
+			Namespace: cr.Namespace,
+			UID:       owner.UID,
+		},
+	}
+	a.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRateLimited,
+		"Issuance of CertificateRequest %q is rate limited by the ACME server until %s", cr.Name, until.UTC().Format(time.RFC3339))
+}
@@ -24,6 +24,7 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"math/big"
 	"net"
 	"reflect"
@@ -219,6 +220,14 @@ func TestSign(t *testing.T) {
 		t.Fatalf("failed to build order during testing: %s", err)
 	}
 
+	baseCROwned := gen.CertificateRequestFrom(baseCR,
+		gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(
+			gen.Certificate("test-cert", gen.SetCertificateUID("test-cert-uid")),
+			cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind),
+		)),
+	)
+	rateLimitedUntil := metav1.NewTime(fixedClockStart.Add(time.Hour))
+
 	tests := map[string]testT{
 		"a CertificateRequest without an approved condition should do nothing": {
 			certificateRequest: baseCRNotApproved.DeepCopy(),
@@ -536,6 +545,69 @@ func TestSign(t *testing.T) {
 			},
 		},
 
+		"if the order is rate limited by the acme server, then report pending and record an event on the owning certificate": {
+			certificateRequest: baseCROwned.DeepCopy(),
+			builder: &testpkg.Builder{
+				ExpectedEvents: []string{
+					fmt.Sprintf(`Warning RateLimited Issuance of CertificateRequest "test-cr" is rate limited by the ACME server until %s`, rateLimitedUntil.UTC().Format(time.RFC3339)),
+					fmt.Sprintf(`Normal OrderRateLimited Waiting on certificate issuance from order default-unit-test-ns/test-cr-1733622556: rate limited by the ACME server until %s`, rateLimitedUntil.UTC().Format(time.RFC3339)),
+				},
+				CertManagerObjects: []runtime.Object{baseCROwned.DeepCopy(), baseIssuer.DeepCopy(),
+					gen.OrderFrom(baseOrder,
+						gen.SetOrderState(cmacme.Pending),
+						gen.SetOrderRateLimitedUntil(rateLimitedUntil),
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCROwned,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            fmt.Sprintf(`Waiting on certificate issuance from order default-unit-test-ns/test-cr-1733622556: rate limited by the ACME server until %s`, rateLimitedUntil.UTC().Format(time.RFC3339)),
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+
+		"if the order rate limit has expired, then report the order state": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				ExpectedEvents: []string{
+					`Normal OrderPending Waiting on certificate issuance from order default-unit-test-ns/test-cr-1733622556: "pending"`,
+				},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(),
+					gen.OrderFrom(baseOrder,
+						gen.SetOrderState(cmacme.Pending),
+						gen.SetOrderRateLimitedUntil(metav1.NewTime(fixedClockStart.Add(-time.Minute))),
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Waiting on certificate issuance from order default-unit-test-ns/test-cr-1733622556: "pending"`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+
 		"if the order is in Valid state but Certificate has not yet been populated": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
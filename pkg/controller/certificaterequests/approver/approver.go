@@ -19,17 +19,22 @@ package approver
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	cmexperimentallisters "github.com/jetstack/cert-manager/pkg/client/listers/experimental/v1alpha1"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/feature"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
+	utilfeature "github.com/jetstack/cert-manager/pkg/util/feature"
 )
 
 const (
@@ -37,8 +42,10 @@ const (
 )
 
 // Controller is a CertificateRequest controller which manages the "Approved"
-// condition. In the absence of any automated policy engine, this controller
-// will _always_ set the "Approved" condition to True. All CertificateRequest
+// condition. Unless the CertificateRequestPolicies feature gate is enabled
+// and a CertificateRequestPolicy applies to the request, or an approval
+// webhook is configured, this controller will _always_ set the "Approved"
+// condition to True. All CertificateRequest
 // signing controllers should wait until the "Approved" condition is set to
 // True before processing.
 type Controller struct {
@@ -48,6 +55,22 @@ type Controller struct {
 	certificateRequestLister cmlisters.CertificateRequestLister
 	cmClient                 cmclient.Interface
 
+	// clusterIssuerLister and namespaceLister are used to deny requests for
+	// ClusterIssuers which may not be used from the namespace of the
+	// request. They are only set if ClusterIssuers are watched.
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	namespaceLister     corelisters.NamespaceLister
+
+	// policyLister is used to evaluate CertificateRequestPolicies. It is only
+	// set if the CertificateRequestPolicies feature gate is enabled.
+	policyLister cmexperimentallisters.CertificateRequestPolicyLister
+
+	// webhook, if set, is the external approval webhook which has the final
+	// say on requests not denied otherwise. Requests whose review is pending
+	// are sent to it again after webhookPollInterval.
+	webhook             *approvalWebhook
+	webhookPollInterval time.Duration
+
 	recorder record.EventRecorder
 
 	queue workqueue.RateLimitingInterface
@@ -72,6 +95,31 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	mustSync := []cache.InformerSynced{certificateRequestInformer.Informer().HasSynced}
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
 
+	// if we are running in non-namespaced mode (i.e. --namespace=""), we also
+	// obtain listers for clusterissuers and namespaces.
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		namespaceInformer := ctx.KubeSharedInformerFactory.Core().V1().Namespaces()
+		c.clusterIssuerLister = clusterIssuerInformer.Lister()
+		c.namespaceLister = namespaceInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced, namespaceInformer.Informer().HasSynced)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(feature.CertificateRequestPolicies) {
+		policyInformer := ctx.SharedInformerFactory.Experimental().V1alpha1().CertificateRequestPolicies()
+		c.policyLister = policyInformer.Lister()
+		mustSync = append(mustSync, policyInformer.Informer().HasSynced)
+	}
+
+	if ctx.ApprovalOptions.WebhookURL != "" {
+		webhook, err := newApprovalWebhook(ctx.ApprovalOptions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error creating approval webhook client: %w", err)
+		}
+		c.webhook = webhook
+		c.webhookPollInterval = ctx.ApprovalOptions.WebhookPollInterval
+	}
+
 	c.certificateRequestLister = certificateRequestInformer.Lister()
 	c.cmClient = ctx.CMClient
 	c.recorder = ctx.Recorder
@@ -18,23 +18,48 @@ package approver
 
 import (
 	"context"
+	"crypto/x509"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmexperimental "github.com/jetstack/cert-manager/pkg/apis/experimental/v1alpha1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/feature"
+	utilfeature "github.com/jetstack/cert-manager/pkg/util/feature"
+	"github.com/jetstack/cert-manager/test/unit/gen"
 )
 
 func TestProcessItem(t *testing.T) {
 	// now time is the current time at the start of the test (the clock is fixed)
 	now := time.Now()
 	metaNow := metav1.NewTime(now)
+
+	exampleComCSR, _, err := gen.CSR(x509.RSA, gen.SetCSRDNSNames("www.example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exampleOrgCSR, _, err := gen.CSR(x509.RSA, gen.SetCSRDNSNames("example.org"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	examplePolicy := &cmexperimental.CertificateRequestPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-example"},
+		Spec: cmexperimental.CertificateRequestPolicySpec{
+			IssuerRefs: []cmexperimental.CertificateRequestPolicyIssuerRef{{Name: "ca"}},
+			DNSNames:   []string{"*.example.com"},
+		},
+	}
+
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem.
 		// if not set, the 'namespace/name' of the 'CertificateRequest' field will be used.
@@ -45,6 +70,19 @@ func TestProcessItem(t *testing.T) {
 		// if not set, the 'key' will be passed to ProcessItem instead.
 		request *cmapi.CertificateRequest
 
+		// clusterIssuer, if set, is the ClusterIssuer referenced by the
+		// CertificateRequest.
+		clusterIssuer *cmapi.ClusterIssuer
+
+		// policies, if set, are the CertificateRequestPolicies evaluated with
+		// the CertificateRequestPolicies feature gate enabled.
+		policies []*cmexperimental.CertificateRequestPolicy
+
+		// webhookStatus and webhookMessage, if set, are the response of the
+		// approval webhook configured for the test.
+		webhookStatus  cmexperimental.CertificateRequestReviewStatus
+		webhookMessage string
+
 		// expectedEvent, if set, is an 'event string' that is expected to be fired.
 		expectedEvent string
 
@@ -165,19 +203,204 @@ func TestProcessItem(t *testing.T) {
 			},
 			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
 		},
+		"approve CertificateRequest if the ClusterIssuer allows the namespace": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "test-clusterissuer", Kind: cmapi.ClusterIssuerKind},
+				},
+			},
+			clusterIssuer: gen.ClusterIssuer("test-clusterissuer",
+				gen.SetIssuerNamespaceAccess(cmapi.IssuerNamespaceAccess{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pki": "enabled"}},
+				}),
+			),
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            ApprovedMessage,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
+		},
+		"deny CertificateRequest if the ClusterIssuer does not allow the namespace": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "test-clusterissuer", Kind: cmapi.ClusterIssuerKind},
+				},
+			},
+			clusterIssuer: gen.ClusterIssuer("test-clusterissuer",
+				gen.SetIssuerNamespaceAccess(cmapi.IssuerNamespaceAccess{Namespaces: []string{"other-ns"}}),
+			),
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionDenied,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             DeniedReasonNamespaceNotAllowed,
+					Message:            `ClusterIssuer "test-clusterissuer" cannot be used from namespace "testns"`,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: `Warning NamespaceNotAllowed ClusterIssuer "test-clusterissuer" cannot be used from namespace "testns"`,
+		},
+		"approve CertificateRequest if no CertificateRequestPolicy applies to its issuer": {
+			request: gen.CertificateRequest("test",
+				gen.SetCertificateRequestNamespace("testns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "other-ca"}),
+				gen.SetCertificateRequestCSR(exampleOrgCSR),
+			),
+			policies: []*cmexperimental.CertificateRequestPolicy{examplePolicy},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            ApprovedMessage,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
+		},
+		"approve CertificateRequest allowed by a CertificateRequestPolicy": {
+			request: gen.CertificateRequest("test",
+				gen.SetCertificateRequestNamespace("testns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca"}),
+				gen.SetCertificateRequestCSR(exampleComCSR),
+			),
+			policies: []*cmexperimental.CertificateRequestPolicy{examplePolicy},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            `Approved by CertificateRequestPolicy "allow-example"`,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: `Normal cert-manager.io Approved by CertificateRequestPolicy "allow-example"`,
+		},
+		"deny CertificateRequest not allowed by any applicable CertificateRequestPolicy": {
+			request: gen.CertificateRequest("test",
+				gen.SetCertificateRequestNamespace("testns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca"}),
+				gen.SetCertificateRequestCSR(exampleOrgCSR),
+			),
+			policies: []*cmexperimental.CertificateRequestPolicy{examplePolicy},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionDenied,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             DeniedReasonPolicy,
+					Message:            `Denied by CertificateRequestPolicies: [allow-example: DNS name "example.org" is not allowed]`,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: `Warning PolicyDenied Denied by CertificateRequestPolicies: [allow-example: DNS name "example.org" is not allowed]`,
+		},
+		"approve CertificateRequest approved by the approval webhook": {
+			request: gen.CertificateRequest("test",
+				gen.SetCertificateRequestNamespace("testns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca"}),
+				gen.SetCertificateRequestCSR(exampleComCSR),
+			),
+			webhookStatus:  cmexperimental.CertificateRequestReviewApproved,
+			webhookMessage: "Approved in ticket SEC-123",
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            "Approved in ticket SEC-123",
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Normal cert-manager.io Approved in ticket SEC-123",
+		},
+		"deny CertificateRequest denied by the approval webhook": {
+			request: gen.CertificateRequest("test",
+				gen.SetCertificateRequestNamespace("testns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca"}),
+				gen.SetCertificateRequestCSR(exampleComCSR),
+			),
+			webhookStatus: cmexperimental.CertificateRequestReviewDenied,
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionDenied,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             DeniedReasonWebhook,
+					Message:            WebhookDeniedMessage,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Warning WebhookDenied " + WebhookDeniedMessage,
+		},
+		"do nothing if the approval webhook review is pending": {
+			request: gen.CertificateRequest("test",
+				gen.SetCertificateRequestNamespace("testns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca"}),
+				gen.SetCertificateRequestCSR(exampleComCSR),
+			),
+			webhookStatus: cmexperimental.CertificateRequestReviewPending,
+		},
+		"do not call the approval webhook if a CertificateRequestPolicy denies the request": {
+			request: gen.CertificateRequest("test",
+				gen.SetCertificateRequestNamespace("testns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca"}),
+				gen.SetCertificateRequestCSR(exampleOrgCSR),
+			),
+			policies:      []*cmexperimental.CertificateRequestPolicy{examplePolicy},
+			webhookStatus: cmexperimental.CertificateRequestReviewApproved,
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionDenied,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             DeniedReasonPolicy,
+					Message:            `Denied by CertificateRequestPolicies: [allow-example: DNS name "example.org" is not allowed]`,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: `Warning PolicyDenied Denied by CertificateRequestPolicies: [allow-example: DNS name "example.org" is not allowed]`,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
+			if test.policies != nil {
+				defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature.CertificateRequestPolicies, true)()
+			}
+
 			// Create and initialise a new unit test builder
 			builder := &testpkg.Builder{
 				T:     t,
 				Clock: fakeclock.NewFakeClock(now),
+				KubeObjects: []runtime.Object{
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testns", Labels: map[string]string{"pki": "enabled"}}},
+				},
 			}
 			if test.request != nil {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.request)
 			}
+			if test.clusterIssuer != nil {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, test.clusterIssuer)
+			}
+			for _, policy := range test.policies {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, policy)
+			}
 			builder.Init()
 
+			if test.webhookStatus != "" {
+				server := newTestWebhookServer(test.webhookStatus, test.webhookMessage)
+				defer server.Close()
+				builder.Context.ApprovalOptions = controllerpkg.ApprovalOptions{
+					WebhookURL:          server.URL,
+					WebhookTimeout:      time.Second,
+					WebhookPollInterval: time.Minute,
+				}
+			}
+
 			c := new(Controller)
 			_, _, err := c.Register(builder.Context)
 			if err != nil {
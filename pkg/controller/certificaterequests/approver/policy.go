@@ -0,0 +1,330 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmexperimental "github.com/jetstack/cert-manager/pkg/apis/experimental/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// policyDenied evaluates the CertificateRequestPolicies which apply to the
+// CertificateRequest. It returns the message the request should be approved
+// or denied with, and whether it should be denied.
+// CertificateRequests which no policy applies to are approved.
+func (c *Controller) policyDenied(cr *cmapi.CertificateRequest) (string, bool, error) {
+	if c.policyLister == nil {
+		return ApprovedMessage, false, nil
+	}
+
+	policies, err := c.policyLister.List(labels.Everything())
+	if err != nil {
+		return "", false, err
+	}
+
+	var applicable []*cmexperimental.CertificateRequestPolicy
+	for _, policy := range policies {
+		if policyAppliesToIssuer(&policy.Spec, cr.Spec.IssuerRef.Name, cr.Spec.IssuerRef.Kind, cr.Spec.IssuerRef.Group) {
+			applicable = append(applicable, policy)
+		}
+	}
+	if len(applicable) == 0 {
+		return ApprovedMessage, false, nil
+	}
+	sort.Slice(applicable, func(i, j int) bool {
+		return applicable[i].Name < applicable[j].Name
+	})
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return fmt.Sprintf("Failed to decode certificate request to evaluate CertificateRequestPolicies: %v", err), true, nil
+	}
+
+	var violations []string
+	for _, policy := range applicable {
+		errs := evaluatePolicy(&policy.Spec, cr, csr)
+		if len(errs) == 0 {
+			return fmt.Sprintf("Approved by CertificateRequestPolicy %q", policy.Name), false, nil
+		}
+		violations = append(violations, fmt.Sprintf("%s: %s", policy.Name, strings.Join(errs, ", ")))
+	}
+
+	return fmt.Sprintf("Denied by CertificateRequestPolicies: [%s]", strings.Join(violations, "; ")), true, nil
+}
+
+// policyAppliesToIssuer returns true if the policy applies to
+// CertificateRequests referencing the given issuer.
+func policyAppliesToIssuer(spec *cmexperimental.CertificateRequestPolicySpec, name, kind, group string) bool {
+	if len(spec.IssuerRefs) == 0 {
+		return true
+	}
+	if kind == "" {
+		kind = cmapi.IssuerKind
+	}
+	if group == "" {
+		group = certmanager.GroupName
+	}
+	for _, ref := range spec.IssuerRefs {
+		if (ref.Name == "" || matchPattern(ref.Name, name)) &&
+			(ref.Kind == "" || matchPattern(ref.Kind, kind)) &&
+			(ref.Group == "" || matchPattern(ref.Group, group)) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluatePolicy returns the list of constraints of the policy that the
+// CertificateRequest violates.
+func evaluatePolicy(spec *cmexperimental.CertificateRequestPolicySpec, cr *cmapi.CertificateRequest, csr *x509.CertificateRequest) []string {
+	var errs []string
+
+	if spec.Requesters != nil && !requesterAllowed(spec.Requesters, cr) {
+		errs = append(errs, fmt.Sprintf("requester %q is not allowed", cr.Spec.Username))
+	}
+
+	errs = append(errs, identityViolations(spec, csr)...)
+
+	if spec.MaxDuration != nil {
+		duration := cmapi.DefaultCertificateDuration
+		if cr.Spec.Duration != nil {
+			duration = cr.Spec.Duration.Duration
+		}
+		if duration > spec.MaxDuration.Duration {
+			errs = append(errs, fmt.Sprintf("duration %s exceeds the maximum of %s", duration, spec.MaxDuration.Duration))
+		}
+	}
+
+	if spec.KeyAlgorithms != nil {
+		if err := keyAlgorithmAllowed(spec.KeyAlgorithms, csr); err != "" {
+			errs = append(errs, err)
+		}
+	}
+
+	if spec.Usages != nil {
+		usages := cr.Spec.Usages
+		if len(usages) == 0 {
+			usages = cmapi.DefaultKeyUsages()
+		}
+		for _, usage := range usages {
+			if !usageAllowed(spec.Usages, usage) {
+				errs = append(errs, fmt.Sprintf("usage %q is not allowed", usage))
+			}
+		}
+	}
+
+	if cr.Spec.IsCA && !spec.AllowCA {
+		errs = append(errs, "CA certificates are not allowed")
+	}
+
+	if spec.Subject != nil {
+		errs = append(errs, subjectViolations(spec.Subject, csr)...)
+	}
+
+	return errs
+}
+
+// identityViolations returns the identities requested by the certificate
+// request which do not match the allowed patterns. If the policy constrains
+// any type of identity, identities of the types it does not constrain are
+// not allowed either, so that they cannot be used to bypass the constraints.
+func identityViolations(spec *cmexperimental.CertificateRequestPolicySpec, csr *x509.CertificateRequest) []string {
+	if spec.DNSNames == nil && spec.URIs == nil && spec.IPAddresses == nil && spec.EmailAddresses == nil {
+		return nil
+	}
+
+	var errs []string
+
+	// The common name is commonly used as a DNS name, so it must be allowed
+	// as one unless the policy constrains the common name explicitly.
+	cn := csr.Subject.CommonName
+	if cn != "" && (spec.Subject == nil || spec.Subject.CommonName == nil) && !containsString(csr.DNSNames, cn) &&
+		(spec.DNSNames == nil || !matchAnyPattern(spec.DNSNames, cn)) {
+		errs = append(errs, fmt.Sprintf("common name %q is not allowed", cn))
+	}
+
+	var uris, ips []string
+	for _, uri := range csr.URIs {
+		uris = append(uris, uri.String())
+	}
+	for _, ip := range csr.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+
+	identities := []struct {
+		name     string
+		patterns []string
+		values   []string
+	}{
+		{"DNS name", spec.DNSNames, csr.DNSNames},
+		{"URI", spec.URIs, uris},
+		{"IP address", spec.IPAddresses, ips},
+		{"email address", spec.EmailAddresses, csr.EmailAddresses},
+	}
+	for _, identity := range identities {
+		for _, value := range identity.values {
+			if identity.patterns == nil || !matchAnyPattern(identity.patterns, value) {
+				errs = append(errs, fmt.Sprintf("%s %q is not allowed", identity.name, value))
+			}
+		}
+	}
+	return errs
+}
+
+// requesterAllowed returns true if the username or one of the groups of the
+// requester match the allowed patterns.
+func requesterAllowed(requesters *cmexperimental.CertificateRequestPolicyRequesters, cr *cmapi.CertificateRequest) bool {
+	if matchAnyPattern(requesters.Usernames, cr.Spec.Username) {
+		return true
+	}
+	for _, group := range cr.Spec.Groups {
+		if matchAnyPattern(requesters.Groups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyAlgorithmAllowed returns a description of the violation if the public
+// key of the certificate request does not use one of the allowed algorithms
+// and sizes, and an empty string otherwise.
+func keyAlgorithmAllowed(allowed []cmexperimental.CertificateRequestPolicyKeyAlgorithm, csr *x509.CertificateRequest) string {
+	algorithm, size, err := publicKeyAlgorithm(csr)
+	if err != nil {
+		return err.Error()
+	}
+
+	for _, a := range allowed {
+		if a.Algorithm != algorithm {
+			continue
+		}
+		if (a.MinSize == 0 || size >= a.MinSize) && (a.MaxSize == 0 || size <= a.MaxSize) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s key of size %d is not allowed", algorithm, size)
+}
+
+// publicKeyAlgorithm returns the algorithm and size of the public key of the
+// certificate request. For ECDSA keys, the size is the size of the curve.
+func publicKeyAlgorithm(csr *x509.CertificateRequest) (cmapi.PrivateKeyAlgorithm, int, error) {
+	switch pub := csr.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return cmapi.RSAKeyAlgorithm, pub.N.BitLen(), nil
+	case *ecdsa.PublicKey:
+		return cmapi.ECDSAKeyAlgorithm, pub.Curve.Params().BitSize, nil
+	case ed25519.PublicKey:
+		return cmapi.Ed25519KeyAlgorithm, 256, nil
+	default:
+		return "", 0, fmt.Errorf("unsupported public key type %T", csr.PublicKey)
+	}
+}
+
+func usageAllowed(allowed []cmapi.KeyUsage, usage cmapi.KeyUsage) bool {
+	for _, a := range allowed {
+		if a == usage {
+			return true
+		}
+	}
+	return false
+}
+
+// subjectViolations returns the subject fields of the certificate request
+// which do not match the allowed patterns.
+func subjectViolations(subject *cmexperimental.CertificateRequestPolicySubject, csr *x509.CertificateRequest) []string {
+	var errs []string
+	if subject.CommonName != nil && csr.Subject.CommonName != "" && !matchPattern(*subject.CommonName, csr.Subject.CommonName) {
+		errs = append(errs, fmt.Sprintf("common name %q is not allowed", csr.Subject.CommonName))
+	}
+
+	fields := []struct {
+		name     string
+		patterns []string
+		values   []string
+	}{
+		{"organization", subject.Organizations, csr.Subject.Organization},
+		{"organizational unit", subject.OrganizationalUnits, csr.Subject.OrganizationalUnit},
+		{"country", subject.Countries, csr.Subject.Country},
+		{"locality", subject.Localities, csr.Subject.Locality},
+		{"province", subject.Provinces, csr.Subject.Province},
+	}
+	for _, field := range fields {
+		if field.patterns == nil {
+			continue
+		}
+		for _, value := range field.values {
+			if !matchAnyPattern(field.patterns, value) {
+				errs = append(errs, fmt.Sprintf("%s %q is not allowed", field.name, value))
+			}
+		}
+	}
+	return errs
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAnyPattern(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if matchPattern(pattern, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern returns true if s matches the pattern, in which the wildcard
+// character `*` matches any sequence of characters.
+func matchPattern(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+
+	return len(s) >= len(last) && strings.HasSuffix(s, last)
+}
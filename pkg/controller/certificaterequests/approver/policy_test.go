@@ -0,0 +1,295 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"crypto/x509"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmexperimental "github.com/jetstack/cert-manager/pkg/apis/experimental/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		match      bool
+	}{
+		{"example.com", "example.com", true},
+		{"example.com", "www.example.com", false},
+		{"*.example.com", "www.example.com", true},
+		{"*.example.com", "a.b.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*", "", true},
+		{"spiffe://cluster.local/ns/*/sa/*", "spiffe://cluster.local/ns/foo/sa/bar", true},
+		{"spiffe://cluster.local/ns/*/sa/*", "spiffe://cluster.local/ns/foo", false},
+		{"a*a", "a", false},
+		{"a*a", "aa", true},
+		{"system:serviceaccount:*:builder", "system:serviceaccount:ci:builder", true},
+	}
+	for _, test := range tests {
+		if got := matchPattern(test.pattern, test.s); got != test.match {
+			t.Errorf("matchPattern(%q, %q) = %t, expected %t", test.pattern, test.s, got, test.match)
+		}
+	}
+}
+
+func TestPolicyAppliesToIssuer(t *testing.T) {
+	tests := map[string]struct {
+		issuerRefs              []cmexperimental.CertificateRequestPolicyIssuerRef
+		name, kind, group       string
+		expectedAppliesToIssuer bool
+	}{
+		"no issuerRefs applies to all issuers": {
+			name:                    "ca",
+			expectedAppliesToIssuer: true,
+		},
+		"empty kind and group match Issuers in the cert-manager.io group": {
+			issuerRefs: []cmexperimental.CertificateRequestPolicyIssuerRef{
+				{Name: "ca", Kind: "Issuer", Group: "cert-manager.io"},
+			},
+			name:                    "ca",
+			expectedAppliesToIssuer: true,
+		},
+		"kind does not match": {
+			issuerRefs: []cmexperimental.CertificateRequestPolicyIssuerRef{
+				{Name: "ca", Kind: "ClusterIssuer"},
+			},
+			name:                    "ca",
+			kind:                    "Issuer",
+			expectedAppliesToIssuer: false,
+		},
+		"external issuer matched by group pattern": {
+			issuerRefs: []cmexperimental.CertificateRequestPolicyIssuerRef{
+				{Group: "*.example.com"},
+			},
+			name:                    "external",
+			kind:                    "ExternalIssuer",
+			group:                   "issuers.example.com",
+			expectedAppliesToIssuer: true,
+		},
+		"name pattern does not match": {
+			issuerRefs: []cmexperimental.CertificateRequestPolicyIssuerRef{
+				{Name: "prod-*"},
+			},
+			name:                    "dev-ca",
+			expectedAppliesToIssuer: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := &cmexperimental.CertificateRequestPolicySpec{IssuerRefs: test.issuerRefs}
+			if got := policyAppliesToIssuer(spec, test.name, test.kind, test.group); got != test.expectedAppliesToIssuer {
+				t.Errorf("unexpected result, exp=%t got=%t", test.expectedAppliesToIssuer, got)
+			}
+		})
+	}
+}
+
+func TestEvaluatePolicy(t *testing.T) {
+	mustCSR := func(alg x509.PublicKeyAlgorithm, mods ...gen.CSRModifier) *x509.CertificateRequest {
+		csrPEM, _, err := gen.CSR(alg, mods...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return csr
+	}
+	commonName := "*.example.com"
+	spiffeURI, _ := url.Parse("spiffe://cluster.local/ns/sandbox/sa/default")
+
+	tests := map[string]struct {
+		spec           cmexperimental.CertificateRequestPolicySpec
+		request        *cmapi.CertificateRequest
+		csr            *x509.CertificateRequest
+		expectedErrors []string
+	}{
+		"an empty policy allows any non-CA request": {
+			request: gen.CertificateRequest("test"),
+			csr:     mustCSR(x509.RSA, gen.SetCSRDNSNames("example.com")),
+		},
+		"an empty policy does not allow CA requests": {
+			request:        gen.CertificateRequest("test", gen.SetCertificateRequestIsCA(true)),
+			csr:            mustCSR(x509.RSA),
+			expectedErrors: []string{"CA certificates are not allowed"},
+		},
+		"CA requests are allowed if allowCA is set": {
+			spec:    cmexperimental.CertificateRequestPolicySpec{AllowCA: true},
+			request: gen.CertificateRequest("test", gen.SetCertificateRequestIsCA(true)),
+			csr:     mustCSR(x509.RSA),
+		},
+		"requester matched by username": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				Requesters: &cmexperimental.CertificateRequestPolicyRequesters{Usernames: []string{"system:serviceaccount:ci:*"}},
+			},
+			request: gen.CertificateRequest("test", gen.SetCertificateRequestUsername("system:serviceaccount:ci:builder")),
+			csr:     mustCSR(x509.RSA),
+		},
+		"requester matched by group": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				Requesters: &cmexperimental.CertificateRequestPolicyRequesters{Groups: []string{"pki-admins"}},
+			},
+			request: gen.CertificateRequest("test",
+				gen.SetCertificateRequestUsername("alice"),
+				gen.SetCertificateRequestGroups([]string{"system:authenticated", "pki-admins"}),
+			),
+			csr: mustCSR(x509.RSA),
+		},
+		"requester not allowed": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				Requesters: &cmexperimental.CertificateRequestPolicyRequesters{Groups: []string{"pki-admins"}},
+			},
+			request:        gen.CertificateRequest("test", gen.SetCertificateRequestUsername("bob")),
+			csr:            mustCSR(x509.RSA),
+			expectedErrors: []string{`requester "bob" is not allowed`},
+		},
+		"DNS names and URIs not matching the patterns": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				DNSNames: []string{"*.example.com"},
+				URIs:     []string{"spiffe://cluster.local/ns/production/*"},
+			},
+			request: gen.CertificateRequest("test"),
+			csr: mustCSR(x509.RSA,
+				gen.SetCSRDNSNames("www.example.com", "example.org"),
+				gen.SetCSRURIs(spiffeURI),
+			),
+			expectedErrors: []string{
+				`DNS name "example.org" is not allowed`,
+				`URI "spiffe://cluster.local/ns/sandbox/sa/default" is not allowed`,
+			},
+		},
+		"common name not matching the DNS name patterns": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				DNSNames: []string{"*.example.com"},
+			},
+			request:        gen.CertificateRequest("test"),
+			csr:            mustCSR(x509.RSA, gen.SetCSRCommonName("example.org")),
+			expectedErrors: []string{`common name "example.org" is not allowed`},
+		},
+		"common name matching the DNS name patterns": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				DNSNames: []string{"*.example.com"},
+			},
+			request: gen.CertificateRequest("test"),
+			csr:     mustCSR(x509.RSA, gen.SetCSRCommonName("www.example.com")),
+		},
+		"IP addresses are denied if only DNS names are constrained": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				DNSNames: []string{"*.example.com"},
+			},
+			request: gen.CertificateRequest("test"),
+			csr: mustCSR(x509.RSA,
+				gen.SetCSRDNSNames("www.example.com"),
+				gen.SetCSRIPAddresses(net.ParseIP("10.0.0.1")),
+			),
+			expectedErrors: []string{`IP address "10.0.0.1" is not allowed`},
+		},
+		"IP and email addresses not matching the patterns": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				IPAddresses:    []string{"10.0.0.*"},
+				EmailAddresses: []string{"*@example.com"},
+			},
+			request: gen.CertificateRequest("test"),
+			csr: mustCSR(x509.RSA,
+				gen.SetCSRIPAddresses(net.ParseIP("10.0.0.1"), net.ParseIP("192.168.0.1")),
+				gen.SetCSREmails([]string{"alice@example.com", "bob@example.org"}),
+			),
+			expectedErrors: []string{
+				`IP address "192.168.0.1" is not allowed`,
+				`email address "bob@example.org" is not allowed`,
+			},
+		},
+		"default duration exceeds the maximum duration": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				MaxDuration: &metav1.Duration{Duration: time.Hour * 24 * 30},
+			},
+			request:        gen.CertificateRequest("test"),
+			csr:            mustCSR(x509.RSA),
+			expectedErrors: []string{"duration 2160h0m0s exceeds the maximum of 720h0m0s"},
+		},
+		"requested duration within the maximum duration": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				MaxDuration: &metav1.Duration{Duration: time.Hour * 24 * 30},
+			},
+			request: gen.CertificateRequest("test", gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour})),
+			csr:     mustCSR(x509.RSA),
+		},
+		"key algorithm and size allowed": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				KeyAlgorithms: []cmexperimental.CertificateRequestPolicyKeyAlgorithm{
+					{Algorithm: cmapi.RSAKeyAlgorithm, MinSize: 3072},
+					{Algorithm: cmapi.ECDSAKeyAlgorithm, MaxSize: 384},
+				},
+			},
+			request: gen.CertificateRequest("test"),
+			csr:     mustCSR(x509.ECDSA),
+		},
+		"key size too small": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				KeyAlgorithms: []cmexperimental.CertificateRequestPolicyKeyAlgorithm{
+					{Algorithm: cmapi.RSAKeyAlgorithm, MinSize: 3072},
+				},
+			},
+			request:        gen.CertificateRequest("test"),
+			csr:            mustCSR(x509.RSA),
+			expectedErrors: []string{"RSA key of size 2048 is not allowed"},
+		},
+		"default usages are evaluated if none are requested": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				Usages: []cmapi.KeyUsage{cmapi.UsageDigitalSignature},
+			},
+			request:        gen.CertificateRequest("test"),
+			csr:            mustCSR(x509.RSA),
+			expectedErrors: []string{`usage "key encipherment" is not allowed`},
+		},
+		"subject fields not matching the patterns": {
+			spec: cmexperimental.CertificateRequestPolicySpec{
+				Subject: &cmexperimental.CertificateRequestPolicySubject{
+					CommonName:    &commonName,
+					Organizations: []string{"Example Inc"},
+				},
+			},
+			request: gen.CertificateRequest("test"),
+			csr: mustCSR(x509.RSA, gen.SetCSRCommonName("example.org"), func(csr *x509.CertificateRequest) {
+				csr.Subject.Organization = []string{"Example Inc", "Other Corp"}
+			}),
+			expectedErrors: []string{
+				`common name "example.org" is not allowed`,
+				`organization "Other Corp" is not allowed`,
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := evaluatePolicy(&test.spec, test.request, test.csr)
+			if !reflect.DeepEqual(errs, test.expectedErrors) {
+				t.Errorf("unexpected violations, exp=%q got=%q", test.expectedErrors, errs)
+			}
+		})
+	}
+}
@@ -18,23 +18,45 @@ package approver
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
 
 const (
 	ApprovedMessage = "Certificate request has been approved by cert-manager.io"
+
+	// DeniedReasonNamespaceNotAllowed is the reason of the Denied condition of
+	// CertificateRequests referencing a ClusterIssuer which may not be used
+	// from their namespace. It matches the reason the signing controllers
+	// fail such requests with.
+	DeniedReasonNamespaceNotAllowed = "NamespaceNotAllowed"
+
+	// DeniedReasonPolicy is the reason of the Denied condition of
+	// CertificateRequests denied by the CertificateRequestPolicies which
+	// apply to them.
+	DeniedReasonPolicy = "PolicyDenied"
+
+	// DeniedReasonWebhook is the reason of the Denied condition of
+	// CertificateRequests denied by the approval webhook.
+	DeniedReasonWebhook = "WebhookDenied"
 )
 
 // Sync will set the "Approved" condition to True on synced
-// CertificateRequests. If the "Denied", "Approved" or "Ready" condition
-// already exists, exit early.
+// CertificateRequests, or the "Denied" condition to True if the request is
+// not allowed by the namespace access restrictions of its ClusterIssuer, by
+// the CertificateRequestPolicies which apply to it or by the approval
+// webhook. If the "Denied", "Approved" or "Ready" condition already exists,
+// exit early.
 func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (err error) {
 	log := logf.FromContext(ctx, "approver")
 
@@ -52,13 +74,66 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	// Deny the CertificateRequest if it references a ClusterIssuer which may
+	// not be used from its namespace.
+	deniedReason := DeniedReasonNamespaceNotAllowed
+	message, denied, err := c.namespaceDenied(cr)
+	if err != nil {
+		return err
+	}
+	// Otherwise, evaluate the CertificateRequestPolicies which apply to it.
+	if !denied {
+		deniedReason = DeniedReasonPolicy
+		message, denied, err = c.policyDenied(cr)
+		if err != nil {
+			return err
+		}
+	}
+	// Otherwise, if an approval webhook is configured, it has the final say.
+	if !denied && c.webhook != nil {
+		deniedReason = DeniedReasonWebhook
+		var pending bool
+		message, denied, pending, err = c.webhookReview(ctx, cr)
+		if err != nil {
+			return err
+		}
+		if pending {
+			key, err := controllerpkg.KeyFunc(cr)
+			if err != nil {
+				return err
+			}
+			log.V(logf.DebugLevel).Info("approval webhook review is pending", "message", message, "poll_interval", c.webhookPollInterval)
+			c.queue.AddAfter(key, c.webhookPollInterval)
+			return nil
+		}
+	}
+	if denied {
+		cr = cr.DeepCopy()
+		apiutil.SetCertificateRequestCondition(cr,
+			cmapi.CertificateRequestConditionDenied,
+			cmmeta.ConditionTrue,
+			deniedReason,
+			message,
+		)
+
+		_, err = c.cmClient.CertmanagerV1().CertificateRequests(cr.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		c.recorder.Event(cr, corev1.EventTypeWarning, deniedReason, message)
+
+		log.V(logf.DebugLevel).Info("denied certificate request", "reason", deniedReason, "message", message)
+
+		return nil
+	}
+
 	// Update the CertificateRequest approved condition to true.
 	cr = cr.DeepCopy()
 	apiutil.SetCertificateRequestCondition(cr,
 		cmapi.CertificateRequestConditionApproved,
 		cmmeta.ConditionTrue,
 		"cert-manager.io",
-		ApprovedMessage,
+		message,
 	)
 
 	// Update CertificateRequest with
@@ -66,9 +141,44 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	if err != nil {
 		return err
 	}
-	c.recorder.Event(cr, corev1.EventTypeNormal, "cert-manager.io", ApprovedMessage)
+	c.recorder.Event(cr, corev1.EventTypeNormal, "cert-manager.io", message)
 
 	log.V(logf.DebugLevel).Info("approved certificate request")
 
 	return nil
 }
+
+// namespaceDenied returns true and a message explaining why if the
+// CertificateRequest references a ClusterIssuer whose namespace access
+// restrictions do not allow it to be used from the namespace of the request.
+// ClusterIssuers which do not exist yet are left to the signing controllers.
+func (c *Controller) namespaceDenied(cr *cmapi.CertificateRequest) (string, bool, error) {
+	ref := cr.Spec.IssuerRef
+	if c.clusterIssuerLister == nil ||
+		!(ref.Group == "" || ref.Group == certmanager.GroupName) ||
+		ref.Kind != cmapi.ClusterIssuerKind {
+		return "", false, nil
+	}
+
+	iss, err := c.clusterIssuerLister.Get(ref.Name)
+	if apierrors.IsNotFound(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if iss.Spec.NamespaceAccess == nil {
+		return "", false, nil
+	}
+
+	namespace, err := c.namespaceLister.Get(cr.Namespace)
+	if err != nil {
+		return "", false, err
+	}
+	allowed, err := apiutil.IssuerAllowsNamespace(iss, namespace)
+	if err != nil || allowed {
+		return "", false, err
+	}
+
+	return fmt.Sprintf("ClusterIssuer %q cannot be used from namespace %q", ref.Name, cr.Namespace), true, nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmexperimental "github.com/jetstack/cert-manager/pkg/apis/experimental/v1alpha1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	// WebhookApprovedMessage is the message CertificateRequests approved by
+	// the approval webhook are approved with if the webhook does not give one.
+	WebhookApprovedMessage = "Certificate request has been approved by the approval webhook"
+
+	// WebhookDeniedMessage is the message CertificateRequests denied by the
+	// approval webhook are denied with if the webhook does not give one.
+	WebhookDeniedMessage = "Certificate request has been denied by the approval webhook"
+)
+
+// approvalWebhook sends CertificateRequestReviews to an external approval
+// webhook.
+type approvalWebhook struct {
+	url    string
+	client *http.Client
+}
+
+func newApprovalWebhook(opts controllerpkg.ApprovalOptions) (*approvalWebhook, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(opts.WebhookCABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(opts.WebhookCABundle) {
+			return nil, errors.New("no certificates found in the approval webhook CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return &approvalWebhook{
+		url: opts.WebhookURL,
+		client: &http.Client{
+			Timeout:   opts.WebhookTimeout,
+			Transport: transport,
+		},
+	}, nil
+}
+
+// review POSTs a CertificateRequestReview for the request to the webhook, and
+// returns the response of the webhook.
+func (w *approvalWebhook) review(ctx context.Context, req *cmexperimental.CertificateRequestReviewRequest) (*cmexperimental.CertificateRequestReviewResponse, error) {
+	body, err := json.Marshal(&cmexperimental.CertificateRequestReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: cmexperimental.SchemeGroupVersion.String(),
+			Kind:       "CertificateRequestReview",
+		},
+		Request: req,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call approval webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read approval webhook response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("approval webhook responded with unexpected status code %d: %s", resp.StatusCode, respBody)
+	}
+
+	var review cmexperimental.CertificateRequestReview
+	if err := json.Unmarshal(respBody, &review); err != nil {
+		return nil, fmt.Errorf("failed to decode approval webhook response: %w", err)
+	}
+	if review.Response == nil {
+		return nil, errors.New("approval webhook response does not contain a response")
+	}
+	if review.Response.UID != req.UID {
+		return nil, fmt.Errorf("approval webhook response is for UID %q, expected %q", review.Response.UID, req.UID)
+	}
+	switch review.Response.Status {
+	case cmexperimental.CertificateRequestReviewApproved,
+		cmexperimental.CertificateRequestReviewDenied,
+		cmexperimental.CertificateRequestReviewPending:
+	default:
+		return nil, fmt.Errorf("approval webhook responded with unknown status %q", review.Response.Status)
+	}
+
+	return review.Response, nil
+}
+
+// buildReviewRequest builds the CertificateRequestReviewRequest sent to the
+// approval webhook for the CertificateRequest.
+func buildReviewRequest(cr *cmapi.CertificateRequest) (*cmexperimental.CertificateRequestReviewRequest, error) {
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, size, err := publicKeyAlgorithm(csr)
+	if err != nil {
+		return nil, err
+	}
+
+	var extra map[string]authenticationv1.ExtraValue
+	if len(cr.Spec.Extra) > 0 {
+		extra = make(map[string]authenticationv1.ExtraValue, len(cr.Spec.Extra))
+		for k, v := range cr.Spec.Extra {
+			extra[k] = v
+		}
+	}
+
+	req := &cmexperimental.CertificateRequestReviewRequest{
+		UID:       cr.UID,
+		Name:      cr.Name,
+		Namespace: cr.Namespace,
+		IssuerRef: cr.Spec.IssuerRef,
+		UserInfo: authenticationv1.UserInfo{
+			Username: cr.Spec.Username,
+			UID:      cr.Spec.UID,
+			Groups:   cr.Spec.Groups,
+			Extra:    extra,
+		},
+		Duration:       cr.Spec.Duration,
+		IsCA:           cr.Spec.IsCA,
+		Usages:         cr.Spec.Usages,
+		Request:        cr.Spec.Request,
+		CommonName:     csr.Subject.CommonName,
+		DNSNames:       csr.DNSNames,
+		IPAddresses:    pki.IPAddressesToString(csr.IPAddresses),
+		URIs:           pki.URLsToString(csr.URIs),
+		EmailAddresses: csr.EmailAddresses,
+		KeyAlgorithm:   algorithm,
+		KeySize:        size,
+	}
+
+	subject := cmapi.X509Subject{
+		Organizations:       csr.Subject.Organization,
+		Countries:           csr.Subject.Country,
+		OrganizationalUnits: csr.Subject.OrganizationalUnit,
+		Localities:          csr.Subject.Locality,
+		Provinces:           csr.Subject.Province,
+		StreetAddresses:     csr.Subject.StreetAddress,
+		PostalCodes:         csr.Subject.PostalCode,
+		SerialNumber:        csr.Subject.SerialNumber,
+	}
+	if !apiequality.Semantic.DeepEqual(subject, cmapi.X509Subject{}) {
+		req.Subject = &subject
+	}
+
+	return req, nil
+}
+
+// webhookReview sends the CertificateRequest to the approval webhook. It
+// returns the message the request should be approved or denied with, whether
+// it should be denied, and whether the review is still pending.
+func (c *Controller) webhookReview(ctx context.Context, cr *cmapi.CertificateRequest) (message string, denied, pending bool, err error) {
+	req, err := buildReviewRequest(cr)
+	if err != nil {
+		return fmt.Sprintf("Failed to decode certificate request for the approval webhook: %v", err), true, false, nil
+	}
+
+	resp, err := c.webhook.review(ctx, req)
+	if err != nil {
+		return "", false, false, err
+	}
+
+	switch resp.Status {
+	case cmexperimental.CertificateRequestReviewApproved:
+		if resp.Message == "" {
+			return WebhookApprovedMessage, false, false, nil
+		}
+		return resp.Message, false, false, nil
+	case cmexperimental.CertificateRequestReviewDenied:
+		if resp.Message == "" {
+			return WebhookDeniedMessage, true, false, nil
+		}
+		return resp.Message, true, false, nil
+	default:
+		return resp.Message, false, true, nil
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmexperimental "github.com/jetstack/cert-manager/pkg/apis/experimental/v1alpha1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestBuildReviewRequest(t *testing.T) {
+	csrPEM, _, err := gen.CSR(x509.ECDSA,
+		gen.SetCSRCommonName("www.example.com"),
+		gen.SetCSRDNSNames("www.example.com", "example.com"),
+		func(csr *x509.CertificateRequest) {
+			csr.Subject.Organization = []string{"Example Inc"}
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := gen.CertificateRequest("test",
+		gen.SetCertificateRequestNamespace("testns"),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca", Kind: cmapi.IssuerKind}),
+		gen.SetCertificateRequestCSR(csrPEM),
+		gen.SetCertificateRequestUsername("alice"),
+		gen.SetCertificateRequestGroups([]string{"system:authenticated"}),
+		gen.SetCertificateRequestKeyUsages(cmapi.UsageServerAuth),
+	)
+	cr.UID = "test-uid"
+
+	req, err := buildReviewRequest(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &cmexperimental.CertificateRequestReviewRequest{
+		UID:       "test-uid",
+		Name:      "test",
+		Namespace: "testns",
+		IssuerRef: cmmeta.ObjectReference{Name: "ca", Kind: cmapi.IssuerKind},
+		UserInfo: authenticationv1.UserInfo{
+			Username: "alice",
+			Groups:   []string{"system:authenticated"},
+		},
+		Usages:       []cmapi.KeyUsage{cmapi.UsageServerAuth},
+		Request:      csrPEM,
+		CommonName:   "www.example.com",
+		Subject:      &cmapi.X509Subject{Organizations: []string{"Example Inc"}},
+		DNSNames:     []string{"www.example.com", "example.com"},
+		KeyAlgorithm: cmapi.ECDSAKeyAlgorithm,
+		KeySize:      256,
+	}
+	if !reflect.DeepEqual(req, expected) {
+		t.Errorf("unexpected review request, exp=%+v got=%+v", expected, req)
+	}
+}
+
+func TestApprovalWebhookReview(t *testing.T) {
+	tests := map[string]struct {
+		statusCode int
+		response   interface{}
+
+		expectedResponse *cmexperimental.CertificateRequestReviewResponse
+		expectedErr      bool
+	}{
+		"approved response is returned": {
+			statusCode: http.StatusOK,
+			response: cmexperimental.CertificateRequestReview{
+				Response: &cmexperimental.CertificateRequestReviewResponse{UID: "test-uid", Status: "Approved", Message: "ticket SEC-123"},
+			},
+			expectedResponse: &cmexperimental.CertificateRequestReviewResponse{UID: "test-uid", Status: "Approved", Message: "ticket SEC-123"},
+		},
+		"pending response is returned": {
+			statusCode: http.StatusOK,
+			response: cmexperimental.CertificateRequestReview{
+				Response: &cmexperimental.CertificateRequestReviewResponse{UID: "test-uid", Status: "Pending"},
+			},
+			expectedResponse: &cmexperimental.CertificateRequestReviewResponse{UID: "test-uid", Status: "Pending"},
+		},
+		"error if the response is missing": {
+			statusCode:  http.StatusOK,
+			response:    cmexperimental.CertificateRequestReview{},
+			expectedErr: true,
+		},
+		"error if the response is for another UID": {
+			statusCode: http.StatusOK,
+			response: cmexperimental.CertificateRequestReview{
+				Response: &cmexperimental.CertificateRequestReviewResponse{UID: "other-uid", Status: "Approved"},
+			},
+			expectedErr: true,
+		},
+		"error if the status is unknown": {
+			statusCode: http.StatusOK,
+			response: cmexperimental.CertificateRequestReview{
+				Response: &cmexperimental.CertificateRequestReviewResponse{UID: "test-uid", Status: "Maybe"},
+			},
+			expectedErr: true,
+		},
+		"error if the webhook does not respond with 200": {
+			statusCode:  http.StatusInternalServerError,
+			response:    "internal error",
+			expectedErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var review cmexperimental.CertificateRequestReview
+				if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+					t.Errorf("failed to decode review: %v", err)
+				}
+				if review.Kind != "CertificateRequestReview" || review.Request == nil || review.Request.UID != "test-uid" {
+					t.Errorf("unexpected review sent to webhook: %+v", review)
+				}
+				w.WriteHeader(test.statusCode)
+				json.NewEncoder(w).Encode(test.response)
+			}))
+			defer server.Close()
+
+			webhook, err := newApprovalWebhook(controllerpkg.ApprovalOptions{WebhookURL: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := webhook.review(context.Background(), &cmexperimental.CertificateRequestReviewRequest{UID: types.UID("test-uid")})
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if !reflect.DeepEqual(resp, test.expectedResponse) {
+				t.Errorf("unexpected response, exp=%+v got=%+v", test.expectedResponse, resp)
+			}
+		})
+	}
+}
+
+// newTestWebhookServer returns a server which responds to every review with
+// the given status and message.
+func newTestWebhookServer(status cmexperimental.CertificateRequestReviewStatus, message string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var review cmexperimental.CertificateRequestReview
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil || review.Request == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(cmexperimental.CertificateRequestReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: cmexperimental.SchemeGroupVersion.String(), Kind: "CertificateRequestReview"},
+			Response: &cmexperimental.CertificateRequestReviewResponse{
+				UID:     review.Request.UID,
+				Status:  status,
+				Message: message,
+			},
+		})
+	}))
+}
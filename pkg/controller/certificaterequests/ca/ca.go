@@ -22,8 +22,10 @@ import (
 	"crypto/x509"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
@@ -31,6 +33,8 @@ import (
 	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
 	issuerpkg "github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/policy"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/revocation"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	cmerrors "github.com/jetstack/cert-manager/pkg/util/errors"
 	"github.com/jetstack/cert-manager/pkg/util/kube"
@@ -49,6 +53,11 @@ type CA struct {
 	secretsLister corelisters.SecretLister
 
 	reporter *crutil.Reporter
+	recorder record.EventRecorder
+
+	// revocationStore records the certificates signed by issuers that have
+	// revocation enabled.
+	revocationStore *revocation.Store
 
 	// Used for testing to get reproducible resulting certificates
 	templateGenerator templateGenerator
@@ -69,6 +78,8 @@ func NewCA(ctx *controllerpkg.Context) *CA {
 		issuerOptions:     ctx.IssuerOptions,
 		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		recorder:          ctx.Recorder,
+		revocationStore:   revocation.NewStore(ctx.Client, ctx.Clock),
 		templateGenerator: pki.GenerateTemplateFromCertificateRequest,
 		signingFn:         pki.SignCSRTemplate,
 	}
@@ -84,7 +95,7 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
 	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	caCerts, caKey, err := kube.SecretCAIssuerKeyPair(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA)
 	if k8sErrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
 
@@ -118,9 +129,25 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 		return nil, nil
 	}
 
+	if err := pki.CheckNameConstraints(caCerts[0], template); err != nil {
+		message := "Requested names violate the name constraints of the CA"
+		c.reporter.Failed(cr, err, "NameConstraintsViolation", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
 	template.CRLDistributionPoints = issuerObj.GetSpec().CA.CRLDistributionPoints
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
 
+	warnings, err := policy.Apply(issuerObj.GetSpec().CA, caCerts[0], template, cr.Spec.Duration != nil)
+	if err != nil {
+		// The issuer will need to be fixed before the request can be signed.
+		message := "Error applying the signing policy of the issuer"
+		c.reporter.Pending(cr, err, "SigningPolicyError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
 	bundle, err := c.signingFn(caCerts, caKey, template)
 	if err != nil {
 		message := "Error signing certificate"
@@ -129,10 +156,37 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 		return nil, err
 	}
 
-	log.V(logf.DebugLevel).Info("certificate issued")
+	if rev := issuerObj.GetSpec().CA.Revocation; rev != nil {
+		// Certificates are only returned once they have been recorded, so
+		// that every certificate signed by the issuer can later be revoked.
+		if err := c.recordIssued(ctx, resourceNamespace, rev.SecretName, bundle.ChainPEM); err != nil {
+			message := fmt.Sprintf("Failed to record signed certificate in revocation database %s/%s", resourceNamespace, rev.SecretName)
+			c.reporter.Pending(cr, err, "RevocationRecordError", message)
+			log.Error(err, message)
+			return nil, err
+		}
+	}
 
-	return &issuerpkg.IssueResponse{
+	resp := &issuerpkg.IssueResponse{
 		Certificate: bundle.ChainPEM,
 		CA:          bundle.CAPEM,
-	}, nil
+	}
+
+	for _, message := range warnings {
+		c.recorder.Event(cr, corev1.EventTypeWarning, "DurationClamped", message)
+		// Also surface the clamping on the Ready condition, as events expire.
+		resp.Warnings = append(resp.Warnings, message)
+	}
+
+	log.V(logf.DebugLevel).Info("certificate issued")
+
+	return resp, nil
+}
+
+func (c *CA) recordIssued(ctx context.Context, namespace, name string, chainPEM []byte) error {
+	cert, err := pki.DecodeX509CertificateBytes(chainPEM)
+	if err != nil {
+		return err
+	}
+	return c.revocationStore.RecordIssued(ctx, namespace, name, cert)
 }
@@ -0,0 +1,242 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmp
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	internalcmp "github.com/jetstack/cert-manager/pkg/internal/cmp"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	cmerrors "github.com/jetstack/cert-manager/pkg/util/errors"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	// CRControllerName is the name of CMP certificate requests controller.
+	CRControllerName = "certificaterequests-issuer-cmp"
+)
+
+// CMP is a CMP-specific implementation of
+// pkg/controller/certificaterequests.Issuer interface.
+type CMP struct {
+	issuerOptions     controllerpkg.IssuerOptions
+	secretsLister     corelisters.SecretLister
+	certificateLister cmlisters.CertificateLister
+	reporter          *crutil.Reporter
+	clock             clock.Clock
+
+	cmpClientBuilder internalcmp.ClientBuilder
+}
+
+func init() {
+	// create certificate request controller for cmp issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(apiutil.IssuerCMP, NewCMP(ctx))).
+			Complete()
+	})
+}
+
+// NewCMP returns a new CMP instance with the given controller context.
+func NewCMP(ctx *controllerpkg.Context) *CMP {
+	return &CMP{
+		issuerOptions:     ctx.IssuerOptions,
+		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		certificateLister: ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(),
+		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		clock:             ctx.Clock,
+		cmpClientBuilder:  internalcmp.New,
+	}
+}
+
+// Sign will request the X.509 certificate from the Certificate Request from
+// the CMP server associated with the provided issuer. If the Certificate
+// that owns the request is being renewed with its existing private key, a
+// key update request is sent for the existing certificate instead.
+func (c *CMP) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v1.GenericIssuer) (*issuer.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+	log = logf.WithRelatedResource(log, issuerObj)
+
+	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
+
+	client, err := c.cmpClientBuilder(resourceNamespace, c.secretsLister, issuerObj)
+	if k8sErrors.IsNotFound(err) {
+		message := "Required secret resource not found"
+
+		c.reporter.Pending(cr, err, "SecretMissing", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	if err != nil {
+		message := "Failed to initialise CMP client for signing"
+		c.reporter.Pending(cr, err, "CMPInitError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	// Possession of the private key must be proven to the CMP server, so the
+	// private key of the request must be available.
+	secretName, ok := cr.ObjectMeta.Annotations[v1.CertificateRequestPrivateKeyAnnotationKey]
+	if !ok || secretName == "" {
+		message := fmt.Sprintf("Annotation %q missing or reference empty",
+			v1.CertificateRequestPrivateKeyAnnotationKey)
+		err := errors.New("secret name missing")
+
+		c.reporter.Failed(cr, err, "MissingAnnotation", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	privateKey, err := kube.SecretTLSKey(ctx, c.secretsLister, cr.Namespace, secretName)
+	if k8sErrors.IsNotFound(err) {
+		message := fmt.Sprintf("Referenced secret %s/%s not found", cr.Namespace, secretName)
+
+		c.reporter.Pending(cr, err, "MissingSecret", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	if cmerrors.IsInvalidData(err) {
+		message := fmt.Sprintf("Failed to get key %q referenced in annotation %q",
+			secretName, v1.CertificateRequestPrivateKeyAnnotationKey)
+
+		c.reporter.Pending(cr, err, "ErrorParsingKey", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	if err != nil {
+		// We are probably in a network error here so we should backoff and retry
+		message := fmt.Sprintf("Failed to get private key from secret %s/%s", cr.Namespace, secretName)
+		c.reporter.Pending(cr, err, "ErrorGettingSecret", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		message := "Failed to decode CSR in spec.request"
+		c.reporter.Failed(cr, err, "ErrorParsingCSR", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	ok, err = pki.PublicKeysEqual(privateKey.Public(), csr.PublicKey)
+	if err != nil || !ok {
+		if err == nil {
+			err = errors.New("CSR not signed by referenced private key")
+		}
+
+		message := "Private key does not match the CSR"
+		c.reporter.Failed(cr, err, "ErrorKeyMatch", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	req := &internalcmp.Request{
+		CSR:        csr,
+		PrivateKey: privateKey,
+	}
+
+	var resp *internalcmp.Response
+	if existing := c.existingCertificate(ctx, cr, csr); existing != nil {
+		log.V(logf.DebugLevel).Info("private key is reused, requesting a key update of the existing certificate")
+		resp, err = client.KeyUpdate(ctx, req, existing)
+	} else {
+		resp, err = client.Enroll(ctx, req)
+	}
+
+	var statusErr *internalcmp.StatusError
+	if errors.As(err, &statusErr) {
+		message := "CMP server rejected the certificate request"
+
+		c.reporter.Failed(cr, err, "SigningError", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	if err != nil {
+		message := "Failed to request certificate from CMP server"
+		c.reporter.Pending(cr, err, "CMPError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	bundle, err := pki.ParseSingleCertificateChain(resp.Chain)
+	if err != nil {
+		message := "Failed to parse returned certificate bundle"
+		c.reporter.Failed(cr, err, "ParseError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	log.V(logf.DebugLevel).Info("certificate issued")
+
+	return &issuer.IssueResponse{
+		Certificate: bundle.ChainPEM,
+		CA:          bundle.CAPEM,
+	}, nil
+}
+
+// existingCertificate returns the currently issued certificate of the
+// Certificate that owns the CertificateRequest, if it has not expired and
+// has the same public key as the request. Otherwise nil is returned and a new
+// certificate is requested.
+func (c *CMP) existingCertificate(ctx context.Context, cr *v1.CertificateRequest, csr *x509.CertificateRequest) *x509.Certificate {
+	name, ok := cr.Annotations[v1.CertificateNameKey]
+	if !ok {
+		return nil
+	}
+	crt, err := c.certificateLister.Certificates(cr.Namespace).Get(name)
+	if err != nil {
+		return nil
+	}
+	cert, err := kube.SecretTLSCert(ctx, c.secretsLister, cr.Namespace, crt.Spec.SecretName)
+	if err != nil {
+		return nil
+	}
+
+	// An expired certificate cannot be used to protect the request.
+	if c.clock.Now().After(cert.NotAfter) {
+		return nil
+	}
+	if ok, err := pki.PublicKeysEqual(cert.PublicKey, csr.PublicKey); err != nil || !ok {
+		return nil
+	}
+
+	return cert
+}
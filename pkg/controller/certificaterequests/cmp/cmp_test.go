@@ -0,0 +1,372 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	internalcmp "github.com/jetstack/cert-manager/pkg/internal/cmp"
+	fakecmp "github.com/jetstack/cert-manager/pkg/internal/cmp/fake"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+func generateCertificate(t *testing.T, template, parent *x509.Certificate, pub crypto.PublicKey, parentKey crypto.Signer) (*x509.Certificate, []byte) {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, err := pki.EncodeX509(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, certPEM
+}
+
+func TestSign(t *testing.T) {
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	baseIssuer := gen.Issuer("cmp-issuer",
+		gen.SetIssuerCMP(cmapi.CMPIssuer{
+			URL: "https://ca.example.com/pkix/",
+		}),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+
+	caKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "cmp-ca"},
+		NotBefore:             fixedClockStart,
+		NotAfter:              fixedClockStart.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caCert, caPEM := generateCertificate(t, caTemplate, caTemplate, caKey.Public(), caKey)
+
+	csrPEM, sk, err := gen.CSR(x509.ECDSA, gen.SetCSRCommonName("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafCert, leafPEM := generateCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    fixedClockStart,
+		NotAfter:     fixedClockStart.Add(time.Hour),
+	}, caCert, sk.Public(), caKey)
+
+	otherSK, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherPEM := generateCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    fixedClockStart,
+		NotAfter:     fixedClockStart.Add(time.Hour),
+	}, caCert, otherSK.Public(), caKey)
+
+	keyPEM, err := pki.EncodePKCS8PrivateKey(sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keySecret := gen.Secret("test-key",
+		gen.SetSecretNamespace(gen.DefaultTestNamespace),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSPrivateKeyKey: keyPEM,
+		}),
+	)
+	existingCert := gen.Certificate("test-cert",
+		gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateSecretName("test-cert-tls"),
+	)
+	existingSecret := func(certPEM []byte) *corev1.Secret {
+		return gen.Secret("test-cert-tls",
+			gen.SetSecretNamespace(gen.DefaultTestNamespace),
+			gen.SetSecretData(map[string][]byte{
+				corev1.TLSCertKey: certPEM,
+			}),
+		)
+	}
+
+	baseCRNotApproved := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(csrPEM),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Name:  baseIssuer.Name,
+			Group: certmanager.GroupName,
+			Kind:  baseIssuer.Kind,
+		}),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestPrivateKeyAnnotationKey: "test-key",
+			cmapi.CertificateNameKey:                        "test-cert",
+		}),
+	)
+	baseCR := gen.CertificateRequestFrom(baseCRNotApproved,
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionApproved,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             "cert-manager.io",
+			Message:            "Certificate request has been approved by cert-manager.io",
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+	noAnnotationCR := baseCR.DeepCopy()
+	delete(noAnnotationCR.Annotations, cmapi.CertificateRequestPrivateKeyAnnotationKey)
+
+	statusUpdate := func(cr *cmapi.CertificateRequest, mods ...gen.CertificateRequestModifier) testpkg.Action {
+		return testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+			cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+			"status",
+			gen.DefaultTestNamespace,
+			gen.CertificateRequestFrom(cr, mods...),
+		))
+	}
+	pendingCondition := func(message string) gen.CertificateRequestModifier {
+		return gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionReady,
+			Status:             cmmeta.ConditionFalse,
+			Reason:             cmapi.CertificateRequestReasonPending,
+			Message:            message,
+			LastTransitionTime: &metaFixedClockStart,
+		})
+	}
+	failedCondition := func(message string) []gen.CertificateRequestModifier {
+		return []gen.CertificateRequestModifier{
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:               cmapi.CertificateRequestConditionReady,
+				Status:             cmmeta.ConditionFalse,
+				Reason:             cmapi.CertificateRequestReasonFailed,
+				Message:            message,
+				LastTransitionTime: &metaFixedClockStart,
+			}),
+			gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+		}
+	}
+	issuedUpdate := statusUpdate(baseCR,
+		gen.SetCertificateRequestCertificate(leafPEM),
+		gen.SetCertificateRequestCA(caPEM),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionReady,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             cmapi.CertificateRequestReasonIssued,
+			Message:            "Certificate fetched from issuer successfully",
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+	successResponse := &internalcmp.Response{
+		Chain: []*x509.Certificate{leafCert, caCert},
+	}
+
+	tests := map[string]testT{
+		"a CertificateRequest without an approved condition should do nothing": {
+			certificateRequest: baseCRNotApproved.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCRNotApproved.DeepCopy(), baseIssuer.DeepCopy()},
+			},
+		},
+		"a missing protection secret should report pending": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal SecretMissing Required secret resource not found: secrets "cmp-secret" not found`,
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(baseCR, pendingCondition(`Required secret resource not found: secrets "cmp-secret" not found`)),
+				},
+			},
+			fakeCMP: fakecmp.New().WithNew(func(string, corelisters.SecretLister, cmapi.GenericIssuer) (*fakecmp.CMP, error) {
+				return nil, k8sErrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "cmp-secret")
+			}),
+		},
+		"a missing private key annotation should report failed": {
+			certificateRequest: noAnnotationCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{noAnnotationCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning MissingAnnotation Annotation "cert-manager.io/private-key-secret-name" missing or reference empty: secret name missing`,
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(noAnnotationCR, failedCondition(`Annotation "cert-manager.io/private-key-secret-name" missing or reference empty: secret name missing`)...),
+				},
+			},
+			fakeCMP: fakecmp.New(),
+		},
+		"a transport error should report pending and return an error": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{keySecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CMPError Failed to request certificate from CMP server: connection refused",
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(baseCR, pendingCondition("Failed to request certificate from CMP server: connection refused")),
+				},
+			},
+			fakeCMP:     fakecmp.New().WithEnroll(nil, errors.New("connection refused")),
+			expectedErr: true,
+		},
+		"a rejected request should report failed": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{keySecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning SigningError CMP server rejected the certificate request: CMP server responded with status rejection (badCertTemplate): subject not allowed",
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(baseCR, failedCondition("CMP server rejected the certificate request: CMP server responded with status rejection (badCertTemplate): subject not allowed")...),
+				},
+			},
+			fakeCMP: fakecmp.New().WithEnroll(nil, &internalcmp.StatusError{
+				Status:       internalcmp.StatusRejection,
+				FailInfo:     []string{"badCertTemplate"},
+				StatusString: []string{"subject not allowed"},
+			}),
+		},
+		"a successful request should return the certificate and CA": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{keySecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{issuedUpdate},
+			},
+			fakeCMP: fakecmp.New().
+				WithEnroll(successResponse, nil).
+				WithKeyUpdate(nil, errors.New("unexpected key update")),
+		},
+		"a reused private key should request a key update": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{keySecret, existingSecret(leafPEM)},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(), existingCert},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{issuedUpdate},
+			},
+			fakeCMP: fakecmp.New().
+				WithEnroll(nil, errors.New("unexpected enroll")).
+				WithKeyUpdate(successResponse, nil),
+		},
+		"a new private key should request a new certificate": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{keySecret, existingSecret(otherPEM)},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(), existingCert},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{issuedUpdate},
+			},
+			fakeCMP: fakecmp.New().
+				WithEnroll(successResponse, nil).
+				WithKeyUpdate(nil, errors.New("unexpected key update")),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			test.builder.Clock = fixedClock
+			runTest(t, test)
+		})
+	}
+}
+
+type testT struct {
+	builder            *testpkg.Builder
+	certificateRequest *cmapi.CertificateRequest
+
+	expectedErr bool
+
+	fakeCMP *fakecmp.CMP
+}
+
+func runTest(t *testing.T, test testT) {
+	test.builder.T = t
+	test.builder.Init()
+	defer test.builder.Stop()
+
+	cmp := NewCMP(test.builder.Context)
+
+	if test.fakeCMP != nil {
+		cmp.cmpClientBuilder = func(ns string, sl corelisters.SecretLister,
+			iss cmapi.GenericIssuer) (internalcmp.Interface, error) {
+			return test.fakeCMP.New(ns, sl, iss)
+		}
+	}
+
+	controller := certificaterequests.New(apiutil.IssuerCMP, cmp)
+	if _, _, err := controller.Register(test.builder.Context); err != nil {
+		t.Errorf("failed to register context with controller: %v", err)
+	}
+
+	test.builder.Start()
+
+	err := controller.Sync(context.Background(), test.certificateRequest)
+	if err != nil && !test.expectedErr {
+		t.Errorf("expected to not get an error, but got: %v", err)
+	}
+	if err == nil && test.expectedErr {
+		t.Errorf("expected to get an error but did not get one")
+	}
+
+	test.builder.CheckAndFinish(err)
+}
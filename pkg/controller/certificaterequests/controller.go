@@ -22,6 +22,7 @@ import (
 
 	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -73,6 +74,10 @@ type Controller struct {
 	issuerLister        cmlisters.IssuerLister
 	clusterIssuerLister cmlisters.ClusterIssuerLister
 
+	// namespaceLister is used to check the namespace access restrictions of
+	// ClusterIssuers. It is only set if ClusterIssuers are watched.
+	namespaceLister corelisters.NamespaceLister
+
 	// Extra informers that should be watched by this certificate request
 	// controller instance. These resources can be owned by certificate requests
 	// that we resolve.
@@ -144,6 +149,10 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 		// register handler function for clusterissuer resources
 		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
 		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+
+		namespaceInformer := ctx.KubeSharedInformerFactory.Core().V1().Namespaces()
+		c.namespaceLister = namespaceInformer.Lister()
+		mustSync = append(mustSync, namespaceInformer.Informer().HasSynced)
 	}
 
 	// set all the references to the listers for used by the Sync function
@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package est
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	internalest "github.com/jetstack/cert-manager/pkg/internal/est"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	// CRControllerName is the name of EST certificate requests controller.
+	CRControllerName = "certificaterequests-issuer-est"
+)
+
+// EST is an EST-specific implementation of
+// pkg/controller/certificaterequests.Issuer interface.
+type EST struct {
+	issuerOptions     controllerpkg.IssuerOptions
+	secretsLister     corelisters.SecretLister
+	certificateLister cmlisters.CertificateLister
+	reporter          *crutil.Reporter
+	clock             clock.Clock
+
+	estClientBuilder internalest.ClientBuilder
+}
+
+func init() {
+	// create certificate request controller for est issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(apiutil.IssuerEST, NewEST(ctx))).
+			Complete()
+	})
+}
+
+// NewEST returns a new EST instance with the given controller context.
+func NewEST(ctx *controllerpkg.Context) *EST {
+	return &EST{
+		issuerOptions:     ctx.IssuerOptions,
+		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		certificateLister: ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(),
+		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		clock:             ctx.Clock,
+		estClientBuilder:  internalest.New,
+	}
+}
+
+// Sign will enroll the X.509 certificate from the Certificate Request with
+// the EST server associated with the provided issuer.
+func (e *EST) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v1.GenericIssuer) (*issuer.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+	log = logf.WithRelatedResource(log, issuerObj)
+
+	resourceNamespace := e.issuerOptions.ResourceNamespace(issuerObj)
+
+	client, err := e.estClientBuilder(resourceNamespace, e.secretsLister, issuerObj)
+	if k8sErrors.IsNotFound(err) {
+		message := "Required secret resource not found"
+
+		e.reporter.Pending(cr, err, "SecretMissing", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	if err != nil {
+		message := "Failed to initialise EST client for signing"
+		e.reporter.Pending(cr, err, "ESTInitError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	// Retrieve the CA certificates before enrolling so that a transient
+	// failure here does not cause the certificate to be issued twice.
+	caPem, err := client.CACerts(ctx)
+	if err != nil {
+		message := "Failed to retrieve CA certificates from EST server"
+		e.reporter.Pending(cr, err, "ESTCACertsError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	var certPem []byte
+	if existing := e.existingKeyPair(cr, issuerObj); existing != nil {
+		log.V(logf.DebugLevel).Info("re-enrolling using the existing certificate")
+		certPem, err = client.SimpleReenroll(ctx, cr.Spec.Request, *existing)
+	} else {
+		certPem, err = client.SimpleEnroll(ctx, cr.Spec.Request)
+	}
+
+	var pendingErr *internalest.PendingError
+	if errors.As(err, &pendingErr) {
+		message := "EST server has not issued the certificate yet"
+		e.reporter.Pending(cr, err, "EnrollmentPending", message)
+		log.V(logf.InfoLevel).Info(message, "retryAfter", pendingErr.RetryAfter)
+		if pendingErr.RetryAfter > 0 {
+			return nil, &issuer.RetryAfterError{Err: err, RetryAfter: pendingErr.RetryAfter}
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		message := "EST server failed to sign certificate"
+
+		e.reporter.Failed(cr, err, "SigningError", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	log.V(logf.DebugLevel).Info("certificate issued")
+
+	return &issuer.IssueResponse{
+		Certificate: certPem,
+		CA:          caPem,
+	}, nil
+}
+
+// existingKeyPair returns the currently issued key pair of the Certificate
+// that owns the CertificateRequest, if re-enrollment is enabled on the issuer,
+// the Secret contains a valid key pair, and the request is for the same
+// subject and subject alternative names as the current certificate. Otherwise
+// nil is returned and the request is enrolled as a new certificate.
+func (e *EST) existingKeyPair(cr *v1.CertificateRequest, issuerObj v1.GenericIssuer) *tls.Certificate {
+	if !issuerObj.GetSpec().EST.Reenroll {
+		return nil
+	}
+
+	name, ok := cr.Annotations[v1.CertificateNameKey]
+	if !ok {
+		return nil
+	}
+	crt, err := e.certificateLister.Certificates(cr.Namespace).Get(name)
+	if err != nil {
+		return nil
+	}
+	secret, err := e.secretsLister.Secrets(cr.Namespace).Get(crt.Spec.SecretName)
+	if err != nil {
+		return nil
+	}
+
+	keyPair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil
+	}
+
+	// An expired certificate cannot be used to authenticate the request.
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil || e.clock.Now().After(cert.NotAfter) {
+		return nil
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil || !namesMatch(csr, cert) {
+		return nil
+	}
+
+	return &keyPair
+}
+
+// namesMatch returns true if the CSR requests exactly the subject and subject
+// alternative names of cert. RFC 7030, section 4.2.2 only allows
+// re-enrollment with the same names as the certificate being renewed.
+func namesMatch(csr *x509.CertificateRequest, cert *x509.Certificate) bool {
+	return bytes.Equal(csr.RawSubject, cert.RawSubject) &&
+		util.EqualUnsorted(csr.DNSNames, cert.DNSNames) &&
+		util.EqualIPsUnsorted(csr.IPAddresses, cert.IPAddresses) &&
+		util.EqualURLsUnsorted(csr.URIs, cert.URIs) &&
+		util.EqualUnsorted(csr.EmailAddresses, cert.EmailAddresses)
+}
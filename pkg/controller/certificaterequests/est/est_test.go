@@ -0,0 +1,418 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package est
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	internalest "github.com/jetstack/cert-manager/pkg/internal/est"
+	fakeest "github.com/jetstack/cert-manager/pkg/internal/est/fake"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+func generateCSR(t *testing.T, secretKey crypto.Signer, commonName string) []byte {
+	asn1Subj, _ := asn1.Marshal(pkix.Name{
+		CommonName: commonName,
+	}.ToRDNSequence())
+	template := x509.CertificateRequest{
+		RawSubject:         asn1Subj,
+		SignatureAlgorithm: x509.SHA256WithRSA,
+	}
+
+	csrBytes, err := x509.CreateCertificateRequest(rand.Reader, &template, secretKey)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	csr := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrBytes})
+
+	return csr
+}
+
+func generateSelfSignedCertFromCR(cr *cmapi.CertificateRequest, key crypto.Signer,
+	duration time.Duration) ([]byte, error) {
+	template, err := pki.GenerateTemplateFromCertificateRequest(cr)
+	if err != nil {
+		return nil, fmt.Errorf("error generating template: %v", err)
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("error signing cert: %v", err)
+	}
+
+	pemByteBuffer := bytes.NewBuffer([]byte{})
+	err = pem.Encode(pemByteBuffer, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cert: %v", err)
+	}
+
+	return pemByteBuffer.Bytes(), nil
+}
+
+func TestSign(t *testing.T) {
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	baseIssuer := gen.Issuer("est-issuer",
+		gen.SetIssuerEST(cmapi.ESTIssuer{
+			Server: "https://est.example.com",
+		}),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+	reenrollIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerEST(cmapi.ESTIssuer{
+			Server:   "https://est.example.com",
+			Reenroll: true,
+		}),
+	)
+
+	rsaSK, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	csrPEM := generateCSR(t, rsaSK, "test")
+
+	baseCRNotApproved := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(csrPEM),
+		gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 24 * 60}),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Name:  baseIssuer.Name,
+			Group: certmanager.GroupName,
+			Kind:  baseIssuer.Kind,
+		}),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateNameKey: "test-cert",
+		}),
+	)
+	baseCR := gen.CertificateRequestFrom(baseCRNotApproved,
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionApproved,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             "cert-manager.io",
+			Message:            "Certificate request has been approved by cert-manager.io",
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+
+	rsaPEMCert, err := generateSelfSignedCertFromCR(baseCR, rsaSK, time.Hour*24*60)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	// A certificate issued for a different subject, which must not be
+	// re-enrolled as the request changes its names.
+	renamedPEMCert, err := generateSelfSignedCertFromCR(
+		gen.CertificateRequestFrom(baseCR, gen.SetCertificateRequestCSR(generateCSR(t, rsaSK, "other"))),
+		rsaSK, time.Hour*24*60)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	existingCert := gen.Certificate("test-cert",
+		gen.SetCertificateSecretName("test-cert-tls"),
+	)
+	existingSecret := gen.Secret("test-cert-tls",
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSCertKey:       rsaPEMCert,
+			corev1.TLSPrivateKeyKey: pki.EncodePKCS1PrivateKey(rsaSK),
+		}),
+	)
+
+	renamedSecret := gen.SecretFrom(existingSecret,
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSCertKey:       renamedPEMCert,
+			corev1.TLSPrivateKeyKey: pki.EncodePKCS1PrivateKey(rsaSK),
+		}),
+	)
+
+	statusUpdate := func(mods ...gen.CertificateRequestModifier) testpkg.Action {
+		return testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+			cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+			"status",
+			gen.DefaultTestNamespace,
+			gen.CertificateRequestFrom(baseCR, mods...),
+		))
+	}
+	issuedUpdate := statusUpdate(
+		gen.SetCertificateRequestCertificate(rsaPEMCert),
+		gen.SetCertificateRequestCA(rsaPEMCert),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionReady,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             cmapi.CertificateRequestReasonIssued,
+			Message:            "Certificate fetched from issuer successfully",
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+
+	tests := map[string]testT{
+		"a CertificateRequest without an approved condition should do nothing": {
+			certificateRequest: baseCRNotApproved.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCRNotApproved.DeepCopy(), baseIssuer.DeepCopy()},
+			},
+		},
+		"a missing secret should report pending": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal SecretMissing Required secret resource not found: secrets "est-password" not found`,
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:               cmapi.CertificateRequestConditionReady,
+						Status:             cmmeta.ConditionFalse,
+						Reason:             cmapi.CertificateRequestReasonPending,
+						Message:            `Required secret resource not found: secrets "est-password" not found`,
+						LastTransitionTime: &metaFixedClockStart,
+					})),
+				},
+			},
+			fakeEST: fakeest.New().WithNew(func(string, corelisters.SecretLister, cmapi.GenericIssuer) (*fakeest.EST, error) {
+				return nil, k8sErrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "est-password")
+			}),
+		},
+		"failing to retrieve the CA certificates should report pending and return an error": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal ESTCACertsError Failed to retrieve CA certificates from EST server: connection refused",
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:               cmapi.CertificateRequestConditionReady,
+						Status:             cmmeta.ConditionFalse,
+						Reason:             cmapi.CertificateRequestReasonPending,
+						Message:            "Failed to retrieve CA certificates from EST server: connection refused",
+						LastTransitionTime: &metaFixedClockStart,
+					})),
+				},
+			},
+			fakeEST:     fakeest.New().WithCACerts(nil, errors.New("connection refused")),
+			expectedErr: true,
+		},
+		"a pending enrollment should report pending and be retried after the requested delay": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal EnrollmentPending EST server has not issued the certificate yet: certificate enrollment is pending, retry after 30s",
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:               cmapi.CertificateRequestConditionReady,
+						Status:             cmmeta.ConditionFalse,
+						Reason:             cmapi.CertificateRequestReasonPending,
+						Message:            "EST server has not issued the certificate yet: certificate enrollment is pending, retry after 30s",
+						LastTransitionTime: &metaFixedClockStart,
+					})),
+				},
+			},
+			fakeEST: fakeest.New().WithCACerts(rsaPEMCert, nil).
+				WithSimpleEnroll(nil, &internalest.PendingError{RetryAfter: 30 * time.Second}),
+		},
+		"a pending enrollment without a retry delay should report pending and return an error": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal EnrollmentPending EST server has not issued the certificate yet: certificate enrollment is pending, retry after 0s",
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:               cmapi.CertificateRequestConditionReady,
+						Status:             cmmeta.ConditionFalse,
+						Reason:             cmapi.CertificateRequestReasonPending,
+						Message:            "EST server has not issued the certificate yet: certificate enrollment is pending, retry after 0s",
+						LastTransitionTime: &metaFixedClockStart,
+					})),
+				},
+			},
+			fakeEST: fakeest.New().WithCACerts(rsaPEMCert, nil).
+				WithSimpleEnroll(nil, &internalest.PendingError{}),
+			expectedErr: true,
+		},
+		"a failed enrollment should report failed": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning SigningError EST server failed to sign certificate: EST server responded with status 400: bad request",
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(
+						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+							Type:               cmapi.CertificateRequestConditionReady,
+							Status:             cmmeta.ConditionFalse,
+							Reason:             cmapi.CertificateRequestReasonFailed,
+							Message:            "EST server failed to sign certificate: EST server responded with status 400: bad request",
+							LastTransitionTime: &metaFixedClockStart,
+						}),
+						gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+					),
+				},
+			},
+			fakeEST: fakeest.New().WithCACerts(rsaPEMCert, nil).
+				WithSimpleEnroll(nil, errors.New("EST server responded with status 400: bad request")),
+		},
+		"a successful enrollment should return the certificate and CA": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{existingSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(), existingCert},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{issuedUpdate},
+			},
+			fakeEST: fakeest.New().WithCACerts(rsaPEMCert, nil).
+				WithSimpleEnroll(rsaPEMCert, nil).
+				WithSimpleReenroll(nil, errors.New("unexpected reenroll")),
+		},
+		"an issuer with reenroll enabled should reenroll with the existing certificate": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{existingSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), reenrollIssuer.DeepCopy(), existingCert},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{issuedUpdate},
+			},
+			fakeEST: fakeest.New().WithCACerts(rsaPEMCert, nil).
+				WithSimpleEnroll(nil, errors.New("unexpected enroll")).
+				WithSimpleReenroll(rsaPEMCert, nil),
+		},
+		"an issuer with reenroll enabled should enroll if the existing certificate has a different subject": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{renamedSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), reenrollIssuer.DeepCopy(), existingCert},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{issuedUpdate},
+			},
+			fakeEST: fakeest.New().WithCACerts(rsaPEMCert, nil).
+				WithSimpleEnroll(rsaPEMCert, nil).
+				WithSimpleReenroll(nil, errors.New("unexpected reenroll")),
+		},
+		"an issuer with reenroll enabled should enroll if there is no existing certificate": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), reenrollIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{issuedUpdate},
+			},
+			fakeEST: fakeest.New().WithCACerts(rsaPEMCert, nil).
+				WithSimpleEnroll(rsaPEMCert, nil).
+				WithSimpleReenroll(nil, errors.New("unexpected reenroll")),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			test.builder.Clock = fixedClock
+			runTest(t, test)
+		})
+	}
+}
+
+type testT struct {
+	builder            *testpkg.Builder
+	certificateRequest *cmapi.CertificateRequest
+
+	expectedErr bool
+
+	fakeEST *fakeest.EST
+}
+
+func runTest(t *testing.T, test testT) {
+	test.builder.T = t
+	test.builder.Init()
+	defer test.builder.Stop()
+
+	est := NewEST(test.builder.Context)
+
+	if test.fakeEST != nil {
+		est.estClientBuilder = func(ns string, sl corelisters.SecretLister,
+			iss cmapi.GenericIssuer) (internalest.Interface, error) {
+			return test.fakeEST.New(ns, sl, iss)
+		}
+	}
+
+	controller := certificaterequests.New(apiutil.IssuerEST, est)
+	if _, _, err := controller.Register(test.builder.Context); err != nil {
+		t.Errorf("failed to register context with controller: %v", err)
+	}
+
+	test.builder.Start()
+
+	err := controller.Sync(context.Background(), test.certificateRequest)
+	if err != nil && !test.expectedErr {
+		t.Errorf("expected to not get an error, but got: %v", err)
+	}
+	if err == nil && test.expectedErr {
+		t.Errorf("expected to get an error but did not get one")
+	}
+
+	test.builder.CheckAndFinish(err)
+}
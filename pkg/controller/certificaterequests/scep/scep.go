@@ -0,0 +1,278 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scep
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	internalscep "github.com/jetstack/cert-manager/pkg/internal/scep"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	cmerrors "github.com/jetstack/cert-manager/pkg/util/errors"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	// CRControllerName is the name of SCEP certificate requests controller.
+	CRControllerName = "certificaterequests-issuer-scep"
+)
+
+// SCEP is a SCEP-specific implementation of
+// pkg/controller/certificaterequests.Issuer interface.
+type SCEP struct {
+	issuerOptions controllerpkg.IssuerOptions
+	secretsLister corelisters.SecretLister
+	reporter      *crutil.Reporter
+
+	scepClientBuilder internalscep.ClientBuilder
+}
+
+func init() {
+	// create certificate request controller for scep issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(apiutil.IssuerSCEP, NewSCEP(ctx))).
+			Complete()
+	})
+}
+
+// NewSCEP returns a new SCEP instance with the given controller context.
+func NewSCEP(ctx *controllerpkg.Context) *SCEP {
+	return &SCEP{
+		issuerOptions:     ctx.IssuerOptions,
+		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		scepClientBuilder: internalscep.New,
+	}
+}
+
+// Sign will enroll the X.509 certificate from the Certificate Request with
+// the SCEP server associated with the provided issuer. If the SCEP server
+// responds that the request is pending, the transaction ID is stored on the
+// Certificate Request so that later syncs poll for the certificate instead.
+func (s *SCEP) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v1.GenericIssuer) (*issuer.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+	log = logf.WithRelatedResource(log, issuerObj)
+
+	resourceNamespace := s.issuerOptions.ResourceNamespace(issuerObj)
+
+	client, err := s.scepClientBuilder(resourceNamespace, s.secretsLister, issuerObj)
+	if k8sErrors.IsNotFound(err) {
+		message := "Required secret resource not found"
+
+		s.reporter.Pending(cr, err, "SecretMissing", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	if err != nil {
+		message := "Failed to initialise SCEP client for signing"
+		s.reporter.Pending(cr, err, "SCEPInitError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	// SCEP messages are signed with, and the issued certificate is encrypted
+	// to, the key of the request, so the private key must be available.
+	secretName, ok := cr.ObjectMeta.Annotations[v1.CertificateRequestPrivateKeyAnnotationKey]
+	if !ok || secretName == "" {
+		message := fmt.Sprintf("Annotation %q missing or reference empty",
+			v1.CertificateRequestPrivateKeyAnnotationKey)
+		err := errors.New("secret name missing")
+
+		s.reporter.Failed(cr, err, "MissingAnnotation", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	privateKey, err := kube.SecretTLSKey(ctx, s.secretsLister, cr.Namespace, secretName)
+	if k8sErrors.IsNotFound(err) {
+		message := fmt.Sprintf("Referenced secret %s/%s not found", cr.Namespace, secretName)
+
+		s.reporter.Pending(cr, err, "MissingSecret", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	if cmerrors.IsInvalidData(err) {
+		message := fmt.Sprintf("Failed to get key %q referenced in annotation %q",
+			secretName, v1.CertificateRequestPrivateKeyAnnotationKey)
+
+		s.reporter.Pending(cr, err, "ErrorParsingKey", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	if err != nil {
+		// We are probably in a network error here so we should backoff and retry
+		message := fmt.Sprintf("Failed to get private key from secret %s/%s", cr.Namespace, secretName)
+		s.reporter.Pending(cr, err, "ErrorGettingSecret", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		message := "Failed to decode CSR in spec.request"
+		s.reporter.Failed(cr, err, "ErrorParsingCSR", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	rsaKey, ok := privateKey.(*rsa.PrivateKey)
+	if !ok {
+		message := "SCEP issuer only supports RSA private keys"
+		err := fmt.Errorf("unsupported private key type %T", privateKey)
+		s.reporter.Failed(cr, err, "ErrorPrivateKey", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	ok, err = pki.PublicKeysEqual(rsaKey.Public(), csr.PublicKey)
+	if err != nil || !ok {
+		if err == nil {
+			err = errors.New("CSR not signed by referenced private key")
+		}
+
+		message := "Private key does not match the CSR"
+		s.reporter.Failed(cr, err, "ErrorKeyMatch", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	caps, err := client.GetCACaps(ctx)
+	if err != nil {
+		message := "Failed to retrieve capabilities from SCEP server"
+		s.reporter.Pending(cr, err, "SCEPCACapsError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	caCerts, err := client.GetCACert(ctx)
+	if err != nil {
+		message := "Failed to retrieve CA certificates from SCEP server"
+		s.reporter.Pending(cr, err, "SCEPCACertError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	req := &internalscep.Request{
+		TransactionID: cr.ObjectMeta.Annotations[v1.SCEPTransactionIDAnnotationKey],
+		CSR:           csr,
+		PrivateKey:    rsaKey,
+		CACerts:       caCerts,
+		Capabilities:  caps,
+	}
+
+	// check if the transaction ID annotation is there, if not send the
+	// initial request.
+	if req.TransactionID == "" {
+		req.TransactionID = internalscep.TransactionID(csr)
+
+		resp, err := client.PKCSReq(ctx, req)
+		if err != nil {
+			message := "Failed to request SCEP certificate"
+			s.reporter.Pending(cr, err, "RequestError", message)
+			log.Error(err, message)
+			return nil, err
+		}
+
+		if resp.Status == internalscep.StatusPending {
+			s.reporter.Pending(cr, nil, "IssuancePending", "SCEP certificate is requested")
+
+			metav1.SetMetaDataAnnotation(&cr.ObjectMeta, v1.SCEPTransactionIDAnnotationKey, req.TransactionID)
+
+			return nil, nil
+		}
+
+		return s.issueResponse(log, cr, resp, caCerts)
+	}
+
+	resp, err := client.CertPoll(ctx, req)
+	if err != nil {
+		message := "Failed to poll SCEP certificate"
+		s.reporter.Pending(cr, err, "RetrieveError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	if resp.Status == internalscep.StatusPending {
+		err := fmt.Errorf("transaction %s is pending", req.TransactionID)
+		message := "SCEP certificate still in a pending state, the request will be retried"
+
+		s.reporter.Pending(cr, err, "IssuancePending", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
+	return s.issueResponse(log, cr, resp, caCerts)
+}
+
+// issueResponse converts a final SCEP response into an IssueResponse,
+// marking the Certificate Request as failed if the SCEP server rejected it.
+func (s *SCEP) issueResponse(log logr.Logger, cr *v1.CertificateRequest, resp *internalscep.Response, caCerts []*x509.Certificate) (*issuer.IssueResponse, error) {
+	if resp.Status == internalscep.StatusFailure {
+		err := fmt.Errorf("failInfo %s", resp.FailInfo.Reason())
+		message := "SCEP server rejected the certificate request"
+
+		s.reporter.Failed(cr, err, resp.FailInfo.Reason(), message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	// Registration authority certificates are not part of the chain.
+	certs := resp.Certificates
+	for _, cert := range caCerts {
+		if cert.IsCA {
+			certs = append(certs, cert)
+		}
+	}
+
+	bundle, err := pki.ParseSingleCertificateChain(certs)
+	if err != nil {
+		message := "Failed to parse returned certificate bundle"
+		s.reporter.Failed(cr, err, "ParseError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	log.V(logf.DebugLevel).Info("certificate issued")
+
+	return &issuer.IssueResponse{
+		Certificate: bundle.ChainPEM,
+		CA:          bundle.CAPEM,
+	}, nil
+}
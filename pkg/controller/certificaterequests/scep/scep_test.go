@@ -0,0 +1,420 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scep
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	internalscep "github.com/jetstack/cert-manager/pkg/internal/scep"
+	fakescep "github.com/jetstack/cert-manager/pkg/internal/scep/fake"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+func generateCertificate(t *testing.T, template, parent *x509.Certificate, pub interface{}, parentKey *rsa.PrivateKey) (*x509.Certificate, []byte) {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, err := pki.EncodeX509(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, certPEM
+}
+
+func TestSign(t *testing.T) {
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	baseIssuer := gen.Issuer("scep-issuer",
+		gen.SetIssuerSCEP(cmapi.SCEPIssuer{
+			URL: "https://scep.example.com/scep",
+		}),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+
+	caKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "scep-ca"},
+		NotBefore:             fixedClockStart,
+		NotAfter:              fixedClockStart.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caCert, caPEM := generateCertificate(t, caTemplate, caTemplate, caKey.Public(), caKey)
+
+	csrPEM, sk, err := gen.CSR(x509.RSA, gen.SetCSRCommonName("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaSK := sk.(*rsa.PrivateKey)
+	leafCert, leafPEM := generateCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    fixedClockStart,
+		NotAfter:     fixedClockStart.Add(time.Hour),
+	}, caCert, rsaSK.Public(), caKey)
+
+	ecSK, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKeyPEM, err := pki.EncodePKCS8PrivateKey(ecSK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keySecret := gen.Secret("test-key",
+		gen.SetSecretNamespace(gen.DefaultTestNamespace),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSPrivateKeyKey: pki.EncodePKCS1PrivateKey(rsaSK),
+		}),
+	)
+	ecKeySecret := gen.Secret("test-key",
+		gen.SetSecretNamespace(gen.DefaultTestNamespace),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSPrivateKeyKey: ecKeyPEM,
+		}),
+	)
+
+	baseCRNotApproved := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(csrPEM),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Name:  baseIssuer.Name,
+			Group: certmanager.GroupName,
+			Kind:  baseIssuer.Kind,
+		}),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestPrivateKeyAnnotationKey: "test-key",
+		}),
+	)
+	baseCR := gen.CertificateRequestFrom(baseCRNotApproved,
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionApproved,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             "cert-manager.io",
+			Message:            "Certificate request has been approved by cert-manager.io",
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+	noAnnotationCR := baseCR.DeepCopy()
+	delete(noAnnotationCR.Annotations, cmapi.CertificateRequestPrivateKeyAnnotationKey)
+	transactionAnnotation := gen.AddCertificateRequestAnnotations(map[string]string{
+		cmapi.SCEPTransactionIDAnnotationKey: "abc123",
+	})
+	pollingCR := gen.CertificateRequestFrom(baseCR, transactionAnnotation)
+
+	update := func(subresource string, cr *cmapi.CertificateRequest, mods ...gen.CertificateRequestModifier) testpkg.Action {
+		return testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+			cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+			subresource,
+			gen.DefaultTestNamespace,
+			gen.CertificateRequestFrom(cr, mods...),
+		))
+	}
+	statusUpdate := func(cr *cmapi.CertificateRequest, mods ...gen.CertificateRequestModifier) testpkg.Action {
+		return update("status", cr, mods...)
+	}
+	pendingCondition := func(message string) gen.CertificateRequestModifier {
+		return gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionReady,
+			Status:             cmmeta.ConditionFalse,
+			Reason:             cmapi.CertificateRequestReasonPending,
+			Message:            message,
+			LastTransitionTime: &metaFixedClockStart,
+		})
+	}
+	failedCondition := func(message string) []gen.CertificateRequestModifier {
+		return []gen.CertificateRequestModifier{
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:               cmapi.CertificateRequestConditionReady,
+				Status:             cmmeta.ConditionFalse,
+				Reason:             cmapi.CertificateRequestReasonFailed,
+				Message:            message,
+				LastTransitionTime: &metaFixedClockStart,
+			}),
+			gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+		}
+	}
+	issuedUpdate := func(cr *cmapi.CertificateRequest) testpkg.Action {
+		return statusUpdate(cr,
+			gen.SetCertificateRequestCertificate(leafPEM),
+			gen.SetCertificateRequestCA(caPEM),
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:               cmapi.CertificateRequestConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				Reason:             cmapi.CertificateRequestReasonIssued,
+				Message:            "Certificate fetched from issuer successfully",
+				LastTransitionTime: &metaFixedClockStart,
+			}),
+		)
+	}
+
+	serverWith := func() *fakescep.SCEP {
+		return fakescep.New().
+			WithGetCACaps(internalscep.Capabilities{"POSTPKIOperation", "SHA-256", "AES"}, nil).
+			WithGetCACert([]*x509.Certificate{caCert}, nil)
+	}
+	successResponse := &internalscep.Response{
+		Status:       internalscep.StatusSuccess,
+		Certificates: []*x509.Certificate{leafCert},
+	}
+	pendingResponse := &internalscep.Response{Status: internalscep.StatusPending}
+
+	tests := map[string]testT{
+		"a CertificateRequest without an approved condition should do nothing": {
+			certificateRequest: baseCRNotApproved.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCRNotApproved.DeepCopy(), baseIssuer.DeepCopy()},
+			},
+		},
+		"a missing challenge password secret should report pending": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal SecretMissing Required secret resource not found: secrets "scep-password" not found`,
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(baseCR, pendingCondition(`Required secret resource not found: secrets "scep-password" not found`)),
+				},
+			},
+			fakeSCEP: fakescep.New().WithNew(func(string, corelisters.SecretLister, cmapi.GenericIssuer) (*fakescep.SCEP, error) {
+				return nil, k8sErrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "scep-password")
+			}),
+		},
+		"a missing private key annotation should report failed": {
+			certificateRequest: noAnnotationCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{noAnnotationCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning MissingAnnotation Annotation "cert-manager.io/private-key-secret-name" missing or reference empty: secret name missing`,
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(noAnnotationCR, failedCondition(`Annotation "cert-manager.io/private-key-secret-name" missing or reference empty: secret name missing`)...),
+				},
+			},
+			fakeSCEP: serverWith(),
+		},
+		"a non-RSA private key should report failed": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{ecKeySecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning ErrorPrivateKey SCEP issuer only supports RSA private keys: unsupported private key type *ecdsa.PrivateKey",
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(baseCR, failedCondition("SCEP issuer only supports RSA private keys: unsupported private key type *ecdsa.PrivateKey")...),
+				},
+			},
+			fakeSCEP: serverWith(),
+		},
+		"failing to retrieve the CA certificates should report pending and return an error": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{keySecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal SCEPCACertError Failed to retrieve CA certificates from SCEP server: connection refused",
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(baseCR, pendingCondition("Failed to retrieve CA certificates from SCEP server: connection refused")),
+				},
+			},
+			fakeSCEP:    serverWith().WithGetCACert(nil, errors.New("connection refused")),
+			expectedErr: true,
+		},
+		"a pending PKCSReq should set the transaction ID and report pending": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{keySecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending SCEP certificate is requested",
+				},
+				ExpectedActions: []testpkg.Action{
+					update("", baseCR,
+						pendingCondition("SCEP certificate is requested"),
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.SCEPTransactionIDAnnotationKey: transactionID(t, csrPEM),
+						}),
+					),
+				},
+			},
+			fakeSCEP: serverWith().WithPKCSReq(pendingResponse, nil),
+		},
+		"a rejected PKCSReq should report failed with the failInfo as reason": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{keySecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning BadRequest SCEP server rejected the certificate request: failInfo BadRequest",
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(baseCR, failedCondition("SCEP server rejected the certificate request: failInfo BadRequest")...),
+				},
+			},
+			fakeSCEP: serverWith().WithPKCSReq(&internalscep.Response{
+				Status:   internalscep.StatusFailure,
+				FailInfo: internalscep.BadRequest,
+			}, nil),
+		},
+		"a successful PKCSReq should return the certificate and CA": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{keySecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{issuedUpdate(baseCR)},
+			},
+			fakeSCEP: serverWith().
+				WithPKCSReq(successResponse, nil).
+				WithCertPoll(nil, errors.New("unexpected certpoll")),
+		},
+		"a pending CertPoll should report pending and return an error": {
+			certificateRequest: pollingCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{keySecret},
+				CertManagerObjects: []runtime.Object{pollingCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuancePending SCEP certificate still in a pending state, the request will be retried: transaction abc123 is pending",
+				},
+				ExpectedActions: []testpkg.Action{
+					statusUpdate(pollingCR, pendingCondition("SCEP certificate still in a pending state, the request will be retried: transaction abc123 is pending")),
+				},
+			},
+			fakeSCEP: serverWith().
+				WithPKCSReq(nil, errors.New("unexpected pkcsreq")).
+				WithCertPoll(pendingResponse, nil),
+			expectedErr: true,
+		},
+		"a successful CertPoll should return the certificate and CA": {
+			certificateRequest: pollingCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{keySecret},
+				CertManagerObjects: []runtime.Object{pollingCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{issuedUpdate(pollingCR)},
+			},
+			fakeSCEP: serverWith().
+				WithPKCSReq(nil, errors.New("unexpected pkcsreq")).
+				WithCertPoll(successResponse, nil),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			test.builder.Clock = fixedClock
+			runTest(t, test)
+		})
+	}
+}
+
+func transactionID(t *testing.T, csrPEM []byte) string {
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return internalscep.TransactionID(csr)
+}
+
+type testT struct {
+	builder            *testpkg.Builder
+	certificateRequest *cmapi.CertificateRequest
+
+	expectedErr bool
+
+	fakeSCEP *fakescep.SCEP
+}
+
+func runTest(t *testing.T, test testT) {
+	test.builder.T = t
+	test.builder.Init()
+	defer test.builder.Stop()
+
+	scep := NewSCEP(test.builder.Context)
+
+	if test.fakeSCEP != nil {
+		scep.scepClientBuilder = func(ns string, sl corelisters.SecretLister,
+			iss cmapi.GenericIssuer) (internalscep.Interface, error) {
+			return test.fakeSCEP.New(ns, sl, iss)
+		}
+	}
+
+	controller := certificaterequests.New(apiutil.IssuerSCEP, scep)
+	if _, _, err := controller.Register(test.builder.Context); err != nil {
+		t.Errorf("failed to register context with controller: %v", err)
+	}
+
+	test.builder.Start()
+
+	err := controller.Sync(context.Background(), test.certificateRequest)
+	if err != nil && !test.expectedErr {
+		t.Errorf("expected to not get an error, but got: %v", err)
+	}
+	if err == nil && test.expectedErr {
+		t.Errorf("expected to get an error but did not get one")
+	}
+
+	test.builder.CheckAndFinish(err)
+}
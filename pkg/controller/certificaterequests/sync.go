@@ -18,6 +18,7 @@ package certificaterequests
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
@@ -111,6 +113,18 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	if issuerObj.GetSpec().NamespaceAccess != nil {
+		allowed, err := c.issuerAllowsNamespace(issuerObj, crCopy.Namespace)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			c.reporter.Failed(crCopy, fmt.Errorf("namespace %q is not allowed by its namespaceAccess", crCopy.Namespace), "NamespaceNotAllowed",
+				fmt.Sprintf("Referenced %s %q cannot be used from this namespace", apiutil.IssuerKind(crCopy.Spec.IssuerRef), crCopy.Spec.IssuerRef.Name))
+			return nil
+		}
+	}
+
 	// check ready condition
 	if !apiutil.IssuerHasCondition(issuerObj, cmapi.IssuerCondition{
 		Type:   cmapi.IssuerConditionReady,
@@ -132,6 +146,15 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 
 	// Attempt to call the Sign function on our issuer
 	resp, err := c.issuer.Sign(ctx, crCopy, issuerObj)
+	var retryErr *issuer.RetryAfterError
+	if errors.As(err, &retryErr) {
+		key, keyErr := keyFunc(crCopy)
+		if keyErr == nil {
+			dbg.Info("issuer asked to retry the certificate request later", "retry_after", retryErr.RetryAfter)
+			c.queue.AddAfter(key, retryErr.RetryAfter)
+			return nil
+		}
+	}
 	if err != nil {
 		log.Error(err, "error issuing certificate request")
 		return err
@@ -156,11 +179,24 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	}
 
 	// Set condition to Ready.
-	c.reporter.Ready(crCopy)
+	c.reporter.Ready(crCopy, resp.Warnings...)
 
 	return nil
 }
 
+// issuerAllowsNamespace returns true if the namespace access restrictions of
+// the issuer allow it to be used from the given namespace.
+func (c *Controller) issuerAllowsNamespace(iss cmapi.GenericIssuer, namespace string) (bool, error) {
+	if c.namespaceLister == nil {
+		return false, fmt.Errorf("cannot check namespace access of %q as namespaces are not being watched", iss.GetObjectMeta().Name)
+	}
+	ns, err := c.namespaceLister.Get(namespace)
+	if err != nil {
+		return false, err
+	}
+	return apiutil.IssuerAllowsNamespace(iss, ns)
+}
+
 func (c *Controller) updateCertificateRequestStatusAndAnnotations(ctx context.Context, old, new *cmapi.CertificateRequest) (*cmapi.CertificateRequest, error) {
 	log := logf.FromContext(ctx, "updateStatus")
 
@@ -29,6 +29,7 @@ import (
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
@@ -486,6 +487,53 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		"should fail if the referenced ClusterIssuer cannot be used from the namespace": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Kind: cmapi.ClusterIssuerKind,
+					Name: "test-clusterissuer",
+				}),
+			),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: gen.DefaultTestNamespace}},
+				},
+				CertManagerObjects: []runtime.Object{baseCR,
+					gen.ClusterIssuer("test-clusterissuer",
+						gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
+						gen.SetIssuerNamespaceAccess(cmapi.IssuerNamespaceAccess{Namespaces: []string{"other-ns"}}),
+						gen.AddIssuerCondition(cmapi.IssuerCondition{
+							Type:   cmapi.IssuerConditionReady,
+							Status: cmmeta.ConditionTrue,
+						}),
+					),
+				},
+				ExpectedEvents: []string{
+					`Warning NamespaceNotAllowed Referenced ClusterIssuer "test-clusterissuer" cannot be used from this namespace: namespace "default-unit-test-ns" is not allowed by its namespaceAccess`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+								Kind: cmapi.ClusterIssuerKind,
+								Name: "test-clusterissuer",
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            `Referenced ClusterIssuer "test-clusterissuer" cannot be used from this namespace: namespace "default-unit-test-ns" is not allowed by its namespaceAccess`,
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestFailureTime(nowMetaTime),
+						),
+					)),
+				},
+			},
+		},
 		"exit nil and no action if the issuer type does not match ours (its not meant for us)": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -18,6 +18,7 @@ package util
 
 import (
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -104,8 +105,10 @@ func (r *Reporter) Pending(cr *cmapi.CertificateRequest, err error, reason, mess
 }
 
 // Ready marks a CertificateRequest as Ready and sends a corresponding event.
-func (r *Reporter) Ready(cr *cmapi.CertificateRequest) {
-	r.recorder.Event(cr, corev1.EventTypeNormal, "CertificateIssued", readyMessage)
+// Any warnings are appended to the message of the Ready condition and event.
+func (r *Reporter) Ready(cr *cmapi.CertificateRequest, warnings ...string) {
+	message := strings.Join(append([]string{readyMessage}, warnings...), ". ")
+	r.recorder.Event(cr, corev1.EventTypeNormal, "CertificateIssued", message)
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
-		cmmeta.ConditionTrue, cmapi.CertificateRequestReasonIssued, readyMessage)
+		cmmeta.ConditionTrue, cmapi.CertificateRequestReasonIssued, message)
 }
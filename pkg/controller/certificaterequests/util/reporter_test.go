@@ -42,6 +42,7 @@ type reporterT struct {
 
 	err             error
 	message, reason string
+	warnings        []string
 
 	call string
 
@@ -213,6 +214,24 @@ func TestReporter(t *testing.T) {
 			call: "ready",
 		},
 
+		"a ready report with warnings should append the warnings to the condition and event messages": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR),
+			warnings:           []string{"Certificate expiry reduced"},
+			expectedEvents: []string{
+				"Normal CertificateIssued Certificate fetched from issuer successfully. Certificate expiry reduced",
+			},
+			expectedConditions: []cmapi.CertificateRequestCondition{{
+				Type:               cmapi.CertificateRequestConditionReady,
+				Reason:             "Issued",
+				Message:            "Certificate fetched from issuer successfully. Certificate expiry reduced",
+				Status:             "True",
+				LastTransitionTime: &nowMetaTime,
+			}},
+			expectedFailureTime: nil,
+
+			call: "ready",
+		},
+
 		"a denied report should update the Ready condition to 'Denied'": {
 			certificateRequest:  gen.CertificateRequestFrom(baseCR),
 			expectedEvents:      []string{},
@@ -260,7 +279,7 @@ func (tt *reporterT) runTest(t *testing.T) {
 	case "denied":
 		reporter.Denied(tt.certificateRequest)
 	default:
-		reporter.Ready(tt.certificateRequest)
+		reporter.Ready(tt.certificateRequest, tt.warnings...)
 	}
 
 	expConditions := conditionsToString(tt.expectedConditions)
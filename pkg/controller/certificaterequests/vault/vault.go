@@ -20,6 +20,7 @@ import (
 	"context"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
@@ -41,6 +42,7 @@ const (
 // pkg/controller/certificaterequests.Issuer interface.
 type Vault struct {
 	issuerOptions controllerpkg.IssuerOptions
+	kubeClient    kubernetes.Interface
 	secretsLister corelisters.SecretLister
 	reporter      *crutil.Reporter
 
@@ -60,9 +62,10 @@ func init() {
 func NewVault(ctx *controllerpkg.Context) *Vault {
 	return &Vault{
 		issuerOptions:      ctx.IssuerOptions,
+		kubeClient:         ctx.Client,
 		secretsLister:      ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder),
-		vaultClientBuilder: vaultinternal.New,
+		vaultClientBuilder: ctx.VaultTokenCache.New,
 	}
 }
 
@@ -74,7 +77,8 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
-	client, err := v.vaultClientBuilder(resourceNamespace, v.secretsLister, issuerObj)
+	createToken := v.kubeClient.CoreV1().ServiceAccounts(resourceNamespace).CreateToken
+	client, err := v.vaultClientBuilder(ctx, resourceNamespace, createToken, v.secretsLister, issuerObj)
 	if k8sErrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 
@@ -92,7 +96,7 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 	}
 
 	certDuration := apiutil.DefaultCertDuration(cr.Spec.Duration)
-	certPem, caPem, err := client.Sign(cr.Spec.Request, certDuration)
+	certPem, caPem, err := client.Sign(ctx, cr.Spec.Request, certDuration, cr.Annotations[v1.VaultRoleAnnotationKey])
 	if err != nil {
 		message := "Vault failed to sign certificate"
 
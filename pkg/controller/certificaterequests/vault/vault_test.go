@@ -168,6 +168,12 @@ func TestSign(t *testing.T) {
 		},
 	}
 
+	roleCR := gen.CertificateRequestFrom(baseCR,
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.VaultRoleAnnotationKey: "web",
+		}),
+	)
+
 	tests := map[string]testT{
 		"a CertificateRequest without an approved condition should do nothing": {
 			certificateRequest: baseCRNotApproved.DeepCopy(),
@@ -207,7 +213,7 @@ func TestSign(t *testing.T) {
 				KubeObjects:        []runtime.Object{},
 				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Normal VaultInitError Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
+					"Normal VaultInitError Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes, JWT or client certificate auth not set",
 				},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -219,7 +225,7 @@ func TestSign(t *testing.T) {
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
+								Message:            "Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes, JWT or client certificate auth not set",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
@@ -497,6 +503,56 @@ func TestSign(t *testing.T) {
 			},
 			fakeVault: fakevault.New().WithSign(rsaPEMCert, rsaPEMCert, nil),
 		},
+		"a request with a vault role annotation should sign using that role": {
+			certificateRequest: roleCR,
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{tokenSecret},
+				CertManagerObjects: []runtime.Object{roleCR.DeepCopy(), gen.IssuerFrom(baseIssuer,
+					gen.SetIssuerVault(cmapi.VaultIssuer{
+						Path:         "pki/sign/default",
+						AllowedRoles: []string{"web"},
+						Auth: cmapi.VaultAuth{
+							TokenSecretRef: &cmmeta.SecretKeySelector{
+								Key: "my-token-key",
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "token-secret",
+								},
+							},
+						},
+					}),
+				)},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(roleCR,
+							gen.SetCertificateRequestCertificate(rsaPEMCert),
+							gen.SetCertificateRequestCA(rsaPEMCert),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			fakeVault: &fakevault.Vault{
+				NewFn: fakevault.New().NewFn,
+				SignFn: func(_ context.Context, _ []byte, _ time.Duration, role string) ([]byte, []byte, error) {
+					if role != "web" {
+						return nil, nil, fmt.Errorf("unexpected role %q", role)
+					}
+					return rsaPEMCert, rsaPEMCert, nil
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -525,9 +581,9 @@ func runTest(t *testing.T, test testT) {
 	vault := NewVault(test.builder.Context)
 
 	if test.fakeVault != nil {
-		vault.vaultClientBuilder = func(ns string, sl corelisters.SecretLister,
-			iss cmapi.GenericIssuer) (internalvault.Interface, error) {
-			return test.fakeVault.New(ns, sl, iss)
+		vault.vaultClientBuilder = func(ctx context.Context, ns string, ct internalvault.CreateTokenFn,
+			sl corelisters.SecretLister, iss cmapi.GenericIssuer) (internalvault.Interface, error) {
+			return test.fakeVault.New(ctx, ns, ct, sl, iss)
 		}
 	}
 
@@ -77,16 +77,6 @@ func encodePKCS12Keystore(password string, rawKey []byte, certPem []byte, caPem
 	return pkcs12.Encode(rand.Reader, key, certs[0], cas, password)
 }
 
-func encodePKCS12Truststore(password string, caPem []byte) ([]byte, error) {
-	ca, err := pki.DecodeX509CertificateBytes(caPem)
-	if err != nil {
-		return nil, err
-	}
-
-	var cas = []*x509.Certificate{ca}
-	return pkcs12.EncodeTrustStore(rand.Reader, cas, password)
-}
-
 func encodeJKSKeystore(password []byte, rawKey []byte, certPem []byte, caPem []byte) ([]byte, error) {
 	// encode the private key to PKCS8
 	key, err := pki.DecodePrivateKeyBytes(rawKey)
@@ -144,28 +134,3 @@ func encodeJKSKeystore(password []byte, rawKey []byte, certPem []byte, caPem []b
 	}
 	return buf.Bytes(), nil
 }
-
-func encodeJKSTruststore(password []byte, caPem []byte) ([]byte, error) {
-	ca, err := pki.DecodeX509CertificateBytes(caPem)
-	if err != nil {
-		return nil, err
-	}
-
-	ks := jks.KeyStore{
-		"ca": &jks.TrustedCertificateEntry{
-			Entry: jks.Entry{
-				CreationDate: time.Now(),
-			},
-			Certificate: jks.Certificate{
-				Type:    "X509",
-				Content: ca.Raw,
-			},
-		},
-	}
-
-	buf := &bytes.Buffer{}
-	if err := jks.Encode(buf, ks, password); err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
-}
@@ -161,7 +161,7 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 			secret.Data[pkcs12SecretKey] = keystoreData
 
 			if len(data.CA) > 0 {
-				truststoreData, err := encodePKCS12Truststore(string(pw), data.CA)
+				truststoreData, err := utilpki.EncodePKCS12Truststore(string(pw), data.CA)
 				if err != nil {
 					return fmt.Errorf("error encoding PKCS12 trust store bundle: %w", err)
 				}
@@ -192,7 +192,7 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 			secret.Data[jksSecretKey] = keystoreData
 
 			if len(data.CA) > 0 {
-				truststoreData, err := encodeJKSTruststore(pw, data.CA)
+				truststoreData, err := utilpki.EncodeJKSTruststore(pw, data.CA)
 				if err != nil {
 					return fmt.Errorf("error encoding JKS trust store bundle: %w", err)
 				}
@@ -205,6 +205,12 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 		}
 	}
 
+	// A stored OCSP response is only valid for the certificate it was
+	// fetched for, so remove it whenever the certificate changes.
+	if !ocspStaplingEnabled(crt) || !bytes.Equal(secret.Data[corev1.TLSCertKey], data.Certificate) {
+		delete(secret.Data, cmmeta.TLSOCSPStapleKey)
+	}
+
 	secret.Data[corev1.TLSPrivateKeyKey] = data.PrivateKey
 	secret.Data[corev1.TLSCertKey] = data.Certificate
 	if len(data.CA) > 0 {
@@ -261,3 +267,35 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 
 	return nil
 }
+
+// UpdateOCSPStaple stores the given DER encoded OCSP response in the
+// Certificate's existing Secret resource. If staple is empty, any stored
+// OCSP response is removed instead.
+// The Secret resource is only updated if its OCSP response data changes.
+func (s *SecretsManager) UpdateOCSPStaple(ctx context.Context, crt *cmapi.Certificate, staple []byte) error {
+	secret, err := s.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(secret.Data[cmmeta.TLSOCSPStapleKey], staple) {
+		return nil
+	}
+
+	secret = secret.DeepCopy()
+	if len(staple) > 0 {
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[cmmeta.TLSOCSPStapleKey] = staple
+	} else {
+		delete(secret.Data, cmmeta.TLSOCSPStapleKey)
+	}
+
+	_, err = s.kubeClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	return err
+}
+
+func ocspStaplingEnabled(crt *cmapi.Certificate) bool {
+	return crt.Spec.OCSPStapling != nil && crt.Spec.OCSPStapling.Enabled
+}
@@ -72,6 +72,10 @@ func TestSecretsManager(t *testing.T) {
 		}),
 	)
 
+	baseCertWithOCSPStapling := gen.CertificateFrom(baseCertBundle.Certificate,
+		gen.SetCertificateOCSPStapling(true),
+	)
+
 	tests := map[string]testT{
 		"if secret does not exists and unable to decode certificate, then error": {
 			certificate: baseCertBundle.Certificate,
@@ -400,6 +404,113 @@ func TestSecretsManager(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+
+		"if the certificate changes, remove the stored OCSP staple": {
+			certificate: baseCertWithOCSPStapling,
+			SecretData:  SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       []byte("foo"),
+							corev1.TLSPrivateKeyKey: []byte("test-key"),
+							cmmeta.TLSCAKey:         []byte("test-ca"),
+							cmmeta.TLSOCSPStapleKey: []byte("test-staple"),
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: gen.DefaultTestNamespace,
+								Name:      "output",
+								Annotations: map[string]string{
+									cmapi.CertificateNameKey:       "test",
+									cmapi.IssuerGroupAnnotationKey: "foo.io",
+									cmapi.IssuerKindAnnotationKey:  "Issuer",
+									cmapi.IssuerNameAnnotationKey:  "ca-issuer",
+
+									cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
+									cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
+									cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+									cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								},
+								Labels: map[string]string{},
+							},
+							Data: map[string][]byte{
+								corev1.TLSCertKey:       baseCertBundle.CertBytes,
+								corev1.TLSPrivateKeyKey: []byte("test-key"),
+								cmmeta.TLSCAKey:         []byte("test-ca"),
+							},
+							Type: corev1.SecretTypeTLS,
+						},
+					)),
+				},
+			},
+			expectedErr: false,
+		},
+
+		"if the certificate does not change, keep the stored OCSP staple": {
+			certificate: baseCertWithOCSPStapling,
+			SecretData:  SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey: []byte("test-key"),
+							cmmeta.TLSCAKey:         []byte("test-ca"),
+							cmmeta.TLSOCSPStapleKey: []byte("test-staple"),
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: gen.DefaultTestNamespace,
+								Name:      "output",
+								Annotations: map[string]string{
+									cmapi.CertificateNameKey:       "test",
+									cmapi.IssuerGroupAnnotationKey: "foo.io",
+									cmapi.IssuerKindAnnotationKey:  "Issuer",
+									cmapi.IssuerNameAnnotationKey:  "ca-issuer",
+
+									cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
+									cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
+									cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+									cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								},
+								Labels: map[string]string{},
+							},
+							Data: map[string][]byte{
+								corev1.TLSCertKey:       baseCertBundle.CertBytes,
+								corev1.TLSPrivateKeyKey: []byte("test-key"),
+								cmmeta.TLSCAKey:         []byte("test-ca"),
+								cmmeta.TLSOCSPStapleKey: []byte("test-staple"),
+							},
+							Type: corev1.SecretTypeTLS,
+						},
+					)),
+				},
+			},
+			expectedErr: false,
+		},
 	}
 
 	// TODO: add to these tests once the JKS/PKCS12 support is updated
@@ -434,3 +545,96 @@ func TestSecretsManager(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateOCSPStaple(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateOCSPStapling(true),
+	)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: gen.DefaultTestNamespace,
+			Name:      "output",
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey: []byte("test-cert"),
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+	secretWithData := func(data map[string][]byte) *corev1.Secret {
+		s := secret.DeepCopy()
+		for k, v := range data {
+			s.Data[k] = v
+		}
+		return s
+	}
+
+	tests := map[string]struct {
+		builder     *testpkg.Builder
+		staple      []byte
+		expectedErr bool
+	}{
+		"if the secret does not exist, return an error": {
+			staple:      []byte("test-staple"),
+			builder:     &testpkg.Builder{},
+			expectedErr: true,
+		},
+		"store the OCSP staple in the secret": {
+			staple: []byte("test-staple"),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{secret},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						secretWithData(map[string][]byte{cmmeta.TLSOCSPStapleKey: []byte("test-staple")}),
+					)),
+				},
+			},
+		},
+		"do nothing if the stored OCSP staple is up to date": {
+			staple: []byte("test-staple"),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{secretWithData(map[string][]byte{cmmeta.TLSOCSPStapleKey: []byte("test-staple")})},
+			},
+		},
+		"remove the stored OCSP staple if the staple is empty": {
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{secretWithData(map[string][]byte{cmmeta.TLSOCSPStapleKey: []byte("test-staple")})},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						secret,
+					)),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.builder.T = t
+			test.builder.Init()
+			defer test.builder.Stop()
+
+			testManager := New(
+				test.builder.Client,
+				test.builder.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+				false,
+			)
+
+			test.builder.Start()
+
+			err := testManager.UpdateOCSPStaple(context.Background(), crt, test.staple)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+			test.builder.CheckAndFinish(err)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocspstapling
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificates"
+	"github.com/jetstack/cert-manager/pkg/controller/certificates/internal/secretsmanager"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/scheduler"
+	"github.com/jetstack/cert-manager/pkg/util/predicate"
+)
+
+const (
+	// ControllerName is the name of the OCSP stapling controller.
+	ControllerName = "certificates-ocsp-stapling"
+
+	// DefaultRefreshInterval is how long an OCSP response that does not set
+	// a nextUpdate time is used for before it is fetched again.
+	DefaultRefreshInterval = time.Hour
+
+	reasonOCSPStapleUpdated = "OCSPStapleUpdated"
+	reasonOCSPStapleFailed  = "OCSPStapleFailed"
+)
+
+// controller fetches OCSP responses for the certificates of Certificate
+// resources that have OCSP stapling enabled, and stores them in the
+// Certificate's Secret resource so that they can be stapled by servers.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
+	recorder          record.EventRecorder
+	clock             clock.Clock
+
+	// secretsManager is used to store OCSP responses in the Certificate's
+	// Secret resource
+	secretsManager *secretsmanager.SecretsManager
+	// httpClient is used to send requests to OCSP responders
+	httpClient *http.Client
+	// scheduledWorkQueue is used to refresh OCSP responses before their
+	// nextUpdate time
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
+}
+
+// NewController returns a new OCSP stapling controller.
+func NewController(
+	log logr.Logger,
+	kubeClient kubernetes.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	httpClient *http.Client,
+	certificateControllerOptions controllerpkg.CertificateOptions,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	secretsInformer := factory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Trigger reconciles on changes to the Secret named `spec.secretName`
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		certificateLister: certificateInformer.Lister(),
+		secretLister:      secretsInformer.Lister(),
+		recorder:          recorder,
+		clock:             clock,
+		secretsManager: secretsmanager.New(
+			kubeClient,
+			secretsInformer.Lister(),
+			certificateControllerOptions.EnableOwnerRef,
+		),
+		httpClient:         httpClient,
+		scheduledWorkQueue: scheduler.NewScheduledWorkQueue(clock, queue.Add),
+	}, queue, mustSync
+}
+
+// ProcessItem ensures that the Secret resource of a Certificate with OCSP
+// stapling enabled contains a current OCSP response for its certificate,
+// and that no OCSP response is stored if OCSP stapling is disabled.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Error(err, "certificate not found for key")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log = logf.WithResource(log, crt)
+	ctx = logf.NewContext(ctx, log)
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("secret not found, waiting for it to be created")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if crt.Spec.OCSPStapling == nil || !crt.Spec.OCSPStapling.Enabled {
+		if _, ok := secret.Data[cmmeta.TLSOCSPStapleKey]; ok {
+			log.V(logf.DebugLevel).Info("removing OCSP staple as OCSP stapling is disabled")
+			return c.secretsManager.UpdateOCSPStaple(ctx, crt, nil)
+		}
+		return nil
+	}
+
+	cert, issuerCert, err := certificates.SecretCertificateAndIssuer(secret)
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonOCSPStapleFailed, "Cannot fetch OCSP response: %v", err)
+		return nil
+	}
+	if len(cert.OCSPServer) == 0 {
+		c.recorder.Event(crt, corev1.EventTypeWarning, reasonOCSPStapleFailed,
+			"Cannot fetch OCSP response: certificate does not name an OCSP responder")
+		return nil
+	}
+
+	now := c.clock.Now()
+	if now.After(cert.NotAfter) {
+		log.V(logf.DebugLevel).Info("not fetching OCSP response for expired certificate")
+		return nil
+	}
+
+	if staple := secret.Data[cmmeta.TLSOCSPStapleKey]; len(staple) > 0 {
+		resp, err := ocsp.ParseResponseForCert(staple, cert, issuerCert)
+		if err == nil && now.Before(refreshTime(resp)) {
+			log.V(logf.DebugLevel).Info("stored OCSP response is up to date", "next_update", resp.NextUpdate)
+			c.scheduledWorkQueue.Add(key, refreshTime(resp).Sub(now))
+			return nil
+		}
+	}
+
+	responder := cert.OCSPServer[0]
+	raw, resp, err := certificates.FetchOCSPResponse(ctx, c.httpClient, responder, cert, issuerCert)
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonOCSPStapleFailed,
+			"Failed to fetch OCSP response from %s: %v", responder, err)
+		return err
+	}
+
+	if err := c.secretsManager.UpdateOCSPStaple(ctx, crt, raw); err != nil {
+		return err
+	}
+
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonOCSPStapleUpdated,
+		"Stored OCSP response from %s with status %s", responder, statusString(resp.Status))
+
+	c.scheduledWorkQueue.Add(key, refreshTime(resp).Sub(now))
+
+	return nil
+}
+
+// refreshTime returns the time at which a new OCSP response should be
+// fetched, which is two thirds of the way through the response's validity
+// period so that there is time to retry before it expires.
+func refreshTime(resp *ocsp.Response) time.Time {
+	if resp.NextUpdate.IsZero() {
+		return resp.ThisUpdate.Add(DefaultRefreshInterval)
+	}
+	validity := resp.NextUpdate.Sub(resp.ThisUpdate)
+	return resp.ThisUpdate.Add(validity * 2 / 3)
+}
+
+func statusString(status int) string {
+	switch status {
+	case ocsp.Good:
+		return "Good"
+	case ocsp.Revoked:
+		return "Revoked"
+	default:
+		return "Unknown"
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		&http.Client{Timeout: certificates.RevocationStatusTimeout},
+		ctx.CertificateOptions,
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
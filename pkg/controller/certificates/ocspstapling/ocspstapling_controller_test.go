@@ -0,0 +1,363 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocspstapling
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+type testKeyPair struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     crypto.Signer
+}
+
+func mustCreateKeyPair(t *testing.T, template *x509.Certificate, parent *testKeyPair) *testKeyPair {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parentCert, parentKey := template, crypto.Signer(key)
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, err := pki.EncodeX509(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testKeyPair{cert: cert, certPEM: certPEM, key: key}
+}
+
+// testResponder is a stand-in for an OCSP responder, which signs responses
+// using the CA's keypair.
+type testResponder struct {
+	t          *testing.T
+	ca         *testKeyPair
+	status     int
+	thisUpdate time.Time
+	nextUpdate time.Time
+	httpStatus int
+}
+
+func (r *testResponder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.httpStatus != 0 {
+		w.WriteHeader(r.httpStatus)
+		return
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		r.t.Errorf("failed to read OCSP request: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	ocspReq, err := ocsp.ParseRequest(body)
+	if err != nil {
+		r.t.Errorf("failed to parse OCSP request: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	resp, err := ocsp.CreateResponse(r.ca.cert, r.ca.cert, ocsp.Response{
+		Status:       r.status,
+		SerialNumber: ocspReq.SerialNumber,
+		ThisUpdate:   r.thisUpdate,
+		NextUpdate:   r.nextUpdate,
+	}, r.ca.key)
+	if err != nil {
+		r.t.Errorf("failed to create OCSP response: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/ocsp-response")
+	w.Write(resp)
+}
+
+func TestProcessItem(t *testing.T) {
+	fixedNow := time.Now().Truncate(time.Second)
+	fixedClock := fakeclock.NewFakeClock(fixedNow)
+
+	caTemplate := func() *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "test-ca"},
+			NotBefore:             fixedNow.Add(-time.Hour),
+			NotAfter:              fixedNow.Add(time.Hour * 24 * 365),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+	}
+	ca := mustCreateKeyPair(t, caTemplate(), nil)
+	otherCA := mustCreateKeyPair(t, caTemplate(), nil)
+
+	responder := &testResponder{t: t, ca: ca}
+	server := httptest.NewServer(responder)
+	defer server.Close()
+
+	leafTemplate := func(ocspServers ...string) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: big.NewInt(1234),
+			Subject:      pkix.Name{CommonName: "example.com"},
+			DNSNames:     []string{"example.com"},
+			NotBefore:    fixedNow.Add(-time.Hour),
+			NotAfter:     fixedNow.Add(time.Hour * 24),
+			OCSPServer:   ocspServers,
+		}
+	}
+	leaf := mustCreateKeyPair(t, leafTemplate(server.URL), ca)
+	leafWithoutOCSP := mustCreateKeyPair(t, leafTemplate(), ca)
+
+	mustCreateResponse := func(thisUpdate, nextUpdate time.Time) []byte {
+		resp, err := ocsp.CreateResponse(ca.cert, ca.cert, ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: leaf.cert.SerialNumber,
+			ThisUpdate:   thisUpdate,
+			NextUpdate:   nextUpdate,
+		}, ca.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	currentStaple := mustCreateResponse(fixedNow.Add(-time.Minute), fixedNow.Add(time.Hour))
+	staleStaple := mustCreateResponse(fixedNow.Add(-time.Hour), fixedNow.Add(time.Minute*10))
+
+	baseCrt := gen.Certificate("test-cert",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer"}),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	staplingCrt := gen.CertificateFrom(baseCrt, gen.SetCertificateOCSPStapling(true))
+
+	secret := func(data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-secret"},
+			Data:       data,
+			Type:       corev1.SecretTypeTLS,
+		}
+	}
+
+	// stapleMatches checks that the Secret is updated with a valid OCSP
+	// response for the leaf certificate.
+	stapleMatches := func(exp, act coretesting.Action) error {
+		updated := act.(coretesting.UpdateAction).GetObject().(*corev1.Secret)
+		if updated.Name != "test-secret" {
+			return fmt.Errorf("unexpected Secret updated: %s", updated.Name)
+		}
+		staple := updated.Data[cmmeta.TLSOCSPStapleKey]
+		resp, err := ocsp.ParseResponseForCert(staple, leaf.cert, ca.cert)
+		if err != nil {
+			return fmt.Errorf("invalid OCSP staple stored in Secret: %v", err)
+		}
+		if !resp.ThisUpdate.Equal(fixedNow) {
+			return fmt.Errorf("expected a newly fetched OCSP staple, got one produced at %s", resp.ThisUpdate)
+		}
+		return nil
+	}
+	updateSecretAction := coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns", nil)
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		secret      *corev1.Secret
+		// responder configures the test OCSP responder
+		responder testResponder
+
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+		expectedErr     bool
+	}{
+		"do nothing if OCSP stapling is disabled": {
+			certificate: baseCrt,
+			secret:      secret(map[string][]byte{corev1.TLSCertKey: leaf.certPEM, cmmeta.TLSCAKey: ca.certPEM}),
+		},
+		"remove the stored OCSP staple if OCSP stapling is disabled": {
+			certificate: baseCrt,
+			secret: secret(map[string][]byte{
+				corev1.TLSCertKey:       leaf.certPEM,
+				cmmeta.TLSCAKey:         ca.certPEM,
+				cmmeta.TLSOCSPStapleKey: currentStaple,
+			}),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "testns",
+					secret(map[string][]byte{corev1.TLSCertKey: leaf.certPEM, cmmeta.TLSCAKey: ca.certPEM}))),
+			},
+		},
+		"do nothing if the Secret does not exist": {
+			certificate: staplingCrt,
+		},
+		"fire an event if the certificate does not name an OCSP responder": {
+			certificate:    staplingCrt,
+			secret:         secret(map[string][]byte{corev1.TLSCertKey: leafWithoutOCSP.certPEM, cmmeta.TLSCAKey: ca.certPEM}),
+			expectedEvents: []string{"Warning OCSPStapleFailed Cannot fetch OCSP response: certificate does not name an OCSP responder"},
+		},
+		"fire an event if the issuer certificate is not stored in the Secret": {
+			certificate:    staplingCrt,
+			secret:         secret(map[string][]byte{corev1.TLSCertKey: leaf.certPEM, cmmeta.TLSCAKey: otherCA.certPEM}),
+			expectedEvents: []string{`Warning OCSPStapleFailed Cannot fetch OCSP response: issuer certificate not found in Secret "test-secret"`},
+		},
+		"fetch an OCSP response using the issuer certificate from ca.crt": {
+			certificate: staplingCrt,
+			secret:      secret(map[string][]byte{corev1.TLSCertKey: leaf.certPEM, cmmeta.TLSCAKey: ca.certPEM}),
+			responder:   testResponder{status: ocsp.Good, thisUpdate: fixedNow, nextUpdate: fixedNow.Add(time.Hour)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(updateSecretAction, stapleMatches),
+			},
+			expectedEvents: []string{fmt.Sprintf("Normal OCSPStapleUpdated Stored OCSP response from %s with status Good", server.URL)},
+		},
+		"fetch an OCSP response using the issuer certificate from the chain in tls.crt": {
+			certificate: staplingCrt,
+			secret:      secret(map[string][]byte{corev1.TLSCertKey: append(append([]byte{}, leaf.certPEM...), ca.certPEM...)}),
+			responder:   testResponder{status: ocsp.Revoked, thisUpdate: fixedNow},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(updateSecretAction, stapleMatches),
+			},
+			expectedEvents: []string{fmt.Sprintf("Normal OCSPStapleUpdated Stored OCSP response from %s with status Revoked", server.URL)},
+		},
+		"do nothing if the stored OCSP staple is up to date": {
+			certificate: staplingCrt,
+			secret: secret(map[string][]byte{
+				corev1.TLSCertKey:       leaf.certPEM,
+				cmmeta.TLSCAKey:         ca.certPEM,
+				cmmeta.TLSOCSPStapleKey: currentStaple,
+			}),
+		},
+		"refresh the stored OCSP staple if it is close to its next update": {
+			certificate: staplingCrt,
+			secret: secret(map[string][]byte{
+				corev1.TLSCertKey:       leaf.certPEM,
+				cmmeta.TLSCAKey:         ca.certPEM,
+				cmmeta.TLSOCSPStapleKey: staleStaple,
+			}),
+			responder: testResponder{status: ocsp.Good, thisUpdate: fixedNow, nextUpdate: fixedNow.Add(time.Hour)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(updateSecretAction, stapleMatches),
+			},
+			expectedEvents: []string{fmt.Sprintf("Normal OCSPStapleUpdated Stored OCSP response from %s with status Good", server.URL)},
+		},
+		"replace a stored OCSP staple that is not valid for the certificate": {
+			certificate: staplingCrt,
+			secret: secret(map[string][]byte{
+				corev1.TLSCertKey:       leaf.certPEM,
+				cmmeta.TLSCAKey:         ca.certPEM,
+				cmmeta.TLSOCSPStapleKey: []byte("not-a-response"),
+			}),
+			responder: testResponder{status: ocsp.Good, thisUpdate: fixedNow, nextUpdate: fixedNow.Add(time.Hour)},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(updateSecretAction, stapleMatches),
+			},
+			expectedEvents: []string{fmt.Sprintf("Normal OCSPStapleUpdated Stored OCSP response from %s with status Good", server.URL)},
+		},
+		"fire an event and return an error if the OCSP responder fails": {
+			certificate:    staplingCrt,
+			secret:         secret(map[string][]byte{corev1.TLSCertKey: leaf.certPEM, cmmeta.TLSCAKey: ca.certPEM}),
+			responder:      testResponder{httpStatus: http.StatusInternalServerError},
+			expectedEvents: []string{fmt.Sprintf(`Warning OCSPStapleFailed Failed to fetch OCSP response from %s: unexpected HTTP status "500 Internal Server Error"`, server.URL)},
+			expectedErr:    true,
+		},
+		"fire an event and return an error if the OCSP response is not signed by the issuer": {
+			certificate:    staplingCrt,
+			secret:         secret(map[string][]byte{corev1.TLSCertKey: leaf.certPEM, cmmeta.TLSCAKey: ca.certPEM}),
+			responder:      testResponder{ca: otherCA, status: ocsp.Good, thisUpdate: fixedNow},
+			expectedEvents: []string{fmt.Sprintf("Warning OCSPStapleFailed Failed to fetch OCSP response from %s: invalid OCSP response: bad OCSP signature: x509: ECDSA verification failure", server.URL)},
+			expectedErr:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedNow)
+
+			*responder = test.responder
+			responder.t = t
+			if responder.ca == nil {
+				responder.ca = ca
+			}
+
+			var kubeObjects []runtime.Object
+			if test.secret != nil {
+				kubeObjects = append(kubeObjects, test.secret)
+			}
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				KubeObjects:        kubeObjects,
+				CertManagerObjects: []runtime.Object{test.certificate},
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+
+			c, _, _ := NewController(logf.Log,
+				builder.Client,
+				builder.KubeSharedInformerFactory,
+				builder.SharedInformerFactory,
+				builder.Recorder,
+				builder.Clock,
+				server.Client(),
+				controllerpkg.CertificateOptions{},
+			)
+			builder.Start()
+			defer builder.Stop()
+
+			err := c.ProcessItem(context.Background(), "testns/test-cert")
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			builder.CheckAndFinish(err)
+		})
+	}
+}
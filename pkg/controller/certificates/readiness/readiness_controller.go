@@ -171,6 +171,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		notAfter := metav1.NewTime(x509cert.NotAfter)
 		renewBeforeHint := crt.Spec.RenewBefore
 		renewalTime := c.renewalTimeCalculator(x509cert.NotBefore, x509cert.NotAfter, renewBeforeHint)
+		renewalTime = certificates.RenewalTimeWithRenewalInfo(renewalTime, crt.Status.RenewalInfo, x509cert)
 
 		//update Certificate's Status
 		crt.Status.NotBefore = &notBefore
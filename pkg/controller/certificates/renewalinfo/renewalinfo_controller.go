@@ -0,0 +1,339 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewalinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificates"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/scheduler"
+	"github.com/jetstack/cert-manager/pkg/util/predicate"
+)
+
+const (
+	// ControllerName is the name of the certificate renewal information
+	// controller.
+	ControllerName = "certificates-renewal-info"
+
+	reasonRenewalInfoFailed      = "RenewalInfoFailed"
+	reasonRenewalWindowSuggested = "RenewalWindowSuggested"
+
+	// defaultCheckInterval is the interval between checks of the renewal
+	// information of a certificate if the issuer does not specify one.
+	defaultCheckInterval = 6 * time.Hour
+	// minCheckInterval and maxCheckInterval bound the interval between
+	// checks requested by the issuer.
+	minCheckInterval = time.Minute
+	maxCheckInterval = 24 * time.Hour
+	// retryInterval is the interval after which a failed check is retried.
+	retryInterval = time.Hour
+)
+
+// This controller periodically retrieves the renewal window suggested by the
+// issuer of the certificate currently stored in a Certificate's
+// `spec.secretName`, and records it in the Certificate's
+// `status.renewalInfo`. The readiness and trigger controllers take the
+// suggested window into account when computing the renewal time.
+type controller struct {
+	certificateLister  cmlisters.CertificateLister
+	secretLister       corelisters.SecretLister
+	client             cmclient.Interface
+	recorder           record.EventRecorder
+	clock              clock.Clock
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
+
+	// helper is used to read the (Cluster)Issuer that issued a certificate
+	helper issuer.Helper
+	// issuerFactory is used to obtain an issuer implementation that is able
+	// to provide renewal information
+	issuerFactory issuer.Factory
+}
+
+// NewController returns a new certificate renewal information controller.
+// If namespace is not empty, ClusterIssuers will not be watched and no
+// renewal information is retrieved for certificates issued by a
+// ClusterIssuer.
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	issuerFactory issuer.Factory,
+	namespace string,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	secretsInformer := factory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Trigger reconciles on changes to the Secret named `spec.secretName`
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	// if we are running in non-namespaced mode (i.e. --namespace=""), we also
+	// obtain a lister for clusterissuers.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return &controller{
+		certificateLister:  certificateInformer.Lister(),
+		secretLister:       secretsInformer.Lister(),
+		client:             client,
+		recorder:           recorder,
+		clock:              clock,
+		scheduledWorkQueue: scheduler.NewScheduledWorkQueue(clock, queue.Add),
+		helper:             issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+		issuerFactory:      issuerFactory,
+	}, queue, mustSync
+}
+
+// ProcessItem retrieves the renewal information of the certificate currently
+// stored in the Certificate's `spec.secretName` if it has not been retrieved
+// yet or is due to be checked again, records it in the Certificate's status
+// and schedules the next check.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Error(err, "certificate not found for key")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log = logf.WithResource(log, crt)
+	ctx = logf.NewContext(ctx, log)
+
+	if crt.DeletionTimestamp != nil {
+		return nil
+	}
+
+	secret, cert, err := certificates.CurrentCertificate(ctx, c.secretLister, crt)
+	if err != nil {
+		return err
+	}
+	// Nothing to check until a certificate has been issued, and there is no
+	// point in checking certificates that have expired.
+	if cert == nil || c.clock.Now().After(cert.NotAfter) {
+		return nil
+	}
+
+	serial := fmt.Sprintf("%x", cert.SerialNumber)
+	if info := crt.Status.RenewalInfo; info != nil && info.SerialNumber == serial {
+		if checkIn := info.NextCheckTime.Time.Sub(c.clock.Now()); checkIn > 0 {
+			c.scheduleCheck(log, key, checkIn)
+			return nil
+		}
+	}
+
+	provider, err := c.renewalInfoProviderFor(certificates.IssuerRefForSecret(crt, secret), crt.Namespace)
+	if err == nil {
+		var renewalInfo *issuer.RenewalInfo
+		renewalInfo, err = provider.RenewalInfo(ctx, cert)
+		if err == nil {
+			return c.recordRenewalInfo(ctx, crt, serial, renewalInfo)
+		}
+	}
+	if errors.Is(err, issuer.ErrRenewalInfoNotSupported) {
+		log.V(logf.DebugLevel).Info("issuer does not provide renewal information")
+		return nil
+	}
+
+	c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRenewalInfoFailed,
+		"Failed to retrieve renewal information for certificate with serial number %s: %v", serial, err)
+	c.scheduleCheck(log, key, retryInterval)
+
+	return nil
+}
+
+// recordRenewalInfo records the renewal information retrieved for the
+// certificate with the given serial number in the Certificate's status, and
+// schedules the next check.
+func (c *controller) recordRenewalInfo(ctx context.Context, crt *cmapi.Certificate, serial string, renewalInfo *issuer.RenewalInfo) error {
+	checkIn := checkInterval(renewalInfo.RetryAfter)
+	now := c.clock.Now()
+	info := &cmapi.CertificateRenewalInfo{
+		SerialNumber:         serial,
+		SuggestedWindowStart: metav1.NewTime(renewalInfo.SuggestedWindowStart),
+		SuggestedWindowEnd:   metav1.NewTime(renewalInfo.SuggestedWindowEnd),
+		ExplanationURL:       renewalInfo.ExplanationURL,
+		LastCheckTime:        metav1.NewTime(now),
+		NextCheckTime:        metav1.NewTime(now.Add(checkIn)),
+	}
+
+	if windowChanged(crt.Status.RenewalInfo, info) {
+		message := fmt.Sprintf("Issuer suggests renewing certificate with serial number %s between %s and %s",
+			serial, info.SuggestedWindowStart.UTC().Format(time.RFC3339), info.SuggestedWindowEnd.UTC().Format(time.RFC3339))
+		if info.ExplanationURL != "" {
+			message += fmt.Sprintf(", see %s", info.ExplanationURL)
+		}
+		c.recorder.Event(crt, corev1.EventTypeNormal, reasonRenewalWindowSuggested, message)
+	}
+
+	crt = crt.DeepCopy()
+	crt.Status.RenewalInfo = info
+	if _, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	key, err := cache.MetaNamespaceKeyFunc(crt)
+	if err != nil {
+		return err
+	}
+	c.scheduleCheck(logf.FromContext(ctx), key, checkIn)
+
+	return nil
+}
+
+// renewalInfoProviderFor returns the issuer implementation for the
+// referenced (Cluster)Issuer, if it is able to provide renewal information.
+func (c *controller) renewalInfoProviderFor(ref cmmeta.ObjectReference, namespace string) (issuer.RenewalInfoProvider, error) {
+	// External issuers cannot provide renewal information.
+	if ref.Group != "" && ref.Group != certmanager.GroupName {
+		return nil, issuer.ErrRenewalInfoNotSupported
+	}
+
+	genericIssuer, err := c.helper.GetGenericIssuer(ref, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	impl, err := c.issuerFactory.IssuerFor(genericIssuer)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, ok := impl.(issuer.RenewalInfoProvider)
+	if !ok {
+		return nil, issuer.ErrRenewalInfoNotSupported
+	}
+
+	return provider, nil
+}
+
+func (c *controller) scheduleCheck(log logr.Logger, key string, checkIn time.Duration) {
+	log.V(logf.DebugLevel).Info("scheduling renewal information check", "duration_until_check", checkIn.String())
+	c.scheduledWorkQueue.Add(key, checkIn)
+}
+
+// checkInterval returns the interval until the renewal information should be
+// checked again, given the interval requested by the issuer.
+func checkInterval(retryAfter time.Duration) time.Duration {
+	switch {
+	case retryAfter <= 0:
+		return defaultCheckInterval
+	case retryAfter < minCheckInterval:
+		return minCheckInterval
+	case retryAfter > maxCheckInterval:
+		return maxCheckInterval
+	default:
+		return retryAfter
+	}
+}
+
+// windowChanged returns true if the suggested renewal window differs between
+// old and new, or if old is for a different certificate.
+func windowChanged(old, new *cmapi.CertificateRenewalInfo) bool {
+	return old == nil || old.SerialNumber != new.SerialNumber ||
+		!old.SuggestedWindowStart.Equal(&new.SuggestedWindowStart) ||
+		!old.SuggestedWindowEnd.Equal(&new.SuggestedWindowEnd)
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		issuer.NewFactory(ctx),
+		ctx.Namespace,
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewalinfo
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	internaltest "github.com/jetstack/cert-manager/pkg/controller/certificates/internal/test"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	issuerpkg "github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/fake"
+	logtest "github.com/jetstack/cert-manager/pkg/logs/testing"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	fixedNow := metav1.NewTime(time.Now().Truncate(time.Second))
+	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+
+	acmeIssuer := gen.Issuer("acme-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerACME(cmacme.ACMEIssuer{}),
+	)
+	caIssuer := gen.Issuer("ca-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{}),
+	)
+
+	baseCrt := gen.Certificate("test-cert",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "acme-issuer"}),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+
+	pk := internaltest.MustCreatePEMPrivateKey(t)
+	mustCreateCert := func(notAfter time.Time) []byte {
+		return internaltest.MustCreateCertWithNotBeforeAfter(t, pk, baseCrt, fixedNow.Add(-time.Hour), notAfter)
+	}
+	mustSerial := func(certPEM []byte) string {
+		cert, err := pki.DecodeX509CertificateBytes(certPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fmt.Sprintf("%x", cert.SerialNumber)
+	}
+
+	currentCert := mustCreateCert(fixedNow.Add(time.Hour * 24 * 90))
+	expiredCert := mustCreateCert(fixedNow.Add(-time.Minute))
+	serial := mustSerial(currentCert)
+
+	secretWithCert := func(certPEM []byte) *corev1.Secret {
+		return gen.Secret("test-secret",
+			gen.SetSecretNamespace("testns"),
+			gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: certPEM}),
+		)
+	}
+
+	windowStart := fixedNow.Add(time.Hour * 24)
+	windowEnd := fixedNow.Add(time.Hour * 48)
+	renewalInfo := func(serial string, lastCheck time.Time, checkIn time.Duration) cmapi.CertificateRenewalInfo {
+		return cmapi.CertificateRenewalInfo{
+			SerialNumber:         serial,
+			SuggestedWindowStart: metav1.NewTime(windowStart),
+			SuggestedWindowEnd:   metav1.NewTime(windowEnd),
+			ExplanationURL:       "https://example.com/incident",
+			LastCheckTime:        metav1.NewTime(lastCheck),
+			NextCheckTime:        metav1.NewTime(lastCheck.Add(checkIn)),
+		}
+	}
+	windowEvent := fmt.Sprintf("Normal RenewalWindowSuggested Issuer suggests renewing certificate with serial number %s between %s and %s, see https://example.com/incident",
+		serial, windowStart.UTC().Format(time.RFC3339), windowEnd.UTC().Format(time.RFC3339))
+
+	tests := map[string]struct {
+		// Certificate to be synced for the test.
+		certificate *cmapi.Certificate
+
+		// objects that will exist in the apiserver before the test is run.
+		existingCMObjects   []runtime.Object
+		existingKubeObjects []runtime.Object
+
+		// retryAfter and renewalInfoErr are returned by the fake provider
+		retryAfter     time.Duration
+		renewalInfoErr error
+		// expectCall is true if the fake provider is expected to be called
+		expectCall bool
+
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+	}{
+		"do nothing if the Secret does not exist": {
+			certificate:       baseCrt,
+			existingCMObjects: []runtime.Object{acmeIssuer},
+		},
+		"do nothing if the certificate has expired": {
+			certificate:         baseCrt,
+			existingCMObjects:   []runtime.Object{acmeIssuer},
+			existingKubeObjects: []runtime.Object{secretWithCert(expiredCert)},
+		},
+		"do nothing if the issuer does not provide renewal information": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer"}),
+			),
+			existingCMObjects:   []runtime.Object{caIssuer},
+			existingKubeObjects: []runtime.Object{secretWithCert(currentCert)},
+		},
+		"do nothing if the certificate is issued by an external issuer": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "external", Group: "example.com"}),
+			),
+			existingKubeObjects: []runtime.Object{secretWithCert(currentCert)},
+		},
+		"do not check again before the next check time": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRenewalInfo(renewalInfo(serial, fixedNow.Add(-time.Hour), time.Hour*6)),
+			),
+			existingCMObjects:   []runtime.Object{acmeIssuer},
+			existingKubeObjects: []runtime.Object{secretWithCert(currentCert)},
+		},
+		"record the suggested window of the current certificate": {
+			certificate:         baseCrt,
+			existingCMObjects:   []runtime.Object{acmeIssuer},
+			existingKubeObjects: []runtime.Object{secretWithCert(currentCert)},
+			expectCall:          true,
+			expectedEvents:      []string{windowEvent},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.SetCertificateRenewalInfo(renewalInfo(serial, fixedNow.Time, defaultCheckInterval)),
+					),
+				)),
+			},
+		},
+		"check again if the recorded information is for a different certificate": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRenewalInfo(renewalInfo("1234", fixedNow.Add(-time.Hour), time.Hour*6)),
+			),
+			existingCMObjects:   []runtime.Object{acmeIssuer},
+			existingKubeObjects: []runtime.Object{secretWithCert(currentCert)},
+			retryAfter:          time.Hour,
+			expectCall:          true,
+			expectedEvents:      []string{windowEvent},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.SetCertificateRenewalInfo(renewalInfo(serial, fixedNow.Time, time.Hour)),
+					),
+				)),
+			},
+		},
+		"update the check times without an event if the window has not changed": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRenewalInfo(renewalInfo(serial, fixedNow.Add(-time.Hour*7), time.Hour*6)),
+			),
+			existingCMObjects:   []runtime.Object{acmeIssuer},
+			existingKubeObjects: []runtime.Object{secretWithCert(currentCert)},
+			retryAfter:          time.Hour * 72,
+			expectCall:          true,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.SetCertificateRenewalInfo(renewalInfo(serial, fixedNow.Time, maxCheckInterval)),
+					),
+				)),
+			},
+		},
+		"fire an event if the renewal information cannot be retrieved": {
+			certificate:         baseCrt,
+			existingCMObjects:   []runtime.Object{acmeIssuer},
+			existingKubeObjects: []runtime.Object{secretWithCert(currentCert)},
+			renewalInfoErr:      errors.New("connection refused"),
+			expectCall:          true,
+			expectedEvents: []string{
+				fmt.Sprintf("Warning RenewalInfoFailed Failed to retrieve renewal information for certificate with serial number %s: connection refused", serial),
+			},
+		},
+		"do nothing if the ACME server does not support renewal information": {
+			certificate:         baseCrt,
+			existingCMObjects:   []runtime.Object{acmeIssuer},
+			existingKubeObjects: []runtime.Object{secretWithCert(currentCert)},
+			renewalInfoErr:      issuerpkg.ErrRenewalInfoNotSupported,
+			expectCall:          true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedNow.Time)
+
+			// Create and initialise a new unit test builder
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: append([]runtime.Object{test.certificate}, test.existingCMObjects...),
+				KubeObjects:        test.existingKubeObjects,
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+
+			called := false
+			issuerFactory := &fake.Factory{
+				IssuerForFunc: func(iss cmapi.GenericIssuer) (issuerpkg.Interface, error) {
+					if iss.GetSpec().ACME == nil {
+						return &fake.Issuer{}, nil
+					}
+					return &fake.RenewalInfoProvider{
+						RenewalInfoFunc: func(_ context.Context, cert *x509.Certificate) (*issuerpkg.RenewalInfo, error) {
+							called = true
+							if got := fmt.Sprintf("%x", cert.SerialNumber); got != serial {
+								t.Errorf("unexpected certificate, exp serial=%s got=%s", serial, got)
+							}
+							if test.renewalInfoErr != nil {
+								return nil, test.renewalInfoErr
+							}
+							return &issuerpkg.RenewalInfo{
+								SuggestedWindowStart: windowStart,
+								SuggestedWindowEnd:   windowEnd,
+								ExplanationURL:       "https://example.com/incident",
+								RetryAfter:           test.retryAfter,
+							}, nil
+						},
+					}, nil
+				},
+			}
+
+			c, _, _ := NewController(logtest.TestLogger{T: t},
+				builder.CMClient,
+				builder.KubeSharedInformerFactory,
+				builder.SharedInformerFactory,
+				builder.Recorder,
+				builder.Clock,
+				issuerFactory,
+				"",
+			)
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = c.ProcessItem(context.Background(), key)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if called != test.expectCall {
+				t.Errorf("unexpected call to the renewal information provider, exp=%t got=%t", test.expectCall, called)
+			}
+
+			builder.CheckAndFinish(err)
+		})
+	}
+}
+
+func TestCheckInterval(t *testing.T) {
+	tests := map[time.Duration]time.Duration{
+		0:                defaultCheckInterval,
+		time.Second:      minCheckInterval,
+		time.Hour:        time.Hour,
+		time.Hour * 1000: maxCheckInterval,
+	}
+	for retryAfter, exp := range tests {
+		if got := checkInterval(retryAfter); got != exp {
+			t.Errorf("unexpected check interval for %s, exp=%s got=%s", retryAfter, exp, got)
+		}
+	}
+}
@@ -137,9 +137,24 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	limit := int(*crt.Spec.RevisionHistoryLimit)
 	toDelete := certificateRequestsToDelete(log, limit, requests)
 
+	// Requests whose certificates have yet to be revoked must be kept around
+	// until the revocation controller has processed them.
+	pendingRevocation := make(map[string]bool)
+	if crt.Spec.RevocationPolicy == cmapi.RevocationPolicyOnReissue {
+		for _, req := range requests {
+			pendingRevocation[req.Name] = certificates.RequestPendingRevocation(req)
+		}
+	}
+
 	for _, req := range toDelete {
-		logf.WithRelatedResourceName(log, req.Name, req.Namespace, cmapi.CertificateRequestKind).
-			WithValues("revision", req.rev).Info("garbage collecting old certificate request revsion")
+		log := logf.WithRelatedResourceName(log, req.Name, req.Namespace, cmapi.CertificateRequestKind).
+			WithValues("revision", req.rev)
+		if pendingRevocation[req.Name] {
+			log.V(logf.DebugLevel).Info("not garbage collecting old certificate request revision as it is pending revocation")
+			continue
+		}
+
+		log.Info("garbage collecting old certificate request revsion")
 		err = c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{})
 		if apierrors.IsNotFound(err) {
 			continue
@@ -0,0 +1,516 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocation
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificates"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/pkg/util/predicate"
+)
+
+const (
+	// ControllerName is the name of the certificate revocation controller.
+	ControllerName = "certificates-revocation"
+
+	// Finalizer is added to Certificates with a revocationPolicy of OnDelete
+	// or OnReissue, so that the current certificate can be revoked before the
+	// Certificate resource is removed.
+	Finalizer = "finalizer.cert-manager.io/revocation"
+
+	reasonRevoked                 = "Revoked"
+	reasonRevocationFailed        = "RevocationFailed"
+	reasonRevocationNotSupported  = "RevocationNotSupported"
+	reasonRevocationRejected      = "RevocationRejected"
+	reasonInvalidRevocationReason = "InvalidRevocationReason"
+	reasonExpired                 = "Expired"
+)
+
+type controller struct {
+	certificateLister        cmlisters.CertificateLister
+	certificateRequestLister cmlisters.CertificateRequestLister
+	secretLister             corelisters.SecretLister
+	client                   cmclient.Interface
+	recorder                 record.EventRecorder
+	clock                    clock.Clock
+
+	// helper is used to read the (Cluster)Issuer that issued a certificate
+	helper issuer.Helper
+	// issuerFactory is used to obtain an issuer implementation that is able
+	// to revoke certificates
+	issuerFactory issuer.Factory
+}
+
+// NewController returns a new certificate revocation controller.
+// If namespace is not empty, ClusterIssuers will not be watched and
+// certificates issued by a ClusterIssuer cannot be revoked.
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	issuerFactory issuer.Factory,
+	namespace string,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	secretsInformer := factory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Trigger reconciles on changes to any 'owned' CertificateRequest resources
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ResourceOwnerOf,
+		),
+	})
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Trigger reconciles on changes to the Secret named `spec.secretName`
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateRequestInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	// if we are running in non-namespaced mode (i.e. --namespace=""), we also
+	// obtain a lister for clusterissuers.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return &controller{
+		certificateLister:        certificateInformer.Lister(),
+		certificateRequestLister: certificateRequestInformer.Lister(),
+		secretLister:             secretsInformer.Lister(),
+		client:                   client,
+		recorder:                 recorder,
+		clock:                    clock,
+		helper:                   issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+		issuerFactory:            issuerFactory,
+	}, queue, mustSync
+}
+
+// ProcessItem will revoke certificates issued for a Certificate according to
+// its `spec.revocationPolicy` and the `cert-manager.io/revoke` annotation.
+// It manages the revocation finalizer so that the current certificate can be
+// revoked when a Certificate with a policy of OnDelete or OnReissue is
+// deleted.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Error(err, "certificate not found for key")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log = logf.WithResource(log, crt)
+	ctx = logf.NewContext(ctx, log)
+
+	if crt.DeletionTimestamp != nil {
+		if !hasFinalizer(crt) {
+			return nil
+		}
+		return c.finalize(ctx, crt)
+	}
+
+	wantsFinalizer := crt.Spec.RevocationPolicy == cmapi.RevocationPolicyOnDelete ||
+		crt.Spec.RevocationPolicy == cmapi.RevocationPolicyOnReissue
+	if wantsFinalizer != hasFinalizer(crt) {
+		crt = crt.DeepCopy()
+		if wantsFinalizer {
+			log.V(logf.DebugLevel).Info("adding revocation finalizer")
+			crt.Finalizers = append(crt.Finalizers, Finalizer)
+		} else {
+			log.V(logf.DebugLevel).Info("removing revocation finalizer")
+			crt.Finalizers = removeFinalizer(crt.Finalizers)
+		}
+		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+		return err
+	}
+
+	if _, ok := crt.Annotations[cmapi.RevokeCertificateAnnotation]; ok {
+		return c.revokeRequested(ctx, crt)
+	}
+
+	if crt.Spec.RevocationPolicy == cmapi.RevocationPolicyOnReissue {
+		return c.revokeSuperseded(ctx, crt)
+	}
+
+	return nil
+}
+
+// finalize revokes the certificate currently stored in `spec.secretName` and
+// then removes the revocation finalizer from the Certificate. The finalizer
+// is only kept in place if revoking failed with an error that may be
+// resolved by retrying.
+func (c *controller) finalize(ctx context.Context, crt *cmapi.Certificate) error {
+	secret, cert, err := certificates.CurrentCertificate(ctx, c.secretLister, crt)
+	if err != nil {
+		return err
+	}
+
+	if cert != nil {
+		err := c.revoke(ctx, crt, certificates.IssuerRefForSecret(crt, secret), cert, pki.RevocationReasonCessationOfOperation)
+		if err != nil && !isPermanent(err) {
+			return err
+		}
+	}
+
+	crt = crt.DeepCopy()
+	crt.Finalizers = removeFinalizer(crt.Finalizers)
+	_, err = c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+	return err
+}
+
+// revokeRequested revokes the certificate currently stored in
+// `spec.secretName` using the reason given in the `cert-manager.io/revoke`
+// annotation, and removes the annotation once the request has been processed.
+func (c *controller) revokeRequested(ctx context.Context, crt *cmapi.Certificate) error {
+	reason, err := pki.ParseRevocationReason(crt.Annotations[cmapi.RevokeCertificateAnnotation])
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonInvalidRevocationReason, "Ignoring revocation request: %v", err)
+		return c.removeRevokeAnnotation(ctx, crt)
+	}
+
+	secret, cert, err := certificates.CurrentCertificate(ctx, c.secretLister, crt)
+	if err != nil {
+		return err
+	}
+	if cert == nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationFailed,
+			"Ignoring revocation request as Secret %q does not contain a certificate", crt.Spec.SecretName)
+		return c.removeRevokeAnnotation(ctx, crt)
+	}
+
+	if err := c.revoke(ctx, crt, certificates.IssuerRefForSecret(crt, secret), cert, reason); err != nil {
+		if isPermanent(err) {
+			return c.removeRevokeAnnotation(ctx, crt)
+		}
+		return err
+	}
+
+	crt, err = c.recordRevocation(ctx, crt, cert, reason)
+	if err != nil {
+		return err
+	}
+
+	// Mark the requests that the certificate was issued for as revoked so
+	// that it is not revoked again once it has been superseded.
+	requests, err := c.listRequests(crt)
+	if err != nil {
+		return err
+	}
+	for _, req := range requests {
+		if !certificates.RequestPendingRevocation(req) {
+			continue
+		}
+		reqCert, err := pki.DecodeX509CertificateBytes(req.Status.Certificate)
+		if err != nil || reqCert.SerialNumber.Cmp(cert.SerialNumber) != 0 {
+			continue
+		}
+		if err := c.setRequestRevokedCondition(ctx, req, cmmeta.ConditionTrue, reasonRevoked,
+			fmt.Sprintf("Certificate was revoked on request with reason %s", reason)); err != nil {
+			return err
+		}
+	}
+
+	return c.removeRevokeAnnotation(ctx, crt)
+}
+
+// revokeSuperseded revokes the certificates issued for any of the
+// Certificate's CertificateRequests with a revision lower than the current
+// revision.
+func (c *controller) revokeSuperseded(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+
+	if crt.Status.Revision == nil {
+		return nil
+	}
+
+	_, current, err := certificates.CurrentCertificate(ctx, c.secretLister, crt)
+	if err != nil {
+		return err
+	}
+	// Nothing has been superseded until a certificate has been issued.
+	if current == nil {
+		return nil
+	}
+
+	requests, err := c.listRequests(crt)
+	if err != nil {
+		return err
+	}
+
+	for _, req := range requests {
+		if !certificates.RequestPendingRevocation(req) {
+			continue
+		}
+
+		log := logf.WithRelatedResource(log, req)
+
+		revision, err := strconv.Atoi(req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey])
+		if err != nil || revision >= *crt.Status.Revision {
+			continue
+		}
+
+		cert, err := pki.DecodeX509CertificateBytes(req.Status.Certificate)
+		if err != nil {
+			log.Error(err, "failed to decode certificate of superseded request")
+			continue
+		}
+		if cert.SerialNumber.Cmp(current.SerialNumber) == 0 {
+			continue
+		}
+
+		if c.clock.Now().After(cert.NotAfter) {
+			if err := c.setRequestRevokedCondition(ctx, req, cmmeta.ConditionFalse, reasonExpired,
+				"Certificate has expired and does not need to be revoked"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = c.revoke(ctx, crt, req.Spec.IssuerRef, cert, pki.RevocationReasonSuperseded)
+		switch {
+		case err == nil:
+			crt, err = c.recordRevocation(ctx, crt, cert, pki.RevocationReasonSuperseded)
+			if err != nil {
+				return err
+			}
+			err = c.setRequestRevokedCondition(ctx, req, cmmeta.ConditionTrue, reasonRevoked,
+				"Certificate was revoked as it has been superseded")
+		case errors.Is(err, issuer.ErrRevocationNotSupported):
+			err = c.setRequestRevokedCondition(ctx, req, cmmeta.ConditionFalse, reasonRevocationNotSupported, err.Error())
+		case isPermanent(err):
+			err = c.setRequestRevokedCondition(ctx, req, cmmeta.ConditionFalse, reasonRevocationRejected, err.Error())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revoke asks the issuer referenced by issuerRef to revoke the given
+// certificate, recording the outcome as an event on the Certificate.
+func (c *controller) revoke(ctx context.Context, crt *cmapi.Certificate, issuerRef cmmeta.ObjectReference, cert *x509.Certificate, reason pki.RevocationReason) error {
+	serial := serialNumber(cert)
+
+	revoker, err := c.revokerFor(issuerRef, crt.Namespace)
+	if errors.Is(err, issuer.ErrRevocationNotSupported) {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationNotSupported,
+			"Cannot revoke certificate with serial number %s: %v", serial, err)
+		return err
+	}
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationFailed,
+			"Failed to revoke certificate with serial number %s: %v", serial, err)
+		return err
+	}
+
+	if err := revoker.Revoke(ctx, cert.Raw, reason); err != nil {
+		var rejectedErr *issuer.RevocationRejectedError
+		if errors.As(err, &rejectedErr) {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationRejected,
+				"Issuer rejected the revocation of certificate with serial number %s: %v", serial, err)
+			return err
+		}
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRevocationFailed,
+			"Failed to revoke certificate with serial number %s: %v", serial, err)
+		return err
+	}
+
+	c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRevoked,
+		"Revoked certificate with serial number %s with reason %s", serial, reason)
+
+	return nil
+}
+
+// revokerFor returns the issuer implementation for the referenced
+// (Cluster)Issuer, if it is able to revoke certificates.
+func (c *controller) revokerFor(ref cmmeta.ObjectReference, namespace string) (issuer.Revoker, error) {
+	// Certificates issued by external issuers cannot be revoked.
+	if ref.Group != "" && ref.Group != certmanager.GroupName {
+		return nil, issuer.ErrRevocationNotSupported
+	}
+
+	genericIssuer, err := c.helper.GetGenericIssuer(ref, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	impl, err := c.issuerFactory.IssuerFor(genericIssuer)
+	if err != nil {
+		return nil, err
+	}
+
+	revoker, ok := impl.(issuer.Revoker)
+	if !ok {
+		return nil, issuer.ErrRevocationNotSupported
+	}
+
+	return revoker, nil
+}
+
+func (c *controller) listRequests(crt *cmapi.Certificate) ([]*cmapi.CertificateRequest, error) {
+	return certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace),
+		labels.Everything(), predicate.ResourceOwnedBy(crt))
+}
+
+// recordRevocation records the revocation of the given certificate in the
+// Certificate's status, and returns the updated Certificate.
+func (c *controller) recordRevocation(ctx context.Context, crt *cmapi.Certificate, cert *x509.Certificate, reason pki.RevocationReason) (*cmapi.Certificate, error) {
+	crt = crt.DeepCopy()
+	now := metav1.NewTime(c.clock.Now())
+	crt.Status.LastRevocation = &cmapi.CertificateRevocation{
+		SerialNumber:   serialNumber(cert),
+		Reason:         reason.String(),
+		RevocationTime: &now,
+	}
+	return c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+}
+
+func (c *controller) removeRevokeAnnotation(ctx context.Context, crt *cmapi.Certificate) error {
+	crt = crt.DeepCopy()
+	delete(crt.Annotations, cmapi.RevokeCertificateAnnotation)
+	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *controller) setRequestRevokedCondition(ctx context.Context, req *cmapi.CertificateRequest, status cmmeta.ConditionStatus, reason, message string) error {
+	req = req.DeepCopy()
+	apiutil.SetCertificateRequestCondition(req, cmapi.CertificateRequestConditionRevoked, status, reason, message)
+	_, err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).UpdateStatus(ctx, req, metav1.UpdateOptions{})
+	return err
+}
+
+// isPermanent returns true if retrying a failed revocation will not succeed.
+func isPermanent(err error) bool {
+	var rejectedErr *issuer.RevocationRejectedError
+	return errors.Is(err, issuer.ErrRevocationNotSupported) || errors.As(err, &rejectedErr) || apierrors.IsNotFound(err)
+}
+
+func serialNumber(cert *x509.Certificate) string {
+	return fmt.Sprintf("%x", cert.SerialNumber)
+}
+
+func hasFinalizer(crt *cmapi.Certificate) bool {
+	for _, f := range crt.Finalizers {
+		if f == Finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func removeFinalizer(finalizers []string) []string {
+	var out []string
+	for _, f := range finalizers {
+		if f != Finalizer {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+		ctx.Clock,
+		issuer.NewFactory(ctx),
+		ctx.Namespace,
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,488 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	internaltest "github.com/jetstack/cert-manager/pkg/controller/certificates/internal/test"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	issuerpkg "github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/fake"
+	logtest "github.com/jetstack/cert-manager/pkg/logs/testing"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	fixedNow := metav1.NewTime(time.Now().Truncate(time.Second))
+	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
+
+	acmeIssuer := gen.Issuer("acme-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerACME(cmacme.ACMEIssuer{}),
+	)
+	caIssuer := gen.Issuer("ca-issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{}),
+	)
+
+	baseCrt := gen.Certificate("test-cert",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateUID("uid-1"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "acme-issuer"}),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+
+	pk := internaltest.MustCreatePEMPrivateKey(t)
+	mustCreateCert := func(notAfter time.Time) []byte {
+		return internaltest.MustCreateCertWithNotBeforeAfter(t, pk, baseCrt, fixedNow.Add(-time.Hour), notAfter)
+	}
+	mustSerial := func(certPEM []byte) string {
+		cert, err := pki.DecodeX509CertificateBytes(certPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fmt.Sprintf("%x", cert.SerialNumber)
+	}
+
+	currentCert := mustCreateCert(fixedNow.Add(time.Hour * 24))
+	oldCert := mustCreateCert(fixedNow.Add(time.Hour * 12))
+	expiredCert := mustCreateCert(fixedNow.Add(-time.Minute))
+
+	secret := gen.Secret("test-secret",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: currentCert}),
+	)
+
+	readyCondition := cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue}
+	baseCR := gen.CertificateRequest("test-cert-1",
+		gen.SetCertificateRequestNamespace("testns"),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "acme-issuer"}),
+		gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(
+			baseCrt, cmapi.SchemeGroupVersion.WithKind("Certificate")),
+		),
+		gen.SetCertificateRequestRevision("1"),
+		gen.SetCertificateRequestCertificate(oldCert),
+		gen.SetCertificateRequestStatusCondition(readyCondition),
+	)
+	currentCR := gen.CertificateRequestFrom(baseCR,
+		gen.SetCertificateRequestName("test-cert-2"),
+		gen.SetCertificateRequestRevision("2"),
+		gen.SetCertificateRequestCertificate(currentCert),
+	)
+
+	revokedCondition := func(status cmmeta.ConditionStatus, reason, message string) cmapi.CertificateRequestCondition {
+		return cmapi.CertificateRequestCondition{
+			Type:               cmapi.CertificateRequestConditionRevoked,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: &fixedNow,
+		}
+	}
+	lastRevocation := func(certPEM []byte, reason string) cmapi.CertificateRevocation {
+		return cmapi.CertificateRevocation{
+			SerialNumber:   mustSerial(certPEM),
+			Reason:         reason,
+			RevocationTime: &fixedNow,
+		}
+	}
+
+	tests := map[string]struct {
+		// Certificate to be synced for the test.
+		certificate *cmapi.Certificate
+
+		// objects that will exist in the apiserver before the test is run.
+		existingCMObjects   []runtime.Object
+		existingKubeObjects []runtime.Object
+
+		// revokeErr is returned by the fake revoker
+		revokeErr error
+		// expectedRevocations is the list of revocation reasons that the fake
+		// revoker is expected to have been called with
+		expectedRevocations []pki.RevocationReason
+
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+
+		// err is the expected error text returned by the controller, if any.
+		err string
+	}{
+		"do nothing if revocationPolicy is not set": {
+			certificate:         baseCrt,
+			existingCMObjects:   []runtime.Object{acmeIssuer, baseCR, currentCR},
+			existingKubeObjects: []runtime.Object{secret},
+		},
+		"add the finalizer if revocationPolicy is OnDelete": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnDelete),
+			),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnDelete),
+						gen.SetCertificateFinalizers(Finalizer),
+					),
+				)),
+			},
+		},
+		"remove the finalizer if revocationPolicy is Never": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyNever),
+				gen.SetCertificateFinalizers("other", Finalizer),
+			),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyNever),
+						gen.SetCertificateFinalizers("other"),
+					),
+				)),
+			},
+		},
+		"revoke the current certificate and remove the finalizer when the Certificate is deleted": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnDelete),
+				gen.SetCertificateFinalizers(Finalizer),
+				gen.SetCertificateDeletionTimestamp(fixedNow),
+			),
+			existingCMObjects:   []runtime.Object{acmeIssuer},
+			existingKubeObjects: []runtime.Object{secret},
+			expectedRevocations: []pki.RevocationReason{pki.RevocationReasonCessationOfOperation},
+			expectedEvents: []string{
+				fmt.Sprintf("Normal Revoked Revoked certificate with serial number %s with reason cessationOfOperation", mustSerial(currentCert)),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnDelete),
+						gen.SetCertificateDeletionTimestamp(fixedNow),
+					),
+				)),
+			},
+		},
+		"keep the finalizer if revoking the certificate failed when the Certificate is deleted": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnDelete),
+				gen.SetCertificateFinalizers(Finalizer),
+				gen.SetCertificateDeletionTimestamp(fixedNow),
+			),
+			existingCMObjects:   []runtime.Object{acmeIssuer},
+			existingKubeObjects: []runtime.Object{secret},
+			revokeErr:           errors.New("connection refused"),
+			expectedRevocations: []pki.RevocationReason{pki.RevocationReasonCessationOfOperation},
+			expectedEvents: []string{
+				fmt.Sprintf("Warning RevocationFailed Failed to revoke certificate with serial number %s: connection refused", mustSerial(currentCert)),
+			},
+			err: "connection refused",
+		},
+		"remove the finalizer if the issuer rejects the revocation when the Certificate is deleted": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnDelete),
+				gen.SetCertificateFinalizers(Finalizer),
+				gen.SetCertificateDeletionTimestamp(fixedNow),
+			),
+			existingCMObjects:   []runtime.Object{acmeIssuer},
+			existingKubeObjects: []runtime.Object{secret},
+			revokeErr:           &issuerpkg.RevocationRejectedError{Err: errors.New("unauthorized")},
+			expectedRevocations: []pki.RevocationReason{pki.RevocationReasonCessationOfOperation},
+			expectedEvents: []string{
+				fmt.Sprintf("Warning RevocationRejected Issuer rejected the revocation of certificate with serial number %s: unauthorized", mustSerial(currentCert)),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnDelete),
+						gen.SetCertificateDeletionTimestamp(fixedNow),
+					),
+				)),
+			},
+		},
+		"remove the finalizer without revoking if the Secret does not exist when the Certificate is deleted": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnDelete),
+				gen.SetCertificateFinalizers(Finalizer),
+				gen.SetCertificateDeletionTimestamp(fixedNow),
+			),
+			existingCMObjects: []runtime.Object{acmeIssuer},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnDelete),
+						gen.SetCertificateDeletionTimestamp(fixedNow),
+					),
+				)),
+			},
+		},
+		"revoke the current certificate when the revoke annotation is set": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.AddCertificateAnnotations(map[string]string{cmapi.RevokeCertificateAnnotation: "keyCompromise"}),
+			),
+			existingCMObjects:   []runtime.Object{acmeIssuer, baseCR, currentCR},
+			existingKubeObjects: []runtime.Object{secret},
+			expectedRevocations: []pki.RevocationReason{pki.RevocationReasonKeyCompromise},
+			expectedEvents: []string{
+				fmt.Sprintf("Normal Revoked Revoked certificate with serial number %s with reason keyCompromise", mustSerial(currentCert)),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.AddCertificateAnnotations(map[string]string{cmapi.RevokeCertificateAnnotation: "keyCompromise"}),
+						gen.SetCertificateLastRevocation(lastRevocation(currentCert, "keyCompromise")),
+					),
+				)),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "status", "testns",
+					gen.CertificateRequestFrom(currentCR,
+						gen.AddCertificateRequestStatusCondition(revokedCondition(cmmeta.ConditionTrue, "Revoked", "Certificate was revoked on request with reason keyCompromise")),
+					),
+				)),
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.AddCertificateAnnotations(map[string]string{}),
+						gen.SetCertificateLastRevocation(lastRevocation(currentCert, "keyCompromise")),
+					),
+				)),
+			},
+		},
+		"remove an invalid revoke annotation without revoking": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.AddCertificateAnnotations(map[string]string{cmapi.RevokeCertificateAnnotation: "lostIt"}),
+			),
+			existingCMObjects:   []runtime.Object{acmeIssuer},
+			existingKubeObjects: []runtime.Object{secret},
+			expectedEvents: []string{
+				`Warning InvalidRevocationReason Ignoring revocation request: unknown revocation reason "lostIt"`,
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.AddCertificateAnnotations(map[string]string{}),
+					),
+				)),
+			},
+		},
+		"remove the revoke annotation if the issuer does not support revocation": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer"}),
+				gen.AddCertificateAnnotations(map[string]string{cmapi.RevokeCertificateAnnotation: ""}),
+			),
+			existingCMObjects:   []runtime.Object{caIssuer},
+			existingKubeObjects: []runtime.Object{secret},
+			expectedEvents: []string{
+				fmt.Sprintf("Warning RevocationNotSupported Cannot revoke certificate with serial number %s: issuer does not support certificate revocation", mustSerial(currentCert)),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer"}),
+						gen.AddCertificateAnnotations(map[string]string{}),
+					),
+				)),
+			},
+		},
+		"revoke superseded certificates if revocationPolicy is OnReissue": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnReissue),
+				gen.SetCertificateFinalizers(Finalizer),
+				gen.SetCertificateRevision(2),
+			),
+			existingCMObjects:   []runtime.Object{acmeIssuer, baseCR, currentCR},
+			existingKubeObjects: []runtime.Object{secret},
+			expectedRevocations: []pki.RevocationReason{pki.RevocationReasonSuperseded},
+			expectedEvents: []string{
+				fmt.Sprintf("Normal Revoked Revoked certificate with serial number %s with reason superseded", mustSerial(oldCert)),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(baseCrt,
+						gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnReissue),
+						gen.SetCertificateFinalizers(Finalizer),
+						gen.SetCertificateRevision(2),
+						gen.SetCertificateLastRevocation(lastRevocation(oldCert, "superseded")),
+					),
+				)),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "status", "testns",
+					gen.CertificateRequestFrom(baseCR,
+						gen.AddCertificateRequestStatusCondition(revokedCondition(cmmeta.ConditionTrue, "Revoked", "Certificate was revoked as it has been superseded")),
+					),
+				)),
+			},
+		},
+		"do not revoke superseded certificates that have already been revoked": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnReissue),
+				gen.SetCertificateFinalizers(Finalizer),
+				gen.SetCertificateRevision(2),
+			),
+			existingCMObjects: []runtime.Object{acmeIssuer, currentCR,
+				gen.CertificateRequestFrom(baseCR,
+					gen.AddCertificateRequestStatusCondition(revokedCondition(cmmeta.ConditionTrue, "Revoked", "Certificate was revoked as it has been superseded")),
+				),
+			},
+			existingKubeObjects: []runtime.Object{secret},
+		},
+		"mark expired superseded certificates without revoking them": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnReissue),
+				gen.SetCertificateFinalizers(Finalizer),
+				gen.SetCertificateRevision(2),
+			),
+			existingCMObjects: []runtime.Object{acmeIssuer, currentCR,
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestCertificate(expiredCert),
+				),
+			},
+			existingKubeObjects: []runtime.Object{secret},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "status", "testns",
+					gen.CertificateRequestFrom(baseCR,
+						gen.SetCertificateRequestCertificate(expiredCert),
+						gen.AddCertificateRequestStatusCondition(revokedCondition(cmmeta.ConditionFalse, "Expired", "Certificate has expired and does not need to be revoked")),
+					),
+				)),
+			},
+		},
+		"mark superseded certificates as not revoked if the issuer does not support revocation": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnReissue),
+				gen.SetCertificateFinalizers(Finalizer),
+				gen.SetCertificateRevision(2),
+			),
+			existingCMObjects: []runtime.Object{acmeIssuer, caIssuer, currentCR,
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca-issuer"}),
+				),
+			},
+			existingKubeObjects: []runtime.Object{secret},
+			expectedEvents: []string{
+				fmt.Sprintf("Warning RevocationNotSupported Cannot revoke certificate with serial number %s: issuer does not support certificate revocation", mustSerial(oldCert)),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "status", "testns",
+					gen.CertificateRequestFrom(baseCR,
+						gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca-issuer"}),
+						gen.AddCertificateRequestStatusCondition(revokedCondition(cmmeta.ConditionFalse, "RevocationNotSupported", "issuer does not support certificate revocation")),
+					),
+				)),
+			},
+		},
+		"return an error if revoking a superseded certificate fails": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateRevocationPolicy(cmapi.RevocationPolicyOnReissue),
+				gen.SetCertificateFinalizers(Finalizer),
+				gen.SetCertificateRevision(2),
+			),
+			existingCMObjects:   []runtime.Object{acmeIssuer, baseCR, currentCR},
+			existingKubeObjects: []runtime.Object{secret},
+			revokeErr:           errors.New("connection refused"),
+			expectedRevocations: []pki.RevocationReason{pki.RevocationReasonSuperseded},
+			expectedEvents: []string{
+				fmt.Sprintf("Warning RevocationFailed Failed to revoke certificate with serial number %s: connection refused", mustSerial(oldCert)),
+			},
+			err: "connection refused",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedNow.Time)
+
+			// Create and initialise a new unit test builder
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: append([]runtime.Object{test.certificate}, test.existingCMObjects...),
+				KubeObjects:        test.existingKubeObjects,
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+
+			var revocations []pki.RevocationReason
+			issuerFactory := &fake.Factory{
+				IssuerForFunc: func(iss cmapi.GenericIssuer) (issuerpkg.Interface, error) {
+					if iss.GetSpec().ACME == nil {
+						return &fake.Issuer{}, nil
+					}
+					return &fake.Revoker{
+						RevokeFunc: func(_ context.Context, _ []byte, reason pki.RevocationReason) error {
+							revocations = append(revocations, reason)
+							return test.revokeErr
+						},
+					}, nil
+				},
+			}
+
+			c, _, _ := NewController(logtest.TestLogger{T: t},
+				builder.CMClient,
+				builder.KubeSharedInformerFactory,
+				builder.SharedInformerFactory,
+				builder.Recorder,
+				builder.Clock,
+				issuerFactory,
+				"",
+			)
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = c.ProcessItem(context.Background(), key)
+			switch {
+			case err != nil:
+				if test.err != err.Error() {
+					t.Errorf("error text did not match, got=%s, exp=%s", err.Error(), test.err)
+				}
+			default:
+				if test.err != "" {
+					t.Errorf("got no error but expected: %s", test.err)
+				}
+			}
+
+			if len(revocations) != len(test.expectedRevocations) {
+				t.Errorf("unexpected revocations, exp=%v got=%v", test.expectedRevocations, revocations)
+			} else {
+				for i := range revocations {
+					if revocations[i] != test.expectedRevocations[i] {
+						t.Errorf("unexpected revocations, exp=%v got=%v", test.expectedRevocations, revocations)
+					}
+				}
+			}
+
+			builder.CheckAndFinish(err)
+		})
+	}
+}
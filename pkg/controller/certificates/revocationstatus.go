@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	// RevocationStatusTimeout is the time allowed for an OCSP responder or
+	// CRL distribution point to answer a request.
+	RevocationStatusTimeout = 10 * time.Second
+
+	// maxRevocationStatusSize is the maximum size of an OCSP response or CRL
+	// that will be read.
+	maxRevocationStatusSize = 10 * 1024 * 1024
+)
+
+// FetchOCSPResponse requests the OCSP status of cert from the given
+// responder, returning the raw response once it has been verified to be
+// signed by issuerCert.
+func FetchOCSPResponse(ctx context.Context, client *http.Client, responder string, cert, issuerCert *x509.Certificate) ([]byte, *ocsp.Response, error) {
+	ocspReq, err := ocsp.CreateRequest(cert, issuerCert, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create OCSP request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, RevocationStatusTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, responder, bytes.NewReader(ocspReq))
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	httpReq.Header.Set("Accept", "application/ocsp-response")
+
+	raw, err := doRevocationStatusRequest(client, httpReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := ocsp.ParseResponseForCert(raw, cert, issuerCert)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid OCSP response: %w", err)
+	}
+
+	return raw, resp, nil
+}
+
+// FetchCRL downloads the CRL published at the given distribution point,
+// returning it once it has been verified to be signed by issuerCert.
+// Both DER and PEM encoded CRLs are accepted.
+func FetchCRL(ctx context.Context, client *http.Client, distributionPoint string, issuerCert *x509.Certificate) (*pkix.CertificateList, error) {
+	ctx, cancel := context.WithTimeout(ctx, RevocationStatusTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, distributionPoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := doRevocationStatusRequest(client, httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	crl, err := x509.ParseCRL(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CRL: %w", err)
+	}
+	if err := issuerCert.CheckCRLSignature(crl); err != nil {
+		return nil, fmt.Errorf("invalid CRL: %w", err)
+	}
+
+	return crl, nil
+}
+
+func doRevocationStatusRequest(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %q", resp.Status)
+	}
+
+	return ioutil.ReadAll(io.LimitReader(resp.Body, maxRevocationStatusSize))
+}
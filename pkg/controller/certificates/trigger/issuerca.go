@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificates/trigger/policies"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+)
+
+// registerIssuerCARotation configures the controller to re-issue
+// Certificates whose issuer's CA has changed, and to re-check Certificates
+// whenever the Issuer or ClusterIssuer they reference, or one of the CA
+// Secrets of that issuer, changes. It returns the InformerSynced functions of the
+// additional informers used.
+func (c *controller) registerIssuerCARotation(log logr.Logger, ctx *controllerpkg.Context, queue workqueue.Interface, rotation *policies.IssuerCARotation) []cache.InformerSynced {
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	mustSync := []cache.InformerSynced{issuerInformer.Informer().HasSynced}
+
+	h := &issuerCAHandler{
+		log:                      log,
+		queue:                    queue,
+		certificateLister:        c.certificateLister,
+		issuerLister:             issuerInformer.Lister(),
+		clusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
+	}
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: h.handleGenericIssuer})
+
+	// ClusterIssuers are only available if we are not scoped to a single
+	// namespace.
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		h.clusterIssuerLister = clusterIssuerInformer.Lister()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: h.handleGenericIssuer})
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	ctx.KubeSharedInformerFactory.Core().V1().Secrets().Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: h.handleSecret})
+
+	c.issuerCARotation = rotation
+	c.dataForCertificate = (&policies.Gatherer{
+		CertificateRequestLister: c.certificateRequestLister,
+		SecretLister:             c.secretLister,
+		IssuerCAGetter: &policies.IssuerCAGetter{
+			IssuerHelper:  issuer.NewHelper(h.issuerLister, h.clusterIssuerLister),
+			SecretLister:  c.secretLister,
+			IssuerOptions: ctx.IssuerOptions,
+		},
+	}).DataForCertificate
+
+	return mustSync
+}
+
+// issuerCAHandler enqueues the Certificates affected by changes to the CA of
+// CA issuers.
+type issuerCAHandler struct {
+	log                      logr.Logger
+	queue                    workqueue.Interface
+	certificateLister        cmlisters.CertificateLister
+	issuerLister             cmlisters.IssuerLister
+	clusterIssuerLister      cmlisters.ClusterIssuerLister
+	clusterResourceNamespace string
+}
+
+// handleGenericIssuer enqueues all Certificates that reference the given
+// Issuer or ClusterIssuer.
+func (h *issuerCAHandler) handleGenericIssuer(obj interface{}) {
+	iss, ok := obj.(cmapi.GenericIssuer)
+	if !ok {
+		h.log.Error(nil, "object does not implement GenericIssuer")
+		return
+	}
+	if iss.GetSpec().CA == nil {
+		return
+	}
+	h.enqueueCertificatesForIssuer(iss)
+}
+
+// handleSecret enqueues all Certificates that reference a CA issuer which
+// uses the given Secret as its CA.
+func (h *issuerCAHandler) handleSecret(obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		h.log.Error(nil, "object is not a Secret")
+		return
+	}
+
+	var issuers []cmapi.GenericIssuer
+	namespaced, err := h.issuerLister.Issuers(secret.Namespace).List(labels.Everything())
+	if err != nil {
+		h.log.Error(err, "failed listing Issuer resources")
+		return
+	}
+	for _, iss := range namespaced {
+		issuers = append(issuers, iss)
+	}
+	if h.clusterIssuerLister != nil && secret.Namespace == h.clusterResourceNamespace {
+		clusterIssuers, err := h.clusterIssuerLister.List(labels.Everything())
+		if err != nil {
+			h.log.Error(err, "failed listing ClusterIssuer resources")
+			return
+		}
+		for _, iss := range clusterIssuers {
+			issuers = append(issuers, iss)
+		}
+	}
+
+	for _, iss := range issuers {
+		if ca := iss.GetSpec().CA; ca != nil && kube.CAIssuerUsesSecret(ca, secret.Name) {
+			h.enqueueCertificatesForIssuer(iss)
+		}
+	}
+}
+
+func (h *issuerCAHandler) enqueueCertificatesForIssuer(iss cmapi.GenericIssuer) {
+	_, isClusterIssuer := iss.(*cmapi.ClusterIssuer)
+
+	var certs []*cmapi.Certificate
+	var err error
+	if isClusterIssuer {
+		certs, err = h.certificateLister.List(labels.Everything())
+	} else {
+		certs, err = h.certificateLister.Certificates(iss.GetObjectMeta().Namespace).List(labels.Everything())
+	}
+	if err != nil {
+		h.log.Error(err, "failed listing Certificate resources")
+		return
+	}
+
+	for _, crt := range certs {
+		ref := crt.Spec.IssuerRef
+		if ref.Name != iss.GetObjectMeta().Name || (ref.Group != "" && ref.Group != cmapi.SchemeGroupVersion.Group) {
+			continue
+		}
+		if isClusterIssuer != (ref.Kind == cmapi.ClusterIssuerKind) {
+			continue
+		}
+
+		key, err := controllerpkg.KeyFunc(crt)
+		if err != nil {
+			h.log.Error(err, "error determining 'key' for resource")
+			continue
+		}
+		h.queue.Add(key)
+	}
+}
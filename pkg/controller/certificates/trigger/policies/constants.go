@@ -41,4 +41,11 @@ const (
 	// Expired is a policy violation reason for a scenario where Certificate has
 	// expired.
 	Expired string = "Expired"
+	// Revoked is a policy violation reason for a scenario where Certificate's
+	// current certificate has been revoked by its issuer.
+	Revoked string = "Revoked"
+	// IssuerCAChanged is a policy violation reason for a scenario where
+	// the CA of the Certificate's issuer has changed since the current
+	// certificate was issued.
+	IssuerCAChanged string = "IssuerCAChanged"
 )
@@ -209,6 +209,7 @@ package policies
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -227,6 +228,10 @@ import (
 type Gatherer struct {
 	CertificateRequestLister cmlisters.CertificateRequestLister
 	SecretLister             corelisters.SecretLister
+
+	// IssuerCAGetter is optional. If set, it is used to look up the current
+	// CA of the Certificate's issuer.
+	IssuerCAGetter *IssuerCAGetter
 }
 
 // DataForCertificate returns the secret as well as the "current" and "next"
@@ -312,10 +317,24 @@ func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificat
 		log.V(logf.DebugLevel).Info("Found no CertificateRequest resources owned by this Certificate for the next revision", "revision", nextCRRevision)
 	}
 
+	// Look up the current CA of the issuer but tolerate failures: the issuer
+	// or its CA Secret may be missing or invalid, which does not affect
+	// whether the certificate must be re-issued for other reasons.
+	var issuerCA []*x509.Certificate
+	if g.IssuerCAGetter != nil {
+		issuerCA, err = g.IssuerCAGetter.IssuerCA(ctx, crt)
+		if err != nil {
+			log.V(logf.DebugLevel).Info("Failed to look up the current CA of the issuer", "error", err)
+			issuerCA = nil
+		}
+	}
+
 	return Input{
+		Context:                ctx,
 		Certificate:            crt,
 		Secret:                 secret,
 		CurrentRevisionRequest: curCR,
 		NextRevisionRequest:    nextCR,
+		IssuerCA:               issuerCA,
 	}, nil
 }
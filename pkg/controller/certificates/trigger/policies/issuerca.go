@@ -0,0 +1,224 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// IssuerCARotationRetryInterval is the interval after which a Certificate
+// whose re-issuance was held back by the issuer CA rotation rate limit is
+// checked again.
+const IssuerCARotationRetryInterval = 30 * time.Second
+
+// IssuerCAGetter looks up the CA certificates that the issuer of a
+// Certificate currently signs certificates with.
+type IssuerCAGetter struct {
+	IssuerHelper  issuer.Helper
+	SecretLister  corelisters.SecretLister
+	IssuerOptions controllerpkg.IssuerOptions
+}
+
+// IssuerCA returns the certificate chain of the CA that the issuer
+// referenced by crt currently signs certificates with, in the same order as
+// the CA issuer passes it when signing. Nil is returned if the issuer is not
+// a CA issuer.
+func (g *IssuerCAGetter) IssuerCA(ctx context.Context, crt *cmapi.Certificate) ([]*x509.Certificate, error) {
+	ref := crt.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != cmapi.SchemeGroupVersion.Group {
+		return nil, nil
+	}
+
+	iss, err := g.IssuerHelper.GetGenericIssuer(ref, crt.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if iss.GetSpec().CA == nil {
+		return nil, nil
+	}
+
+	return kube.SecretCAIssuerChain(ctx, g.SecretLister, g.IssuerOptions.ResourceNamespace(iss), iss.GetSpec().CA)
+}
+
+// IssuerCARotation spreads out and rate limits the re-issuance of
+// certificates whose issuer's CA has changed, so that rotating a CA used by
+// many certificates does not cause them all to be re-issued at once.
+type IssuerCARotation struct {
+	clock   clock.Clock
+	window  time.Duration
+	limiter flowcontrol.RateLimiter
+
+	lock sync.Mutex
+	// detected records when a change to each issuer CA, keyed by the
+	// fingerprint of its certificate chain, was first observed
+	detected map[[sha256.Size]byte]time.Time
+}
+
+// NewIssuerCARotation returns an IssuerCARotation that re-issues
+// certificates affected by a CA change at a point in the given window after
+// the change was first observed, and at most qps certificates per second.
+func NewIssuerCARotation(clock clock.Clock, window time.Duration, qps float32) *IssuerCARotation {
+	return &IssuerCARotation{
+		clock:    clock,
+		window:   window,
+		limiter:  flowcontrol.NewTokenBucketRateLimiterWithClock(qps, 1, clock),
+		detected: make(map[[sha256.Size]byte]time.Time),
+	}
+}
+
+// IssuerCARotated returns a policy function that triggers re-issuance if the
+// certificate chain or CA stored in the Secret of a Certificate no longer
+// match the current CA of its issuer.
+// Re-issuance is held back until the Certificate's slot in the rotation
+// window has passed and the rotation rate limit allows it.
+func IssuerCARotated(rotation *IssuerCARotation) Func {
+	return func(input Input) (string, string, bool) {
+		changed, err := issuerCAChanged(input)
+		if err != nil || !changed {
+			return "", "", false
+		}
+
+		if rotation.reissueIn(input) > 0 || !rotation.limiter.TryAccept() {
+			return "", "", false
+		}
+
+		ref := input.Certificate.Spec.IssuerRef
+		return IssuerCAChanged, fmt.Sprintf("Re-issuing certificate as the CA of %s has changed", formatIssuerRef(ref.Name, ref.Kind, ref.Group)), true
+	}
+}
+
+// RecheckIn returns how long to wait before checking again whether the
+// Certificate in input must be re-issued because the CA of its issuer has
+// changed, or zero if no such check is needed.
+// The Certificate is re-issued once its slot in the rotation window, which is
+// derived from its name, has passed. If the rate limit is exceeded at that
+// time, it is checked again after IssuerCARotationRetryInterval.
+func (r *IssuerCARotation) RecheckIn(input Input) time.Duration {
+	if changed, err := issuerCAChanged(input); err != nil || !changed {
+		return 0
+	}
+	if reissueIn := r.reissueIn(input); reissueIn > 0 {
+		return reissueIn
+	}
+	return IssuerCARotationRetryInterval
+}
+
+// reissueIn returns the time left until the slot of the Certificate in
+// input in the rotation window of its issuer's current CA.
+func (r *IssuerCARotation) reissueIn(input Input) time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+	for key, detectedAt := range r.detected {
+		if now.Sub(detectedAt) > r.window {
+			delete(r.detected, key)
+		}
+	}
+
+	key := issuerCAFingerprint(input.IssuerCA)
+	detectedAt, ok := r.detected[key]
+	if !ok {
+		detectedAt = now
+		r.detected[key] = detectedAt
+	}
+
+	return detectedAt.Add(r.slot(input.Certificate)).Sub(now)
+}
+
+// slot returns the offset in the rotation window at which crt is
+// re-issued.
+func (r *IssuerCARotation) slot(crt *cmapi.Certificate) time.Duration {
+	if r.window <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(crt.Namespace + "/" + crt.Name))
+	return time.Duration(h.Sum64() % uint64(r.window))
+}
+
+// issuerCAChanged returns whether the certificate chain and CA stored in
+// the Secret in input differ from those the issuer would return if it
+// signed the stored certificate with its current CA.
+func issuerCAChanged(input Input) (bool, error) {
+	if len(input.IssuerCA) == 0 || input.Secret == nil {
+		return false, nil
+	}
+
+	chain, err := pki.DecodeX509CertificateChainBytes(input.Secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return false, err
+	}
+
+	// ParseSingleCertificateChain modifies the slice it is given.
+	certs := append([]*x509.Certificate{chain[0]}, input.IssuerCA...)
+	bundle, err := pki.ParseSingleCertificateChain(certs)
+	if err != nil {
+		// The stored certificate was not signed by the current CA.
+		return true, nil
+	}
+
+	return !bytes.Equal(certificatesRaw(bundle.ChainPEM), certificatesRaw(input.Secret.Data[corev1.TLSCertKey])) ||
+		!bytes.Equal(certificatesRaw(bundle.CAPEM), certificatesRaw(input.Secret.Data[cmmeta.TLSCAKey])), nil
+}
+
+// certificatesRaw returns the concatenated DER encoding of the PEM encoded
+// certificates in data, so that bundles can be compared regardless of
+// comments and white space.
+func certificatesRaw(data []byte) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	certs, err := pki.DecodeX509CertificateChainBytes(data)
+	if err != nil {
+		return data
+	}
+	var raw []byte
+	for _, cert := range certs {
+		raw = append(raw, cert.Raw...)
+	}
+	return raw
+}
+
+func issuerCAFingerprint(certs []*x509.Certificate) [sha256.Size]byte {
+	h := sha256.New()
+	for _, cert := range certs {
+		h.Write(cert.Raw)
+	}
+	var fingerprint [sha256.Size]byte
+	copy(fingerprint[:], h.Sum(nil))
+	return fingerprint
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestIssuerCARotated(t *testing.T) {
+	oldCA, oldCAPEM, oldCAKey := mustCreateTestCertificate(t, testCATemplate("old-ca"), nil, nil)
+	newCA, newCAPEM, newCAKey := mustCreateTestCertificate(t, testCATemplate("new-ca"), nil, nil)
+	_, oldLeafPEM, _ := mustCreateTestCertificate(t, testLeafTemplate(), oldCA, oldCAKey)
+	_, newLeafPEM, _ := mustCreateTestCertificate(t, testLeafTemplate(), newCA, newCAKey)
+
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+		Spec: cmapi.CertificateSpec{
+			IssuerRef: cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"},
+		},
+	}
+	secret := func(certPEM, caPEM []byte) *corev1.Secret {
+		return &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: certPEM, cmmeta.TLSCAKey: caPEM}}
+	}
+
+	tests := map[string]struct {
+		secret   *corev1.Secret
+		issuerCA []*x509.Certificate
+
+		reason, message string
+		reissue         bool
+	}{
+		"do nothing if the issuer CA is not known": {
+			secret: secret(oldLeafPEM, oldCAPEM),
+		},
+		"do not reissue if the certificate was issued by the current CA": {
+			secret:   secret(newLeafPEM, newCAPEM),
+			issuerCA: []*x509.Certificate{newCA},
+		},
+		"reissue if the certificate was issued by a different CA": {
+			secret:   secret(oldLeafPEM, oldCAPEM),
+			issuerCA: []*x509.Certificate{newCA},
+			reason:   IssuerCAChanged,
+			message:  "Re-issuing certificate as the CA of Issuer.cert-manager.io/ca-issuer has changed",
+			reissue:  true,
+		},
+		"reissue if the stored CA is not the current CA": {
+			secret:   secret(newLeafPEM, oldCAPEM),
+			issuerCA: []*x509.Certificate{newCA},
+			reason:   IssuerCAChanged,
+			message:  "Re-issuing certificate as the CA of Issuer.cert-manager.io/ca-issuer has changed",
+			reissue:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rotation := NewIssuerCARotation(fakeclock.NewFakeClock(time.Now()), 0, 1)
+			reason, message, reissue := IssuerCARotated(rotation)(Input{
+				Certificate: crt,
+				Secret:      test.secret,
+				IssuerCA:    test.issuerCA,
+			})
+
+			if test.reason != reason {
+				t.Errorf("unexpected 'reason' exp=%s, got=%s", test.reason, reason)
+			}
+			if test.message != message {
+				t.Errorf("unexpected 'message' exp=%s, got=%s", test.message, message)
+			}
+			if test.reissue != reissue {
+				t.Errorf("unexpected 'reissue' exp=%v, got=%v", test.reissue, reissue)
+			}
+		})
+	}
+}
+
+func TestIssuerCARotationStaggersReissuance(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	oldCA, oldCAPEM, oldCAKey := mustCreateTestCertificate(t, testCATemplate("old-ca"), nil, nil)
+	newCA, _, _ := mustCreateTestCertificate(t, testCATemplate("new-ca"), nil, nil)
+	_, oldLeafPEM, _ := mustCreateTestCertificate(t, testLeafTemplate(), oldCA, oldCAKey)
+
+	rotation := NewIssuerCARotation(fixedClock, time.Hour, 1)
+	policy := IssuerCARotated(rotation)
+	input := Input{
+		Certificate: &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"}},
+		Secret:      &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: oldLeafPEM, cmmeta.TLSCAKey: oldCAPEM}},
+		IssuerCA:    []*x509.Certificate{newCA},
+	}
+
+	slot := rotation.slot(input.Certificate)
+	if slot <= 0 || slot >= time.Hour {
+		t.Fatalf("expected slot in the rotation window, got %s", slot)
+	}
+	if recheckIn := rotation.RecheckIn(input); recheckIn != slot {
+		t.Errorf("unexpected recheck delay exp=%s, got=%s", slot, recheckIn)
+	}
+	if _, _, reissue := policy(input); reissue {
+		t.Errorf("unexpected reissue before the certificate's slot in the rotation window")
+	}
+
+	fixedClock.Step(slot)
+	if _, _, reissue := policy(input); !reissue {
+		t.Errorf("expected reissue once the certificate's slot in the rotation window has passed")
+	}
+}
+
+func TestIssuerCARotationRateLimitsReissuance(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+	oldCA, oldCAPEM, oldCAKey := mustCreateTestCertificate(t, testCATemplate("old-ca"), nil, nil)
+	newCA, _, _ := mustCreateTestCertificate(t, testCATemplate("new-ca"), nil, nil)
+	_, oldLeafPEM, _ := mustCreateTestCertificate(t, testLeafTemplate(), oldCA, oldCAKey)
+
+	rotation := NewIssuerCARotation(fixedClock, 0, 0.1)
+	policy := IssuerCARotated(rotation)
+	input := func(name string) Input {
+		return Input{
+			Certificate: &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: name}},
+			Secret:      &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: oldLeafPEM, cmmeta.TLSCAKey: oldCAPEM}},
+			IssuerCA:    []*x509.Certificate{newCA},
+		}
+	}
+
+	if _, _, reissue := policy(input("first")); !reissue {
+		t.Errorf("expected the first certificate to be reissued")
+	}
+	second := input("second")
+	if _, _, reissue := policy(second); reissue {
+		t.Errorf("expected the second certificate to be rate limited")
+	}
+	if recheckIn := rotation.RecheckIn(second); recheckIn != IssuerCARotationRetryInterval {
+		t.Errorf("unexpected recheck delay exp=%s, got=%s", IssuerCARotationRetryInterval, recheckIn)
+	}
+
+	fixedClock.Step(IssuerCARotationRetryInterval)
+	if _, _, reissue := policy(second); !reissue {
+		t.Errorf("expected the second certificate to be reissued once the rate limit allows it")
+	}
+}
@@ -18,7 +18,9 @@ limitations under the License.
 package policies
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"time"
 
@@ -32,6 +34,11 @@ import (
 )
 
 type Input struct {
+	// Context is the context of the sync that the input was gathered for.
+	// Policies that make network requests use it so that the requests are
+	// cancelled along with the sync. If nil, context.Background() is used.
+	Context context.Context
+
 	Certificate *cmapi.Certificate
 	Secret      *corev1.Secret
 
@@ -47,6 +54,11 @@ type Input struct {
 	// Take a look at the gatherer package's documentation to see more about why
 	// we care about the "next" certificate request.
 	NextRevisionRequest *cmapi.CertificateRequest
+
+	// IssuerCA is the certificate chain of the CA that the Certificate's
+	// issuer currently signs certificates with. It is only populated for CA
+	// issuers, and only if the gatherer has been configured to look it up.
+	IssuerCA []*x509.Certificate
 }
 
 // A Func evaluates the given input data and decides whether a
@@ -70,16 +82,26 @@ func (c Chain) Evaluate(input Input) (string, string, bool) {
 	return "", "", false
 }
 
-func NewTriggerPolicyChain(c clock.Clock) Chain {
-	return Chain{
+// NewTriggerPolicyChain returns the policy chain used to decide whether a
+// Certificate must be re-issued. If revocationChecker is nil, the revocation
+// status of certificates will not be checked. If issuerCARotation is nil,
+// certificates will not be re-issued when the CA of their issuer changes.
+func NewTriggerPolicyChain(c clock.Clock, revocationChecker *RevocationChecker, issuerCARotation *IssuerCARotation) Chain {
+	chain := Chain{
 		SecretDoesNotExist,
 		SecretIsMissingData,
 		SecretPublicKeysDiffer,
 		SecretPrivateKeyMatchesSpec,
 		SecretIssuerAnnotationsNotUpToDate,
 		CurrentCertificateRequestNotValidForSpec,
-		CurrentCertificateNearingExpiry(c),
 	}
+	if revocationChecker != nil {
+		chain = append(chain, CurrentCertificateRevoked(revocationChecker))
+	}
+	if issuerCARotation != nil {
+		chain = append(chain, IssuerCARotated(issuerCARotation))
+	}
+	return append(chain, CurrentCertificateNearingExpiry(c))
 }
 
 func SecretDoesNotExist(input Input) (string, string, bool) {
@@ -214,6 +236,9 @@ func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 		notAfter := metav1.NewTime(x509cert.NotAfter)
 		crt := input.Certificate
 		renewalTime := certificates.RenewalTime(notBefore.Time, notAfter.Time, crt.Spec.RenewBefore)
+		// Renew earlier if the issuer has suggested a renewal window for this
+		// certificate that starts before the regular renewal time.
+		renewalTime = certificates.RenewalTimeWithRenewalInfo(renewalTime, crt.Status.RenewalInfo, x509cert)
 
 		renewIn := renewalTime.Time.Sub(c.Now())
 		if renewIn > 0 {
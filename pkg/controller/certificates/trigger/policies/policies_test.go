@@ -17,6 +17,7 @@ limitations under the License.
 package policies
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	internaltest "github.com/jetstack/cert-manager/pkg/controller/certificates/internal/test"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
 // Runs a full set of tests against the 'policy chain' once it is composed
@@ -37,6 +39,16 @@ import (
 func TestDefaultPolicyChain(t *testing.T) {
 	clock := &fakeclock.FakeClock{}
 	staticFixedPrivateKey := internaltest.MustCreatePEMPrivateKey(t)
+	longLivedCert := internaltest.MustCreateCertWithNotBeforeAfter(t, staticFixedPrivateKey,
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+		clock.Now().Add(time.Hour*-24),
+		// expires in 89 days time
+		clock.Now().Add(time.Hour*24*89),
+	)
+	longLivedX509Cert, err := pki.DecodeX509CertificateBytes(longLivedCert)
+	if err != nil {
+		t.Fatal(err)
+	}
 	tests := map[string]struct {
 		// policy inputs
 		certificate *cmapi.Certificate
@@ -484,8 +496,75 @@ func TestDefaultPolicyChain(t *testing.T) {
 				},
 			},
 		},
+		"trigger renewal if the issuer's suggested renewal window has started": {
+			certificate: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+				},
+				Status: cmapi.CertificateStatus{
+					RenewalInfo: &cmapi.CertificateRenewalInfo{
+						SerialNumber:         fmt.Sprintf("%x", longLivedX509Cert.SerialNumber),
+						SuggestedWindowStart: metav1.NewTime(clock.Now().Add(time.Hour * -2)),
+						SuggestedWindowEnd:   metav1.NewTime(clock.Now().Add(time.Hour * -1)),
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey:       longLivedCert,
+				},
+			},
+			reason:  Renewing,
+			message: "Renewing certificate as renewal was scheduled at <nil>",
+			reissue: true,
+		},
+		"does not trigger renewal if the suggested renewal window is for a different certificate": {
+			certificate: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+				},
+				Status: cmapi.CertificateStatus{
+					RenewalInfo: &cmapi.CertificateRenewalInfo{
+						SerialNumber:         "0",
+						SuggestedWindowStart: metav1.NewTime(clock.Now().Add(time.Hour * -2)),
+						SuggestedWindowEnd:   metav1.NewTime(clock.Now().Add(time.Hour * -1)),
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey:       longLivedCert,
+				},
+			},
+		},
 	}
-	policyChain := NewTriggerPolicyChain(clock)
+	policyChain := NewTriggerPolicyChain(clock, nil, nil)
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			reason, message, reissue := policyChain.Evaluate(Input{
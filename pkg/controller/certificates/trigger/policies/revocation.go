@@ -0,0 +1,253 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/sync/singleflight"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/certificates"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// revocationStatusCacheLeeway is subtracted from a Certificate's revocation
+// check interval when caching a status, so that a re-check scheduled at the
+// end of the interval is not answered from the cache.
+const revocationStatusCacheLeeway = time.Minute
+
+// revocationStatusRetryInterval is the time for which a failure to determine
+// the revocation status of a certificate is cached before it is checked
+// again.
+const revocationStatusRetryInterval = time.Minute
+
+// errRevocationStatusUnknown is returned if none of the OCSP responders and
+// CRL distribution points of a certificate could be queried.
+var errRevocationStatusUnknown = errors.New("revocation status could not be determined")
+
+// RevocationChecker checks whether certificates have been revoked using the
+// OCSP responders and CRL distribution points named in them. The status of
+// each certificate is cached, so that it is checked at most once per
+// Certificate's revocation check interval. Concurrent checks of the same
+// certificate are deduplicated, and checks of different certificates do not
+// wait on each other.
+type RevocationChecker struct {
+	client *http.Client
+	clock  clock.Clock
+
+	// lock guards statuses. It is not held while checking a certificate.
+	lock     sync.Mutex
+	statuses map[[sha256.Size]byte]revocationStatus
+
+	inflight singleflight.Group
+}
+
+// revocationStatus is the result of checking the revocation status of a
+// certificate.
+type revocationStatus struct {
+	revoked   bool
+	revokedAt time.Time
+	reason    pki.RevocationReason
+	// source describes where the revocation status was obtained from
+	source string
+
+	// checkAfter is the time after which the status must be checked again
+	checkAfter time.Time
+}
+
+// NewRevocationChecker returns a RevocationChecker that uses the given
+// HTTP client to query OCSP responders and download CRLs.
+func NewRevocationChecker(client *http.Client, clock clock.Clock) *RevocationChecker {
+	return &RevocationChecker{
+		client:   client,
+		clock:    clock,
+		statuses: make(map[[sha256.Size]byte]revocationStatus),
+	}
+}
+
+// RevocationCheckInterval returns the interval at which the revocation
+// status of a Certificate's current certificate should be checked, or zero
+// if revocation checking is not enabled for the Certificate.
+func RevocationCheckInterval(crt *cmapi.Certificate) time.Duration {
+	rc := crt.Spec.RevocationCheck
+	if rc == nil || !rc.Enabled {
+		return 0
+	}
+	if rc.Interval == nil {
+		return cmapi.DefaultRevocationCheckInterval
+	}
+	return rc.Interval.Duration
+}
+
+// CurrentCertificateRevoked returns a policy function that triggers
+// re-issuance if the current certificate of a Certificate with revocation
+// checking enabled has been revoked by its issuer.
+// Failures to determine the revocation status do not trigger re-issuance.
+func CurrentCertificateRevoked(checker *RevocationChecker) Func {
+	return func(input Input) (string, string, bool) {
+		interval := RevocationCheckInterval(input.Certificate)
+		if interval == 0 {
+			return "", "", false
+		}
+
+		cert, issuerCert, err := certificates.SecretCertificateAndIssuer(input.Secret)
+		if err != nil {
+			return "", "", false
+		}
+
+		ctx := input.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		status := checker.status(ctx, cert, issuerCert, interval)
+		if !status.revoked {
+			return "", "", false
+		}
+
+		return Revoked, fmt.Sprintf("Re-issuing certificate as it was revoked at %s with reason %s according to %s",
+			status.revokedAt.UTC().Format(time.RFC3339), status.reason, status.source), true
+	}
+}
+
+// status returns the revocation status of cert, checking it again if the
+// cached status is older than interval. If the status cannot be determined,
+// it is checked again after revocationStatusRetryInterval.
+func (r *RevocationChecker) status(ctx context.Context, cert, issuerCert *x509.Certificate, interval time.Duration) revocationStatus {
+	key := sha256.Sum256(cert.Raw)
+	if status, ok := r.cachedStatus(key); ok {
+		return status
+	}
+
+	v, _, _ := r.inflight.Do(string(key[:]), func() (interface{}, error) {
+		// a check of the certificate may have finished between the lookup
+		// above and the start of this one
+		if status, ok := r.cachedStatus(key); ok {
+			return status, nil
+		}
+
+		status, err := r.check(ctx, cert, issuerCert)
+		switch {
+		case err != nil && interval > revocationStatusRetryInterval:
+			interval = revocationStatusRetryInterval
+		case interval > revocationStatusCacheLeeway:
+			interval -= revocationStatusCacheLeeway
+		}
+		status.checkAfter = r.clock.Now().Add(interval)
+
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.statuses[key] = status
+
+		return status, nil
+	})
+
+	return v.(revocationStatus)
+}
+
+// cachedStatus returns the cached revocation status of the certificate with
+// the given key, if there is one that does not need to be checked again.
+// Expired statuses of all certificates are removed from the cache.
+func (r *RevocationChecker) cachedStatus(key [sha256.Size]byte) (revocationStatus, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+	for key, status := range r.statuses {
+		if now.After(status.checkAfter) {
+			delete(r.statuses, key)
+		}
+	}
+
+	status, ok := r.statuses[key]
+	return status, ok
+}
+
+// check determines the revocation status of cert using its OCSP responder,
+// falling back to its CRL distribution points if the certificate does not
+// name an OCSP responder or the responder cannot give a definitive answer.
+// A certificate is only reported as revoked if its issuer said so. An error
+// is returned if the certificate names OCSP responders or CRL distribution
+// points, but none of them could be queried.
+func (r *RevocationChecker) check(ctx context.Context, cert, issuerCert *x509.Certificate) (revocationStatus, error) {
+	queried := false
+	for _, responder := range cert.OCSPServer {
+		queried = true
+		_, resp, err := certificates.FetchOCSPResponse(ctx, r.client, responder, cert, issuerCert)
+		if err != nil {
+			continue
+		}
+
+		source := fmt.Sprintf("OCSP responder %s", responder)
+		switch resp.Status {
+		case ocsp.Good:
+			return revocationStatus{source: source}, nil
+		case ocsp.Revoked:
+			return revocationStatus{
+				revoked:   true,
+				revokedAt: resp.RevokedAt,
+				reason:    pki.RevocationReason(resp.RevocationReason),
+				source:    source,
+			}, nil
+		}
+	}
+
+	for _, distributionPoint := range cert.CRLDistributionPoints {
+		if !strings.HasPrefix(distributionPoint, "http://") && !strings.HasPrefix(distributionPoint, "https://") {
+			continue
+		}
+
+		queried = true
+		crl, err := certificates.FetchCRL(ctx, r.client, distributionPoint, issuerCert)
+		if err != nil {
+			continue
+		}
+
+		source := fmt.Sprintf("CRL %s", distributionPoint)
+		for _, entry := range crl.TBSCertList.RevokedCertificates {
+			if entry.SerialNumber.Cmp(cert.SerialNumber) != 0 {
+				continue
+			}
+			// A reason code that cannot be parsed does not make the
+			// certificate any less revoked.
+			reason, _ := pki.CRLEntryRevocationReason(entry)
+			return revocationStatus{
+				revoked:   true,
+				revokedAt: entry.RevocationTime,
+				reason:    reason,
+				source:    source,
+			}, nil
+		}
+		return revocationStatus{source: source}, nil
+	}
+
+	if queried {
+		return revocationStatus{}, errRevocationStatusUnknown
+	}
+	return revocationStatus{}, nil
+}
@@ -0,0 +1,424 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func mustCreateTestCertificate(t *testing.T, template, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, []byte, crypto.Signer) {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, err := pki.EncodeX509(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, certPEM, key
+}
+
+// testCATemplate returns the template of a CA certificate valid for a day.
+func testCATemplate(commonName string) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour * 24),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+}
+
+// testLeafTemplate returns the template of a certificate for example.com
+// valid for an hour.
+func testLeafTemplate() *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber: big.NewInt(1234),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+}
+
+// revocationServer is a stand-in for a CA's OCSP responder and CRL
+// distribution point.
+type revocationServer struct {
+	t  *testing.T
+	ca *testCA
+
+	// ocspStatus is the status returned by the OCSP responder, or -1 if
+	// the responder should fail.
+	ocspStatus int
+	// crlRevoked is the list of serial numbers included in the CRL, which
+	// is not served if nil.
+	crlRevoked []*big.Int
+
+	requests int
+}
+
+func (s *revocationServer) fail(w http.ResponseWriter, err error) {
+	s.t.Error(err)
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+func (s *revocationServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.requests++
+	switch req.URL.Path {
+	case "/ocsp":
+		if s.ocspStatus < 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			s.fail(w, err)
+			return
+		}
+		ocspReq, err := ocsp.ParseRequest(body)
+		if err != nil {
+			s.fail(w, err)
+			return
+		}
+		resp, err := ocsp.CreateResponse(s.ca.cert, s.ca.cert, ocsp.Response{
+			Status:           s.ocspStatus,
+			SerialNumber:     ocspReq.SerialNumber,
+			ThisUpdate:       time.Now(),
+			RevokedAt:        time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+			RevocationReason: ocsp.KeyCompromise,
+		}, s.ca.key)
+		if err != nil {
+			s.fail(w, err)
+			return
+		}
+		w.Write(resp)
+	case "/crl":
+		if s.crlRevoked == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var revoked []pkix.RevokedCertificate
+		for _, serial := range s.crlRevoked {
+			entry, err := pki.RevokedCertificate(serial, time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC), pki.RevocationReasonSuperseded)
+			if err != nil {
+				s.fail(w, err)
+				return
+			}
+			revoked = append(revoked, entry)
+		}
+		crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+			Number:              big.NewInt(1),
+			ThisUpdate:          time.Now(),
+			NextUpdate:          time.Now().Add(time.Hour),
+			RevokedCertificates: revoked,
+		}, s.ca.cert, s.ca.key)
+		if err != nil {
+			s.fail(w, err)
+			return
+		}
+		w.Write(crl)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestCurrentCertificateRevoked(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+
+	caCert, caPEM, caKey := mustCreateTestCertificate(t, testCATemplate("test-ca"), nil, nil)
+	ca := &testCA{cert: caCert, key: caKey}
+
+	server := &revocationServer{t: t, ca: ca}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	leafTemplate := func(ocspServers, crlDistributionPoints []string) *x509.Certificate {
+		template := testLeafTemplate()
+		template.OCSPServer = ocspServers
+		template.CRLDistributionPoints = crlDistributionPoints
+		return template
+	}
+	_, leafPEM, _ := mustCreateTestCertificate(t, leafTemplate([]string{httpServer.URL + "/ocsp"}, []string{httpServer.URL + "/crl"}), caCert, caKey)
+	_, crlOnlyPEM, _ := mustCreateTestCertificate(t, leafTemplate(nil, []string{"ldap://example.com/crl", httpServer.URL + "/crl"}), caCert, caKey)
+
+	enabledCrt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		RevocationCheck: &cmapi.CertificateRevocationCheck{Enabled: true},
+	}}
+	secret := func(certPEM, caPEM []byte) *corev1.Secret {
+		return &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: certPEM, cmmeta.TLSCAKey: caPEM}}
+	}
+
+	tests := map[string]struct {
+		certificate *cmapi.Certificate
+		secret      *corev1.Secret
+		ocspStatus  int
+		crlRevoked  []*big.Int
+
+		reason, message string
+		reissue         bool
+		requests        int
+	}{
+		"do nothing if revocation checking is not enabled": {
+			certificate: &cmapi.Certificate{},
+			secret:      secret(leafPEM, caPEM),
+			ocspStatus:  ocsp.Revoked,
+		},
+		"do nothing if revocation checking is explicitly disabled": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				RevocationCheck: &cmapi.CertificateRevocationCheck{Enabled: false},
+			}},
+			secret:     secret(leafPEM, caPEM),
+			ocspStatus: ocsp.Revoked,
+		},
+		"do nothing if the issuer certificate is not available": {
+			certificate: enabledCrt,
+			secret:      secret(leafPEM, nil),
+			ocspStatus:  ocsp.Revoked,
+		},
+		"do not reissue if the OCSP responder reports the certificate as good": {
+			certificate: enabledCrt,
+			secret:      secret(leafPEM, caPEM),
+			ocspStatus:  ocsp.Good,
+			crlRevoked:  []*big.Int{big.NewInt(1234)},
+			requests:    1,
+		},
+		"reissue if the OCSP responder reports the certificate as revoked": {
+			certificate: enabledCrt,
+			secret:      secret(leafPEM, caPEM),
+			ocspStatus:  ocsp.Revoked,
+			reason:      Revoked,
+			message:     "Re-issuing certificate as it was revoked at 2021-01-02T03:04:05Z with reason keyCompromise according to OCSP responder " + httpServer.URL + "/ocsp",
+			reissue:     true,
+			requests:    1,
+		},
+		"fall back to the CRL if the OCSP responder does not know the certificate": {
+			certificate: enabledCrt,
+			secret:      secret(leafPEM, caPEM),
+			ocspStatus:  ocsp.Unknown,
+			crlRevoked:  []*big.Int{big.NewInt(1234)},
+			reason:      Revoked,
+			message:     "Re-issuing certificate as it was revoked at 2021-02-03T04:05:06Z with reason superseded according to CRL " + httpServer.URL + "/crl",
+			reissue:     true,
+			requests:    2,
+		},
+		"fall back to the CRL if the OCSP responder fails": {
+			certificate: enabledCrt,
+			secret:      secret(leafPEM, caPEM),
+			ocspStatus:  -1,
+			crlRevoked:  []*big.Int{big.NewInt(1234)},
+			reason:      Revoked,
+			message:     "Re-issuing certificate as it was revoked at 2021-02-03T04:05:06Z with reason superseded according to CRL " + httpServer.URL + "/crl",
+			reissue:     true,
+			requests:    2,
+		},
+		"use the CRL if the certificate does not name an OCSP responder": {
+			certificate: enabledCrt,
+			secret:      secret(crlOnlyPEM, caPEM),
+			crlRevoked:  []*big.Int{big.NewInt(1234)},
+			reason:      Revoked,
+			message:     "Re-issuing certificate as it was revoked at 2021-02-03T04:05:06Z with reason superseded according to CRL " + httpServer.URL + "/crl",
+			reissue:     true,
+			requests:    1,
+		},
+		"do not reissue if the certificate is not listed in the CRL": {
+			certificate: enabledCrt,
+			secret:      secret(crlOnlyPEM, caPEM),
+			crlRevoked:  []*big.Int{big.NewInt(4321)},
+			requests:    1,
+		},
+		"do not reissue if the revocation status cannot be determined": {
+			certificate: enabledCrt,
+			secret:      secret(leafPEM, caPEM),
+			ocspStatus:  -1,
+			requests:    2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server.t = t
+			server.ocspStatus = test.ocspStatus
+			server.crlRevoked = test.crlRevoked
+			server.requests = 0
+
+			checker := NewRevocationChecker(httpServer.Client(), fixedClock)
+			reason, message, reissue := CurrentCertificateRevoked(checker)(Input{
+				Certificate: test.certificate,
+				Secret:      test.secret,
+			})
+
+			if test.reason != reason {
+				t.Errorf("unexpected 'reason' exp=%s, got=%s", test.reason, reason)
+			}
+			if test.message != message {
+				t.Errorf("unexpected 'message' exp=%s, got=%s", test.message, message)
+			}
+			if test.reissue != reissue {
+				t.Errorf("unexpected 'reissue' exp=%v, got=%v", test.reissue, reissue)
+			}
+			if test.requests != server.requests {
+				t.Errorf("unexpected number of requests exp=%d, got=%d", test.requests, server.requests)
+			}
+		})
+	}
+}
+
+func TestRevocationCheckerCachesStatus(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+
+	caCert, caPEM, caKey := mustCreateTestCertificate(t, testCATemplate("test-ca"), nil, nil)
+
+	server := &revocationServer{t: t, ca: &testCA{cert: caCert, key: caKey}, ocspStatus: ocsp.Good}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	leafTemplate := testLeafTemplate()
+	leafTemplate.OCSPServer = []string{httpServer.URL + "/ocsp"}
+	_, leafPEM, _ := mustCreateTestCertificate(t, leafTemplate, caCert, caKey)
+
+	policy := CurrentCertificateRevoked(NewRevocationChecker(httpServer.Client(), fixedClock))
+	input := Input{
+		Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+			RevocationCheck: &cmapi.CertificateRevocationCheck{
+				Enabled:  true,
+				Interval: &metav1.Duration{Duration: time.Minute * 10},
+			},
+		}},
+		Secret: &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: leafPEM, cmmeta.TLSCAKey: caPEM}},
+	}
+
+	if _, _, reissue := policy(input); reissue {
+		t.Fatalf("unexpected reissue of good certificate")
+	}
+
+	// The status is cached until the interval has (almost) passed.
+	server.ocspStatus = ocsp.Revoked
+	fixedClock.Step(time.Minute * 5)
+	if _, _, reissue := policy(input); reissue {
+		t.Errorf("expected the cached status to be used")
+	}
+	if server.requests != 1 {
+		t.Errorf("expected 1 request to the OCSP responder, got %d", server.requests)
+	}
+
+	fixedClock.Step(time.Minute * 5)
+	if _, _, reissue := policy(input); !reissue {
+		t.Errorf("expected the status to be checked again once the interval has passed")
+	}
+	if server.requests != 2 {
+		t.Errorf("expected 2 requests to the OCSP responder, got %d", server.requests)
+	}
+}
+
+func TestRevocationCheckerRetriesFailures(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(time.Now())
+
+	caCert, caPEM, caKey := mustCreateTestCertificate(t, testCATemplate("test-ca"), nil, nil)
+
+	server := &revocationServer{t: t, ca: &testCA{cert: caCert, key: caKey}, ocspStatus: -1}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	leafTemplate := testLeafTemplate()
+	leafTemplate.OCSPServer = []string{httpServer.URL + "/ocsp"}
+	_, leafPEM, _ := mustCreateTestCertificate(t, leafTemplate, caCert, caKey)
+
+	policy := CurrentCertificateRevoked(NewRevocationChecker(httpServer.Client(), fixedClock))
+	input := Input{
+		Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+			RevocationCheck: &cmapi.CertificateRevocationCheck{
+				Enabled:  true,
+				Interval: &metav1.Duration{Duration: time.Hour},
+			},
+		}},
+		Secret: &corev1.Secret{Data: map[string][]byte{corev1.TLSCertKey: leafPEM, cmmeta.TLSCAKey: caPEM}},
+	}
+
+	// A cancelled sync does not query the OCSP responder.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	input.Context = ctx
+	if _, _, reissue := policy(input); reissue {
+		t.Fatalf("unexpected reissue of certificate with unknown status")
+	}
+	if server.requests != 0 {
+		t.Errorf("expected no requests to the OCSP responder, got %d", server.requests)
+	}
+
+	// The failure is only cached for the retry interval, not for the whole
+	// revocation check interval.
+	input.Context = context.Background()
+	if _, _, reissue := policy(input); reissue {
+		t.Fatalf("unexpected reissue of certificate with unknown status")
+	}
+	if server.requests != 0 {
+		t.Errorf("expected the cached failure to be used, got %d requests", server.requests)
+	}
+
+	fixedClock.Step(revocationStatusRetryInterval + time.Second)
+	if _, _, reissue := policy(input); reissue {
+		t.Fatalf("unexpected reissue of certificate with unknown status")
+	}
+	if server.requests != 1 {
+		t.Errorf("expected 1 request to the OCSP responder, got %d", server.requests)
+	}
+
+	server.ocspStatus = ocsp.Revoked
+	fixedClock.Step(revocationStatusRetryInterval + time.Second)
+	if _, _, reissue := policy(input); !reissue {
+		t.Errorf("expected the status to be checked again once the retry interval has passed")
+	}
+	if server.requests != 2 {
+		t.Errorf("expected 2 requests to the OCSP responder, got %d", server.requests)
+	}
+}
@@ -18,6 +18,7 @@ package trigger
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -41,8 +42,10 @@ import (
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/controller/certificates"
 	"github.com/jetstack/cert-manager/pkg/controller/certificates/trigger/policies"
+	"github.com/jetstack/cert-manager/pkg/feature"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/scheduler"
+	utilfeature "github.com/jetstack/cert-manager/pkg/util/feature"
 	"github.com/jetstack/cert-manager/pkg/util/predicate"
 )
 
@@ -61,6 +64,11 @@ type controller struct {
 	recorder                 record.EventRecorder
 	scheduledWorkQueue       scheduler.ScheduledWorkQueue
 
+	// issuerCARotation is used to schedule re-checks of Certificates whose
+	// issuer's CA has changed. It is nil if such Certificates are not
+	// re-issued.
+	issuerCARotation *policies.IssuerCARotation
+
 	// The following are used for testing purposes.
 	clock              clock.Clock
 	shouldReissue      policies.Func
@@ -165,7 +173,20 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if crt.Status.RenewalTime != nil {
 		// ensure a resync is scheduled in the future so that we re-check
 		// Certificate resources and trigger them near expiry time
-		c.scheduleRecheckOfCertificateIfRequired(log, key, crt.Status.RenewalTime.Time.Sub(c.clock.Now()))
+		recheckIn := crt.Status.RenewalTime.Time.Sub(c.clock.Now())
+		// if revocation checking is enabled, also make sure that the
+		// revocation status is checked again once the interval has passed
+		if interval := policies.RevocationCheckInterval(crt); interval > 0 && interval < recheckIn {
+			recheckIn = interval
+		}
+		// if the CA of the issuer has changed, also make sure that the
+		// certificate is checked again once it may be re-issued
+		if c.issuerCARotation != nil {
+			if rotateIn := c.issuerCARotation.RecheckIn(input); rotateIn > 0 && rotateIn < recheckIn {
+				recheckIn = rotateIn
+			}
+		}
+		c.scheduleRecheckOfCertificateIfRequired(log, key, recheckIn)
 	}
 
 	reason, message, reissue := c.shouldReissue(input)
@@ -267,16 +288,31 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 	// construct a new named logger to be reused throughout the controller
 	log := logf.FromContext(ctx.RootContext, ControllerName)
 
+	var issuerCARotation *policies.IssuerCARotation
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ReissueOnIssuerCARotation) {
+		issuerCARotation = policies.NewIssuerCARotation(ctx.Clock,
+			ctx.CertificateOptions.IssuerCARotationReissueWindow,
+			ctx.CertificateOptions.IssuerCARotationReissueQPS,
+		)
+	}
+
 	ctrl, queue, mustSync := NewController(log,
 		ctx.CMClient,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
 		ctx.Recorder,
 		ctx.Clock,
-		policies.NewTriggerPolicyChain(ctx.Clock).Evaluate,
+		policies.NewTriggerPolicyChain(ctx.Clock,
+			policies.NewRevocationChecker(&http.Client{Timeout: certificates.RevocationStatusTimeout}, ctx.Clock),
+			issuerCARotation,
+		).Evaluate,
 	)
 	c.controller = ctrl
 
+	if issuerCARotation != nil {
+		mustSync = append(mustSync, ctrl.registerIssuerCARotation(log, ctx, queue, issuerCARotation)...)
+	}
+
 	return queue, mustSync, nil
 }
 
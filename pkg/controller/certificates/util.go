@@ -17,19 +17,29 @@ limitations under the License.
 package certificates
 
 import (
+	"context"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"fmt"
+	"math/big"
 	"reflect"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
 
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
@@ -173,6 +183,13 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 	if !util.EqualKeyUsagesUnsorted(req.Spec.Usages, spec.Usages) {
 		violations = append(violations, "spec.usages")
 	}
+	nameConstraints, err := pki.NameConstraintsExtension(spec.NameConstraints)
+	if err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(requestExtension(x509req, pki.OIDExtensionNameConstraints), nameConstraints) {
+		violations = append(violations, "spec.nameConstraints")
+	}
 	if spec.Duration != nil && req.Spec.Duration != nil &&
 		spec.Duration.Duration != req.Spec.Duration.Duration {
 		violations = append(violations, "spec.duration")
@@ -184,6 +201,17 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 	return violations, nil
 }
 
+// requestExtension returns the extension of the certificate request with the
+// given OID, or nil if the request does not contain it.
+func requestExtension(req *x509.CertificateRequest, oid asn1.ObjectIdentifier) *pkix.Extension {
+	for i := range req.Extensions {
+		if req.Extensions[i].Id.Equal(oid) {
+			return &req.Extensions[i]
+		}
+	}
+	return nil
+}
+
 // SecretDataAltNamesMatchSpec will compare a Secret resource containing certificate
 // data to a CertificateSpec and return a list of 'violations' for any fields that
 // do not match their counterparts.
@@ -310,3 +338,113 @@ func RenewalTime(notBefore, notAfter time.Time, renewBeforeOverride *metav1.Dura
 	rt := metav1.NewTime(notAfter.Add(-1 * renewBefore))
 	return &rt
 }
+
+// RenewalTimeWithRenewalInfo returns the renewal time of the given
+// certificate taking into account the renewal window suggested by its
+// issuer, if any. A fixed point within the suggested window, derived from the
+// certificate's serial number so that renewals of many certificates are
+// spread across the window, is used if it is earlier than renewalTime.
+// The renewal information is ignored if it does not belong to cert.
+func RenewalTimeWithRenewalInfo(renewalTime *metav1.Time, info *cmapi.CertificateRenewalInfo, cert *x509.Certificate) *metav1.Time {
+	if info == nil || cert == nil || info.SerialNumber != fmt.Sprintf("%x", cert.SerialNumber) {
+		return renewalTime
+	}
+
+	start, end := info.SuggestedWindowStart.Time, info.SuggestedWindowEnd.Time
+	if end.Before(start) {
+		return renewalTime
+	}
+
+	suggested := start
+	if window := int64(end.Sub(start) / time.Second); window > 0 {
+		offset := new(big.Int).Mod(cert.SerialNumber, big.NewInt(window)).Int64()
+		suggested = start.Add(time.Duration(offset) * time.Second)
+	}
+
+	if renewalTime != nil && !suggested.Before(renewalTime.Time) {
+		return renewalTime
+	}
+	rt := metav1.NewTime(suggested)
+	return &rt
+}
+
+// RequestPendingRevocation returns true if the given CertificateRequest has
+// been issued but has not yet been processed by the revocation controller,
+// i.e. it does not have a `Revoked` condition.
+func RequestPendingRevocation(req *cmapi.CertificateRequest) bool {
+	if len(req.Status.Certificate) == 0 {
+		return false
+	}
+	if !apiutil.CertificateRequestHasCondition(req, cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		return false
+	}
+	return apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionRevoked) == nil
+}
+
+// SecretCertificateAndIssuer returns the certificate stored in the given
+// Secret along with the certificate of its issuer, which is taken from the
+// chain in `tls.crt` or, if not present there, from `ca.crt`.
+func SecretCertificateAndIssuer(secret *corev1.Secret) (*x509.Certificate, *x509.Certificate, error) {
+	chain, err := pki.DecodeX509CertificateChainBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode certificate stored in Secret: %v", err)
+	}
+
+	cert := chain[0]
+	candidates := chain[1:]
+	if ca, err := pki.DecodeX509CertificateChainBytes(secret.Data[cmmeta.TLSCAKey]); err == nil {
+		candidates = append(candidates, ca...)
+	}
+
+	for _, candidate := range candidates {
+		if cert.CheckSignatureFrom(candidate) == nil {
+			return cert, candidate, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("issuer certificate not found in Secret %q", secret.Name)
+}
+
+// CurrentCertificate returns the Secret named by the Certificate's
+// `spec.secretName` and the certificate stored within it. A nil certificate
+// is returned if the Secret does not exist or does not contain a valid
+// certificate.
+func CurrentCertificate(ctx context.Context, secretLister corelisters.SecretLister, crt *cmapi.Certificate) (*corev1.Secret, *x509.Certificate, error) {
+	secret, err := secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(secret.Data[corev1.TLSCertKey]) == 0 {
+		return secret, nil, nil
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to decode certificate stored in secret")
+		return secret, nil, nil
+	}
+
+	return secret, cert, nil
+}
+
+// IssuerRefForSecret returns a reference to the issuer that issued the
+// certificate stored in the given Secret, falling back to the Certificate's
+// issuerRef if the Secret does not record it.
+func IssuerRefForSecret(crt *cmapi.Certificate, secret *corev1.Secret) cmmeta.ObjectReference {
+	name := secret.Annotations[cmapi.IssuerNameAnnotationKey]
+	if name == "" {
+		return crt.Spec.IssuerRef
+	}
+	return cmmeta.ObjectReference{
+		Name:  name,
+		Kind:  secret.Annotations[cmapi.IssuerKindAnnotationKey],
+		Group: secret.Annotations[cmapi.IssuerGroupAnnotationKey],
+	}
+}
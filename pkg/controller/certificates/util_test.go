@@ -18,7 +18,9 @@ package certificates
 
 import (
 	"crypto"
+	"crypto/x509"
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -28,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
@@ -352,3 +355,90 @@ func TestRenewalTime(t *testing.T) {
 		})
 	}
 }
+
+func TestRenewalTimeWithRenewalInfo(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cert := &x509.Certificate{SerialNumber: big.NewInt(0x1234)}
+	window := func(serial string, start, end time.Time) *cmapi.CertificateRenewalInfo {
+		return &cmapi.CertificateRenewalInfo{
+			SerialNumber:         serial,
+			SuggestedWindowStart: metav1.NewTime(start),
+			SuggestedWindowEnd:   metav1.NewTime(end),
+		}
+	}
+	tests := map[string]struct {
+		renewalTime *metav1.Time
+		info        *cmapi.CertificateRenewalInfo
+		expected    *metav1.Time
+	}{
+		"no renewal info": {
+			renewalTime: &metav1.Time{Time: now.Add(time.Hour * 24)},
+			expected:    &metav1.Time{Time: now.Add(time.Hour * 24)},
+		},
+		"renewal info for a different certificate is ignored": {
+			renewalTime: &metav1.Time{Time: now.Add(time.Hour * 24)},
+			info:        window("abcd", now, now.Add(time.Hour)),
+			expected:    &metav1.Time{Time: now.Add(time.Hour * 24)},
+		},
+		"suggested window before the renewal time is used": {
+			renewalTime: &metav1.Time{Time: now.Add(time.Hour * 24)},
+			info:        window("1234", now, now.Add(time.Hour)),
+			// 0x1234 seconds mod 3600 seconds
+			expected: &metav1.Time{Time: now.Add(1060 * time.Second)},
+		},
+		"suggested window after the renewal time is ignored": {
+			renewalTime: &metav1.Time{Time: now.Add(time.Hour)},
+			info:        window("1234", now.Add(time.Hour*24), now.Add(time.Hour*48)),
+			expected:    &metav1.Time{Time: now.Add(time.Hour)},
+		},
+		"empty suggested window uses its start": {
+			renewalTime: &metav1.Time{Time: now.Add(time.Hour * 24)},
+			info:        window("1234", now, now),
+			expected:    &metav1.Time{Time: now},
+		},
+		"invalid suggested window is ignored": {
+			renewalTime: &metav1.Time{Time: now.Add(time.Hour * 24)},
+			info:        window("1234", now.Add(time.Hour), now),
+			expected:    &metav1.Time{Time: now.Add(time.Hour * 24)},
+		},
+	}
+	for n, s := range tests {
+		t.Run(n, func(t *testing.T) {
+			renewalTime := RenewalTimeWithRenewalInfo(s.renewalTime, s.info, cert)
+			if !renewalTime.Time.Equal(s.expected.Time) {
+				t.Errorf("Expected renewal time: %v got: %v", s.expected, renewalTime)
+			}
+		})
+	}
+}
+
+func TestIssuerRefForSecret(t *testing.T) {
+	crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		IssuerRef: cmmeta.ObjectReference{Name: "current", Kind: "Issuer", Group: "cert-manager.io"},
+	}}
+
+	tests := map[string]struct {
+		annotations map[string]string
+		expected    cmmeta.ObjectReference
+	}{
+		"use the issuer recorded on the Secret": {
+			annotations: map[string]string{
+				cmapi.IssuerNameAnnotationKey:  "previous",
+				cmapi.IssuerKindAnnotationKey:  "ClusterIssuer",
+				cmapi.IssuerGroupAnnotationKey: "example.com",
+			},
+			expected: cmmeta.ObjectReference{Name: "previous", Kind: "ClusterIssuer", Group: "example.com"},
+		},
+		"fall back to the Certificate's issuerRef if the Secret does not record an issuer": {
+			expected: crt.Spec.IssuerRef,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}}
+			if got := IssuerRefForSecret(crt, secret); got != test.expected {
+				t.Errorf("unexpected issuer reference, exp=%+v got=%+v", test.expected, got)
+			}
+		})
+	}
+}
@@ -32,9 +32,12 @@ import (
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	experimentalapi "github.com/jetstack/cert-manager/pkg/apis/experimental/v1alpha1"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests"
 	"github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/util"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/policy"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/revocation"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	cmerrors "github.com/jetstack/cert-manager/pkg/util/errors"
 	"github.com/jetstack/cert-manager/pkg/util/kube"
@@ -59,6 +62,10 @@ type CA struct {
 
 	recorder record.EventRecorder
 
+	// revocationStore records the certificates signed by issuers that have
+	// revocation enabled.
+	revocationStore *revocation.Store
+
 	// Used for testing to get reproducible resulting certificates
 	templateGenerator templateGenerator
 	signingFn         signingFn
@@ -79,6 +86,7 @@ func NewCA(ctx *controllerpkg.Context) *CA {
 		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		certClient:        ctx.Client.CertificatesV1().CertificateSigningRequests(),
 		recorder:          ctx.Recorder,
+		revocationStore:   revocation.NewStore(ctx.Client, ctx.Clock),
 		templateGenerator: pki.GenerateTemplateFromCertificateSigningRequest,
 		signingFn:         pki.SignCSRTemplate,
 	}
@@ -95,7 +103,7 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
 	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	caCerts, caKey, err := kube.SecretCAIssuerKeyPair(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA)
 	if apierrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
 		c.recorder.Event(csr, corev1.EventTypeWarning, "SecretMissing", message)
@@ -124,9 +132,26 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 		return err
 	}
 
+	if err := pki.CheckNameConstraints(caCerts[0], template); err != nil {
+		message := fmt.Sprintf("Requested names violate the name constraints of the CA: %s", err)
+		c.recorder.Event(csr, corev1.EventTypeWarning, "NameConstraintsViolation", message)
+		util.CertificateSigningRequestSetFailed(csr, "NameConstraintsViolation", message)
+		_, err = c.certClient.UpdateStatus(ctx, csr, metav1.UpdateOptions{})
+		return err
+	}
+
 	template.CRLDistributionPoints = issuerObj.GetSpec().CA.CRLDistributionPoints
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
 
+	_, durationRequested := csr.Annotations[experimentalapi.CertificateSigningRequestDurationAnnotationKey]
+	warnings, err := policy.Apply(issuerObj.GetSpec().CA, caCerts[0], template, durationRequested)
+	if err != nil {
+		// The issuer will need to be fixed before the request can be signed.
+		message := "Error applying the signing policy of the issuer"
+		c.recorder.Eventf(csr, corev1.EventTypeWarning, "SigningPolicyError", "%s: %s", message, err)
+		return nil
+	}
+
 	bundle, err := c.signingFn(caCerts, caKey, template)
 	if err != nil {
 		message := fmt.Sprintf("Error signing certificate: %s", err)
@@ -136,6 +161,16 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 		return err
 	}
 
+	if rev := issuerObj.GetSpec().CA.Revocation; rev != nil {
+		// Certificates are only returned once they have been recorded, so
+		// that every certificate signed by the issuer can later be revoked.
+		if err := c.recordIssued(ctx, resourceNamespace, rev.SecretName, bundle.ChainPEM); err != nil {
+			message := fmt.Sprintf("Failed to record signed certificate in revocation database %s/%s", resourceNamespace, rev.SecretName)
+			c.recorder.Eventf(csr, corev1.EventTypeWarning, "RevocationRecordError", "%s: %s", message, err)
+			return err
+		}
+	}
+
 	csr.Status.Certificate = bundle.ChainPEM
 	csr, err = c.certClient.UpdateStatus(ctx, csr, metav1.UpdateOptions{})
 	if err != nil {
@@ -144,8 +179,20 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 		return err
 	}
 
+	for _, message := range warnings {
+		c.recorder.Event(csr, corev1.EventTypeWarning, "DurationClamped", message)
+	}
+
 	log.V(logf.DebugLevel).Info("certificate issued")
 	c.recorder.Event(csr, corev1.EventTypeNormal, "CertificateIssued", "Certificate fetched from issuer successfully")
 
 	return nil
 }
+
+func (c *CA) recordIssued(ctx context.Context, namespace, name string, chainPEM []byte) error {
+	cert, err := pki.DecodeX509CertificateBytes(chainPEM)
+	if err != nil {
+		return err
+	}
+	return c.revocationStore.RecordIssued(ctx, namespace, name, cert)
+}
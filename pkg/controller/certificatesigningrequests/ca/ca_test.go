@@ -49,6 +49,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests"
 	"github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/util"
 	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/revocation"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 	"github.com/jetstack/cert-manager/test/unit/gen"
 	testlisters "github.com/jetstack/cert-manager/test/unit/listers"
@@ -85,7 +86,7 @@ func generateSelfSignedCACert(t *testing.T, key crypto.Signer, name string) (*x5
 			CommonName: name,
 		},
 		NotBefore: time.Now(),
-		NotAfter:  time.Now().Add(time.Minute),
+		NotAfter:  time.Now().Add(365 * 24 * time.Hour),
 		KeyUsage:  x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
 		PublicKey: key.Public(),
 		IsCA:      true,
@@ -182,6 +183,24 @@ func TestSign(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	revocationIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerCA(cmapi.CAIssuer{
+			SecretName: "root-ca-secret",
+			Revocation: &cmapi.CARevocation{SecretName: "root-ca-revocations"},
+		}),
+	)
+	issuedCert, err := pki.DecodeX509CertificateBytes(certBundle.ChainPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revocationDB := &revocation.Database{}
+	revocationDB.AddIssued(issuedCert)
+	revocationSecretName := revocation.ShardSecretName("root-ca-revocations", issuedCert.SerialNumber)
+	revocationDBData, err := revocationDB.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := map[string]testT{
 		"a CertificateSigningRequest without an approved condition should fire event": {
 			csr: baseCSRNotApproved.DeepCopy(),
@@ -523,6 +542,72 @@ func TestSign(t *testing.T) {
 				},
 			},
 		},
+		"a successful signing with revocation enabled should record the signed certificate before updating the CertificateSigningRequest": {
+			csr: baseCSR.DeepCopy(),
+			templateGenerator: func(csr *certificatesv1.CertificateSigningRequest) (*x509.Certificate, error) {
+				return template, nil
+			},
+			signingFn: func(_ []*x509.Certificate, _ crypto.Signer, _ *x509.Certificate) (pki.PEMBundle, error) {
+				return pki.PEMBundle{CAPEM: certBundle.CAPEM, ChainPEM: certBundle.ChainPEM}, nil
+			},
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{ecCASecret, baseCSR.DeepCopy()},
+				CertManagerObjects: []runtime.Object{revocationIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						authzv1.SchemeGroupVersion.WithResource("subjectaccessreviews"),
+						"",
+						&authzv1.SubjectAccessReview{
+							Spec: authzv1.SubjectAccessReviewSpec{
+								User:   "user-1",
+								Groups: []string{"group-1", "group-2"},
+								Extra: map[string]authzv1.ExtraValue{
+									"extra": []string{"1", "2"},
+								},
+								UID: "uid-1",
+
+								ResourceAttributes: &authzv1.ResourceAttributes{
+									Group:     certmanager.GroupName,
+									Resource:  "signers",
+									Verb:      "reference",
+									Namespace: baseIssuer.Namespace,
+									Name:      baseIssuer.Name,
+									Version:   "*",
+								},
+							},
+						},
+					)),
+					testpkg.NewAction(coretesting.NewGetAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						revocationSecretName,
+					)),
+					testpkg.NewAction(coretesting.NewCreateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      revocationSecretName,
+								Namespace: gen.DefaultTestNamespace,
+							},
+							Type: corev1.SecretTypeOpaque,
+							Data: map[string][]byte{revocation.DatabaseKey: revocationDBData},
+						},
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
+						"status",
+						"",
+						gen.CertificateSigningRequestFrom(baseCSR,
+							gen.SetCertificateSigningRequestCertificate(certBundle.ChainPEM),
+						),
+					)),
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package est
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	certificatesclient "k8s.io/client-go/kubernetes/typed/certificates/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests"
+	"github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/util"
+	internalest "github.com/jetstack/cert-manager/pkg/internal/est"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	CSRControllerName = "certificatesigningrequests-issuer-est"
+)
+
+// EST is a controller for signing Kubernetes CertificateSigningRequest
+// using EST Issuers.
+type EST struct {
+	issuerOptions controllerpkg.IssuerOptions
+	secretsLister corelisters.SecretLister
+
+	recorder record.EventRecorder
+
+	certClient    certificatesclient.CertificateSigningRequestInterface
+	clientBuilder internalest.ClientBuilder
+}
+
+func init() {
+	controllerpkg.Register(CSRControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CSRControllerName).
+			For(certificatesigningrequests.New(apiutil.IssuerEST, NewEST(ctx))).
+			Complete()
+	})
+}
+
+func NewEST(ctx *controllerpkg.Context) *EST {
+	return &EST{
+		issuerOptions: ctx.IssuerOptions,
+		secretsLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		recorder:      ctx.Recorder,
+		certClient:    ctx.Client.CertificatesV1().CertificateSigningRequests(),
+		clientBuilder: internalest.New,
+	}
+}
+
+// Sign attempts to sign the given CertificateSigningRequest based on the
+// provided EST Issuer or ClusterIssuer. This function updates the
+// CertificateSigningRequest resource if signing was successful. Returns an
+// error which, if not nil, should trigger a retry.
+// CertificateSigningRequests are always enrolled using simpleenroll, since
+// there is no existing certificate to re-enroll with.
+func (e *EST) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, issuerObj cmapi.GenericIssuer) error {
+	log := logf.FromContext(ctx, "sign")
+	log = logf.WithRelatedResource(log, issuerObj)
+
+	resourceNamespace := e.issuerOptions.ResourceNamespace(issuerObj)
+
+	client, err := e.clientBuilder(resourceNamespace, e.secretsLister, issuerObj)
+	if apierrors.IsNotFound(err) {
+		message := "Required secret resource not found"
+		log.Error(err, message)
+		e.recorder.Event(csr, corev1.EventTypeWarning, "SecretNotFound", message)
+		util.CertificateSigningRequestSetFailed(csr, "SecretNotFound", message)
+		_, err := e.certClient.UpdateStatus(ctx, csr, metav1.UpdateOptions{})
+		return err
+	}
+
+	if err != nil {
+		message := fmt.Sprintf("Failed to initialise EST client for signing: %s", err)
+		log.Error(err, message)
+		e.recorder.Event(csr, corev1.EventTypeWarning, "ErrorESTInit", message)
+		return err
+	}
+
+	certPEM, err := client.SimpleEnroll(ctx, csr.Spec.Request)
+
+	var pendingErr *internalest.PendingError
+	if errors.As(err, &pendingErr) {
+		message := fmt.Sprintf("EST server has not issued the certificate yet: %s", err)
+		log.V(logf.InfoLevel).Info(message)
+		e.recorder.Event(csr, corev1.EventTypeNormal, "EnrollmentPending", message)
+		if pendingErr.RetryAfter > 0 {
+			return &issuer.RetryAfterError{Err: err, RetryAfter: pendingErr.RetryAfter}
+		}
+		return err
+	}
+
+	if err != nil {
+		message := fmt.Sprintf("EST server failed to sign: %s", err)
+		log.Error(err, message)
+		e.recorder.Event(csr, corev1.EventTypeWarning, "ErrorSigning", message)
+		util.CertificateSigningRequestSetFailed(csr, "ErrorSigning", message)
+		_, err := e.certClient.UpdateStatus(ctx, csr, metav1.UpdateOptions{})
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("certificate issued")
+
+	csr.Status.Certificate = certPEM
+	csr, err = e.certClient.UpdateStatus(ctx, csr, metav1.UpdateOptions{})
+	if err != nil {
+		message := "Error updating certificate"
+		e.recorder.Eventf(csr, corev1.EventTypeWarning, "ErrorUpdate", "%s: %s", message, err)
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("est certificate issued")
+	e.recorder.Event(csr, corev1.EventTypeNormal, "CertificateIssued", "Certificate signed successfully")
+
+	return nil
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package est
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	authzv1 "k8s.io/api/authorization/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests"
+	"github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/util"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	internalest "github.com/jetstack/cert-manager/pkg/internal/est"
+	fakeest "github.com/jetstack/cert-manager/pkg/internal/est/fake"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+func TestProcessItem(t *testing.T) {
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	util.Clock = fixedClock
+
+	baseIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerEST(cmapi.ESTIssuer{
+			Server: "https://est.example.com",
+		}),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+
+	csrPEM, _, err := gen.CSR(x509.RSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseCSR := gen.CertificateSigningRequest("test-cr",
+		gen.SetCertificateSigningRequestRequest(csrPEM),
+		gen.SetCertificateSigningRequestSignerName("issuers.cert-manager.io/default-unit-test-ns.test-issuer"),
+		gen.SetCertificateSigningRequestUsername("user-1"),
+		gen.SetCertificateSigningRequestGroups([]string{"group-1", "group-2"}),
+		gen.SetCertificateSigningRequestUID("uid-1"),
+	)
+	approvedCSR := gen.CertificateSigningRequestFrom(baseCSR,
+		gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+			Type:   certificatesv1.CertificateApproved,
+			Status: corev1.ConditionTrue,
+		}),
+	)
+
+	sarAction := testpkg.NewAction(coretesting.NewCreateAction(
+		authzv1.SchemeGroupVersion.WithResource("subjectaccessreviews"),
+		"",
+		&authzv1.SubjectAccessReview{
+			Spec: authzv1.SubjectAccessReviewSpec{
+				User:   "user-1",
+				Groups: []string{"group-1", "group-2"},
+				Extra:  map[string]authzv1.ExtraValue{},
+				UID:    "uid-1",
+
+				ResourceAttributes: &authzv1.ResourceAttributes{
+					Group:     certmanager.GroupName,
+					Resource:  "signers",
+					Verb:      "reference",
+					Namespace: baseIssuer.Namespace,
+					Name:      baseIssuer.Name,
+					Version:   "*",
+				},
+			},
+		},
+	))
+	statusUpdate := func(mods ...gen.CertificateSigningRequestModifier) testpkg.Action {
+		return testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+			certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
+			"status",
+			"",
+			gen.CertificateSigningRequestFrom(approvedCSR, mods...),
+		))
+	}
+
+	tests := map[string]struct {
+		builder       *testpkg.Builder
+		csr           *certificatesv1.CertificateSigningRequest
+		clientBuilder internalest.ClientBuilder
+		expectedErr   bool
+	}{
+		"a CertificateSigningRequest without an approved condition should fire an event": {
+			csr: gen.CertificateSigningRequestFrom(baseCSR),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal WaitingApproval Waiting for the Approved condition before issuing",
+				},
+			},
+		},
+		"an approved CSR where the est client builder returns a not found error should mark as Failed": {
+			csr: approvedCSR.DeepCopy(),
+			clientBuilder: func(_ string, _ corelisters.SecretLister, _ cmapi.GenericIssuer) (internalest.Interface, error) {
+				return nil, apierrors.NewNotFound(schema.GroupResource{}, "test-secret")
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning SecretNotFound Required secret resource not found",
+				},
+				ExpectedActions: []testpkg.Action{
+					sarAction,
+					statusUpdate(gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+						Type:               certificatesv1.CertificateFailed,
+						Status:             corev1.ConditionTrue,
+						Reason:             "SecretNotFound",
+						Message:            "Required secret resource not found",
+						LastTransitionTime: metaFixedClockStart,
+						LastUpdateTime:     metaFixedClockStart,
+					})),
+				},
+			},
+		},
+		"an approved CSR where the est client builder returns a generic error should return error to retry": {
+			csr: approvedCSR.DeepCopy(),
+			clientBuilder: func(_ string, _ corelisters.SecretLister, _ cmapi.GenericIssuer) (internalest.Interface, error) {
+				return nil, errors.New("generic error")
+			},
+			expectedErr: true,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning ErrorESTInit Failed to initialise EST client for signing: generic error",
+				},
+				ExpectedActions: []testpkg.Action{sarAction},
+			},
+		},
+		"an approved CSR where the enrollment is pending should be retried after the requested delay": {
+			csr: approvedCSR.DeepCopy(),
+			clientBuilder: func(_ string, _ corelisters.SecretLister, _ cmapi.GenericIssuer) (internalest.Interface, error) {
+				return fakeest.New().WithSimpleEnroll(nil, &internalest.PendingError{RetryAfter: time.Minute}), nil
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal EnrollmentPending EST server has not issued the certificate yet: certificate enrollment is pending, retry after 1m0s",
+				},
+				ExpectedActions: []testpkg.Action{sarAction},
+			},
+		},
+		"an approved CSR where the enrollment is pending without a retry delay should return error to retry": {
+			csr: approvedCSR.DeepCopy(),
+			clientBuilder: func(_ string, _ corelisters.SecretLister, _ cmapi.GenericIssuer) (internalest.Interface, error) {
+				return fakeest.New().WithSimpleEnroll(nil, &internalest.PendingError{}), nil
+			},
+			expectedErr: true,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal EnrollmentPending EST server has not issued the certificate yet: certificate enrollment is pending, retry after 0s",
+				},
+				ExpectedActions: []testpkg.Action{sarAction},
+			},
+		},
+		"an approved CSR where the enrollment fails should be marked as Failed": {
+			csr: approvedCSR.DeepCopy(),
+			clientBuilder: func(_ string, _ corelisters.SecretLister, _ cmapi.GenericIssuer) (internalest.Interface, error) {
+				return fakeest.New().WithSimpleEnroll(nil, errors.New("bad request")), nil
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning ErrorSigning EST server failed to sign: bad request",
+				},
+				ExpectedActions: []testpkg.Action{
+					sarAction,
+					statusUpdate(gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+						Type:               certificatesv1.CertificateFailed,
+						Status:             corev1.ConditionTrue,
+						Reason:             "ErrorSigning",
+						Message:            "EST server failed to sign: bad request",
+						LastTransitionTime: metaFixedClockStart,
+						LastUpdateTime:     metaFixedClockStart,
+					})),
+				},
+			},
+		},
+		"an approved CSR which is enrolled should have its certificate set": {
+			csr: approvedCSR.DeepCopy(),
+			clientBuilder: func(_ string, _ corelisters.SecretLister, _ cmapi.GenericIssuer) (internalest.Interface, error) {
+				return fakeest.New().WithSimpleEnroll([]byte("signed-cert"), nil), nil
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate signed successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					sarAction,
+					statusUpdate(gen.SetCertificateSigningRequestCertificate([]byte("signed-cert"))),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.csr != nil {
+				test.builder.KubeObjects = append(test.builder.KubeObjects, test.csr)
+			}
+
+			fixedClock.SetTime(fixedClockStart)
+			test.builder.Clock = fixedClock
+			test.builder.T = t
+			test.builder.Init()
+
+			// Always return true for SubjectAccessReviews in tests
+			test.builder.FakeKubeClient().PrependReactor("create", "*", func(action coretesting.Action) (bool, runtime.Object, error) {
+				if action.GetResource() != authzv1.SchemeGroupVersion.WithResource("subjectaccessreviews") {
+					return false, nil, nil
+				}
+				return true, &authzv1.SubjectAccessReview{
+					Status: authzv1.SubjectAccessReviewStatus{
+						Allowed: true,
+					},
+				}, nil
+			})
+
+			defer test.builder.Stop()
+
+			est := NewEST(test.builder.Context)
+			est.clientBuilder = test.clientBuilder
+
+			controller := certificatesigningrequests.New(apiutil.IssuerEST, est)
+			controller.Register(test.builder.Context)
+			test.builder.Start()
+
+			err := controller.ProcessItem(context.Background(), test.csr.Name)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+
+			test.builder.CheckAndFinish(err)
+		})
+	}
+}
@@ -18,6 +18,7 @@ package certificatesigningrequests
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	authzv1 "k8s.io/api/authorization/v1"
@@ -31,6 +32,7 @@ import (
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	"github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/util"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
 
@@ -139,7 +141,17 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 
 	dbg.Info("invoking sign function as existing certificate does not exist")
 
-	return c.signer.Sign(ctx, csr, issuerObj)
+	err = c.signer.Sign(ctx, csr, issuerObj)
+	var retryErr *issuer.RetryAfterError
+	if errors.As(err, &retryErr) {
+		key, keyErr := keyFunc(csr)
+		if keyErr == nil {
+			dbg.Info("issuer asked to retry the certificate signing request later", "retry_after", retryErr.RetryAfter)
+			c.queue.AddAfter(key, retryErr.RetryAfter)
+			return nil
+		}
+	}
+	return err
 }
 
 // userCanReferenceSigner will return true if the CSR requester has a bound
@@ -26,12 +26,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	certificatesclient "k8s.io/client-go/kubernetes/typed/certificates/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	experimentalapi "github.com/jetstack/cert-manager/pkg/apis/experimental/v1alpha1"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests"
 	"github.com/jetstack/cert-manager/pkg/controller/certificatesigningrequests/util"
@@ -50,6 +52,7 @@ type signingFn func(*x509.Certificate, *x509.Certificate, crypto.PublicKey, inte
 // using Vault Issuers.
 type Vault struct {
 	issuerOptions controllerpkg.IssuerOptions
+	kubeClient    kubernetes.Interface
 	secretsLister corelisters.SecretLister
 
 	recorder record.EventRecorder
@@ -69,10 +72,11 @@ func init() {
 func NewVault(ctx *controllerpkg.Context) *Vault {
 	return &Vault{
 		issuerOptions: ctx.IssuerOptions,
+		kubeClient:    ctx.Client,
 		secretsLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		recorder:      ctx.Recorder,
 		certClient:    ctx.Client.CertificatesV1().CertificateSigningRequests(),
-		clientBuilder: internalvault.New,
+		clientBuilder: ctx.VaultTokenCache.New,
 	}
 }
 
@@ -86,7 +90,8 @@ func (v *Vault) Sign(ctx context.Context, csr *certificatesv1.CertificateSigning
 
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
-	client, err := v.clientBuilder(resourceNamespace, v.secretsLister, issuerObj)
+	createToken := v.kubeClient.CoreV1().ServiceAccounts(resourceNamespace).CreateToken
+	client, err := v.clientBuilder(ctx, resourceNamespace, createToken, v.secretsLister, issuerObj)
 	if apierrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 		log.Error(err, message)
@@ -113,7 +118,7 @@ func (v *Vault) Sign(ctx context.Context, csr *certificatesv1.CertificateSigning
 		return err
 	}
 
-	certPEM, _, err := client.Sign(csr.Spec.Request, duration)
+	certPEM, _, err := client.Sign(ctx, csr.Spec.Request, duration, csr.Annotations[experimentalapi.CertificateSigningRequestVaultRoleAnnotationKey])
 	if err != nil {
 		message := fmt.Sprintf("Vault failed to sign: %s", err)
 		log.Error(err, message)
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -49,7 +50,7 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 				}
 			}
 		case iss.Spec.CA != nil:
-			if iss.Spec.CA.SecretName == secret.Name {
+			if kube.CAIssuerUsesSecret(iss.Spec.CA, secret.Name) {
 				affected = append(affected, iss)
 				continue
 			}
@@ -66,6 +67,39 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 					continue
 				}
 			}
+		case iss.Spec.EST != nil:
+			if iss.Spec.EST.Auth != nil && iss.Spec.EST.Auth.BasicAuth != nil {
+				if iss.Spec.EST.Auth.BasicAuth.PasswordSecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
+			if iss.Spec.EST.Auth != nil && iss.Spec.EST.Auth.ClientCertificate != nil {
+				if iss.Spec.EST.Auth.ClientCertificate.SecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
+		case iss.Spec.SCEP != nil:
+			if iss.Spec.SCEP.ChallengePasswordSecretRef != nil {
+				if iss.Spec.SCEP.ChallengePasswordSecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
+		case iss.Spec.CMP != nil:
+			if iss.Spec.CMP.SharedSecret != nil {
+				if iss.Spec.CMP.SharedSecret.SecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
+			if iss.Spec.CMP.Signature != nil {
+				if iss.Spec.CMP.Signature.SecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		case iss.Spec.Vault != nil:
 			if iss.Spec.Vault.Auth.TokenSecretRef != nil {
 				if iss.Spec.Vault.Auth.TokenSecretRef.Name == secret.Name {
@@ -85,6 +119,12 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 					continue
 				}
 			}
+			if iss.Spec.Vault.Auth.ClientCertificate != nil {
+				if iss.Spec.Vault.Auth.ClientCertificate.SecretName == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		}
 	}
 
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/errors"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
 
@@ -42,6 +43,19 @@ func (c *controller) Sync(ctx context.Context, iss *cmapi.ClusterIssuer) (err er
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
+	if iss.DeletionTimestamp != nil {
+		return c.finalize(ctx, iss)
+	}
+
+	// add or remove the finalizer used to deactivate the issuer's account
+	// when it is deleted, and wait for the update to be observed
+	if wants := issuer.WantsAccountDeactivation(iss); wants != issuer.HasAccountDeactivationFinalizer(iss) {
+		issuerCopy := iss.DeepCopy()
+		issuer.SetAccountDeactivationFinalizer(issuerCopy, wants)
+		_, err := c.cmClient.CertmanagerV1().ClusterIssuers().Update(ctx, issuerCopy, metav1.UpdateOptions{})
+		return err
+	}
+
 	issuerCopy := iss.DeepCopy()
 	defer func() {
 		if _, saveErr := c.updateIssuerStatus(ctx, iss, issuerCopy); saveErr != nil {
@@ -65,6 +79,25 @@ func (c *controller) Sync(ctx context.Context, iss *cmapi.ClusterIssuer) (err er
 	return nil
 }
 
+// finalize deactivates the account of a deleted ClusterIssuer if requested, and
+// then removes the account deactivation finalizer.
+func (c *controller) finalize(ctx context.Context, iss *cmapi.ClusterIssuer) error {
+	if !issuer.HasAccountDeactivationFinalizer(iss) {
+		return nil
+	}
+
+	if issuer.WantsAccountDeactivation(iss) {
+		if err := issuer.DeactivateAccount(ctx, c.issuerFactory, c.recorder, iss); err != nil {
+			return err
+		}
+	}
+
+	issuerCopy := iss.DeepCopy()
+	issuer.SetAccountDeactivationFinalizer(issuerCopy, false)
+	_, err := c.cmClient.CertmanagerV1().ClusterIssuers().Update(ctx, issuerCopy, metav1.UpdateOptions{})
+	return err
+}
+
 func (c *controller) updateIssuerStatus(ctx context.Context, old, new *cmapi.ClusterIssuer) (*cmapi.ClusterIssuer, error) {
 	if apiequality.Semantic.DeepEqual(old.Status, new.Status) {
 		return nil, nil
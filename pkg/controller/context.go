@@ -33,6 +33,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/acme/accounts"
 	clientset "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	informers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+	"github.com/jetstack/cert-manager/pkg/internal/vault"
 	"github.com/jetstack/cert-manager/pkg/metrics"
 )
 
@@ -84,11 +85,22 @@ type Context struct {
 	// Metrics is used for exposing Prometheus metrics across the controllers
 	Metrics *metrics.Metrics
 
+	// VaultTokenCache is used as a cache of Vault tokens between the various
+	// controllers that sign using Vault issuers
+	VaultTokenCache *vault.TokenCache
+
 	IssuerOptions
 	ACMEOptions
 	IngressShimOptions
 	CertificateOptions
 	SchedulerOptions
+	ApprovalOptions
+}
+
+// NewVaultTokenCache returns a new cache of Vault tokens to be shared between
+// the controllers that sign using Vault issuers.
+func NewVaultTokenCache(clock clock.Clock, metrics *metrics.Metrics) *vault.TokenCache {
+	return vault.NewTokenCache(clock, metrics)
 }
 
 type IssuerOptions struct {
@@ -156,6 +168,13 @@ type CertificateOptions struct {
 	// CopiedAnnotationPrefixes defines which annotations should be copied
 	// Certificate -> CertificateRequest, CertificateRequest -> Order.
 	CopiedAnnotationPrefixes []string
+	// IssuerCARotationReissueWindow is the duration over which the
+	// re-issuance of certificates is spread out after the CA of their
+	// issuer has changed.
+	IssuerCARotationReissueWindow time.Duration
+	// IssuerCARotationReissueQPS is the maximum number of certificates per
+	// second that are re-issued because the CA of their issuer has changed.
+	IssuerCARotationReissueQPS float32
 }
 
 type SchedulerOptions struct {
@@ -163,3 +182,18 @@ type SchedulerOptions struct {
 	// scheduled as 'processing' at once.
 	MaxConcurrentChallenges int
 }
+
+type ApprovalOptions struct {
+	// WebhookURL is the URL that CertificateRequestReviews are POSTed to for
+	// the approval of CertificateRequests. No webhook is used if empty.
+	WebhookURL string
+	// WebhookCABundle is a PEM encoded bundle of CA certificates used to
+	// verify the serving certificate of the approval webhook. The system
+	// trust store is used if empty.
+	WebhookCABundle []byte
+	// WebhookTimeout is the timeout of requests to the approval webhook.
+	WebhookTimeout time.Duration
+	// WebhookPollInterval is the interval at which CertificateRequests are
+	// sent to the approval webhook again while their review is pending.
+	WebhookPollInterval time.Duration
+}
@@ -40,6 +40,25 @@ const (
 	// ExperimentalGatewayAPISupport enables the gateway-shim controller and adds support for
 	// the Gateway API to the HTTP-01 challenge solver.
 	ExperimentalGatewayAPISupport featuregate.Feature = "ExperimentalGatewayAPISupport"
+
+	// alpha: v1.6.0
+	//
+	// ReissueOnIssuerCARotation enables re-issuance of certificates issued by
+	// CA issuers whose signing CA has changed since they were issued.
+	ReissueOnIssuerCARotation featuregate.Feature = "ReissueOnIssuerCARotation"
+
+	// alpha: v1.6.0
+	//
+	// CertificateRequestPolicies enables the approval and denial of
+	// CertificateRequests based on CertificateRequestPolicy resources.
+	CertificateRequestPolicies featuregate.Feature = "CertificateRequestPolicies"
+
+	// alpha: v1.6.0
+	//
+	// ACMERenewalInfo enables retrieving the renewal window suggested by ACME
+	// servers implementing the ACME Renewal Information (ARI) extension, and
+	// renewing certificates within it.
+	ACMERenewalInfo featuregate.Feature = "ACMERenewalInfo"
 )
 
 func init() {
@@ -53,4 +72,7 @@ var defaultCertManagerFeatureGates = map[featuregate.Feature]featuregate.Feature
 	ValidateCAA: {Default: false, PreRelease: featuregate.Alpha},
 	ExperimentalCertificateSigningRequestControllers: {Default: false, PreRelease: featuregate.Alpha},
 	ExperimentalGatewayAPISupport:                    {Default: false, PreRelease: featuregate.Alpha},
+	ReissueOnIssuerCARotation:                        {Default: false, PreRelease: featuregate.Alpha},
+	CertificateRequestPolicies:                       {Default: false, PreRelease: featuregate.Alpha},
+	ACMERenewalInfo:                                  {Default: false, PreRelease: featuregate.Alpha},
 }
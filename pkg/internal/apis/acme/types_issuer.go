@@ -48,6 +48,13 @@ type ACMEIssuer struct {
 	// "DST Root CA X3" or "ISRG Root X1" for the newer Let's Encrypt root CA.
 	PreferredChain string
 
+	// ChainSelection configures how the certificate chain is selected among
+	// the chains offered by the ACME server, matching certificates by their
+	// fingerprints rather than by common name only.
+	// If PreferredChain is also set, it is only considered after the
+	// preferred chains configured here.
+	ChainSelection *ACMEChainSelection
+
 	// Enables or disables validation of the ACME server TLS certificate.
 	// If true, requests to the ACME server will not have their TLS certificate
 	// validated (i.e. insecure connections will be allowed).
@@ -70,6 +77,16 @@ type ACMEIssuer struct {
 	// If `key` is not specified, a default of `tls.key` will be used.
 	PrivateKey cmmeta.SecretKeySelector
 
+	// NextPrivateKey is a reference to a key in a Secret resource containing
+	// the private key that the ACME account should be changed to.
+	// If set and different from the key referenced by `privateKeySecretRef`,
+	// the account key is rolled over with the ACME server using the RFC 8555
+	// keyChange flow and the new key is then written to the Secret referenced
+	// by `privateKeySecretRef`, so that the ACME account and its URI are kept.
+	// The field can be removed once the rollover has completed.
+	// Only RSA keys are supported.
+	NextPrivateKey *cmmeta.SecretKeySelector
+
 	// Solvers is a list of challenge solvers that will be used to solve
 	// ACME challenges for the matching domains.
 	// Solver configurations must be provided in order to obtain certificates
@@ -85,6 +102,14 @@ type ACMEIssuer struct {
 	// Defaults to false.
 	DisableAccountKeyGeneration bool
 
+	// DeactivateAccountOnDeletion enables deactivating the ACME account with
+	// the ACME server when the Issuer is deleted. If true, a finalizer is
+	// added to the Issuer so that the account can be deactivated before the
+	// Issuer is removed. A deactivated account cannot be used again, so this
+	// should not be enabled if the account key is shared with other issuers.
+	// Defaults to false.
+	DeactivateAccountOnDeletion bool
+
 	// Enables requesting a Not After date on certificates that matches the
 	// duration of the certificate. This is not supported by all ACME servers
 	// like Let's Encrypt. If set to true when the ACME server does not support
@@ -93,6 +118,43 @@ type ACMEIssuer struct {
 	EnableDurationFeature bool
 }
 
+// ACMEChainSelection configures how the certificate chain is selected among
+// the default chain and the alternate chains offered by the ACME server.
+type ACMEChainSelection struct {
+	// Preferred is a list of selectors, in order of preference. The chains
+	// containing a certificate matching the first selector that matches any
+	// of the offered chains are preferred over the other chains.
+	Preferred []ACMEChainCertificateSelector
+
+	// Exclude is a list of selectors. Chains containing a certificate
+	// matching any of these selectors are never selected. If all chains
+	// offered by the ACME server are excluded, the Order fails.
+	Exclude []ACMEChainCertificateSelector
+
+	// PreferShortest selects the chain with the fewest certificates among the
+	// remaining chains. Otherwise, the first remaining chain in the order
+	// offered by the ACME server, starting with its default chain, is selected.
+	PreferShortest bool
+}
+
+// ACMEChainCertificateSelector matches certificates of the chains offered by
+// the ACME server. Exactly one field must be set.
+type ACMEChainCertificateSelector struct {
+	// CommonName matches certificates with this subject common name,
+	// including the root certificate that the last certificate served is
+	// issued by.
+	CommonName string
+
+	// SHA256 matches the certificate with this hex encoded SHA-256
+	// fingerprint of its DER encoding.
+	SHA256 string
+
+	// SPKISHA256 matches certificates with this hex encoded SHA-256 hash of
+	// their DER encoded SubjectPublicKeyInfo. Unlike the certificate
+	// fingerprint, it also matches certificates re-issued for the same key.
+	SPKISHA256 string
+}
+
 // ACMEExternalAccountBinding is a reference to a CA external account of the ACME
 // server.
 type ACMEExternalAccountBinding struct {
@@ -143,6 +205,12 @@ type ACMEChallengeSolver struct {
 	// Configures cert-manager to attempt to complete authorizations by
 	// performing the DNS01 challenge flow.
 	DNS01 *ACMEChallengeSolverDNS01
+
+	// Configures cert-manager to attempt to complete authorizations by
+	// performing the TLS-ALPN-01 challenge flow (RFC 8737).
+	// It is not possible to obtain certificates for wildcard domain names
+	// (e.g. `*.example.com`) using the TLS-ALPN-01 challenge mechanism.
+	TLSALPN01 *ACMEChallengeSolverTLSALPN01
 }
 
 // CertificateDomainSelector selects certificates using a label selector, and
@@ -290,6 +358,140 @@ type ACMEChallengeSolverHTTP01IngressObjectMeta struct {
 	Labels map[string]string
 }
 
+// ACMEChallengeSolverTLSALPN01 contains configuration detailing how to solve
+// TLS-ALPN-01 challenges within a Kubernetes cluster.
+// 'Solver pods' answer TLS connections negotiating the `acme-tls/1`
+// application protocol with the challenge certificate. They are exposed
+// through a Service, to which traffic for the domain on port 443 can be
+// routed by a Gateway TLSRoute or an Ingress with SNI passthrough.
+// Only one of GatewayTLSRoute / SNIPassthrough can be specified.
+type ACMEChallengeSolverTLSALPN01 struct {
+	// Optional configuration of the Service exposing the solver pods.
+	Service *ACMEChallengeSolverTLSALPN01Service
+
+	// The Gateway TLSRoute solver will create TLSRoutes with the specified
+	// labels in the same namespace as the challenge, passing TLS connections
+	// for the challenge domain through to the solver Service.
+	// This solver is experimental, and fields / behaviour may change in the future.
+	GatewayTLSRoute *ACMEChallengeSolverTLSALPN01GatewayTLSRoute
+
+	// The SNI passthrough solver will create Ingress resources for the
+	// challenge domain that route to the solver Service, for ingress
+	// controllers that can pass TLS connections through based on their SNI.
+	SNIPassthrough *ACMEChallengeSolverTLSALPN01SNIPassthrough
+
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for TLS-ALPN-01 challenges.
+	PodTemplate *ACMEChallengeSolverTLSALPN01PodTemplate
+}
+
+// ACMEChallengeSolverTLSALPN01Service configures the Service exposing
+// TLS-ALPN-01 solver pods.
+type ACMEChallengeSolverTLSALPN01Service struct {
+	// Optional service type for Kubernetes solver service. Supported values
+	// are LoadBalancer, NodePort (default) or ClusterIP.
+	Type corev1.ServiceType
+
+	// The port the Service exposes the solver pods on. ACME servers always
+	// connect to port 443, so this only needs to be changed when traffic
+	// is forwarded to the Service on another port. Defaults to 443.
+	Port int32
+
+	// Annotations that should be added to the created ACME TLS-ALPN-01 solver
+	// Service, e.g. to configure a cloud load balancer.
+	Annotations map[string]string
+
+	// Labels that should be added to the created ACME TLS-ALPN-01 solver
+	// Service.
+	Labels map[string]string
+}
+
+// The ACMEChallengeSolverTLSALPN01GatewayTLSRoute solver will create TLSRoute
+// objects for a Gateway class routing to an ACME challenge solver pod.
+type ACMEChallengeSolverTLSALPN01GatewayTLSRoute struct {
+	// The labels that cert-manager will use when creating the temporary
+	// TLSRoute needed for solving the TLS-ALPN-01 challenge. These labels
+	// must match the label selector of at least one Gateway with a TLS
+	// passthrough listener on port 443.
+	Labels map[string]string
+}
+
+// The ACMEChallengeSolverTLSALPN01SNIPassthrough solver will create Ingress
+// resources routing TLS connections for the challenge domain to an ACME
+// challenge solver pod, relying on the ingress controller passing them
+// through without terminating TLS.
+type ACMEChallengeSolverTLSALPN01SNIPassthrough struct {
+	// The ingress class to use when creating Ingress resources to solve ACME
+	// challenges that use this challenge solver.
+	Class *string
+
+	// Optional ingress template used to configure the ACME challenge solver
+	// ingress used for TLS-ALPN-01 challenges. It must contain the
+	// annotations enabling SNI passthrough for the ingress controller, e.g.
+	// `nginx.ingress.kubernetes.io/ssl-passthrough: "true"` for
+	// ingress-nginx.
+	IngressTemplate *ACMEChallengeSolverTLSALPN01IngressTemplate
+}
+
+type ACMEChallengeSolverTLSALPN01PodTemplate struct {
+	// ObjectMeta overrides for the pod used to solve TLS-ALPN-01 challenges.
+	// Only the 'labels' and 'annotations' fields may be set.
+	// If labels or annotations overlap with in-built values, the values here
+	// will override the in-built values.
+	ACMEChallengeSolverTLSALPN01PodObjectMeta
+
+	// PodSpec defines overrides for the TLS-ALPN-01 challenge solver pod.
+	// Only the 'priorityClassName', 'nodeSelector', 'affinity',
+	// 'serviceAccountName' and 'tolerations' fields are supported currently.
+	// All other fields will be ignored.
+	// +optional
+	Spec ACMEChallengeSolverTLSALPN01PodSpec
+}
+
+type ACMEChallengeSolverTLSALPN01PodObjectMeta struct {
+	// Annotations that should be added to the created ACME TLS-ALPN-01 solver pods.
+	Annotations map[string]string
+
+	// Labels that should be added to the created ACME TLS-ALPN-01 solver pods.
+	Labels map[string]string
+}
+
+type ACMEChallengeSolverTLSALPN01PodSpec struct {
+	// NodeSelector is a selector which must be true for the pod to fit on a node.
+	// Selector which must match a node's labels for the pod to be scheduled on that node.
+	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
+	NodeSelector map[string]string
+
+	// If specified, the pod's scheduling constraints
+	Affinity *corev1.Affinity
+
+	// If specified, the pod's tolerations.
+	Tolerations []corev1.Toleration
+
+	// If specified, the pod's priorityClassName.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// If specified, the pod's service account
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+type ACMEChallengeSolverTLSALPN01IngressTemplate struct {
+	// ObjectMeta overrides for the ingress used to solve TLS-ALPN-01 challenges.
+	// Only the 'labels' and 'annotations' fields may be set.
+	// If labels or annotations overlap with in-built values, the values here
+	// will override the in-built values.
+	ACMEChallengeSolverTLSALPN01IngressObjectMeta
+}
+
+type ACMEChallengeSolverTLSALPN01IngressObjectMeta struct {
+	// Annotations that should be added to the created ACME TLS-ALPN-01 solver ingress.
+	Annotations map[string]string
+
+	// Labels that should be added to the created ACME TLS-ALPN-01 solver ingress.
+	Labels map[string]string
+}
+
 // Used to configure a DNS01 challenge provider to be used when solving DNS01
 // challenges.
 // Only one DNS provider may be configured per solver.
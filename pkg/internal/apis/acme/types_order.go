@@ -109,6 +109,43 @@ type OrderStatus struct {
 	// FailureTime stores the time that this order failed.
 	// This is used to influence garbage collection and back-off.
 	FailureTime *metav1.Time
+
+	// RateLimitedUntil is set while requests for this Order are rejected by the
+	// ACME server due to rate limiting. It stores the time until which the
+	// Order will not be retried.
+	RateLimitedUntil *metav1.Time
+
+	// SelectedChain describes the certificate chain selected among the chains
+	// offered by the ACME server. It is only set if the issuer configures
+	// chain selection.
+	SelectedChain *ACMESelectedChain
+}
+
+// ACMESelectedChain describes the certificate chain selected among the chains
+// offered by the ACME server for an Order.
+type ACMESelectedChain struct {
+	// Index is the position of the selected chain among the chains offered
+	// by the ACME server, 0 being its default chain and the alternate chains
+	// following in the order they were offered.
+	Index int
+
+	// Certificates describes the certificates of the selected chain following
+	// the issued certificate, in the order they were served.
+	Certificates []ACMEChainCertificate
+}
+
+// ACMEChainCertificate identifies a certificate of a certificate chain.
+type ACMEChainCertificate struct {
+	// CommonName is the subject common name of the certificate.
+	CommonName string
+
+	// SHA256 is the hex encoded SHA-256 fingerprint of the DER encoded
+	// certificate.
+	SHA256 string
+
+	// SPKISHA256 is the hex encoded SHA-256 hash of the DER encoded
+	// SubjectPublicKeyInfo of the certificate.
+	SPKISHA256 string
 }
 
 // ACMEAuthorization contains data returned from the ACME server on an
@@ -52,6 +52,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChainCertificate)(nil), (*acme.ACMEChainCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChainCertificate_To_acme_ACMEChainCertificate(a.(*v1beta1.ACMEChainCertificate), b.(*acme.ACMEChainCertificate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChainCertificate)(nil), (*v1beta1.ACMEChainCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChainCertificate_To_v1beta1_ACMEChainCertificate(a.(*acme.ACMEChainCertificate), b.(*v1beta1.ACMEChainCertificate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChainCertificateSelector)(nil), (*acme.ACMEChainCertificateSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChainCertificateSelector_To_acme_ACMEChainCertificateSelector(a.(*v1beta1.ACMEChainCertificateSelector), b.(*acme.ACMEChainCertificateSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChainCertificateSelector)(nil), (*v1beta1.ACMEChainCertificateSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChainCertificateSelector_To_v1beta1_ACMEChainCertificateSelector(a.(*acme.ACMEChainCertificateSelector), b.(*v1beta1.ACMEChainCertificateSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChainSelection)(nil), (*acme.ACMEChainSelection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChainSelection_To_acme_ACMEChainSelection(a.(*v1beta1.ACMEChainSelection), b.(*acme.ACMEChainSelection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChainSelection)(nil), (*v1beta1.ACMEChainSelection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChainSelection_To_v1beta1_ACMEChainSelection(a.(*acme.ACMEChainSelection), b.(*v1beta1.ACMEChainSelection), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChallenge)(nil), (*acme.ACMEChallenge)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEChallenge_To_acme_ACMEChallenge(a.(*v1beta1.ACMEChallenge), b.(*acme.ACMEChallenge), scope)
 	}); err != nil {
@@ -162,6 +192,96 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChallengeSolverTLSALPN01)(nil), (*acme.ACMEChallengeSolverTLSALPN01)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(a.(*v1beta1.ACMEChallengeSolverTLSALPN01), b.(*acme.ACMEChallengeSolverTLSALPN01), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01)(nil), (*v1beta1.ACMEChallengeSolverTLSALPN01)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1beta1_ACMEChallengeSolverTLSALPN01(a.(*acme.ACMEChallengeSolverTLSALPN01), b.(*v1beta1.ACMEChallengeSolverTLSALPN01), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChallengeSolverTLSALPN01GatewayTLSRoute)(nil), (*acme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverTLSALPN01GatewayTLSRoute_To_acme_ACMEChallengeSolverTLSALPN01GatewayTLSRoute(a.(*v1beta1.ACMEChallengeSolverTLSALPN01GatewayTLSRoute), b.(*acme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute)(nil), (*v1beta1.ACMEChallengeSolverTLSALPN01GatewayTLSRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01GatewayTLSRoute_To_v1beta1_ACMEChallengeSolverTLSALPN01GatewayTLSRoute(a.(*acme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute), b.(*v1beta1.ACMEChallengeSolverTLSALPN01GatewayTLSRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChallengeSolverTLSALPN01IngressObjectMeta)(nil), (*acme.ACMEChallengeSolverTLSALPN01IngressObjectMeta)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta(a.(*v1beta1.ACMEChallengeSolverTLSALPN01IngressObjectMeta), b.(*acme.ACMEChallengeSolverTLSALPN01IngressObjectMeta), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01IngressObjectMeta)(nil), (*v1beta1.ACMEChallengeSolverTLSALPN01IngressObjectMeta)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta(a.(*acme.ACMEChallengeSolverTLSALPN01IngressObjectMeta), b.(*v1beta1.ACMEChallengeSolverTLSALPN01IngressObjectMeta), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChallengeSolverTLSALPN01IngressTemplate)(nil), (*acme.ACMEChallengeSolverTLSALPN01IngressTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverTLSALPN01IngressTemplate_To_acme_ACMEChallengeSolverTLSALPN01IngressTemplate(a.(*v1beta1.ACMEChallengeSolverTLSALPN01IngressTemplate), b.(*acme.ACMEChallengeSolverTLSALPN01IngressTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01IngressTemplate)(nil), (*v1beta1.ACMEChallengeSolverTLSALPN01IngressTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01IngressTemplate_To_v1beta1_ACMEChallengeSolverTLSALPN01IngressTemplate(a.(*acme.ACMEChallengeSolverTLSALPN01IngressTemplate), b.(*v1beta1.ACMEChallengeSolverTLSALPN01IngressTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChallengeSolverTLSALPN01PodObjectMeta)(nil), (*acme.ACMEChallengeSolverTLSALPN01PodObjectMeta)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta(a.(*v1beta1.ACMEChallengeSolverTLSALPN01PodObjectMeta), b.(*acme.ACMEChallengeSolverTLSALPN01PodObjectMeta), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01PodObjectMeta)(nil), (*v1beta1.ACMEChallengeSolverTLSALPN01PodObjectMeta)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta(a.(*acme.ACMEChallengeSolverTLSALPN01PodObjectMeta), b.(*v1beta1.ACMEChallengeSolverTLSALPN01PodObjectMeta), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChallengeSolverTLSALPN01PodSpec)(nil), (*acme.ACMEChallengeSolverTLSALPN01PodSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec_To_acme_ACMEChallengeSolverTLSALPN01PodSpec(a.(*v1beta1.ACMEChallengeSolverTLSALPN01PodSpec), b.(*acme.ACMEChallengeSolverTLSALPN01PodSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01PodSpec)(nil), (*v1beta1.ACMEChallengeSolverTLSALPN01PodSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01PodSpec_To_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec(a.(*acme.ACMEChallengeSolverTLSALPN01PodSpec), b.(*v1beta1.ACMEChallengeSolverTLSALPN01PodSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChallengeSolverTLSALPN01PodTemplate)(nil), (*acme.ACMEChallengeSolverTLSALPN01PodTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverTLSALPN01PodTemplate_To_acme_ACMEChallengeSolverTLSALPN01PodTemplate(a.(*v1beta1.ACMEChallengeSolverTLSALPN01PodTemplate), b.(*acme.ACMEChallengeSolverTLSALPN01PodTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01PodTemplate)(nil), (*v1beta1.ACMEChallengeSolverTLSALPN01PodTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01PodTemplate_To_v1beta1_ACMEChallengeSolverTLSALPN01PodTemplate(a.(*acme.ACMEChallengeSolverTLSALPN01PodTemplate), b.(*v1beta1.ACMEChallengeSolverTLSALPN01PodTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChallengeSolverTLSALPN01SNIPassthrough)(nil), (*acme.ACMEChallengeSolverTLSALPN01SNIPassthrough)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverTLSALPN01SNIPassthrough_To_acme_ACMEChallengeSolverTLSALPN01SNIPassthrough(a.(*v1beta1.ACMEChallengeSolverTLSALPN01SNIPassthrough), b.(*acme.ACMEChallengeSolverTLSALPN01SNIPassthrough), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01SNIPassthrough)(nil), (*v1beta1.ACMEChallengeSolverTLSALPN01SNIPassthrough)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01SNIPassthrough_To_v1beta1_ACMEChallengeSolverTLSALPN01SNIPassthrough(a.(*acme.ACMEChallengeSolverTLSALPN01SNIPassthrough), b.(*v1beta1.ACMEChallengeSolverTLSALPN01SNIPassthrough), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEChallengeSolverTLSALPN01Service)(nil), (*acme.ACMEChallengeSolverTLSALPN01Service)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverTLSALPN01Service_To_acme_ACMEChallengeSolverTLSALPN01Service(a.(*v1beta1.ACMEChallengeSolverTLSALPN01Service), b.(*acme.ACMEChallengeSolverTLSALPN01Service), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01Service)(nil), (*v1beta1.ACMEChallengeSolverTLSALPN01Service)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01Service_To_v1beta1_ACMEChallengeSolverTLSALPN01Service(a.(*acme.ACMEChallengeSolverTLSALPN01Service), b.(*v1beta1.ACMEChallengeSolverTLSALPN01Service), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMEExternalAccountBinding)(nil), (*acme.ACMEExternalAccountBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(a.(*v1beta1.ACMEExternalAccountBinding), b.(*acme.ACMEExternalAccountBinding), scope)
 	}); err != nil {
@@ -282,6 +402,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ACMESelectedChain)(nil), (*acme.ACMESelectedChain)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMESelectedChain_To_acme_ACMESelectedChain(a.(*v1beta1.ACMESelectedChain), b.(*acme.ACMESelectedChain), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMESelectedChain)(nil), (*v1beta1.ACMESelectedChain)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMESelectedChain_To_v1beta1_ACMESelectedChain(a.(*acme.ACMESelectedChain), b.(*v1beta1.ACMESelectedChain), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.CertificateDNSNameSelector)(nil), (*acme.CertificateDNSNameSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CertificateDNSNameSelector_To_acme_CertificateDNSNameSelector(a.(*v1beta1.CertificateDNSNameSelector), b.(*acme.CertificateDNSNameSelector), scope)
 	}); err != nil {
@@ -403,6 +533,78 @@ func Convert_acme_ACMEAuthorization_To_v1beta1_ACMEAuthorization(in *acme.ACMEAu
 	return autoConvert_acme_ACMEAuthorization_To_v1beta1_ACMEAuthorization(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEChainCertificate_To_acme_ACMEChainCertificate(in *v1beta1.ACMEChainCertificate, out *acme.ACMEChainCertificate, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.SHA256 = in.SHA256
+	out.SPKISHA256 = in.SPKISHA256
+	return nil
+}
+
+// Convert_v1beta1_ACMEChainCertificate_To_acme_ACMEChainCertificate is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChainCertificate_To_acme_ACMEChainCertificate(in *v1beta1.ACMEChainCertificate, out *acme.ACMEChainCertificate, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChainCertificate_To_acme_ACMEChainCertificate(in, out, s)
+}
+
+func autoConvert_acme_ACMEChainCertificate_To_v1beta1_ACMEChainCertificate(in *acme.ACMEChainCertificate, out *v1beta1.ACMEChainCertificate, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.SHA256 = in.SHA256
+	out.SPKISHA256 = in.SPKISHA256
+	return nil
+}
+
+// Convert_acme_ACMEChainCertificate_To_v1beta1_ACMEChainCertificate is an autogenerated conversion function.
+func Convert_acme_ACMEChainCertificate_To_v1beta1_ACMEChainCertificate(in *acme.ACMEChainCertificate, out *v1beta1.ACMEChainCertificate, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChainCertificate_To_v1beta1_ACMEChainCertificate(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChainCertificateSelector_To_acme_ACMEChainCertificateSelector(in *v1beta1.ACMEChainCertificateSelector, out *acme.ACMEChainCertificateSelector, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.SHA256 = in.SHA256
+	out.SPKISHA256 = in.SPKISHA256
+	return nil
+}
+
+// Convert_v1beta1_ACMEChainCertificateSelector_To_acme_ACMEChainCertificateSelector is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChainCertificateSelector_To_acme_ACMEChainCertificateSelector(in *v1beta1.ACMEChainCertificateSelector, out *acme.ACMEChainCertificateSelector, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChainCertificateSelector_To_acme_ACMEChainCertificateSelector(in, out, s)
+}
+
+func autoConvert_acme_ACMEChainCertificateSelector_To_v1beta1_ACMEChainCertificateSelector(in *acme.ACMEChainCertificateSelector, out *v1beta1.ACMEChainCertificateSelector, s conversion.Scope) error {
+	out.CommonName = in.CommonName
+	out.SHA256 = in.SHA256
+	out.SPKISHA256 = in.SPKISHA256
+	return nil
+}
+
+// Convert_acme_ACMEChainCertificateSelector_To_v1beta1_ACMEChainCertificateSelector is an autogenerated conversion function.
+func Convert_acme_ACMEChainCertificateSelector_To_v1beta1_ACMEChainCertificateSelector(in *acme.ACMEChainCertificateSelector, out *v1beta1.ACMEChainCertificateSelector, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChainCertificateSelector_To_v1beta1_ACMEChainCertificateSelector(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChainSelection_To_acme_ACMEChainSelection(in *v1beta1.ACMEChainSelection, out *acme.ACMEChainSelection, s conversion.Scope) error {
+	out.Preferred = *(*[]acme.ACMEChainCertificateSelector)(unsafe.Pointer(&in.Preferred))
+	out.Exclude = *(*[]acme.ACMEChainCertificateSelector)(unsafe.Pointer(&in.Exclude))
+	out.PreferShortest = in.PreferShortest
+	return nil
+}
+
+// Convert_v1beta1_ACMEChainSelection_To_acme_ACMEChainSelection is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChainSelection_To_acme_ACMEChainSelection(in *v1beta1.ACMEChainSelection, out *acme.ACMEChainSelection, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChainSelection_To_acme_ACMEChainSelection(in, out, s)
+}
+
+func autoConvert_acme_ACMEChainSelection_To_v1beta1_ACMEChainSelection(in *acme.ACMEChainSelection, out *v1beta1.ACMEChainSelection, s conversion.Scope) error {
+	out.Preferred = *(*[]v1beta1.ACMEChainCertificateSelector)(unsafe.Pointer(&in.Preferred))
+	out.Exclude = *(*[]v1beta1.ACMEChainCertificateSelector)(unsafe.Pointer(&in.Exclude))
+	out.PreferShortest = in.PreferShortest
+	return nil
+}
+
+// Convert_acme_ACMEChainSelection_To_v1beta1_ACMEChainSelection is an autogenerated conversion function.
+func Convert_acme_ACMEChainSelection_To_v1beta1_ACMEChainSelection(in *acme.ACMEChainSelection, out *v1beta1.ACMEChainSelection, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChainSelection_To_v1beta1_ACMEChainSelection(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEChallenge_To_acme_ACMEChallenge(in *v1beta1.ACMEChallenge, out *acme.ACMEChallenge, s conversion.Scope) error {
 	out.URL = in.URL
 	out.Token = in.Token
@@ -439,6 +641,7 @@ func autoConvert_v1beta1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *v1b
 	} else {
 		out.DNS01 = nil
 	}
+	out.TLSALPN01 = (*acme.ACMEChallengeSolverTLSALPN01)(unsafe.Pointer(in.TLSALPN01))
 	return nil
 }
 
@@ -459,6 +662,7 @@ func autoConvert_acme_ACMEChallengeSolver_To_v1beta1_ACMEChallengeSolver(in *acm
 	} else {
 		out.DNS01 = nil
 	}
+	out.TLSALPN01 = (*v1beta1.ACMEChallengeSolverTLSALPN01)(unsafe.Pointer(in.TLSALPN01))
 	return nil
 }
 
@@ -831,6 +1035,226 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1beta1_ACMEChalle
 	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1beta1_ACMEChallengeSolverHTTP01IngressTemplate(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in *v1beta1.ACMEChallengeSolverTLSALPN01, out *acme.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	out.Service = (*acme.ACMEChallengeSolverTLSALPN01Service)(unsafe.Pointer(in.Service))
+	out.GatewayTLSRoute = (*acme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute)(unsafe.Pointer(in.GatewayTLSRoute))
+	out.SNIPassthrough = (*acme.ACMEChallengeSolverTLSALPN01SNIPassthrough)(unsafe.Pointer(in.SNIPassthrough))
+	out.PodTemplate = (*acme.ACMEChallengeSolverTLSALPN01PodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01 is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in *v1beta1.ACMEChallengeSolverTLSALPN01, out *acme.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01_To_v1beta1_ACMEChallengeSolverTLSALPN01(in *acme.ACMEChallengeSolverTLSALPN01, out *v1beta1.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	out.Service = (*v1beta1.ACMEChallengeSolverTLSALPN01Service)(unsafe.Pointer(in.Service))
+	out.GatewayTLSRoute = (*v1beta1.ACMEChallengeSolverTLSALPN01GatewayTLSRoute)(unsafe.Pointer(in.GatewayTLSRoute))
+	out.SNIPassthrough = (*v1beta1.ACMEChallengeSolverTLSALPN01SNIPassthrough)(unsafe.Pointer(in.SNIPassthrough))
+	out.PodTemplate = (*v1beta1.ACMEChallengeSolverTLSALPN01PodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1beta1_ACMEChallengeSolverTLSALPN01 is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1beta1_ACMEChallengeSolverTLSALPN01(in *acme.ACMEChallengeSolverTLSALPN01, out *v1beta1.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01_To_v1beta1_ACMEChallengeSolverTLSALPN01(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01GatewayTLSRoute_To_acme_ACMEChallengeSolverTLSALPN01GatewayTLSRoute(in *v1beta1.ACMEChallengeSolverTLSALPN01GatewayTLSRoute, out *acme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute, s conversion.Scope) error {
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverTLSALPN01GatewayTLSRoute_To_acme_ACMEChallengeSolverTLSALPN01GatewayTLSRoute is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverTLSALPN01GatewayTLSRoute_To_acme_ACMEChallengeSolverTLSALPN01GatewayTLSRoute(in *v1beta1.ACMEChallengeSolverTLSALPN01GatewayTLSRoute, out *acme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01GatewayTLSRoute_To_acme_ACMEChallengeSolverTLSALPN01GatewayTLSRoute(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01GatewayTLSRoute_To_v1beta1_ACMEChallengeSolverTLSALPN01GatewayTLSRoute(in *acme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute, out *v1beta1.ACMEChallengeSolverTLSALPN01GatewayTLSRoute, s conversion.Scope) error {
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01GatewayTLSRoute_To_v1beta1_ACMEChallengeSolverTLSALPN01GatewayTLSRoute is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01GatewayTLSRoute_To_v1beta1_ACMEChallengeSolverTLSALPN01GatewayTLSRoute(in *acme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute, out *v1beta1.ACMEChallengeSolverTLSALPN01GatewayTLSRoute, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01GatewayTLSRoute_To_v1beta1_ACMEChallengeSolverTLSALPN01GatewayTLSRoute(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta(in *v1beta1.ACMEChallengeSolverTLSALPN01IngressObjectMeta, out *acme.ACMEChallengeSolverTLSALPN01IngressObjectMeta, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta(in *v1beta1.ACMEChallengeSolverTLSALPN01IngressObjectMeta, out *acme.ACMEChallengeSolverTLSALPN01IngressObjectMeta, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta(in *acme.ACMEChallengeSolverTLSALPN01IngressObjectMeta, out *v1beta1.ACMEChallengeSolverTLSALPN01IngressObjectMeta, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta(in *acme.ACMEChallengeSolverTLSALPN01IngressObjectMeta, out *v1beta1.ACMEChallengeSolverTLSALPN01IngressObjectMeta, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01IngressTemplate_To_acme_ACMEChallengeSolverTLSALPN01IngressTemplate(in *v1beta1.ACMEChallengeSolverTLSALPN01IngressTemplate, out *acme.ACMEChallengeSolverTLSALPN01IngressTemplate, s conversion.Scope) error {
+	if err := Convert_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta(&in.ACMEChallengeSolverTLSALPN01IngressObjectMeta, &out.ACMEChallengeSolverTLSALPN01IngressObjectMeta, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverTLSALPN01IngressTemplate_To_acme_ACMEChallengeSolverTLSALPN01IngressTemplate is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverTLSALPN01IngressTemplate_To_acme_ACMEChallengeSolverTLSALPN01IngressTemplate(in *v1beta1.ACMEChallengeSolverTLSALPN01IngressTemplate, out *acme.ACMEChallengeSolverTLSALPN01IngressTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01IngressTemplate_To_acme_ACMEChallengeSolverTLSALPN01IngressTemplate(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01IngressTemplate_To_v1beta1_ACMEChallengeSolverTLSALPN01IngressTemplate(in *acme.ACMEChallengeSolverTLSALPN01IngressTemplate, out *v1beta1.ACMEChallengeSolverTLSALPN01IngressTemplate, s conversion.Scope) error {
+	if err := Convert_acme_ACMEChallengeSolverTLSALPN01IngressObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01IngressObjectMeta(&in.ACMEChallengeSolverTLSALPN01IngressObjectMeta, &out.ACMEChallengeSolverTLSALPN01IngressObjectMeta, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01IngressTemplate_To_v1beta1_ACMEChallengeSolverTLSALPN01IngressTemplate is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01IngressTemplate_To_v1beta1_ACMEChallengeSolverTLSALPN01IngressTemplate(in *acme.ACMEChallengeSolverTLSALPN01IngressTemplate, out *v1beta1.ACMEChallengeSolverTLSALPN01IngressTemplate, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01IngressTemplate_To_v1beta1_ACMEChallengeSolverTLSALPN01IngressTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta(in *v1beta1.ACMEChallengeSolverTLSALPN01PodObjectMeta, out *acme.ACMEChallengeSolverTLSALPN01PodObjectMeta, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta(in *v1beta1.ACMEChallengeSolverTLSALPN01PodObjectMeta, out *acme.ACMEChallengeSolverTLSALPN01PodObjectMeta, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta(in *acme.ACMEChallengeSolverTLSALPN01PodObjectMeta, out *v1beta1.ACMEChallengeSolverTLSALPN01PodObjectMeta, s conversion.Scope) error {
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta(in *acme.ACMEChallengeSolverTLSALPN01PodObjectMeta, out *v1beta1.ACMEChallengeSolverTLSALPN01PodObjectMeta, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec_To_acme_ACMEChallengeSolverTLSALPN01PodSpec(in *v1beta1.ACMEChallengeSolverTLSALPN01PodSpec, out *acme.ACMEChallengeSolverTLSALPN01PodSpec, s conversion.Scope) error {
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.PriorityClassName = in.PriorityClassName
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec_To_acme_ACMEChallengeSolverTLSALPN01PodSpec is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec_To_acme_ACMEChallengeSolverTLSALPN01PodSpec(in *v1beta1.ACMEChallengeSolverTLSALPN01PodSpec, out *acme.ACMEChallengeSolverTLSALPN01PodSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec_To_acme_ACMEChallengeSolverTLSALPN01PodSpec(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01PodSpec_To_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec(in *acme.ACMEChallengeSolverTLSALPN01PodSpec, out *v1beta1.ACMEChallengeSolverTLSALPN01PodSpec, s conversion.Scope) error {
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.PriorityClassName = in.PriorityClassName
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01PodSpec_To_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01PodSpec_To_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec(in *acme.ACMEChallengeSolverTLSALPN01PodSpec, out *v1beta1.ACMEChallengeSolverTLSALPN01PodSpec, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01PodSpec_To_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01PodTemplate_To_acme_ACMEChallengeSolverTLSALPN01PodTemplate(in *v1beta1.ACMEChallengeSolverTLSALPN01PodTemplate, out *acme.ACMEChallengeSolverTLSALPN01PodTemplate, s conversion.Scope) error {
+	if err := Convert_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta(&in.ACMEChallengeSolverTLSALPN01PodObjectMeta, &out.ACMEChallengeSolverTLSALPN01PodObjectMeta, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec_To_acme_ACMEChallengeSolverTLSALPN01PodSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverTLSALPN01PodTemplate_To_acme_ACMEChallengeSolverTLSALPN01PodTemplate is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverTLSALPN01PodTemplate_To_acme_ACMEChallengeSolverTLSALPN01PodTemplate(in *v1beta1.ACMEChallengeSolverTLSALPN01PodTemplate, out *acme.ACMEChallengeSolverTLSALPN01PodTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01PodTemplate_To_acme_ACMEChallengeSolverTLSALPN01PodTemplate(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01PodTemplate_To_v1beta1_ACMEChallengeSolverTLSALPN01PodTemplate(in *acme.ACMEChallengeSolverTLSALPN01PodTemplate, out *v1beta1.ACMEChallengeSolverTLSALPN01PodTemplate, s conversion.Scope) error {
+	if err := Convert_acme_ACMEChallengeSolverTLSALPN01PodObjectMeta_To_v1beta1_ACMEChallengeSolverTLSALPN01PodObjectMeta(&in.ACMEChallengeSolverTLSALPN01PodObjectMeta, &out.ACMEChallengeSolverTLSALPN01PodObjectMeta, s); err != nil {
+		return err
+	}
+	if err := Convert_acme_ACMEChallengeSolverTLSALPN01PodSpec_To_v1beta1_ACMEChallengeSolverTLSALPN01PodSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01PodTemplate_To_v1beta1_ACMEChallengeSolverTLSALPN01PodTemplate is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01PodTemplate_To_v1beta1_ACMEChallengeSolverTLSALPN01PodTemplate(in *acme.ACMEChallengeSolverTLSALPN01PodTemplate, out *v1beta1.ACMEChallengeSolverTLSALPN01PodTemplate, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01PodTemplate_To_v1beta1_ACMEChallengeSolverTLSALPN01PodTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01SNIPassthrough_To_acme_ACMEChallengeSolverTLSALPN01SNIPassthrough(in *v1beta1.ACMEChallengeSolverTLSALPN01SNIPassthrough, out *acme.ACMEChallengeSolverTLSALPN01SNIPassthrough, s conversion.Scope) error {
+	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressTemplate = (*acme.ACMEChallengeSolverTLSALPN01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverTLSALPN01SNIPassthrough_To_acme_ACMEChallengeSolverTLSALPN01SNIPassthrough is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverTLSALPN01SNIPassthrough_To_acme_ACMEChallengeSolverTLSALPN01SNIPassthrough(in *v1beta1.ACMEChallengeSolverTLSALPN01SNIPassthrough, out *acme.ACMEChallengeSolverTLSALPN01SNIPassthrough, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01SNIPassthrough_To_acme_ACMEChallengeSolverTLSALPN01SNIPassthrough(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01SNIPassthrough_To_v1beta1_ACMEChallengeSolverTLSALPN01SNIPassthrough(in *acme.ACMEChallengeSolverTLSALPN01SNIPassthrough, out *v1beta1.ACMEChallengeSolverTLSALPN01SNIPassthrough, s conversion.Scope) error {
+	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressTemplate = (*v1beta1.ACMEChallengeSolverTLSALPN01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01SNIPassthrough_To_v1beta1_ACMEChallengeSolverTLSALPN01SNIPassthrough is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01SNIPassthrough_To_v1beta1_ACMEChallengeSolverTLSALPN01SNIPassthrough(in *acme.ACMEChallengeSolverTLSALPN01SNIPassthrough, out *v1beta1.ACMEChallengeSolverTLSALPN01SNIPassthrough, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01SNIPassthrough_To_v1beta1_ACMEChallengeSolverTLSALPN01SNIPassthrough(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01Service_To_acme_ACMEChallengeSolverTLSALPN01Service(in *v1beta1.ACMEChallengeSolverTLSALPN01Service, out *acme.ACMEChallengeSolverTLSALPN01Service, s conversion.Scope) error {
+	out.Type = v1.ServiceType(in.Type)
+	out.Port = in.Port
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverTLSALPN01Service_To_acme_ACMEChallengeSolverTLSALPN01Service is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverTLSALPN01Service_To_acme_ACMEChallengeSolverTLSALPN01Service(in *v1beta1.ACMEChallengeSolverTLSALPN01Service, out *acme.ACMEChallengeSolverTLSALPN01Service, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverTLSALPN01Service_To_acme_ACMEChallengeSolverTLSALPN01Service(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01Service_To_v1beta1_ACMEChallengeSolverTLSALPN01Service(in *acme.ACMEChallengeSolverTLSALPN01Service, out *v1beta1.ACMEChallengeSolverTLSALPN01Service, s conversion.Scope) error {
+	out.Type = v1.ServiceType(in.Type)
+	out.Port = in.Port
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01Service_To_v1beta1_ACMEChallengeSolverTLSALPN01Service is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01Service_To_v1beta1_ACMEChallengeSolverTLSALPN01Service(in *acme.ACMEChallengeSolverTLSALPN01Service, out *v1beta1.ACMEChallengeSolverTLSALPN01Service, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01Service_To_v1beta1_ACMEChallengeSolverTLSALPN01Service(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(in *v1beta1.ACMEExternalAccountBinding, out *acme.ACMEExternalAccountBinding, s conversion.Scope) error {
 	out.KeyID = in.KeyID
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
@@ -863,6 +1287,7 @@ func autoConvert_v1beta1_ACMEIssuer_To_acme_ACMEIssuer(in *v1beta1.ACMEIssuer, o
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.ChainSelection = (*acme.ACMEChainSelection)(unsafe.Pointer(in.ChainSelection))
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
@@ -876,6 +1301,15 @@ func autoConvert_v1beta1_ACMEIssuer_To_acme_ACMEIssuer(in *v1beta1.ACMEIssuer, o
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PrivateKey, &out.PrivateKey, s); err != nil {
 		return err
 	}
+	if in.NextPrivateKey != nil {
+		in, out := &in.NextPrivateKey, &out.NextPrivateKey
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NextPrivateKey = nil
+	}
 	if in.Solvers != nil {
 		in, out := &in.Solvers, &out.Solvers
 		*out = make([]acme.ACMEChallengeSolver, len(*in))
@@ -888,6 +1322,7 @@ func autoConvert_v1beta1_ACMEIssuer_To_acme_ACMEIssuer(in *v1beta1.ACMEIssuer, o
 		out.Solvers = nil
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
+	out.DeactivateAccountOnDeletion = in.DeactivateAccountOnDeletion
 	out.EnableDurationFeature = in.EnableDurationFeature
 	return nil
 }
@@ -901,6 +1336,7 @@ func autoConvert_acme_ACMEIssuer_To_v1beta1_ACMEIssuer(in *acme.ACMEIssuer, out
 	out.Email = in.Email
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
+	out.ChainSelection = (*v1beta1.ACMEChainSelection)(unsafe.Pointer(in.ChainSelection))
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
@@ -914,6 +1350,15 @@ func autoConvert_acme_ACMEIssuer_To_v1beta1_ACMEIssuer(in *acme.ACMEIssuer, out
 	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PrivateKey, &out.PrivateKey, s); err != nil {
 		return err
 	}
+	if in.NextPrivateKey != nil {
+		in, out := &in.NextPrivateKey, &out.NextPrivateKey
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NextPrivateKey = nil
+	}
 	if in.Solvers != nil {
 		in, out := &in.Solvers, &out.Solvers
 		*out = make([]v1beta1.ACMEChallengeSolver, len(*in))
@@ -926,6 +1371,7 @@ func autoConvert_acme_ACMEIssuer_To_v1beta1_ACMEIssuer(in *acme.ACMEIssuer, out
 		out.Solvers = nil
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
+	out.DeactivateAccountOnDeletion = in.DeactivateAccountOnDeletion
 	out.EnableDurationFeature = in.EnableDurationFeature
 	return nil
 }
@@ -1275,6 +1721,28 @@ func Convert_acme_ACMEIssuerStatus_To_v1beta1_ACMEIssuerStatus(in *acme.ACMEIssu
 	return autoConvert_acme_ACMEIssuerStatus_To_v1beta1_ACMEIssuerStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMESelectedChain_To_acme_ACMESelectedChain(in *v1beta1.ACMESelectedChain, out *acme.ACMESelectedChain, s conversion.Scope) error {
+	out.Index = in.Index
+	out.Certificates = *(*[]acme.ACMEChainCertificate)(unsafe.Pointer(&in.Certificates))
+	return nil
+}
+
+// Convert_v1beta1_ACMESelectedChain_To_acme_ACMESelectedChain is an autogenerated conversion function.
+func Convert_v1beta1_ACMESelectedChain_To_acme_ACMESelectedChain(in *v1beta1.ACMESelectedChain, out *acme.ACMESelectedChain, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMESelectedChain_To_acme_ACMESelectedChain(in, out, s)
+}
+
+func autoConvert_acme_ACMESelectedChain_To_v1beta1_ACMESelectedChain(in *acme.ACMESelectedChain, out *v1beta1.ACMESelectedChain, s conversion.Scope) error {
+	out.Index = in.Index
+	out.Certificates = *(*[]v1beta1.ACMEChainCertificate)(unsafe.Pointer(&in.Certificates))
+	return nil
+}
+
+// Convert_acme_ACMESelectedChain_To_v1beta1_ACMESelectedChain is an autogenerated conversion function.
+func Convert_acme_ACMESelectedChain_To_v1beta1_ACMESelectedChain(in *acme.ACMESelectedChain, out *v1beta1.ACMESelectedChain, s conversion.Scope) error {
+	return autoConvert_acme_ACMESelectedChain_To_v1beta1_ACMESelectedChain(in, out, s)
+}
+
 func autoConvert_v1beta1_CertificateDNSNameSelector_To_acme_CertificateDNSNameSelector(in *v1beta1.CertificateDNSNameSelector, out *acme.CertificateDNSNameSelector, s conversion.Scope) error {
 	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
@@ -1559,6 +2027,8 @@ func autoConvert_v1beta1_OrderStatus_To_acme_OrderStatus(in *v1beta1.OrderStatus
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RateLimitedUntil = (*pkgapismetav1.Time)(unsafe.Pointer(in.RateLimitedUntil))
+	out.SelectedChain = (*acme.ACMESelectedChain)(unsafe.Pointer(in.SelectedChain))
 	return nil
 }
 
@@ -1575,6 +2045,8 @@ func autoConvert_acme_OrderStatus_To_v1beta1_OrderStatus(in *acme.OrderStatus, o
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]v1beta1.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.FailureTime = (*pkgapismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.RateLimitedUntil = (*pkgapismetav1.Time)(unsafe.Pointer(in.RateLimitedUntil))
+	out.SelectedChain = (*v1beta1.ACMESelectedChain)(unsafe.Pointer(in.SelectedChain))
 	return nil
 }
 
@@ -134,6 +134,11 @@ type CertificateSpec struct {
 	// `secretName` Secret resource.
 	Keystores *CertificateKeystores
 
+	// OCSPStapling configures fetching an OCSP response for the issued
+	// certificate so that it can be stapled by servers using the
+	// `spec.secretName` Secret resource.
+	OCSPStapling *CertificateOCSPStapling
+
 	// IssuerRef is a reference to the issuer for this certificate.
 	// If the `kind` field is not set, or set to `Issuer`, an Issuer resource
 	// with the given name in the same namespace as the Certificate will be used.
@@ -146,6 +151,11 @@ type CertificateSpec struct {
 	// This will automatically add the `cert sign` usage to the list of `usages`.
 	IsCA bool
 
+	// NameConstraints is the X.509 Name Constraints extension to include in
+	// the certificate, restricting the names that certificates signed by
+	// this CA may use. It may only be set when `isCA` is true.
+	NameConstraints *NameConstraints
+
 	// Usages is the set of x509 usages that are requested for the certificate.
 	// Defaults to `digital signature` and `key encipherment` if not specified.
 	Usages []KeyUsage
@@ -165,6 +175,61 @@ type CertificateSpec struct {
 	// revisionHistoryLimit must be a value of `1` or greater. If unset (`nil`),
 	// revisions will not be garbage collected. Default value is `nil`.
 	RevisionHistoryLimit *int32
+
+	// RevocationPolicy controls when cert-manager will ask the issuer to
+	// revoke certificates that it has issued for this Certificate.
+	// If set to `Never`, cert-manager will never revoke certificates.
+	// If set to `OnDelete`, the certificate stored in `spec.secretName` will
+	// be revoked when this Certificate resource is deleted.
+	// If set to `OnReissue`, the behaviour of `OnDelete` applies, and
+	// previously issued certificates will also be revoked once they have been
+	// superseded by a re-issued certificate.
+	// Revocation is only performed for issuer types that support it.
+	// Default is `Never`.
+	RevocationPolicy CertificateRevocationPolicy
+
+	// RevocationCheck configures periodic checks of whether the certificate
+	// stored in `spec.secretName` has been revoked, using the OCSP responder
+	// or CRL distribution point named in the certificate. A certificate that
+	// has been revoked will be re-issued.
+	RevocationCheck *CertificateRevocationCheck
+}
+
+// NameConstraints configures the X.509 Name Constraints extension of a CA
+// certificate, as described in RFC 5280 section 4.2.1.10.
+type NameConstraints struct {
+	// Critical marks the Name Constraints extension as critical.
+	Critical bool
+
+	// Permitted contains the names that certificates signed by the CA may
+	// use. Names of a type that has permitted entries must match one of them.
+	Permitted *NameConstraintItem
+
+	// Excluded contains the names that certificates signed by the CA may not
+	// use. Exclusions take precedence over permitted entries.
+	Excluded *NameConstraintItem
+}
+
+// NameConstraintItem is a set of names of each type supported in the
+// subtrees of the X.509 Name Constraints extension.
+type NameConstraintItem struct {
+	// DNSDomains is a list of DNS domains. A domain matches itself and all of
+	// its subdomains, unless it starts with a period in which case it only
+	// matches its subdomains.
+	DNSDomains []string
+
+	// IPRanges is a list of IP address ranges in CIDR notation,
+	// e.g. "10.0.0.0/8" or "2001:db8::/32".
+	IPRanges []string
+
+	// EmailAddresses is a list of email addresses or domains. A full address
+	// matches only that mailbox, a domain matches all mailboxes on that host,
+	// and a domain starting with a period matches mailboxes on its subdomains.
+	EmailAddresses []string
+
+	// URIDomains is a list of domains matched against the host of URI SANs,
+	// following the same rules as DNSDomains.
+	URIDomains []string
 }
 
 // CertificatePrivateKey contains configuration options for private keys
@@ -221,6 +286,42 @@ var (
 	RotationPolicyAlways PrivateKeyRotationPolicy = "Always"
 )
 
+// CertificateRevocationPolicy denotes when cert-manager should revoke
+// certificates that have been issued for a Certificate.
+type CertificateRevocationPolicy string
+
+const (
+	// RevocationPolicyNever means cert-manager will never revoke certificates
+	// issued for the Certificate.
+	RevocationPolicyNever CertificateRevocationPolicy = "Never"
+
+	// RevocationPolicyOnDelete means the current certificate will be revoked
+	// when the Certificate resource is deleted.
+	RevocationPolicyOnDelete CertificateRevocationPolicy = "OnDelete"
+
+	// RevocationPolicyOnReissue means previously issued certificates will be
+	// revoked once they have been superseded by a re-issued certificate, and
+	// the current certificate will be revoked when the Certificate resource is
+	// deleted.
+	RevocationPolicyOnReissue CertificateRevocationPolicy = "OnReissue"
+)
+
+// CertificateRevocationCheck configures checking the revocation status of
+// the certificate issued for a Certificate.
+type CertificateRevocationCheck struct {
+	// Enabled enables revocation checking for the Certificate.
+	// If true, the status of the current certificate will be checked using
+	// its OCSP responder, falling back to its CRL distribution point if the
+	// certificate does not name an OCSP responder or the responder cannot be
+	// reached. If the certificate has been revoked, it will be re-issued.
+	Enabled bool
+
+	// Interval is the minimum time between two checks of the revocation
+	// status of the current certificate.
+	// Minimum value is 5m. Defaults to 1h.
+	Interval *metav1.Duration
+}
+
 // X509Subject Full X509 name specification
 type X509Subject struct {
 	// Organizations to be used on the Certificate.
@@ -241,6 +342,19 @@ type X509Subject struct {
 	SerialNumber string
 }
 
+// CertificateOCSPStapling configures fetching OCSP responses for the
+// certificate stored in the Certificate's output Secret.
+type CertificateOCSPStapling struct {
+	// Enabled enables OCSP stapling for the Certificate.
+	// If true, the OCSP response for the issued certificate will be fetched
+	// from the OCSP responder named in its Authority Information Access
+	// extension and stored in a file named `tls.ocsp-staple` in the target
+	// Secret resource, in DER format.
+	// The response is refreshed before its `nextUpdate` time, and removed
+	// whenever the certificate is re-issued.
+	Enabled bool
+}
+
 // CertificateKeystores configures additional keystore output formats to be
 // created in the Certificate's output Secret.
 type CertificateKeystores struct {
@@ -332,6 +446,64 @@ type CertificateStatus struct {
 	// It will automatically unset this field when the Issuing condition is
 	// not set or False.
 	NextPrivateKeySecretName *string
+
+	// LastRevocation records the most recent revocation of a certificate
+	// issued for this Certificate, either as a result of the configured
+	// `spec.revocationPolicy` or because revocation was requested manually
+	// using the `cert-manager.io/revoke` annotation.
+	LastRevocation *CertificateRevocation
+
+	// RenewalInfo is the renewal window suggested by the ACME server that
+	// issued the current certificate, as retrieved from its renewalInfo
+	// endpoint. If the suggested window starts earlier than the renewal
+	// time derived from `spec.renewBefore`, the certificate is renewed
+	// within the suggested window instead.
+	// Only set if the ACMERenewalInfo feature gate is enabled.
+	RenewalInfo *CertificateRenewalInfo
+}
+
+// CertificateRevocation records the revocation of a certificate that was
+// issued for a Certificate.
+type CertificateRevocation struct {
+	// SerialNumber is the hex encoded serial number of the revoked certificate.
+	SerialNumber string
+
+	// Reason is the RFC 5280 revocation reason that was given when revoking
+	// the certificate, for example `keyCompromise` or `superseded`.
+	Reason string
+
+	// RevocationTime is the time at which cert-manager revoked the
+	// certificate.
+	RevocationTime *metav1.Time
+}
+
+// CertificateRenewalInfo is the renewal information of a certificate, as
+// defined by the ACME Renewal Information (ARI) extension.
+type CertificateRenewalInfo struct {
+	// SerialNumber is the hex encoded serial number of the certificate the
+	// renewal information applies to.
+	SerialNumber string
+
+	// SuggestedWindowStart is the start of the window in which the ACME
+	// server suggests the certificate should be renewed.
+	SuggestedWindowStart metav1.Time
+
+	// SuggestedWindowEnd is the end of the window in which the ACME server
+	// suggests the certificate should be renewed.
+	SuggestedWindowEnd metav1.Time
+
+	// ExplanationURL is a URL given by the ACME server pointing to a page
+	// explaining why the suggested window has changed, e.g. after a
+	// mass-revocation incident.
+	ExplanationURL string
+
+	// LastCheckTime is the time at which the renewal information was last
+	// retrieved from the ACME server.
+	LastCheckTime metav1.Time
+
+	// NextCheckTime is the time at which the renewal information will be
+	// retrieved from the ACME server again.
+	NextCheckTime metav1.Time
 }
 
 // CertificateCondition contains condition information for an Certificate.
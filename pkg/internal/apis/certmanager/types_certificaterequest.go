@@ -180,4 +180,11 @@ const (
 	// denied, and must never be signed. Condition must never have a status of
 	// `False`, and cannot be modified once set.
 	CertificateRequestConditionDenied CertificateRequestConditionType = "Denied"
+
+	// CertificateRequestConditionRevoked indicates that the certificate
+	// stored in the CertificateRequest's `status.certificate` field has been
+	// revoked by cert-manager. A status of `False` indicates that cert-manager
+	// will not attempt to revoke the certificate, for example because the
+	// issuer does not support revocation or rejected the request.
+	CertificateRequestConditionRevoked CertificateRequestConditionType = "Revoked"
 )
@@ -82,6 +82,25 @@ type IssuerList struct {
 // configuration required for the issuer.
 type IssuerSpec struct {
 	IssuerConfig
+
+	// NamespaceAccess restricts the namespaces whose Certificates and
+	// CertificateRequests may reference this issuer.
+	// It may only be set on ClusterIssuers. If not set, the ClusterIssuer may
+	// be used from any namespace.
+	NamespaceAccess *IssuerNamespaceAccess
+}
+
+// IssuerNamespaceAccess restricts the namespaces that may use a ClusterIssuer.
+// A namespace may use the ClusterIssuer if it is listed in namespaces or
+// matches the namespaceSelector. At least one of the two must be set.
+type IssuerNamespaceAccess struct {
+	// Namespaces is a list of names of namespaces that may use the
+	// ClusterIssuer.
+	Namespaces []string
+
+	// NamespaceSelector selects the namespaces that may use the ClusterIssuer
+	// by their labels.
+	NamespaceSelector *metav1.LabelSelector
 }
 
 type IssuerConfig struct {
@@ -105,6 +124,18 @@ type IssuerConfig struct {
 	// Venafi configures this issuer to sign certificates using a Venafi TPP
 	// or Venafi Cloud policy zone.
 	Venafi *VenafiIssuer
+
+	// EST configures this issuer to obtain certificates from an Enrollment
+	// over Secure Transport (RFC 7030) server.
+	EST *ESTIssuer
+
+	// SCEP configures this issuer to obtain certificates from a Simple
+	// Certificate Enrollment Protocol (RFC 8894) server.
+	SCEP *SCEPIssuer
+
+	// CMP configures this issuer to obtain certificates from a Certificate
+	// Management Protocol (RFC 4210) server.
+	CMP *CMPIssuer
 }
 
 // Configures an issuer to sign certificates using a Venafi TPP
@@ -177,6 +208,20 @@ type VaultIssuer struct {
 	// "my_pki_mount/sign/my-role-name".
 	Path string
 
+	// AllowedRoles is a list of Vault PKI roles that Certificates and
+	// CertificateRequests may select instead of the role in Path, using the
+	// `vault.cert-manager.io/role` annotation. The role in Path is always
+	// allowed. Selecting a role requires Path to be of the form
+	// "<mount>/sign/<role>".
+	AllowedRoles []string
+
+	// SignVerbatim requests certificates from the `sign-verbatim` endpoint of
+	// the PKI mount instead of the `sign` endpoint, so that the subject, SANs
+	// and key usages of the CSR are used as requested rather than being
+	// constrained by the role. Requires Path to be of the form
+	// "<mount>/sign/<role>".
+	SignVerbatim bool
+
 	// Name of the vault namespace. Namespaces is a set of features within Vault Enterprise that allows Vault environments to support Secure Multi-tenancy. e.g: "ns1"
 	// More about namespaces can be found here https://www.vaultproject.io/docs/enterprise/namespaces
 	Namespace string
@@ -201,6 +246,15 @@ type VaultAuth struct {
 	// Kubernetes authenticates with Vault by passing the ServiceAccount
 	// token stored in the named Secret resource to the Vault server.
 	Kubernetes *VaultKubernetesAuth
+
+	// JWT authenticates with Vault using the JWT/OIDC auth method, presenting
+	// a short-lived token for a Kubernetes ServiceAccount that is requested
+	// through the TokenRequest API.
+	JWT *VaultJWTAuth
+
+	// ClientCertificate authenticates with Vault by presenting a TLS client
+	// certificate to the Vault `cert` auth method.
+	ClientCertificate *VaultClientCertificateAuth
 }
 
 // VaultAppRole authenticates with Vault using the App Role auth mechanism,
@@ -240,11 +294,257 @@ type VaultKubernetesAuth struct {
 	Role string
 }
 
+// VaultJWTAuth authenticates with Vault using the JWT/OIDC auth method. A
+// short-lived, audience-scoped token for the referenced ServiceAccount is
+// requested from the Kubernetes API server on every login, so no long-lived
+// ServiceAccount token Secret is required.
+// The cert-manager controller must be permitted to `create` the
+// `serviceaccounts/token` subresource of the referenced ServiceAccount.
+type VaultJWTAuth struct {
+	// The Vault mountPath here is the mount path to use when authenticating with
+	// Vault. For example, setting a value to `/v1/auth/foo`, will use the path
+	// `/v1/auth/foo/login` to authenticate with Vault. If unspecified, the
+	// default value "/v1/auth/jwt" will be used.
+	Path string
+
+	// A required field containing the Vault Role to assume. A Role binds the
+	// claims of the presented token with a set of Vault policies.
+	Role string
+
+	// ServiceAccountRef is a reference to the ServiceAccount, in the issuer's
+	// resource namespace, that the token is requested for.
+	ServiceAccountRef ServiceAccountRef
+
+	// Audiences is an optional list of additional audiences to include in the
+	// requested token. The token is always scoped to the audience
+	// "vault://<namespace>/<issuer-name>" for an Issuer, or
+	// "vault://<issuer-name>" for a ClusterIssuer.
+	Audiences []string
+}
+
+// VaultClientCertificateAuth authenticates with Vault using the TLS
+// certificate auth method, presenting the client certificate and private key
+// stored in a Kubernetes Secret when connecting to the Vault server.
+type VaultClientCertificateAuth struct {
+	// The Vault mountPath here is the mount path to use when authenticating with
+	// Vault. For example, setting a value to `/v1/auth/foo`, will use the path
+	// `/v1/auth/foo/login` to authenticate with Vault. If unspecified, the
+	// default value "/v1/auth/cert" will be used.
+	Path string
+
+	// SecretName is the name of a Secret of type `kubernetes.io/tls` holding
+	// the client certificate and private key in its `tls.crt` and `tls.key`
+	// entries. The Secret may itself be managed by a cert-manager Certificate.
+	SecretName string
+
+	// Name of the certificate role to authenticate against. If unspecified,
+	// Vault tries all certificate roles that match the presented certificate.
+	Name string
+}
+
+// ServiceAccountRef is a reference to a ServiceAccount resource.
+type ServiceAccountRef struct {
+	// Name of the ServiceAccount.
+	Name string
+}
+
+// Configures an issuer to obtain certificates from an Enrollment over Secure
+// Transport (RFC 7030) server.
+type ESTIssuer struct {
+	// Server is the base URL of the EST server, e.g: "https://est.example.com".
+	// Requests are made to the `/.well-known/est` path of the server.
+	Server string
+
+	// Label is the optional label of the CA on the EST server, which is
+	// appended to the `/.well-known/est` path, e.g: "tls-servers".
+	Label string
+
+	// PEM-encoded CA bundle (base64-encoded) used to validate the EST server
+	// certificate. If not set the system root certificates are used to
+	// validate the TLS connection.
+	CABundle []byte
+
+	// Auth configures how cert-manager authenticates with the EST server.
+	// If not set, requests are not authenticated.
+	Auth *ESTAuth
+
+	// Reenroll configures the issuer to renew certificates using the
+	// `/simplereenroll` operation, authenticating with the certificate and
+	// private key stored in the Secret of the Certificate being renewed.
+	// Certificates are enrolled using `/simpleenroll` if no valid certificate
+	// is stored in the Secret.
+	Reenroll bool
+}
+
+// Configuration used to authenticate with an EST server.
+// Both `basicAuth` and `clientCertificate` may be specified.
+type ESTAuth struct {
+	// BasicAuth authenticates with the EST server using HTTP basic
+	// authentication.
+	BasicAuth *ESTBasicAuth
+
+	// ClientCertificate authenticates with the EST server using a TLS client
+	// certificate.
+	ClientCertificate *ESTClientCertificateAuth
+}
+
+// ESTBasicAuth authenticates with an EST server using a username and a
+// password stored in a Kubernetes Secret resource.
+type ESTBasicAuth struct {
+	// Username is the username used to authenticate with the EST server.
+	Username string
+
+	// PasswordSecretRef is a reference to a key in a Secret resource
+	// containing the password used to authenticate with the EST server.
+	PasswordSecretRef cmmeta.SecretKeySelector
+}
+
+// ESTClientCertificateAuth authenticates with an EST server using a TLS
+// client certificate stored in a Kubernetes Secret resource.
+type ESTClientCertificateAuth struct {
+	// SecretRef is a reference to a Secret of type `kubernetes.io/tls`
+	// containing the client certificate and private key in the `tls.crt`
+	// and `tls.key` keys.
+	SecretRef cmmeta.LocalObjectReference
+}
+
+// Configures an issuer to obtain certificates from a Simple Certificate
+// Enrollment Protocol (RFC 8894) server, such as Microsoft NDES.
+// SCEP requests are signed and decrypted using the private key of the
+// certificate being requested, so only RSA private keys are supported.
+type SCEPIssuer struct {
+	// URL is the URL of the PKI operation endpoint of the SCEP server, e.g:
+	// "http://ndes.example.com/certsrv/mscep/mscep.dll".
+	URL string
+
+	// PEM-encoded CA bundle (base64-encoded) used to validate the SCEP server
+	// certificate if the URL uses https. If not set the system root
+	// certificates are used to validate the TLS connection.
+	CABundle []byte
+
+	// ChallengePasswordSecretRef is a reference to a key in a Secret resource
+	// containing the challenge password that is added to certificate
+	// requests sent to the SCEP server.
+	ChallengePasswordSecretRef *cmmeta.SecretKeySelector
+}
+
+// Configures an issuer to obtain certificates from a Certificate Management
+// Protocol version 2 (RFC 4210) server over HTTP (RFC 6712).
+// Certificate requests are proven using the private key of the certificate
+// being requested, and a key update request (kur) is sent instead of a
+// certification request when a Certificate is renewed with the same private
+// key.
+// Exactly one of SharedSecret or Signature must be specified.
+type CMPIssuer struct {
+	// URL is the URL of the CMP server endpoint, e.g:
+	// "https://ca.example.com/pkix/".
+	URL string
+
+	// PEM-encoded CA bundle (base64-encoded) used to validate the CMP server
+	// certificate if the URL uses https, and the certificate used to sign
+	// signature-protected responses. If not set the system root certificates
+	// are used.
+	CABundle []byte
+
+	// RequestType is the CMP message type used to request new certificates.
+	// Either "ir" (initialization request) or "cr" (certification request).
+	// Defaults to "cr".
+	RequestType CMPRequestType
+
+	// SharedSecret protects CMP messages with a password-based MAC using a
+	// secret shared with the CA.
+	SharedSecret *CMPSharedSecretProtection
+
+	// Signature protects CMP messages with a signature using a certificate
+	// and private key trusted by the CA.
+	Signature *CMPSignatureProtection
+}
+
+type CMPRequestType string
+
+const (
+	// CMPInitializationRequest requests new certificates using an
+	// initialization request (ir) message.
+	CMPInitializationRequest CMPRequestType = "ir"
+
+	// CMPCertificationRequest requests new certificates using a certification
+	// request (cr) message.
+	CMPCertificationRequest CMPRequestType = "cr"
+)
+
+// CMPSharedSecretProtection protects CMP messages with a password-based MAC.
+type CMPSharedSecretProtection struct {
+	// Reference is the reference value the CA associated with the shared
+	// secret, sent as the sender key identifier of each message.
+	Reference string
+
+	// SecretRef is a reference to a key in a Secret resource containing the
+	// shared secret.
+	SecretRef cmmeta.SecretKeySelector
+}
+
+// CMPSignatureProtection protects CMP messages with a signature.
+type CMPSignatureProtection struct {
+	// SecretRef is a reference to a Secret of type `kubernetes.io/tls`
+	// containing the certificate and private key used to sign messages in the
+	// `tls.crt` and `tls.key` keys. Any intermediate certificates in `tls.crt`
+	// are sent to the CMP server alongside the signature.
+	SecretRef cmmeta.LocalObjectReference
+}
+
 type CAIssuer struct {
 	// SecretName is the name of the secret used to sign Certificates issued
 	// by this Issuer.
 	SecretName string
 
+	// PrivateKeyPasswordSecretRef references a key in a Secret containing the
+	// password used to decrypt the CA private key, when `tls.key` in the
+	// SecretName Secret is a password protected PKCS#8 private key
+	// ("ENCRYPTED PRIVATE KEY"). Only keys encrypted using PBES2 with PBKDF2
+	// and AES or triple DES, as produced by OpenSSL, are supported.
+	PrivateKeyPasswordSecretRef *cmmeta.SecretKeySelector
+
+	// ChainSecretRef references a key in a Secret containing the PEM encoded
+	// intermediate CA certificates between the signing CA and the root CA.
+	// If set, these certificates are used to build the chain of issued
+	// certificates instead of any intermediates following the signing CA
+	// certificate in `tls.crt`.
+	ChainSecretRef *cmmeta.SecretKeySelector
+
+	// RootSecretRef references a key in a Secret containing the PEM encoded
+	// root CA certificate. If set, it is used instead of `ca.crt` in the
+	// SecretName Secret, and is set as the `ca.crt` of issued certificates.
+	RootSecretRef *cmmeta.SecretKeySelector
+
+	// SignatureAlgorithm is the algorithm used to sign certificates, which
+	// must be compatible with the CA private key. If not set, an algorithm is
+	// chosen based on the type and size of the CA private key.
+	SignatureAlgorithm SignatureAlgorithm
+
+	// SerialNumberLength is the maximum length in bytes, between 8 and 20, of
+	// the DER encoded, randomly generated serial numbers of signed
+	// certificates. If not set, serial numbers are random integers of up to
+	// 128 bits.
+	SerialNumberLength int
+
+	// DefaultDuration is the validity period of certificates signed by this
+	// issuer when the request does not specify a duration. If not set, the
+	// cert-manager default of 90 days is used.
+	DefaultDuration *metav1.Duration
+
+	// MaxDuration is the maximum validity period of certificates signed by
+	// this issuer, including any backdating. Requests for a longer duration
+	// are signed with a reduced expiry and a warning is reported on the
+	// request. Regardless of this field, signed certificates never
+	// outlive the CA certificate: their NotAfter is clamped to the expiry of
+	// the CA certificate and a warning is reported on the request.
+	MaxDuration *metav1.Duration
+
+	// Backdate is the amount of time the NotBefore of signed certificates is
+	// set in the past, to tolerate clock skew between cert-manager and the
+	// clients validating them. It does not extend their NotAfter.
+	Backdate *metav1.Duration
+
 	// The CRL distribution points is an X.509 v3 certificate extension which identifies
 	// the location of the CRL from which the revocation of this certificate can be checked.
 	// If not set, certificates will be issued without distribution points set.
@@ -256,8 +556,91 @@ type CAIssuer struct {
 	// certificate will be issued with no OCSP servers set. For example, an
 	// OCSP server URL could be "http://ocsp.int-x3.letsencrypt.org".
 	OCSPServers []string
+
+	// Revocation configures cert-manager to keep track of the certificates
+	// signed by this issuer so that they can later be revoked, and to publish
+	// a certificate revocation list (CRL) for them.
+	// If not set, certificates signed by this issuer cannot be revoked.
+	Revocation *CARevocation
+}
+
+// CARevocation configures revocation of certificates signed by a CA issuer.
+type CARevocation struct {
+	// SecretName is the name prefix of the Secrets used to persist the serial
+	// numbers of all certificates signed by this issuer, along with any
+	// revocations. Certificates are spread across up to 256 Secrets named
+	// `<secretName>-00` to `<secretName>-ff` by the last byte of their serial
+	// number. The Secrets are created if they do not exist, and live in the
+	// same namespace as the signing CA Secret.
+	SecretName string
+
+	// CRL configures the generation of a certificate revocation list (CRL)
+	// signed by the CA. The CRL is also served over HTTP by the cert-manager
+	// controller when it is started with `--crl-server-listen-address`.
+	CRL *CACRL
+
+	// OCSP configures an OCSP responder for certificates signed by this
+	// issuer, answering requests from the revocation database. The responder
+	// is served by the cert-manager controller when it is started with
+	// `--ocsp-server-listen-address`.
+	OCSP *CAOCSP
 }
 
+// CACRL configures where and how often a CA issuer publishes its CRL.
+// Exactly one of SecretName or ConfigMapName must be set.
+type CACRL struct {
+	// SecretName is the name of a Secret to publish the PEM encoded CRL to,
+	// under the `ca.crl` key.
+	SecretName string
+
+	// ConfigMapName is the name of a ConfigMap to publish the PEM encoded CRL
+	// to, under the `ca.crl` key.
+	ConfigMapName string
+
+	// Duration is the period of validity of each generated CRL, i.e. the
+	// time between its thisUpdate and nextUpdate fields. A new CRL is
+	// generated once two thirds of this period have elapsed, or as soon as a
+	// certificate is revoked. Defaults to 24 hours.
+	Duration *metav1.Duration
+}
+
+// CAOCSP configures the OCSP responder of a CA issuer.
+type CAOCSP struct {
+	// ResponderSecretName is the name of a Secret containing the delegated
+	// OCSP responder certificate and private key used to sign OCSP responses,
+	// under the `tls.crt` and `tls.key` keys. The certificate must be signed
+	// by this issuer's CA and have the `ocsp signing` usage; it would usually
+	// be managed by a Certificate resource referencing this issuer.
+	ResponderSecretName string
+
+	// ResponseDuration is the period of validity of OCSP responses, i.e. the
+	// time between their thisUpdate and nextUpdate fields. Defaults to 1 hour.
+	ResponseDuration *metav1.Duration
+}
+
+// SignatureAlgorithm is an algorithm used by a CA issuer to sign certificates.
+type SignatureAlgorithm string
+
+const (
+	// PKCS#1 v1.5 signature algorithms, which require an RSA key.
+	SHA256WithRSA SignatureAlgorithm = "SHA256WithRSA"
+	SHA384WithRSA SignatureAlgorithm = "SHA384WithRSA"
+	SHA512WithRSA SignatureAlgorithm = "SHA512WithRSA"
+
+	// RSA-PSS signature algorithms, which require an RSA key.
+	SHA256WithRSAPSS SignatureAlgorithm = "SHA256WithRSAPSS"
+	SHA384WithRSAPSS SignatureAlgorithm = "SHA384WithRSAPSS"
+	SHA512WithRSAPSS SignatureAlgorithm = "SHA512WithRSAPSS"
+
+	// ECDSA signature algorithms, which require an ECDSA key.
+	ECDSAWithSHA256 SignatureAlgorithm = "ECDSAWithSHA256"
+	ECDSAWithSHA384 SignatureAlgorithm = "ECDSAWithSHA384"
+	ECDSAWithSHA512 SignatureAlgorithm = "ECDSAWithSHA512"
+
+	// The Ed25519 signature algorithm, which requires an Ed25519 key.
+	PureEd25519 SignatureAlgorithm = "PureEd25519"
+)
+
 // IssuerStatus contains status information about an Issuer
 type IssuerStatus struct {
 	// List of status conditions to indicate the status of a CertificateRequest.
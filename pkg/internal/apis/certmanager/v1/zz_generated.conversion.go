@@ -25,12 +25,12 @@ import (
 
 	apisacmev1 "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
-	apismetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	pkgapismetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	acme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
 	acmev1 "github.com/jetstack/cert-manager/pkg/internal/apis/acme/v1"
 	certmanager "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
 	meta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
-	internalapismetav1 "github.com/jetstack/cert-manager/pkg/internal/apis/meta/v1"
+	apismetav1 "github.com/jetstack/cert-manager/pkg/internal/apis/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -43,6 +43,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1.CACRL)(nil), (*certmanager.CACRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CACRL_To_certmanager_CACRL(a.(*v1.CACRL), b.(*certmanager.CACRL), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CACRL)(nil), (*v1.CACRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CACRL_To_v1_CACRL(a.(*certmanager.CACRL), b.(*v1.CACRL), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CAIssuer_To_certmanager_CAIssuer(a.(*v1.CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -53,6 +63,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CAOCSP)(nil), (*certmanager.CAOCSP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CAOCSP_To_certmanager_CAOCSP(a.(*v1.CAOCSP), b.(*certmanager.CAOCSP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAOCSP)(nil), (*v1.CAOCSP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAOCSP_To_v1_CAOCSP(a.(*certmanager.CAOCSP), b.(*v1.CAOCSP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CARevocation)(nil), (*certmanager.CARevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CARevocation_To_certmanager_CARevocation(a.(*v1.CARevocation), b.(*certmanager.CARevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CARevocation)(nil), (*v1.CARevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CARevocation_To_v1_CARevocation(a.(*certmanager.CARevocation), b.(*v1.CARevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CMPIssuer)(nil), (*certmanager.CMPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CMPIssuer_To_certmanager_CMPIssuer(a.(*v1.CMPIssuer), b.(*certmanager.CMPIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CMPIssuer)(nil), (*v1.CMPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CMPIssuer_To_v1_CMPIssuer(a.(*certmanager.CMPIssuer), b.(*v1.CMPIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CMPSharedSecretProtection)(nil), (*certmanager.CMPSharedSecretProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(a.(*v1.CMPSharedSecretProtection), b.(*certmanager.CMPSharedSecretProtection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CMPSharedSecretProtection)(nil), (*v1.CMPSharedSecretProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CMPSharedSecretProtection_To_v1_CMPSharedSecretProtection(a.(*certmanager.CMPSharedSecretProtection), b.(*v1.CMPSharedSecretProtection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CMPSignatureProtection)(nil), (*certmanager.CMPSignatureProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(a.(*v1.CMPSignatureProtection), b.(*certmanager.CMPSignatureProtection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CMPSignatureProtection)(nil), (*v1.CMPSignatureProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CMPSignatureProtection_To_v1_CMPSignatureProtection(a.(*certmanager.CMPSignatureProtection), b.(*v1.CMPSignatureProtection), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Certificate_To_certmanager_Certificate(a.(*v1.Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -93,6 +153,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateOCSPStapling)(nil), (*certmanager.CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(a.(*v1.CertificateOCSPStapling), b.(*certmanager.CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateOCSPStapling)(nil), (*v1.CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateOCSPStapling_To_v1_CertificateOCSPStapling(a.(*certmanager.CertificateOCSPStapling), b.(*v1.CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificatePrivateKey)(nil), (*certmanager.CertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(a.(*v1.CertificatePrivateKey), b.(*certmanager.CertificatePrivateKey), scope)
 	}); err != nil {
@@ -103,6 +173,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRenewalInfo)(nil), (*certmanager.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(a.(*v1.CertificateRenewalInfo), b.(*certmanager.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalInfo)(nil), (*v1.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalInfo_To_v1_CertificateRenewalInfo(a.(*certmanager.CertificateRenewalInfo), b.(*v1.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -153,6 +233,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRevocation)(nil), (*certmanager.CertificateRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRevocation_To_certmanager_CertificateRevocation(a.(*v1.CertificateRevocation), b.(*certmanager.CertificateRevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocation)(nil), (*v1.CertificateRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocation_To_v1_CertificateRevocation(a.(*certmanager.CertificateRevocation), b.(*v1.CertificateRevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRevocationCheck)(nil), (*certmanager.CertificateRevocationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(a.(*v1.CertificateRevocationCheck), b.(*certmanager.CertificateRevocationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocationCheck)(nil), (*v1.CertificateRevocationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocationCheck_To_v1_CertificateRevocationCheck(a.(*certmanager.CertificateRevocationCheck), b.(*v1.CertificateRevocationCheck), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*v1.CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
 	}); err != nil {
@@ -193,6 +293,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ESTAuth)(nil), (*certmanager.ESTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ESTAuth_To_certmanager_ESTAuth(a.(*v1.ESTAuth), b.(*certmanager.ESTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTAuth)(nil), (*v1.ESTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTAuth_To_v1_ESTAuth(a.(*certmanager.ESTAuth), b.(*v1.ESTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ESTBasicAuth)(nil), (*certmanager.ESTBasicAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ESTBasicAuth_To_certmanager_ESTBasicAuth(a.(*v1.ESTBasicAuth), b.(*certmanager.ESTBasicAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTBasicAuth)(nil), (*v1.ESTBasicAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTBasicAuth_To_v1_ESTBasicAuth(a.(*certmanager.ESTBasicAuth), b.(*v1.ESTBasicAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ESTClientCertificateAuth)(nil), (*certmanager.ESTClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(a.(*v1.ESTClientCertificateAuth), b.(*certmanager.ESTClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTClientCertificateAuth)(nil), (*v1.ESTClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTClientCertificateAuth_To_v1_ESTClientCertificateAuth(a.(*certmanager.ESTClientCertificateAuth), b.(*v1.ESTClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ESTIssuer)(nil), (*certmanager.ESTIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ESTIssuer_To_certmanager_ESTIssuer(a.(*v1.ESTIssuer), b.(*certmanager.ESTIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTIssuer)(nil), (*v1.ESTIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTIssuer_To_v1_ESTIssuer(a.(*certmanager.ESTIssuer), b.(*v1.ESTIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Issuer_To_certmanager_Issuer(a.(*v1.Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -233,6 +373,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerNamespaceAccess)(nil), (*certmanager.IssuerNamespaceAccess)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(a.(*v1.IssuerNamespaceAccess), b.(*certmanager.IssuerNamespaceAccess), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerNamespaceAccess)(nil), (*v1.IssuerNamespaceAccess)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerNamespaceAccess_To_v1_IssuerNamespaceAccess(a.(*certmanager.IssuerNamespaceAccess), b.(*v1.IssuerNamespaceAccess), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.IssuerSpec)(nil), (*certmanager.IssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(a.(*v1.IssuerSpec), b.(*certmanager.IssuerSpec), scope)
 	}); err != nil {
@@ -263,6 +413,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.NameConstraintItem)(nil), (*certmanager.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_NameConstraintItem_To_certmanager_NameConstraintItem(a.(*v1.NameConstraintItem), b.(*certmanager.NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraintItem)(nil), (*v1.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraintItem_To_v1_NameConstraintItem(a.(*certmanager.NameConstraintItem), b.(*v1.NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.NameConstraints)(nil), (*certmanager.NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_NameConstraints_To_certmanager_NameConstraints(a.(*v1.NameConstraints), b.(*certmanager.NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraints)(nil), (*v1.NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraints_To_v1_NameConstraints(a.(*certmanager.NameConstraints), b.(*v1.NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*v1.PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
@@ -273,6 +443,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.SCEPIssuer)(nil), (*certmanager.SCEPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_SCEPIssuer_To_certmanager_SCEPIssuer(a.(*v1.SCEPIssuer), b.(*certmanager.SCEPIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.SCEPIssuer)(nil), (*v1.SCEPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SCEPIssuer_To_v1_SCEPIssuer(a.(*certmanager.SCEPIssuer), b.(*v1.SCEPIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -283,6 +463,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ServiceAccountRef)(nil), (*certmanager.ServiceAccountRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ServiceAccountRef_To_certmanager_ServiceAccountRef(a.(*v1.ServiceAccountRef), b.(*certmanager.ServiceAccountRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ServiceAccountRef)(nil), (*v1.ServiceAccountRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ServiceAccountRef_To_v1_ServiceAccountRef(a.(*certmanager.ServiceAccountRef), b.(*v1.ServiceAccountRef), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VaultAppRole)(nil), (*certmanager.VaultAppRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VaultAppRole_To_certmanager_VaultAppRole(a.(*v1.VaultAppRole), b.(*certmanager.VaultAppRole), scope)
 	}); err != nil {
@@ -303,6 +493,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultClientCertificateAuth)(nil), (*certmanager.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(a.(*v1.VaultClientCertificateAuth), b.(*certmanager.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultClientCertificateAuth)(nil), (*v1.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(a.(*certmanager.VaultClientCertificateAuth), b.(*v1.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VaultIssuer)(nil), (*certmanager.VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VaultIssuer_To_certmanager_VaultIssuer(a.(*v1.VaultIssuer), b.(*certmanager.VaultIssuer), scope)
 	}); err != nil {
@@ -313,6 +513,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultJWTAuth)(nil), (*certmanager.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultJWTAuth_To_certmanager_VaultJWTAuth(a.(*v1.VaultJWTAuth), b.(*certmanager.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultJWTAuth)(nil), (*v1.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultJWTAuth_To_v1_VaultJWTAuth(a.(*certmanager.VaultJWTAuth), b.(*v1.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VaultKubernetesAuth)(nil), (*certmanager.VaultKubernetesAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(a.(*v1.VaultKubernetesAuth), b.(*certmanager.VaultKubernetesAuth), scope)
 	}); err != nil {
@@ -376,10 +586,67 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1_CACRL_To_certmanager_CACRL(in *v1.CACRL, out *certmanager.CACRL, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.ConfigMapName = in.ConfigMapName
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_v1_CACRL_To_certmanager_CACRL is an autogenerated conversion function.
+func Convert_v1_CACRL_To_certmanager_CACRL(in *v1.CACRL, out *certmanager.CACRL, s conversion.Scope) error {
+	return autoConvert_v1_CACRL_To_certmanager_CACRL(in, out, s)
+}
+
+func autoConvert_certmanager_CACRL_To_v1_CACRL(in *certmanager.CACRL, out *v1.CACRL, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.ConfigMapName = in.ConfigMapName
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_certmanager_CACRL_To_v1_CACRL is an autogenerated conversion function.
+func Convert_certmanager_CACRL_To_v1_CACRL(in *certmanager.CACRL, out *v1.CACRL, s conversion.Scope) error {
+	return autoConvert_certmanager_CACRL_To_v1_CACRL(in, out, s)
+}
+
 func autoConvert_v1_CAIssuer_To_certmanager_CAIssuer(in *v1.CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	if in.PrivateKeyPasswordSecretRef != nil {
+		in, out := &in.PrivateKeyPasswordSecretRef, &out.PrivateKeyPasswordSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrivateKeyPasswordSecretRef = nil
+	}
+	if in.ChainSecretRef != nil {
+		in, out := &in.ChainSecretRef, &out.ChainSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainSecretRef = nil
+	}
+	if in.RootSecretRef != nil {
+		in, out := &in.RootSecretRef, &out.RootSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RootSecretRef = nil
+	}
+	out.SignatureAlgorithm = certmanager.SignatureAlgorithm(in.SignatureAlgorithm)
+	out.SerialNumberLength = in.SerialNumberLength
+	out.DefaultDuration = (*metav1.Duration)(unsafe.Pointer(in.DefaultDuration))
+	out.MaxDuration = (*metav1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.Backdate = (*metav1.Duration)(unsafe.Pointer(in.Backdate))
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.Revocation = (*certmanager.CARevocation)(unsafe.Pointer(in.Revocation))
 	return nil
 }
 
@@ -390,8 +657,41 @@ func Convert_v1_CAIssuer_To_certmanager_CAIssuer(in *v1.CAIssuer, out *certmanag
 
 func autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, out *v1.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	if in.PrivateKeyPasswordSecretRef != nil {
+		in, out := &in.PrivateKeyPasswordSecretRef, &out.PrivateKeyPasswordSecretRef
+		*out = new(pkgapismetav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrivateKeyPasswordSecretRef = nil
+	}
+	if in.ChainSecretRef != nil {
+		in, out := &in.ChainSecretRef, &out.ChainSecretRef
+		*out = new(pkgapismetav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainSecretRef = nil
+	}
+	if in.RootSecretRef != nil {
+		in, out := &in.RootSecretRef, &out.RootSecretRef
+		*out = new(pkgapismetav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RootSecretRef = nil
+	}
+	out.SignatureAlgorithm = v1.SignatureAlgorithm(in.SignatureAlgorithm)
+	out.SerialNumberLength = in.SerialNumberLength
+	out.DefaultDuration = (*metav1.Duration)(unsafe.Pointer(in.DefaultDuration))
+	out.MaxDuration = (*metav1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.Backdate = (*metav1.Duration)(unsafe.Pointer(in.Backdate))
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.Revocation = (*v1.CARevocation)(unsafe.Pointer(in.Revocation))
 	return nil
 }
 
@@ -400,6 +700,162 @@ func Convert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, out *
 	return autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1_CAOCSP_To_certmanager_CAOCSP(in *v1.CAOCSP, out *certmanager.CAOCSP, s conversion.Scope) error {
+	out.ResponderSecretName = in.ResponderSecretName
+	out.ResponseDuration = (*metav1.Duration)(unsafe.Pointer(in.ResponseDuration))
+	return nil
+}
+
+// Convert_v1_CAOCSP_To_certmanager_CAOCSP is an autogenerated conversion function.
+func Convert_v1_CAOCSP_To_certmanager_CAOCSP(in *v1.CAOCSP, out *certmanager.CAOCSP, s conversion.Scope) error {
+	return autoConvert_v1_CAOCSP_To_certmanager_CAOCSP(in, out, s)
+}
+
+func autoConvert_certmanager_CAOCSP_To_v1_CAOCSP(in *certmanager.CAOCSP, out *v1.CAOCSP, s conversion.Scope) error {
+	out.ResponderSecretName = in.ResponderSecretName
+	out.ResponseDuration = (*metav1.Duration)(unsafe.Pointer(in.ResponseDuration))
+	return nil
+}
+
+// Convert_certmanager_CAOCSP_To_v1_CAOCSP is an autogenerated conversion function.
+func Convert_certmanager_CAOCSP_To_v1_CAOCSP(in *certmanager.CAOCSP, out *v1.CAOCSP, s conversion.Scope) error {
+	return autoConvert_certmanager_CAOCSP_To_v1_CAOCSP(in, out, s)
+}
+
+func autoConvert_v1_CARevocation_To_certmanager_CARevocation(in *v1.CARevocation, out *certmanager.CARevocation, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.CRL = (*certmanager.CACRL)(unsafe.Pointer(in.CRL))
+	out.OCSP = (*certmanager.CAOCSP)(unsafe.Pointer(in.OCSP))
+	return nil
+}
+
+// Convert_v1_CARevocation_To_certmanager_CARevocation is an autogenerated conversion function.
+func Convert_v1_CARevocation_To_certmanager_CARevocation(in *v1.CARevocation, out *certmanager.CARevocation, s conversion.Scope) error {
+	return autoConvert_v1_CARevocation_To_certmanager_CARevocation(in, out, s)
+}
+
+func autoConvert_certmanager_CARevocation_To_v1_CARevocation(in *certmanager.CARevocation, out *v1.CARevocation, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.CRL = (*v1.CACRL)(unsafe.Pointer(in.CRL))
+	out.OCSP = (*v1.CAOCSP)(unsafe.Pointer(in.OCSP))
+	return nil
+}
+
+// Convert_certmanager_CARevocation_To_v1_CARevocation is an autogenerated conversion function.
+func Convert_certmanager_CARevocation_To_v1_CARevocation(in *certmanager.CARevocation, out *v1.CARevocation, s conversion.Scope) error {
+	return autoConvert_certmanager_CARevocation_To_v1_CARevocation(in, out, s)
+}
+
+func autoConvert_v1_CMPIssuer_To_certmanager_CMPIssuer(in *v1.CMPIssuer, out *certmanager.CMPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.RequestType = certmanager.CMPRequestType(in.RequestType)
+	if in.SharedSecret != nil {
+		in, out := &in.SharedSecret, &out.SharedSecret
+		*out = new(certmanager.CMPSharedSecretProtection)
+		if err := Convert_v1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SharedSecret = nil
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(certmanager.CMPSignatureProtection)
+		if err := Convert_v1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Signature = nil
+	}
+	return nil
+}
+
+// Convert_v1_CMPIssuer_To_certmanager_CMPIssuer is an autogenerated conversion function.
+func Convert_v1_CMPIssuer_To_certmanager_CMPIssuer(in *v1.CMPIssuer, out *certmanager.CMPIssuer, s conversion.Scope) error {
+	return autoConvert_v1_CMPIssuer_To_certmanager_CMPIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_CMPIssuer_To_v1_CMPIssuer(in *certmanager.CMPIssuer, out *v1.CMPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.RequestType = v1.CMPRequestType(in.RequestType)
+	if in.SharedSecret != nil {
+		in, out := &in.SharedSecret, &out.SharedSecret
+		*out = new(v1.CMPSharedSecretProtection)
+		if err := Convert_certmanager_CMPSharedSecretProtection_To_v1_CMPSharedSecretProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SharedSecret = nil
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(v1.CMPSignatureProtection)
+		if err := Convert_certmanager_CMPSignatureProtection_To_v1_CMPSignatureProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Signature = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CMPIssuer_To_v1_CMPIssuer is an autogenerated conversion function.
+func Convert_certmanager_CMPIssuer_To_v1_CMPIssuer(in *certmanager.CMPIssuer, out *v1.CMPIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_CMPIssuer_To_v1_CMPIssuer(in, out, s)
+}
+
+func autoConvert_v1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(in *v1.CMPSharedSecretProtection, out *certmanager.CMPSharedSecretProtection, s conversion.Scope) error {
+	out.Reference = in.Reference
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection is an autogenerated conversion function.
+func Convert_v1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(in *v1.CMPSharedSecretProtection, out *certmanager.CMPSharedSecretProtection, s conversion.Scope) error {
+	return autoConvert_v1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(in, out, s)
+}
+
+func autoConvert_certmanager_CMPSharedSecretProtection_To_v1_CMPSharedSecretProtection(in *certmanager.CMPSharedSecretProtection, out *v1.CMPSharedSecretProtection, s conversion.Scope) error {
+	out.Reference = in.Reference
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CMPSharedSecretProtection_To_v1_CMPSharedSecretProtection is an autogenerated conversion function.
+func Convert_certmanager_CMPSharedSecretProtection_To_v1_CMPSharedSecretProtection(in *certmanager.CMPSharedSecretProtection, out *v1.CMPSharedSecretProtection, s conversion.Scope) error {
+	return autoConvert_certmanager_CMPSharedSecretProtection_To_v1_CMPSharedSecretProtection(in, out, s)
+}
+
+func autoConvert_v1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(in *v1.CMPSignatureProtection, out *certmanager.CMPSignatureProtection, s conversion.Scope) error {
+	if err := apismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection is an autogenerated conversion function.
+func Convert_v1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(in *v1.CMPSignatureProtection, out *certmanager.CMPSignatureProtection, s conversion.Scope) error {
+	return autoConvert_v1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(in, out, s)
+}
+
+func autoConvert_certmanager_CMPSignatureProtection_To_v1_CMPSignatureProtection(in *certmanager.CMPSignatureProtection, out *v1.CMPSignatureProtection, s conversion.Scope) error {
+	if err := apismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CMPSignatureProtection_To_v1_CMPSignatureProtection is an autogenerated conversion function.
+func Convert_certmanager_CMPSignatureProtection_To_v1_CMPSignatureProtection(in *certmanager.CMPSignatureProtection, out *v1.CMPSignatureProtection, s conversion.Scope) error {
+	return autoConvert_certmanager_CMPSignatureProtection_To_v1_CMPSignatureProtection(in, out, s)
+}
+
 func autoConvert_v1_Certificate_To_certmanager_Certificate(in *v1.Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -449,7 +905,7 @@ func Convert_v1_CertificateCondition_To_certmanager_CertificateCondition(in *v1.
 
 func autoConvert_certmanager_CertificateCondition_To_v1_CertificateCondition(in *certmanager.CertificateCondition, out *v1.CertificateCondition, s conversion.Scope) error {
 	out.Type = v1.CertificateConditionType(in.Type)
-	out.Status = apismetav1.ConditionStatus(in.Status)
+	out.Status = pkgapismetav1.ConditionStatus(in.Status)
 	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
@@ -558,6 +1014,26 @@ func Convert_certmanager_CertificateList_To_v1_CertificateList(in *certmanager.C
 	return autoConvert_certmanager_CertificateList_To_v1_CertificateList(in, out, s)
 }
 
+func autoConvert_v1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *v1.CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_v1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_v1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *v1.CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_v1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateOCSPStapling_To_v1_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *v1.CertificateOCSPStapling, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_certmanager_CertificateOCSPStapling_To_v1_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_certmanager_CertificateOCSPStapling_To_v1_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *v1.CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateOCSPStapling_To_v1_CertificateOCSPStapling(in, out, s)
+}
+
 func autoConvert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v1.CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
 	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
@@ -584,6 +1060,36 @@ func Convert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in *c
 	return autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in, out, s)
 }
 
+func autoConvert_v1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.ExplanationURL = in.ExplanationURL
+	out.LastCheckTime = in.LastCheckTime
+	out.NextCheckTime = in.NextCheckTime
+	return nil
+}
+
+// Convert_v1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_v1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalInfo_To_v1_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1.CertificateRenewalInfo, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.ExplanationURL = in.ExplanationURL
+	out.LastCheckTime = in.LastCheckTime
+	out.NextCheckTime = in.NextCheckTime
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalInfo_To_v1_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalInfo_To_v1_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalInfo_To_v1_CertificateRenewalInfo(in, out, s)
+}
+
 func autoConvert_v1_CertificateRequest_To_certmanager_CertificateRequest(in *v1.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -632,7 +1138,7 @@ func Convert_v1_CertificateRequestCondition_To_certmanager_CertificateRequestCon
 
 func autoConvert_certmanager_CertificateRequestCondition_To_v1_CertificateRequestCondition(in *certmanager.CertificateRequestCondition, out *v1.CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = v1.CertificateRequestConditionType(in.Type)
-	out.Status = apismetav1.ConditionStatus(in.Status)
+	out.Status = pkgapismetav1.ConditionStatus(in.Status)
 	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
@@ -688,7 +1194,7 @@ func Convert_certmanager_CertificateRequestList_To_v1_CertificateRequestList(in
 
 func autoConvert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in *v1.CertificateRequestSpec, out *certmanager.CertificateRequestSpec, s conversion.Scope) error {
 	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
-	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
@@ -708,7 +1214,7 @@ func Convert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in
 
 func autoConvert_certmanager_CertificateRequestSpec_To_v1_CertificateRequestSpec(in *certmanager.CertificateRequestSpec, out *v1.CertificateRequestSpec, s conversion.Scope) error {
 	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
-	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
@@ -752,6 +1258,52 @@ func Convert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestStatus(in, out, s)
 }
 
+func autoConvert_v1_CertificateRevocation_To_certmanager_CertificateRevocation(in *v1.CertificateRevocation, out *certmanager.CertificateRevocation, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.Reason = in.Reason
+	out.RevocationTime = (*metav1.Time)(unsafe.Pointer(in.RevocationTime))
+	return nil
+}
+
+// Convert_v1_CertificateRevocation_To_certmanager_CertificateRevocation is an autogenerated conversion function.
+func Convert_v1_CertificateRevocation_To_certmanager_CertificateRevocation(in *v1.CertificateRevocation, out *certmanager.CertificateRevocation, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRevocation_To_certmanager_CertificateRevocation(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocation_To_v1_CertificateRevocation(in *certmanager.CertificateRevocation, out *v1.CertificateRevocation, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.Reason = in.Reason
+	out.RevocationTime = (*metav1.Time)(unsafe.Pointer(in.RevocationTime))
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocation_To_v1_CertificateRevocation is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocation_To_v1_CertificateRevocation(in *certmanager.CertificateRevocation, out *v1.CertificateRevocation, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocation_To_v1_CertificateRevocation(in, out, s)
+}
+
+func autoConvert_v1_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(in *v1.CertificateRevocationCheck, out *certmanager.CertificateRevocationCheck, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Interval = (*metav1.Duration)(unsafe.Pointer(in.Interval))
+	return nil
+}
+
+// Convert_v1_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck is an autogenerated conversion function.
+func Convert_v1_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(in *v1.CertificateRevocationCheck, out *certmanager.CertificateRevocationCheck, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocationCheck_To_v1_CertificateRevocationCheck(in *certmanager.CertificateRevocationCheck, out *v1.CertificateRevocationCheck, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Interval = (*metav1.Duration)(unsafe.Pointer(in.Interval))
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocationCheck_To_v1_CertificateRevocationCheck is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocationCheck_To_v1_CertificateRevocationCheck(in *certmanager.CertificateRevocationCheck, out *v1.CertificateRevocationCheck, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocationCheck_To_v1_CertificateRevocationCheck(in, out, s)
+}
+
 func autoConvert_v1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
@@ -794,14 +1346,18 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	} else {
 		out.Keystores = nil
 	}
-	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.OCSPStapling = (*certmanager.CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
+	out.NameConstraints = (*certmanager.NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.PrivateKey = (*certmanager.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	out.RevocationPolicy = certmanager.CertificateRevocationPolicy(in.RevocationPolicy)
+	out.RevocationCheck = (*certmanager.CertificateRevocationCheck)(unsafe.Pointer(in.RevocationCheck))
 	return nil
 }
 
@@ -825,14 +1381,18 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	} else {
 		out.Keystores = nil
 	}
-	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.OCSPStapling = (*v1.CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
+	out.NameConstraints = (*v1.NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.Usages = *(*[]v1.KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.PrivateKey = (*v1.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	out.RevocationPolicy = v1.CertificateRevocationPolicy(in.RevocationPolicy)
+	out.RevocationCheck = (*v1.CertificateRevocationCheck)(unsafe.Pointer(in.RevocationCheck))
 	return nil
 }
 
@@ -844,6 +1404,8 @@ func autoConvert_v1_CertificateStatus_To_certmanager_CertificateStatus(in *v1.Ce
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
+	out.LastRevocation = (*certmanager.CertificateRevocation)(unsafe.Pointer(in.LastRevocation))
+	out.RenewalInfo = (*certmanager.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	return nil
 }
 
@@ -860,6 +1422,8 @@ func autoConvert_certmanager_CertificateStatus_To_v1_CertificateStatus(in *certm
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
+	out.LastRevocation = (*v1.CertificateRevocation)(unsafe.Pointer(in.LastRevocation))
+	out.RenewalInfo = (*v1.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	return nil
 }
 
@@ -942,6 +1506,154 @@ func Convert_certmanager_ClusterIssuerList_To_v1_ClusterIssuerList(in *certmanag
 	return autoConvert_certmanager_ClusterIssuerList_To_v1_ClusterIssuerList(in, out, s)
 }
 
+func autoConvert_v1_ESTAuth_To_certmanager_ESTAuth(in *v1.ESTAuth, out *certmanager.ESTAuth, s conversion.Scope) error {
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(certmanager.ESTBasicAuth)
+		if err := Convert_v1_ESTBasicAuth_To_certmanager_ESTBasicAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BasicAuth = nil
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(certmanager.ESTClientCertificateAuth)
+		if err := Convert_v1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClientCertificate = nil
+	}
+	return nil
+}
+
+// Convert_v1_ESTAuth_To_certmanager_ESTAuth is an autogenerated conversion function.
+func Convert_v1_ESTAuth_To_certmanager_ESTAuth(in *v1.ESTAuth, out *certmanager.ESTAuth, s conversion.Scope) error {
+	return autoConvert_v1_ESTAuth_To_certmanager_ESTAuth(in, out, s)
+}
+
+func autoConvert_certmanager_ESTAuth_To_v1_ESTAuth(in *certmanager.ESTAuth, out *v1.ESTAuth, s conversion.Scope) error {
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(v1.ESTBasicAuth)
+		if err := Convert_certmanager_ESTBasicAuth_To_v1_ESTBasicAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BasicAuth = nil
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(v1.ESTClientCertificateAuth)
+		if err := Convert_certmanager_ESTClientCertificateAuth_To_v1_ESTClientCertificateAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClientCertificate = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_ESTAuth_To_v1_ESTAuth is an autogenerated conversion function.
+func Convert_certmanager_ESTAuth_To_v1_ESTAuth(in *certmanager.ESTAuth, out *v1.ESTAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTAuth_To_v1_ESTAuth(in, out, s)
+}
+
+func autoConvert_v1_ESTBasicAuth_To_certmanager_ESTBasicAuth(in *v1.ESTBasicAuth, out *certmanager.ESTBasicAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ESTBasicAuth_To_certmanager_ESTBasicAuth is an autogenerated conversion function.
+func Convert_v1_ESTBasicAuth_To_certmanager_ESTBasicAuth(in *v1.ESTBasicAuth, out *certmanager.ESTBasicAuth, s conversion.Scope) error {
+	return autoConvert_v1_ESTBasicAuth_To_certmanager_ESTBasicAuth(in, out, s)
+}
+
+func autoConvert_certmanager_ESTBasicAuth_To_v1_ESTBasicAuth(in *certmanager.ESTBasicAuth, out *v1.ESTBasicAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_ESTBasicAuth_To_v1_ESTBasicAuth is an autogenerated conversion function.
+func Convert_certmanager_ESTBasicAuth_To_v1_ESTBasicAuth(in *certmanager.ESTBasicAuth, out *v1.ESTBasicAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTBasicAuth_To_v1_ESTBasicAuth(in, out, s)
+}
+
+func autoConvert_v1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(in *v1.ESTClientCertificateAuth, out *certmanager.ESTClientCertificateAuth, s conversion.Scope) error {
+	if err := apismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(in *v1.ESTClientCertificateAuth, out *certmanager.ESTClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_ESTClientCertificateAuth_To_v1_ESTClientCertificateAuth(in *certmanager.ESTClientCertificateAuth, out *v1.ESTClientCertificateAuth, s conversion.Scope) error {
+	if err := apismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_ESTClientCertificateAuth_To_v1_ESTClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_ESTClientCertificateAuth_To_v1_ESTClientCertificateAuth(in *certmanager.ESTClientCertificateAuth, out *v1.ESTClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTClientCertificateAuth_To_v1_ESTClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_v1_ESTIssuer_To_certmanager_ESTIssuer(in *v1.ESTIssuer, out *certmanager.ESTIssuer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.Label = in.Label
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(certmanager.ESTAuth)
+		if err := Convert_v1_ESTAuth_To_certmanager_ESTAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Auth = nil
+	}
+	out.Reenroll = in.Reenroll
+	return nil
+}
+
+// Convert_v1_ESTIssuer_To_certmanager_ESTIssuer is an autogenerated conversion function.
+func Convert_v1_ESTIssuer_To_certmanager_ESTIssuer(in *v1.ESTIssuer, out *certmanager.ESTIssuer, s conversion.Scope) error {
+	return autoConvert_v1_ESTIssuer_To_certmanager_ESTIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_ESTIssuer_To_v1_ESTIssuer(in *certmanager.ESTIssuer, out *v1.ESTIssuer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.Label = in.Label
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(v1.ESTAuth)
+		if err := Convert_certmanager_ESTAuth_To_v1_ESTAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Auth = nil
+	}
+	out.Reenroll = in.Reenroll
+	return nil
+}
+
+// Convert_certmanager_ESTIssuer_To_v1_ESTIssuer is an autogenerated conversion function.
+func Convert_certmanager_ESTIssuer_To_v1_ESTIssuer(in *certmanager.ESTIssuer, out *v1.ESTIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTIssuer_To_v1_ESTIssuer(in, out, s)
+}
+
 func autoConvert_v1_Issuer_To_certmanager_Issuer(in *v1.Issuer, out *certmanager.Issuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -991,7 +1703,7 @@ func Convert_v1_IssuerCondition_To_certmanager_IssuerCondition(in *v1.IssuerCond
 
 func autoConvert_certmanager_IssuerCondition_To_v1_IssuerCondition(in *certmanager.IssuerCondition, out *v1.IssuerCondition, s conversion.Scope) error {
 	out.Type = v1.IssuerConditionType(in.Type)
-	out.Status = apismetav1.ConditionStatus(in.Status)
+	out.Status = pkgapismetav1.ConditionStatus(in.Status)
 	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
@@ -1014,7 +1726,15 @@ func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(certmanager.CAIssuer)
+		if err := Convert_v1_CAIssuer_To_certmanager_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(certmanager.VaultIssuer)
@@ -1034,6 +1754,33 @@ func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig
 	} else {
 		out.Venafi = nil
 	}
+	if in.EST != nil {
+		in, out := &in.EST, &out.EST
+		*out = new(certmanager.ESTIssuer)
+		if err := Convert_v1_ESTIssuer_To_certmanager_ESTIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EST = nil
+	}
+	if in.SCEP != nil {
+		in, out := &in.SCEP, &out.SCEP
+		*out = new(certmanager.SCEPIssuer)
+		if err := Convert_v1_SCEPIssuer_To_certmanager_SCEPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SCEP = nil
+	}
+	if in.CMP != nil {
+		in, out := &in.CMP, &out.CMP
+		*out = new(certmanager.CMPIssuer)
+		if err := Convert_v1_CMPIssuer_To_certmanager_CMPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CMP = nil
+	}
 	return nil
 }
 
@@ -1052,7 +1799,15 @@ func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.Iss
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*v1.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(v1.CAIssuer)
+		if err := Convert_certmanager_CAIssuer_To_v1_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(v1.VaultIssuer)
@@ -1072,6 +1827,33 @@ func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.Iss
 	} else {
 		out.Venafi = nil
 	}
+	if in.EST != nil {
+		in, out := &in.EST, &out.EST
+		*out = new(v1.ESTIssuer)
+		if err := Convert_certmanager_ESTIssuer_To_v1_ESTIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EST = nil
+	}
+	if in.SCEP != nil {
+		in, out := &in.SCEP, &out.SCEP
+		*out = new(v1.SCEPIssuer)
+		if err := Convert_certmanager_SCEPIssuer_To_v1_SCEPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SCEP = nil
+	}
+	if in.CMP != nil {
+		in, out := &in.CMP, &out.CMP
+		*out = new(v1.CMPIssuer)
+		if err := Convert_certmanager_CMPIssuer_To_v1_CMPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CMP = nil
+	}
 	return nil
 }
 
@@ -1122,10 +1904,33 @@ func Convert_certmanager_IssuerList_To_v1_IssuerList(in *certmanager.IssuerList,
 	return autoConvert_certmanager_IssuerList_To_v1_IssuerList(in, out, s)
 }
 
+func autoConvert_v1_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(in *v1.IssuerNamespaceAccess, out *certmanager.IssuerNamespaceAccess, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespaceSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	return nil
+}
+
+// Convert_v1_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess is an autogenerated conversion function.
+func Convert_v1_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(in *v1.IssuerNamespaceAccess, out *certmanager.IssuerNamespaceAccess, s conversion.Scope) error {
+	return autoConvert_v1_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerNamespaceAccess_To_v1_IssuerNamespaceAccess(in *certmanager.IssuerNamespaceAccess, out *v1.IssuerNamespaceAccess, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespaceSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	return nil
+}
+
+// Convert_certmanager_IssuerNamespaceAccess_To_v1_IssuerNamespaceAccess is an autogenerated conversion function.
+func Convert_certmanager_IssuerNamespaceAccess_To_v1_IssuerNamespaceAccess(in *certmanager.IssuerNamespaceAccess, out *v1.IssuerNamespaceAccess, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerNamespaceAccess_To_v1_IssuerNamespaceAccess(in, out, s)
+}
+
 func autoConvert_v1_IssuerSpec_To_certmanager_IssuerSpec(in *v1.IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
 	if err := Convert_v1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.NamespaceAccess = (*certmanager.IssuerNamespaceAccess)(unsafe.Pointer(in.NamespaceAccess))
 	return nil
 }
 
@@ -1138,6 +1943,7 @@ func autoConvert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerS
 	if err := Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.NamespaceAccess = (*v1.IssuerNamespaceAccess)(unsafe.Pointer(in.NamespaceAccess))
 	return nil
 }
 
@@ -1186,7 +1992,7 @@ func Convert_certmanager_IssuerStatus_To_v1_IssuerStatus(in *certmanager.IssuerS
 
 func autoConvert_v1_JKSKeystore_To_certmanager_JKSKeystore(in *v1.JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1199,7 +2005,7 @@ func Convert_v1_JKSKeystore_To_certmanager_JKSKeystore(in *v1.JKSKeystore, out *
 
 func autoConvert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeystore, out *v1.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1210,9 +2016,59 @@ func Convert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeysto
 	return autoConvert_certmanager_JKSKeystore_To_v1_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1_NameConstraintItem_To_certmanager_NameConstraintItem(in *v1.NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.URIDomains = *(*[]string)(unsafe.Pointer(&in.URIDomains))
+	return nil
+}
+
+// Convert_v1_NameConstraintItem_To_certmanager_NameConstraintItem is an autogenerated conversion function.
+func Convert_v1_NameConstraintItem_To_certmanager_NameConstraintItem(in *v1.NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_v1_NameConstraintItem_To_certmanager_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraintItem_To_v1_NameConstraintItem(in *certmanager.NameConstraintItem, out *v1.NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.URIDomains = *(*[]string)(unsafe.Pointer(&in.URIDomains))
+	return nil
+}
+
+// Convert_certmanager_NameConstraintItem_To_v1_NameConstraintItem is an autogenerated conversion function.
+func Convert_certmanager_NameConstraintItem_To_v1_NameConstraintItem(in *certmanager.NameConstraintItem, out *v1.NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraintItem_To_v1_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_v1_NameConstraints_To_certmanager_NameConstraints(in *v1.NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	out.Critical = in.Critical
+	out.Permitted = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_v1_NameConstraints_To_certmanager_NameConstraints is an autogenerated conversion function.
+func Convert_v1_NameConstraints_To_certmanager_NameConstraints(in *v1.NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	return autoConvert_v1_NameConstraints_To_certmanager_NameConstraints(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraints_To_v1_NameConstraints(in *certmanager.NameConstraints, out *v1.NameConstraints, s conversion.Scope) error {
+	out.Critical = in.Critical
+	out.Permitted = (*v1.NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*v1.NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_certmanager_NameConstraints_To_v1_NameConstraints is an autogenerated conversion function.
+func Convert_certmanager_NameConstraints_To_v1_NameConstraints(in *certmanager.NameConstraints, out *v1.NameConstraints, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraints_To_v1_NameConstraints(in, out, s)
+}
+
 func autoConvert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1225,7 +2081,7 @@ func Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1.PKCS12Keysto
 
 func autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *v1.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1236,6 +2092,46 @@ func Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKC
 	return autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1_SCEPIssuer_To_certmanager_SCEPIssuer(in *v1.SCEPIssuer, out *certmanager.SCEPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.ChallengePasswordSecretRef != nil {
+		in, out := &in.ChallengePasswordSecretRef, &out.ChallengePasswordSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChallengePasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1_SCEPIssuer_To_certmanager_SCEPIssuer is an autogenerated conversion function.
+func Convert_v1_SCEPIssuer_To_certmanager_SCEPIssuer(in *v1.SCEPIssuer, out *certmanager.SCEPIssuer, s conversion.Scope) error {
+	return autoConvert_v1_SCEPIssuer_To_certmanager_SCEPIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_SCEPIssuer_To_v1_SCEPIssuer(in *certmanager.SCEPIssuer, out *v1.SCEPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.ChallengePasswordSecretRef != nil {
+		in, out := &in.ChallengePasswordSecretRef, &out.ChallengePasswordSecretRef
+		*out = new(pkgapismetav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChallengePasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_SCEPIssuer_To_v1_SCEPIssuer is an autogenerated conversion function.
+func Convert_certmanager_SCEPIssuer_To_v1_SCEPIssuer(in *certmanager.SCEPIssuer, out *v1.SCEPIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_SCEPIssuer_To_v1_SCEPIssuer(in, out, s)
+}
+
 func autoConvert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1256,10 +2152,30 @@ func Convert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in *certmanager
 	return autoConvert_certmanager_SelfSignedIssuer_To_v1_SelfSignedIssuer(in, out, s)
 }
 
+func autoConvert_v1_ServiceAccountRef_To_certmanager_ServiceAccountRef(in *v1.ServiceAccountRef, out *certmanager.ServiceAccountRef, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1_ServiceAccountRef_To_certmanager_ServiceAccountRef is an autogenerated conversion function.
+func Convert_v1_ServiceAccountRef_To_certmanager_ServiceAccountRef(in *v1.ServiceAccountRef, out *certmanager.ServiceAccountRef, s conversion.Scope) error {
+	return autoConvert_v1_ServiceAccountRef_To_certmanager_ServiceAccountRef(in, out, s)
+}
+
+func autoConvert_certmanager_ServiceAccountRef_To_v1_ServiceAccountRef(in *certmanager.ServiceAccountRef, out *v1.ServiceAccountRef, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_ServiceAccountRef_To_v1_ServiceAccountRef is an autogenerated conversion function.
+func Convert_certmanager_ServiceAccountRef_To_v1_ServiceAccountRef(in *certmanager.ServiceAccountRef, out *v1.ServiceAccountRef, s conversion.Scope) error {
+	return autoConvert_certmanager_ServiceAccountRef_To_v1_ServiceAccountRef(in, out, s)
+}
+
 func autoConvert_v1_VaultAppRole_To_certmanager_VaultAppRole(in *v1.VaultAppRole, out *certmanager.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1273,7 +2189,7 @@ func Convert_v1_VaultAppRole_To_certmanager_VaultAppRole(in *v1.VaultAppRole, ou
 func autoConvert_certmanager_VaultAppRole_To_v1_VaultAppRole(in *certmanager.VaultAppRole, out *v1.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1288,7 +2204,7 @@ func autoConvert_v1_VaultAuth_To_certmanager_VaultAuth(in *v1.VaultAuth, out *ce
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(meta.SecretKeySelector)
-		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1312,6 +2228,8 @@ func autoConvert_v1_VaultAuth_To_certmanager_VaultAuth(in *v1.VaultAuth, out *ce
 	} else {
 		out.Kubernetes = nil
 	}
+	out.JWT = (*certmanager.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*certmanager.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1323,8 +2241,8 @@ func Convert_v1_VaultAuth_To_certmanager_VaultAuth(in *v1.VaultAuth, out *certma
 func autoConvert_certmanager_VaultAuth_To_v1_VaultAuth(in *certmanager.VaultAuth, out *v1.VaultAuth, s conversion.Scope) error {
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
-		*out = new(apismetav1.SecretKeySelector)
-		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		*out = new(pkgapismetav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1348,6 +2266,8 @@ func autoConvert_certmanager_VaultAuth_To_v1_VaultAuth(in *certmanager.VaultAuth
 	} else {
 		out.Kubernetes = nil
 	}
+	out.JWT = (*v1.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*v1.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1356,12 +2276,38 @@ func Convert_certmanager_VaultAuth_To_v1_VaultAuth(in *certmanager.VaultAuth, ou
 	return autoConvert_certmanager_VaultAuth_To_v1_VaultAuth(in, out, s)
 }
 
+func autoConvert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(in, out, s)
+}
+
 func autoConvert_v1_VaultIssuer_To_certmanager_VaultIssuer(in *v1.VaultIssuer, out *certmanager.VaultIssuer, s conversion.Scope) error {
 	if err := Convert_v1_VaultAuth_To_certmanager_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
 		return err
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
+	out.SignVerbatim = in.SignVerbatim
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
@@ -1378,6 +2324,8 @@ func autoConvert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.Vault
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
+	out.SignVerbatim = in.SignVerbatim
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
@@ -1388,9 +2336,39 @@ func Convert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.VaultIssu
 	return autoConvert_certmanager_VaultIssuer_To_v1_VaultIssuer(in, out, s)
 }
 
+func autoConvert_v1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	if err := Convert_v1_ServiceAccountRef_To_certmanager_ServiceAccountRef(&in.ServiceAccountRef, &out.ServiceAccountRef, s); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_v1_VaultJWTAuth_To_certmanager_VaultJWTAuth is an autogenerated conversion function.
+func Convert_v1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_v1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultJWTAuth_To_v1_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	if err := Convert_certmanager_ServiceAccountRef_To_v1_ServiceAccountRef(&in.ServiceAccountRef, &out.ServiceAccountRef, s); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_certmanager_VaultJWTAuth_To_v1_VaultJWTAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultJWTAuth_To_v1_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultJWTAuth_To_v1_VaultJWTAuth(in, out, s)
+}
+
 func autoConvert_v1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1.VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1404,7 +2382,7 @@ func Convert_v1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1.Va
 
 func autoConvert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in *certmanager.VaultKubernetesAuth, out *v1.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1418,7 +2396,7 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in *certm
 
 func autoConvert_v1_VenafiCloud_To_certmanager_VenafiCloud(in *v1.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1431,7 +2409,7 @@ func Convert_v1_VenafiCloud_To_certmanager_VenafiCloud(in *v1.VenafiCloud, out *
 
 func autoConvert_certmanager_VenafiCloud_To_v1_VenafiCloud(in *certmanager.VenafiCloud, out *v1.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1500,7 +2478,7 @@ func Convert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in *certmanager.VenafiI
 
 func autoConvert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := apismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
@@ -1514,7 +2492,7 @@ func Convert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *certma
 
 func autoConvert_certmanager_VenafiTPP_To_v1_VenafiTPP(in *certmanager.VenafiTPP, out *v1.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := internalapismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := apismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
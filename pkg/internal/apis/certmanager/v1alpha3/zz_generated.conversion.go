@@ -25,12 +25,12 @@ import (
 
 	apisacmev1alpha3 "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha3"
 	v1alpha3 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha3"
-	metav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	apismetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	acme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
 	acmev1alpha3 "github.com/jetstack/cert-manager/pkg/internal/apis/acme/v1alpha3"
 	certmanager "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
 	meta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
-	apismetav1 "github.com/jetstack/cert-manager/pkg/internal/apis/meta/v1"
+	metav1 "github.com/jetstack/cert-manager/pkg/internal/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -43,6 +43,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.CACRL)(nil), (*certmanager.CACRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CACRL_To_certmanager_CACRL(a.(*v1alpha3.CACRL), b.(*certmanager.CACRL), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CACRL)(nil), (*v1alpha3.CACRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CACRL_To_v1alpha3_CACRL(a.(*certmanager.CACRL), b.(*v1alpha3.CACRL), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(a.(*v1alpha3.CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -53,6 +63,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.CAOCSP)(nil), (*certmanager.CAOCSP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CAOCSP_To_certmanager_CAOCSP(a.(*v1alpha3.CAOCSP), b.(*certmanager.CAOCSP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAOCSP)(nil), (*v1alpha3.CAOCSP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAOCSP_To_v1alpha3_CAOCSP(a.(*certmanager.CAOCSP), b.(*v1alpha3.CAOCSP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.CARevocation)(nil), (*certmanager.CARevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CARevocation_To_certmanager_CARevocation(a.(*v1alpha3.CARevocation), b.(*certmanager.CARevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CARevocation)(nil), (*v1alpha3.CARevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CARevocation_To_v1alpha3_CARevocation(a.(*certmanager.CARevocation), b.(*v1alpha3.CARevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.CMPIssuer)(nil), (*certmanager.CMPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CMPIssuer_To_certmanager_CMPIssuer(a.(*v1alpha3.CMPIssuer), b.(*certmanager.CMPIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CMPIssuer)(nil), (*v1alpha3.CMPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CMPIssuer_To_v1alpha3_CMPIssuer(a.(*certmanager.CMPIssuer), b.(*v1alpha3.CMPIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.CMPSharedSecretProtection)(nil), (*certmanager.CMPSharedSecretProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(a.(*v1alpha3.CMPSharedSecretProtection), b.(*certmanager.CMPSharedSecretProtection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CMPSharedSecretProtection)(nil), (*v1alpha3.CMPSharedSecretProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CMPSharedSecretProtection_To_v1alpha3_CMPSharedSecretProtection(a.(*certmanager.CMPSharedSecretProtection), b.(*v1alpha3.CMPSharedSecretProtection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.CMPSignatureProtection)(nil), (*certmanager.CMPSignatureProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(a.(*v1alpha3.CMPSignatureProtection), b.(*certmanager.CMPSignatureProtection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CMPSignatureProtection)(nil), (*v1alpha3.CMPSignatureProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CMPSignatureProtection_To_v1alpha3_CMPSignatureProtection(a.(*certmanager.CMPSignatureProtection), b.(*v1alpha3.CMPSignatureProtection), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Certificate_To_certmanager_Certificate(a.(*v1alpha3.Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -93,11 +153,31 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.CertificateOCSPStapling)(nil), (*certmanager.CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(a.(*v1alpha3.CertificateOCSPStapling), b.(*certmanager.CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateOCSPStapling)(nil), (*v1alpha3.CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateOCSPStapling_To_v1alpha3_CertificateOCSPStapling(a.(*certmanager.CertificateOCSPStapling), b.(*v1alpha3.CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.CertificatePrivateKey)(nil), (*certmanager.CertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(a.(*v1alpha3.CertificatePrivateKey), b.(*certmanager.CertificatePrivateKey), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.CertificateRenewalInfo)(nil), (*certmanager.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(a.(*v1alpha3.CertificateRenewalInfo), b.(*certmanager.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalInfo)(nil), (*v1alpha3.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalInfo_To_v1alpha3_CertificateRenewalInfo(a.(*certmanager.CertificateRenewalInfo), b.(*v1alpha3.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1alpha3.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -138,6 +218,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.CertificateRevocation)(nil), (*certmanager.CertificateRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateRevocation_To_certmanager_CertificateRevocation(a.(*v1alpha3.CertificateRevocation), b.(*certmanager.CertificateRevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocation)(nil), (*v1alpha3.CertificateRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocation_To_v1alpha3_CertificateRevocation(a.(*certmanager.CertificateRevocation), b.(*v1alpha3.CertificateRevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.CertificateRevocationCheck)(nil), (*certmanager.CertificateRevocationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(a.(*v1alpha3.CertificateRevocationCheck), b.(*certmanager.CertificateRevocationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocationCheck)(nil), (*v1alpha3.CertificateRevocationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocationCheck_To_v1alpha3_CertificateRevocationCheck(a.(*certmanager.CertificateRevocationCheck), b.(*v1alpha3.CertificateRevocationCheck), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*v1alpha3.CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
 	}); err != nil {
@@ -178,6 +278,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ESTAuth)(nil), (*certmanager.ESTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ESTAuth_To_certmanager_ESTAuth(a.(*v1alpha3.ESTAuth), b.(*certmanager.ESTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTAuth)(nil), (*v1alpha3.ESTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTAuth_To_v1alpha3_ESTAuth(a.(*certmanager.ESTAuth), b.(*v1alpha3.ESTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ESTBasicAuth)(nil), (*certmanager.ESTBasicAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ESTBasicAuth_To_certmanager_ESTBasicAuth(a.(*v1alpha3.ESTBasicAuth), b.(*certmanager.ESTBasicAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTBasicAuth)(nil), (*v1alpha3.ESTBasicAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTBasicAuth_To_v1alpha3_ESTBasicAuth(a.(*certmanager.ESTBasicAuth), b.(*v1alpha3.ESTBasicAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ESTClientCertificateAuth)(nil), (*certmanager.ESTClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(a.(*v1alpha3.ESTClientCertificateAuth), b.(*certmanager.ESTClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTClientCertificateAuth)(nil), (*v1alpha3.ESTClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTClientCertificateAuth_To_v1alpha3_ESTClientCertificateAuth(a.(*certmanager.ESTClientCertificateAuth), b.(*v1alpha3.ESTClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ESTIssuer)(nil), (*certmanager.ESTIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ESTIssuer_To_certmanager_ESTIssuer(a.(*v1alpha3.ESTIssuer), b.(*certmanager.ESTIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTIssuer)(nil), (*v1alpha3.ESTIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTIssuer_To_v1alpha3_ESTIssuer(a.(*certmanager.ESTIssuer), b.(*v1alpha3.ESTIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Issuer_To_certmanager_Issuer(a.(*v1alpha3.Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -218,6 +358,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.IssuerNamespaceAccess)(nil), (*certmanager.IssuerNamespaceAccess)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(a.(*v1alpha3.IssuerNamespaceAccess), b.(*certmanager.IssuerNamespaceAccess), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerNamespaceAccess)(nil), (*v1alpha3.IssuerNamespaceAccess)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerNamespaceAccess_To_v1alpha3_IssuerNamespaceAccess(a.(*certmanager.IssuerNamespaceAccess), b.(*v1alpha3.IssuerNamespaceAccess), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.IssuerSpec)(nil), (*certmanager.IssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_IssuerSpec_To_certmanager_IssuerSpec(a.(*v1alpha3.IssuerSpec), b.(*certmanager.IssuerSpec), scope)
 	}); err != nil {
@@ -248,6 +398,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.NameConstraintItem)(nil), (*certmanager.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem(a.(*v1alpha3.NameConstraintItem), b.(*certmanager.NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraintItem)(nil), (*v1alpha3.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraintItem_To_v1alpha3_NameConstraintItem(a.(*certmanager.NameConstraintItem), b.(*v1alpha3.NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.NameConstraints)(nil), (*certmanager.NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NameConstraints_To_certmanager_NameConstraints(a.(*v1alpha3.NameConstraints), b.(*certmanager.NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraints)(nil), (*v1alpha3.NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraints_To_v1alpha3_NameConstraints(a.(*certmanager.NameConstraints), b.(*v1alpha3.NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*v1alpha3.PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
@@ -258,6 +428,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.SCEPIssuer)(nil), (*certmanager.SCEPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SCEPIssuer_To_certmanager_SCEPIssuer(a.(*v1alpha3.SCEPIssuer), b.(*certmanager.SCEPIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.SCEPIssuer)(nil), (*v1alpha3.SCEPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SCEPIssuer_To_v1alpha3_SCEPIssuer(a.(*certmanager.SCEPIssuer), b.(*v1alpha3.SCEPIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1alpha3.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -268,6 +448,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ServiceAccountRef)(nil), (*certmanager.ServiceAccountRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ServiceAccountRef_To_certmanager_ServiceAccountRef(a.(*v1alpha3.ServiceAccountRef), b.(*certmanager.ServiceAccountRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ServiceAccountRef)(nil), (*v1alpha3.ServiceAccountRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ServiceAccountRef_To_v1alpha3_ServiceAccountRef(a.(*certmanager.ServiceAccountRef), b.(*v1alpha3.ServiceAccountRef), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.VaultAppRole)(nil), (*certmanager.VaultAppRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VaultAppRole_To_certmanager_VaultAppRole(a.(*v1alpha3.VaultAppRole), b.(*certmanager.VaultAppRole), scope)
 	}); err != nil {
@@ -288,6 +478,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.VaultClientCertificateAuth)(nil), (*certmanager.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(a.(*v1alpha3.VaultClientCertificateAuth), b.(*certmanager.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultClientCertificateAuth)(nil), (*v1alpha3.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultClientCertificateAuth_To_v1alpha3_VaultClientCertificateAuth(a.(*certmanager.VaultClientCertificateAuth), b.(*v1alpha3.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.VaultIssuer)(nil), (*certmanager.VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VaultIssuer_To_certmanager_VaultIssuer(a.(*v1alpha3.VaultIssuer), b.(*certmanager.VaultIssuer), scope)
 	}); err != nil {
@@ -298,6 +498,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.VaultJWTAuth)(nil), (*certmanager.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VaultJWTAuth_To_certmanager_VaultJWTAuth(a.(*v1alpha3.VaultJWTAuth), b.(*certmanager.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultJWTAuth)(nil), (*v1alpha3.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultJWTAuth_To_v1alpha3_VaultJWTAuth(a.(*certmanager.VaultJWTAuth), b.(*v1alpha3.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.VaultKubernetesAuth)(nil), (*certmanager.VaultKubernetesAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(a.(*v1alpha3.VaultKubernetesAuth), b.(*certmanager.VaultKubernetesAuth), scope)
 	}); err != nil {
@@ -376,10 +586,67 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha3_CACRL_To_certmanager_CACRL(in *v1alpha3.CACRL, out *certmanager.CACRL, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.ConfigMapName = in.ConfigMapName
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_v1alpha3_CACRL_To_certmanager_CACRL is an autogenerated conversion function.
+func Convert_v1alpha3_CACRL_To_certmanager_CACRL(in *v1alpha3.CACRL, out *certmanager.CACRL, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CACRL_To_certmanager_CACRL(in, out, s)
+}
+
+func autoConvert_certmanager_CACRL_To_v1alpha3_CACRL(in *certmanager.CACRL, out *v1alpha3.CACRL, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.ConfigMapName = in.ConfigMapName
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_certmanager_CACRL_To_v1alpha3_CACRL is an autogenerated conversion function.
+func Convert_certmanager_CACRL_To_v1alpha3_CACRL(in *certmanager.CACRL, out *v1alpha3.CACRL, s conversion.Scope) error {
+	return autoConvert_certmanager_CACRL_To_v1alpha3_CACRL(in, out, s)
+}
+
 func autoConvert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(in *v1alpha3.CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	if in.PrivateKeyPasswordSecretRef != nil {
+		in, out := &in.PrivateKeyPasswordSecretRef, &out.PrivateKeyPasswordSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrivateKeyPasswordSecretRef = nil
+	}
+	if in.ChainSecretRef != nil {
+		in, out := &in.ChainSecretRef, &out.ChainSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainSecretRef = nil
+	}
+	if in.RootSecretRef != nil {
+		in, out := &in.RootSecretRef, &out.RootSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RootSecretRef = nil
+	}
+	out.SignatureAlgorithm = certmanager.SignatureAlgorithm(in.SignatureAlgorithm)
+	out.SerialNumberLength = in.SerialNumberLength
+	out.DefaultDuration = (*v1.Duration)(unsafe.Pointer(in.DefaultDuration))
+	out.MaxDuration = (*v1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.Backdate = (*v1.Duration)(unsafe.Pointer(in.Backdate))
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.Revocation = (*certmanager.CARevocation)(unsafe.Pointer(in.Revocation))
 	return nil
 }
 
@@ -390,8 +657,41 @@ func Convert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(in *v1alpha3.CAIssuer, ou
 
 func autoConvert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in *certmanager.CAIssuer, out *v1alpha3.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	if in.PrivateKeyPasswordSecretRef != nil {
+		in, out := &in.PrivateKeyPasswordSecretRef, &out.PrivateKeyPasswordSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrivateKeyPasswordSecretRef = nil
+	}
+	if in.ChainSecretRef != nil {
+		in, out := &in.ChainSecretRef, &out.ChainSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainSecretRef = nil
+	}
+	if in.RootSecretRef != nil {
+		in, out := &in.RootSecretRef, &out.RootSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RootSecretRef = nil
+	}
+	out.SignatureAlgorithm = v1alpha3.SignatureAlgorithm(in.SignatureAlgorithm)
+	out.SerialNumberLength = in.SerialNumberLength
+	out.DefaultDuration = (*v1.Duration)(unsafe.Pointer(in.DefaultDuration))
+	out.MaxDuration = (*v1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.Backdate = (*v1.Duration)(unsafe.Pointer(in.Backdate))
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.Revocation = (*v1alpha3.CARevocation)(unsafe.Pointer(in.Revocation))
 	return nil
 }
 
@@ -400,6 +700,162 @@ func Convert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in *certmanager.CAIssuer,
 	return autoConvert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha3_CAOCSP_To_certmanager_CAOCSP(in *v1alpha3.CAOCSP, out *certmanager.CAOCSP, s conversion.Scope) error {
+	out.ResponderSecretName = in.ResponderSecretName
+	out.ResponseDuration = (*v1.Duration)(unsafe.Pointer(in.ResponseDuration))
+	return nil
+}
+
+// Convert_v1alpha3_CAOCSP_To_certmanager_CAOCSP is an autogenerated conversion function.
+func Convert_v1alpha3_CAOCSP_To_certmanager_CAOCSP(in *v1alpha3.CAOCSP, out *certmanager.CAOCSP, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CAOCSP_To_certmanager_CAOCSP(in, out, s)
+}
+
+func autoConvert_certmanager_CAOCSP_To_v1alpha3_CAOCSP(in *certmanager.CAOCSP, out *v1alpha3.CAOCSP, s conversion.Scope) error {
+	out.ResponderSecretName = in.ResponderSecretName
+	out.ResponseDuration = (*v1.Duration)(unsafe.Pointer(in.ResponseDuration))
+	return nil
+}
+
+// Convert_certmanager_CAOCSP_To_v1alpha3_CAOCSP is an autogenerated conversion function.
+func Convert_certmanager_CAOCSP_To_v1alpha3_CAOCSP(in *certmanager.CAOCSP, out *v1alpha3.CAOCSP, s conversion.Scope) error {
+	return autoConvert_certmanager_CAOCSP_To_v1alpha3_CAOCSP(in, out, s)
+}
+
+func autoConvert_v1alpha3_CARevocation_To_certmanager_CARevocation(in *v1alpha3.CARevocation, out *certmanager.CARevocation, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.CRL = (*certmanager.CACRL)(unsafe.Pointer(in.CRL))
+	out.OCSP = (*certmanager.CAOCSP)(unsafe.Pointer(in.OCSP))
+	return nil
+}
+
+// Convert_v1alpha3_CARevocation_To_certmanager_CARevocation is an autogenerated conversion function.
+func Convert_v1alpha3_CARevocation_To_certmanager_CARevocation(in *v1alpha3.CARevocation, out *certmanager.CARevocation, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CARevocation_To_certmanager_CARevocation(in, out, s)
+}
+
+func autoConvert_certmanager_CARevocation_To_v1alpha3_CARevocation(in *certmanager.CARevocation, out *v1alpha3.CARevocation, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.CRL = (*v1alpha3.CACRL)(unsafe.Pointer(in.CRL))
+	out.OCSP = (*v1alpha3.CAOCSP)(unsafe.Pointer(in.OCSP))
+	return nil
+}
+
+// Convert_certmanager_CARevocation_To_v1alpha3_CARevocation is an autogenerated conversion function.
+func Convert_certmanager_CARevocation_To_v1alpha3_CARevocation(in *certmanager.CARevocation, out *v1alpha3.CARevocation, s conversion.Scope) error {
+	return autoConvert_certmanager_CARevocation_To_v1alpha3_CARevocation(in, out, s)
+}
+
+func autoConvert_v1alpha3_CMPIssuer_To_certmanager_CMPIssuer(in *v1alpha3.CMPIssuer, out *certmanager.CMPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.RequestType = certmanager.CMPRequestType(in.RequestType)
+	if in.SharedSecret != nil {
+		in, out := &in.SharedSecret, &out.SharedSecret
+		*out = new(certmanager.CMPSharedSecretProtection)
+		if err := Convert_v1alpha3_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SharedSecret = nil
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(certmanager.CMPSignatureProtection)
+		if err := Convert_v1alpha3_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Signature = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_CMPIssuer_To_certmanager_CMPIssuer is an autogenerated conversion function.
+func Convert_v1alpha3_CMPIssuer_To_certmanager_CMPIssuer(in *v1alpha3.CMPIssuer, out *certmanager.CMPIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CMPIssuer_To_certmanager_CMPIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_CMPIssuer_To_v1alpha3_CMPIssuer(in *certmanager.CMPIssuer, out *v1alpha3.CMPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.RequestType = v1alpha3.CMPRequestType(in.RequestType)
+	if in.SharedSecret != nil {
+		in, out := &in.SharedSecret, &out.SharedSecret
+		*out = new(v1alpha3.CMPSharedSecretProtection)
+		if err := Convert_certmanager_CMPSharedSecretProtection_To_v1alpha3_CMPSharedSecretProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SharedSecret = nil
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(v1alpha3.CMPSignatureProtection)
+		if err := Convert_certmanager_CMPSignatureProtection_To_v1alpha3_CMPSignatureProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Signature = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CMPIssuer_To_v1alpha3_CMPIssuer is an autogenerated conversion function.
+func Convert_certmanager_CMPIssuer_To_v1alpha3_CMPIssuer(in *certmanager.CMPIssuer, out *v1alpha3.CMPIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_CMPIssuer_To_v1alpha3_CMPIssuer(in, out, s)
+}
+
+func autoConvert_v1alpha3_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(in *v1alpha3.CMPSharedSecretProtection, out *certmanager.CMPSharedSecretProtection, s conversion.Scope) error {
+	out.Reference = in.Reference
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection is an autogenerated conversion function.
+func Convert_v1alpha3_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(in *v1alpha3.CMPSharedSecretProtection, out *certmanager.CMPSharedSecretProtection, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(in, out, s)
+}
+
+func autoConvert_certmanager_CMPSharedSecretProtection_To_v1alpha3_CMPSharedSecretProtection(in *certmanager.CMPSharedSecretProtection, out *v1alpha3.CMPSharedSecretProtection, s conversion.Scope) error {
+	out.Reference = in.Reference
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CMPSharedSecretProtection_To_v1alpha3_CMPSharedSecretProtection is an autogenerated conversion function.
+func Convert_certmanager_CMPSharedSecretProtection_To_v1alpha3_CMPSharedSecretProtection(in *certmanager.CMPSharedSecretProtection, out *v1alpha3.CMPSharedSecretProtection, s conversion.Scope) error {
+	return autoConvert_certmanager_CMPSharedSecretProtection_To_v1alpha3_CMPSharedSecretProtection(in, out, s)
+}
+
+func autoConvert_v1alpha3_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(in *v1alpha3.CMPSignatureProtection, out *certmanager.CMPSignatureProtection, s conversion.Scope) error {
+	if err := metav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_CMPSignatureProtection_To_certmanager_CMPSignatureProtection is an autogenerated conversion function.
+func Convert_v1alpha3_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(in *v1alpha3.CMPSignatureProtection, out *certmanager.CMPSignatureProtection, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(in, out, s)
+}
+
+func autoConvert_certmanager_CMPSignatureProtection_To_v1alpha3_CMPSignatureProtection(in *certmanager.CMPSignatureProtection, out *v1alpha3.CMPSignatureProtection, s conversion.Scope) error {
+	if err := metav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CMPSignatureProtection_To_v1alpha3_CMPSignatureProtection is an autogenerated conversion function.
+func Convert_certmanager_CMPSignatureProtection_To_v1alpha3_CMPSignatureProtection(in *certmanager.CMPSignatureProtection, out *v1alpha3.CMPSignatureProtection, s conversion.Scope) error {
+	return autoConvert_certmanager_CMPSignatureProtection_To_v1alpha3_CMPSignatureProtection(in, out, s)
+}
+
 func autoConvert_v1alpha3_Certificate_To_certmanager_Certificate(in *v1alpha3.Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -449,7 +905,7 @@ func Convert_v1alpha3_CertificateCondition_To_certmanager_CertificateCondition(i
 
 func autoConvert_certmanager_CertificateCondition_To_v1alpha3_CertificateCondition(in *certmanager.CertificateCondition, out *v1alpha3.CertificateCondition, s conversion.Scope) error {
 	out.Type = v1alpha3.CertificateConditionType(in.Type)
-	out.Status = metav1.ConditionStatus(in.Status)
+	out.Status = apismetav1.ConditionStatus(in.Status)
 	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
@@ -558,6 +1014,26 @@ func Convert_certmanager_CertificateList_To_v1alpha3_CertificateList(in *certman
 	return autoConvert_certmanager_CertificateList_To_v1alpha3_CertificateList(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *v1alpha3.CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_v1alpha3_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *v1alpha3.CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateOCSPStapling_To_v1alpha3_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *v1alpha3.CertificateOCSPStapling, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_certmanager_CertificateOCSPStapling_To_v1alpha3_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_certmanager_CertificateOCSPStapling_To_v1alpha3_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *v1alpha3.CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateOCSPStapling_To_v1alpha3_CertificateOCSPStapling(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v1alpha3.CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
 	return nil
@@ -576,6 +1052,36 @@ func autoConvert_certmanager_CertificatePrivateKey_To_v1alpha3_CertificatePrivat
 	return nil
 }
 
+func autoConvert_v1alpha3_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1alpha3.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.ExplanationURL = in.ExplanationURL
+	out.LastCheckTime = in.LastCheckTime
+	out.NextCheckTime = in.NextCheckTime
+	return nil
+}
+
+// Convert_v1alpha3_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1alpha3.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalInfo_To_v1alpha3_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1alpha3.CertificateRenewalInfo, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.ExplanationURL = in.ExplanationURL
+	out.LastCheckTime = in.LastCheckTime
+	out.NextCheckTime = in.NextCheckTime
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalInfo_To_v1alpha3_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalInfo_To_v1alpha3_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1alpha3.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalInfo_To_v1alpha3_CertificateRenewalInfo(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificateRequest_To_certmanager_CertificateRequest(in *v1alpha3.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -624,7 +1130,7 @@ func Convert_v1alpha3_CertificateRequestCondition_To_certmanager_CertificateRequ
 
 func autoConvert_certmanager_CertificateRequestCondition_To_v1alpha3_CertificateRequestCondition(in *certmanager.CertificateRequestCondition, out *v1alpha3.CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = v1alpha3.CertificateRequestConditionType(in.Type)
-	out.Status = metav1.ConditionStatus(in.Status)
+	out.Status = apismetav1.ConditionStatus(in.Status)
 	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
@@ -680,7 +1186,7 @@ func Convert_certmanager_CertificateRequestList_To_v1alpha3_CertificateRequestLi
 
 func autoConvert_v1alpha3_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in *v1alpha3.CertificateRequestSpec, out *certmanager.CertificateRequestSpec, s conversion.Scope) error {
 	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	// WARNING: in.CSRPEM requires manual conversion: does not exist in peer-type
@@ -695,7 +1201,7 @@ func autoConvert_v1alpha3_CertificateRequestSpec_To_certmanager_CertificateReque
 
 func autoConvert_certmanager_CertificateRequestSpec_To_v1alpha3_CertificateRequestSpec(in *certmanager.CertificateRequestSpec, out *v1alpha3.CertificateRequestSpec, s conversion.Scope) error {
 	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	// WARNING: in.Request requires manual conversion: does not exist in peer-type
@@ -734,6 +1240,52 @@ func Convert_certmanager_CertificateRequestStatus_To_v1alpha3_CertificateRequest
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1alpha3_CertificateRequestStatus(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificateRevocation_To_certmanager_CertificateRevocation(in *v1alpha3.CertificateRevocation, out *certmanager.CertificateRevocation, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.Reason = in.Reason
+	out.RevocationTime = (*v1.Time)(unsafe.Pointer(in.RevocationTime))
+	return nil
+}
+
+// Convert_v1alpha3_CertificateRevocation_To_certmanager_CertificateRevocation is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateRevocation_To_certmanager_CertificateRevocation(in *v1alpha3.CertificateRevocation, out *certmanager.CertificateRevocation, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateRevocation_To_certmanager_CertificateRevocation(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocation_To_v1alpha3_CertificateRevocation(in *certmanager.CertificateRevocation, out *v1alpha3.CertificateRevocation, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.Reason = in.Reason
+	out.RevocationTime = (*v1.Time)(unsafe.Pointer(in.RevocationTime))
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocation_To_v1alpha3_CertificateRevocation is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocation_To_v1alpha3_CertificateRevocation(in *certmanager.CertificateRevocation, out *v1alpha3.CertificateRevocation, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocation_To_v1alpha3_CertificateRevocation(in, out, s)
+}
+
+func autoConvert_v1alpha3_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(in *v1alpha3.CertificateRevocationCheck, out *certmanager.CertificateRevocationCheck, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	return nil
+}
+
+// Convert_v1alpha3_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(in *v1alpha3.CertificateRevocationCheck, out *certmanager.CertificateRevocationCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocationCheck_To_v1alpha3_CertificateRevocationCheck(in *certmanager.CertificateRevocationCheck, out *v1alpha3.CertificateRevocationCheck, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocationCheck_To_v1alpha3_CertificateRevocationCheck is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocationCheck_To_v1alpha3_CertificateRevocationCheck(in *certmanager.CertificateRevocationCheck, out *v1alpha3.CertificateRevocationCheck, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocationCheck_To_v1alpha3_CertificateRevocationCheck(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1alpha3.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
@@ -784,10 +1336,12 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *v1a
 	} else {
 		out.Keystores = nil
 	}
-	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.OCSPStapling = (*certmanager.CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
+	out.NameConstraints = (*certmanager.NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
 	// WARNING: in.KeySize requires manual conversion: does not exist in peer-type
 	// WARNING: in.KeyAlgorithm requires manual conversion: does not exist in peer-type
@@ -803,6 +1357,8 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *v1a
 	}
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	out.RevocationPolicy = certmanager.CertificateRevocationPolicy(in.RevocationPolicy)
+	out.RevocationCheck = (*certmanager.CertificateRevocationCheck)(unsafe.Pointer(in.RevocationCheck))
 	return nil
 }
 
@@ -834,10 +1390,12 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 	} else {
 		out.Keystores = nil
 	}
-	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.OCSPStapling = (*v1alpha3.CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
+	out.NameConstraints = (*v1alpha3.NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.Usages = *(*[]v1alpha3.KeyUsage)(unsafe.Pointer(&in.Usages))
 	if in.PrivateKey != nil {
 		in, out := &in.PrivateKey, &out.PrivateKey
@@ -850,6 +1408,8 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 	}
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	out.RevocationPolicy = v1alpha3.CertificateRevocationPolicy(in.RevocationPolicy)
+	out.RevocationCheck = (*v1alpha3.CertificateRevocationCheck)(unsafe.Pointer(in.RevocationCheck))
 	return nil
 }
 
@@ -861,6 +1421,8 @@ func autoConvert_v1alpha3_CertificateStatus_To_certmanager_CertificateStatus(in
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
+	out.LastRevocation = (*certmanager.CertificateRevocation)(unsafe.Pointer(in.LastRevocation))
+	out.RenewalInfo = (*certmanager.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	return nil
 }
 
@@ -877,6 +1439,8 @@ func autoConvert_certmanager_CertificateStatus_To_v1alpha3_CertificateStatus(in
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
+	out.LastRevocation = (*v1alpha3.CertificateRevocation)(unsafe.Pointer(in.LastRevocation))
+	out.RenewalInfo = (*v1alpha3.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	return nil
 }
 
@@ -959,6 +1523,154 @@ func Convert_certmanager_ClusterIssuerList_To_v1alpha3_ClusterIssuerList(in *cer
 	return autoConvert_certmanager_ClusterIssuerList_To_v1alpha3_ClusterIssuerList(in, out, s)
 }
 
+func autoConvert_v1alpha3_ESTAuth_To_certmanager_ESTAuth(in *v1alpha3.ESTAuth, out *certmanager.ESTAuth, s conversion.Scope) error {
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(certmanager.ESTBasicAuth)
+		if err := Convert_v1alpha3_ESTBasicAuth_To_certmanager_ESTBasicAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BasicAuth = nil
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(certmanager.ESTClientCertificateAuth)
+		if err := Convert_v1alpha3_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClientCertificate = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ESTAuth_To_certmanager_ESTAuth is an autogenerated conversion function.
+func Convert_v1alpha3_ESTAuth_To_certmanager_ESTAuth(in *v1alpha3.ESTAuth, out *certmanager.ESTAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ESTAuth_To_certmanager_ESTAuth(in, out, s)
+}
+
+func autoConvert_certmanager_ESTAuth_To_v1alpha3_ESTAuth(in *certmanager.ESTAuth, out *v1alpha3.ESTAuth, s conversion.Scope) error {
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(v1alpha3.ESTBasicAuth)
+		if err := Convert_certmanager_ESTBasicAuth_To_v1alpha3_ESTBasicAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BasicAuth = nil
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(v1alpha3.ESTClientCertificateAuth)
+		if err := Convert_certmanager_ESTClientCertificateAuth_To_v1alpha3_ESTClientCertificateAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClientCertificate = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_ESTAuth_To_v1alpha3_ESTAuth is an autogenerated conversion function.
+func Convert_certmanager_ESTAuth_To_v1alpha3_ESTAuth(in *certmanager.ESTAuth, out *v1alpha3.ESTAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTAuth_To_v1alpha3_ESTAuth(in, out, s)
+}
+
+func autoConvert_v1alpha3_ESTBasicAuth_To_certmanager_ESTBasicAuth(in *v1alpha3.ESTBasicAuth, out *certmanager.ESTBasicAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ESTBasicAuth_To_certmanager_ESTBasicAuth is an autogenerated conversion function.
+func Convert_v1alpha3_ESTBasicAuth_To_certmanager_ESTBasicAuth(in *v1alpha3.ESTBasicAuth, out *certmanager.ESTBasicAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ESTBasicAuth_To_certmanager_ESTBasicAuth(in, out, s)
+}
+
+func autoConvert_certmanager_ESTBasicAuth_To_v1alpha3_ESTBasicAuth(in *certmanager.ESTBasicAuth, out *v1alpha3.ESTBasicAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_ESTBasicAuth_To_v1alpha3_ESTBasicAuth is an autogenerated conversion function.
+func Convert_certmanager_ESTBasicAuth_To_v1alpha3_ESTBasicAuth(in *certmanager.ESTBasicAuth, out *v1alpha3.ESTBasicAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTBasicAuth_To_v1alpha3_ESTBasicAuth(in, out, s)
+}
+
+func autoConvert_v1alpha3_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(in *v1alpha3.ESTClientCertificateAuth, out *certmanager.ESTClientCertificateAuth, s conversion.Scope) error {
+	if err := metav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1alpha3_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(in *v1alpha3.ESTClientCertificateAuth, out *certmanager.ESTClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_ESTClientCertificateAuth_To_v1alpha3_ESTClientCertificateAuth(in *certmanager.ESTClientCertificateAuth, out *v1alpha3.ESTClientCertificateAuth, s conversion.Scope) error {
+	if err := metav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_ESTClientCertificateAuth_To_v1alpha3_ESTClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_ESTClientCertificateAuth_To_v1alpha3_ESTClientCertificateAuth(in *certmanager.ESTClientCertificateAuth, out *v1alpha3.ESTClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTClientCertificateAuth_To_v1alpha3_ESTClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_v1alpha3_ESTIssuer_To_certmanager_ESTIssuer(in *v1alpha3.ESTIssuer, out *certmanager.ESTIssuer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.Label = in.Label
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(certmanager.ESTAuth)
+		if err := Convert_v1alpha3_ESTAuth_To_certmanager_ESTAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Auth = nil
+	}
+	out.Reenroll = in.Reenroll
+	return nil
+}
+
+// Convert_v1alpha3_ESTIssuer_To_certmanager_ESTIssuer is an autogenerated conversion function.
+func Convert_v1alpha3_ESTIssuer_To_certmanager_ESTIssuer(in *v1alpha3.ESTIssuer, out *certmanager.ESTIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ESTIssuer_To_certmanager_ESTIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_ESTIssuer_To_v1alpha3_ESTIssuer(in *certmanager.ESTIssuer, out *v1alpha3.ESTIssuer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.Label = in.Label
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(v1alpha3.ESTAuth)
+		if err := Convert_certmanager_ESTAuth_To_v1alpha3_ESTAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Auth = nil
+	}
+	out.Reenroll = in.Reenroll
+	return nil
+}
+
+// Convert_certmanager_ESTIssuer_To_v1alpha3_ESTIssuer is an autogenerated conversion function.
+func Convert_certmanager_ESTIssuer_To_v1alpha3_ESTIssuer(in *certmanager.ESTIssuer, out *v1alpha3.ESTIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTIssuer_To_v1alpha3_ESTIssuer(in, out, s)
+}
+
 func autoConvert_v1alpha3_Issuer_To_certmanager_Issuer(in *v1alpha3.Issuer, out *certmanager.Issuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1008,7 +1720,7 @@ func Convert_v1alpha3_IssuerCondition_To_certmanager_IssuerCondition(in *v1alpha
 
 func autoConvert_certmanager_IssuerCondition_To_v1alpha3_IssuerCondition(in *certmanager.IssuerCondition, out *v1alpha3.IssuerCondition, s conversion.Scope) error {
 	out.Type = v1alpha3.IssuerConditionType(in.Type)
-	out.Status = metav1.ConditionStatus(in.Status)
+	out.Status = apismetav1.ConditionStatus(in.Status)
 	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
@@ -1031,7 +1743,15 @@ func autoConvert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(in *v1alpha3.
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(certmanager.CAIssuer)
+		if err := Convert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(certmanager.VaultIssuer)
@@ -1051,6 +1771,33 @@ func autoConvert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(in *v1alpha3.
 	} else {
 		out.Venafi = nil
 	}
+	if in.EST != nil {
+		in, out := &in.EST, &out.EST
+		*out = new(certmanager.ESTIssuer)
+		if err := Convert_v1alpha3_ESTIssuer_To_certmanager_ESTIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EST = nil
+	}
+	if in.SCEP != nil {
+		in, out := &in.SCEP, &out.SCEP
+		*out = new(certmanager.SCEPIssuer)
+		if err := Convert_v1alpha3_SCEPIssuer_To_certmanager_SCEPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SCEP = nil
+	}
+	if in.CMP != nil {
+		in, out := &in.CMP, &out.CMP
+		*out = new(certmanager.CMPIssuer)
+		if err := Convert_v1alpha3_CMPIssuer_To_certmanager_CMPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CMP = nil
+	}
 	return nil
 }
 
@@ -1069,7 +1816,15 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(in *certmanag
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*v1alpha3.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(v1alpha3.CAIssuer)
+		if err := Convert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(v1alpha3.VaultIssuer)
@@ -1089,6 +1844,33 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(in *certmanag
 	} else {
 		out.Venafi = nil
 	}
+	if in.EST != nil {
+		in, out := &in.EST, &out.EST
+		*out = new(v1alpha3.ESTIssuer)
+		if err := Convert_certmanager_ESTIssuer_To_v1alpha3_ESTIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EST = nil
+	}
+	if in.SCEP != nil {
+		in, out := &in.SCEP, &out.SCEP
+		*out = new(v1alpha3.SCEPIssuer)
+		if err := Convert_certmanager_SCEPIssuer_To_v1alpha3_SCEPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SCEP = nil
+	}
+	if in.CMP != nil {
+		in, out := &in.CMP, &out.CMP
+		*out = new(v1alpha3.CMPIssuer)
+		if err := Convert_certmanager_CMPIssuer_To_v1alpha3_CMPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CMP = nil
+	}
 	return nil
 }
 
@@ -1139,10 +1921,33 @@ func Convert_certmanager_IssuerList_To_v1alpha3_IssuerList(in *certmanager.Issue
 	return autoConvert_certmanager_IssuerList_To_v1alpha3_IssuerList(in, out, s)
 }
 
+func autoConvert_v1alpha3_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(in *v1alpha3.IssuerNamespaceAccess, out *certmanager.IssuerNamespaceAccess, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	return nil
+}
+
+// Convert_v1alpha3_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess is an autogenerated conversion function.
+func Convert_v1alpha3_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(in *v1alpha3.IssuerNamespaceAccess, out *certmanager.IssuerNamespaceAccess, s conversion.Scope) error {
+	return autoConvert_v1alpha3_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerNamespaceAccess_To_v1alpha3_IssuerNamespaceAccess(in *certmanager.IssuerNamespaceAccess, out *v1alpha3.IssuerNamespaceAccess, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	return nil
+}
+
+// Convert_certmanager_IssuerNamespaceAccess_To_v1alpha3_IssuerNamespaceAccess is an autogenerated conversion function.
+func Convert_certmanager_IssuerNamespaceAccess_To_v1alpha3_IssuerNamespaceAccess(in *certmanager.IssuerNamespaceAccess, out *v1alpha3.IssuerNamespaceAccess, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerNamespaceAccess_To_v1alpha3_IssuerNamespaceAccess(in, out, s)
+}
+
 func autoConvert_v1alpha3_IssuerSpec_To_certmanager_IssuerSpec(in *v1alpha3.IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
 	if err := Convert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.NamespaceAccess = (*certmanager.IssuerNamespaceAccess)(unsafe.Pointer(in.NamespaceAccess))
 	return nil
 }
 
@@ -1155,6 +1960,7 @@ func autoConvert_certmanager_IssuerSpec_To_v1alpha3_IssuerSpec(in *certmanager.I
 	if err := Convert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.NamespaceAccess = (*v1alpha3.IssuerNamespaceAccess)(unsafe.Pointer(in.NamespaceAccess))
 	return nil
 }
 
@@ -1203,7 +2009,7 @@ func Convert_certmanager_IssuerStatus_To_v1alpha3_IssuerStatus(in *certmanager.I
 
 func autoConvert_v1alpha3_JKSKeystore_To_certmanager_JKSKeystore(in *v1alpha3.JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1216,7 +2022,7 @@ func Convert_v1alpha3_JKSKeystore_To_certmanager_JKSKeystore(in *v1alpha3.JKSKey
 
 func autoConvert_certmanager_JKSKeystore_To_v1alpha3_JKSKeystore(in *certmanager.JKSKeystore, out *v1alpha3.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1227,9 +2033,59 @@ func Convert_certmanager_JKSKeystore_To_v1alpha3_JKSKeystore(in *certmanager.JKS
 	return autoConvert_certmanager_JKSKeystore_To_v1alpha3_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem(in *v1alpha3.NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.URIDomains = *(*[]string)(unsafe.Pointer(&in.URIDomains))
+	return nil
+}
+
+// Convert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem is an autogenerated conversion function.
+func Convert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem(in *v1alpha3.NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraintItem_To_v1alpha3_NameConstraintItem(in *certmanager.NameConstraintItem, out *v1alpha3.NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.URIDomains = *(*[]string)(unsafe.Pointer(&in.URIDomains))
+	return nil
+}
+
+// Convert_certmanager_NameConstraintItem_To_v1alpha3_NameConstraintItem is an autogenerated conversion function.
+func Convert_certmanager_NameConstraintItem_To_v1alpha3_NameConstraintItem(in *certmanager.NameConstraintItem, out *v1alpha3.NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraintItem_To_v1alpha3_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_v1alpha3_NameConstraints_To_certmanager_NameConstraints(in *v1alpha3.NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	out.Critical = in.Critical
+	out.Permitted = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_v1alpha3_NameConstraints_To_certmanager_NameConstraints is an autogenerated conversion function.
+func Convert_v1alpha3_NameConstraints_To_certmanager_NameConstraints(in *v1alpha3.NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NameConstraints_To_certmanager_NameConstraints(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraints_To_v1alpha3_NameConstraints(in *certmanager.NameConstraints, out *v1alpha3.NameConstraints, s conversion.Scope) error {
+	out.Critical = in.Critical
+	out.Permitted = (*v1alpha3.NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*v1alpha3.NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_certmanager_NameConstraints_To_v1alpha3_NameConstraints is an autogenerated conversion function.
+func Convert_certmanager_NameConstraints_To_v1alpha3_NameConstraints(in *certmanager.NameConstraints, out *v1alpha3.NameConstraints, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraints_To_v1alpha3_NameConstraints(in, out, s)
+}
+
 func autoConvert_v1alpha3_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1alpha3.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1242,7 +2098,7 @@ func Convert_v1alpha3_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1alpha3.
 
 func autoConvert_certmanager_PKCS12Keystore_To_v1alpha3_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *v1alpha3.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1253,6 +2109,46 @@ func Convert_certmanager_PKCS12Keystore_To_v1alpha3_PKCS12Keystore(in *certmanag
 	return autoConvert_certmanager_PKCS12Keystore_To_v1alpha3_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1alpha3_SCEPIssuer_To_certmanager_SCEPIssuer(in *v1alpha3.SCEPIssuer, out *certmanager.SCEPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.ChallengePasswordSecretRef != nil {
+		in, out := &in.ChallengePasswordSecretRef, &out.ChallengePasswordSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChallengePasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_SCEPIssuer_To_certmanager_SCEPIssuer is an autogenerated conversion function.
+func Convert_v1alpha3_SCEPIssuer_To_certmanager_SCEPIssuer(in *v1alpha3.SCEPIssuer, out *certmanager.SCEPIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha3_SCEPIssuer_To_certmanager_SCEPIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_SCEPIssuer_To_v1alpha3_SCEPIssuer(in *certmanager.SCEPIssuer, out *v1alpha3.SCEPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.ChallengePasswordSecretRef != nil {
+		in, out := &in.ChallengePasswordSecretRef, &out.ChallengePasswordSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChallengePasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_SCEPIssuer_To_v1alpha3_SCEPIssuer is an autogenerated conversion function.
+func Convert_certmanager_SCEPIssuer_To_v1alpha3_SCEPIssuer(in *certmanager.SCEPIssuer, out *v1alpha3.SCEPIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_SCEPIssuer_To_v1alpha3_SCEPIssuer(in, out, s)
+}
+
 func autoConvert_v1alpha3_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1alpha3.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1273,10 +2169,30 @@ func Convert_certmanager_SelfSignedIssuer_To_v1alpha3_SelfSignedIssuer(in *certm
 	return autoConvert_certmanager_SelfSignedIssuer_To_v1alpha3_SelfSignedIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha3_ServiceAccountRef_To_certmanager_ServiceAccountRef(in *v1alpha3.ServiceAccountRef, out *certmanager.ServiceAccountRef, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha3_ServiceAccountRef_To_certmanager_ServiceAccountRef is an autogenerated conversion function.
+func Convert_v1alpha3_ServiceAccountRef_To_certmanager_ServiceAccountRef(in *v1alpha3.ServiceAccountRef, out *certmanager.ServiceAccountRef, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ServiceAccountRef_To_certmanager_ServiceAccountRef(in, out, s)
+}
+
+func autoConvert_certmanager_ServiceAccountRef_To_v1alpha3_ServiceAccountRef(in *certmanager.ServiceAccountRef, out *v1alpha3.ServiceAccountRef, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_ServiceAccountRef_To_v1alpha3_ServiceAccountRef is an autogenerated conversion function.
+func Convert_certmanager_ServiceAccountRef_To_v1alpha3_ServiceAccountRef(in *certmanager.ServiceAccountRef, out *v1alpha3.ServiceAccountRef, s conversion.Scope) error {
+	return autoConvert_certmanager_ServiceAccountRef_To_v1alpha3_ServiceAccountRef(in, out, s)
+}
+
 func autoConvert_v1alpha3_VaultAppRole_To_certmanager_VaultAppRole(in *v1alpha3.VaultAppRole, out *certmanager.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1290,7 +2206,7 @@ func Convert_v1alpha3_VaultAppRole_To_certmanager_VaultAppRole(in *v1alpha3.Vaul
 func autoConvert_certmanager_VaultAppRole_To_v1alpha3_VaultAppRole(in *certmanager.VaultAppRole, out *v1alpha3.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1305,7 +2221,7 @@ func autoConvert_v1alpha3_VaultAuth_To_certmanager_VaultAuth(in *v1alpha3.VaultA
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(meta.SecretKeySelector)
-		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1329,6 +2245,8 @@ func autoConvert_v1alpha3_VaultAuth_To_certmanager_VaultAuth(in *v1alpha3.VaultA
 	} else {
 		out.Kubernetes = nil
 	}
+	out.JWT = (*certmanager.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*certmanager.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1340,8 +2258,8 @@ func Convert_v1alpha3_VaultAuth_To_certmanager_VaultAuth(in *v1alpha3.VaultAuth,
 func autoConvert_certmanager_VaultAuth_To_v1alpha3_VaultAuth(in *certmanager.VaultAuth, out *v1alpha3.VaultAuth, s conversion.Scope) error {
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
-		*out = new(metav1.SecretKeySelector)
-		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1365,6 +2283,8 @@ func autoConvert_certmanager_VaultAuth_To_v1alpha3_VaultAuth(in *certmanager.Vau
 	} else {
 		out.Kubernetes = nil
 	}
+	out.JWT = (*v1alpha3.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*v1alpha3.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1373,12 +2293,38 @@ func Convert_certmanager_VaultAuth_To_v1alpha3_VaultAuth(in *certmanager.VaultAu
 	return autoConvert_certmanager_VaultAuth_To_v1alpha3_VaultAuth(in, out, s)
 }
 
+func autoConvert_v1alpha3_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1alpha3.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha3_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1alpha3_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1alpha3.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultClientCertificateAuth_To_v1alpha3_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1alpha3.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_VaultClientCertificateAuth_To_v1alpha3_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultClientCertificateAuth_To_v1alpha3_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1alpha3.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultClientCertificateAuth_To_v1alpha3_VaultClientCertificateAuth(in, out, s)
+}
+
 func autoConvert_v1alpha3_VaultIssuer_To_certmanager_VaultIssuer(in *v1alpha3.VaultIssuer, out *certmanager.VaultIssuer, s conversion.Scope) error {
 	if err := Convert_v1alpha3_VaultAuth_To_certmanager_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
 		return err
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
+	out.SignVerbatim = in.SignVerbatim
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
@@ -1395,6 +2341,8 @@ func autoConvert_certmanager_VaultIssuer_To_v1alpha3_VaultIssuer(in *certmanager
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
+	out.SignVerbatim = in.SignVerbatim
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
@@ -1405,9 +2353,39 @@ func Convert_certmanager_VaultIssuer_To_v1alpha3_VaultIssuer(in *certmanager.Vau
 	return autoConvert_certmanager_VaultIssuer_To_v1alpha3_VaultIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha3_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1alpha3.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	if err := Convert_v1alpha3_ServiceAccountRef_To_certmanager_ServiceAccountRef(&in.ServiceAccountRef, &out.ServiceAccountRef, s); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_v1alpha3_VaultJWTAuth_To_certmanager_VaultJWTAuth is an autogenerated conversion function.
+func Convert_v1alpha3_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1alpha3.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VaultJWTAuth_To_certmanager_VaultJWTAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultJWTAuth_To_v1alpha3_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1alpha3.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	if err := Convert_certmanager_ServiceAccountRef_To_v1alpha3_ServiceAccountRef(&in.ServiceAccountRef, &out.ServiceAccountRef, s); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_certmanager_VaultJWTAuth_To_v1alpha3_VaultJWTAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultJWTAuth_To_v1alpha3_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1alpha3.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultJWTAuth_To_v1alpha3_VaultJWTAuth(in, out, s)
+}
+
 func autoConvert_v1alpha3_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1alpha3.VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1421,7 +2399,7 @@ func Convert_v1alpha3_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in
 
 func autoConvert_certmanager_VaultKubernetesAuth_To_v1alpha3_VaultKubernetesAuth(in *certmanager.VaultKubernetesAuth, out *v1alpha3.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1435,7 +2413,7 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1alpha3_VaultKubernetesAuth(in
 
 func autoConvert_v1alpha3_VenafiCloud_To_certmanager_VenafiCloud(in *v1alpha3.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1448,7 +2426,7 @@ func Convert_v1alpha3_VenafiCloud_To_certmanager_VenafiCloud(in *v1alpha3.Venafi
 
 func autoConvert_certmanager_VenafiCloud_To_v1alpha3_VenafiCloud(in *certmanager.VenafiCloud, out *v1alpha3.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1517,7 +2495,7 @@ func Convert_certmanager_VenafiIssuer_To_v1alpha3_VenafiIssuer(in *certmanager.V
 
 func autoConvert_v1alpha3_VenafiTPP_To_certmanager_VenafiTPP(in *v1alpha3.VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := metav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
@@ -1531,7 +2509,7 @@ func Convert_v1alpha3_VenafiTPP_To_certmanager_VenafiTPP(in *v1alpha3.VenafiTPP,
 
 func autoConvert_certmanager_VenafiTPP_To_v1alpha3_VenafiTPP(in *certmanager.VenafiTPP, out *v1alpha3.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := metav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
@@ -25,12 +25,12 @@ import (
 
 	apisacmev1beta1 "github.com/jetstack/cert-manager/pkg/apis/acme/v1beta1"
 	v1beta1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1beta1"
-	metav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	apismetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	acme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
 	acmev1beta1 "github.com/jetstack/cert-manager/pkg/internal/apis/acme/v1beta1"
 	certmanager "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
 	meta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
-	apismetav1 "github.com/jetstack/cert-manager/pkg/internal/apis/meta/v1"
+	metav1 "github.com/jetstack/cert-manager/pkg/internal/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -43,6 +43,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1beta1.CACRL)(nil), (*certmanager.CACRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CACRL_To_certmanager_CACRL(a.(*v1beta1.CACRL), b.(*certmanager.CACRL), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CACRL)(nil), (*v1beta1.CACRL)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CACRL_To_v1beta1_CACRL(a.(*certmanager.CACRL), b.(*v1beta1.CACRL), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CAIssuer_To_certmanager_CAIssuer(a.(*v1beta1.CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -53,6 +63,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.CAOCSP)(nil), (*certmanager.CAOCSP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CAOCSP_To_certmanager_CAOCSP(a.(*v1beta1.CAOCSP), b.(*certmanager.CAOCSP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAOCSP)(nil), (*v1beta1.CAOCSP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAOCSP_To_v1beta1_CAOCSP(a.(*certmanager.CAOCSP), b.(*v1beta1.CAOCSP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.CARevocation)(nil), (*certmanager.CARevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CARevocation_To_certmanager_CARevocation(a.(*v1beta1.CARevocation), b.(*certmanager.CARevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CARevocation)(nil), (*v1beta1.CARevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CARevocation_To_v1beta1_CARevocation(a.(*certmanager.CARevocation), b.(*v1beta1.CARevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.CMPIssuer)(nil), (*certmanager.CMPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CMPIssuer_To_certmanager_CMPIssuer(a.(*v1beta1.CMPIssuer), b.(*certmanager.CMPIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CMPIssuer)(nil), (*v1beta1.CMPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CMPIssuer_To_v1beta1_CMPIssuer(a.(*certmanager.CMPIssuer), b.(*v1beta1.CMPIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.CMPSharedSecretProtection)(nil), (*certmanager.CMPSharedSecretProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(a.(*v1beta1.CMPSharedSecretProtection), b.(*certmanager.CMPSharedSecretProtection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CMPSharedSecretProtection)(nil), (*v1beta1.CMPSharedSecretProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CMPSharedSecretProtection_To_v1beta1_CMPSharedSecretProtection(a.(*certmanager.CMPSharedSecretProtection), b.(*v1beta1.CMPSharedSecretProtection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.CMPSignatureProtection)(nil), (*certmanager.CMPSignatureProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(a.(*v1beta1.CMPSignatureProtection), b.(*certmanager.CMPSignatureProtection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CMPSignatureProtection)(nil), (*v1beta1.CMPSignatureProtection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CMPSignatureProtection_To_v1beta1_CMPSignatureProtection(a.(*certmanager.CMPSignatureProtection), b.(*v1beta1.CMPSignatureProtection), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Certificate_To_certmanager_Certificate(a.(*v1beta1.Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -93,6 +153,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.CertificateOCSPStapling)(nil), (*certmanager.CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(a.(*v1beta1.CertificateOCSPStapling), b.(*certmanager.CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateOCSPStapling)(nil), (*v1beta1.CertificateOCSPStapling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateOCSPStapling_To_v1beta1_CertificateOCSPStapling(a.(*certmanager.CertificateOCSPStapling), b.(*v1beta1.CertificateOCSPStapling), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.CertificatePrivateKey)(nil), (*certmanager.CertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(a.(*v1beta1.CertificatePrivateKey), b.(*certmanager.CertificatePrivateKey), scope)
 	}); err != nil {
@@ -103,6 +173,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.CertificateRenewalInfo)(nil), (*certmanager.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(a.(*v1beta1.CertificateRenewalInfo), b.(*certmanager.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalInfo)(nil), (*v1beta1.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalInfo_To_v1beta1_CertificateRenewalInfo(a.(*certmanager.CertificateRenewalInfo), b.(*v1beta1.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1beta1.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -153,6 +233,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.CertificateRevocation)(nil), (*certmanager.CertificateRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateRevocation_To_certmanager_CertificateRevocation(a.(*v1beta1.CertificateRevocation), b.(*certmanager.CertificateRevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocation)(nil), (*v1beta1.CertificateRevocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocation_To_v1beta1_CertificateRevocation(a.(*certmanager.CertificateRevocation), b.(*v1beta1.CertificateRevocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.CertificateRevocationCheck)(nil), (*certmanager.CertificateRevocationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(a.(*v1beta1.CertificateRevocationCheck), b.(*certmanager.CertificateRevocationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRevocationCheck)(nil), (*v1beta1.CertificateRevocationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRevocationCheck_To_v1beta1_CertificateRevocationCheck(a.(*certmanager.CertificateRevocationCheck), b.(*v1beta1.CertificateRevocationCheck), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.CertificateSecretTemplate)(nil), (*certmanager.CertificateSecretTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(a.(*v1beta1.CertificateSecretTemplate), b.(*certmanager.CertificateSecretTemplate), scope)
 	}); err != nil {
@@ -203,6 +303,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ESTAuth)(nil), (*certmanager.ESTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ESTAuth_To_certmanager_ESTAuth(a.(*v1beta1.ESTAuth), b.(*certmanager.ESTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTAuth)(nil), (*v1beta1.ESTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTAuth_To_v1beta1_ESTAuth(a.(*certmanager.ESTAuth), b.(*v1beta1.ESTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ESTBasicAuth)(nil), (*certmanager.ESTBasicAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ESTBasicAuth_To_certmanager_ESTBasicAuth(a.(*v1beta1.ESTBasicAuth), b.(*certmanager.ESTBasicAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTBasicAuth)(nil), (*v1beta1.ESTBasicAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTBasicAuth_To_v1beta1_ESTBasicAuth(a.(*certmanager.ESTBasicAuth), b.(*v1beta1.ESTBasicAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ESTClientCertificateAuth)(nil), (*certmanager.ESTClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(a.(*v1beta1.ESTClientCertificateAuth), b.(*certmanager.ESTClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTClientCertificateAuth)(nil), (*v1beta1.ESTClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTClientCertificateAuth_To_v1beta1_ESTClientCertificateAuth(a.(*certmanager.ESTClientCertificateAuth), b.(*v1beta1.ESTClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ESTIssuer)(nil), (*certmanager.ESTIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ESTIssuer_To_certmanager_ESTIssuer(a.(*v1beta1.ESTIssuer), b.(*certmanager.ESTIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTIssuer)(nil), (*v1beta1.ESTIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTIssuer_To_v1beta1_ESTIssuer(a.(*certmanager.ESTIssuer), b.(*v1beta1.ESTIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Issuer_To_certmanager_Issuer(a.(*v1beta1.Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -243,6 +383,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.IssuerNamespaceAccess)(nil), (*certmanager.IssuerNamespaceAccess)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(a.(*v1beta1.IssuerNamespaceAccess), b.(*certmanager.IssuerNamespaceAccess), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerNamespaceAccess)(nil), (*v1beta1.IssuerNamespaceAccess)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerNamespaceAccess_To_v1beta1_IssuerNamespaceAccess(a.(*certmanager.IssuerNamespaceAccess), b.(*v1beta1.IssuerNamespaceAccess), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.IssuerSpec)(nil), (*certmanager.IssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_IssuerSpec_To_certmanager_IssuerSpec(a.(*v1beta1.IssuerSpec), b.(*certmanager.IssuerSpec), scope)
 	}); err != nil {
@@ -273,6 +423,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.NameConstraintItem)(nil), (*certmanager.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NameConstraintItem_To_certmanager_NameConstraintItem(a.(*v1beta1.NameConstraintItem), b.(*certmanager.NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraintItem)(nil), (*v1beta1.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraintItem_To_v1beta1_NameConstraintItem(a.(*certmanager.NameConstraintItem), b.(*v1beta1.NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.NameConstraints)(nil), (*certmanager.NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NameConstraints_To_certmanager_NameConstraints(a.(*v1beta1.NameConstraints), b.(*certmanager.NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraints)(nil), (*v1beta1.NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraints_To_v1beta1_NameConstraints(a.(*certmanager.NameConstraints), b.(*v1beta1.NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*v1beta1.PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
@@ -283,6 +453,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.SCEPIssuer)(nil), (*certmanager.SCEPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SCEPIssuer_To_certmanager_SCEPIssuer(a.(*v1beta1.SCEPIssuer), b.(*certmanager.SCEPIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.SCEPIssuer)(nil), (*v1beta1.SCEPIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SCEPIssuer_To_v1beta1_SCEPIssuer(a.(*certmanager.SCEPIssuer), b.(*v1beta1.SCEPIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1beta1.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -293,6 +473,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ServiceAccountRef)(nil), (*certmanager.ServiceAccountRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceAccountRef_To_certmanager_ServiceAccountRef(a.(*v1beta1.ServiceAccountRef), b.(*certmanager.ServiceAccountRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ServiceAccountRef)(nil), (*v1beta1.ServiceAccountRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ServiceAccountRef_To_v1beta1_ServiceAccountRef(a.(*certmanager.ServiceAccountRef), b.(*v1beta1.ServiceAccountRef), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.VaultAppRole)(nil), (*certmanager.VaultAppRole)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VaultAppRole_To_certmanager_VaultAppRole(a.(*v1beta1.VaultAppRole), b.(*certmanager.VaultAppRole), scope)
 	}); err != nil {
@@ -313,6 +503,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VaultClientCertificateAuth)(nil), (*certmanager.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(a.(*v1beta1.VaultClientCertificateAuth), b.(*certmanager.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultClientCertificateAuth)(nil), (*v1beta1.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultClientCertificateAuth_To_v1beta1_VaultClientCertificateAuth(a.(*certmanager.VaultClientCertificateAuth), b.(*v1beta1.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.VaultIssuer)(nil), (*certmanager.VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VaultIssuer_To_certmanager_VaultIssuer(a.(*v1beta1.VaultIssuer), b.(*certmanager.VaultIssuer), scope)
 	}); err != nil {
@@ -323,6 +523,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VaultJWTAuth)(nil), (*certmanager.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VaultJWTAuth_To_certmanager_VaultJWTAuth(a.(*v1beta1.VaultJWTAuth), b.(*certmanager.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultJWTAuth)(nil), (*v1beta1.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultJWTAuth_To_v1beta1_VaultJWTAuth(a.(*certmanager.VaultJWTAuth), b.(*v1beta1.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.VaultKubernetesAuth)(nil), (*certmanager.VaultKubernetesAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(a.(*v1beta1.VaultKubernetesAuth), b.(*certmanager.VaultKubernetesAuth), scope)
 	}); err != nil {
@@ -376,10 +586,67 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1beta1_CACRL_To_certmanager_CACRL(in *v1beta1.CACRL, out *certmanager.CACRL, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.ConfigMapName = in.ConfigMapName
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_v1beta1_CACRL_To_certmanager_CACRL is an autogenerated conversion function.
+func Convert_v1beta1_CACRL_To_certmanager_CACRL(in *v1beta1.CACRL, out *certmanager.CACRL, s conversion.Scope) error {
+	return autoConvert_v1beta1_CACRL_To_certmanager_CACRL(in, out, s)
+}
+
+func autoConvert_certmanager_CACRL_To_v1beta1_CACRL(in *certmanager.CACRL, out *v1beta1.CACRL, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.ConfigMapName = in.ConfigMapName
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	return nil
+}
+
+// Convert_certmanager_CACRL_To_v1beta1_CACRL is an autogenerated conversion function.
+func Convert_certmanager_CACRL_To_v1beta1_CACRL(in *certmanager.CACRL, out *v1beta1.CACRL, s conversion.Scope) error {
+	return autoConvert_certmanager_CACRL_To_v1beta1_CACRL(in, out, s)
+}
+
 func autoConvert_v1beta1_CAIssuer_To_certmanager_CAIssuer(in *v1beta1.CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	if in.PrivateKeyPasswordSecretRef != nil {
+		in, out := &in.PrivateKeyPasswordSecretRef, &out.PrivateKeyPasswordSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrivateKeyPasswordSecretRef = nil
+	}
+	if in.ChainSecretRef != nil {
+		in, out := &in.ChainSecretRef, &out.ChainSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainSecretRef = nil
+	}
+	if in.RootSecretRef != nil {
+		in, out := &in.RootSecretRef, &out.RootSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RootSecretRef = nil
+	}
+	out.SignatureAlgorithm = certmanager.SignatureAlgorithm(in.SignatureAlgorithm)
+	out.SerialNumberLength = in.SerialNumberLength
+	out.DefaultDuration = (*v1.Duration)(unsafe.Pointer(in.DefaultDuration))
+	out.MaxDuration = (*v1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.Backdate = (*v1.Duration)(unsafe.Pointer(in.Backdate))
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.Revocation = (*certmanager.CARevocation)(unsafe.Pointer(in.Revocation))
 	return nil
 }
 
@@ -390,8 +657,41 @@ func Convert_v1beta1_CAIssuer_To_certmanager_CAIssuer(in *v1beta1.CAIssuer, out
 
 func autoConvert_certmanager_CAIssuer_To_v1beta1_CAIssuer(in *certmanager.CAIssuer, out *v1beta1.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	if in.PrivateKeyPasswordSecretRef != nil {
+		in, out := &in.PrivateKeyPasswordSecretRef, &out.PrivateKeyPasswordSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrivateKeyPasswordSecretRef = nil
+	}
+	if in.ChainSecretRef != nil {
+		in, out := &in.ChainSecretRef, &out.ChainSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChainSecretRef = nil
+	}
+	if in.RootSecretRef != nil {
+		in, out := &in.RootSecretRef, &out.RootSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RootSecretRef = nil
+	}
+	out.SignatureAlgorithm = v1beta1.SignatureAlgorithm(in.SignatureAlgorithm)
+	out.SerialNumberLength = in.SerialNumberLength
+	out.DefaultDuration = (*v1.Duration)(unsafe.Pointer(in.DefaultDuration))
+	out.MaxDuration = (*v1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.Backdate = (*v1.Duration)(unsafe.Pointer(in.Backdate))
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	out.Revocation = (*v1beta1.CARevocation)(unsafe.Pointer(in.Revocation))
 	return nil
 }
 
@@ -400,6 +700,162 @@ func Convert_certmanager_CAIssuer_To_v1beta1_CAIssuer(in *certmanager.CAIssuer,
 	return autoConvert_certmanager_CAIssuer_To_v1beta1_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1beta1_CAOCSP_To_certmanager_CAOCSP(in *v1beta1.CAOCSP, out *certmanager.CAOCSP, s conversion.Scope) error {
+	out.ResponderSecretName = in.ResponderSecretName
+	out.ResponseDuration = (*v1.Duration)(unsafe.Pointer(in.ResponseDuration))
+	return nil
+}
+
+// Convert_v1beta1_CAOCSP_To_certmanager_CAOCSP is an autogenerated conversion function.
+func Convert_v1beta1_CAOCSP_To_certmanager_CAOCSP(in *v1beta1.CAOCSP, out *certmanager.CAOCSP, s conversion.Scope) error {
+	return autoConvert_v1beta1_CAOCSP_To_certmanager_CAOCSP(in, out, s)
+}
+
+func autoConvert_certmanager_CAOCSP_To_v1beta1_CAOCSP(in *certmanager.CAOCSP, out *v1beta1.CAOCSP, s conversion.Scope) error {
+	out.ResponderSecretName = in.ResponderSecretName
+	out.ResponseDuration = (*v1.Duration)(unsafe.Pointer(in.ResponseDuration))
+	return nil
+}
+
+// Convert_certmanager_CAOCSP_To_v1beta1_CAOCSP is an autogenerated conversion function.
+func Convert_certmanager_CAOCSP_To_v1beta1_CAOCSP(in *certmanager.CAOCSP, out *v1beta1.CAOCSP, s conversion.Scope) error {
+	return autoConvert_certmanager_CAOCSP_To_v1beta1_CAOCSP(in, out, s)
+}
+
+func autoConvert_v1beta1_CARevocation_To_certmanager_CARevocation(in *v1beta1.CARevocation, out *certmanager.CARevocation, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.CRL = (*certmanager.CACRL)(unsafe.Pointer(in.CRL))
+	out.OCSP = (*certmanager.CAOCSP)(unsafe.Pointer(in.OCSP))
+	return nil
+}
+
+// Convert_v1beta1_CARevocation_To_certmanager_CARevocation is an autogenerated conversion function.
+func Convert_v1beta1_CARevocation_To_certmanager_CARevocation(in *v1beta1.CARevocation, out *certmanager.CARevocation, s conversion.Scope) error {
+	return autoConvert_v1beta1_CARevocation_To_certmanager_CARevocation(in, out, s)
+}
+
+func autoConvert_certmanager_CARevocation_To_v1beta1_CARevocation(in *certmanager.CARevocation, out *v1beta1.CARevocation, s conversion.Scope) error {
+	out.SecretName = in.SecretName
+	out.CRL = (*v1beta1.CACRL)(unsafe.Pointer(in.CRL))
+	out.OCSP = (*v1beta1.CAOCSP)(unsafe.Pointer(in.OCSP))
+	return nil
+}
+
+// Convert_certmanager_CARevocation_To_v1beta1_CARevocation is an autogenerated conversion function.
+func Convert_certmanager_CARevocation_To_v1beta1_CARevocation(in *certmanager.CARevocation, out *v1beta1.CARevocation, s conversion.Scope) error {
+	return autoConvert_certmanager_CARevocation_To_v1beta1_CARevocation(in, out, s)
+}
+
+func autoConvert_v1beta1_CMPIssuer_To_certmanager_CMPIssuer(in *v1beta1.CMPIssuer, out *certmanager.CMPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.RequestType = certmanager.CMPRequestType(in.RequestType)
+	if in.SharedSecret != nil {
+		in, out := &in.SharedSecret, &out.SharedSecret
+		*out = new(certmanager.CMPSharedSecretProtection)
+		if err := Convert_v1beta1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SharedSecret = nil
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(certmanager.CMPSignatureProtection)
+		if err := Convert_v1beta1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Signature = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_CMPIssuer_To_certmanager_CMPIssuer is an autogenerated conversion function.
+func Convert_v1beta1_CMPIssuer_To_certmanager_CMPIssuer(in *v1beta1.CMPIssuer, out *certmanager.CMPIssuer, s conversion.Scope) error {
+	return autoConvert_v1beta1_CMPIssuer_To_certmanager_CMPIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_CMPIssuer_To_v1beta1_CMPIssuer(in *certmanager.CMPIssuer, out *v1beta1.CMPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.RequestType = v1beta1.CMPRequestType(in.RequestType)
+	if in.SharedSecret != nil {
+		in, out := &in.SharedSecret, &out.SharedSecret
+		*out = new(v1beta1.CMPSharedSecretProtection)
+		if err := Convert_certmanager_CMPSharedSecretProtection_To_v1beta1_CMPSharedSecretProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SharedSecret = nil
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(v1beta1.CMPSignatureProtection)
+		if err := Convert_certmanager_CMPSignatureProtection_To_v1beta1_CMPSignatureProtection(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Signature = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CMPIssuer_To_v1beta1_CMPIssuer is an autogenerated conversion function.
+func Convert_certmanager_CMPIssuer_To_v1beta1_CMPIssuer(in *certmanager.CMPIssuer, out *v1beta1.CMPIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_CMPIssuer_To_v1beta1_CMPIssuer(in, out, s)
+}
+
+func autoConvert_v1beta1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(in *v1beta1.CMPSharedSecretProtection, out *certmanager.CMPSharedSecretProtection, s conversion.Scope) error {
+	out.Reference = in.Reference
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection is an autogenerated conversion function.
+func Convert_v1beta1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(in *v1beta1.CMPSharedSecretProtection, out *certmanager.CMPSharedSecretProtection, s conversion.Scope) error {
+	return autoConvert_v1beta1_CMPSharedSecretProtection_To_certmanager_CMPSharedSecretProtection(in, out, s)
+}
+
+func autoConvert_certmanager_CMPSharedSecretProtection_To_v1beta1_CMPSharedSecretProtection(in *certmanager.CMPSharedSecretProtection, out *v1beta1.CMPSharedSecretProtection, s conversion.Scope) error {
+	out.Reference = in.Reference
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CMPSharedSecretProtection_To_v1beta1_CMPSharedSecretProtection is an autogenerated conversion function.
+func Convert_certmanager_CMPSharedSecretProtection_To_v1beta1_CMPSharedSecretProtection(in *certmanager.CMPSharedSecretProtection, out *v1beta1.CMPSharedSecretProtection, s conversion.Scope) error {
+	return autoConvert_certmanager_CMPSharedSecretProtection_To_v1beta1_CMPSharedSecretProtection(in, out, s)
+}
+
+func autoConvert_v1beta1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(in *v1beta1.CMPSignatureProtection, out *certmanager.CMPSignatureProtection, s conversion.Scope) error {
+	if err := metav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection is an autogenerated conversion function.
+func Convert_v1beta1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(in *v1beta1.CMPSignatureProtection, out *certmanager.CMPSignatureProtection, s conversion.Scope) error {
+	return autoConvert_v1beta1_CMPSignatureProtection_To_certmanager_CMPSignatureProtection(in, out, s)
+}
+
+func autoConvert_certmanager_CMPSignatureProtection_To_v1beta1_CMPSignatureProtection(in *certmanager.CMPSignatureProtection, out *v1beta1.CMPSignatureProtection, s conversion.Scope) error {
+	if err := metav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CMPSignatureProtection_To_v1beta1_CMPSignatureProtection is an autogenerated conversion function.
+func Convert_certmanager_CMPSignatureProtection_To_v1beta1_CMPSignatureProtection(in *certmanager.CMPSignatureProtection, out *v1beta1.CMPSignatureProtection, s conversion.Scope) error {
+	return autoConvert_certmanager_CMPSignatureProtection_To_v1beta1_CMPSignatureProtection(in, out, s)
+}
+
 func autoConvert_v1beta1_Certificate_To_certmanager_Certificate(in *v1beta1.Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -449,7 +905,7 @@ func Convert_v1beta1_CertificateCondition_To_certmanager_CertificateCondition(in
 
 func autoConvert_certmanager_CertificateCondition_To_v1beta1_CertificateCondition(in *certmanager.CertificateCondition, out *v1beta1.CertificateCondition, s conversion.Scope) error {
 	out.Type = v1beta1.CertificateConditionType(in.Type)
-	out.Status = metav1.ConditionStatus(in.Status)
+	out.Status = apismetav1.ConditionStatus(in.Status)
 	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
@@ -558,6 +1014,26 @@ func Convert_certmanager_CertificateList_To_v1beta1_CertificateList(in *certmana
 	return autoConvert_certmanager_CertificateList_To_v1beta1_CertificateList(in, out, s)
 }
 
+func autoConvert_v1beta1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *v1beta1.CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_v1beta1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_v1beta1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in *v1beta1.CertificateOCSPStapling, out *certmanager.CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateOCSPStapling_To_certmanager_CertificateOCSPStapling(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateOCSPStapling_To_v1beta1_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *v1beta1.CertificateOCSPStapling, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_certmanager_CertificateOCSPStapling_To_v1beta1_CertificateOCSPStapling is an autogenerated conversion function.
+func Convert_certmanager_CertificateOCSPStapling_To_v1beta1_CertificateOCSPStapling(in *certmanager.CertificateOCSPStapling, out *v1beta1.CertificateOCSPStapling, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateOCSPStapling_To_v1beta1_CertificateOCSPStapling(in, out, s)
+}
+
 func autoConvert_v1beta1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *v1beta1.CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
 	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
@@ -584,6 +1060,36 @@ func Convert_certmanager_CertificatePrivateKey_To_v1beta1_CertificatePrivateKey(
 	return autoConvert_certmanager_CertificatePrivateKey_To_v1beta1_CertificatePrivateKey(in, out, s)
 }
 
+func autoConvert_v1beta1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1beta1.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.ExplanationURL = in.ExplanationURL
+	out.LastCheckTime = in.LastCheckTime
+	out.NextCheckTime = in.NextCheckTime
+	return nil
+}
+
+// Convert_v1beta1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_v1beta1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1beta1.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalInfo_To_v1beta1_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1beta1.CertificateRenewalInfo, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.ExplanationURL = in.ExplanationURL
+	out.LastCheckTime = in.LastCheckTime
+	out.NextCheckTime = in.NextCheckTime
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalInfo_To_v1beta1_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalInfo_To_v1beta1_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1beta1.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalInfo_To_v1beta1_CertificateRenewalInfo(in, out, s)
+}
+
 func autoConvert_v1beta1_CertificateRequest_To_certmanager_CertificateRequest(in *v1beta1.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -632,7 +1138,7 @@ func Convert_v1beta1_CertificateRequestCondition_To_certmanager_CertificateReque
 
 func autoConvert_certmanager_CertificateRequestCondition_To_v1beta1_CertificateRequestCondition(in *certmanager.CertificateRequestCondition, out *v1beta1.CertificateRequestCondition, s conversion.Scope) error {
 	out.Type = v1beta1.CertificateRequestConditionType(in.Type)
-	out.Status = metav1.ConditionStatus(in.Status)
+	out.Status = apismetav1.ConditionStatus(in.Status)
 	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
@@ -688,7 +1194,7 @@ func Convert_certmanager_CertificateRequestList_To_v1beta1_CertificateRequestLis
 
 func autoConvert_v1beta1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(in *v1beta1.CertificateRequestSpec, out *certmanager.CertificateRequestSpec, s conversion.Scope) error {
 	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
@@ -708,7 +1214,7 @@ func Convert_v1beta1_CertificateRequestSpec_To_certmanager_CertificateRequestSpe
 
 func autoConvert_certmanager_CertificateRequestSpec_To_v1beta1_CertificateRequestSpec(in *certmanager.CertificateRequestSpec, out *v1beta1.CertificateRequestSpec, s conversion.Scope) error {
 	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
-	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
@@ -752,6 +1258,52 @@ func Convert_certmanager_CertificateRequestStatus_To_v1beta1_CertificateRequestS
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1beta1_CertificateRequestStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_CertificateRevocation_To_certmanager_CertificateRevocation(in *v1beta1.CertificateRevocation, out *certmanager.CertificateRevocation, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.Reason = in.Reason
+	out.RevocationTime = (*v1.Time)(unsafe.Pointer(in.RevocationTime))
+	return nil
+}
+
+// Convert_v1beta1_CertificateRevocation_To_certmanager_CertificateRevocation is an autogenerated conversion function.
+func Convert_v1beta1_CertificateRevocation_To_certmanager_CertificateRevocation(in *v1beta1.CertificateRevocation, out *certmanager.CertificateRevocation, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateRevocation_To_certmanager_CertificateRevocation(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocation_To_v1beta1_CertificateRevocation(in *certmanager.CertificateRevocation, out *v1beta1.CertificateRevocation, s conversion.Scope) error {
+	out.SerialNumber = in.SerialNumber
+	out.Reason = in.Reason
+	out.RevocationTime = (*v1.Time)(unsafe.Pointer(in.RevocationTime))
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocation_To_v1beta1_CertificateRevocation is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocation_To_v1beta1_CertificateRevocation(in *certmanager.CertificateRevocation, out *v1beta1.CertificateRevocation, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocation_To_v1beta1_CertificateRevocation(in, out, s)
+}
+
+func autoConvert_v1beta1_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(in *v1beta1.CertificateRevocationCheck, out *certmanager.CertificateRevocationCheck, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	return nil
+}
+
+// Convert_v1beta1_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck is an autogenerated conversion function.
+func Convert_v1beta1_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(in *v1beta1.CertificateRevocationCheck, out *certmanager.CertificateRevocationCheck, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateRevocationCheck_To_certmanager_CertificateRevocationCheck(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRevocationCheck_To_v1beta1_CertificateRevocationCheck(in *certmanager.CertificateRevocationCheck, out *v1beta1.CertificateRevocationCheck, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	return nil
+}
+
+// Convert_certmanager_CertificateRevocationCheck_To_v1beta1_CertificateRevocationCheck is an autogenerated conversion function.
+func Convert_certmanager_CertificateRevocationCheck_To_v1beta1_CertificateRevocationCheck(in *certmanager.CertificateRevocationCheck, out *v1beta1.CertificateRevocationCheck, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRevocationCheck_To_v1beta1_CertificateRevocationCheck(in, out, s)
+}
+
 func autoConvert_v1beta1_CertificateSecretTemplate_To_certmanager_CertificateSecretTemplate(in *v1beta1.CertificateSecretTemplate, out *certmanager.CertificateSecretTemplate, s conversion.Scope) error {
 	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
@@ -794,14 +1346,18 @@ func autoConvert_v1beta1_CertificateSpec_To_certmanager_CertificateSpec(in *v1be
 	} else {
 		out.Keystores = nil
 	}
-	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.OCSPStapling = (*certmanager.CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	if err := metav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
+	out.NameConstraints = (*certmanager.NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.PrivateKey = (*certmanager.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	out.RevocationPolicy = certmanager.CertificateRevocationPolicy(in.RevocationPolicy)
+	out.RevocationCheck = (*certmanager.CertificateRevocationCheck)(unsafe.Pointer(in.RevocationCheck))
 	return nil
 }
 
@@ -830,14 +1386,18 @@ func autoConvert_certmanager_CertificateSpec_To_v1beta1_CertificateSpec(in *cert
 	} else {
 		out.Keystores = nil
 	}
-	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+	out.OCSPStapling = (*v1beta1.CertificateOCSPStapling)(unsafe.Pointer(in.OCSPStapling))
+	if err := metav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
 	out.IsCA = in.IsCA
+	out.NameConstraints = (*v1beta1.NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.Usages = *(*[]v1beta1.KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.PrivateKey = (*v1beta1.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
+	out.RevocationPolicy = v1beta1.CertificateRevocationPolicy(in.RevocationPolicy)
+	out.RevocationCheck = (*v1beta1.CertificateRevocationCheck)(unsafe.Pointer(in.RevocationCheck))
 	return nil
 }
 
@@ -854,6 +1414,8 @@ func autoConvert_v1beta1_CertificateStatus_To_certmanager_CertificateStatus(in *
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
+	out.LastRevocation = (*certmanager.CertificateRevocation)(unsafe.Pointer(in.LastRevocation))
+	out.RenewalInfo = (*certmanager.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	return nil
 }
 
@@ -870,6 +1432,8 @@ func autoConvert_certmanager_CertificateStatus_To_v1beta1_CertificateStatus(in *
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
+	out.LastRevocation = (*v1beta1.CertificateRevocation)(unsafe.Pointer(in.LastRevocation))
+	out.RenewalInfo = (*v1beta1.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	return nil
 }
 
@@ -952,6 +1516,154 @@ func Convert_certmanager_ClusterIssuerList_To_v1beta1_ClusterIssuerList(in *cert
 	return autoConvert_certmanager_ClusterIssuerList_To_v1beta1_ClusterIssuerList(in, out, s)
 }
 
+func autoConvert_v1beta1_ESTAuth_To_certmanager_ESTAuth(in *v1beta1.ESTAuth, out *certmanager.ESTAuth, s conversion.Scope) error {
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(certmanager.ESTBasicAuth)
+		if err := Convert_v1beta1_ESTBasicAuth_To_certmanager_ESTBasicAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BasicAuth = nil
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(certmanager.ESTClientCertificateAuth)
+		if err := Convert_v1beta1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClientCertificate = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_ESTAuth_To_certmanager_ESTAuth is an autogenerated conversion function.
+func Convert_v1beta1_ESTAuth_To_certmanager_ESTAuth(in *v1beta1.ESTAuth, out *certmanager.ESTAuth, s conversion.Scope) error {
+	return autoConvert_v1beta1_ESTAuth_To_certmanager_ESTAuth(in, out, s)
+}
+
+func autoConvert_certmanager_ESTAuth_To_v1beta1_ESTAuth(in *certmanager.ESTAuth, out *v1beta1.ESTAuth, s conversion.Scope) error {
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(v1beta1.ESTBasicAuth)
+		if err := Convert_certmanager_ESTBasicAuth_To_v1beta1_ESTBasicAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BasicAuth = nil
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(v1beta1.ESTClientCertificateAuth)
+		if err := Convert_certmanager_ESTClientCertificateAuth_To_v1beta1_ESTClientCertificateAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClientCertificate = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_ESTAuth_To_v1beta1_ESTAuth is an autogenerated conversion function.
+func Convert_certmanager_ESTAuth_To_v1beta1_ESTAuth(in *certmanager.ESTAuth, out *v1beta1.ESTAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTAuth_To_v1beta1_ESTAuth(in, out, s)
+}
+
+func autoConvert_v1beta1_ESTBasicAuth_To_certmanager_ESTBasicAuth(in *v1beta1.ESTBasicAuth, out *certmanager.ESTBasicAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ESTBasicAuth_To_certmanager_ESTBasicAuth is an autogenerated conversion function.
+func Convert_v1beta1_ESTBasicAuth_To_certmanager_ESTBasicAuth(in *v1beta1.ESTBasicAuth, out *certmanager.ESTBasicAuth, s conversion.Scope) error {
+	return autoConvert_v1beta1_ESTBasicAuth_To_certmanager_ESTBasicAuth(in, out, s)
+}
+
+func autoConvert_certmanager_ESTBasicAuth_To_v1beta1_ESTBasicAuth(in *certmanager.ESTBasicAuth, out *v1beta1.ESTBasicAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_ESTBasicAuth_To_v1beta1_ESTBasicAuth is an autogenerated conversion function.
+func Convert_certmanager_ESTBasicAuth_To_v1beta1_ESTBasicAuth(in *certmanager.ESTBasicAuth, out *v1beta1.ESTBasicAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTBasicAuth_To_v1beta1_ESTBasicAuth(in, out, s)
+}
+
+func autoConvert_v1beta1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(in *v1beta1.ESTClientCertificateAuth, out *certmanager.ESTClientCertificateAuth, s conversion.Scope) error {
+	if err := metav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1beta1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(in *v1beta1.ESTClientCertificateAuth, out *certmanager.ESTClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1beta1_ESTClientCertificateAuth_To_certmanager_ESTClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_ESTClientCertificateAuth_To_v1beta1_ESTClientCertificateAuth(in *certmanager.ESTClientCertificateAuth, out *v1beta1.ESTClientCertificateAuth, s conversion.Scope) error {
+	if err := metav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.SecretRef, &out.SecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_ESTClientCertificateAuth_To_v1beta1_ESTClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_ESTClientCertificateAuth_To_v1beta1_ESTClientCertificateAuth(in *certmanager.ESTClientCertificateAuth, out *v1beta1.ESTClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTClientCertificateAuth_To_v1beta1_ESTClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_v1beta1_ESTIssuer_To_certmanager_ESTIssuer(in *v1beta1.ESTIssuer, out *certmanager.ESTIssuer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.Label = in.Label
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(certmanager.ESTAuth)
+		if err := Convert_v1beta1_ESTAuth_To_certmanager_ESTAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Auth = nil
+	}
+	out.Reenroll = in.Reenroll
+	return nil
+}
+
+// Convert_v1beta1_ESTIssuer_To_certmanager_ESTIssuer is an autogenerated conversion function.
+func Convert_v1beta1_ESTIssuer_To_certmanager_ESTIssuer(in *v1beta1.ESTIssuer, out *certmanager.ESTIssuer, s conversion.Scope) error {
+	return autoConvert_v1beta1_ESTIssuer_To_certmanager_ESTIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_ESTIssuer_To_v1beta1_ESTIssuer(in *certmanager.ESTIssuer, out *v1beta1.ESTIssuer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.Label = in.Label
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(v1beta1.ESTAuth)
+		if err := Convert_certmanager_ESTAuth_To_v1beta1_ESTAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Auth = nil
+	}
+	out.Reenroll = in.Reenroll
+	return nil
+}
+
+// Convert_certmanager_ESTIssuer_To_v1beta1_ESTIssuer is an autogenerated conversion function.
+func Convert_certmanager_ESTIssuer_To_v1beta1_ESTIssuer(in *certmanager.ESTIssuer, out *v1beta1.ESTIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTIssuer_To_v1beta1_ESTIssuer(in, out, s)
+}
+
 func autoConvert_v1beta1_Issuer_To_certmanager_Issuer(in *v1beta1.Issuer, out *certmanager.Issuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1001,7 +1713,7 @@ func Convert_v1beta1_IssuerCondition_To_certmanager_IssuerCondition(in *v1beta1.
 
 func autoConvert_certmanager_IssuerCondition_To_v1beta1_IssuerCondition(in *certmanager.IssuerCondition, out *v1beta1.IssuerCondition, s conversion.Scope) error {
 	out.Type = v1beta1.IssuerConditionType(in.Type)
-	out.Status = metav1.ConditionStatus(in.Status)
+	out.Status = apismetav1.ConditionStatus(in.Status)
 	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
 	out.Reason = in.Reason
 	out.Message = in.Message
@@ -1024,7 +1736,15 @@ func autoConvert_v1beta1_IssuerConfig_To_certmanager_IssuerConfig(in *v1beta1.Is
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(certmanager.CAIssuer)
+		if err := Convert_v1beta1_CAIssuer_To_certmanager_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(certmanager.VaultIssuer)
@@ -1044,6 +1764,33 @@ func autoConvert_v1beta1_IssuerConfig_To_certmanager_IssuerConfig(in *v1beta1.Is
 	} else {
 		out.Venafi = nil
 	}
+	if in.EST != nil {
+		in, out := &in.EST, &out.EST
+		*out = new(certmanager.ESTIssuer)
+		if err := Convert_v1beta1_ESTIssuer_To_certmanager_ESTIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EST = nil
+	}
+	if in.SCEP != nil {
+		in, out := &in.SCEP, &out.SCEP
+		*out = new(certmanager.SCEPIssuer)
+		if err := Convert_v1beta1_SCEPIssuer_To_certmanager_SCEPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SCEP = nil
+	}
+	if in.CMP != nil {
+		in, out := &in.CMP, &out.CMP
+		*out = new(certmanager.CMPIssuer)
+		if err := Convert_v1beta1_CMPIssuer_To_certmanager_CMPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CMP = nil
+	}
 	return nil
 }
 
@@ -1062,7 +1809,15 @@ func autoConvert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(in *certmanage
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*v1beta1.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(v1beta1.CAIssuer)
+		if err := Convert_certmanager_CAIssuer_To_v1beta1_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(v1beta1.VaultIssuer)
@@ -1082,6 +1837,33 @@ func autoConvert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(in *certmanage
 	} else {
 		out.Venafi = nil
 	}
+	if in.EST != nil {
+		in, out := &in.EST, &out.EST
+		*out = new(v1beta1.ESTIssuer)
+		if err := Convert_certmanager_ESTIssuer_To_v1beta1_ESTIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EST = nil
+	}
+	if in.SCEP != nil {
+		in, out := &in.SCEP, &out.SCEP
+		*out = new(v1beta1.SCEPIssuer)
+		if err := Convert_certmanager_SCEPIssuer_To_v1beta1_SCEPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SCEP = nil
+	}
+	if in.CMP != nil {
+		in, out := &in.CMP, &out.CMP
+		*out = new(v1beta1.CMPIssuer)
+		if err := Convert_certmanager_CMPIssuer_To_v1beta1_CMPIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CMP = nil
+	}
 	return nil
 }
 
@@ -1132,10 +1914,33 @@ func Convert_certmanager_IssuerList_To_v1beta1_IssuerList(in *certmanager.Issuer
 	return autoConvert_certmanager_IssuerList_To_v1beta1_IssuerList(in, out, s)
 }
 
+func autoConvert_v1beta1_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(in *v1beta1.IssuerNamespaceAccess, out *certmanager.IssuerNamespaceAccess, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	return nil
+}
+
+// Convert_v1beta1_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess is an autogenerated conversion function.
+func Convert_v1beta1_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(in *v1beta1.IssuerNamespaceAccess, out *certmanager.IssuerNamespaceAccess, s conversion.Scope) error {
+	return autoConvert_v1beta1_IssuerNamespaceAccess_To_certmanager_IssuerNamespaceAccess(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerNamespaceAccess_To_v1beta1_IssuerNamespaceAccess(in *certmanager.IssuerNamespaceAccess, out *v1beta1.IssuerNamespaceAccess, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	return nil
+}
+
+// Convert_certmanager_IssuerNamespaceAccess_To_v1beta1_IssuerNamespaceAccess is an autogenerated conversion function.
+func Convert_certmanager_IssuerNamespaceAccess_To_v1beta1_IssuerNamespaceAccess(in *certmanager.IssuerNamespaceAccess, out *v1beta1.IssuerNamespaceAccess, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerNamespaceAccess_To_v1beta1_IssuerNamespaceAccess(in, out, s)
+}
+
 func autoConvert_v1beta1_IssuerSpec_To_certmanager_IssuerSpec(in *v1beta1.IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.NamespaceAccess = (*certmanager.IssuerNamespaceAccess)(unsafe.Pointer(in.NamespaceAccess))
 	return nil
 }
 
@@ -1148,6 +1953,7 @@ func autoConvert_certmanager_IssuerSpec_To_v1beta1_IssuerSpec(in *certmanager.Is
 	if err := Convert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.NamespaceAccess = (*v1beta1.IssuerNamespaceAccess)(unsafe.Pointer(in.NamespaceAccess))
 	return nil
 }
 
@@ -1196,7 +2002,7 @@ func Convert_certmanager_IssuerStatus_To_v1beta1_IssuerStatus(in *certmanager.Is
 
 func autoConvert_v1beta1_JKSKeystore_To_certmanager_JKSKeystore(in *v1beta1.JKSKeystore, out *certmanager.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1209,7 +2015,7 @@ func Convert_v1beta1_JKSKeystore_To_certmanager_JKSKeystore(in *v1beta1.JKSKeyst
 
 func autoConvert_certmanager_JKSKeystore_To_v1beta1_JKSKeystore(in *certmanager.JKSKeystore, out *v1beta1.JKSKeystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1220,9 +2026,59 @@ func Convert_certmanager_JKSKeystore_To_v1beta1_JKSKeystore(in *certmanager.JKSK
 	return autoConvert_certmanager_JKSKeystore_To_v1beta1_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1beta1_NameConstraintItem_To_certmanager_NameConstraintItem(in *v1beta1.NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.URIDomains = *(*[]string)(unsafe.Pointer(&in.URIDomains))
+	return nil
+}
+
+// Convert_v1beta1_NameConstraintItem_To_certmanager_NameConstraintItem is an autogenerated conversion function.
+func Convert_v1beta1_NameConstraintItem_To_certmanager_NameConstraintItem(in *v1beta1.NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_v1beta1_NameConstraintItem_To_certmanager_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraintItem_To_v1beta1_NameConstraintItem(in *certmanager.NameConstraintItem, out *v1beta1.NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.URIDomains = *(*[]string)(unsafe.Pointer(&in.URIDomains))
+	return nil
+}
+
+// Convert_certmanager_NameConstraintItem_To_v1beta1_NameConstraintItem is an autogenerated conversion function.
+func Convert_certmanager_NameConstraintItem_To_v1beta1_NameConstraintItem(in *certmanager.NameConstraintItem, out *v1beta1.NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraintItem_To_v1beta1_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_v1beta1_NameConstraints_To_certmanager_NameConstraints(in *v1beta1.NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	out.Critical = in.Critical
+	out.Permitted = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_v1beta1_NameConstraints_To_certmanager_NameConstraints is an autogenerated conversion function.
+func Convert_v1beta1_NameConstraints_To_certmanager_NameConstraints(in *v1beta1.NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	return autoConvert_v1beta1_NameConstraints_To_certmanager_NameConstraints(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraints_To_v1beta1_NameConstraints(in *certmanager.NameConstraints, out *v1beta1.NameConstraints, s conversion.Scope) error {
+	out.Critical = in.Critical
+	out.Permitted = (*v1beta1.NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*v1beta1.NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_certmanager_NameConstraints_To_v1beta1_NameConstraints is an autogenerated conversion function.
+func Convert_certmanager_NameConstraints_To_v1beta1_NameConstraints(in *certmanager.NameConstraints, out *v1beta1.NameConstraints, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraints_To_v1beta1_NameConstraints(in, out, s)
+}
+
 func autoConvert_v1beta1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1beta1.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1235,7 +2091,7 @@ func Convert_v1beta1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1beta1.PK
 
 func autoConvert_certmanager_PKCS12Keystore_To_v1beta1_PKCS12Keystore(in *certmanager.PKCS12Keystore, out *v1beta1.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1246,6 +2102,46 @@ func Convert_certmanager_PKCS12Keystore_To_v1beta1_PKCS12Keystore(in *certmanage
 	return autoConvert_certmanager_PKCS12Keystore_To_v1beta1_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1beta1_SCEPIssuer_To_certmanager_SCEPIssuer(in *v1beta1.SCEPIssuer, out *certmanager.SCEPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.ChallengePasswordSecretRef != nil {
+		in, out := &in.ChallengePasswordSecretRef, &out.ChallengePasswordSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChallengePasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_SCEPIssuer_To_certmanager_SCEPIssuer is an autogenerated conversion function.
+func Convert_v1beta1_SCEPIssuer_To_certmanager_SCEPIssuer(in *v1beta1.SCEPIssuer, out *certmanager.SCEPIssuer, s conversion.Scope) error {
+	return autoConvert_v1beta1_SCEPIssuer_To_certmanager_SCEPIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_SCEPIssuer_To_v1beta1_SCEPIssuer(in *certmanager.SCEPIssuer, out *v1beta1.SCEPIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.ChallengePasswordSecretRef != nil {
+		in, out := &in.ChallengePasswordSecretRef, &out.ChallengePasswordSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ChallengePasswordSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_SCEPIssuer_To_v1beta1_SCEPIssuer is an autogenerated conversion function.
+func Convert_certmanager_SCEPIssuer_To_v1beta1_SCEPIssuer(in *certmanager.SCEPIssuer, out *v1beta1.SCEPIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_SCEPIssuer_To_v1beta1_SCEPIssuer(in, out, s)
+}
+
 func autoConvert_v1beta1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1beta1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1266,10 +2162,30 @@ func Convert_certmanager_SelfSignedIssuer_To_v1beta1_SelfSignedIssuer(in *certma
 	return autoConvert_certmanager_SelfSignedIssuer_To_v1beta1_SelfSignedIssuer(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceAccountRef_To_certmanager_ServiceAccountRef(in *v1beta1.ServiceAccountRef, out *certmanager.ServiceAccountRef, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1beta1_ServiceAccountRef_To_certmanager_ServiceAccountRef is an autogenerated conversion function.
+func Convert_v1beta1_ServiceAccountRef_To_certmanager_ServiceAccountRef(in *v1beta1.ServiceAccountRef, out *certmanager.ServiceAccountRef, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceAccountRef_To_certmanager_ServiceAccountRef(in, out, s)
+}
+
+func autoConvert_certmanager_ServiceAccountRef_To_v1beta1_ServiceAccountRef(in *certmanager.ServiceAccountRef, out *v1beta1.ServiceAccountRef, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_ServiceAccountRef_To_v1beta1_ServiceAccountRef is an autogenerated conversion function.
+func Convert_certmanager_ServiceAccountRef_To_v1beta1_ServiceAccountRef(in *certmanager.ServiceAccountRef, out *v1beta1.ServiceAccountRef, s conversion.Scope) error {
+	return autoConvert_certmanager_ServiceAccountRef_To_v1beta1_ServiceAccountRef(in, out, s)
+}
+
 func autoConvert_v1beta1_VaultAppRole_To_certmanager_VaultAppRole(in *v1beta1.VaultAppRole, out *certmanager.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1283,7 +2199,7 @@ func Convert_v1beta1_VaultAppRole_To_certmanager_VaultAppRole(in *v1beta1.VaultA
 func autoConvert_certmanager_VaultAppRole_To_v1beta1_VaultAppRole(in *certmanager.VaultAppRole, out *v1beta1.VaultAppRole, s conversion.Scope) error {
 	out.Path = in.Path
 	out.RoleId = in.RoleId
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1298,7 +2214,7 @@ func autoConvert_v1beta1_VaultAuth_To_certmanager_VaultAuth(in *v1beta1.VaultAut
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
 		*out = new(meta.SecretKeySelector)
-		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1322,6 +2238,8 @@ func autoConvert_v1beta1_VaultAuth_To_certmanager_VaultAuth(in *v1beta1.VaultAut
 	} else {
 		out.Kubernetes = nil
 	}
+	out.JWT = (*certmanager.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*certmanager.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1333,8 +2251,8 @@ func Convert_v1beta1_VaultAuth_To_certmanager_VaultAuth(in *v1beta1.VaultAuth, o
 func autoConvert_certmanager_VaultAuth_To_v1beta1_VaultAuth(in *certmanager.VaultAuth, out *v1beta1.VaultAuth, s conversion.Scope) error {
 	if in.TokenSecretRef != nil {
 		in, out := &in.TokenSecretRef, &out.TokenSecretRef
-		*out = new(metav1.SecretKeySelector)
-		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
@@ -1358,6 +2276,8 @@ func autoConvert_certmanager_VaultAuth_To_v1beta1_VaultAuth(in *certmanager.Vaul
 	} else {
 		out.Kubernetes = nil
 	}
+	out.JWT = (*v1beta1.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*v1beta1.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1366,12 +2286,38 @@ func Convert_certmanager_VaultAuth_To_v1beta1_VaultAuth(in *certmanager.VaultAut
 	return autoConvert_certmanager_VaultAuth_To_v1beta1_VaultAuth(in, out, s)
 }
 
+func autoConvert_v1beta1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1beta1.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1beta1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1beta1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1beta1.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1beta1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultClientCertificateAuth_To_v1beta1_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1beta1.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_VaultClientCertificateAuth_To_v1beta1_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultClientCertificateAuth_To_v1beta1_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1beta1.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultClientCertificateAuth_To_v1beta1_VaultClientCertificateAuth(in, out, s)
+}
+
 func autoConvert_v1beta1_VaultIssuer_To_certmanager_VaultIssuer(in *v1beta1.VaultIssuer, out *certmanager.VaultIssuer, s conversion.Scope) error {
 	if err := Convert_v1beta1_VaultAuth_To_certmanager_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
 		return err
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
+	out.SignVerbatim = in.SignVerbatim
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
@@ -1388,6 +2334,8 @@ func autoConvert_certmanager_VaultIssuer_To_v1beta1_VaultIssuer(in *certmanager.
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
+	out.SignVerbatim = in.SignVerbatim
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	return nil
@@ -1398,9 +2346,39 @@ func Convert_certmanager_VaultIssuer_To_v1beta1_VaultIssuer(in *certmanager.Vaul
 	return autoConvert_certmanager_VaultIssuer_To_v1beta1_VaultIssuer(in, out, s)
 }
 
+func autoConvert_v1beta1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1beta1.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	if err := Convert_v1beta1_ServiceAccountRef_To_certmanager_ServiceAccountRef(&in.ServiceAccountRef, &out.ServiceAccountRef, s); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_v1beta1_VaultJWTAuth_To_certmanager_VaultJWTAuth is an autogenerated conversion function.
+func Convert_v1beta1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1beta1.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_v1beta1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultJWTAuth_To_v1beta1_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1beta1.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	if err := Convert_certmanager_ServiceAccountRef_To_v1beta1_ServiceAccountRef(&in.ServiceAccountRef, &out.ServiceAccountRef, s); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_certmanager_VaultJWTAuth_To_v1beta1_VaultJWTAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultJWTAuth_To_v1beta1_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1beta1.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultJWTAuth_To_v1beta1_VaultJWTAuth(in, out, s)
+}
+
 func autoConvert_v1beta1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1beta1.VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1414,7 +2392,7 @@ func Convert_v1beta1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *
 
 func autoConvert_certmanager_VaultKubernetesAuth_To_v1beta1_VaultKubernetesAuth(in *certmanager.VaultKubernetesAuth, out *v1beta1.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.SecretRef, &out.SecretRef, s); err != nil {
 		return err
 	}
 	out.Role = in.Role
@@ -1428,7 +2406,7 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1beta1_VaultKubernetesAuth(in *
 
 func autoConvert_v1beta1_VenafiCloud_To_certmanager_VenafiCloud(in *v1beta1.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1441,7 +2419,7 @@ func Convert_v1beta1_VenafiCloud_To_certmanager_VenafiCloud(in *v1beta1.VenafiCl
 
 func autoConvert_certmanager_VenafiCloud_To_v1beta1_VenafiCloud(in *certmanager.VenafiCloud, out *v1beta1.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
 		return err
 	}
 	return nil
@@ -1510,7 +2488,7 @@ func Convert_certmanager_VenafiIssuer_To_v1beta1_VenafiIssuer(in *certmanager.Ve
 
 func autoConvert_v1beta1_VenafiTPP_To_certmanager_VenafiTPP(in *v1beta1.VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := metav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
@@ -1524,7 +2502,7 @@ func Convert_v1beta1_VenafiTPP_To_certmanager_VenafiTPP(in *v1beta1.VenafiTPP, o
 
 func autoConvert_certmanager_VenafiTPP_To_v1beta1_VenafiTPP(in *certmanager.VenafiTPP, out *v1beta1.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
-	if err := apismetav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
+	if err := metav1.Convert_meta_LocalObjectReference_To_v1_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
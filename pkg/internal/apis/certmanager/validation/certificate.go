@@ -26,6 +26,7 @@ import (
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/jetstack/cert-manager/pkg/api/util"
@@ -33,6 +34,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/internal/api/validation"
 	internalcmapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
 	cmmeta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
 // Validation functions for cert-manager Certificate types
@@ -79,6 +81,10 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		}
 	}
 
+	if crt.NameConstraints != nil {
+		el = append(el, validateNameConstraints(crt, fldPath)...)
+	}
+
 	if crt.Duration != nil || crt.RenewBefore != nil {
 		el = append(el, ValidateDuration(crt, fldPath)...)
 	}
@@ -88,6 +94,10 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 	if crt.RevisionHistoryLimit != nil && *crt.RevisionHistoryLimit < 1 {
 		el = append(el, field.Invalid(fldPath.Child("revisionHistoryLimit"), *crt.RevisionHistoryLimit, "must not be less than 1"))
 	}
+	if crt.RevocationCheck != nil && crt.RevocationCheck.Interval != nil && crt.RevocationCheck.Interval.Duration < cmapi.MinimumRevocationCheckInterval {
+		el = append(el, field.Invalid(fldPath.Child("revocationCheck", "interval"), crt.RevocationCheck.Interval.Duration,
+			fmt.Sprintf("must not be less than %s", cmapi.MinimumRevocationCheckInterval)))
+	}
 
 	if crt.SecretTemplate != nil {
 		if len(crt.SecretTemplate.Labels) > 0 {
@@ -104,6 +114,7 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 func ValidateCertificate(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, validation.WarningList) {
 	crt := obj.(*internalcmapi.Certificate)
 	allErrs := ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateRevokeAnnotation(crt.Annotations, field.NewPath("metadata", "annotations"))...)
 	w := validateAPIVersion(a.RequestKind)
 	return allErrs, w
 }
@@ -111,10 +122,25 @@ func ValidateCertificate(a *admissionv1.AdmissionRequest, obj runtime.Object) (f
 func ValidateUpdateCertificate(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, validation.WarningList) {
 	crt := obj.(*internalcmapi.Certificate)
 	allErrs := ValidateCertificateSpec(&crt.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateRevokeAnnotation(crt.Annotations, field.NewPath("metadata", "annotations"))...)
 	w := validateAPIVersion(a.RequestKind)
 	return allErrs, w
 }
 
+// validateRevokeAnnotation ensures that the value of the
+// `cert-manager.io/revoke` annotation, if present, is a valid RFC 5280
+// revocation reason.
+func validateRevokeAnnotation(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	reason, ok := annotations[internalcmapi.RevokeCertificateAnnotation]
+	if !ok {
+		return nil
+	}
+	if _, err := pki.ParseRevocationReason(reason); err != nil {
+		return field.ErrorList{field.Invalid(fldPath.Key(internalcmapi.RevokeCertificateAnnotation), reason, err.Error())}
+	}
+	return nil
+}
+
 func validateIssuerRef(issuerRef cmmeta.ObjectReference, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
@@ -165,6 +191,66 @@ func validateEmailAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Pat
 	return el
 }
 
+func validateNameConstraints(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	fldPath = fldPath.Child("nameConstraints")
+	if !crt.IsCA {
+		el = append(el, field.Invalid(fldPath, "", "may only be set when isCA is true"))
+	}
+
+	nc := crt.NameConstraints
+	if nameConstraintItemEmpty(nc.Permitted) && nameConstraintItemEmpty(nc.Excluded) {
+		el = append(el, field.Invalid(fldPath, "", "at least one permitted or excluded name must be set"))
+	}
+	if nc.Permitted != nil {
+		el = append(el, validateNameConstraintItem(nc.Permitted, fldPath.Child("permitted"))...)
+	}
+	if nc.Excluded != nil {
+		el = append(el, validateNameConstraintItem(nc.Excluded, fldPath.Child("excluded"))...)
+	}
+	return el
+}
+
+func nameConstraintItemEmpty(item *internalcmapi.NameConstraintItem) bool {
+	return item == nil || (len(item.DNSDomains) == 0 && len(item.IPRanges) == 0 && len(item.EmailAddresses) == 0 && len(item.URIDomains) == 0)
+}
+
+func validateNameConstraintItem(item *internalcmapi.NameConstraintItem, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, d := range item.DNSDomains {
+		if !validConstraintDomain(d) {
+			el = append(el, field.Invalid(fldPath.Child("dnsDomains").Index(i), d, "invalid DNS domain"))
+		}
+	}
+	for i, r := range item.IPRanges {
+		if _, _, err := net.ParseCIDR(r); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("ipRanges").Index(i), r, "invalid IP range, must be in CIDR notation"))
+		}
+	}
+	for i, e := range item.EmailAddresses {
+		if strings.Contains(e, "@") {
+			if a, err := mail.ParseAddress(e); err != nil || a.Address != e {
+				el = append(el, field.Invalid(fldPath.Child("emailAddresses").Index(i), e, "invalid email address"))
+			}
+		} else if !validConstraintDomain(e) {
+			el = append(el, field.Invalid(fldPath.Child("emailAddresses").Index(i), e, "invalid email domain"))
+		}
+	}
+	for i, d := range item.URIDomains {
+		if !validConstraintDomain(d) {
+			el = append(el, field.Invalid(fldPath.Child("uriDomains").Index(i), d, "invalid URI domain"))
+		}
+	}
+	return el
+}
+
+// validConstraintDomain returns true if d is a DNS domain, optionally with a
+// leading period to only match its subdomains.
+func validConstraintDomain(d string) bool {
+	d = strings.TrimPrefix(d, ".")
+	return len(utilvalidation.IsDNS1123Subdomain(strings.ToLower(d))) == 0
+}
+
 func validateUsages(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	for i, u := range a.Usages {
@@ -37,6 +37,9 @@ func ValidateCertificateForIssuer(crt *cmapi.Certificate, issuerObj cmapi.Generi
 		el = append(el, ValidateCertificateForVaultIssuer(&crt.Spec, issuerObj.GetSpec(), path)...)
 	case issuerObj.GetSpec().SelfSigned != nil:
 	case issuerObj.GetSpec().Venafi != nil:
+	case issuerObj.GetSpec().EST != nil:
+	case issuerObj.GetSpec().SCEP != nil:
+		el = append(el, ValidateCertificateForSCEPIssuer(&crt.Spec, issuerObj.GetSpec(), path)...)
 	default:
 		el = append(el, field.Invalid(path, "", fmt.Sprintf("no issuer specified for Issuer '%s/%s'", issuerObj.GetObjectMeta().Namespace, issuerObj.GetObjectMeta().Name)))
 	}
@@ -79,3 +82,13 @@ func ValidateCertificateForVaultIssuer(crt *cmapi.CertificateSpec, issuer *cmapi
 
 	return el
 }
+
+func ValidateCertificateForSCEPIssuer(crt *cmapi.CertificateSpec, issuer *cmapi.IssuerSpec, specPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if crt.PrivateKey != nil && len(crt.PrivateKey.Algorithm) != 0 && crt.PrivateKey.Algorithm != cmapi.RSAKeyAlgorithm {
+		el = append(el, field.Invalid(specPath.Child("privateKey", "algorithm"), crt.PrivateKey.Algorithm, "SCEP issuer only supports RSA private keys"))
+	}
+
+	return el
+}
@@ -47,6 +47,17 @@ func TestValidateCertificateForIssuer(t *testing.T) {
 			},
 		},
 	}
+	scepIssuer := &cmapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultTestIssuerName,
+			Namespace: defaultTestNamespace,
+		},
+		Spec: cmapi.IssuerSpec{
+			IssuerConfig: cmapi.IssuerConfig{
+				SCEP: &cmapi.SCEPIssuer{},
+			},
+		},
+	}
 	scenarios := map[string]struct {
 		crt    *cmapi.Certificate
 		issuer *cmapi.Issuer
@@ -130,6 +141,31 @@ func TestValidateCertificateForIssuer(t *testing.T) {
 			issuer: acmeIssuer,
 			errs:   []*field.Error{},
 		},
+		"certificate with ECDSA keyAlgorithm for SCEP": {
+			crt: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					PrivateKey: &cmapi.CertificatePrivateKey{
+						Algorithm: cmapi.ECDSAKeyAlgorithm,
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			issuer: scepIssuer,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("privateKey", "algorithm"), cmapi.ECDSAKeyAlgorithm, "SCEP issuer only supports RSA private keys"),
+			},
+		},
+		"certificate with RSA keyAlgorithm for SCEP": {
+			crt: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					PrivateKey: &cmapi.CertificatePrivateKey{
+						Algorithm: cmapi.RSAKeyAlgorithm,
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			issuer: scepIssuer,
+		},
 		"certificate with unspecified issuer type": {
 			crt: &cmapi.Certificate{
 				Spec: cmapi.CertificateSpec{
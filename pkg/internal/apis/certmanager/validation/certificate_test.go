@@ -565,6 +565,79 @@ func TestValidateCertificate(t *testing.T) {
 				field.Invalid(fldPath.Child("revisionHistoryLimit"), int32(0), "must not be less than 1"),
 			},
 		},
+		"valid certificate with revocation check interval": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "abc",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					RevocationCheck: &internalcmapi.CertificateRevocationCheck{
+						Enabled:  true,
+						Interval: &metav1.Duration{Duration: time.Minute * 5},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with revocation check interval < 5m": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "abc",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					RevocationCheck: &internalcmapi.CertificateRevocationCheck{
+						Enabled:  true,
+						Interval: &metav1.Duration{Duration: time.Minute},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("revocationCheck", "interval"), time.Minute, "must not be less than 5m0s"),
+			},
+		},
+		"valid certificate with revoke annotation": {
+			cfg: &internalcmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{internalcmapi.RevokeCertificateAnnotation: "keyCompromise"},
+				},
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "abc",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"valid certificate with empty revoke annotation": {
+			cfg: &internalcmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{internalcmapi.RevokeCertificateAnnotation: ""},
+				},
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "abc",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with unknown revocation reason in revoke annotation": {
+			cfg: &internalcmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{internalcmapi.RevokeCertificateAnnotation: "lostIt"},
+				},
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "abc",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(field.NewPath("metadata", "annotations").Key(internalcmapi.RevokeCertificateAnnotation), "lostIt", `unknown revocation reason "lostIt"`),
+			},
+		},
 		"v1alpha2 certificate created": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -730,6 +803,69 @@ func TestValidateCertificate(t *testing.T) {
 						"alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')"),
 			},
 		},
+		"valid name constraints on a CA certificate": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IsCA:       true,
+					NameConstraints: &internalcmapi.NameConstraints{
+						Critical: true,
+						Permitted: &internalcmapi.NameConstraintItem{
+							DNSDomains:     []string{"example.com", ".example.org"},
+							IPRanges:       []string{"10.0.0.0/8", "2001:db8::/32"},
+							EmailAddresses: []string{"admin@example.com", "example.com"},
+							URIDomains:     []string{".example.com"},
+						},
+						Excluded: &internalcmapi.NameConstraintItem{
+							DNSDomains: []string{"secret.example.com"},
+						},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid name constraints": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					NameConstraints: &internalcmapi.NameConstraints{
+						Permitted: &internalcmapi.NameConstraintItem{
+							DNSDomains:     []string{"*.example.com"},
+							IPRanges:       []string{"10.0.0.1"},
+							EmailAddresses: []string{"not an@address"},
+							URIDomains:     []string{"https://example.com"},
+						},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("nameConstraints"), "", "may only be set when isCA is true"),
+				field.Invalid(fldPath.Child("nameConstraints", "permitted", "dnsDomains").Index(0), "*.example.com", "invalid DNS domain"),
+				field.Invalid(fldPath.Child("nameConstraints", "permitted", "ipRanges").Index(0), "10.0.0.1", "invalid IP range, must be in CIDR notation"),
+				field.Invalid(fldPath.Child("nameConstraints", "permitted", "emailAddresses").Index(0), "not an@address", "invalid email address"),
+				field.Invalid(fldPath.Child("nameConstraints", "permitted", "uriDomains").Index(0), "https://example.com", "invalid URI domain"),
+			},
+		},
+		"name constraints without any names": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:      "testcn",
+					SecretName:      "abc",
+					IssuerRef:       validIssuerRef,
+					IsCA:            true,
+					NameConstraints: &internalcmapi.NameConstraints{Critical: true},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("nameConstraints"), "", "at least one permitted or excluded name must be set"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
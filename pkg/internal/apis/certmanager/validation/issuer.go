@@ -17,15 +17,22 @@ limitations under the License.
 package validation
 
 import (
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
+	"net/url"
 	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/jetstack/cert-manager/pkg/internal/api/validation"
 	cmacme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
 	"github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
@@ -38,6 +45,7 @@ import (
 func ValidateIssuer(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, validation.WarningList) {
 	iss := obj.(*certmanager.Issuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateIssuerNamespaceAccessUnset(&iss.Spec, field.NewPath("spec"))...)
 	warnings = append(warnings, validateAPIVersion(a.RequestKind)...)
 	return allErrs, warnings
 }
@@ -45,13 +53,44 @@ func ValidateIssuer(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.
 func ValidateUpdateIssuer(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, validation.WarningList) {
 	iss := obj.(*certmanager.Issuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateIssuerNamespaceAccessUnset(&iss.Spec, field.NewPath("spec"))...)
 	// Admission request should never be nil
 	warnings = append(warnings, validateAPIVersion(a.RequestKind)...)
 	return allErrs, warnings
 }
 
 func ValidateIssuerSpec(iss *certmanager.IssuerSpec, fldPath *field.Path) (field.ErrorList, validation.WarningList) {
-	return ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	el, warnings := ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	if iss.NamespaceAccess != nil {
+		el = append(el, ValidateIssuerNamespaceAccess(iss.NamespaceAccess, fldPath.Child("namespaceAccess"))...)
+	}
+	return el, warnings
+}
+
+// validateIssuerNamespaceAccessUnset returns an error if namespace access
+// restrictions are set on a namespaced Issuer, which can only be used from
+// its own namespace anyway.
+func validateIssuerNamespaceAccessUnset(iss *certmanager.IssuerSpec, fldPath *field.Path) field.ErrorList {
+	if iss.NamespaceAccess == nil {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(fldPath.Child("namespaceAccess"), "namespace access may only be restricted on ClusterIssuers")}
+}
+
+func ValidateIssuerNamespaceAccess(access *certmanager.IssuerNamespaceAccess, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(access.Namespaces) == 0 && access.NamespaceSelector == nil {
+		el = append(el, field.Required(fldPath, "at least one of namespaces or namespaceSelector must be specified"))
+	}
+	for i, namespace := range access.Namespaces {
+		for _, msg := range apivalidation.ValidateNamespaceName(namespace, false) {
+			el = append(el, field.Invalid(fldPath.Child("namespaces").Index(i), namespace, msg))
+		}
+	}
+	if access.NamespaceSelector != nil {
+		el = append(el, metav1validation.ValidateLabelSelector(access.NamespaceSelector, fldPath.Child("namespaceSelector"))...)
+	}
+	return el
 }
 
 func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (field.ErrorList, validation.WarningList) {
@@ -99,6 +138,30 @@ func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (f
 			el = append(el, ValidateVenafiIssuerConfig(iss.Venafi, fldPath.Child("venafi"))...)
 		}
 	}
+	if iss.EST != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("est"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidateESTIssuerConfig(iss.EST, fldPath.Child("est"))...)
+		}
+	}
+	if iss.SCEP != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("scep"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidateSCEPIssuerConfig(iss.SCEP, fldPath.Child("scep"))...)
+		}
+	}
+	if iss.CMP != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("cmp"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidateCMPIssuerConfig(iss.CMP, fldPath.Child("cmp"))...)
+		}
+	}
 	if numConfigs == 0 {
 		el = append(el, field.Required(fldPath, "at least one issuer must be configured"))
 	}
@@ -112,6 +175,9 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 	if len(iss.PrivateKey.Name) == 0 {
 		el = append(el, field.Required(fldPath.Child("privateKeySecretRef", "name"), "private key secret name is a required field"))
 	}
+	if iss.NextPrivateKey != nil && len(iss.NextPrivateKey.Name) == 0 {
+		el = append(el, field.Required(fldPath.Child("nextPrivateKeySecretRef", "name"), "next private key secret name is a required field"))
+	}
 	if len(iss.Server) == 0 {
 		el = append(el, field.Required(fldPath.Child("server"), "acme server URL is a required field"))
 	}
@@ -129,6 +195,10 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 		}
 	}
 
+	if iss.ChainSelection != nil {
+		el = append(el, ValidateACMEChainSelection(iss.ChainSelection, fldPath.Child("chainSelection"))...)
+	}
+
 	for i, sol := range iss.Solvers {
 		el = append(el, ValidateACMEIssuerChallengeSolverConfig(&sol, fldPath.Child("solvers").Index(i))...)
 	}
@@ -136,6 +206,52 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 	return el, warnings
 }
 
+func ValidateACMEChainSelection(sel *cmacme.ACMEChainSelection, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	for i, cs := range sel.Preferred {
+		el = append(el, validateACMEChainCertificateSelector(&cs, fldPath.Child("preferred").Index(i))...)
+	}
+	for i, cs := range sel.Exclude {
+		el = append(el, validateACMEChainCertificateSelector(&cs, fldPath.Child("exclude").Index(i))...)
+	}
+
+	return el
+}
+
+func validateACMEChainCertificateSelector(cs *cmacme.ACMEChainCertificateSelector, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	numDefined := 0
+	if len(cs.CommonName) > 0 {
+		numDefined++
+	}
+	if len(cs.SHA256) > 0 {
+		numDefined++
+		el = append(el, validateSHA256Fingerprint(cs.SHA256, fldPath.Child("sha256"))...)
+	}
+	if len(cs.SPKISHA256) > 0 {
+		numDefined++
+		el = append(el, validateSHA256Fingerprint(cs.SPKISHA256, fldPath.Child("spkiSHA256"))...)
+	}
+	switch {
+	case numDefined == 0:
+		el = append(el, field.Required(fldPath, "one of 'commonName', 'sha256' or 'spkiSHA256' must be specified"))
+	case numDefined > 1:
+		el = append(el, field.Forbidden(fldPath, "only one of 'commonName', 'sha256' or 'spkiSHA256' may be specified"))
+	}
+
+	return el
+}
+
+func validateSHA256Fingerprint(fingerprint string, fldPath *field.Path) field.ErrorList {
+	b, err := hex.DecodeString(fingerprint)
+	if err != nil || len(b) != sha256.Size {
+		return field.ErrorList{field.Invalid(fldPath, fingerprint, "must be a hex encoded SHA-256 hash")}
+	}
+	return nil
+}
+
 func ValidateACMEIssuerChallengeSolverConfig(sol *cmacme.ACMEChallengeSolver, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
@@ -152,6 +268,14 @@ func ValidateACMEIssuerChallengeSolverConfig(sol *cmacme.ACMEChallengeSolver, fl
 			el = append(el, ValidateACMEChallengeSolverDNS01(sol.DNS01, fldPath.Child("dns01"))...)
 		}
 	}
+	if sol.TLSALPN01 != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath, "may not specify more than one solver type in a single solver"))
+		} else {
+			numProviders++
+			el = append(el, ValidateACMEIssuerChallengeSolverTLSALPN01Config(sol.TLSALPN01, fldPath.Child("tlsalpn01"))...)
+		}
+	}
 	if numProviders == 0 {
 		el = append(el, field.Required(fldPath, "no solver type configured"))
 	}
@@ -210,16 +334,121 @@ func ValidateACMEIssuerChallengeSolverHTTP01GatewayConfig(gateway *cmacme.ACMECh
 	return el
 }
 
+func ValidateACMEIssuerChallengeSolverTLSALPN01Config(tlsalpn01 *cmacme.ACMEChallengeSolverTLSALPN01, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if svc := tlsalpn01.Service; svc != nil {
+		switch svc.Type {
+		case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+		default:
+			el = append(el, field.Invalid(fldPath.Child("service", "type"), svc.Type, `must be empty, "ClusterIP", "NodePort" or "LoadBalancer"`))
+		}
+		if svc.Port < 0 || svc.Port > 65535 {
+			el = append(el, field.Invalid(fldPath.Child("service", "port"), svc.Port, "must be between 0 and 65535"))
+		}
+	}
+	if tlsalpn01.GatewayTLSRoute != nil && tlsalpn01.SNIPassthrough != nil {
+		el = append(el, field.Forbidden(fldPath, "only one of 'gatewayTLSRoute' or 'sniPassthrough' may be specified"))
+	}
+	if tlsalpn01.GatewayTLSRoute != nil && len(tlsalpn01.GatewayTLSRoute.Labels) == 0 {
+		el = append(el, field.Required(fldPath.Child("gatewayTLSRoute", "labels"), `labels must be set`))
+	}
+
+	return el
+}
+
 func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if len(iss.SecretName) == 0 {
 		el = append(el, field.Required(fldPath.Child("secretName"), ""))
 	}
+	if iss.PrivateKeyPasswordSecretRef != nil {
+		el = append(el, ValidateSecretKeySelector(iss.PrivateKeyPasswordSecretRef, fldPath.Child("privateKeyPasswordSecretRef"))...)
+	}
+	if iss.ChainSecretRef != nil {
+		el = append(el, ValidateSecretKeySelector(iss.ChainSecretRef, fldPath.Child("chainSecretRef"))...)
+	}
+	if iss.RootSecretRef != nil {
+		el = append(el, ValidateSecretKeySelector(iss.RootSecretRef, fldPath.Child("rootSecretRef"))...)
+	}
 	for i, ocspURL := range iss.OCSPServers {
 		if ocspURL == "" {
 			el = append(el, field.Invalid(fldPath.Child("ocspServer").Index(i), ocspURL, "must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org"))
 		}
 	}
+	el = append(el, validateCASigningPolicy(iss, fldPath)...)
+	if iss.Revocation != nil {
+		el = append(el, validateCARevocation(iss, fldPath.Child("revocation"))...)
+	}
+	return el
+}
+
+func validateCASigningPolicy(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if iss.SerialNumberLength != 0 &&
+		(iss.SerialNumberLength < cmapi.MinimumCASerialNumberLength || iss.SerialNumberLength > cmapi.MaximumCASerialNumberLength) {
+		el = append(el, field.Invalid(fldPath.Child("serialNumberLength"), iss.SerialNumberLength,
+			fmt.Sprintf("must be between %d and %d", cmapi.MinimumCASerialNumberLength, cmapi.MaximumCASerialNumberLength)))
+	}
+	if iss.DefaultDuration != nil && iss.DefaultDuration.Duration < cmapi.MinimumCertificateDuration {
+		el = append(el, field.Invalid(fldPath.Child("defaultDuration"), iss.DefaultDuration.Duration,
+			fmt.Sprintf("must be greater than %s", cmapi.MinimumCertificateDuration)))
+	}
+	if iss.MaxDuration != nil && iss.MaxDuration.Duration < cmapi.MinimumCertificateDuration {
+		el = append(el, field.Invalid(fldPath.Child("maxDuration"), iss.MaxDuration.Duration,
+			fmt.Sprintf("must be greater than %s", cmapi.MinimumCertificateDuration)))
+	}
+	if iss.DefaultDuration != nil && iss.MaxDuration != nil && iss.DefaultDuration.Duration > iss.MaxDuration.Duration {
+		el = append(el, field.Invalid(fldPath.Child("defaultDuration"), iss.DefaultDuration.Duration,
+			fmt.Sprintf("must not be greater than maxDuration %s", iss.MaxDuration.Duration)))
+	}
+	if iss.Backdate != nil && iss.Backdate.Duration < 0 {
+		el = append(el, field.Invalid(fldPath.Child("backdate"), iss.Backdate.Duration, "must not be negative"))
+	}
+	return el
+}
+
+func validateCARevocation(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	rev := iss.Revocation
+	if len(rev.SecretName) == 0 {
+		el = append(el, field.Required(fldPath.Child("secretName"), ""))
+	} else if rev.SecretName == iss.SecretName {
+		el = append(el, field.Invalid(fldPath.Child("secretName"), rev.SecretName, "must not be the same as the CA secret"))
+	}
+	if rev.OCSP != nil {
+		el = append(el, validateCAOCSP(iss, fldPath.Child("ocsp"))...)
+	}
+	if rev.CRL == nil {
+		return el
+	}
+
+	crlPath := fldPath.Child("crl")
+	switch {
+	case len(rev.CRL.SecretName) == 0 && len(rev.CRL.ConfigMapName) == 0:
+		el = append(el, field.Required(crlPath, "one of secretName or configMapName must be set"))
+	case len(rev.CRL.SecretName) > 0 && len(rev.CRL.ConfigMapName) > 0:
+		el = append(el, field.Forbidden(crlPath, "only one of secretName or configMapName may be set"))
+	case len(rev.CRL.SecretName) > 0 && (rev.CRL.SecretName == iss.SecretName || rev.CRL.SecretName == rev.SecretName):
+		el = append(el, field.Invalid(crlPath.Child("secretName"), rev.CRL.SecretName, "must not be the same as the CA or revocation secret"))
+	}
+	if rev.CRL.Duration != nil && rev.CRL.Duration.Duration < time.Hour {
+		el = append(el, field.Invalid(crlPath.Child("duration"), rev.CRL.Duration.Duration.String(), "must be at least 1h"))
+	}
+	return el
+}
+
+func validateCAOCSP(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	ocsp := iss.Revocation.OCSP
+	if len(ocsp.ResponderSecretName) == 0 {
+		el = append(el, field.Required(fldPath.Child("responderSecretName"), ""))
+	} else if ocsp.ResponderSecretName == iss.SecretName || ocsp.ResponderSecretName == iss.Revocation.SecretName {
+		el = append(el, field.Invalid(fldPath.Child("responderSecretName"), ocsp.ResponderSecretName, "must not be the same as the CA or revocation secret"))
+	}
+	if ocsp.ResponseDuration != nil && ocsp.ResponseDuration.Duration < time.Minute {
+		el = append(el, field.Invalid(fldPath.Child("responseDuration"), ocsp.ResponseDuration.Duration.String(), "must be at least 1m"))
+	}
 	return el
 }
 
@@ -246,10 +475,32 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 		}
 	}
 
+	// selecting a role or signing verbatim requires the path to point at the
+	// sign endpoint of a PKI role, so that it can be rewritten
+	if (len(iss.AllowedRoles) > 0 || iss.SignVerbatim) && len(iss.Path) > 0 && !isVaultSignPath(iss.Path) {
+		el = append(el, field.Invalid(fldPath.Child("path"), iss.Path, "must be of the form <mount>/sign/<role> when allowedRoles or signVerbatim is set"))
+	}
+	for i, role := range iss.AllowedRoles {
+		if len(role) == 0 || strings.Contains(role, "/") {
+			el = append(el, field.Invalid(fldPath.Child("allowedRoles").Index(i), role, "must be a non-empty role name not containing '/'"))
+		}
+	}
+
 	return el
 	// TODO: add validation for Vault authentication types
 }
 
+// isVaultSignPath returns true if path is of the form "<mount>/sign/<role>".
+func isVaultSignPath(path string) bool {
+	path = strings.Trim(path, "/")
+	i := strings.LastIndex(path, "/sign/")
+	if i <= 0 {
+		return false
+	}
+	role := path[i+len("/sign/"):]
+	return len(role) > 0 && !strings.Contains(role, "/")
+}
+
 func ValidateVenafiTPP(tpp *certmanager.VenafiTPP, fldPath *field.Path) (el field.ErrorList) {
 	if tpp.URL == "" {
 		el = append(el, field.Required(fldPath.Child("url"), ""))
@@ -285,6 +536,119 @@ func ValidateVenafiIssuerConfig(iss *certmanager.VenafiIssuer, fldPath *field.Pa
 	return el
 }
 
+func ValidateESTIssuerConfig(iss *certmanager.ESTIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(iss.Server) == 0 {
+		el = append(el, field.Required(fldPath.Child("server"), ""))
+	} else if u, err := url.Parse(iss.Server); err != nil || u.Scheme != "https" || u.Host == "" {
+		el = append(el, field.Invalid(fldPath.Child("server"), iss.Server, "must be an https URL"))
+	}
+	if strings.Contains(iss.Label, "/") {
+		el = append(el, field.Invalid(fldPath.Child("label"), iss.Label, "must not contain '/'"))
+	}
+
+	// check if caBundle is valid
+	certs := iss.CABundle
+	if len(certs) > 0 {
+		caCertPool := x509.NewCertPool()
+		ok := caCertPool.AppendCertsFromPEM(certs)
+		if !ok {
+			el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"))
+		}
+	}
+
+	if iss.Auth != nil {
+		authPath := fldPath.Child("auth")
+		if iss.Auth.BasicAuth == nil && iss.Auth.ClientCertificate == nil {
+			el = append(el, field.Required(authPath, "please supply at least one of: basicAuth, clientCertificate"))
+		}
+		if basicAuth := iss.Auth.BasicAuth; basicAuth != nil {
+			if len(basicAuth.Username) == 0 {
+				el = append(el, field.Required(authPath.Child("basicAuth", "username"), ""))
+			}
+			el = append(el, ValidateSecretKeySelector(&basicAuth.PasswordSecretRef, authPath.Child("basicAuth", "passwordSecretRef"))...)
+		}
+		if clientCertificate := iss.Auth.ClientCertificate; clientCertificate != nil {
+			if len(clientCertificate.SecretRef.Name) == 0 {
+				el = append(el, field.Required(authPath.Child("clientCertificate", "secretRef", "name"), ""))
+			}
+		}
+	}
+
+	return el
+}
+
+func ValidateSCEPIssuerConfig(iss *certmanager.SCEPIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(iss.URL) == 0 {
+		el = append(el, field.Required(fldPath.Child("url"), ""))
+	} else if u, err := url.Parse(iss.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		el = append(el, field.Invalid(fldPath.Child("url"), iss.URL, "must be an http or https URL"))
+	}
+
+	// check if caBundle is valid
+	certs := iss.CABundle
+	if len(certs) > 0 {
+		caCertPool := x509.NewCertPool()
+		ok := caCertPool.AppendCertsFromPEM(certs)
+		if !ok {
+			el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"))
+		}
+	}
+
+	if iss.ChallengePasswordSecretRef != nil {
+		el = append(el, ValidateSecretKeySelector(iss.ChallengePasswordSecretRef, fldPath.Child("challengePasswordSecretRef"))...)
+	}
+
+	return el
+}
+
+func ValidateCMPIssuerConfig(iss *certmanager.CMPIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(iss.URL) == 0 {
+		el = append(el, field.Required(fldPath.Child("url"), ""))
+	} else if u, err := url.Parse(iss.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		el = append(el, field.Invalid(fldPath.Child("url"), iss.URL, "must be an http or https URL"))
+	}
+
+	// check if caBundle is valid
+	certs := iss.CABundle
+	if len(certs) > 0 {
+		caCertPool := x509.NewCertPool()
+		ok := caCertPool.AppendCertsFromPEM(certs)
+		if !ok {
+			el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"))
+		}
+	}
+
+	switch iss.RequestType {
+	case "", certmanager.CMPInitializationRequest, certmanager.CMPCertificationRequest:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("requestType"), iss.RequestType,
+			[]string{string(certmanager.CMPInitializationRequest), string(certmanager.CMPCertificationRequest)}))
+	}
+
+	switch {
+	case iss.SharedSecret == nil && iss.Signature == nil:
+		el = append(el, field.Required(fldPath, "please supply one of: sharedSecret, signature"))
+	case iss.SharedSecret != nil && iss.Signature != nil:
+		el = append(el, field.Forbidden(fldPath, "please supply one of: sharedSecret, signature"))
+	}
+	if sharedSecret := iss.SharedSecret; sharedSecret != nil {
+		if len(sharedSecret.Reference) == 0 {
+			el = append(el, field.Required(fldPath.Child("sharedSecret", "reference"), ""))
+		}
+		el = append(el, ValidateSecretKeySelector(&sharedSecret.SecretRef, fldPath.Child("sharedSecret", "secretRef"))...)
+	}
+	if signature := iss.Signature; signature != nil {
+		if len(signature.SecretRef.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("signature", "secretRef", "name"), ""))
+		}
+	}
+
+	return el
+}
+
 // This list must be kept in sync with pkg/issuer/acme/dns/rfc2136/rfc2136.go
 var supportedTSIGAlgorithms = []string{
 	"HMACMD5",
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -94,6 +95,35 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
 			},
 		},
+		"vault issuer with allowed roles and sign verbatim": {
+			spec: &cmapi.VaultIssuer{
+				Server:       "something",
+				Path:         "pki/sign/default",
+				AllowedRoles: []string{"web", "mail"},
+				SignVerbatim: true,
+			},
+		},
+		"vault issuer with allowed roles and a path that is not a sign endpoint": {
+			spec: &cmapi.VaultIssuer{
+				Server:       "something",
+				Path:         "pki/issue/default",
+				AllowedRoles: []string{"web"},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("path"), "pki/issue/default", "must be of the form <mount>/sign/<role> when allowedRoles or signVerbatim is set"),
+			},
+		},
+		"vault issuer with invalid allowed roles": {
+			spec: &cmapi.VaultIssuer{
+				Server:       "something",
+				Path:         "pki/sign/default",
+				AllowedRoles: []string{"", "a/b"},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("allowedRoles").Index(0), "", "must be a non-empty role name not containing '/'"),
+				field.Invalid(fldPath.Child("allowedRoles").Index(1), "a/b", "must be a non-empty role name not containing '/'"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -129,6 +159,63 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				field.Required(fldPath.Child("server"), "acme server URL is a required field"),
 			},
 		},
+		"acme issuer with next private key missing a name": {
+			spec: &cmacme.ACMEIssuer{
+				Email:          "valid-email",
+				Server:         "valid-server",
+				PrivateKey:     validSecretKeyRef,
+				NextPrivateKey: &cmmeta.SecretKeySelector{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("nextPrivateKeySecretRef", "name"), "next private key secret name is a required field"),
+			},
+		},
+		"acme issuer with valid next private key": {
+			spec: &cmacme.ACMEIssuer{
+				Email:          "valid-email",
+				Server:         "valid-server",
+				PrivateKey:     validSecretKeyRef,
+				NextPrivateKey: &validSecretKeyRef,
+			},
+		},
+		"acme issuer with valid chain selection": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				ChainSelection: &cmacme.ACMEChainSelection{
+					Preferred: []cmacme.ACMEChainCertificateSelector{
+						{SPKISHA256: "0b9fa5a59eed715c26c1020c711b4f6ec42d58b0015e14337a39dad301c5afc3"},
+						{CommonName: "ISRG Root X1"},
+					},
+					Exclude: []cmacme.ACMEChainCertificateSelector{
+						{SHA256: "0687260331A72403D909F105E69BCF0D32E1BD2493FFC6D9206D11BCD6770739"},
+					},
+					PreferShortest: true,
+				},
+			},
+		},
+		"acme issuer with invalid chain selection": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				ChainSelection: &cmacme.ACMEChainSelection{
+					Preferred: []cmacme.ACMEChainCertificateSelector{
+						{},
+						{CommonName: "ISRG Root X1", SHA256: "0687260331a72403d909f105e69bcf0d32e1bd2493ffc6d9206d11bcd6770739"},
+					},
+					Exclude: []cmacme.ACMEChainCertificateSelector{
+						{SPKISHA256: "not-a-hash"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("chainSelection", "preferred").Index(0), "one of 'commonName', 'sha256' or 'spkiSHA256' must be specified"),
+				field.Forbidden(fldPath.Child("chainSelection", "preferred").Index(1), "only one of 'commonName', 'sha256' or 'spkiSHA256' may be specified"),
+				field.Invalid(fldPath.Child("chainSelection", "exclude").Index(0).Child("spkiSHA256"), "not-a-hash", "must be a hex encoded SHA-256 hash"),
+			},
+		},
 		"acme solver without any config": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -431,6 +518,253 @@ func TestValidateIssuerSpec(t *testing.T) {
 			},
 			errs: []*field.Error{field.Required(fldPath.Child("ca", "secretName"), "")},
 		},
+		"valid ca issuer with encrypted key, chain and root": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PrivateKeyPasswordSecretRef: &cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca-password"},
+							Key:                  "password",
+						},
+						ChainSecretRef: &cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca-chain"},
+							Key:                  "chain.pem",
+						},
+						RootSecretRef: &cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "root"},
+							Key:                  "ca.crt",
+						},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer with incomplete secret references": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:                  "valid",
+						PrivateKeyPasswordSecretRef: &cmmeta.SecretKeySelector{Key: "password"},
+						RootSecretRef: &cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "root"},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("ca", "privateKeyPasswordSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("ca", "rootSecretRef", "key"), "secret key is required"),
+			},
+		},
+		"valid ca issuer with signing policy": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:         "valid",
+						SignatureAlgorithm: cmapi.SHA384WithRSAPSS,
+						SerialNumberLength: 20,
+						DefaultDuration:    &metav1.Duration{Duration: 24 * time.Hour},
+						MaxDuration:        &metav1.Duration{Duration: 30 * 24 * time.Hour},
+						Backdate:           &metav1.Duration{Duration: time.Minute},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer with invalid signing policy": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:         "valid",
+						SerialNumberLength: 21,
+						DefaultDuration:    &metav1.Duration{Duration: 48 * time.Hour},
+						MaxDuration:        &metav1.Duration{Duration: 24 * time.Hour},
+						Backdate:           &metav1.Duration{Duration: -time.Minute},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "serialNumberLength"), 21, "must be between 8 and 20"),
+				field.Invalid(fldPath.Child("ca", "defaultDuration"), 48*time.Hour, "must not be greater than maxDuration 24h0m0s"),
+				field.Invalid(fldPath.Child("ca", "backdate"), -time.Minute, "must not be negative"),
+			},
+		},
+		"ca issuer with signing policy durations below the minimum": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:      "valid",
+						DefaultDuration: &metav1.Duration{Duration: time.Minute},
+						MaxDuration:     &metav1.Duration{Duration: time.Minute},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "defaultDuration"), time.Minute, "must be greater than 1h0m0s"),
+				field.Invalid(fldPath.Child("ca", "maxDuration"), time.Minute, "must be greater than 1h0m0s"),
+			},
+		},
+		"valid ca issuer with revocation and crl": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						Revocation: &cmapi.CARevocation{
+							SecretName: "revocations",
+							CRL: &cmapi.CACRL{
+								ConfigMapName: "crl",
+								Duration:      &metav1.Duration{Duration: 12 * time.Hour},
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer revocation without secret name specified": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						Revocation: &cmapi.CARevocation{},
+					},
+				},
+			},
+			errs: []*field.Error{field.Required(fldPath.Child("ca", "revocation", "secretName"), "")},
+		},
+		"ca issuer revocation secret name same as ca secret": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						Revocation: &cmapi.CARevocation{SecretName: "valid"},
+					},
+				},
+			},
+			errs: []*field.Error{field.Invalid(fldPath.Child("ca", "revocation", "secretName"), "valid", "must not be the same as the CA secret")},
+		},
+		"ca issuer crl without publish target": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						Revocation: &cmapi.CARevocation{
+							SecretName: "revocations",
+							CRL:        &cmapi.CACRL{},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{field.Required(fldPath.Child("ca", "revocation", "crl"), "one of secretName or configMapName must be set")},
+		},
+		"ca issuer crl with both secret and configmap": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						Revocation: &cmapi.CARevocation{
+							SecretName: "revocations",
+							CRL:        &cmapi.CACRL{SecretName: "crl", ConfigMapName: "crl"},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{field.Forbidden(fldPath.Child("ca", "revocation", "crl"), "only one of secretName or configMapName may be set")},
+		},
+		"ca issuer crl secret same as revocation secret": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						Revocation: &cmapi.CARevocation{
+							SecretName: "revocations",
+							CRL:        &cmapi.CACRL{SecretName: "revocations"},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{field.Invalid(fldPath.Child("ca", "revocation", "crl", "secretName"), "revocations", "must not be the same as the CA or revocation secret")},
+		},
+		"valid ca issuer with ocsp responder": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						Revocation: &cmapi.CARevocation{
+							SecretName: "revocations",
+							OCSP: &cmapi.CAOCSP{
+								ResponderSecretName: "ocsp-responder",
+								ResponseDuration:    &metav1.Duration{Duration: time.Hour},
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer ocsp without responder secret name": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						Revocation: &cmapi.CARevocation{
+							SecretName: "revocations",
+							OCSP:       &cmapi.CAOCSP{},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{field.Required(fldPath.Child("ca", "revocation", "ocsp", "responderSecretName"), "")},
+		},
+		"ca issuer ocsp responder secret same as ca secret": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						Revocation: &cmapi.CARevocation{
+							SecretName: "revocations",
+							OCSP:       &cmapi.CAOCSP{ResponderSecretName: "valid"},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{field.Invalid(fldPath.Child("ca", "revocation", "ocsp", "responderSecretName"), "valid", "must not be the same as the CA or revocation secret")},
+		},
+		"ca issuer ocsp response duration too short": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						Revocation: &cmapi.CARevocation{
+							SecretName: "revocations",
+							OCSP: &cmapi.CAOCSP{
+								ResponderSecretName: "ocsp-responder",
+								ResponseDuration:    &metav1.Duration{Duration: time.Second},
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{field.Invalid(fldPath.Child("ca", "revocation", "ocsp", "responseDuration"), "1s", "must be at least 1m")},
+		},
+		"ca issuer crl duration too short": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						Revocation: &cmapi.CARevocation{
+							SecretName: "revocations",
+							CRL: &cmapi.CACRL{
+								SecretName: "crl",
+								Duration:   &metav1.Duration{Duration: time.Minute},
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{field.Invalid(fldPath.Child("ca", "revocation", "crl", "duration"), "1m0s", "must be at least 1h")},
+		},
 		"valid self signed issuer": {
 			spec: &cmapi.IssuerSpec{
 				IssuerConfig: cmapi.IssuerConfig{
@@ -500,6 +834,44 @@ func TestValidateIssuerSpec(t *testing.T) {
 				field.Invalid(fldPath.Child("ca", "ocspServer").Index(0), "", `must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org`),
 			},
 		},
+		"valid namespace access": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{SecretName: "valid"},
+				},
+				NamespaceAccess: &cmapi.IssuerNamespaceAccess{
+					Namespaces: []string{"team-a"},
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"pki": "production"},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"namespace access without namespaces or selector": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{SecretName: "valid"},
+				},
+				NamespaceAccess: &cmapi.IssuerNamespaceAccess{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("namespaceAccess"), "at least one of namespaces or namespaceSelector must be specified"),
+			},
+		},
+		"namespace access with invalid namespace name": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{SecretName: "valid"},
+				},
+				NamespaceAccess: &cmapi.IssuerNamespaceAccess{
+					Namespaces: []string{"Team_A"},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("namespaceAccess", "namespaces").Index(0), "Team_A", `a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -598,6 +970,95 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 	}
 }
 
+func TestValidateACMEIssuerTLSALPN01Config(t *testing.T) {
+	fldPath := field.NewPath("")
+	scenarios := map[string]struct {
+		cfg  *cmacme.ACMEChallengeSolverTLSALPN01
+		errs []*field.Error
+	}{
+		"no fields specified": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{},
+		},
+		"valid service config": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				Service: &cmacme.ACMEChallengeSolverTLSALPN01Service{
+					Type:        corev1.ServiceTypeLoadBalancer,
+					Port:        8443,
+					Annotations: map[string]string{"a": "b"},
+				},
+			},
+		},
+		"invalid service type": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				Service: &cmacme.ACMEChallengeSolverTLSALPN01Service{
+					Type: corev1.ServiceTypeExternalName,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("service", "type"), corev1.ServiceTypeExternalName, `must be empty, "ClusterIP", "NodePort" or "LoadBalancer"`),
+			},
+		},
+		"invalid service port": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				Service: &cmacme.ACMEChallengeSolverTLSALPN01Service{
+					Port: 70000,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("service", "port"), int32(70000), "must be between 0 and 65535"),
+			},
+		},
+		"valid gateway config": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				GatewayTLSRoute: &cmacme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute{
+					Labels: map[string]string{"a": "b"},
+				},
+			},
+		},
+		"gateway config without labels": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				GatewayTLSRoute: &cmacme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("gatewayTLSRoute", "labels"), `labels must be set`),
+			},
+		},
+		"valid sni passthrough config": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				SNIPassthrough: &cmacme.ACMEChallengeSolverTLSALPN01SNIPassthrough{
+					Class: strPtr("nginx"),
+				},
+			},
+		},
+		"both gateway and sni passthrough specified": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				GatewayTLSRoute: &cmacme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute{
+					Labels: map[string]string{"a": "b"},
+				},
+				SNIPassthrough: &cmacme.ACMEChallengeSolverTLSALPN01SNIPassthrough{},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "only one of 'gatewayTLSRoute' or 'sniPassthrough' may be specified"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateACMEIssuerChallengeSolverTLSALPN01Config(s.cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 	fldPath := field.NewPath("test")
 	scenarios := map[string]struct {
@@ -1198,6 +1659,213 @@ func TestValidateVenafiTPP(t *testing.T) {
 	}
 }
 
+func TestValidateESTIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("")
+	scenarios := map[string]struct {
+		spec *cmapi.ESTIssuer
+		errs []*field.Error
+	}{
+		"valid est issuer": {
+			spec: &cmapi.ESTIssuer{
+				Server: "https://est.example.com",
+				Label:  "tls-servers",
+				Auth: &cmapi.ESTAuth{
+					BasicAuth: &cmapi.ESTBasicAuth{
+						Username:          "cert-manager",
+						PasswordSecretRef: validSecretKeyRef,
+					},
+					ClientCertificate: &cmapi.ESTClientCertificateAuth{
+						SecretRef: cmmeta.LocalObjectReference{Name: "est-client"},
+					},
+				},
+			},
+		},
+		"est issuer with missing server": {
+			spec: &cmapi.ESTIssuer{},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("server"), ""),
+			},
+		},
+		"est issuer with invalid fields": {
+			spec: &cmapi.ESTIssuer{
+				Server:   "http://est.example.com",
+				Label:    "a/b",
+				CABundle: []byte("invalid"),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("server"), "http://est.example.com", "must be an https URL"),
+				field.Invalid(fldPath.Child("label"), "a/b", "must not contain '/'"),
+				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
+			},
+		},
+		"est issuer with empty auth": {
+			spec: &cmapi.ESTIssuer{
+				Server: "https://est.example.com",
+				Auth:   &cmapi.ESTAuth{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("auth"), "please supply at least one of: basicAuth, clientCertificate"),
+			},
+		},
+		"est issuer with incomplete auth": {
+			spec: &cmapi.ESTIssuer{
+				Server: "https://est.example.com",
+				Auth: &cmapi.ESTAuth{
+					BasicAuth:         &cmapi.ESTBasicAuth{},
+					ClientCertificate: &cmapi.ESTClientCertificateAuth{},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("auth", "basicAuth", "username"), ""),
+				field.Required(fldPath.Child("auth", "basicAuth", "passwordSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("auth", "basicAuth", "passwordSecretRef", "key"), "secret key is required"),
+				field.Required(fldPath.Child("auth", "clientCertificate", "secretRef", "name"), ""),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateESTIssuerConfig(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSCEPIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("")
+	scenarios := map[string]struct {
+		spec *cmapi.SCEPIssuer
+		errs []*field.Error
+	}{
+		"valid scep issuer": {
+			spec: &cmapi.SCEPIssuer{
+				URL:                        "http://ndes.example.com/certsrv/mscep/mscep.dll",
+				ChallengePasswordSecretRef: &validSecretKeyRef,
+			},
+		},
+		"scep issuer with missing url": {
+			spec: &cmapi.SCEPIssuer{},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("url"), ""),
+			},
+		},
+		"scep issuer with invalid fields": {
+			spec: &cmapi.SCEPIssuer{
+				URL:                        "ldap://ndes.example.com",
+				CABundle:                   []byte("invalid"),
+				ChallengePasswordSecretRef: &cmmeta.SecretKeySelector{},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("url"), "ldap://ndes.example.com", "must be an http or https URL"),
+				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
+				field.Required(fldPath.Child("challengePasswordSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("challengePasswordSecretRef", "key"), "secret key is required"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateSCEPIssuerConfig(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCMPIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("")
+	scenarios := map[string]struct {
+		spec *cmapi.CMPIssuer
+		errs []*field.Error
+	}{
+		"valid cmp issuer with shared secret protection": {
+			spec: &cmapi.CMPIssuer{
+				URL:         "https://ca.example.com/pkix/",
+				RequestType: cmapi.CMPInitializationRequest,
+				SharedSecret: &cmapi.CMPSharedSecretProtection{
+					Reference: "1234",
+					SecretRef: validSecretKeyRef,
+				},
+			},
+		},
+		"valid cmp issuer with signature protection": {
+			spec: &cmapi.CMPIssuer{
+				URL: "https://ca.example.com/pkix/",
+				Signature: &cmapi.CMPSignatureProtection{
+					SecretRef: cmmeta.LocalObjectReference{Name: "cmp-signer"},
+				},
+			},
+		},
+		"cmp issuer with missing fields": {
+			spec: &cmapi.CMPIssuer{},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("url"), ""),
+				field.Required(fldPath, "please supply one of: sharedSecret, signature"),
+			},
+		},
+		"cmp issuer with both protections": {
+			spec: &cmapi.CMPIssuer{
+				URL: "https://ca.example.com/pkix/",
+				SharedSecret: &cmapi.CMPSharedSecretProtection{
+					Reference: "1234",
+					SecretRef: validSecretKeyRef,
+				},
+				Signature: &cmapi.CMPSignatureProtection{
+					SecretRef: cmmeta.LocalObjectReference{Name: "cmp-signer"},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "please supply one of: sharedSecret, signature"),
+			},
+		},
+		"cmp issuer with invalid fields": {
+			spec: &cmapi.CMPIssuer{
+				URL:          "ldap://ca.example.com",
+				CABundle:     []byte("invalid"),
+				RequestType:  "p10cr",
+				SharedSecret: &cmapi.CMPSharedSecretProtection{},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("url"), "ldap://ca.example.com", "must be an http or https URL"),
+				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
+				field.NotSupported(fldPath.Child("requestType"), cmapi.CMPRequestType("p10cr"), []string{"ir", "cr"}),
+				field.Required(fldPath.Child("sharedSecret", "reference"), ""),
+				field.Required(fldPath.Child("sharedSecret", "secretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("sharedSecret", "secretRef", "key"), "secret key is required"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateCMPIssuerConfig(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateIssuer(t *testing.T) {
 	baseIssuerConfig := cmapi.IssuerSpec{
 		IssuerConfig: cmapi.IssuerConfig{
@@ -1263,6 +1931,22 @@ func TestValidateIssuer(t *testing.T) {
 					"Issuer"),
 			},
 		},
+		"Issuer with namespace access": {
+			cfg: &cmapi.Issuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig:    baseIssuerConfig.IssuerConfig,
+					NamespaceAccess: &cmapi.IssuerNamespaceAccess{Namespaces: []string{"team-a"}},
+				},
+			},
+			a: &admissionv1.AdmissionRequest{
+				RequestKind: &metav1.GroupVersionKind{Group: "cert-manager.io",
+					Version: "v1",
+					Kind:    "Issuer"},
+			},
+			expectedE: []*field.Error{
+				field.Forbidden(field.NewPath("spec", "namespaceAccess"), "namespace access may only be restricted on ClusterIssuers"),
+			},
+		},
 	}
 
 	for n, s := range scenarios {
@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	cmclientv1 "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/certmanager/v1"
+	internalcmapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+)
+
+// namespaceAccess is responsible for rejecting Certificates that reference a
+// ClusterIssuer which may not be used from the namespace of the Certificate.
+type namespaceAccess struct {
+	namespaceclient     corev1client.NamespaceInterface
+	clusterissuerclient cmclientv1.ClusterIssuerInterface
+}
+
+func newNamespaceAccess() *namespaceAccess {
+	return &namespaceAccess{}
+}
+
+func (n *namespaceAccess) Init(client kubernetes.Interface, cmClient cmclient.Interface) {
+	n.namespaceclient = client.CoreV1().Namespaces()
+	n.clusterissuerclient = cmClient.CertmanagerV1().ClusterIssuers()
+}
+
+// Validate will reject Certificates which are created with, or updated to, a
+// reference to a ClusterIssuer whose namespace access restrictions do not
+// allow it to be used from the namespace of the Certificate. References to
+// ClusterIssuers which do not exist are allowed, as they are checked again
+// when the CertificateRequest for the Certificate is signed.
+func (n *namespaceAccess) Validate(ctx context.Context, req *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) *field.Error {
+	// Only perform validation on CREATE and UPDATE operations of the
+	// resource itself, so that status updates are never rejected
+	if (req.Operation != admissionv1.Create && req.Operation != admissionv1.Update) || req.SubResource != "" {
+		return nil
+	}
+
+	// Only Validate over Certificate resources
+	if req.RequestKind.Group != certmanager.GroupName || req.RequestKind.Kind != cmapi.CertificateKind {
+		return nil
+	}
+
+	crt, ok := obj.(*internalcmapi.Certificate)
+	if !ok {
+		return nil
+	}
+	ref := crt.Spec.IssuerRef
+	if !(ref.Group == "" || ref.Group == certmanager.GroupName) || ref.Kind != cmapi.ClusterIssuerKind {
+		return nil
+	}
+	if oldCrt, ok := oldObj.(*internalcmapi.Certificate); ok && oldCrt.Spec.IssuerRef == ref {
+		return nil
+	}
+
+	// Error if the clients are not initialised
+	if n.namespaceclient == nil || n.clusterissuerclient == nil {
+		return field.InternalError(field.NewPath("spec", "issuerRef"), errors.New("namespace access validation not initialised"))
+	}
+
+	iss, err := n.clusterissuerclient.Get(ctx, ref.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return field.InternalError(field.NewPath("spec", "issuerRef"), err)
+	}
+	if iss.Spec.NamespaceAccess == nil {
+		return nil
+	}
+
+	namespace, err := n.namespaceclient.Get(ctx, req.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return field.InternalError(field.NewPath("spec", "issuerRef"), err)
+	}
+	allowed, err := apiutil.IssuerAllowsNamespace(iss, namespace)
+	if err != nil {
+		return field.InternalError(field.NewPath("spec", "issuerRef"), err)
+	}
+	if !allowed {
+		return field.Forbidden(field.NewPath("spec", "issuerRef"),
+			fmt.Sprintf("ClusterIssuer %q cannot be used from namespace %q", ref.Name, req.Namespace))
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
+	internalcmapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+	internalcmmeta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestValidateNamespaceAccess(t *testing.T) {
+	certificateFor := func(kind, name string) *internalcmapi.Certificate {
+		return &internalcmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "test"},
+			Spec: internalcmapi.CertificateSpec{
+				IssuerRef: internalcmmeta.ObjectReference{Kind: kind, Name: name},
+			},
+		}
+	}
+	request := func(op admissionv1.Operation, subResource string) *admissionv1.AdmissionRequest {
+		return &admissionv1.AdmissionRequest{
+			Operation:   op,
+			Namespace:   "team-a",
+			SubResource: subResource,
+			RequestKind: &metav1.GroupVersionKind{Group: "cert-manager.io", Kind: "Certificate"},
+		}
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"pki": "enabled"}}}
+	unrestricted := gen.ClusterIssuer("unrestricted")
+	allowedBySelector := gen.ClusterIssuer("allowed",
+		gen.SetIssuerNamespaceAccess(cmapi.IssuerNamespaceAccess{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pki": "enabled"}},
+		}),
+	)
+	disallowed := gen.ClusterIssuer("disallowed",
+		gen.SetIssuerNamespaceAccess(cmapi.IssuerNamespaceAccess{Namespaces: []string{"team-b"}}),
+	)
+
+	tests := map[string]struct {
+		req            *admissionv1.AdmissionRequest
+		oldCrt, newCrt runtime.Object
+		expErr         *field.Error
+	}{
+		"if the request is not for a Certificate, exit nil": {
+			req: &admissionv1.AdmissionRequest{
+				Operation:   admissionv1.Create,
+				RequestKind: &metav1.GroupVersionKind{Group: "cert-manager.io", Kind: "CertificateRequest"},
+			},
+			newCrt: &internalcmapi.CertificateRequest{},
+		},
+		"if the Certificate references an Issuer, exit nil": {
+			req:    request(admissionv1.Create, ""),
+			newCrt: certificateFor("Issuer", "disallowed"),
+		},
+		"if the referenced ClusterIssuer does not exist, exit nil": {
+			req:    request(admissionv1.Create, ""),
+			newCrt: certificateFor("ClusterIssuer", "missing"),
+		},
+		"if the referenced ClusterIssuer has no namespace access restrictions, exit nil": {
+			req:    request(admissionv1.Create, ""),
+			newCrt: certificateFor("ClusterIssuer", "unrestricted"),
+		},
+		"if the namespace is selected by the referenced ClusterIssuer, exit nil": {
+			req:    request(admissionv1.Create, ""),
+			newCrt: certificateFor("ClusterIssuer", "allowed"),
+		},
+		"if the namespace is not allowed by the referenced ClusterIssuer, error": {
+			req:    request(admissionv1.Create, ""),
+			newCrt: certificateFor("ClusterIssuer", "disallowed"),
+			expErr: field.Forbidden(field.NewPath("spec", "issuerRef"), `ClusterIssuer "disallowed" cannot be used from namespace "team-a"`),
+		},
+		"if the issuerRef is changed to a ClusterIssuer that does not allow the namespace, error": {
+			req:    request(admissionv1.Update, ""),
+			oldCrt: certificateFor("ClusterIssuer", "allowed"),
+			newCrt: certificateFor("ClusterIssuer", "disallowed"),
+			expErr: field.Forbidden(field.NewPath("spec", "issuerRef"), `ClusterIssuer "disallowed" cannot be used from namespace "team-a"`),
+		},
+		"if the issuerRef is not changed on update, exit nil": {
+			req:    request(admissionv1.Update, ""),
+			oldCrt: certificateFor("ClusterIssuer", "disallowed"),
+			newCrt: certificateFor("ClusterIssuer", "disallowed"),
+		},
+		"if the request is for the status subresource, exit nil": {
+			req:    request(admissionv1.Update, "status"),
+			newCrt: certificateFor("ClusterIssuer", "disallowed"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			n := newNamespaceAccess()
+			n.Init(kubefake.NewSimpleClientset(namespace), cmfake.NewSimpleClientset(unrestricted, allowedBySelector, disallowed))
+
+			err := n.Validate(context.TODO(), test.req, test.oldCrt, test.newCrt)
+			if !reflect.DeepEqual(test.expErr, err) {
+				t.Errorf("unexpected error, exp=%#+v got=%#+v",
+					test.expErr, err)
+			}
+		})
+	}
+}
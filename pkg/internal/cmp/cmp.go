@@ -0,0 +1,285 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmp implements a client for Certificate Management Protocol version
+// 2 (RFC 4210) servers using the HTTP transfer of RFC 6712.
+package cmp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+var _ Interface = &CMP{}
+
+// ClientBuilder is a function type that returns a new Interface.
+// Can be used in tests to create a mock CMP client.
+type ClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
+	issuer v1.GenericIssuer) (Interface, error)
+
+// Interface implements the CMP transactions used to obtain certificates from
+// a CMP server.
+type Interface interface {
+	// Enroll requests a new certificate using an initialization request (ir)
+	// or certification request (cr), depending on the issuer configuration.
+	Enroll(ctx context.Context, req *Request) (*Response, error)
+
+	// KeyUpdate requests an update of the existing certificate using a key
+	// update request (kur). The request is protected using the private key
+	// of the request, which must be the private key of the existing
+	// certificate.
+	KeyUpdate(ctx context.Context, req *Request, existing *x509.Certificate) (*Response, error)
+}
+
+// Request contains the information needed to request a certificate from a
+// CMP server.
+type Request struct {
+	// CSR is the certificate signing request the certificate template is
+	// built from.
+	CSR *x509.CertificateRequest
+
+	// PrivateKey is the private key of the certificate signing request, used
+	// to prove possession of the key to the CMP server.
+	PrivateKey crypto.Signer
+}
+
+// Response is the result of a successful CMP transaction.
+type Response struct {
+	// Chain contains the issued certificate followed by the certificates of
+	// its issuers returned by the CMP server.
+	Chain []*x509.Certificate
+}
+
+// CMP implements Interface and holds the configuration used to connect to a
+// CMP server.
+type CMP struct {
+	url         string
+	requestType v1.CMPRequestType
+
+	protection protection
+	// secret is the shared secret used to verify MAC protected responses, if
+	// any.
+	secret []byte
+	// trusted are the certificates used to verify signature protected
+	// responses. If empty, the system roots are used.
+	trusted []*x509.Certificate
+
+	client *http.Client
+}
+
+// New returns a new CMP client for the given issuer, reading the referenced
+// shared secret or signing key pair Secret from the given namespace.
+func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+	cfg := issuer.GetSpec().CMP
+	if cfg == nil {
+		return nil, errors.New("CMP config cannot be empty")
+	}
+
+	c := &CMP{
+		url:         cfg.URL,
+		requestType: cfg.RequestType,
+	}
+	if c.requestType == "" {
+		c.requestType = v1.CMPCertificationRequest
+	}
+
+	switch {
+	case cfg.SharedSecret != nil:
+		ref := cfg.SharedSecret.SecretRef
+		secret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("no data for %q in secret '%s/%s'", ref.Key, namespace, ref.Name)
+		}
+		c.secret = bytes.TrimSpace(value)
+		c.protection = &macProtection{
+			reference: []byte(cfg.SharedSecret.Reference),
+			secret:    c.secret,
+		}
+
+	case cfg.Signature != nil:
+		chain, key, err := kube.SecretTLSKeyPair(context.TODO(), secretsLister, namespace, cfg.Signature.SecretRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		c.protection = &signatureProtection{signer: key, chain: chain}
+
+	default:
+		return nil, errors.New("CMP config must specify one of sharedSecret or signature")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(cfg.CABundle) > 0 {
+		trusted, err := pki.DecodeX509CertificateChainBytes(cfg.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("error loading CMP CA bundle: %s", err)
+		}
+		rootCAs := x509.NewCertPool()
+		for _, cert := range trusted {
+			rootCAs.AddCert(cert)
+		}
+		c.trusted = trusted
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	}
+	c.client = &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport,
+	}
+
+	return c, nil
+}
+
+// Enroll requests a new certificate from the CMP server.
+func (c *CMP) Enroll(ctx context.Context, req *Request) (*Response, error) {
+	reqType, repType := bodyCR, bodyCP
+	if c.requestType == v1.CMPInitializationRequest {
+		reqType, repType = bodyIR, bodyIP
+	}
+
+	t, err := newTransaction(c.protection, req.CSR.RawSubject, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.certRequest(ctx, t, req, nil, reqType, repType)
+}
+
+// KeyUpdate requests an update of the existing certificate from the CMP
+// server. Key update requests are protected by a signature of the existing
+// certificate, as required by RFC 4210 section 5.3.5.
+func (c *CMP) KeyUpdate(ctx context.Context, req *Request, existing *x509.Certificate) (*Response, error) {
+	p := &signatureProtection{signer: req.PrivateKey, chain: []*x509.Certificate{existing}}
+	t, err := newTransaction(p, req.CSR.RawSubject, existing.RawIssuer)
+	if err != nil {
+		return nil, err
+	}
+	return c.certRequest(ctx, t, req, existing, bodyKUR, bodyKUP)
+}
+
+// certRequest sends a certificate request message of the given type,
+// confirms the issued certificate, and returns it.
+func (c *CMP) certRequest(ctx context.Context, t *transaction, req *Request, oldCert *x509.Certificate, reqType, repType int) (*Response, error) {
+	msg, err := newCertReqMsg(req.CSR, req.PrivateKey, oldCert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CMP certificate request: %s", err)
+	}
+	reqDER, err := t.message(reqType, []certReqMsg{msg})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CMP message: %s", err)
+	}
+
+	respDER, err := c.do(ctx, reqDER)
+	if err != nil {
+		return nil, err
+	}
+	body, extraCerts, err := t.response(respDER, c.secret, c.trusted, repType)
+	if err != nil {
+		return nil, err
+	}
+
+	var rep certRepMessage
+	if _, err := asn1.Unmarshal(body.Bytes, &rep); err != nil {
+		return nil, fmt.Errorf("failed to parse CMP certificate response: %s", err)
+	}
+	if len(rep.Response) != 1 {
+		return nil, fmt.Errorf("expected 1 CMP certificate response, got %d", len(rep.Response))
+	}
+	certResp := rep.Response[0]
+	if status := PKIStatus(certResp.Status.Status); status != StatusAccepted && status != StatusGrantedWithMods {
+		return nil, certResp.Status.statusError()
+	}
+
+	certOrEncCert := certResp.CertifiedKeyPair.CertOrEncCert
+	if certOrEncCert.Class != asn1.ClassContextSpecific || certOrEncCert.Tag != 0 {
+		return nil, errors.New("CMP certificate response does not contain a plaintext certificate")
+	}
+	cert, err := x509.ParseCertificate(certOrEncCert.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse issued certificate: %s", err)
+	}
+
+	// Confirm the certificate so that the CMP server publishes it.
+	confDER, err := t.message(bodyCertConf, []certStatus{{
+		CertHash:  certHash(cert),
+		CertReqID: certResp.CertReqID,
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CMP message: %s", err)
+	}
+	respDER, err = c.do(ctx, confDER)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := t.response(respDER, c.secret, c.trusted, bodyPKIConf); err != nil {
+		return nil, fmt.Errorf("failed to confirm certificate: %w", err)
+	}
+
+	certs := extraCerts
+	for _, raw := range rep.CAPubs {
+		caCert, err := x509.ParseCertificate(raw.FullBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CA certificate: %s", err)
+		}
+		certs = append(certs, caCert)
+	}
+
+	return &Response{Chain: buildChain(cert, certs)}, nil
+}
+
+// do sends a PKIMessage to the CMP server and returns the PKIMessage it
+// responds with.
+func (c *CMP) do(ctx context.Context, message []byte) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(message))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/pkixcmp")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CMP server responded with status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/pkixcmp" {
+		return nil, fmt.Errorf("unexpected content type for CMP response: %q", contentType)
+	}
+	return body, nil
+}
@@ -0,0 +1,457 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+	testlisters "github.com/jetstack/cert-manager/test/unit/listers"
+)
+
+// cmpServer is a minimal CMP server used as a stand-in for a real CA in
+// tests.
+type cmpServer struct {
+	t *testing.T
+
+	caCert *x509.Certificate
+	caKey  crypto.Signer
+
+	// secret verifies MAC protected requests.
+	secret []byte
+	// responseProtection protects responses.
+	responseProtection protection
+	// failInfo causes certificate requests to be rejected with the given
+	// PKIFailureInfo bit.
+	failInfo int
+
+	// bodyTypes records the body types of the received messages.
+	bodyTypes []int
+	// oldCertID records whether a key update request referenced the old
+	// certificate.
+	oldCertID bool
+	issued    *x509.Certificate
+}
+
+func (s *cmpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/pkixcmp" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+
+	var msg pkiMessage
+	if _, err := asn1.Unmarshal(data, &msg); err != nil {
+		s.t.Fatal(err)
+	}
+	var header pkiHeader
+	if _, err := asn1.Unmarshal(msg.Header.FullBytes, &header); err != nil {
+		s.t.Fatal(err)
+	}
+	var extraCerts []*x509.Certificate
+	for _, raw := range msg.ExtraCerts {
+		cert, err := x509.ParseCertificate(raw.FullBytes)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		extraCerts = append(extraCerts, cert)
+	}
+	part, err := asn1.Marshal(protectedPart{Header: msg.Header, Body: msg.Body})
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	t := &transaction{
+		protection:    s.responseProtection,
+		subject:       s.caCert.RawSubject,
+		recipient:     header.Sender.Bytes,
+		transactionID: header.TransactionID,
+		recipNonce:    header.SenderNonce,
+	}
+	s.bodyTypes = append(s.bodyTypes, msg.Body.Tag)
+
+	if err := verifyProtection(header, part, msg.Protection.RightAlign(), extraCerts, s.secret, []*x509.Certificate{s.caCert}); err != nil {
+		s.t.Logf("rejecting request: %s", err)
+		s.respondError(w, t, 1)
+		return
+	}
+
+	switch msg.Body.Tag {
+	case bodyIR, bodyCR, bodyKUR:
+		if s.failInfo != 0 {
+			s.respondError(w, t, s.failInfo)
+			return
+		}
+		s.certRequest(w, t, msg.Body)
+
+	case bodyCertConf:
+		var statuses []certStatus
+		if _, err := asn1.Unmarshal(msg.Body.Bytes, &statuses); err != nil {
+			s.t.Fatal(err)
+		}
+		if len(statuses) != 1 || !bytes.Equal(statuses[0].CertHash, certHash(s.issued)) {
+			s.t.Errorf("unexpected certificate confirmation")
+		}
+		s.respond(w, t, bodyPKIConf, asn1.NullRawValue)
+
+	default:
+		s.t.Errorf("unexpected body type %d", msg.Body.Tag)
+	}
+}
+
+func (s *cmpServer) certRequest(w http.ResponseWriter, t *transaction, body asn1.RawValue) {
+	var msgs []certReqMsg
+	if _, err := asn1.Unmarshal(body.Bytes, &msgs); err != nil {
+		s.t.Fatal(err)
+	}
+	var req certRequest
+	if _, err := asn1.Unmarshal(msgs[0].CertReq.FullBytes, &req); err != nil {
+		s.t.Fatal(err)
+	}
+
+	spki, err := asn1.Marshal(asn1.RawValue{Tag: asn1.TagSequence, IsCompound: true, Bytes: req.CertTemplate.PublicKey.Bytes})
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	pub, err := x509.ParsePKIXPublicKey(spki)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+
+	// Verify the proof of possession.
+	if msgs[0].POPO.Tag != 1 {
+		s.t.Fatalf("expected signature proof of possession, got tag %d", msgs[0].POPO.Tag)
+	}
+	popoDER, err := asn1.Marshal(asn1.RawValue{Tag: asn1.TagSequence, IsCompound: true, Bytes: msgs[0].POPO.Bytes})
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	var popo popoSigningKey
+	if _, err := asn1.Unmarshal(popoDER, &popo); err != nil {
+		s.t.Fatal(err)
+	}
+	if err := verifySignature(&x509.Certificate{PublicKey: pub}, popo.AlgorithmIdentifier, msgs[0].CertReq.FullBytes, popo.Signature.RightAlign()); err != nil {
+		s.respondError(w, t, 9)
+		return
+	}
+
+	for _, control := range req.Controls {
+		if control.Type.Equal(oidRegCtrlOldCertID) {
+			s.oldCertID = true
+		}
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		RawSubject:   req.CertTemplate.Subject.Bytes,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if exts := req.CertTemplate.Extensions.Bytes; len(exts) > 0 {
+		extsDER, err := asn1.Marshal(asn1.RawValue{Tag: asn1.TagSequence, IsCompound: true, Bytes: exts})
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		if _, err := asn1.Unmarshal(extsDER, &template.ExtraExtensions); err != nil {
+			s.t.Fatal(err)
+		}
+	}
+	_, cert, err := pki.SignCertificate(template, s.caCert, pub, s.caKey)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	s.issued = cert
+
+	s.respond(w, t, body.Tag+1, certRepMessage{
+		CAPubs: []asn1.RawValue{{FullBytes: s.caCert.Raw}},
+		Response: []certResponse{{
+			CertReqID: req.CertReqID,
+			Status:    pkiStatusInfo{Status: int(StatusAccepted)},
+			CertifiedKeyPair: certifiedKeyPair{
+				CertOrEncCert: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: cert.Raw},
+			},
+		}},
+	})
+}
+
+func (s *cmpServer) respondError(w http.ResponseWriter, t *transaction, failInfo int) {
+	bits := make([]byte, 4)
+	bits[failInfo/8] |= 0x80 >> uint(failInfo%8)
+	s.respond(w, t, bodyError, errorMsgContent{
+		PKIStatusInfo: pkiStatusInfo{
+			Status:   int(StatusRejection),
+			FailInfo: asn1.BitString{Bytes: bits, BitLength: failInfo + 1},
+		},
+	})
+}
+
+func (s *cmpServer) respond(w http.ResponseWriter, t *transaction, bodyType int, content interface{}) {
+	data, err := t.message(bodyType, content)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	w.Header().Set("Content-Type", "application/pkixcmp")
+	w.Write(data)
+}
+
+func generateCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer) {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	_, cert, err := pki.SignCertificate(template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestCMP(t *testing.T) {
+	caCert, caKey := generateCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "cmp-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}, nil, nil)
+	signerCert, signerKey := generateCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "cmp-client"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, caCert, caKey)
+
+	csrKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "example.com"},
+		DNSNames: []string{"example.com"},
+	}, csrKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, existingCert, err := pki.SignCertificate(&x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, caCert, csrKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signerKeyPEM, err := pki.EncodePKCS8PrivateKey(signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signerCertPEM, err := pki.EncodeX509(signerCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCertPEM, err := pki.EncodeX509(caCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sharedSecret := gen.Secret("cmp-secret",
+		gen.SetSecretNamespace("test-ns"),
+		gen.SetSecretData(map[string][]byte{"secret": []byte("password\n")}),
+	)
+	signerSecret := gen.Secret("cmp-signer",
+		gen.SetSecretNamespace("test-ns"),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSCertKey:       signerCertPEM,
+			corev1.TLSPrivateKeyKey: signerKeyPEM,
+		}),
+	)
+	macConfig := cmapi.CMPIssuer{
+		SharedSecret: &cmapi.CMPSharedSecretProtection{
+			Reference: "1234",
+			SecretRef: cmmeta.SecretKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{Name: "cmp-secret"},
+				Key:                  "secret",
+			},
+		},
+	}
+	caProtection := &signatureProtection{signer: caKey, chain: []*x509.Certificate{caCert}}
+
+	tests := map[string]struct {
+		server    *cmpServer
+		config    cmapi.CMPIssuer
+		keyUpdate bool
+
+		expectedBodyTypes []int
+		expectedErr       error
+	}{
+		"certification request with shared secret protection": {
+			server: &cmpServer{
+				secret:             []byte("password"),
+				responseProtection: &macProtection{secret: []byte("password")},
+			},
+			config:            macConfig,
+			expectedBodyTypes: []int{bodyCR, bodyCertConf},
+		},
+		"initialization request with shared secret protection": {
+			server: &cmpServer{
+				secret:             []byte("password"),
+				responseProtection: &macProtection{secret: []byte("password")},
+			},
+			config: cmapi.CMPIssuer{
+				RequestType:  cmapi.CMPInitializationRequest,
+				SharedSecret: macConfig.SharedSecret,
+			},
+			expectedBodyTypes: []int{bodyIR, bodyCertConf},
+		},
+		"certification request with signature protection": {
+			server:            &cmpServer{responseProtection: caProtection},
+			config:            cmapi.CMPIssuer{CABundle: caCertPEM, Signature: &cmapi.CMPSignatureProtection{SecretRef: cmmeta.LocalObjectReference{Name: "cmp-signer"}}},
+			expectedBodyTypes: []int{bodyCR, bodyCertConf},
+		},
+		"key update request is protected by the existing certificate": {
+			server:            &cmpServer{responseProtection: caProtection},
+			config:            cmapi.CMPIssuer{CABundle: caCertPEM, Signature: &cmapi.CMPSignatureProtection{SecretRef: cmmeta.LocalObjectReference{Name: "cmp-signer"}}},
+			keyUpdate:         true,
+			expectedBodyTypes: []int{bodyKUR, bodyCertConf},
+		},
+		"rejected certification request": {
+			server: &cmpServer{
+				secret:             []byte("password"),
+				responseProtection: &macProtection{secret: []byte("password")},
+				failInfo:           9,
+			},
+			config:            macConfig,
+			expectedBodyTypes: []int{bodyCR},
+			expectedErr:       &StatusError{Status: StatusRejection, FailInfo: []string{"badPOP"}},
+		},
+		"response protected with a different shared secret": {
+			server: &cmpServer{
+				secret:             []byte("password"),
+				responseProtection: &macProtection{secret: []byte("other")},
+			},
+			config:            macConfig,
+			expectedBodyTypes: []int{bodyCR},
+			expectedErr:       errors.New("CMP response MAC verification failed"),
+		},
+		"response signed by an untrusted CA": {
+			server:            &cmpServer{responseProtection: &signatureProtection{signer: signerKey, chain: []*x509.Certificate{signerCert}}},
+			config:            cmapi.CMPIssuer{Signature: &cmapi.CMPSignatureProtection{SecretRef: cmmeta.LocalObjectReference{Name: "cmp-signer"}}},
+			expectedBodyTypes: []int{bodyCR},
+			expectedErr:       errors.New("CMP response signer is not trusted: x509: certificate signed by unknown authority"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.server.t = t
+			test.server.caCert = caCert
+			test.server.caKey = caKey
+
+			srv := httptest.NewServer(test.server)
+			defer srv.Close()
+
+			test.config.URL = srv.URL + "/pkix/"
+			issuer := gen.Issuer("cmp-issuer",
+				gen.SetIssuerNamespace("test-ns"),
+				gen.SetIssuerCMP(test.config),
+			)
+
+			secretsLister := testlisters.FakeSecretListerFrom(testlisters.NewFakeSecretLister(),
+				testlisters.SetFakeSecretNamespaceListerGet(sharedSecret, nil),
+			)
+			if test.config.Signature != nil {
+				secretsLister = testlisters.FakeSecretListerFrom(testlisters.NewFakeSecretLister(),
+					testlisters.SetFakeSecretNamespaceListerGet(signerSecret, nil),
+				)
+			}
+
+			c, err := New("test-ns", secretsLister, issuer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := &Request{CSR: csr, PrivateKey: csrKey}
+			var resp *Response
+			if test.keyUpdate {
+				resp, err = c.KeyUpdate(context.TODO(), req, existingCert)
+			} else {
+				resp, err = c.Enroll(context.TODO(), req)
+			}
+
+			if !reflect.DeepEqual(test.server.bodyTypes, test.expectedBodyTypes) {
+				t.Errorf("unexpected message body types %v, expected %v", test.server.bodyTypes, test.expectedBodyTypes)
+			}
+			if test.expectedErr != nil {
+				if err == nil || err.Error() != test.expectedErr.Error() {
+					t.Fatalf("expected error %q, got %v", test.expectedErr, err)
+				}
+				var statusErr *StatusError
+				if _, ok := test.expectedErr.(*StatusError); ok && !errors.As(err, &statusErr) {
+					t.Errorf("expected a StatusError, got %T", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(resp.Chain) != 2 || !resp.Chain[1].Equal(caCert) {
+				t.Fatalf("expected the issued certificate followed by the CA certificate, got %d certificates", len(resp.Chain))
+			}
+			if ok, _ := pki.PublicKeysEqual(resp.Chain[0].PublicKey, csrKey.Public()); !ok {
+				t.Errorf("expected the issued certificate to be first")
+			}
+			if !reflect.DeepEqual(resp.Chain[0].DNSNames, []string{"example.com"}) {
+				t.Errorf("unexpected DNS names %v", resp.Chain[0].DNSNames)
+			}
+			if test.server.oldCertID != test.keyUpdate {
+				t.Errorf("expected oldCertID control to be %t", test.keyUpdate)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake contains a fake CMP client for use in tests
+package fake
+
+import (
+	"context"
+	"crypto/x509"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/internal/cmp"
+)
+
+var _ cmp.Interface = &CMP{}
+
+type CMP struct {
+	NewFn       func(string, corelisters.SecretLister, v1.GenericIssuer) (*CMP, error)
+	EnrollFn    func(context.Context, *cmp.Request) (*cmp.Response, error)
+	KeyUpdateFn func(context.Context, *cmp.Request, *x509.Certificate) (*cmp.Response, error)
+}
+
+// New returns a new fake CMP client
+func New() *CMP {
+	c := &CMP{
+		EnrollFn: func(context.Context, *cmp.Request) (*cmp.Response, error) {
+			return nil, nil
+		},
+		KeyUpdateFn: func(context.Context, *cmp.Request, *x509.Certificate) (*cmp.Response, error) {
+			return nil, nil
+		},
+	}
+
+	c.NewFn = func(string, corelisters.SecretLister, v1.GenericIssuer) (*CMP, error) {
+		return c, nil
+	}
+
+	return c
+}
+
+// Enroll implements `cmp.Interface`.
+func (c *CMP) Enroll(ctx context.Context, req *cmp.Request) (*cmp.Response, error) {
+	return c.EnrollFn(ctx, req)
+}
+
+// KeyUpdate implements `cmp.Interface`.
+func (c *CMP) KeyUpdate(ctx context.Context, req *cmp.Request, existing *x509.Certificate) (*cmp.Response, error) {
+	return c.KeyUpdateFn(ctx, req, existing)
+}
+
+// WithEnroll sets the fake CMP client's Enroll function.
+func (c *CMP) WithEnroll(resp *cmp.Response, err error) *CMP {
+	c.EnrollFn = func(context.Context, *cmp.Request) (*cmp.Response, error) {
+		return resp, err
+	}
+	return c
+}
+
+// WithKeyUpdate sets the fake CMP client's KeyUpdate function.
+func (c *CMP) WithKeyUpdate(resp *cmp.Response, err error) *CMP {
+	c.KeyUpdateFn = func(context.Context, *cmp.Request, *x509.Certificate) (*cmp.Response, error) {
+		return resp, err
+	}
+	return c
+}
+
+// WithNew sets the fake CMP client's New function.
+func (c *CMP) WithNew(f func(string, corelisters.SecretLister, v1.GenericIssuer) (*CMP, error)) *CMP {
+	c.NewFn = f
+	return c
+}
+
+// New calls NewFn and returns a pointer to the fake CMP client.
+func (c *CMP) New(ns string, sl corelisters.SecretLister, iss v1.GenericIssuer) (cmp.Interface, error) {
+	_, err := c.NewFn(ns, sl, iss)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,793 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+)
+
+// PKIStatus is the status of a response to a CMP request.
+type PKIStatus int
+
+const (
+	StatusAccepted               PKIStatus = 0
+	StatusGrantedWithMods        PKIStatus = 1
+	StatusRejection              PKIStatus = 2
+	StatusWaiting                PKIStatus = 3
+	StatusRevocationWarning      PKIStatus = 4
+	StatusRevocationNotification PKIStatus = 5
+	StatusKeyUpdateWarning       PKIStatus = 6
+)
+
+func (s PKIStatus) String() string {
+	switch s {
+	case StatusAccepted:
+		return "accepted"
+	case StatusGrantedWithMods:
+		return "grantedWithMods"
+	case StatusRejection:
+		return "rejection"
+	case StatusWaiting:
+		return "waiting"
+	case StatusRevocationWarning:
+		return "revocationWarning"
+	case StatusRevocationNotification:
+		return "revocationNotification"
+	case StatusKeyUpdateWarning:
+		return "keyUpdateWarning"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// failInfoNames are the names of the bits of PKIFailureInfo, as defined in
+// RFC 4210 appendix F.
+var failInfoNames = []string{
+	"badAlg", "badMessageCheck", "badRequest", "badTime", "badCertId",
+	"badDataFormat", "wrongAuthority", "incorrectData", "missingTimeStamp",
+	"badPOP", "certRevoked", "certConfirmed", "wrongIntegrity",
+	"badRecipientNonce", "timeNotAvailable", "unacceptedPolicy",
+	"unacceptedExtension", "addInfoNotAvailable", "badSenderNonce",
+	"badCertTemplate", "signerNotTrusted", "transactionIdInUse",
+	"unsupportedVersion", "notAuthorized", "systemUnavail", "systemFailure",
+	"duplicateCertReq",
+}
+
+// StatusError is returned when the CMP server does not grant a request.
+type StatusError struct {
+	// Status is the PKIStatus returned by the CMP server.
+	Status PKIStatus
+
+	// FailInfo contains the names of the PKIFailureInfo bits set by the CMP
+	// server, if any.
+	FailInfo []string
+
+	// StatusString contains any human readable text returned by the CMP
+	// server.
+	StatusString []string
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("CMP server responded with status %s", e.Status)
+	if len(e.FailInfo) > 0 {
+		msg += fmt.Sprintf(" (%s)", strings.Join(e.FailInfo, ", "))
+	}
+	if len(e.StatusString) > 0 {
+		msg += ": " + strings.Join(e.StatusString, "; ")
+	}
+	return msg
+}
+
+// Tags of the PKIBody choices used by this client.
+const (
+	bodyIR       = 0
+	bodyIP       = 1
+	bodyCR       = 2
+	bodyCP       = 3
+	bodyKUR      = 7
+	bodyKUP      = 8
+	bodyPKIConf  = 19
+	bodyError    = 23
+	bodyCertConf = 24
+)
+
+var (
+	oidPasswordBasedMAC = asn1.ObjectIdentifier{1, 2, 840, 113533, 7, 66, 13}
+	oidSHA1             = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA256           = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidHMACWithSHA1     = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 8, 1, 2}
+	oidHMACWithSHA256   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidRegCtrlOldCertID = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 5, 1, 5}
+
+	oidSHA256WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}
+	oidSHA384WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 12}
+	oidSHA512WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 13}
+	oidECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidECDSAWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 3}
+	oidECDSAWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 4}
+	oidEd25519         = asn1.ObjectIdentifier{1, 3, 101, 112}
+)
+
+// signatureAlgorithms maps the signature algorithms accepted on protected
+// responses to the algorithm used to verify them.
+var signatureAlgorithms = []struct {
+	oid  asn1.ObjectIdentifier
+	algo x509.SignatureAlgorithm
+}{
+	{oidSHA256WithRSA, x509.SHA256WithRSA},
+	{oidSHA384WithRSA, x509.SHA384WithRSA},
+	{oidSHA512WithRSA, x509.SHA512WithRSA},
+	{oidECDSAWithSHA256, x509.ECDSAWithSHA256},
+	{oidECDSAWithSHA384, x509.ECDSAWithSHA384},
+	{oidECDSAWithSHA512, x509.ECDSAWithSHA512},
+	{oidEd25519, x509.PureEd25519},
+}
+
+// maxPBMIterations bounds the iteration count accepted on MAC protected
+// responses so that a server cannot make the client spin.
+const maxPBMIterations = 100000
+
+// pkiMessage is a CMP PKIMessage. The header and body are kept as raw DER so
+// that the protection of received messages can be verified.
+type pkiMessage struct {
+	Header     asn1.RawValue
+	Body       asn1.RawValue
+	Protection asn1.BitString  `asn1:"optional,explicit,tag:0"`
+	ExtraCerts []asn1.RawValue `asn1:"optional,explicit,tag:1"`
+}
+
+type pkiHeader struct {
+	PVNO          int
+	Sender        asn1.RawValue
+	Recipient     asn1.RawValue
+	MessageTime   time.Time                `asn1:"generalized,optional,explicit,tag:0"`
+	ProtectionAlg pkix.AlgorithmIdentifier `asn1:"optional,explicit,tag:1"`
+	SenderKID     []byte                   `asn1:"optional,explicit,tag:2"`
+	RecipKID      []byte                   `asn1:"optional,explicit,tag:3"`
+	TransactionID []byte                   `asn1:"optional,explicit,tag:4"`
+	SenderNonce   []byte                   `asn1:"optional,explicit,tag:5"`
+	RecipNonce    []byte                   `asn1:"optional,explicit,tag:6"`
+	FreeText      asn1.RawValue            `asn1:"optional,explicit,tag:7"`
+	GeneralInfo   asn1.RawValue            `asn1:"optional,explicit,tag:8"`
+}
+
+type protectedPart struct {
+	Header asn1.RawValue
+	Body   asn1.RawValue
+}
+
+type pbmParameter struct {
+	Salt           []byte
+	OWF            pkix.AlgorithmIdentifier
+	IterationCount int
+	MAC            pkix.AlgorithmIdentifier
+}
+
+type certReqMsg struct {
+	CertReq asn1.RawValue
+	POPO    asn1.RawValue `asn1:"optional"`
+}
+
+type certRequest struct {
+	CertReqID    int
+	CertTemplate certTemplate
+	Controls     []attributeTypeAndValue `asn1:"optional"`
+}
+
+// certTemplate contains the fields of a CRMF CertTemplate (RFC 4211) that
+// are populated from a certificate signing request.
+type certTemplate struct {
+	Subject    asn1.RawValue `asn1:"optional"`
+	PublicKey  asn1.RawValue `asn1:"optional"`
+	Extensions asn1.RawValue `asn1:"optional"`
+}
+
+type attributeTypeAndValue struct {
+	Type  asn1.ObjectIdentifier
+	Value asn1.RawValue
+}
+
+type certID struct {
+	Issuer       asn1.RawValue
+	SerialNumber asn1.RawValue
+}
+
+type popoSigningKey struct {
+	AlgorithmIdentifier pkix.AlgorithmIdentifier
+	Signature           asn1.BitString
+}
+
+type certRepMessage struct {
+	CAPubs   []asn1.RawValue `asn1:"optional,explicit,tag:1"`
+	Response []certResponse
+}
+
+type certResponse struct {
+	CertReqID        int
+	Status           pkiStatusInfo
+	CertifiedKeyPair certifiedKeyPair `asn1:"optional"`
+	RspInfo          []byte           `asn1:"optional"`
+}
+
+type pkiStatusInfo struct {
+	Status       int
+	StatusString []asn1.RawValue `asn1:"optional"`
+	FailInfo     asn1.BitString  `asn1:"optional"`
+}
+
+type certifiedKeyPair struct {
+	CertOrEncCert   asn1.RawValue
+	PrivateKey      asn1.RawValue `asn1:"optional,explicit,tag:0"`
+	PublicationInfo asn1.RawValue `asn1:"optional,explicit,tag:1"`
+}
+
+type errorMsgContent struct {
+	PKIStatusInfo pkiStatusInfo
+	ErrorCode     int             `asn1:"optional"`
+	ErrorDetails  []asn1.RawValue `asn1:"optional"`
+}
+
+type certStatus struct {
+	CertHash  []byte
+	CertReqID int
+}
+
+// statusError returns a StatusError for the given PKIStatusInfo.
+func (s pkiStatusInfo) statusError() *StatusError {
+	err := &StatusError{Status: PKIStatus(s.Status)}
+	for i, name := range failInfoNames {
+		if s.FailInfo.At(i) == 1 {
+			err.FailInfo = append(err.FailInfo, name)
+		}
+	}
+	for _, text := range s.StatusString {
+		var str string
+		if _, e := asn1.Unmarshal(text.FullBytes, &str); e == nil {
+			err.StatusString = append(err.StatusString, str)
+		}
+	}
+	return err
+}
+
+// directoryName returns a GeneralName containing the given DER encoded Name.
+// An empty name is encoded as the NULL-DN.
+func directoryName(name []byte) asn1.RawValue {
+	if len(name) == 0 {
+		name = []byte{0x30, 0x00}
+	}
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 4, IsCompound: true, Bytes: name}
+}
+
+// protection protects CMP messages sent to the server and verifies the
+// protection of messages received from it.
+type protection interface {
+	// algorithm returns the protection algorithm of outgoing messages.
+	algorithm() (pkix.AlgorithmIdentifier, error)
+	// sender returns the DER encoded sender name and sender key identifier of
+	// outgoing messages, given the subject of the request.
+	sender(subject []byte) ([]byte, []byte)
+	// protect returns the protection of the given protected part.
+	protect(alg pkix.AlgorithmIdentifier, data []byte) ([]byte, error)
+	// extraCerts returns the certificates to send with outgoing messages.
+	extraCerts() []*x509.Certificate
+}
+
+// macProtection protects messages with a password-based MAC (RFC 4210
+// section 5.1.3.1).
+type macProtection struct {
+	reference []byte
+	secret    []byte
+}
+
+func (m *macProtection) algorithm() (pkix.AlgorithmIdentifier, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	params, err := asn1.Marshal(pbmParameter{
+		Salt:           salt,
+		OWF:            pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+		IterationCount: 10000,
+		MAC:            pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256},
+	})
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	return pkix.AlgorithmIdentifier{
+		Algorithm:  oidPasswordBasedMAC,
+		Parameters: asn1.RawValue{FullBytes: params},
+	}, nil
+}
+
+func (m *macProtection) sender(subject []byte) ([]byte, []byte) {
+	return subject, m.reference
+}
+
+func (m *macProtection) protect(alg pkix.AlgorithmIdentifier, data []byte) ([]byte, error) {
+	return passwordBasedMAC(m.secret, alg, data)
+}
+
+func (m *macProtection) extraCerts() []*x509.Certificate {
+	return nil
+}
+
+// signatureProtection protects messages with a signature (RFC 4210 section
+// 5.1.3.3).
+type signatureProtection struct {
+	signer crypto.Signer
+	chain  []*x509.Certificate
+}
+
+func (s *signatureProtection) algorithm() (pkix.AlgorithmIdentifier, error) {
+	alg, _, err := signingAlgorithm(s.signer)
+	return alg, err
+}
+
+func (s *signatureProtection) sender(_ []byte) ([]byte, []byte) {
+	return s.chain[0].RawSubject, s.chain[0].SubjectKeyId
+}
+
+func (s *signatureProtection) protect(_ pkix.AlgorithmIdentifier, data []byte) ([]byte, error) {
+	return sign(s.signer, data)
+}
+
+func (s *signatureProtection) extraCerts() []*x509.Certificate {
+	return s.chain
+}
+
+// passwordBasedMAC computes the password-based MAC of data with the
+// parameters of the given algorithm, as defined in RFC 4211 section 4.4.
+func passwordBasedMAC(secret []byte, alg pkix.AlgorithmIdentifier, data []byte) ([]byte, error) {
+	if !alg.Algorithm.Equal(oidPasswordBasedMAC) {
+		return nil, fmt.Errorf("unsupported MAC algorithm %s", alg.Algorithm)
+	}
+	var params pbmParameter
+	if _, err := asn1.Unmarshal(alg.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse password-based MAC parameters: %s", err)
+	}
+	if params.IterationCount < 1 || params.IterationCount > maxPBMIterations {
+		return nil, fmt.Errorf("unsupported password-based MAC iteration count %d", params.IterationCount)
+	}
+
+	var owf func() hash.Hash
+	switch {
+	case params.OWF.Algorithm.Equal(oidSHA256):
+		owf = sha256.New
+	case params.OWF.Algorithm.Equal(oidSHA1):
+		owf = sha1.New
+	default:
+		return nil, fmt.Errorf("unsupported password-based MAC one-way function %s", params.OWF.Algorithm)
+	}
+	var mac func() hash.Hash
+	switch {
+	case params.MAC.Algorithm.Equal(oidHMACWithSHA256):
+		mac = sha256.New
+	case params.MAC.Algorithm.Equal(oidHMACWithSHA1):
+		mac = sha1.New
+	default:
+		return nil, fmt.Errorf("unsupported password-based MAC algorithm %s", params.MAC.Algorithm)
+	}
+
+	h := owf()
+	h.Write(secret)
+	h.Write(params.Salt)
+	key := h.Sum(nil)
+	for i := 1; i < params.IterationCount; i++ {
+		h.Reset()
+		h.Write(key)
+		key = h.Sum(nil)
+	}
+
+	m := hmac.New(mac, key)
+	m.Write(data)
+	return m.Sum(nil), nil
+}
+
+// signingAlgorithm returns the algorithm identifier and hash used to sign
+// with the given key.
+func signingAlgorithm(signer crypto.Signer) (pkix.AlgorithmIdentifier, crypto.Hash, error) {
+	switch signer.Public().(type) {
+	case *rsa.PublicKey:
+		return pkix.AlgorithmIdentifier{Algorithm: oidSHA256WithRSA, Parameters: asn1.NullRawValue}, crypto.SHA256, nil
+	case *ecdsa.PublicKey:
+		return pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256}, crypto.SHA256, nil
+	case ed25519.PublicKey:
+		return pkix.AlgorithmIdentifier{Algorithm: oidEd25519}, crypto.Hash(0), nil
+	default:
+		return pkix.AlgorithmIdentifier{}, 0, fmt.Errorf("unsupported private key type %T", signer.Public())
+	}
+}
+
+// sign signs data with the given key using the algorithm returned by
+// signingAlgorithm.
+func sign(signer crypto.Signer, data []byte) ([]byte, error) {
+	_, hashFunc, err := signingAlgorithm(signer)
+	if err != nil {
+		return nil, err
+	}
+	digest := data
+	if hashFunc != 0 {
+		h := hashFunc.New()
+		h.Write(data)
+		digest = h.Sum(nil)
+	}
+	return signer.Sign(rand.Reader, digest, hashFunc)
+}
+
+// verifySignature verifies the signature of data by the given certificate.
+func verifySignature(cert *x509.Certificate, alg pkix.AlgorithmIdentifier, data, signature []byte) error {
+	for _, s := range signatureAlgorithms {
+		if s.oid.Equal(alg.Algorithm) {
+			return cert.CheckSignature(s.algo, data, signature)
+		}
+	}
+	return fmt.Errorf("unsupported signature algorithm %s", alg.Algorithm)
+}
+
+// newCertReqMsg returns a certificate request message for the given
+// certificate signing request, with proof of possession signed by the given
+// key. If oldCert is given, it is referenced using the oldCertID control so
+// that the server updates that certificate.
+func newCertReqMsg(csr *x509.CertificateRequest, key crypto.Signer, oldCert *x509.Certificate) (certReqMsg, error) {
+	var spki asn1.RawValue
+	if _, err := asn1.Unmarshal(csr.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return certReqMsg{}, err
+	}
+
+	req := certRequest{
+		CertTemplate: certTemplate{
+			// Name is a CHOICE so the tag is explicit.
+			Subject:   asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 5, IsCompound: true, Bytes: csr.RawSubject},
+			PublicKey: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 6, IsCompound: true, Bytes: spki.Bytes},
+		},
+	}
+	if len(csr.Extensions) > 0 {
+		exts, err := asn1.Marshal(csr.Extensions)
+		if err != nil {
+			return certReqMsg{}, err
+		}
+		var seq asn1.RawValue
+		if _, err := asn1.Unmarshal(exts, &seq); err != nil {
+			return certReqMsg{}, err
+		}
+		req.CertTemplate.Extensions = asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 9, IsCompound: true, Bytes: seq.Bytes}
+	}
+	if oldCert != nil {
+		serial, err := asn1.Marshal(oldCert.SerialNumber)
+		if err != nil {
+			return certReqMsg{}, err
+		}
+		id, err := asn1.Marshal(certID{
+			Issuer:       directoryName(oldCert.RawIssuer),
+			SerialNumber: asn1.RawValue{FullBytes: serial},
+		})
+		if err != nil {
+			return certReqMsg{}, err
+		}
+		req.Controls = []attributeTypeAndValue{{Type: oidRegCtrlOldCertID, Value: asn1.RawValue{FullBytes: id}}}
+	}
+
+	reqDER, err := asn1.Marshal(req)
+	if err != nil {
+		return certReqMsg{}, err
+	}
+
+	alg, _, err := signingAlgorithm(key)
+	if err != nil {
+		return certReqMsg{}, err
+	}
+	signature, err := sign(key, reqDER)
+	if err != nil {
+		return certReqMsg{}, err
+	}
+	popo, err := asn1.Marshal(popoSigningKey{
+		AlgorithmIdentifier: alg,
+		Signature:           asn1.BitString{Bytes: signature, BitLength: len(signature) * 8},
+	})
+	if err != nil {
+		return certReqMsg{}, err
+	}
+	var popoSeq asn1.RawValue
+	if _, err := asn1.Unmarshal(popo, &popoSeq); err != nil {
+		return certReqMsg{}, err
+	}
+
+	return certReqMsg{
+		CertReq: asn1.RawValue{FullBytes: reqDER},
+		// signature [1] POPOSigningKey is implicitly tagged.
+		POPO: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 1, IsCompound: true, Bytes: popoSeq.Bytes},
+	}, nil
+}
+
+// transaction holds the state of a single CMP transaction, which consists of
+// a request and its response followed by a certificate confirmation.
+type transaction struct {
+	protection protection
+	subject    []byte
+	recipient  []byte
+
+	transactionID []byte
+	senderNonce   []byte
+	recipNonce    []byte
+}
+
+func newTransaction(p protection, subject, recipient []byte) (*transaction, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &transaction{
+		protection:    p,
+		subject:       subject,
+		recipient:     recipient,
+		transactionID: id,
+	}, nil
+}
+
+// message returns a protected PKIMessage with the given body.
+func (t *transaction) message(bodyType int, content interface{}) ([]byte, error) {
+	contentDER, err := asn1.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	body, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: bodyType, IsCompound: true, Bytes: contentDER})
+	if err != nil {
+		return nil, err
+	}
+
+	t.senderNonce = make([]byte, 16)
+	if _, err := rand.Read(t.senderNonce); err != nil {
+		return nil, err
+	}
+	alg, err := t.protection.algorithm()
+	if err != nil {
+		return nil, err
+	}
+	sender, senderKID := t.protection.sender(t.subject)
+	header, err := asn1.Marshal(pkiHeader{
+		PVNO:          2,
+		Sender:        directoryName(sender),
+		Recipient:     directoryName(t.recipient),
+		MessageTime:   time.Now().UTC(),
+		ProtectionAlg: alg,
+		SenderKID:     senderKID,
+		TransactionID: t.transactionID,
+		SenderNonce:   t.senderNonce,
+		RecipNonce:    t.recipNonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	part, err := asn1.Marshal(protectedPart{
+		Header: asn1.RawValue{FullBytes: header},
+		Body:   asn1.RawValue{FullBytes: body},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sig, err := t.protection.protect(alg, part)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := pkiMessage{
+		Header:     asn1.RawValue{FullBytes: header},
+		Body:       asn1.RawValue{FullBytes: body},
+		Protection: asn1.BitString{Bytes: sig, BitLength: len(sig) * 8},
+	}
+	for _, cert := range t.protection.extraCerts() {
+		msg.ExtraCerts = append(msg.ExtraCerts, asn1.RawValue{FullBytes: cert.Raw})
+	}
+	return asn1.Marshal(msg)
+}
+
+// response parses and verifies a PKIMessage received in response to the last
+// message sent in the transaction, returning its body and extra
+// certificates. An error body is returned as a StatusError.
+func (t *transaction) response(data []byte, secret []byte, trusted []*x509.Certificate, expectedBodyType int) (asn1.RawValue, []*x509.Certificate, error) {
+	var msg pkiMessage
+	if rest, err := asn1.Unmarshal(data, &msg); err != nil {
+		return asn1.RawValue{}, nil, fmt.Errorf("failed to parse CMP response: %s", err)
+	} else if len(rest) > 0 {
+		return asn1.RawValue{}, nil, errors.New("trailing data after CMP response")
+	}
+	var header pkiHeader
+	if _, err := asn1.Unmarshal(msg.Header.FullBytes, &header); err != nil {
+		return asn1.RawValue{}, nil, fmt.Errorf("failed to parse CMP response header: %s", err)
+	}
+
+	var extraCerts []*x509.Certificate
+	for _, raw := range msg.ExtraCerts {
+		cert, err := x509.ParseCertificate(raw.FullBytes)
+		if err != nil {
+			return asn1.RawValue{}, nil, fmt.Errorf("failed to parse CMP response certificate: %s", err)
+		}
+		extraCerts = append(extraCerts, cert)
+	}
+
+	part, err := asn1.Marshal(protectedPart{Header: msg.Header, Body: msg.Body})
+	if err != nil {
+		return asn1.RawValue{}, nil, err
+	}
+	if err := verifyProtection(header, part, msg.Protection.RightAlign(), extraCerts, secret, trusted); err != nil {
+		return asn1.RawValue{}, nil, err
+	}
+
+	if header.PVNO != 2 && header.PVNO != 3 {
+		return asn1.RawValue{}, nil, fmt.Errorf("unsupported CMP version %d", header.PVNO)
+	}
+	if !bytes.Equal(header.TransactionID, t.transactionID) {
+		return asn1.RawValue{}, nil, errors.New("CMP response transactionID does not match the request")
+	}
+	if !bytes.Equal(header.RecipNonce, t.senderNonce) {
+		return asn1.RawValue{}, nil, errors.New("CMP response recipNonce does not match the request senderNonce")
+	}
+	t.recipNonce = header.SenderNonce
+
+	if msg.Body.Class != asn1.ClassContextSpecific {
+		return asn1.RawValue{}, nil, errors.New("failed to parse CMP response body")
+	}
+	switch msg.Body.Tag {
+	case expectedBodyType:
+	case bodyError:
+		var content errorMsgContent
+		if _, err := asn1.Unmarshal(msg.Body.Bytes, &content); err != nil {
+			return asn1.RawValue{}, nil, fmt.Errorf("failed to parse CMP error message: %s", err)
+		}
+		return asn1.RawValue{}, nil, content.PKIStatusInfo.statusError()
+	default:
+		return asn1.RawValue{}, nil, fmt.Errorf("unexpected CMP response body type %d", msg.Body.Tag)
+	}
+
+	return msg.Body, extraCerts, nil
+}
+
+// verifyProtection verifies the protection of a received message. MAC
+// protected messages are verified using the shared secret. Signature protected
+// messages are verified using the certificate identified by the sender of the
+// message, which is looked up in the extra certificates of the message and the
+// trusted certificates, and must chain to the trusted certificates. If there
+// are no trusted certificates the system roots are used.
+func verifyProtection(header pkiHeader, part, protection []byte, extraCerts []*x509.Certificate, secret []byte, trusted []*x509.Certificate) error {
+	alg := header.ProtectionAlg
+	if len(alg.Algorithm) == 0 || len(protection) == 0 {
+		return errors.New("CMP response is not protected")
+	}
+
+	if alg.Algorithm.Equal(oidPasswordBasedMAC) {
+		if secret == nil {
+			return errors.New("CMP response is MAC protected but no shared secret is configured")
+		}
+		mac, err := passwordBasedMAC(secret, alg, part)
+		if err != nil {
+			return err
+		}
+		if !hmac.Equal(mac, protection) {
+			return errors.New("CMP response MAC verification failed")
+		}
+		return nil
+	}
+
+	signer := findSigner(header, append(extraCerts, trusted...))
+	if signer == nil {
+		return errors.New("CMP response is signature protected but the signer certificate was not found")
+	}
+	var roots *x509.CertPool
+	if len(trusted) > 0 {
+		roots = x509.NewCertPool()
+		for _, cert := range trusted {
+			roots.AddCert(cert)
+		}
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range extraCerts {
+		intermediates.AddCert(cert)
+	}
+	if _, err := signer.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return fmt.Errorf("CMP response signer is not trusted: %s", err)
+	}
+	if err := verifySignature(signer, alg, part, protection); err != nil {
+		return fmt.Errorf("CMP response signature verification failed: %s", err)
+	}
+	return nil
+}
+
+// findSigner returns the certificate matching the sender name and key
+// identifier of the given header.
+func findSigner(header pkiHeader, certs []*x509.Certificate) *x509.Certificate {
+	for _, cert := range certs {
+		if header.Sender.Tag != 4 || !bytes.Equal(cert.RawSubject, header.Sender.Bytes) {
+			continue
+		}
+		if len(header.SenderKID) > 0 && len(cert.SubjectKeyId) > 0 && !bytes.Equal(header.SenderKID, cert.SubjectKeyId) {
+			continue
+		}
+		return cert
+	}
+	return nil
+}
+
+// certHash returns the hash of the certificate used to confirm it, computed
+// with the hash algorithm of its signature as required by RFC 4210 section
+// 5.3.18.
+func certHash(cert *x509.Certificate) []byte {
+	var h hash.Hash
+	switch cert.SignatureAlgorithm {
+	case x509.SHA384WithRSA, x509.ECDSAWithSHA384, x509.SHA384WithRSAPSS:
+		h = crypto.SHA384.New()
+	case x509.SHA512WithRSA, x509.ECDSAWithSHA512, x509.SHA512WithRSAPSS, x509.PureEd25519:
+		h = crypto.SHA512.New()
+	default:
+		h = sha256.New()
+	}
+	h.Write(cert.Raw)
+	return h.Sum(nil)
+}
+
+// buildChain returns the issued certificate followed by its issuers found
+// among the given certificates.
+func buildChain(leaf *x509.Certificate, certs []*x509.Certificate) []*x509.Certificate {
+	chain := []*x509.Certificate{leaf}
+	for cert := leaf; !bytes.Equal(cert.RawIssuer, cert.RawSubject); {
+		var issuer *x509.Certificate
+		for _, candidate := range certs {
+			if containsCertificate(chain, candidate) {
+				continue
+			}
+			if cert.CheckSignatureFrom(candidate) == nil {
+				issuer = candidate
+				break
+			}
+		}
+		if issuer == nil {
+			break
+		}
+		chain = append(chain, issuer)
+		cert = issuer
+	}
+	return chain
+}
+
+func containsCertificate(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
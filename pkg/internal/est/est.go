@@ -0,0 +1,268 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package est implements a client for Enrollment over Secure Transport
+// (RFC 7030) servers.
+package est
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+	corev1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+var _ Interface = &EST{}
+
+// ClientBuilder is a function type that returns a new Interface.
+// Can be used in tests to create a mock EST client.
+type ClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
+	issuer v1.GenericIssuer) (Interface, error)
+
+// Interface implements the EST operations used to obtain certificates from
+// an EST server.
+type Interface interface {
+	// CACerts returns the PEM encoded CA certificates of the EST server.
+	CACerts(ctx context.Context) (caPEM []byte, err error)
+
+	// SimpleEnroll requests a certificate for the PEM encoded certificate
+	// signing request, and returns the PEM encoded certificate chain.
+	SimpleEnroll(ctx context.Context, csrPEM []byte) (certPEM []byte, err error)
+
+	// SimpleReenroll renews a certificate for the PEM encoded certificate
+	// signing request, authenticating with the given certificate, and
+	// returns the PEM encoded certificate chain.
+	SimpleReenroll(ctx context.Context, csrPEM []byte, cert tls.Certificate) (certPEM []byte, err error)
+}
+
+// PendingError is returned if the EST server has accepted a request but not
+// issued the certificate yet.
+type PendingError struct {
+	// RetryAfter is the duration after which the request should be retried,
+	// as indicated by the server.
+	RetryAfter time.Duration
+}
+
+func (e *PendingError) Error() string {
+	return fmt.Sprintf("certificate enrollment is pending, retry after %s", e.RetryAfter)
+}
+
+// EST implements Interface and holds the configuration used to connect to an
+// EST server.
+type EST struct {
+	baseURL    string
+	rootCAs    *x509.CertPool
+	clientCert *tls.Certificate
+
+	username, password string
+
+	client *http.Client
+}
+
+// New returns a new EST client for the given issuer, reading the referenced
+// Secret resources from the given namespace.
+func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+	cfg := issuer.GetSpec().EST
+	if cfg == nil {
+		return nil, errors.New("EST config cannot be empty")
+	}
+
+	e := &EST{
+		baseURL: strings.TrimSuffix(cfg.Server, "/") + "/.well-known/est",
+	}
+	if cfg.Label != "" {
+		e.baseURL += "/" + cfg.Label
+	}
+
+	if len(cfg.CABundle) > 0 {
+		e.rootCAs = x509.NewCertPool()
+		if !e.rootCAs.AppendCertsFromPEM(cfg.CABundle) {
+			return nil, errors.New("error loading EST CA bundle")
+		}
+	}
+
+	if cfg.Auth != nil && cfg.Auth.BasicAuth != nil {
+		ref := cfg.Auth.BasicAuth.PasswordSecretRef
+		secret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		password, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("no data for %q in secret '%s/%s'", ref.Key, namespace, ref.Name)
+		}
+		e.username = cfg.Auth.BasicAuth.Username
+		e.password = strings.TrimSpace(string(password))
+	}
+
+	if cfg.Auth != nil && cfg.Auth.ClientCertificate != nil {
+		name := cfg.Auth.ClientCertificate.SecretRef.Name
+		secret, err := secretsLister.Secrets(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("error loading EST client certificate from secret '%s/%s': %s", namespace, name, err)
+		}
+		e.clientCert = &cert
+	}
+
+	e.client = e.newHTTPClient(e.clientCert)
+
+	return e, nil
+}
+
+func (e *EST) newHTTPClient(clientCert *tls.Certificate) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: e.rootCAs}
+	if clientCert != nil {
+		transport.TLSClientConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport,
+	}
+}
+
+// CACerts retrieves the CA certificates of the EST server from the
+// `/cacerts` endpoint.
+func (e *EST) CACerts(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.baseURL+"/cacerts", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	certs, err := e.do(e.client, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve EST CA certificates: %w", err)
+	}
+
+	var caPEM []byte
+	for _, cert := range certs {
+		certPEM, err := pki.EncodeX509(cert)
+		if err != nil {
+			return nil, err
+		}
+		caPEM = append(caPEM, certPEM...)
+	}
+	return caPEM, nil
+}
+
+// SimpleEnroll requests a certificate from the `/simpleenroll` endpoint.
+func (e *EST) SimpleEnroll(ctx context.Context, csrPEM []byte) ([]byte, error) {
+	return e.enroll(ctx, e.client, "/simpleenroll", csrPEM)
+}
+
+// SimpleReenroll renews a certificate using the `/simplereenroll` endpoint,
+// authenticating with the given certificate instead of any client
+// certificate configured on the issuer.
+func (e *EST) SimpleReenroll(ctx context.Context, csrPEM []byte, cert tls.Certificate) ([]byte, error) {
+	return e.enroll(ctx, e.newHTTPClient(&cert), "/simplereenroll", csrPEM)
+}
+
+func (e *EST) enroll(ctx context.Context, client *http.Client, operation string, csrPEM []byte) ([]byte, error) {
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode CSR for signing: %s", err)
+	}
+
+	body := base64.StdEncoding.EncodeToString(csr.Raw)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+operation, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/pkcs10")
+	req.Header.Set("Content-Transfer-Encoding", "base64")
+
+	certs, err := e.do(client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Order the issued certificate first, followed by any chain returned.
+	for i, cert := range certs {
+		if ok, _ := pki.PublicKeysEqual(cert.PublicKey, csr.PublicKey); ok {
+			certs[0], certs[i] = certs[i], certs[0]
+			break
+		}
+	}
+	var certPEM []byte
+	for _, cert := range certs {
+		b, err := pki.EncodeX509(cert)
+		if err != nil {
+			return nil, err
+		}
+		certPEM = append(certPEM, b...)
+	}
+	return certPEM, nil
+}
+
+// do sends the request to the EST server, and decodes the certificates in
+// the base64 encoded PKCS#7 certs-only response.
+func (e *EST) do(client *http.Client, req *http.Request) ([]*x509.Certificate, error) {
+	if e.username != "" {
+		req.SetBasicAuth(e.username, e.password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusAccepted:
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return nil, &PendingError{RetryAfter: time.Duration(retryAfter) * time.Second}
+	default:
+		return nil, fmt.Errorf("EST server responded with status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(body)), ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode EST response: %s", err)
+	}
+	p7, err := pkcs7.Parse(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse EST response: %s", err)
+	}
+	if len(p7.Certificates) == 0 {
+		return nil, errors.New("EST response does not contain any certificates")
+	}
+	return p7.Certificates, nil
+}
@@ -0,0 +1,383 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package est
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+	corev1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+	testlisters "github.com/jetstack/cert-manager/test/unit/listers"
+)
+
+// estServer is a minimal EST server used as a stand-in for a real EST
+// deployment in tests.
+type estServer struct {
+	t *testing.T
+
+	label              string
+	caCert             *x509.Certificate
+	caKey              crypto.Signer
+	username, password string
+	requireClientCert  bool
+	pending            bool
+}
+
+func (s *estServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	prefix := "/.well-known/est"
+	if s.label != "" {
+		prefix += "/" + s.label
+	}
+	if !strings.HasPrefix(r.URL.Path, prefix+"/") {
+		http.NotFound(w, r)
+		return
+	}
+	operation := strings.TrimPrefix(r.URL.Path, prefix)
+
+	if operation == "/cacerts" {
+		s.writeCerts(w, s.caCert)
+		return
+	}
+
+	if operation != "/simpleenroll" && operation != "/simplereenroll" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if s.username != "" {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != s.username || password != s.password {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	if (s.requireClientCert || operation == "/simplereenroll") && len(r.TLS.PeerCertificates) == 0 {
+		http.Error(w, "client certificate required", http.StatusUnauthorized)
+		return
+	}
+
+	if s.pending {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	der, err := base64.StdEncoding.DecodeString(string(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	_, cert, err := pki.SignCertificate(template, s.caCert, csr.PublicKey, s.caKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Return the chain with the CA first, to ensure the client orders the
+	// issued certificate first.
+	s.writeCerts(w, s.caCert, cert)
+}
+
+func (s *estServer) writeCerts(w http.ResponseWriter, certs ...*x509.Certificate) {
+	var der []byte
+	for _, cert := range certs {
+		der = append(der, cert.Raw...)
+	}
+	p7, err := pkcs7.DegenerateCertificate(der)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	w.Header().Set("Content-Type", "application/pkcs7-mime")
+	w.Header().Set("Content-Transfer-Encoding", "base64")
+	w.Write([]byte(base64.StdEncoding.EncodeToString(p7)))
+}
+
+func generateCA(t *testing.T) (*x509.Certificate, crypto.Signer) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "est-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	_, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func generateCSR(t *testing.T) ([]byte, crypto.Signer) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := pki.EncodeCSR(&x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "example.com"},
+		DNSNames: []string{"example.com"},
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), key
+}
+
+// generateKeyPair returns a PEM encoded certificate and private key signed
+// by the given CA.
+func generateKeyPair(t *testing.T, caCert *x509.Certificate, caKey crypto.Signer) ([]byte, []byte) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certPEM, _, err := pki.SignCertificate(template, caCert, key.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodePKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM, keyPEM
+}
+
+func TestEST(t *testing.T) {
+	caCert, caKey := generateCA(t)
+	clientCertPEM, clientKeyPEM := generateKeyPair(t, caCert, caKey)
+	csrPEM, csrKey := generateCSR(t)
+
+	clientCert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passwordSecret := gen.Secret("est-password",
+		gen.SetSecretNamespace("test-ns"),
+		gen.SetSecretData(map[string][]byte{"password": []byte("secret\n")}),
+	)
+	clientCertSecret := gen.Secret("est-client",
+		gen.SetSecretNamespace("test-ns"),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSCertKey:       clientCertPEM,
+			corev1.TLSPrivateKeyKey: clientKeyPEM,
+		}),
+	)
+
+	basicAuth := &cmapi.ESTAuth{
+		BasicAuth: &cmapi.ESTBasicAuth{
+			Username: "user",
+			PasswordSecretRef: cmmeta.SecretKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{Name: "est-password"},
+				Key:                  "password",
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		server *estServer
+		auth   *cmapi.ESTAuth
+		label  string
+		// reenroll calls SimpleReenroll instead of SimpleEnroll.
+		reenroll bool
+
+		expectedErr     string
+		expectedPending bool
+	}{
+		"enroll without authentication": {
+			server: &estServer{},
+		},
+		"enroll with a label": {
+			server: &estServer{label: "arbitrary"},
+			label:  "arbitrary",
+		},
+		"enroll with basic authentication": {
+			server: &estServer{username: "user", password: "secret"},
+			auth:   basicAuth,
+		},
+		"enroll with incorrect basic authentication": {
+			server:      &estServer{username: "user", password: "other"},
+			auth:        basicAuth,
+			expectedErr: "EST server responded with status 401: unauthorized",
+		},
+		"enroll with a client certificate": {
+			server: &estServer{requireClientCert: true},
+			auth: &cmapi.ESTAuth{
+				ClientCertificate: &cmapi.ESTClientCertificateAuth{
+					SecretRef: cmmeta.LocalObjectReference{Name: "est-client"},
+				},
+			},
+		},
+		"enroll without a required client certificate": {
+			server:      &estServer{requireClientCert: true},
+			expectedErr: "EST server responded with status 401: client certificate required",
+		},
+		"reenroll with the existing certificate": {
+			server:   &estServer{},
+			reenroll: true,
+		},
+		"enrollment pending": {
+			server:          &estServer{pending: true},
+			expectedPending: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.server.t = t
+			test.server.caCert = caCert
+			test.server.caKey = caKey
+
+			srv := httptest.NewUnstartedServer(test.server)
+			srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+			srv.StartTLS()
+			defer srv.Close()
+
+			serverCAPEM, err := pki.EncodeX509(srv.Certificate())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			issuer := gen.Issuer("est-issuer",
+				gen.SetIssuerNamespace("test-ns"),
+				gen.SetIssuerEST(cmapi.ESTIssuer{
+					Server:   srv.URL,
+					Label:    test.label,
+					CABundle: serverCAPEM,
+					Auth:     test.auth,
+				}),
+			)
+
+			secrets := map[string]*corev1.Secret{
+				passwordSecret.Name:   passwordSecret,
+				clientCertSecret.Name: clientCertSecret,
+			}
+			secretsLister := testlisters.NewFakeSecretLister()
+			secretsLister.SecretsFn = func(string) corelisters.SecretNamespaceLister {
+				return testlisters.FakeSecretNamespaceListerFrom(testlisters.NewFakeSecretNamespaceLister(),
+					func(f *testlisters.FakeSecretNamespaceLister) {
+						f.GetFn = func(name string) (*corev1.Secret, error) {
+							return secrets[name], nil
+						}
+					},
+				)
+			}
+
+			c, err := New("test-ns", secretsLister, issuer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			caPEM, err := c.CACerts(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(caPEM) != string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw})) {
+				t.Errorf("unexpected CA certificates: %s", caPEM)
+			}
+
+			var certPEM []byte
+			if test.reenroll {
+				certPEM, err = c.SimpleReenroll(context.TODO(), csrPEM, clientCert)
+			} else {
+				certPEM, err = c.SimpleEnroll(context.TODO(), csrPEM)
+			}
+
+			var pendingErr *PendingError
+			if test.expectedPending != errors.As(err, &pendingErr) {
+				t.Fatalf("expected pending error=%t, got: %v", test.expectedPending, err)
+			}
+			if test.expectedPending {
+				if pendingErr.RetryAfter != 30*time.Second {
+					t.Errorf("unexpected retry after duration: %s", pendingErr.RetryAfter)
+				}
+				return
+			}
+
+			if test.expectedErr != "" {
+				if err == nil || err.Error() != test.expectedErr {
+					t.Fatalf("expected error %q, got: %v", test.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			certs, err := pki.DecodeX509CertificateChainBytes(certPEM)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(certs) != 2 {
+				t.Fatalf("expected 2 certificates in chain, got %d", len(certs))
+			}
+			if ok, _ := pki.PublicKeysEqual(certs[0].PublicKey, csrKey.Public()); !ok {
+				t.Errorf("expected the issued certificate to be first in the chain")
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake contains a fake EST client for use in tests
+package fake
+
+import (
+	"context"
+	"crypto/tls"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/internal/est"
+)
+
+var _ est.Interface = &EST{}
+
+type EST struct {
+	NewFn            func(string, corelisters.SecretLister, v1.GenericIssuer) (*EST, error)
+	CACertsFn        func(context.Context) ([]byte, error)
+	SimpleEnrollFn   func(context.Context, []byte) ([]byte, error)
+	SimpleReenrollFn func(context.Context, []byte, tls.Certificate) ([]byte, error)
+}
+
+// New returns a new fake EST client
+func New() *EST {
+	e := &EST{
+		CACertsFn: func(context.Context) ([]byte, error) {
+			return nil, nil
+		},
+		SimpleEnrollFn: func(context.Context, []byte) ([]byte, error) {
+			return nil, nil
+		},
+		SimpleReenrollFn: func(context.Context, []byte, tls.Certificate) ([]byte, error) {
+			return nil, nil
+		},
+	}
+
+	e.NewFn = func(string, corelisters.SecretLister, v1.GenericIssuer) (*EST, error) {
+		return e, nil
+	}
+
+	return e
+}
+
+// CACerts implements `est.Interface`.
+func (e *EST) CACerts(ctx context.Context) ([]byte, error) {
+	return e.CACertsFn(ctx)
+}
+
+// SimpleEnroll implements `est.Interface`.
+func (e *EST) SimpleEnroll(ctx context.Context, csrPEM []byte) ([]byte, error) {
+	return e.SimpleEnrollFn(ctx, csrPEM)
+}
+
+// SimpleReenroll implements `est.Interface`.
+func (e *EST) SimpleReenroll(ctx context.Context, csrPEM []byte, cert tls.Certificate) ([]byte, error) {
+	return e.SimpleReenrollFn(ctx, csrPEM, cert)
+}
+
+// WithCACerts sets the fake EST client's CACerts function.
+func (e *EST) WithCACerts(caPEM []byte, err error) *EST {
+	e.CACertsFn = func(context.Context) ([]byte, error) {
+		return caPEM, err
+	}
+	return e
+}
+
+// WithSimpleEnroll sets the fake EST client's SimpleEnroll function.
+func (e *EST) WithSimpleEnroll(certPEM []byte, err error) *EST {
+	e.SimpleEnrollFn = func(context.Context, []byte) ([]byte, error) {
+		return certPEM, err
+	}
+	return e
+}
+
+// WithSimpleReenroll sets the fake EST client's SimpleReenroll function.
+func (e *EST) WithSimpleReenroll(certPEM []byte, err error) *EST {
+	e.SimpleReenrollFn = func(context.Context, []byte, tls.Certificate) ([]byte, error) {
+		return certPEM, err
+	}
+	return e
+}
+
+// WithNew sets the fake EST client's New function.
+func (e *EST) WithNew(f func(string, corelisters.SecretLister, v1.GenericIssuer) (*EST, error)) *EST {
+	e.NewFn = f
+	return e
+}
+
+// New calls NewFn and returns a pointer to the fake EST client.
+func (e *EST) New(ns string, sl corelisters.SecretLister, iss v1.GenericIssuer) (est.Interface, error) {
+	_, err := e.NewFn(ns, sl, iss)
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake contains a fake SCEP client for use in tests
+package fake
+
+import (
+	"context"
+	"crypto/x509"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/internal/scep"
+)
+
+var _ scep.Interface = &SCEP{}
+
+type SCEP struct {
+	NewFn       func(string, corelisters.SecretLister, v1.GenericIssuer) (*SCEP, error)
+	GetCACapsFn func(context.Context) (scep.Capabilities, error)
+	GetCACertFn func(context.Context) ([]*x509.Certificate, error)
+	PKCSReqFn   func(context.Context, *scep.Request) (*scep.Response, error)
+	CertPollFn  func(context.Context, *scep.Request) (*scep.Response, error)
+}
+
+// New returns a new fake SCEP client
+func New() *SCEP {
+	s := &SCEP{
+		GetCACapsFn: func(context.Context) (scep.Capabilities, error) {
+			return nil, nil
+		},
+		GetCACertFn: func(context.Context) ([]*x509.Certificate, error) {
+			return nil, nil
+		},
+		PKCSReqFn: func(context.Context, *scep.Request) (*scep.Response, error) {
+			return nil, nil
+		},
+		CertPollFn: func(context.Context, *scep.Request) (*scep.Response, error) {
+			return nil, nil
+		},
+	}
+
+	s.NewFn = func(string, corelisters.SecretLister, v1.GenericIssuer) (*SCEP, error) {
+		return s, nil
+	}
+
+	return s
+}
+
+// GetCACaps implements `scep.Interface`.
+func (s *SCEP) GetCACaps(ctx context.Context) (scep.Capabilities, error) {
+	return s.GetCACapsFn(ctx)
+}
+
+// GetCACert implements `scep.Interface`.
+func (s *SCEP) GetCACert(ctx context.Context) ([]*x509.Certificate, error) {
+	return s.GetCACertFn(ctx)
+}
+
+// PKCSReq implements `scep.Interface`.
+func (s *SCEP) PKCSReq(ctx context.Context, req *scep.Request) (*scep.Response, error) {
+	return s.PKCSReqFn(ctx, req)
+}
+
+// CertPoll implements `scep.Interface`.
+func (s *SCEP) CertPoll(ctx context.Context, req *scep.Request) (*scep.Response, error) {
+	return s.CertPollFn(ctx, req)
+}
+
+// WithGetCACaps sets the fake SCEP client's GetCACaps function.
+func (s *SCEP) WithGetCACaps(caps scep.Capabilities, err error) *SCEP {
+	s.GetCACapsFn = func(context.Context) (scep.Capabilities, error) {
+		return caps, err
+	}
+	return s
+}
+
+// WithGetCACert sets the fake SCEP client's GetCACert function.
+func (s *SCEP) WithGetCACert(certs []*x509.Certificate, err error) *SCEP {
+	s.GetCACertFn = func(context.Context) ([]*x509.Certificate, error) {
+		return certs, err
+	}
+	return s
+}
+
+// WithPKCSReq sets the fake SCEP client's PKCSReq function.
+func (s *SCEP) WithPKCSReq(resp *scep.Response, err error) *SCEP {
+	s.PKCSReqFn = func(context.Context, *scep.Request) (*scep.Response, error) {
+		return resp, err
+	}
+	return s
+}
+
+// WithCertPoll sets the fake SCEP client's CertPoll function.
+func (s *SCEP) WithCertPoll(resp *scep.Response, err error) *SCEP {
+	s.CertPollFn = func(context.Context, *scep.Request) (*scep.Response, error) {
+		return resp, err
+	}
+	return s
+}
+
+// WithNew sets the fake SCEP client's New function.
+func (s *SCEP) WithNew(f func(string, corelisters.SecretLister, v1.GenericIssuer) (*SCEP, error)) *SCEP {
+	s.NewFn = f
+	return s
+}
+
+// New calls NewFn and returns a pointer to the fake SCEP client.
+func (s *SCEP) New(ns string, sl corelisters.SecretLister, iss v1.GenericIssuer) (scep.Interface, error) {
+	_, err := s.NewFn(ns, sl, iss)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
@@ -0,0 +1,380 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scep
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// PKIStatus is the status of a SCEP CertRep message.
+type PKIStatus string
+
+const (
+	// StatusSuccess means the request was granted.
+	StatusSuccess PKIStatus = "0"
+	// StatusFailure means the request was rejected.
+	StatusFailure PKIStatus = "2"
+	// StatusPending means the request awaits manual approval.
+	StatusPending PKIStatus = "3"
+)
+
+// FailInfo is the reason a SCEP request was rejected.
+type FailInfo string
+
+const (
+	// BadAlg means an unrecognized or unsupported algorithm was used.
+	BadAlg FailInfo = "0"
+	// BadMessageCheck means the integrity check of the message failed.
+	BadMessageCheck FailInfo = "1"
+	// BadRequest means the transaction was not permitted or supported.
+	BadRequest FailInfo = "2"
+	// BadTime means the signingTime attribute was not sufficiently close to
+	// the system time.
+	BadTime FailInfo = "3"
+	// BadCertID means no certificate could be identified matching the
+	// provided criteria.
+	BadCertID FailInfo = "4"
+)
+
+// Reason returns a CamelCase reason for the failInfo code, suitable for use
+// as the reason of a condition or event.
+func (f FailInfo) Reason() string {
+	switch f {
+	case BadAlg:
+		return "BadAlg"
+	case BadMessageCheck:
+		return "BadMessageCheck"
+	case BadRequest:
+		return "BadRequest"
+	case BadTime:
+		return "BadTime"
+	case BadCertID:
+		return "BadCertID"
+	default:
+		return "UnknownFailInfo"
+	}
+}
+
+const (
+	messageTypeCertRep  = "3"
+	messageTypePKCSReq  = "19"
+	messageTypeCertPoll = "20"
+)
+
+var (
+	oidMessageType       = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 2}
+	oidPKIStatus         = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 3}
+	oidFailInfo          = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 4}
+	oidSenderNonce       = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 5}
+	oidRecipientNonce    = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 6}
+	oidTransactionID     = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 7}
+	oidChallengePassword = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 7}
+	oidSHA256WithRSA     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}
+)
+
+// encryptMu guards pkcs7.ContentEncryptionAlgorithm, which is a package level
+// variable used by pkcs7.Encrypt.
+var encryptMu sync.Mutex
+
+// pkiMessage is a signed and encrypted SCEP request.
+type pkiMessage struct {
+	req         *Request
+	senderNonce []byte
+	signer      *x509.Certificate
+
+	raw []byte
+}
+
+// newPKIMessage encrypts the content for the SCEP server and signs it with
+// the private key of the request.
+func newPKIMessage(req *Request, messageType string, content []byte) (*pkiMessage, error) {
+	if len(req.CACerts) == 0 {
+		return nil, errors.New("no SCEP CA certificates given")
+	}
+
+	envelope, err := encrypt(content, recipient(req.CACerts), req.Capabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := selfSignedSigner(req.CSR, req.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	senderNonce := make([]byte, 16)
+	if _, err := rand.Read(senderNonce); err != nil {
+		return nil, err
+	}
+
+	sd, err := pkcs7.NewSignedData(envelope)
+	if err != nil {
+		return nil, err
+	}
+	if req.Capabilities.Has("SHA-256") || req.Capabilities.Has("SCEPStandard") {
+		sd.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+	}
+	err = sd.AddSigner(signer, req.PrivateKey, pkcs7.SignerInfoConfig{
+		ExtraSignedAttributes: []pkcs7.Attribute{
+			{Type: oidMessageType, Value: messageType},
+			{Type: oidTransactionID, Value: req.TransactionID},
+			{Type: oidSenderNonce, Value: senderNonce},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	raw, err := sd.Finish()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pkiMessage{
+		req:         req,
+		senderNonce: senderNonce,
+		signer:      signer,
+		raw:         raw,
+	}, nil
+}
+
+// parseCertRep verifies the CertRep message returned by the SCEP server in
+// response to the message, and decrypts any issued certificates.
+func (m *pkiMessage) parseCertRep(data []byte) (*Response, error) {
+	p7, err := pkcs7.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SCEP response: %s", err)
+	}
+
+	// The response is signed by the CA or an RA, which may be omitted from
+	// the response.
+	p7.Certificates = append(p7.Certificates, m.req.CACerts...)
+	if err := p7.Verify(); err != nil {
+		return nil, fmt.Errorf("failed to verify SCEP response: %s", err)
+	}
+	if !containsCertificate(m.req.CACerts, p7.GetOnlySigner()) {
+		return nil, errors.New("SCEP response is not signed by the CA or an RA")
+	}
+
+	var messageType, transactionID, status string
+	var recipientNonce []byte
+	for _, attr := range []struct {
+		oid asn1.ObjectIdentifier
+		out interface{}
+	}{
+		{oidMessageType, &messageType},
+		{oidTransactionID, &transactionID},
+		{oidPKIStatus, &status},
+		{oidRecipientNonce, &recipientNonce},
+	} {
+		if err := p7.UnmarshalSignedAttribute(attr.oid, attr.out); err != nil {
+			return nil, fmt.Errorf("failed to read SCEP response attribute %s: %s", attr.oid, err)
+		}
+	}
+
+	switch {
+	case messageType != messageTypeCertRep:
+		return nil, fmt.Errorf("unexpected SCEP response message type %q", messageType)
+	case transactionID != m.req.TransactionID:
+		return nil, fmt.Errorf("unexpected SCEP response transaction ID %q", transactionID)
+	case !bytes.Equal(recipientNonce, m.senderNonce):
+		return nil, errors.New("SCEP response recipient nonce does not match the request")
+	}
+
+	resp := &Response{Status: PKIStatus(status)}
+	switch resp.Status {
+	case StatusPending:
+		return resp, nil
+
+	case StatusFailure:
+		var failInfo string
+		if err := p7.UnmarshalSignedAttribute(oidFailInfo, &failInfo); err != nil {
+			return nil, fmt.Errorf("failed to read SCEP response failInfo: %s", err)
+		}
+		resp.FailInfo = FailInfo(failInfo)
+		return resp, nil
+
+	case StatusSuccess:
+		envelope, err := pkcs7.Parse(p7.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SCEP response content: %s", err)
+		}
+		content, err := envelope.Decrypt(m.signer, m.req.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt SCEP response content: %s", err)
+		}
+		certs, err := pkcs7.Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SCEP response certificates: %s", err)
+		}
+		if len(certs.Certificates) == 0 {
+			return nil, errors.New("SCEP response does not contain any certificates")
+		}
+
+		// Order the issued certificate first.
+		resp.Certificates = certs.Certificates
+		for i, cert := range resp.Certificates {
+			if ok, _ := pki.PublicKeysEqual(cert.PublicKey, m.req.CSR.PublicKey); ok {
+				resp.Certificates[0], resp.Certificates[i] = resp.Certificates[i], resp.Certificates[0]
+				break
+			}
+		}
+		return resp, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected SCEP response status %q", status)
+	}
+}
+
+// recipient returns the certificate that messages to the SCEP server should
+// be encrypted for. This is the RA certificate capable of key encipherment if
+// there is one, otherwise the CA certificate.
+func recipient(caCerts []*x509.Certificate) *x509.Certificate {
+	for _, cert := range caCerts {
+		if !cert.IsCA && cert.KeyUsage&x509.KeyUsageKeyEncipherment != 0 {
+			return cert
+		}
+	}
+	return caCerts[0]
+}
+
+// encrypt encrypts the content for the recipient, using AES if supported by
+// the SCEP server. Servers which do not support AES fall back to DES, since
+// triple DES encryption is not supported by the pkcs7 package.
+func encrypt(content []byte, recipient *x509.Certificate, caps Capabilities) ([]byte, error) {
+	encryptMu.Lock()
+	defer encryptMu.Unlock()
+
+	pkcs7.ContentEncryptionAlgorithm = pkcs7.EncryptionAlgorithmDESCBC
+	if caps.Has("AES") || caps.Has("SCEPStandard") {
+		pkcs7.ContentEncryptionAlgorithm = pkcs7.EncryptionAlgorithmAES128CBC
+	}
+	return pkcs7.Encrypt(content, []*x509.Certificate{recipient})
+}
+
+// selfSignedSigner returns a short lived self-signed certificate for the
+// subject and key of the CSR, used to sign SCEP messages as described in
+// RFC 8894 section 2.3.
+func selfSignedSigner(csr *x509.CertificateRequest, key *rsa.PrivateKey) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		RawSubject:   csr.RawSubject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SCEP signer certificate: %s", err)
+	}
+	return x509.ParseCertificate(der)
+}
+
+type certificationRequestInfo struct {
+	Version       int
+	Subject       asn1.RawValue
+	PublicKey     asn1.RawValue
+	RawAttributes []asn1.RawValue `asn1:"tag:0"`
+}
+
+type certificationRequest struct {
+	Info               asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          asn1.BitString
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []interface{} `asn1:"set"`
+}
+
+// addChallengePassword returns the DER encoded CSR with the challenge
+// password attribute added, re-signed with the given private key.
+func addChallengePassword(csr *x509.CertificateRequest, key *rsa.PrivateKey, password string) ([]byte, error) {
+	var info certificationRequestInfo
+	if _, err := asn1.Unmarshal(csr.RawTBSCertificateRequest, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse CSR: %s", err)
+	}
+
+	passwordAttr, err := asn1.Marshal(attribute{Type: oidChallengePassword, Values: []interface{}{password}})
+	if err != nil {
+		return nil, err
+	}
+	info.RawAttributes = append(info.RawAttributes, asn1.RawValue{FullBytes: passwordAttr})
+
+	rawInfo, err := asn1.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+
+	hashed := crypto.SHA256.New()
+	hashed.Write(rawInfo)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed.Sum(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign CSR: %s", err)
+	}
+
+	return asn1.Marshal(certificationRequest{
+		Info: asn1.RawValue{FullBytes: rawInfo},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidSHA256WithRSA,
+			Parameters: asn1.NullRawValue,
+		},
+		Signature: asn1.BitString{Bytes: signature, BitLength: len(signature) * 8},
+	})
+}
+
+// issuerAndSubject returns the content of a CertPoll message for the CSR.
+func issuerAndSubject(ca *x509.Certificate, csr *x509.CertificateRequest) ([]byte, error) {
+	return asn1.Marshal(struct {
+		Issuer  asn1.RawValue
+		Subject asn1.RawValue
+	}{
+		Issuer:  asn1.RawValue{FullBytes: ca.RawSubject},
+		Subject: asn1.RawValue{FullBytes: csr.RawSubject},
+	})
+}
+
+func containsCertificate(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	if cert == nil {
+		return false
+	}
+	for _, c := range certs {
+		if bytes.Equal(c.Raw, cert.Raw) {
+			return true
+		}
+	}
+	return false
+}
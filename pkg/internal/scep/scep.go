@@ -0,0 +1,307 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scep implements a client for Simple Certificate Enrollment Protocol
+// (RFC 8894) servers.
+package scep
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var _ Interface = &SCEP{}
+
+// ClientBuilder is a function type that returns a new Interface.
+// Can be used in tests to create a mock SCEP client.
+type ClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
+	issuer v1.GenericIssuer) (Interface, error)
+
+// Interface implements the SCEP operations used to obtain certificates from a
+// SCEP server.
+type Interface interface {
+	// GetCACaps returns the capabilities advertised by the SCEP server.
+	GetCACaps(ctx context.Context) (Capabilities, error)
+
+	// GetCACert returns the CA certificate of the SCEP server, followed by any
+	// registration authority (RA) certificates.
+	GetCACert(ctx context.Context) ([]*x509.Certificate, error)
+
+	// PKCSReq submits a certificate signing request to the SCEP server.
+	PKCSReq(ctx context.Context, req *Request) (*Response, error)
+
+	// CertPoll polls the SCEP server for the result of a certificate signing
+	// request which was previously reported as pending.
+	CertPoll(ctx context.Context, req *Request) (*Response, error)
+}
+
+// Capabilities are the capabilities advertised by a SCEP server in response
+// to a GetCACaps request.
+type Capabilities []string
+
+// Has returns true if the capability is advertised by the SCEP server.
+// Capabilities are compared case insensitively.
+func (c Capabilities) Has(capability string) bool {
+	for _, cc := range c {
+		if strings.EqualFold(cc, capability) {
+			return true
+		}
+	}
+	return false
+}
+
+// Request contains the information needed to send a PKCSReq or CertPoll
+// message to a SCEP server.
+type Request struct {
+	// TransactionID identifies the enrollment transaction. It must be the same
+	// for a PKCSReq message and any subsequent CertPoll messages.
+	TransactionID string
+
+	// CSR is the certificate signing request to enroll.
+	CSR *x509.CertificateRequest
+
+	// PrivateKey is the private key of the certificate signing request. It is
+	// used to sign the SCEP messages and decrypt the issued certificate.
+	PrivateKey *rsa.PrivateKey
+
+	// CACerts are the CA and RA certificates returned by GetCACert.
+	CACerts []*x509.Certificate
+
+	// Capabilities are the capabilities returned by GetCACaps.
+	Capabilities Capabilities
+}
+
+// Response is the result of a PKCSReq or CertPoll message.
+type Response struct {
+	// Status is the pkiStatus of the response.
+	Status PKIStatus
+
+	// FailInfo is the reason the request failed, if Status is FAILURE.
+	FailInfo FailInfo
+
+	// Certificates are the certificates returned by the SCEP server if
+	// Status is SUCCESS, with the issued certificate first.
+	Certificates []*x509.Certificate
+}
+
+// TransactionID returns the transaction ID for the given certificate signing
+// request, which is the hex encoded SHA-256 hash of its public key as
+// recommended by RFC 8894 section 3.2.1.1.
+func TransactionID(csr *x509.CertificateRequest) string {
+	sum := sha256.Sum256(csr.RawSubjectPublicKeyInfo)
+	return hex.EncodeToString(sum[:])
+}
+
+// SCEP implements Interface and holds the configuration used to connect to a
+// SCEP server.
+type SCEP struct {
+	url               string
+	challengePassword string
+
+	client *http.Client
+}
+
+// New returns a new SCEP client for the given issuer, reading the referenced
+// challenge password Secret from the given namespace.
+func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+	cfg := issuer.GetSpec().SCEP
+	if cfg == nil {
+		return nil, errors.New("SCEP config cannot be empty")
+	}
+
+	s := &SCEP{
+		url: cfg.URL,
+	}
+
+	if ref := cfg.ChallengePasswordSecretRef; ref != nil {
+		secret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		password, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("no data for %q in secret '%s/%s'", ref.Key, namespace, ref.Name)
+		}
+		s.challengePassword = strings.TrimSpace(string(password))
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(cfg.CABundle) > 0 {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(cfg.CABundle) {
+			return nil, errors.New("error loading SCEP CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	}
+	s.client = &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport,
+	}
+
+	return s, nil
+}
+
+// GetCACaps retrieves the capabilities of the SCEP server.
+func (s *SCEP) GetCACaps(ctx context.Context) (Capabilities, error) {
+	body, _, err := s.do(ctx, http.MethodGet, "GetCACaps", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve SCEP server capabilities: %w", err)
+	}
+	return Capabilities(strings.Fields(string(body))), nil
+}
+
+// GetCACert retrieves the CA certificate of the SCEP server, and any RA
+// certificates.
+func (s *SCEP) GetCACert(ctx context.Context) ([]*x509.Certificate, error) {
+	body, contentType, err := s.do(ctx, http.MethodGet, "GetCACert", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve SCEP CA certificate: %w", err)
+	}
+
+	switch contentType {
+	case "application/x-x509-ca-cert":
+		cert, err := x509.ParseCertificate(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SCEP CA certificate: %s", err)
+		}
+		return []*x509.Certificate{cert}, nil
+
+	case "application/x-x509-ca-ra-cert":
+		p7, err := pkcs7.Parse(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SCEP CA certificates: %s", err)
+		}
+		if len(p7.Certificates) == 0 {
+			return nil, errors.New("SCEP server did not return any CA certificates")
+		}
+		// Order the CA certificate first, followed by the RA certificates.
+		for i, cert := range p7.Certificates {
+			if cert.IsCA {
+				p7.Certificates[0], p7.Certificates[i] = p7.Certificates[i], p7.Certificates[0]
+				break
+			}
+		}
+		return p7.Certificates, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected content type for SCEP CA certificate: %q", contentType)
+	}
+}
+
+// PKCSReq submits the certificate signing request to the SCEP server. The
+// challenge password of the issuer, if any, is added to the request.
+func (s *SCEP) PKCSReq(ctx context.Context, req *Request) (*Response, error) {
+	csr := req.CSR.Raw
+	if s.challengePassword != "" {
+		var err error
+		csr, err = addChallengePassword(req.CSR, req.PrivateKey, s.challengePassword)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.pkiOperation(ctx, req, messageTypePKCSReq, csr)
+}
+
+// CertPoll polls the SCEP server for the certificate of a pending request.
+func (s *SCEP) CertPoll(ctx context.Context, req *Request) (*Response, error) {
+	content, err := issuerAndSubject(req.CACerts[0], req.CSR)
+	if err != nil {
+		return nil, err
+	}
+	return s.pkiOperation(ctx, req, messageTypeCertPoll, content)
+}
+
+func (s *SCEP) pkiOperation(ctx context.Context, req *Request, messageType string, content []byte) (*Response, error) {
+	msg, err := newPKIMessage(req, messageType, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SCEP message: %s", err)
+	}
+
+	method := http.MethodGet
+	if req.Capabilities.Has("POSTPKIOperation") {
+		method = http.MethodPost
+	}
+	body, _, err := s.do(ctx, method, "PKIOperation", msg.raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.parseCertRep(body)
+}
+
+// do sends a request for the given SCEP operation, returning the response
+// body and content type.
+func (s *SCEP) do(ctx context.Context, method, operation string, message []byte) ([]byte, string, error) {
+	u, err := url.Parse(s.url)
+	if err != nil {
+		return nil, "", err
+	}
+	query := u.Query()
+	query.Set("operation", operation)
+
+	var reqBody io.Reader
+	switch {
+	case method == http.MethodPost:
+		reqBody = bytes.NewReader(message)
+	case message != nil:
+		query.Set("message", base64.StdEncoding.EncodeToString(message))
+	}
+	u.RawQuery = query.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return nil, "", err
+	}
+	if method == http.MethodPost {
+		httpReq.Header.Set("Content-Type", "application/x-pki-message")
+	}
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("SCEP server responded with status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	contentType := strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	return body, contentType, nil
+}
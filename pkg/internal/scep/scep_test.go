@@ -0,0 +1,503 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scep
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+	testlisters "github.com/jetstack/cert-manager/test/unit/listers"
+)
+
+// scepServer is a minimal SCEP server used as a stand-in for a real SCEP
+// deployment in tests.
+type scepServer struct {
+	t *testing.T
+
+	caCert *x509.Certificate
+	caKey  *rsa.PrivateKey
+	// raCert and raKey are used to sign and decrypt messages if set.
+	raCert *x509.Certificate
+	raKey  *rsa.PrivateKey
+
+	caps              []string
+	challengePassword string
+	// pending causes PKCSReq messages to be answered with a pending status.
+	pending bool
+
+	// requests holds the CSRs of pending requests by transaction ID.
+	requests map[string]*x509.CertificateRequest
+}
+
+func (s *scepServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Query().Get("operation") {
+	case "GetCACaps":
+		w.Write([]byte(strings.Join(s.caps, "\n")))
+
+	case "GetCACert":
+		if s.raCert == nil {
+			w.Header().Set("Content-Type", "application/x-x509-ca-cert")
+			w.Write(s.caCert.Raw)
+			return
+		}
+		der, err := pkcs7.DegenerateCertificate(append(s.raCert.Raw, s.caCert.Raw...))
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/x-x509-ca-ra-cert")
+		w.Write(der)
+
+	case "PKIOperation":
+		var msg []byte
+		var err error
+		if r.Method == http.MethodPost {
+			msg, err = ioutil.ReadAll(r.Body)
+		} else {
+			msg, err = base64.StdEncoding.DecodeString(r.URL.Query().Get("message"))
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.pkiOperation(w, msg)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *scepServer) pkiOperation(w http.ResponseWriter, msg []byte) {
+	p7, err := pkcs7.Parse(msg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := p7.Verify(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var messageType, transactionID string
+	var senderNonce []byte
+	p7.UnmarshalSignedAttribute(oidMessageType, &messageType)
+	p7.UnmarshalSignedAttribute(oidTransactionID, &transactionID)
+	p7.UnmarshalSignedAttribute(oidSenderNonce, &senderNonce)
+
+	recipientCert, recipientKey := s.caCert, s.caKey
+	if s.raCert != nil {
+		recipientCert, recipientKey = s.raCert, s.raKey
+	}
+	envelope, err := pkcs7.Parse(p7.Content)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	content, err := envelope.Decrypt(recipientCert, recipientKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reply := func(status PKIStatus, failInfo FailInfo, certs ...*x509.Certificate) {
+		s.writeCertRep(w, p7.GetOnlySigner(), transactionID, senderNonce, status, failInfo, certs...)
+	}
+
+	var csr *x509.CertificateRequest
+	switch messageType {
+	case messageTypePKCSReq:
+		csr, err = x509.ParseCertificateRequest(content)
+		if err != nil || csr.CheckSignature() != nil {
+			reply(StatusFailure, BadMessageCheck)
+			return
+		}
+		if transactionID != TransactionID(csr) {
+			reply(StatusFailure, BadRequest)
+			return
+		}
+		if s.challengePassword != "" && challengePassword(s.t, csr) != s.challengePassword {
+			reply(StatusFailure, BadRequest)
+			return
+		}
+		if s.pending {
+			s.requests[transactionID] = csr
+			reply(StatusPending, "")
+			return
+		}
+
+	case messageTypeCertPoll:
+		var ok bool
+		if csr, ok = s.requests[transactionID]; !ok {
+			reply(StatusFailure, BadCertID)
+			return
+		}
+
+	default:
+		reply(StatusFailure, BadRequest)
+		return
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      csr.Subject,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	_, cert, err := pki.SignCertificate(template, s.caCert, csr.PublicKey, s.caKey)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	reply(StatusSuccess, "", s.caCert, cert)
+}
+
+func (s *scepServer) writeCertRep(w http.ResponseWriter, recipient *x509.Certificate, transactionID string, recipientNonce []byte, status PKIStatus, failInfo FailInfo, certs ...*x509.Certificate) {
+	var content []byte
+	if status == StatusSuccess {
+		var der []byte
+		for _, cert := range certs {
+			der = append(der, cert.Raw...)
+		}
+		degenerate, err := pkcs7.DegenerateCertificate(der)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		content, err = encrypt(degenerate, recipient, Capabilities{"AES"})
+		if err != nil {
+			s.t.Fatal(err)
+		}
+	}
+
+	attrs := []pkcs7.Attribute{
+		{Type: oidMessageType, Value: messageTypeCertRep},
+		{Type: oidTransactionID, Value: transactionID},
+		{Type: oidPKIStatus, Value: string(status)},
+		{Type: oidRecipientNonce, Value: recipientNonce},
+	}
+	if status == StatusFailure {
+		attrs = append(attrs, pkcs7.Attribute{Type: oidFailInfo, Value: string(failInfo)})
+	}
+
+	signerCert, signerKey := s.caCert, s.caKey
+	if s.raCert != nil {
+		signerCert, signerKey = s.raCert, s.raKey
+	}
+	sd, err := pkcs7.NewSignedData(content)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	if err := sd.AddSigner(signerCert, signerKey, pkcs7.SignerInfoConfig{ExtraSignedAttributes: attrs}); err != nil {
+		s.t.Fatal(err)
+	}
+	der, err := sd.Finish()
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	w.Header().Set("Content-Type", "application/x-pki-message")
+	w.Write(der)
+}
+
+// challengePassword returns the challenge password attribute of the CSR.
+func challengePassword(t *testing.T, csr *x509.CertificateRequest) string {
+	var info certificationRequestInfo
+	if _, err := asn1.Unmarshal(csr.RawTBSCertificateRequest, &info); err != nil {
+		t.Fatal(err)
+	}
+	for _, raw := range info.RawAttributes {
+		var attr struct {
+			Type   asn1.ObjectIdentifier
+			Values []asn1.RawValue `asn1:"set"`
+		}
+		if _, err := asn1.Unmarshal(raw.FullBytes, &attr); err != nil {
+			t.Fatal(err)
+		}
+		if attr.Type.Equal(oidChallengePassword) && len(attr.Values) == 1 {
+			var password string
+			if _, err := asn1.Unmarshal(attr.Values[0].FullBytes, &password); err != nil {
+				t.Fatal(err)
+			}
+			return password
+		}
+	}
+	return ""
+}
+
+func generateCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	_, cert, err := pki.SignCertificate(template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestSCEP(t *testing.T) {
+	caCert, caKey := generateCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "scep-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}, nil, nil)
+	raCert, raKey := generateCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "scep-ra"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}, caCert, caKey)
+
+	csrKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "example.com"},
+		DNSNames: []string{"example.com"},
+	}, csrKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passwordSecret := gen.Secret("scep-password",
+		gen.SetSecretNamespace("test-ns"),
+		gen.SetSecretData(map[string][]byte{"password": []byte("secret\n")}),
+	)
+	passwordRef := &cmmeta.SecretKeySelector{
+		LocalObjectReference: cmmeta.LocalObjectReference{Name: "scep-password"},
+		Key:                  "password",
+	}
+
+	tests := map[string]struct {
+		server      *scepServer
+		passwordRef *cmmeta.SecretKeySelector
+
+		expectedCACerts  []*x509.Certificate
+		expectedStatus   PKIStatus
+		expectedFailInfo FailInfo
+		// expectedPollStatus is the expected status of a CertPoll message sent
+		// after a pending PKCSReq.
+		expectedPollStatus PKIStatus
+	}{
+		"enroll using GET and DES": {
+			server:          &scepServer{},
+			expectedCACerts: []*x509.Certificate{caCert},
+			expectedStatus:  StatusSuccess,
+		},
+		"enroll using POST, AES and SHA-256 with a challenge password": {
+			server: &scepServer{
+				caps:              []string{"POSTPKIOperation", "AES", "SHA-256"},
+				challengePassword: "secret",
+			},
+			passwordRef:     passwordRef,
+			expectedCACerts: []*x509.Certificate{caCert},
+			expectedStatus:  StatusSuccess,
+		},
+		"enroll with an incorrect challenge password": {
+			server: &scepServer{
+				caps:              []string{"POSTPKIOperation"},
+				challengePassword: "other",
+			},
+			passwordRef:      passwordRef,
+			expectedCACerts:  []*x509.Certificate{caCert},
+			expectedStatus:   StatusFailure,
+			expectedFailInfo: BadRequest,
+		},
+		"enroll with a registration authority": {
+			server: &scepServer{
+				raCert: raCert,
+				raKey:  raKey,
+				caps:   []string{"SCEPStandard", "POSTPKIOperation"},
+			},
+			expectedCACerts: []*x509.Certificate{caCert, raCert},
+			expectedStatus:  StatusSuccess,
+		},
+		"enrollment pending then polled": {
+			server: &scepServer{
+				caps:    []string{"POSTPKIOperation"},
+				pending: true,
+			},
+			expectedCACerts:    []*x509.Certificate{caCert},
+			expectedStatus:     StatusPending,
+			expectedPollStatus: StatusSuccess,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.server.t = t
+			test.server.caCert = caCert
+			test.server.caKey = caKey
+			test.server.requests = make(map[string]*x509.CertificateRequest)
+
+			srv := httptest.NewServer(test.server)
+			defer srv.Close()
+
+			issuer := gen.Issuer("scep-issuer",
+				gen.SetIssuerNamespace("test-ns"),
+				gen.SetIssuerSCEP(cmapi.SCEPIssuer{
+					URL:                        srv.URL + "/scep",
+					ChallengePasswordSecretRef: test.passwordRef,
+				}),
+			)
+
+			secretsLister := testlisters.FakeSecretListerFrom(testlisters.NewFakeSecretLister(),
+				testlisters.SetFakeSecretNamespaceListerGet(passwordSecret, nil),
+			)
+
+			c, err := New("test-ns", secretsLister, issuer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			caps, err := c.GetCACaps(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(caps) != len(test.server.caps) {
+				t.Errorf("unexpected capabilities: %v", caps)
+			}
+
+			caCerts, err := c.GetCACert(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(caCerts) != len(test.expectedCACerts) {
+				t.Fatalf("expected %d CA certificates, got %d", len(test.expectedCACerts), len(caCerts))
+			}
+			for i := range caCerts {
+				if !caCerts[i].Equal(test.expectedCACerts[i]) {
+					t.Errorf("unexpected CA certificate at index %d: %s", i, caCerts[i].Subject)
+				}
+			}
+
+			req := &Request{
+				TransactionID: TransactionID(csr),
+				CSR:           csr,
+				PrivateKey:    csrKey,
+				CACerts:       caCerts,
+				Capabilities:  caps,
+			}
+			resp, err := c.PKCSReq(context.TODO(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Status != test.expectedStatus || resp.FailInfo != test.expectedFailInfo {
+				t.Fatalf("unexpected response status=%q failInfo=%q", resp.Status, resp.FailInfo)
+			}
+
+			if test.expectedPollStatus != "" {
+				resp, err = c.CertPoll(context.TODO(), req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if resp.Status != test.expectedPollStatus {
+					t.Fatalf("unexpected poll response status=%q failInfo=%q", resp.Status, resp.FailInfo)
+				}
+			}
+
+			if resp.Status != StatusSuccess {
+				return
+			}
+			if len(resp.Certificates) != 2 {
+				t.Fatalf("expected 2 certificates, got %d", len(resp.Certificates))
+			}
+			if ok, _ := pki.PublicKeysEqual(resp.Certificates[0].PublicKey, csrKey.Public()); !ok {
+				t.Errorf("expected the issued certificate to be first")
+			}
+		})
+	}
+}
+
+func TestParseCertRepRejectsUnknownSigner(t *testing.T) {
+	ca := func(cn string) (*x509.Certificate, *rsa.PrivateKey) {
+		return generateCertificate(t, &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             time.Now(),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+		}, nil, nil)
+	}
+	caCert, _ := ca("scep-ca")
+	otherCert, otherKey := ca("other-ca")
+
+	csrKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "example.com"},
+	}, csrKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := newPKIMessage(&Request{
+		TransactionID: TransactionID(csr),
+		CSR:           csr,
+		PrivateKey:    csrKey,
+		CACerts:       []*x509.Certificate{caCert},
+	}, messageTypePKCSReq, csr.Raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Respond with a CertRep signed by a CA other than the one returned by
+	// GetCACert.
+	other := &scepServer{t: t, caCert: otherCert, caKey: otherKey}
+	rec := httptest.NewRecorder()
+	other.writeCertRep(rec, msg.signer, TransactionID(csr), msg.senderNonce, StatusPending, "")
+
+	_, err = msg.parseCertRep(rec.Body.Bytes())
+	if err == nil || err.Error() != "SCEP response is not signed by the CA or an RA" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
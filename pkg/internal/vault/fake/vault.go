@@ -18,24 +18,31 @@ limitations under the License.
 package fake
 
 import (
+	"context"
 	"time"
 
 	vault "github.com/hashicorp/vault/api"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 )
 
+// createTokenFn mirrors vault.CreateTokenFn, which cannot be imported here
+// without an import cycle in the vault package tests.
+type createTokenFn = func(context.Context, string, *authv1.TokenRequest, metav1.CreateOptions) (*authv1.TokenRequest, error)
+
 type Vault struct {
-	NewFn                           func(string, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error)
-	SignFn                          func([]byte, time.Duration) ([]byte, []byte, error)
+	NewFn                           func(context.Context, string, createTokenFn, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error)
+	SignFn                          func(context.Context, []byte, time.Duration, string) ([]byte, []byte, error)
 	IsVaultInitializedAndUnsealedFn func() error
 }
 
 // New returns a new fake Vault
 func New() *Vault {
 	v := &Vault{
-		SignFn: func([]byte, time.Duration) ([]byte, []byte, error) {
+		SignFn: func(context.Context, []byte, time.Duration, string) ([]byte, []byte, error) {
 			return nil, nil, nil
 		},
 		IsVaultInitializedAndUnsealedFn: func() error {
@@ -43,7 +50,7 @@ func New() *Vault {
 		},
 	}
 
-	v.NewFn = func(string, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error) {
+	v.NewFn = func(context.Context, string, createTokenFn, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error) {
 		return v, nil
 	}
 
@@ -51,27 +58,27 @@ func New() *Vault {
 }
 
 // Sign implements `vault.Interface`.
-func (v *Vault) Sign(csrPEM []byte, duration time.Duration) ([]byte, []byte, error) {
-	return v.SignFn(csrPEM, duration)
+func (v *Vault) Sign(ctx context.Context, csrPEM []byte, duration time.Duration, role string) ([]byte, []byte, error) {
+	return v.SignFn(ctx, csrPEM, duration, role)
 }
 
 // WithSign sets the fake Vault's Sign function.
 func (v *Vault) WithSign(certPEM, caPEM []byte, err error) *Vault {
-	v.SignFn = func([]byte, time.Duration) ([]byte, []byte, error) {
+	v.SignFn = func(context.Context, []byte, time.Duration, string) ([]byte, []byte, error) {
 		return certPEM, caPEM, err
 	}
 	return v
 }
 
 // WithNew sets the fake Vault's New function.
-func (v *Vault) WithNew(f func(string, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error)) *Vault {
+func (v *Vault) WithNew(f func(context.Context, string, createTokenFn, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error)) *Vault {
 	v.NewFn = f
 	return v
 }
 
 // New call NewFn and returns a pointer to the fake Vault.
-func (v *Vault) New(ns string, sl corelisters.SecretLister, iss v1.GenericIssuer) (*Vault, error) {
-	_, err := v.NewFn(ns, sl, iss)
+func (v *Vault) New(ctx context.Context, ns string, ct createTokenFn, sl corelisters.SecretLister, iss v1.GenericIssuer) (*Vault, error) {
+	_, err := v.NewFn(ctx, ns, ct, sl, iss)
 	if err != nil {
 		return nil, err
 	}
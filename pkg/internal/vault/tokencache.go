@@ -0,0 +1,335 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
+
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/metrics"
+)
+
+// TokenCache is a cache of Vault tokens shared between the controllers that
+// build Vault clients, keyed by the UID of the issuer that obtained them.
+// Reusing tokens avoids logging in to Vault for every signing operation.
+// A cached token is discarded when it expires, or when the Vault config of the
+// issuer or any Secret referenced by its auth config changes.
+type TokenCache struct {
+	lock    sync.Mutex
+	clock   clock.Clock
+	metrics *metrics.Metrics
+
+	tokens map[types.UID]cachedToken
+}
+
+// cachedToken is a Vault token obtained by logging in for an issuer.
+type cachedToken struct {
+	token     string
+	expiry    time.Time
+	ttl       time.Duration
+	renewable bool
+
+	// fingerprint identifies the issuer config and referenced Secrets that
+	// the token was obtained with.
+	fingerprint string
+
+	// issuer identifies the issuer in metrics.
+	issuer issuerRef
+}
+
+type issuerRef struct {
+	name, namespace, kind string
+}
+
+// NewTokenCache returns a new, empty TokenCache. Metrics may be nil.
+func NewTokenCache(clock clock.Clock, metrics *metrics.Metrics) *TokenCache {
+	return &TokenCache{
+		clock:   clock,
+		metrics: metrics,
+		tokens:  make(map[types.UID]cachedToken),
+	}
+}
+
+// New returns a Vault client for the issuer that reuses the token cached for
+// the issuer, only logging in to Vault if no valid token is cached. It can be
+// used as a ClientBuilder. A nil TokenCache behaves like New.
+func (c *TokenCache) New(ctx context.Context, namespace string, createToken CreateTokenFn, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+	if c == nil {
+		return New(ctx, namespace, createToken, secretsLister, issuer)
+	}
+
+	v, err := newVault(ctx, namespace, createToken, secretsLister, issuer, c, c.clock)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// get returns the token cached for the issuer, if it was obtained with the
+// given fingerprint and is not about to expire. Stale tokens are discarded.
+func (c *TokenCache) get(issuer v1.GenericIssuer, fingerprint string) (cachedToken, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	uid := issuer.GetObjectMeta().UID
+	cached, ok := c.tokens[uid]
+	if !ok {
+		return cachedToken{}, false
+	}
+
+	if cached.fingerprint == fingerprint && !c.expiring(cached) {
+		return cached, true
+	}
+
+	c.deleteLocked(uid)
+	return cachedToken{}, false
+}
+
+// put stores the token for the issuer, replacing any cached token, and
+// discards the tokens of all issuers that have expired.
+func (c *TokenCache) put(issuer v1.GenericIssuer, token cachedToken) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for uid, cached := range c.tokens {
+		if !cached.expiry.IsZero() && !c.clock.Now().Before(cached.expiry) {
+			c.deleteLocked(uid)
+		}
+	}
+
+	token.issuer = newIssuerRef(issuer)
+	c.tokens[issuer.GetObjectMeta().UID] = token
+
+	if c.metrics != nil {
+		c.metrics.UpdateVaultTokenTTL(token.issuer.name, token.issuer.namespace, token.issuer.kind, token.ttl)
+	}
+}
+
+// remove discards the token cached for the issuer.
+func (c *TokenCache) remove(issuer v1.GenericIssuer) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.deleteLocked(issuer.GetObjectMeta().UID)
+}
+
+func (c *TokenCache) deleteLocked(uid types.UID) {
+	cached, ok := c.tokens[uid]
+	if !ok {
+		return
+	}
+
+	delete(c.tokens, uid)
+
+	if c.metrics != nil {
+		c.metrics.RemoveVaultTokenTTL(cached.issuer.name, cached.issuer.namespace, cached.issuer.kind)
+	}
+}
+
+// expiring returns true if the token expires within tokenExpiryMargin.
+func (c *TokenCache) expiring(cached cachedToken) bool {
+	return !cached.expiry.IsZero() && !c.clock.Now().Add(tokenExpiryMargin).Before(cached.expiry)
+}
+
+// observeLogin records a login to Vault with the given auth method.
+func (c *TokenCache) observeLogin(method string, err error) {
+	if c.metrics == nil {
+		return
+	}
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	c.metrics.IncrementVaultLoginCount(method, status)
+}
+
+func newIssuerRef(issuer v1.GenericIssuer) issuerRef {
+	ref := issuerRef{
+		name:      issuer.GetObjectMeta().Name,
+		namespace: issuer.GetObjectMeta().Namespace,
+		kind:      v1.IssuerKind,
+	}
+	if ref.namespace == "" {
+		ref.kind = v1.ClusterIssuerKind
+	}
+	return ref
+}
+
+// authMethod returns the name of the auth method used to log in to Vault, in
+// the same order of precedence as setToken.
+func authMethod(auth v1.VaultAuth) string {
+	switch {
+	case auth.TokenSecretRef != nil:
+		return "token"
+	case auth.AppRole != nil:
+		return "appRole"
+	case auth.Kubernetes != nil:
+		return "kubernetes"
+	case auth.JWT != nil:
+		return "jwt"
+	case auth.ClientCertificate != nil:
+		return "clientCertificate"
+	}
+	return ""
+}
+
+// authenticate sets the token of the client. If a token cache is in use, a
+// token cached for the issuer is reused and renewed where possible, and Vault
+// is only logged in to if there is no valid cached token.
+func (v *Vault) authenticate(ctx context.Context) error {
+	// Static tokens are read from their Secret and never cached.
+	if v.cache == nil || v.issuer.GetSpec().Vault.Auth.TokenSecretRef != nil {
+		return v.setToken(ctx, v.client)
+	}
+
+	fingerprint, err := v.computeFingerprint()
+	if err != nil {
+		return err
+	}
+	v.fingerprint = fingerprint
+
+	cached, ok := v.cache.get(v.issuer, fingerprint)
+	if !ok {
+		return v.login(ctx)
+	}
+
+	v.client.SetToken(cached.token)
+	v.tokenExpiry = cached.expiry
+	v.tokenTTL = cached.ttl
+	v.renewable = cached.renewable
+
+	if !v.shouldRenew() {
+		return nil
+	}
+
+	if err := v.renewToken(); err != nil {
+		// The token may have reached its maximum TTL or have been revoked, so
+		// log in again instead.
+		return v.login(ctx)
+	}
+
+	v.cache.put(v.issuer, v.cachedToken())
+	return nil
+}
+
+// login logs in to Vault with the auth method of the issuer and, if a token
+// cache is in use, stores the new token in the cache.
+func (v *Vault) login(ctx context.Context) error {
+	err := v.setToken(ctx, v.client)
+	if v.cache == nil {
+		return err
+	}
+
+	v.cache.observeLogin(authMethod(v.issuer.GetSpec().Vault.Auth), err)
+	if err != nil {
+		v.cache.remove(v.issuer)
+		return err
+	}
+
+	v.cache.put(v.issuer, v.cachedToken())
+	return nil
+}
+
+func (v *Vault) cachedToken() cachedToken {
+	return cachedToken{
+		token:       v.client.Token(),
+		expiry:      v.tokenExpiry,
+		ttl:         v.tokenTTL,
+		renewable:   v.renewable,
+		fingerprint: v.fingerprint,
+	}
+}
+
+// shouldRenew returns true if the current token is renewable and less than a
+// third of its TTL remains.
+func (v *Vault) shouldRenew() bool {
+	if !v.renewable || v.tokenExpiry.IsZero() {
+		return false
+	}
+
+	return v.tokenExpiry.Sub(v.clock.Now()) < v.tokenTTL/3
+}
+
+// renewToken renews the lease of the current token.
+func (v *Vault) renewToken() error {
+	request := v.client.NewRequest("POST", "/v1/auth/token/renew-self")
+
+	v.addVaultNamespaceToRequest(request)
+
+	resp, err := v.client.RawRequest(request)
+	if err != nil {
+		return fmt.Errorf("error renewing Vault token: %s", err.Error())
+	}
+
+	defer resp.Body.Close()
+	vaultResult := vault.Secret{}
+	if err := resp.DecodeJSON(&vaultResult); err != nil {
+		return fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	v.recordLease(&vaultResult)
+	return nil
+}
+
+// computeFingerprint returns a fingerprint of the Vault config of the issuer
+// and of the versions of the Secrets referenced by its auth config, so that a
+// cached token is discarded when either changes.
+func (v *Vault) computeFingerprint() (string, error) {
+	config, err := json.Marshal(v.issuer.GetSpec().Vault)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", v.namespace)
+	h.Write(config)
+
+	auth := v.issuer.GetSpec().Vault.Auth
+	var secretNames []string
+	if auth.AppRole != nil {
+		secretNames = append(secretNames, auth.AppRole.SecretRef.Name)
+	}
+	if auth.Kubernetes != nil {
+		secretNames = append(secretNames, auth.Kubernetes.SecretRef.Name)
+	}
+	if auth.ClientCertificate != nil {
+		secretNames = append(secretNames, auth.ClientCertificate.SecretName)
+	}
+
+	for _, name := range secretNames {
+		secret, err := v.secretsLister.Secrets(v.namespace).Get(name)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "\x00%s/%s", name, secret.ResourceVersion)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
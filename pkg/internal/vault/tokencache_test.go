@@ -0,0 +1,296 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+	"github.com/jetstack/cert-manager/test/unit/listers"
+)
+
+// scriptedClient is a Vault Client that records the paths requested and
+// responds to logins with a new token each time.
+type scriptedClient struct {
+	token string
+	calls []string
+	// logins is the number of logins made, used to name issued tokens.
+	logins int
+
+	loginTTL  int
+	renewErr  error
+	forbidden map[string]bool
+}
+
+func (c *scriptedClient) NewRequest(method, requestPath string) *vault.Request {
+	return &vault.Request{
+		Method:      method,
+		URL:         &url.URL{Path: requestPath},
+		ClientToken: c.token,
+	}
+}
+
+func (c *scriptedClient) RawRequest(r *vault.Request) (*vault.Response, error) {
+	c.calls = append(c.calls, r.URL.Path)
+
+	switch r.URL.Path {
+	case "/v1/auth/approle/login":
+		c.logins++
+		return jsonResponse(fmt.Sprintf(`{"auth":{"client_token":"token-%d","lease_duration":%d,"renewable":true}}`, c.logins, c.loginTTL)), nil
+	case "/v1/auth/token/renew-self":
+		if c.renewErr != nil {
+			return nil, c.renewErr
+		}
+		return jsonResponse(fmt.Sprintf(`{"auth":{"client_token":"%s","lease_duration":%d,"renewable":true}}`, r.ClientToken, c.loginTTL)), nil
+	case "/v1/pki/sign/example":
+		if c.forbidden[r.ClientToken] {
+			return nil, &vault.ResponseError{StatusCode: http.StatusForbidden}
+		}
+		return jsonResponse(`{"data":{"certificate":"` + strings.ReplaceAll(testLeafCertificate, "\n", `\n`) + `"}}`), nil
+	}
+
+	return nil, fmt.Errorf("unexpected request to %s", r.URL.Path)
+}
+
+func (c *scriptedClient) SetToken(v string) { c.token = v }
+func (c *scriptedClient) Token() string     { return c.token }
+func (c *scriptedClient) Sys() *vault.Sys   { return nil }
+
+func jsonResponse(body string) *vault.Response {
+	return &vault.Response{
+		Response: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		},
+	}
+}
+
+func TestTokenCache(t *testing.T) {
+	issuer := gen.Issuer("vault-issuer",
+		gen.SetIssuerNamespace("test-namespace"),
+		gen.SetIssuerVault(cmapi.VaultIssuer{
+			Server: "https://vault.example.com",
+			Path:   "pki/sign/example",
+			Auth: cmapi.VaultAuth{
+				AppRole: &cmapi.VaultAppRole{
+					Path:   "approle",
+					RoleId: "my-role-id",
+					SecretRef: cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: "approle"},
+						Key:                  "secret-id",
+					},
+				},
+			},
+		}),
+	)
+	issuer.UID = types.UID("issuer-uid")
+
+	secretWithVersion := func(version string) *listers.FakeSecretLister {
+		return listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+			listers.SetFakeSecretNamespaceListerGet(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "approle", Namespace: "test-namespace", ResourceVersion: version},
+				Data:       map[string][]byte{"secret-id": []byte("my-secret-id")},
+			}, nil),
+		)
+	}
+
+	type step struct {
+		// advance moves the clock forward before the step.
+		advance time.Duration
+		// secretVersion is the resourceVersion of the referenced Secret.
+		secretVersion string
+		// modifyIssuer changes the issuer before the step.
+		modifyIssuer func(*cmapi.Issuer)
+
+		expectedToken string
+		expectedCalls []string
+	}
+
+	login := "/v1/auth/approle/login"
+	renew := "/v1/auth/token/renew-self"
+
+	tests := map[string]struct {
+		renewErr error
+		steps    []step
+	}{
+		"a cached token should be reused": {
+			steps: []step{
+				{secretVersion: "1", expectedToken: "token-1", expectedCalls: []string{login}},
+				{secretVersion: "1", expectedToken: "token-1", expectedCalls: nil},
+				{advance: 30 * time.Minute, secretVersion: "1", expectedToken: "token-1", expectedCalls: nil},
+			},
+		},
+		"a token close to expiry should be renewed": {
+			steps: []step{
+				{secretVersion: "1", expectedToken: "token-1", expectedCalls: []string{login}},
+				{advance: 45 * time.Minute, secretVersion: "1", expectedToken: "token-1", expectedCalls: []string{renew}},
+				{advance: 10 * time.Minute, secretVersion: "1", expectedToken: "token-1", expectedCalls: nil},
+			},
+		},
+		"a token that cannot be renewed should be replaced by logging in": {
+			renewErr: fmt.Errorf("token reached max TTL"),
+			steps: []step{
+				{secretVersion: "1", expectedToken: "token-1", expectedCalls: []string{login}},
+				{advance: 45 * time.Minute, secretVersion: "1", expectedToken: "token-2", expectedCalls: []string{renew, login}},
+			},
+		},
+		"an expired token should be replaced by logging in": {
+			steps: []step{
+				{secretVersion: "1", expectedToken: "token-1", expectedCalls: []string{login}},
+				{advance: 2 * time.Hour, secretVersion: "1", expectedToken: "token-2", expectedCalls: []string{login}},
+			},
+		},
+		"a change of the referenced Secret should invalidate the cached token": {
+			steps: []step{
+				{secretVersion: "1", expectedToken: "token-1", expectedCalls: []string{login}},
+				{secretVersion: "2", expectedToken: "token-2", expectedCalls: []string{login}},
+			},
+		},
+		"a change of the issuer spec should invalidate the cached token": {
+			steps: []step{
+				{secretVersion: "1", expectedToken: "token-1", expectedCalls: []string{login}},
+				{
+					secretVersion: "1",
+					modifyIssuer: func(iss *cmapi.Issuer) {
+						iss.Spec.Vault.Auth.AppRole.RoleId = "another-role-id"
+					},
+					expectedToken: "token-2",
+					expectedCalls: []string{login},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			clock := fakeclock.NewFakeClock(time.Now())
+			cache := NewTokenCache(clock, nil)
+			iss := issuer.DeepCopy()
+			client := &scriptedClient{
+				loginTTL: 3600,
+				renewErr: test.renewErr,
+			}
+
+			for i, step := range test.steps {
+				clock.Step(step.advance)
+				if step.modifyIssuer != nil {
+					step.modifyIssuer(iss)
+				}
+
+				// every step builds a new client without a token
+				client.token = ""
+				client.calls = nil
+
+				v := &Vault{
+					namespace:     "test-namespace",
+					secretsLister: secretWithVersion(step.secretVersion),
+					issuer:        iss,
+					clock:         clock,
+					cache:         cache,
+					client:        client,
+				}
+
+				if err := v.authenticate(context.Background()); err != nil {
+					t.Fatalf("step %d: unexpected error: %s", i, err)
+				}
+
+				if client.Token() != step.expectedToken {
+					t.Errorf("step %d: got unexpected token, exp=%s got=%s", i, step.expectedToken, client.Token())
+				}
+
+				if fmt.Sprint(client.calls) != fmt.Sprint(step.expectedCalls) {
+					t.Errorf("step %d: got unexpected requests, exp=%v got=%v", i, step.expectedCalls, client.calls)
+				}
+			}
+		})
+	}
+}
+
+func TestSignReauthenticatesOnForbidden(t *testing.T) {
+	issuer := gen.Issuer("vault-issuer",
+		gen.SetIssuerNamespace("test-namespace"),
+		gen.SetIssuerVault(cmapi.VaultIssuer{
+			Path: "pki/sign/example",
+			Auth: cmapi.VaultAuth{
+				AppRole: &cmapi.VaultAppRole{
+					Path:   "approle",
+					RoleId: "my-role-id",
+					SecretRef: cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{Name: "approle"},
+						Key:                  "secret-id",
+					},
+				},
+			},
+		}),
+	)
+
+	clock := fakeclock.NewFakeClock(time.Now())
+	client := &scriptedClient{
+		loginTTL: 3600,
+		// the first token has been revoked
+		forbidden: map[string]bool{"token-1": true},
+	}
+	v := &Vault{
+		namespace: "test-namespace",
+		secretsLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+			listers.SetFakeSecretNamespaceListerGet(&corev1.Secret{
+				Data: map[string][]byte{"secret-id": []byte("my-secret-id")},
+			}, nil),
+		),
+		issuer: issuer,
+		clock:  clock,
+		cache:  NewTokenCache(clock, nil),
+		client: client,
+	}
+
+	if err := v.authenticate(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, _, err := v.Sign(context.Background(), generateCSR(t, generateRSAPrivateKey(t)), time.Hour, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expCalls := []string{
+		"/v1/auth/approle/login",
+		"/v1/pki/sign/example",
+		"/v1/auth/approle/login",
+		"/v1/pki/sign/example",
+	}
+	if fmt.Sprint(client.calls) != fmt.Sprint(expCalls) {
+		t.Errorf("got unexpected requests, exp=%v got=%v", expCalls, client.calls)
+	}
+
+	if client.Token() != "token-2" {
+		t.Errorf("expected client to use the new token, got=%s", client.Token())
+	}
+}
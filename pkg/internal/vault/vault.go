@@ -17,7 +17,10 @@ limitations under the License.
 package vault
 
 import (
+	"context"
+	"crypto/tls"
 	"crypto/x509"
+	"encoding/asn1"
 	"errors"
 	"fmt"
 	"net/http"
@@ -28,9 +31,13 @@ import (
 
 	vault "github.com/hashicorp/vault/api"
 	"github.com/hashicorp/vault/sdk/helper/certutil"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
 
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
@@ -38,15 +45,31 @@ var _ Interface = &Vault{}
 
 // ClientBuilder is a function type that returns a new Interface.
 // Can be used in tests to create a mock signer of Vault certificate requests.
-type ClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
-	issuer v1.GenericIssuer) (Interface, error)
+type ClientBuilder func(ctx context.Context, namespace string, createToken CreateTokenFn,
+	secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error)
+
+// CreateTokenFn requests a token for the named ServiceAccount using the
+// TokenRequest API. It is bound to the resource namespace of the issuer,
+// typically as `ServiceAccounts(namespace).CreateToken`.
+type CreateTokenFn func(ctx context.Context, serviceAccount string,
+	req *authv1.TokenRequest, opts metav1.CreateOptions) (*authv1.TokenRequest, error)
+
+// jwtTokenExpirationSeconds is the lifetime requested for ServiceAccount
+// tokens used with JWT/OIDC auth. The token is only used to log in once, so
+// the minimum lifetime accepted by the TokenRequest API is requested.
+const jwtTokenExpirationSeconds = 600
+
+// tokenExpiryMargin is how long before the expiry of a Vault token obtained
+// by logging in the client re-authenticates, so that the token does not
+// expire in the middle of a request.
+const tokenExpiryMargin = 30 * time.Second
 
 // Interface implements various high level functionality related to connecting
 // with a Vault server, verifying its status and signing certificate request for
 // Vault's certificate.
 // TODO: Sys() is duplicated here and in Client interface
 type Interface interface {
-	Sign(csrPEM []byte, duration time.Duration) (certPEM []byte, caPEM []byte, err error)
+	Sign(ctx context.Context, csrPEM []byte, duration time.Duration, role string) (certPEM []byte, caPEM []byte, err error)
 	Sys() *vault.Sys
 	IsVaultInitializedAndUnsealed() error
 }
@@ -64,21 +87,53 @@ type Client interface {
 // Vault client.
 type Vault struct {
 	secretsLister corelisters.SecretLister
+	createToken   CreateTokenFn
 	issuer        v1.GenericIssuer
 	namespace     string
+	clock         clock.Clock
 
 	client Client
+
+	// cache, if set, is used to reuse Vault tokens between clients built for
+	// the same issuer.
+	cache *TokenCache
+	// fingerprint identifies the issuer configuration and referenced Secrets
+	// that the current token was obtained with. It is only set when a cache
+	// is in use.
+	fingerprint string
+
+	// tokenExpiry is when the current Vault token expires, if the token was
+	// obtained by logging in. It is the zero time if the token does not
+	// expire or is not managed by the client.
+	tokenExpiry time.Time
+	// tokenTTL is the lifetime the current token was granted with.
+	tokenTTL time.Duration
+	// renewable is true if the lease of the current token can be renewed.
+	renewable bool
 }
 
-// New returns a new Vault instance with the given namespace, issuer and
-// secrets lister.
+// New returns a new Vault instance with the given namespace, issuer, secrets
+// lister and function to request ServiceAccount tokens.
 // Returned errors may be network failures and should be considered for
 // retrying.
-func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+func New(ctx context.Context, namespace string, createToken CreateTokenFn, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+	v, err := newVault(ctx, namespace, createToken, secretsLister, issuer, nil, clock.RealClock{})
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func newVault(ctx context.Context, namespace string, createToken CreateTokenFn, secretsLister corelisters.SecretLister,
+	issuer v1.GenericIssuer, cache *TokenCache, clock clock.Clock) (*Vault, error) {
 	v := &Vault{
 		secretsLister: secretsLister,
+		createToken:   createToken,
 		namespace:     namespace,
 		issuer:        issuer,
+		clock:         clock,
+		cache:         cache,
 	}
 
 	cfg, err := v.newConfig()
@@ -91,45 +146,62 @@ func New(namespace string, secretsLister corelisters.SecretLister, issuer v1.Gen
 		return nil, fmt.Errorf("error initializing Vault client: %s", err.Error())
 	}
 
-	if err := v.setToken(client); err != nil {
+	v.client = client
+
+	if err := v.authenticate(ctx); err != nil {
 		return nil, err
 	}
 
-	v.client = client
-
 	return v, nil
 }
 
 // Sign will connect to a Vault instance to sign a certificate signing request.
-func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []byte, err error) {
+// If role is not empty, the request is signed by that role instead of the role
+// in the path of the issuer, provided the issuer allows it.
+func (v *Vault) Sign(ctx context.Context, csrPEM []byte, duration time.Duration, role string) (cert []byte, ca []byte, err error) {
 	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to decode CSR for signing: %s", err)
 	}
 
-	parameters := map[string]string{
-		"common_name": csr.Subject.CommonName,
-		"alt_names":   strings.Join(csr.DNSNames, ","),
-		"ip_sans":     strings.Join(pki.IPAddressesToString(csr.IPAddresses), ","),
-		"uri_sans":    strings.Join(pki.URLsToString(csr.URIs), ","),
-		"ttl":         duration.String(),
-		"csr":         string(csrPEM),
-
-		"exclude_cn_from_sans": "true",
-	}
-
 	vaultIssuer := v.issuer.GetSpec().Vault
-	url := path.Join("/v1", vaultIssuer.Path)
-
-	request := v.client.NewRequest("POST", url)
+	signPath, err := SignPath(vaultIssuer, role)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	v.addVaultNamespaceToRequest(request)
+	var parameters map[string]string
+	if vaultIssuer.SignVerbatim {
+		parameters, err = signVerbatimParameters(csr, csrPEM, duration)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		parameters = map[string]string{
+			"common_name": csr.Subject.CommonName,
+			"alt_names":   strings.Join(csr.DNSNames, ","),
+			"ip_sans":     strings.Join(pki.IPAddressesToString(csr.IPAddresses), ","),
+			"uri_sans":    strings.Join(pki.URLsToString(csr.URIs), ","),
+			"ttl":         duration.String(),
+			"csr":         string(csrPEM),
+
+			"exclude_cn_from_sans": "true",
+		}
+	}
 
-	if err := request.SetJSONBody(parameters); err != nil {
-		return nil, nil, fmt.Errorf("failed to build vault request: %s", err)
+	if err := v.reauthenticateIfExpired(ctx); err != nil {
+		return nil, nil, err
 	}
 
-	resp, err := v.client.RawRequest(request)
+	resp, err := v.signRequest(signPath, parameters)
+	if isForbidden(err) && v.issuer.GetSpec().Vault.Auth.TokenSecretRef == nil {
+		// The token may have been revoked, or may have expired earlier than
+		// expected, so log in again and retry once.
+		if err := v.login(ctx); err != nil {
+			return nil, nil, err
+		}
+		resp, err = v.signRequest(signPath, parameters)
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %s", err)
 	}
@@ -145,7 +217,169 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 	return extractCertificatesFromVaultCertificateSecret(&vaultResult)
 }
 
-func (v *Vault) setToken(client Client) error {
+// signRequest sends a request with the given parameters to the given signing
+// path, using the current token of the client.
+func (v *Vault) signRequest(signPath string, parameters map[string]string) (*vault.Response, error) {
+	url := path.Join("/v1", signPath)
+
+	request := v.client.NewRequest("POST", url)
+
+	v.addVaultNamespaceToRequest(request)
+
+	if err := request.SetJSONBody(parameters); err != nil {
+		return nil, fmt.Errorf("failed to build vault request: %s", err)
+	}
+
+	return v.client.RawRequest(request)
+}
+
+// SignPath returns the path of the Vault PKI endpoint used to sign requests
+// for the issuer. If role is not empty it replaces the role in the path of the
+// issuer, and must be allowed by the issuer. If the issuer signs verbatim, the
+// `sign-verbatim` endpoint of the PKI mount is used.
+func SignPath(vaultIssuer *v1.VaultIssuer, role string) (string, error) {
+	if role == "" && !vaultIssuer.SignVerbatim {
+		return vaultIssuer.Path, nil
+	}
+
+	mount, issuerRole, ok := SplitSignPath(vaultIssuer.Path)
+	if !ok {
+		return "", fmt.Errorf("vault path %q must be of the form <mount>/sign/<role> to select a role or sign verbatim", vaultIssuer.Path)
+	}
+
+	if role == "" {
+		role = issuerRole
+	}
+
+	if role != issuerRole && !containsString(vaultIssuer.AllowedRoles, role) {
+		return "", fmt.Errorf("vault role %q is not allowed by the issuer", role)
+	}
+
+	endpoint := "sign"
+	if vaultIssuer.SignVerbatim {
+		endpoint = "sign-verbatim"
+	}
+
+	return path.Join(mount, endpoint, role), nil
+}
+
+// SplitSignPath splits a Vault path of the form "<mount>/sign/<role>" into the
+// PKI mount and the role. It returns false if the path is not of that form.
+func SplitSignPath(signPath string) (mount, role string, ok bool) {
+	signPath = strings.Trim(signPath, "/")
+
+	i := strings.LastIndex(signPath, "/sign/")
+	if i <= 0 {
+		return "", "", false
+	}
+
+	mount, role = signPath[:i], signPath[i+len("/sign/"):]
+	if role == "" || strings.Contains(role, "/") {
+		return "", "", false
+	}
+
+	return mount, role, true
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// vaultKeyUsages are the names Vault uses for key usages, in the order of the
+// bits of x509.KeyUsage.
+var vaultKeyUsages = []string{
+	"DigitalSignature",
+	"ContentCommitment",
+	"KeyEncipherment",
+	"DataEncipherment",
+	"KeyAgreement",
+	"CertSign",
+	"CRLSign",
+	"EncipherOnly",
+	"DecipherOnly",
+}
+
+// vaultExtKeyUsages are the names Vault uses for extended key usages.
+var vaultExtKeyUsages = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:                            "Any",
+	x509.ExtKeyUsageServerAuth:                     "ServerAuth",
+	x509.ExtKeyUsageClientAuth:                     "ClientAuth",
+	x509.ExtKeyUsageCodeSigning:                    "CodeSigning",
+	x509.ExtKeyUsageEmailProtection:                "EmailProtection",
+	x509.ExtKeyUsageIPSECEndSystem:                 "IPSECEndSystem",
+	x509.ExtKeyUsageIPSECTunnel:                    "IPSECTunnel",
+	x509.ExtKeyUsageIPSECUser:                      "IPSECUser",
+	x509.ExtKeyUsageTimeStamping:                   "TimeStamping",
+	x509.ExtKeyUsageOCSPSigning:                    "OCSPSigning",
+	x509.ExtKeyUsageMicrosoftServerGatedCrypto:     "MicrosoftServerGatedCrypto",
+	x509.ExtKeyUsageNetscapeServerGatedCrypto:      "NetscapeServerGatedCrypto",
+	x509.ExtKeyUsageMicrosoftCommercialCodeSigning: "MicrosoftCommercialCodeSigning",
+	x509.ExtKeyUsageMicrosoftKernelCodeSigning:     "MicrosoftKernelCodeSigning",
+}
+
+// signVerbatimParameters returns the parameters of a request to the
+// `sign-verbatim` endpoint. The subject and SANs are taken from the CSR by
+// Vault, but the key usages have to be passed explicitly, so they are read
+// from the extensions requested in the CSR.
+func signVerbatimParameters(csr *x509.CertificateRequest, csrPEM []byte, duration time.Duration) (map[string]string, error) {
+	parameters := map[string]string{
+		"ttl": duration.String(),
+		"csr": string(csrPEM),
+	}
+
+	for _, ext := range csr.Extensions {
+		switch {
+		case ext.Id.Equal(pki.OIDExtensionKeyUsage):
+			var bits asn1.BitString
+			if _, err := asn1.Unmarshal(ext.Value, &bits); err != nil {
+				return nil, fmt.Errorf("failed to parse key usage extension of CSR: %s", err)
+			}
+
+			var usages []string
+			for i, name := range vaultKeyUsages {
+				if bits.At(i) != 0 {
+					usages = append(usages, name)
+				}
+			}
+			parameters["key_usage"] = strings.Join(usages, ",")
+
+		case ext.Id.Equal(pki.OIDExtensionExtendedKeyUsage):
+			var oids []asn1.ObjectIdentifier
+			if _, err := asn1.Unmarshal(ext.Value, &oids); err != nil {
+				return nil, fmt.Errorf("failed to parse extended key usage extension of CSR: %s", err)
+			}
+
+			var usages, unknown []string
+			for _, oid := range oids {
+				if eku, ok := pki.ExtKeyUsageFromOID(oid); ok {
+					usages = append(usages, vaultExtKeyUsages[eku])
+				} else {
+					unknown = append(unknown, oid.String())
+				}
+			}
+			parameters["ext_key_usage"] = strings.Join(usages, ",")
+			if len(unknown) > 0 {
+				parameters["ext_key_usage_oids"] = strings.Join(unknown, ",")
+			}
+		}
+	}
+
+	return parameters, nil
+}
+
+// isForbidden returns true if err is a permission denied response from Vault,
+// which is returned when the presented token is invalid.
+func isForbidden(err error) bool {
+	var respErr *vault.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden
+}
+
+func (v *Vault) setToken(ctx context.Context, client Client) error {
 	tokenRef := v.issuer.GetSpec().Vault.Auth.TokenSecretRef
 	if tokenRef != nil {
 		token, err := v.tokenRef(tokenRef.Name, v.namespace, tokenRef.Key)
@@ -178,13 +412,70 @@ func (v *Vault) setToken(client Client) error {
 		return nil
 	}
 
-	return fmt.Errorf("error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set")
+	jwtAuth := v.issuer.GetSpec().Vault.Auth.JWT
+	if jwtAuth != nil {
+		token, err := v.requestTokenWithJWTAuth(ctx, client, jwtAuth)
+		if err != nil {
+			return fmt.Errorf("error authenticating with ServiceAccount %s token: %s", jwtAuth.ServiceAccountRef.Name, err.Error())
+		}
+		client.SetToken(token)
+		return nil
+	}
+
+	clientCertificate := v.issuer.GetSpec().Vault.Auth.ClientCertificate
+	if clientCertificate != nil {
+		token, err := v.requestTokenWithClientCertificate(client, clientCertificate)
+		if err != nil {
+			return fmt.Errorf("error logging in with client certificate from %s: %s", clientCertificate.SecretName, err.Error())
+		}
+		client.SetToken(token)
+		return nil
+	}
+
+	return fmt.Errorf("error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes, JWT or client certificate auth not set")
+}
+
+// reauthenticateIfExpired logs in to Vault again if the current token was
+// obtained by logging in and is about to expire.
+func (v *Vault) reauthenticateIfExpired(ctx context.Context) error {
+	if v.tokenExpiry.IsZero() || v.clock.Now().Add(tokenExpiryMargin).Before(v.tokenExpiry) {
+		return nil
+	}
+
+	return v.login(ctx)
+}
+
+// recordLease records the lifetime of a token obtained by logging in to Vault
+// or renewing a token, so that it can be renewed before it expires.
+func (v *Vault) recordLease(secret *vault.Secret) {
+	v.tokenExpiry = time.Time{}
+	v.tokenTTL = 0
+	v.renewable = false
+
+	// A lease duration of zero means that the token does not expire.
+	if secret.Auth == nil || secret.Auth.LeaseDuration <= 0 {
+		return
+	}
+
+	v.tokenTTL = time.Duration(secret.Auth.LeaseDuration) * time.Second
+	v.tokenExpiry = v.clock.Now().Add(v.tokenTTL)
+	v.renewable = secret.Auth.Renewable
 }
 
 func (v *Vault) newConfig() (*vault.Config, error) {
 	cfg := vault.DefaultConfig()
 	cfg.Address = v.issuer.GetSpec().Vault.Server
 
+	tlsConfig := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig
+
+	if clientCertificate := v.issuer.GetSpec().Vault.Auth.ClientCertificate; clientCertificate != nil {
+		certificate, err := v.clientCertificate(clientCertificate.SecretName)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
 	certs := v.issuer.GetSpec().Vault.CABundle
 	if len(certs) == 0 {
 		return cfg, nil
@@ -196,11 +487,30 @@ func (v *Vault) newConfig() (*vault.Config, error) {
 		return nil, fmt.Errorf("error loading Vault CA bundle")
 	}
 
-	cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs = caCertPool
+	tlsConfig.RootCAs = caCertPool
 
 	return cfg, nil
 }
 
+// clientCertificate reads the TLS client certificate and private key used to
+// authenticate with Vault from the named Secret.
+func (v *Vault) clientCertificate(secretName string) (tls.Certificate, error) {
+	certs, key, err := kube.SecretTLSKeyPair(context.TODO(), v.secretsLister, v.namespace, secretName)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	certificate := tls.Certificate{
+		PrivateKey: key,
+		Leaf:       certs[0],
+	}
+	for _, cert := range certs {
+		certificate.Certificate = append(certificate.Certificate, cert.Raw)
+	}
+
+	return certificate, nil
+}
+
 func (v *Vault) tokenRef(name, namespace, key string) (string, error) {
 	secret, err := v.secretsLister.Secrets(namespace).Get(name)
 	if err != nil {
@@ -291,6 +601,8 @@ func (v *Vault) requestTokenWithAppRoleRef(client Client, appRole *v1.VaultAppRo
 		return "", errors.New("no token returned")
 	}
 
+	v.recordLease(&vaultResult)
+
 	return token, nil
 }
 
@@ -312,19 +624,109 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 
 	jwt := string(keyBytes)
 
+	mountPath := kubernetesAuth.Path
+	if mountPath == "" {
+		mountPath = v1.DefaultVaultKubernetesAuthMountPath
+	}
+
+	return v.loginWithJWT(client, mountPath, kubernetesAuth.Role, jwt)
+}
+
+// requestTokenWithJWTAuth requests a short-lived token for the referenced
+// ServiceAccount from the TokenRequest API and uses it to log in to the
+// JWT/OIDC auth method.
+func (v *Vault) requestTokenWithJWTAuth(ctx context.Context, client Client, jwtAuth *v1.VaultJWTAuth) (string, error) {
+	if v.createToken == nil {
+		return "", errors.New("requesting ServiceAccount tokens is not supported")
+	}
+
+	audiences := append([]string{v.defaultAudience()}, jwtAuth.Audiences...)
+	expirationSeconds := int64(jwtTokenExpirationSeconds)
+
+	tokenRequest, err := v.createToken(ctx, jwtAuth.ServiceAccountRef.Name, &authv1.TokenRequest{
+		Spec: authv1.TokenRequestSpec{
+			Audiences:         audiences,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error requesting token: %s", err.Error())
+	}
+
+	mountPath := jwtAuth.Path
+	if mountPath == "" {
+		mountPath = v1.DefaultVaultJWTAuthMountPath
+	}
+
+	return v.loginWithJWT(client, mountPath, jwtAuth.Role, tokenRequest.Status.Token)
+}
+
+// defaultAudience returns the audience that tokens requested for JWT/OIDC
+// auth are always scoped to, so that a token cannot be replayed against
+// another issuer.
+func (v *Vault) defaultAudience() string {
+	if ns := v.issuer.GetObjectMeta().Namespace; ns != "" {
+		return fmt.Sprintf("vault://%s/%s", ns, v.issuer.GetObjectMeta().Name)
+	}
+	return fmt.Sprintf("vault://%s", v.issuer.GetObjectMeta().Name)
+}
+
+// loginWithJWT logs in to the JWT based auth method mounted at mountPath and
+// returns the resulting Vault token.
+func (v *Vault) loginWithJWT(client Client, mountPath, role, jwt string) (string, error) {
 	parameters := map[string]string{
-		"role": kubernetesAuth.Role,
+		"role": role,
 		"jwt":  jwt,
 	}
 
-	mountPath := kubernetesAuth.Path
+	url := filepath.Join(mountPath, "login")
+	request := client.NewRequest("POST", url)
+	err := request.SetJSONBody(parameters)
+	if err != nil {
+		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+	}
+
+	v.addVaultNamespaceToRequest(request)
+
+	resp, err := client.RawRequest(request)
+	if err != nil {
+		return "", fmt.Errorf("error calling Vault server: %s", err.Error())
+	}
+
+	defer resp.Body.Close()
+	vaultResult := vault.Secret{}
+	err = resp.DecodeJSON(&vaultResult)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	token, err := vaultResult.TokenID()
+	if err != nil {
+		return "", fmt.Errorf("unable to read token: %s", err.Error())
+	}
+
+	v.recordLease(&vaultResult)
+
+	return token, nil
+}
+
+// requestTokenWithClientCertificate logs in to the TLS certificate auth method.
+// The client certificate itself is presented during the TLS handshake, so only
+// the optional certificate role name is sent in the request.
+func (v *Vault) requestTokenWithClientCertificate(client Client, clientCertificate *v1.VaultClientCertificateAuth) (string, error) {
+	parameters := map[string]string{}
+	if clientCertificate.Name != "" {
+		parameters["name"] = clientCertificate.Name
+	}
+
+	mountPath := clientCertificate.Path
 	if mountPath == "" {
-		mountPath = v1.DefaultVaultKubernetesAuthMountPath
+		mountPath = v1.DefaultVaultClientCertificateAuthMountPath
 	}
 
 	url := filepath.Join(mountPath, "login")
 	request := client.NewRequest("POST", url)
-	err = request.SetJSONBody(parameters)
+	err := request.SetJSONBody(parameters)
 	if err != nil {
 		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
 	}
@@ -348,6 +750,12 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 		return "", fmt.Errorf("unable to read token: %s", err.Error())
 	}
 
+	if token == "" {
+		return "", errors.New("no token returned")
+	}
+
+	v.recordLease(&vaultResult)
+
 	return token, nil
 }
 
@@ -18,6 +18,7 @@ package vault
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
@@ -28,6 +29,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"strings"
 	"testing"
@@ -36,7 +38,10 @@ import (
 	vault "github.com/hashicorp/vault/api"
 	"github.com/hashicorp/vault/sdk/helper/certutil"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
+	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
@@ -175,6 +180,31 @@ func generateCSR(t *testing.T, secretKey crypto.Signer) []byte {
 	return csr
 }
 
+// generateClientCertificateSecret returns a TLS Secret holding a self-signed
+// client certificate and its private key.
+func generateClientCertificateSecret(t *testing.T) *corev1.Secret {
+	pk := generateRSAPrivateKey(t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "vault-client"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatalf("failed to create client certificate: %s", err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "test-namespace"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(pk)}),
+		},
+	}
+}
+
 type testSignT struct {
 	issuer     *cmapi.Issuer
 	fakeLister *listers.FakeSecretLister
@@ -298,7 +328,7 @@ func TestSign(t *testing.T) {
 			client:        test.fakeClient,
 		}
 
-		cert, ca, err := v.Sign(test.csrPEM, time.Minute)
+		cert, ca, err := v.Sign(context.Background(), test.csrPEM, time.Minute, "")
 		if ((test.expectedErr == nil) != (err == nil)) &&
 			test.expectedErr != nil &&
 			test.expectedErr.Error() != err.Error() {
@@ -331,6 +361,104 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestSignPath(t *testing.T) {
+	tests := map[string]struct {
+		issuer      cmapi.VaultIssuer
+		role        string
+		expPath     string
+		expectedErr error
+	}{
+		"no role and no verbatim should return the issuer path unchanged": {
+			issuer:  cmapi.VaultIssuer{Path: "pki/custom/path"},
+			expPath: "pki/custom/path",
+		},
+		"the issuer role should always be allowed": {
+			issuer:  cmapi.VaultIssuer{Path: "pki/sign/default"},
+			role:    "default",
+			expPath: "pki/sign/default",
+		},
+		"an allowed role should replace the issuer role": {
+			issuer:  cmapi.VaultIssuer{Path: "/v1/pki_int/sign/default", AllowedRoles: []string{"web", "mail"}},
+			role:    "mail",
+			expPath: "v1/pki_int/sign/mail",
+		},
+		"a role not in the allow-list should error": {
+			issuer:      cmapi.VaultIssuer{Path: "pki/sign/default", AllowedRoles: []string{"web"}},
+			role:        "admin",
+			expectedErr: errors.New(`vault role "admin" is not allowed by the issuer`),
+		},
+		"sign verbatim should use the sign-verbatim endpoint of the issuer role": {
+			issuer:  cmapi.VaultIssuer{Path: "pki/sign/default", SignVerbatim: true},
+			expPath: "pki/sign-verbatim/default",
+		},
+		"sign verbatim with an allowed role should use the sign-verbatim endpoint of that role": {
+			issuer:  cmapi.VaultIssuer{Path: "pki/sign/default", SignVerbatim: true, AllowedRoles: []string{"web"}},
+			role:    "web",
+			expPath: "pki/sign-verbatim/web",
+		},
+		"selecting a role with a path not ending in a sign endpoint should error": {
+			issuer:      cmapi.VaultIssuer{Path: "pki/issue/default", AllowedRoles: []string{"web"}},
+			role:        "web",
+			expectedErr: errors.New(`vault path "pki/issue/default" must be of the form <mount>/sign/<role> to select a role or sign verbatim`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := SignPath(&test.issuer, test.role)
+			if test.expectedErr == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if test.expectedErr != nil && (err == nil || err.Error() != test.expectedErr.Error()) {
+				t.Fatalf("unexpected error, exp=%v got=%v", test.expectedErr, err)
+			}
+			if p != test.expPath {
+				t.Errorf("unexpected path, exp=%q got=%q", test.expPath, p)
+			}
+		})
+	}
+}
+
+func TestSignVerbatimParameters(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateKeyUsages(cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth, cmapi.UsageClientAuth),
+	)
+	template, err := pki.GenerateCSR(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := pki.EncodeCSR(template, generateRSAPrivateKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params, err := signVerbatimParameters(csr, csrPEM, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := map[string]string{
+		"ttl":           "1h0m0s",
+		"csr":           string(csrPEM),
+		"key_usage":     "DigitalSignature,KeyEncipherment",
+		"ext_key_usage": "ServerAuth,ClientAuth",
+	}
+	if len(params) != len(exp) {
+		t.Errorf("unexpected parameters, exp=%v got=%v", exp, params)
+	}
+	for k, v := range exp {
+		if params[k] != v {
+			t.Errorf("unexpected value for parameter %q, exp=%q got=%q", k, v, params[k])
+		}
+	}
+}
+
 type testExtractCertificatesFromVaultCertT struct {
 	secret       *certutil.Secret
 	expectedCert string
@@ -375,9 +503,10 @@ type testSetTokenT struct {
 	expectedToken string
 	expectedErr   error
 
-	issuer     *cmapi.Issuer
-	fakeLister *listers.FakeSecretLister
-	fakeClient *vaultfake.Client
+	issuer      cmapi.GenericIssuer
+	fakeLister  *listers.FakeSecretLister
+	fakeClient  *vaultfake.Client
+	createToken CreateTokenFn
 }
 
 func TestSetToken(t *testing.T) {
@@ -398,6 +527,52 @@ func TestSetToken(t *testing.T) {
 			"my-kube-key": []byte("my-secret-kube-token"),
 		},
 	}
+
+	jwtAuth := &cmapi.VaultJWTAuth{
+		Role: "jwt-vault-role",
+		ServiceAccountRef: cmapi.ServiceAccountRef{
+			Name: "vault-sa",
+		},
+		Audiences: []string{"extra-audience"},
+	}
+
+	// createTokenFor returns a CreateTokenFn that only issues a token for the
+	// expected ServiceAccount and audiences.
+	// ctx is passed to setToken, and is expected to be used to request
+	// ServiceAccount tokens
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, true)
+
+	createTokenFor := func(expAudiences ...string) CreateTokenFn {
+		return func(reqCtx context.Context, serviceAccount string, req *authv1.TokenRequest, _ metav1.CreateOptions) (*authv1.TokenRequest, error) {
+			if reqCtx.Value(ctxKey{}) == nil {
+				return nil, errors.New("token requested without the caller's context")
+			}
+			if serviceAccount != "vault-sa" {
+				return nil, fmt.Errorf("unexpected ServiceAccount %q", serviceAccount)
+			}
+			if fmt.Sprint(req.Spec.Audiences) != fmt.Sprint(expAudiences) {
+				return nil, fmt.Errorf("unexpected audiences %v", req.Spec.Audiences)
+			}
+			if req.Spec.ExpirationSeconds == nil || *req.Spec.ExpirationSeconds != jwtTokenExpirationSeconds {
+				return nil, errors.New("unexpected token expiration")
+			}
+			req.Status.Token = "my-sa-token"
+			return req, nil
+		}
+	}
+
+	jwtLoginResponse := func() *vault.Response {
+		return &vault.Response{
+			Response: &http.Response{
+				Body: ioutil.NopCloser(
+					strings.NewReader(
+						`{"request_id":"","lease_id":"","lease_duration":0,"renewable":false,"data":null,"warnings":null,"data":{"id":"my-jwt-token"}}`),
+				),
+			},
+		}
+	}
+
 	tests := map[string]testSetTokenT{
 		"if neither token secret ref, app role secret ref, or kube auth then not found then error": {
 			issuer: gen.Issuer("vault-issuer",
@@ -410,7 +585,7 @@ func TestSetToken(t *testing.T) {
 			fakeClient:    vaultfake.NewFakeClient(),
 			expectedToken: "",
 			expectedErr: errors.New(
-				"error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
+				"error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes, JWT or client certificate auth not set",
 			),
 		},
 
@@ -621,6 +796,58 @@ func TestSetToken(t *testing.T) {
 			expectedErr:   nil,
 		},
 
+		"if jwt auth set but requesting a token fails should error": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerNamespace("test-namespace"),
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					CABundle: []byte(testLeafCertificate),
+					Auth: cmapi.VaultAuth{
+						JWT: jwtAuth,
+					},
+				}),
+			),
+			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister()),
+			fakeClient: vaultfake.NewFakeClient(),
+			createToken: func(context.Context, string, *authv1.TokenRequest, metav1.CreateOptions) (*authv1.TokenRequest, error) {
+				return nil, errors.New("forbidden")
+			},
+			expectedToken: "",
+			expectedErr:   errors.New("error authenticating with ServiceAccount vault-sa token: error requesting token: forbidden"),
+		},
+
+		"if jwt auth set on an Issuer should request a namespaced audience token and log in": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerNamespace("test-namespace"),
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					CABundle: []byte(testLeafCertificate),
+					Auth: cmapi.VaultAuth{
+						JWT: jwtAuth,
+					},
+				}),
+			),
+			fakeLister:    listers.FakeSecretListerFrom(listers.NewFakeSecretLister()),
+			fakeClient:    vaultfake.NewFakeClient().WithRawRequest(jwtLoginResponse(), nil),
+			createToken:   createTokenFor("vault://test-namespace/vault-issuer", "extra-audience"),
+			expectedToken: "my-jwt-token",
+			expectedErr:   nil,
+		},
+
+		"if jwt auth set on a ClusterIssuer should request a cluster audience token and log in": {
+			issuer: gen.ClusterIssuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					CABundle: []byte(testLeafCertificate),
+					Auth: cmapi.VaultAuth{
+						JWT: jwtAuth,
+					},
+				}),
+			),
+			fakeLister:    listers.FakeSecretListerFrom(listers.NewFakeSecretLister()),
+			fakeClient:    vaultfake.NewFakeClient().WithRawRequest(jwtLoginResponse(), nil),
+			createToken:   createTokenFor("vault://vault-issuer", "extra-audience"),
+			expectedToken: "my-jwt-token",
+			expectedErr:   nil,
+		},
+
 		"if app role secret ref and token secret set, take preference on token secret": {
 			issuer: gen.Issuer("vault-issuer",
 				gen.SetIssuerVault(cmapi.VaultIssuer{
@@ -658,10 +885,12 @@ func TestSetToken(t *testing.T) {
 			v := &Vault{
 				namespace:     "test-namespace",
 				secretsLister: test.fakeLister,
+				createToken:   test.createToken,
 				issuer:        test.issuer,
+				clock:         fakeclock.NewFakeClock(time.Now()),
 			}
 
-			err := v.setToken(test.fakeClient)
+			err := v.setToken(ctx, test.fakeClient)
 			if ((test.expectedErr == nil) != (err == nil)) &&
 				test.expectedErr != nil &&
 				test.expectedErr.Error() != err.Error() {
@@ -878,10 +1107,13 @@ func TestTokenRef(t *testing.T) {
 type testNewConfigT struct {
 	expectedErr error
 	issuer      *cmapi.Issuer
+	fakeLister  *listers.FakeSecretLister
 	checkFunc   func(cfg *vault.Config) error
 }
 
 func TestNewConfig(t *testing.T) {
+	clientCertSecret := generateClientCertificateSecret(t)
+
 	tests := map[string]testNewConfigT{
 		"no CA bundle set in issuer should return nil": {
 			issuer: gen.Issuer("vault-issuer",
@@ -927,14 +1159,58 @@ func TestNewConfig(t *testing.T) {
 				return nil
 			},
 		},
+
+		"a missing client certificate secret should error": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					Auth: cmapi.VaultAuth{
+						ClientCertificate: &cmapi.VaultClientCertificateAuth{
+							SecretName: "client-cert",
+						},
+					},
+				}),
+			),
+			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+				listers.SetFakeSecretNamespaceListerGet(nil, errors.New("secret does not exist")),
+			),
+			expectedErr: errors.New("secret does not exist"),
+		},
+
+		"a client certificate should be added to the config": {
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					Auth: cmapi.VaultAuth{
+						ClientCertificate: &cmapi.VaultClientCertificateAuth{
+							SecretName: "client-cert",
+						},
+					},
+				}),
+			),
+			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+				listers.SetFakeSecretNamespaceListerGet(clientCertSecret, nil),
+			),
+			expectedErr: nil,
+			checkFunc: func(cfg *vault.Config) error {
+				certs := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.Certificates
+				if len(certs) != 1 || certs[0].Leaf == nil || certs[0].Leaf.Subject.CommonName != "vault-client" {
+					return fmt.Errorf("got unexpected client certificates in config: %v", certs)
+				}
+				if certs[0].PrivateKey == nil {
+					return errors.New("expected client certificate private key to be set")
+				}
+				return nil
+			},
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			v := &Vault{
-				namespace:     "test-namespace",
-				secretsLister: nil,
-				issuer:        test.issuer,
+				namespace: "test-namespace",
+				issuer:    test.issuer,
+			}
+			if test.fakeLister != nil {
+				v.secretsLister = test.fakeLister
 			}
 
 			cfg, err := v.newConfig()
@@ -1064,6 +1340,7 @@ func TestRequestTokenWithAppRoleRef(t *testing.T) {
 				issuer: gen.Issuer("vault-issuer",
 					gen.SetIssuerNamespace("namespace"),
 				),
+				clock: fakeclock.NewFakeClock(time.Now()),
 			}
 
 			token, err := v.requestTokenWithAppRoleRef(test.client, test.appRole)
@@ -1081,3 +1358,156 @@ func TestRequestTokenWithAppRoleRef(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestTokenWithClientCertificate(t *testing.T) {
+	loginResponse := func(body string) *vault.Response {
+		return &vault.Response{
+			Response: &http.Response{
+				Body: ioutil.NopCloser(strings.NewReader(body)),
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		client            *vaultfake.Client
+		clientCertificate *cmapi.VaultClientCertificateAuth
+
+		expectedToken      string
+		expectedTTL        time.Duration
+		expectedParameters map[string]string
+		expectedErr        error
+	}{
+		"a failed login request should error": {
+			client:            vaultfake.NewFakeClient().WithRawRequest(nil, errors.New("request failed")),
+			clientCertificate: &cmapi.VaultClientCertificateAuth{SecretName: "client-cert"},
+			expectedErr:       errors.New("error calling Vault server: request failed"),
+		},
+		"no token returned should error": {
+			client: vaultfake.NewFakeClient().WithRawRequest(
+				loginResponse(`{"auth":{"client_token":"","lease_duration":3600}}`), nil),
+			clientCertificate: &cmapi.VaultClientCertificateAuth{SecretName: "client-cert"},
+			expectedErr:       errors.New("no token returned"),
+		},
+		"a token should be returned with its TTL": {
+			client: vaultfake.NewFakeClient().WithRawRequest(
+				loginResponse(`{"auth":{"client_token":"my-cert-token","lease_duration":3600}}`), nil),
+			clientCertificate:  &cmapi.VaultClientCertificateAuth{SecretName: "client-cert"},
+			expectedToken:      "my-cert-token",
+			expectedTTL:        time.Hour,
+			expectedParameters: map[string]string{},
+		},
+		"the certificate role name should be sent if set": {
+			client: vaultfake.NewFakeClient().WithRawRequest(
+				loginResponse(`{"auth":{"client_token":"my-cert-token","lease_duration":60}}`), nil),
+			clientCertificate: &cmapi.VaultClientCertificateAuth{
+				SecretName: "client-cert",
+				Name:       "web",
+			},
+			expectedToken:      "my-cert-token",
+			expectedTTL:        time.Minute,
+			expectedParameters: map[string]string{"name": "web"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &Vault{
+				namespace: "test-namespace",
+				issuer:    gen.Issuer("vault-issuer", gen.SetIssuerVault(cmapi.VaultIssuer{})),
+				clock:     fakeclock.NewFakeClock(time.Now()),
+			}
+
+			token, err := v.requestTokenWithClientCertificate(test.client, test.clientCertificate)
+			if (test.expectedErr == nil) != (err == nil) ||
+				(err != nil && test.expectedErr.Error() != err.Error()) {
+				t.Errorf("unexpected error, exp=%v got=%v", test.expectedErr, err)
+			}
+
+			if token != test.expectedToken {
+				t.Errorf("got unexpected token, exp=%s got=%s", test.expectedToken, token)
+			}
+
+			if v.tokenTTL != test.expectedTTL {
+				t.Errorf("got unexpected TTL, exp=%s got=%s", test.expectedTTL, v.tokenTTL)
+			}
+
+			if test.expectedParameters != nil {
+				if got := fmt.Sprint(test.client.NewRequestS.Obj); got != fmt.Sprint(test.expectedParameters) {
+					t.Errorf("got unexpected login parameters, exp=%v got=%s", test.expectedParameters, got)
+				}
+			}
+		})
+	}
+}
+
+func TestReauthenticateIfExpired(t *testing.T) {
+	now := time.Now()
+
+	tests := map[string]struct {
+		tokenExpiry time.Time
+
+		expectedToken  string
+		expectedExpiry time.Time
+	}{
+		"a token without expiry should not be renewed": {
+			tokenExpiry:    time.Time{},
+			expectedToken:  "old-token",
+			expectedExpiry: time.Time{},
+		},
+		"a token that is not about to expire should not be renewed": {
+			tokenExpiry:    now.Add(time.Minute),
+			expectedToken:  "old-token",
+			expectedExpiry: now.Add(time.Minute),
+		},
+		"a token that is about to expire should be renewed": {
+			tokenExpiry:    now.Add(tokenExpiryMargin / 2),
+			expectedToken:  "new-token",
+			expectedExpiry: now.Add(time.Hour),
+		},
+		"an expired token should be renewed": {
+			tokenExpiry:    now.Add(-time.Minute),
+			expectedToken:  "new-token",
+			expectedExpiry: now.Add(time.Hour),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := vaultfake.NewFakeClient().WithRawRequest(&vault.Response{
+				Response: &http.Response{
+					Body: ioutil.NopCloser(strings.NewReader(
+						`{"auth":{"client_token":"new-token","lease_duration":3600}}`)),
+				},
+			}, nil)
+			client.SetToken("old-token")
+
+			v := &Vault{
+				namespace: "test-namespace",
+				issuer: gen.Issuer("vault-issuer",
+					gen.SetIssuerVault(cmapi.VaultIssuer{
+						Auth: cmapi.VaultAuth{
+							ClientCertificate: &cmapi.VaultClientCertificateAuth{
+								SecretName: "client-cert",
+							},
+						},
+					}),
+				),
+				clock:       fakeclock.NewFakeClock(now),
+				client:      client,
+				tokenExpiry: test.tokenExpiry,
+			}
+
+			if err := v.reauthenticateIfExpired(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if client.Token() != test.expectedToken {
+				t.Errorf("got unexpected token, exp=%s got=%s", test.expectedToken, client.Token())
+			}
+
+			if !v.tokenExpiry.Equal(test.expectedExpiry) {
+				t.Errorf("got unexpected token expiry, exp=%s got=%s", test.expectedExpiry, v.tokenExpiry)
+			}
+		})
+	}
+}
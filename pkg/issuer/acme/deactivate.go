@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/rsa"
+	"net/http"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/jetstack/cert-manager/pkg/acme"
+	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/errors"
+)
+
+const (
+	successAccountDeactivated         = "ACMEAccountDeactivated"
+	messageTemplateAccountDeactivated = "The ACME account %q was deactivated"
+)
+
+var _ issuer.AccountDeactivator = &Acme{}
+
+// DeactivateAccount deactivates the issuer's ACME account with the ACME
+// server. Requests rejected by the ACME server, other than due to rate
+// limiting, are returned as InvalidData errors.
+func (a *Acme) DeactivateAccount(ctx context.Context) error {
+	log := logf.FromContext(ctx, "deactivateAccount")
+
+	accountURL := a.issuer.GetStatus().ACMEStatus().URI
+	if accountURL == "" {
+		log.V(logf.DebugLevel).Info("not deactivating ACME account as it has not been registered")
+		return nil
+	}
+
+	cl, err := a.accountRegistry.GetClient(string(a.issuer.GetUID()))
+	if err == accounts.ErrNotFound {
+		cl, err = a.accountClient(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.V(logf.InfoLevel).Info("deactivating ACME account", "account", accountURL)
+
+	err = cl.DeactivateReg(ctx)
+	if err == acmeapi.ErrNoAccount {
+		log.V(logf.InfoLevel).Info("ACME account does not exist, nothing to deactivate")
+		return nil
+	}
+	if acmeErr, ok := err.(*acmeapi.Error); ok {
+		// 4xx errors (other than rate limiting) indicate that the ACME server
+		// will never accept this request, e.g. because the account has
+		// already been deactivated.
+		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 && acmeErr.StatusCode != http.StatusTooManyRequests {
+			return errors.NewInvalidData("%v", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
+	a.recorder.Eventf(a.issuer, corev1.EventTypeNormal, successAccountDeactivated, messageTemplateAccountDeactivated, accountURL)
+
+	return nil
+}
+
+// accountClient builds an ACME client for the issuer's account from the
+// account key stored in the Secret referenced by `privateKeySecretRef`.
+func (a *Acme) accountClient(ctx context.Context) (acmecl.Interface, error) {
+	ns := a.issuer.GetObjectMeta().Namespace
+	if ns == "" {
+		ns = a.clusterResourceNamespace
+	}
+
+	spec := a.issuer.GetSpec().ACME
+	selector := acme.PrivateKeySelector(spec.PrivateKey)
+	pk, err := a.keyFromSecret(ctx, ns, selector.Name, selector.Key)
+	if apierrors.IsNotFound(err) {
+		return nil, errors.NewInvalidData("ACME account key Secret %q does not exist", selector.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	rsaPk, ok := pk.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.NewInvalidData(messageTemplateNotRSA, selector.Name)
+	}
+
+	httpClient := accounts.BuildHTTPClient(a.metrics, spec.SkipTLSVerify)
+	return a.clientBuilder(httpClient, *spec, rsaPk), nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	acmeapi "golang.org/x/crypto/acme"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	fakeregistry "github.com/jetstack/cert-manager/pkg/acme/accounts/test"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	controllertest "github.com/jetstack/cert-manager/pkg/controller/test"
+	cmerrors "github.com/jetstack/cert-manager/pkg/util/errors"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestDeactivateAccount(t *testing.T) {
+	const accountURL = "https://acme.example.com/acct/1"
+	rsaKey := mustGenerateRSAKey(t)
+	ecdsaKey := mustGenerateEDCSAKey(t)
+
+	tests := map[string]struct {
+		accountURL    string
+		getClientErr  error
+		accountKey    crypto.Signer
+		accountKeyErr error
+		deactivateErr error
+
+		expectedDeactivate bool
+		expectedRemove     bool
+		expectedEvents     []string
+		wantsErr           bool
+		wantsInvalidData   bool
+	}{
+		"does nothing if the account has not been registered": {},
+		"deactivates the account using the registered ACME client": {
+			accountURL:         accountURL,
+			expectedDeactivate: true,
+			expectedRemove:     true,
+			expectedEvents:     []string{`Normal ACMEAccountDeactivated The ACME account "https://acme.example.com/acct/1" was deactivated`},
+		},
+		"deactivates the account using the stored account key if no ACME client has been registered": {
+			accountURL:         accountURL,
+			getClientErr:       accounts.ErrNotFound,
+			accountKey:         rsaKey,
+			expectedDeactivate: true,
+			expectedRemove:     true,
+			expectedEvents:     []string{`Normal ACMEAccountDeactivated The ACME account "https://acme.example.com/acct/1" was deactivated`},
+		},
+		"fails if no ACME client has been registered and the account key Secret does not exist": {
+			accountURL:       accountURL,
+			getClientErr:     accounts.ErrNotFound,
+			accountKeyErr:    apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "account-key"),
+			wantsErr:         true,
+			wantsInvalidData: true,
+		},
+		"fails if no ACME client has been registered and the account key is not an RSA key": {
+			accountURL:       accountURL,
+			getClientErr:     accounts.ErrNotFound,
+			accountKey:       ecdsaKey,
+			wantsErr:         true,
+			wantsInvalidData: true,
+		},
+		"does nothing if the account does not exist on the ACME server": {
+			accountURL:         accountURL,
+			deactivateErr:      acmeapi.ErrNoAccount,
+			expectedDeactivate: true,
+		},
+		"fails without retrying if the ACME server rejects the request": {
+			accountURL:         accountURL,
+			deactivateErr:      &acmeapi.Error{StatusCode: http.StatusUnauthorized, ProblemType: "urn:ietf:params:acme:error:unauthorized"},
+			expectedDeactivate: true,
+			wantsErr:           true,
+			wantsInvalidData:   true,
+		},
+		"fails and retries if the ACME server is rate limiting": {
+			accountURL:         accountURL,
+			deactivateErr:      &acmeapi.Error{StatusCode: http.StatusTooManyRequests, ProblemType: "urn:ietf:params:acme:error:rateLimited"},
+			expectedDeactivate: true,
+			wantsErr:           true,
+		},
+		"fails and retries if the ACME server cannot be reached": {
+			accountURL:         accountURL,
+			deactivateErr:      errors.New("connection refused"),
+			expectedDeactivate: true,
+			wantsErr:           true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := gen.Issuer("test-issuer",
+				gen.SetIssuerNamespace("testns"),
+				gen.SetIssuerACME(cmacme.ACMEIssuer{
+					PrivateKey: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "account-key"}},
+				}),
+			)
+			iss.UID = "test-uid"
+			iss.Status.ACME = &cmacme.ACMEIssuerStatus{URI: test.accountURL}
+
+			deactivateCalled := false
+			cl := &acmecl.FakeACME{
+				FakeDeactivateReg: func(context.Context) error {
+					deactivateCalled = true
+					return test.deactivateErr
+				},
+			}
+
+			removeCalled := false
+			recorder := new(controllertest.FakeRecorder)
+			a := Acme{
+				issuer:   iss,
+				recorder: recorder,
+				accountRegistry: &fakeregistry.FakeRegistry{
+					GetClientFunc: func(uid string) (acmecl.Interface, error) {
+						if uid != "test-uid" {
+							t.Errorf("unexpected uid %q", uid)
+						}
+						if test.getClientErr != nil {
+							return nil, test.getClientErr
+						}
+						return cl, nil
+					},
+					RemoveClientFunc: func(uid string) {
+						removeCalled = true
+					},
+				},
+				keyFromSecret: func(_ context.Context, namespace, name, _ string) (crypto.Signer, error) {
+					if namespace != "testns" || name != "account-key" {
+						t.Errorf("unexpected key reference %s/%s", namespace, name)
+					}
+					return test.accountKey, test.accountKeyErr
+				},
+				clientBuilder: clientBuilderMock(cl),
+			}
+
+			err := a.DeactivateAccount(context.Background())
+			if (err != nil) != test.wantsErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.wantsErr, err)
+			}
+			if cmerrors.IsInvalidData(err) != test.wantsInvalidData {
+				t.Errorf("unexpected InvalidData error, exp=%t got=%v", test.wantsInvalidData, err)
+			}
+			if deactivateCalled != test.expectedDeactivate {
+				t.Errorf("unexpected deactivation, exp=%t got=%t", test.expectedDeactivate, deactivateCalled)
+			}
+			if removeCalled != test.expectedRemove {
+				t.Errorf("unexpected removal of the registered client, exp=%t got=%t", test.expectedRemove, removeCalled)
+			}
+			if !reflect.DeepEqual(recorder.Events, test.expectedEvents) {
+				t.Errorf("unexpected events, exp=%v got=%v", test.expectedEvents, recorder.Events)
+			}
+		})
+	}
+}
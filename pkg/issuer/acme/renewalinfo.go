@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+var _ issuer.RenewalInfoProvider = &Acme{}
+
+// RenewalInfo retrieves the renewal window suggested by the ACME server for
+// the given certificate from the renewalInfo endpoint defined by the ACME
+// Renewal Information (ARI) extension. ErrRenewalInfoNotSupported is
+// returned if the ACME server does not implement the extension.
+func (a *Acme) RenewalInfo(ctx context.Context, cert *x509.Certificate) (*issuer.RenewalInfo, error) {
+	log := logf.FromContext(ctx, "renewalInfo")
+
+	cl, err := a.accountRegistry.GetClient(string(a.issuer.GetUID()))
+	if err == accounts.ErrNotFound {
+		cl, err = a.accountClient(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.V(logf.DebugLevel).Info("retrieving certificate renewal information", "server", a.issuer.GetSpec().ACME.Server)
+	info, err := cl.RenewalInfo(ctx, cert)
+	if err == acmecl.ErrRenewalInfoNotSupported {
+		return nil, issuer.ErrRenewalInfoNotSupported
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &issuer.RenewalInfo{
+		SuggestedWindowStart: info.SuggestedWindowStart,
+		SuggestedWindowEnd:   info.SuggestedWindowEnd,
+		ExplanationURL:       info.ExplanationURL,
+		RetryAfter:           info.RetryAfter,
+	}, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	fakeregistry "github.com/jetstack/cert-manager/pkg/acme/accounts/test"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestRenewalInfo(t *testing.T) {
+	start := time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)
+	info := &acmecl.RenewalInfo{
+		SuggestedWindowStart: start,
+		SuggestedWindowEnd:   start.Add(48 * time.Hour),
+		ExplanationURL:       "https://example.com/incident",
+		RetryAfter:           time.Hour,
+	}
+
+	tests := map[string]struct {
+		getClientErr   error
+		renewalInfo    *acmecl.RenewalInfo
+		renewalInfoErr error
+
+		expected    *issuer.RenewalInfo
+		expectedErr error
+		wantsErr    bool
+	}{
+		"returns the renewal information retrieved by the registered ACME client": {
+			renewalInfo: info,
+			expected: &issuer.RenewalInfo{
+				SuggestedWindowStart: info.SuggestedWindowStart,
+				SuggestedWindowEnd:   info.SuggestedWindowEnd,
+				ExplanationURL:       info.ExplanationURL,
+				RetryAfter:           info.RetryAfter,
+			},
+		},
+		"uses the stored account key if no ACME client has been registered": {
+			getClientErr: accounts.ErrNotFound,
+			renewalInfo:  info,
+			expected: &issuer.RenewalInfo{
+				SuggestedWindowStart: info.SuggestedWindowStart,
+				SuggestedWindowEnd:   info.SuggestedWindowEnd,
+				ExplanationURL:       info.ExplanationURL,
+				RetryAfter:           info.RetryAfter,
+			},
+		},
+		"returns ErrRenewalInfoNotSupported if the ACME server does not support renewal information": {
+			renewalInfoErr: acmecl.ErrRenewalInfoNotSupported,
+			expectedErr:    issuer.ErrRenewalInfoNotSupported,
+			wantsErr:       true,
+		},
+		"returns errors of the ACME client": {
+			renewalInfoErr: errors.New("connection refused"),
+			wantsErr:       true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := gen.Issuer("test-issuer",
+				gen.SetIssuerNamespace("testns"),
+				gen.SetIssuerACME(cmacme.ACMEIssuer{
+					PrivateKey: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "account-key"}},
+				}),
+			)
+			iss.UID = "test-uid"
+
+			cert := &x509.Certificate{}
+			cl := &acmecl.FakeACME{
+				FakeRenewalInfo: func(_ context.Context, c *x509.Certificate) (*acmecl.RenewalInfo, error) {
+					if c != cert {
+						t.Errorf("unexpected certificate %v", c)
+					}
+					return test.renewalInfo, test.renewalInfoErr
+				},
+			}
+
+			a := Acme{
+				issuer: iss,
+				accountRegistry: &fakeregistry.FakeRegistry{
+					GetClientFunc: func(uid string) (acmecl.Interface, error) {
+						if test.getClientErr != nil {
+							return nil, test.getClientErr
+						}
+						return cl, nil
+					},
+				},
+				keyFromSecret: func(context.Context, string, string, string) (crypto.Signer, error) {
+					return mustGenerateRSAKey(t), nil
+				},
+				clientBuilder: clientBuilderMock(cl),
+			}
+
+			got, err := a.RenewalInfo(context.Background(), cert)
+			if (err != nil) != test.wantsErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.wantsErr, err)
+			}
+			if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+				t.Errorf("unexpected error, exp=%v got=%v", test.expectedErr, err)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("unexpected renewal info, exp=%+v got=%+v", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+var _ issuer.Revoker = &Acme{}
+
+// Revoke asks the ACME server to revoke the given DER encoded certificate.
+// The request is signed using the issuer's account key, so the certificate
+// must have been issued to the same ACME account. Certificates that have
+// already been revoked are not treated as an error by the ACME client.
+func (a *Acme) Revoke(ctx context.Context, cert []byte, reason pki.RevocationReason) error {
+	log := logf.FromContext(ctx, "revoke")
+
+	cl, err := a.accountRegistry.GetClient(string(a.issuer.GetUID()))
+	if err != nil {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("revoking certificate", "reason", reason.String())
+
+	// A nil key means the request is signed using the ACME account key.
+	err = cl.RevokeCert(ctx, nil, cert, acme.CRLReasonCode(reason))
+	if acmeErr, ok := err.(*acme.Error); ok {
+		// 4xx errors (other than rate limiting) indicate that the ACME server
+		// will never accept this request, e.g. because the certificate was
+		// not issued to this account.
+		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 && acmeErr.StatusCode != http.StatusTooManyRequests {
+			return &issuer.RevocationRejectedError{Err: err}
+		}
+	}
+	return err
+}
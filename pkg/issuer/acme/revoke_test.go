@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"errors"
+	"net/http"
+	"testing"
+
+	acmeapi "golang.org/x/crypto/acme"
+
+	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	fakeregistry "github.com/jetstack/cert-manager/pkg/acme/accounts/test"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestRevoke(t *testing.T) {
+	certDER := []byte("certificate")
+
+	tests := map[string]struct {
+		getClientErr error
+		revokeErr    error
+		wantsErr     bool
+		wantsReject  bool
+	}{
+		"revokes the certificate using the registered ACME client": {},
+		"returns an error if no ACME client has been registered for the issuer": {
+			getClientErr: accounts.ErrNotFound,
+			wantsErr:     true,
+		},
+		"returns an error if the ACME server fails to revoke the certificate": {
+			revokeErr: errors.New("connection refused"),
+			wantsErr:  true,
+		},
+		"returns a RevocationRejectedError if the ACME server rejects the request": {
+			revokeErr:   &acmeapi.Error{StatusCode: http.StatusForbidden, ProblemType: "urn:ietf:params:acme:error:unauthorized"},
+			wantsErr:    true,
+			wantsReject: true,
+		},
+		"does not treat rate limiting as a rejection": {
+			revokeErr: &acmeapi.Error{StatusCode: http.StatusTooManyRequests, ProblemType: "urn:ietf:params:acme:error:rateLimited"},
+			wantsErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := gen.Issuer("test-issuer", gen.SetIssuerACME(cmacme.ACMEIssuer{}))
+			iss.UID = "test-uid"
+
+			revokeCalled := false
+			cl := &acmecl.FakeACME{
+				FakeRevokeCert: func(_ context.Context, key crypto.Signer, cert []byte, reason acmeapi.CRLReasonCode) error {
+					revokeCalled = true
+					if key != nil {
+						t.Errorf("expected the account key to be used to sign the request")
+					}
+					if !bytes.Equal(cert, certDER) {
+						t.Errorf("unexpected certificate passed to RevokeCert")
+					}
+					if reason != acmeapi.CRLReasonKeyCompromise {
+						t.Errorf("unexpected reason, exp=%d got=%d", acmeapi.CRLReasonKeyCompromise, reason)
+					}
+					return test.revokeErr
+				},
+			}
+			ar := &fakeregistry.FakeRegistry{
+				GetClientFunc: func(uid string) (acmecl.Interface, error) {
+					if uid != "test-uid" {
+						t.Errorf("unexpected issuer UID, exp=test-uid got=%s", uid)
+					}
+					if test.getClientErr != nil {
+						return nil, test.getClientErr
+					}
+					return cl, nil
+				},
+			}
+
+			a := Acme{
+				issuer:          iss,
+				accountRegistry: ar,
+			}
+
+			err := a.Revoke(context.Background(), certDER, pki.RevocationReasonKeyCompromise)
+			if (err != nil) != test.wantsErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.wantsErr, err)
+			}
+			var rejectedErr *issuer.RevocationRejectedError
+			if errors.As(err, &rejectedErr) != test.wantsReject {
+				t.Errorf("unexpected rejection, exp=%t got=%v", test.wantsReject, err)
+			}
+			if test.getClientErr == nil && !revokeCalled {
+				t.Errorf("expected RevokeCert to be called")
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack/cert-manager/pkg/acme"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/errors"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// rolloverAccountKey changes the key of the issuer's ACME account to the key
+// referenced by `spec.acme.nextPrivateKeySecretRef` if it differs from
+// currentKey, and then stores the new key in the Secret referenced by
+// `spec.acme.privateKeySecretRef`. It returns the key the account is
+// identified by after the rollover.
+// Errors that will not be resolved by retrying are InvalidData errors.
+func (a *Acme) rolloverAccountKey(ctx context.Context, ns string, httpClient *http.Client, currentKey *rsa.PrivateKey) (*rsa.PrivateKey, error) {
+	spec := a.issuer.GetSpec().ACME
+	nextSelector := acme.PrivateKeySelector(*spec.NextPrivateKey)
+	log := logf.WithRelatedResourceName(logf.FromContext(ctx), nextSelector.Name, ns, "Secret")
+
+	signer, err := a.keyFromSecret(ctx, ns, nextSelector.Name, nextSelector.Key)
+	if err != nil {
+		return nil, err
+	}
+	nextKey, ok := signer.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.NewInvalidData(messageTemplateNotRSA, nextSelector.Name)
+	}
+
+	if nextKey.PublicKey.Equal(&currentKey.PublicKey) {
+		return currentKey, nil
+	}
+
+	accountURL := a.issuer.GetStatus().ACMEStatus().URI
+	if accountURL == "" {
+		// The key of an account can only be changed once it has been
+		// registered, which will trigger another call to Setup.
+		log.V(logf.DebugLevel).Info("not rolling over ACME account key as the account has not been registered yet")
+		return currentKey, nil
+	}
+
+	// Check whether the account key has already been changed, in which case
+	// only storing the new key failed previously.
+	acc, err := a.clientBuilder(httpClient, *spec, nextKey).GetReg(ctx, "")
+	switch {
+	case err == acmeapi.ErrNoAccount:
+		log.V(logf.InfoLevel).Info("rolling over ACME account key")
+		if err := a.clientBuilder(httpClient, *spec, currentKey).AccountKeyRollover(ctx, nextKey); err != nil {
+			if acmeErr, ok := err.(*acmeapi.Error); ok && acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+				return nil, errors.NewInvalidData("%v", err)
+			}
+			return nil, err
+		}
+
+	case err != nil:
+		return nil, err
+
+	case acc.URI != accountURL:
+		return nil, errors.NewInvalidData("the key in Secret %q is already used by the ACME account %q", nextSelector.Name, acc.URI)
+
+	default:
+		log.V(logf.DebugLevel).Info("ACME account key has already been rolled over")
+	}
+
+	selector := acme.PrivateKeySelector(spec.PrivateKey)
+	secret, err := a.secretsClient.Secrets(ns).Get(ctx, selector.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store rolled over ACME account key: %w", err)
+	}
+	secret = secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[selector.Key] = pki.EncodePKCS1PrivateKey(nextKey)
+	if _, err := a.secretsClient.Secrets(ns).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to store rolled over ACME account key: %w", err)
+	}
+
+	a.recorder.Eventf(a.issuer, corev1.EventTypeNormal, successAccountKeyRolledOver, messageTemplateAccountKeyRolledOver, nextSelector.Name)
+
+	return nextKey, nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	controllertest "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/util/errors"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestAcme_rolloverAccountKey(t *testing.T) {
+	const accountURL = "https://acme.example.com/acct/1"
+	currentKey := mustGenerateRSAKey(t).(*rsa.PrivateKey)
+	nextKey := mustGenerateRSAKey(t).(*rsa.PrivateKey)
+	ecdsaKey := mustGenerateEDCSAKey(t)
+
+	tests := map[string]struct {
+		accountURL string
+		nextKey    crypto.Signer
+
+		// account and error returned by GetReg for the next key
+		getRegAcc *acmeapi.Account
+		getRegErr error
+		// error returned by the key rollover
+		rolloverErr error
+
+		expectedKey      *rsa.PrivateKey
+		expectedRollover bool
+		expectedStored   bool
+		expectedEvents   []string
+		wantsErr         bool
+		wantsInvalidData bool
+	}{
+		"does nothing if the next key is the current key": {
+			accountURL:  accountURL,
+			nextKey:     currentKey,
+			expectedKey: currentKey,
+		},
+		"does nothing until the account has been registered": {
+			nextKey:     nextKey,
+			expectedKey: currentKey,
+		},
+		"rolls over the account key and stores the next key": {
+			accountURL:       accountURL,
+			nextKey:          nextKey,
+			getRegErr:        acmeapi.ErrNoAccount,
+			expectedKey:      nextKey,
+			expectedRollover: true,
+			expectedStored:   true,
+			expectedEvents:   []string{`Normal ACMEAccountKeyRolledOver The ACME account key was rolled over to the key in Secret "next-key"`},
+		},
+		"only stores the next key if the account key has already been rolled over": {
+			accountURL:     accountURL,
+			nextKey:        nextKey,
+			getRegAcc:      &acmeapi.Account{URI: accountURL},
+			expectedKey:    nextKey,
+			expectedStored: true,
+			expectedEvents: []string{`Normal ACMEAccountKeyRolledOver The ACME account key was rolled over to the key in Secret "next-key"`},
+		},
+		"fails if the next key belongs to a different account": {
+			accountURL:       accountURL,
+			nextKey:          nextKey,
+			getRegAcc:        &acmeapi.Account{URI: "https://acme.example.com/acct/2"},
+			wantsErr:         true,
+			wantsInvalidData: true,
+		},
+		"fails if the next key is not an RSA key": {
+			accountURL:       accountURL,
+			nextKey:          ecdsaKey,
+			wantsErr:         true,
+			wantsInvalidData: true,
+		},
+		"fails without retrying if the ACME server rejects the rollover": {
+			accountURL:       accountURL,
+			nextKey:          nextKey,
+			getRegErr:        acmeapi.ErrNoAccount,
+			rolloverErr:      &acmeapi.Error{StatusCode: http.StatusConflict},
+			expectedRollover: true,
+			wantsErr:         true,
+			wantsInvalidData: true,
+		},
+		"fails and retries if the ACME server cannot be reached": {
+			accountURL:       accountURL,
+			nextKey:          nextKey,
+			getRegErr:        acmeapi.ErrNoAccount,
+			rolloverErr:      fmt.Errorf("connection refused"),
+			expectedRollover: true,
+			wantsErr:         true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := gen.Issuer("test-issuer",
+				gen.SetIssuerNamespace("testns"),
+				gen.SetIssuerACME(cmacme.ACMEIssuer{
+					Server:         "https://acme.example.com/directory",
+					PrivateKey:     cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "account-key"}},
+					NextPrivateKey: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "next-key"}},
+				}),
+			)
+			iss.Status.ACME = &cmacme.ACMEIssuerStatus{URI: test.accountURL}
+
+			kubeClient := kubefake.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "account-key", Namespace: "testns"},
+				Data:       map[string][]byte{corev1.TLSPrivateKeyKey: pki.EncodePKCS1PrivateKey(currentKey)},
+			})
+
+			rolloverCalled := false
+			recorder := new(controllertest.FakeRecorder)
+			a := Acme{
+				issuer:        iss,
+				secretsClient: kubeClient.CoreV1(),
+				recorder:      recorder,
+				keyFromSecret: func(_ context.Context, namespace, name, keyName string) (crypto.Signer, error) {
+					if namespace != "testns" || name != "next-key" || keyName != corev1.TLSPrivateKeyKey {
+						t.Errorf("unexpected key reference %s/%s[%s]", namespace, name, keyName)
+					}
+					return test.nextKey, nil
+				},
+				clientBuilder: func(_ *http.Client, _ cmacme.ACMEIssuer, key *rsa.PrivateKey) acmecl.Interface {
+					if key == nextKey {
+						return &acmecl.FakeACME{
+							FakeGetReg: func(context.Context, string) (*acmeapi.Account, error) {
+								return test.getRegAcc, test.getRegErr
+							},
+						}
+					}
+					if key != currentKey {
+						t.Errorf("unexpected account key passed to the client builder")
+					}
+					return &acmecl.FakeACME{
+						FakeAccountKeyRollover: func(_ context.Context, newKey crypto.Signer) error {
+							rolloverCalled = true
+							if newKey != nextKey {
+								t.Errorf("unexpected key passed to rollover")
+							}
+							return test.rolloverErr
+						},
+					}
+				},
+			}
+
+			key, err := a.rolloverAccountKey(context.Background(), "testns", nil, currentKey)
+			if (err != nil) != test.wantsErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.wantsErr, err)
+			}
+			if errors.IsInvalidData(err) != test.wantsInvalidData {
+				t.Errorf("unexpected InvalidData error, exp=%t got=%v", test.wantsInvalidData, err)
+			}
+			if key != test.expectedKey {
+				t.Errorf("unexpected key returned")
+			}
+			if rolloverCalled != test.expectedRollover {
+				t.Errorf("unexpected rollover, exp=%t got=%t", test.expectedRollover, rolloverCalled)
+			}
+			if !reflect.DeepEqual(recorder.Events, test.expectedEvents) {
+				t.Errorf("unexpected events, exp=%v got=%v", test.expectedEvents, recorder.Events)
+			}
+
+			secret, err := kubeClient.CoreV1().Secrets("testns").Get(context.Background(), "account-key", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			stored := bytes.Equal(secret.Data[corev1.TLSPrivateKeyKey], pki.EncodePKCS1PrivateKey(nextKey))
+			if stored != test.expectedStored {
+				t.Errorf("unexpected stored account key, exp next key=%t got=%t", test.expectedStored, stored)
+			}
+		})
+	}
+}
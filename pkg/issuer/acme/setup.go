@@ -44,15 +44,19 @@ const (
 	errorAccountRegistrationFailed = "ErrRegisterACMEAccount"
 	errorAccountVerificationFailed = "ErrVerifyACMEAccount"
 	errorAccountUpdateFailed       = "ErrUpdateACMEAccount"
+	errorAccountKeyRolloverFailed  = "ErrRolloverACMEAccountKey"
 	errorInvalidConfig             = "InvalidConfig"
 	errorInvalidURL                = "InvalidURL"
 
 	successAccountRegistered = "ACMEAccountRegistered"
 	successAccountVerified   = "ACMEAccountVerified"
 
+	successAccountKeyRolledOver = "ACMEAccountKeyRolledOver"
+
 	messageAccountRegistrationFailed     = "Failed to register ACME account: "
 	messageAccountVerificationFailed     = "Failed to verify ACME account: "
 	messageAccountUpdateFailed           = "Failed to update ACME account:"
+	messageAccountKeyRolloverFailed      = "Failed to roll over ACME account key: "
 	messageAccountRegistered             = "The ACME account was registered with the ACME server"
 	messageAccountVerified               = "The ACME account was verified with the ACME server"
 	messageNoSecretKeyGenerationDisabled = "the ACME issuer config has 'disableAccountKeyGeneration' set to true, but the secret was not found: "
@@ -63,6 +67,7 @@ const (
 	messageTemplateFailedToParseURL        = "Failed to parse existing ACME server URI %q: %v"
 	messageTemplateFailedToParseAccountURL = "Failed to parse existing ACME account URI %q: %v"
 	messageTemplateFailedToGetEABKey       = "failed to get External Account Binding key from secret: %v"
+	messageTemplateAccountKeyRolledOver    = "The ACME account key was rolled over to the key in Secret %q"
 )
 
 // Setup will verify an existing ACME registration, or create one if not
@@ -156,6 +161,23 @@ func (a *Acme) Setup(ctx context.Context) error {
 	// this function.
 	a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
 	httpClient := accounts.BuildHTTPClient(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify)
+
+	// change the account key if a different key has been configured
+	if a.issuer.GetSpec().ACME.NextPrivateKey != nil {
+		rsaPk, err = a.rolloverAccountKey(ctx, ns, httpClient, rsaPk)
+		if err != nil {
+			reason = errorAccountKeyRolloverFailed
+			msg = messageAccountKeyRolloverFailed + err.Error()
+			log.Error(err, "failed to roll over ACME account key")
+			a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorAccountKeyRolloverFailed, msg)
+			// Do not retry if the next key or the request is invalid.
+			if errors.IsInvalidData(err) {
+				return nil
+			}
+			return err
+		}
+	}
+
 	cl := a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk)
 
 	// TODO: perform a complex check to determine whether we need to verify
@@ -211,7 +233,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 		status = cmmeta.ConditionTrue
 
 		// ensure the cached client in the account registry is up to date
-		a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk)
+		a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), a.issuer.GetStatus().ACMEStatus().URI, *a.issuer.GetSpec().ACME, rsaPk)
 		return nil
 	}
 
@@ -313,7 +335,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 	a.issuer.GetStatus().ACMEStatus().URI = account.URI
 	a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail = registeredEmail
 	// ensure the cached client in the account registry is up to date
-	a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk)
+	a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), a.issuer.GetStatus().ACMEStatus().URI, *a.issuer.GetSpec().ACME, rsaPk)
 
 	return nil
 }
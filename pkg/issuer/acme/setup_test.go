@@ -411,7 +411,7 @@ func TestAcme_Setup(t *testing.T) {
 				RemoveClientFunc: func(string) {
 					removeClientWasCalled = true
 				},
-				AddClientFunc: func(string, cmacme.ACMEIssuer, *rsa.PrivateKey) {
+				AddClientFunc: func(string, string, cmacme.ACMEIssuer, *rsa.PrivateKey) {
 					addClientWasCalled = true
 				},
 			}
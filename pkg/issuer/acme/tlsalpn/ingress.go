@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsalpn
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// ensureIngress ensures that the SNI passthrough Ingress needed to solve a
+// challenge exists.
+func (s *Solver) ensureIngress(ctx context.Context, ch *cmacme.Challenge, svc *corev1.Service) (*networkingv1.Ingress, error) {
+	log := logf.FromContext(ctx).WithName("ensureIngress")
+
+	log.V(logf.DebugLevel).Info("checking for existing TLS-ALPN-01 solver ingresses")
+	existingIngresses, err := s.getIngressesForChallenge(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingIngresses) == 1 {
+		logf.WithRelatedResource(log, existingIngresses[0]).Info("found one existing TLS-ALPN-01 solver ingress")
+		return existingIngresses[0], nil
+	}
+	if len(existingIngresses) > 1 {
+		log.V(logf.DebugLevel).Info("multiple challenge solver ingresses found for challenge. cleaning up all existing ingresses.")
+		if err := s.cleanupIngresses(ctx, ch); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("multiple existing challenge solver ingresses found and cleaned up. retrying challenge sync")
+	}
+
+	log.V(logf.InfoLevel).Info("creating TLS-ALPN-01 challenge solver ingress")
+	return s.ingressCreateUpdater.Ingresses(ch.Namespace).Create(ctx, buildIngress(ch, svc), metav1.CreateOptions{})
+}
+
+// getIngressesForChallenge returns a list of Ingresses that were created to
+// solve the given challenge
+func (s *Solver) getIngressesForChallenge(ctx context.Context, ch *cmacme.Challenge) ([]*networkingv1.Ingress, error) {
+	log := logf.FromContext(ctx)
+
+	selector, err := podSelector(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	ingressList, err := s.ingressLister.Ingresses(ch.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var relevantIngresses []*networkingv1.Ingress
+	for _, ingress := range ingressList {
+		if !metav1.IsControlledBy(ingress, ch) {
+			logf.WithRelatedResource(log, ingress).Info("found existing solver ingress for this challenge resource, however " +
+				"it does not have an appropriate OwnerReference referencing this challenge. Skipping it altogether.")
+			continue
+		}
+		relevantIngresses = append(relevantIngresses, ingress)
+	}
+
+	return relevantIngresses, nil
+}
+
+// buildIngress returns an Ingress routing all traffic for the challenge
+// domain to the given solver Service. The ingress controller is expected to
+// pass TLS connections through to the Service based on their SNI, which
+// usually has to be enabled with an annotation in the ingress template.
+func buildIngress(ch *cmacme.Challenge, svc *corev1.Service) *networkingv1.Ingress {
+	cfg := ch.Spec.Solver.TLSALPN01.SNIPassthrough
+
+	annotations := make(map[string]string)
+	if cfg.Class != nil {
+		annotations[cmapi.IngressClassAnnotationKey] = *cfg.Class
+	}
+	labels := podLabels(ch)
+	if cfg.IngressTemplate != nil {
+		for k, v := range cfg.IngressTemplate.Labels {
+			labels[k] = v
+		}
+		for k, v := range cfg.IngressTemplate.Annotations {
+			annotations[k] = v
+		}
+	}
+
+	pathType := networkingv1.PathTypePrefix
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName:    "cm-acme-tls-alpn-solver-",
+			Namespace:       ch.Namespace,
+			Labels:          labels,
+			Annotations:     annotations,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)},
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: ch.Spec.DNSName,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: svc.Name,
+											Port: networkingv1.ServiceBackendPort{
+												Number: servicePort(ch),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *Solver) cleanupIngresses(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupIngresses")
+
+	ingresses, err := s.getIngressesForChallenge(ctx, ch)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, ingress := range ingresses {
+		log := logf.WithRelatedResource(log, ingress)
+		log.V(logf.DebugLevel).Info("deleting ingress resource")
+
+		err := s.ingressCreateUpdater.Ingresses(ingress.Namespace).Delete(ctx, ingress.Name, metav1.DeleteOptions{})
+		if err != nil {
+			log.V(logf.WarnLevel).Info("failed to delete ingress resource", "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		log.V(logf.DebugLevel).Info("successfully deleted ingress resource")
+	}
+	return utilerrors.NewAggregate(errs)
+}
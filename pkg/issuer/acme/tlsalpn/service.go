@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsalpn
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+func (s *Solver) ensureService(ctx context.Context, ch *cmacme.Challenge) (*corev1.Service, error) {
+	log := logf.FromContext(ctx).WithName("ensureService")
+
+	log.V(logf.DebugLevel).Info("checking for existing TLS-ALPN-01 solver services for challenge")
+	existingServices, err := s.getServicesForChallenge(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingServices) == 1 {
+		logf.WithRelatedResource(log, existingServices[0]).Info("found one existing TLS-ALPN-01 solver Service for challenge resource")
+		return existingServices[0], nil
+	}
+	if len(existingServices) > 1 {
+		log.V(logf.DebugLevel).Info("multiple challenge solver services found for challenge. cleaning up all existing services.")
+		err := s.cleanupServices(ctx, ch)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("multiple existing challenge solver services found and cleaned up. retrying challenge sync")
+	}
+
+	log.V(logf.DebugLevel).Info("creating TLS-ALPN-01 challenge solver service")
+	return s.Client.CoreV1().Services(ch.Namespace).Create(ctx, buildService(ch), metav1.CreateOptions{})
+}
+
+// getServicesForChallenge returns a list of services that were created to solve
+// the given challenge
+func (s *Solver) getServicesForChallenge(ctx context.Context, ch *cmacme.Challenge) ([]*corev1.Service, error) {
+	log := logf.FromContext(ctx).WithName("getServicesForChallenge")
+
+	selector, err := podSelector(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceList, err := s.serviceLister.Services(ch.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var relevantServices []*corev1.Service
+	for _, service := range serviceList {
+		if !metav1.IsControlledBy(service, ch) {
+			logf.WithRelatedResource(log, service).Info("found existing solver service for this challenge resource, however " +
+				"it does not have an appropriate OwnerReference referencing this challenge. Skipping it altogether.")
+			continue
+		}
+		relevantServices = append(relevantServices, service)
+	}
+
+	return relevantServices, nil
+}
+
+// servicePort returns the port the solver Service should expose the solver
+// pods on.
+func servicePort(ch *cmacme.Challenge) int32 {
+	if ch.Spec.Solver.TLSALPN01 != nil && ch.Spec.Solver.TLSALPN01.Service != nil &&
+		ch.Spec.Solver.TLSALPN01.Service.Port != 0 {
+		return ch.Spec.Solver.TLSALPN01.Service.Port
+	}
+	return tlsPort
+}
+
+func buildService(ch *cmacme.Challenge) *corev1.Service {
+	podLabels := podLabels(ch)
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName:    "cm-acme-tls-alpn-solver-",
+			Namespace:       ch.Namespace,
+			Labels:          podLabels,
+			Annotations:     map[string]string{},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeNodePort,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "https",
+					Port:       servicePort(ch),
+					TargetPort: intstr.FromInt(acmeSolverListenPort),
+				},
+			},
+			Selector: podLabels,
+		},
+	}
+
+	if ch.Spec.Solver.TLSALPN01 == nil || ch.Spec.Solver.TLSALPN01.Service == nil {
+		return service
+	}
+	cfg := ch.Spec.Solver.TLSALPN01.Service
+	if cfg.Type != "" {
+		service.Spec.Type = cfg.Type
+	}
+	// copy the user supplied labels, but never override the labels used to
+	// select the solver pods
+	labels := make(map[string]string)
+	for k, v := range cfg.Labels {
+		labels[k] = v
+	}
+	for k, v := range podLabels {
+		labels[k] = v
+	}
+	service.Labels = labels
+	for k, v := range cfg.Annotations {
+		service.Annotations[k] = v
+	}
+
+	return service
+}
+
+func (s *Solver) cleanupServices(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupServices")
+
+	services, err := s.getServicesForChallenge(ctx, ch)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, service := range services {
+		log := logf.WithRelatedResource(log, service)
+		log.V(logf.DebugLevel).Info("deleting service resource")
+
+		err := s.Client.CoreV1().Services(service.Namespace).Delete(ctx, service.Name, metav1.DeleteOptions{})
+		if err != nil {
+			log.V(logf.WarnLevel).Info("failed to delete service resource", "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		log.V(logf.DebugLevel).Info("successfully deleted service resource")
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	// ACMETLSProtocol is the ALPN protocol negotiated by TLS-ALPN-01
+	// validation connections, as defined in RFC 8737 section 6.2.
+	ACMETLSProtocol = "acme-tls/1"
+
+	// handshakeTimeout is the maximum time a validation connection may take
+	// to complete the TLS handshake.
+	handshakeTimeout = 10 * time.Second
+)
+
+// OIDACMEIdentifier is the OID of the id-pe-acmeIdentifier extension, which
+// contains the SHA-256 digest of the key authorization of a TLS-ALPN-01
+// challenge, as defined in RFC 8737 section 6.1.
+var OIDACMEIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+
+// TLSALPN01Solver is a TLS server answering TLS-ALPN-01 validation
+// connections for a single domain with the challenge certificate.
+type TLSALPN01Solver struct {
+	ListenPort int
+
+	Domain string
+	Key    string
+
+	lock     sync.Mutex
+	listener net.Listener
+	closed   bool
+}
+
+// Listen starts accepting validation connections, and blocks until the
+// solver is shut down or the listener fails.
+func (t *TLSALPN01Solver) Listen(log logr.Logger) error {
+	log.Info("starting listener",
+		"expected_domain", t.Domain,
+		"expected_key", t.Key,
+		"listen_port", t.ListenPort,
+	)
+
+	cert, err := ChallengeCertificate(t.Domain, t.Key)
+	if err != nil {
+		return err
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{ACMETLSProtocol},
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			log := log.WithValues("server_name", hello.ServerName, "protocols", hello.SupportedProtos)
+			log.Info("validating handshake")
+
+			if !supportsACMETLSProtocol(hello.SupportedProtos) {
+				log.Info("client did not offer the acme-tls/1 protocol")
+				return nil, fmt.Errorf("client did not offer the %s protocol", ACMETLSProtocol)
+			}
+
+			log.Info("comparing server name", "expected_server_name", t.Domain)
+			if !strings.EqualFold(hello.ServerName, t.Domain) {
+				log.Info("invalid server name", "expected_server_name", t.Domain)
+				return nil, fmt.Errorf("unexpected server name %q", hello.ServerName)
+			}
+
+			log.Info("got successful challenge handshake, presenting challenge certificate")
+			return &cert, nil
+		},
+	}
+
+	listener, err := tls.Listen("tcp", fmt.Sprintf(":%d", t.ListenPort), config)
+	if err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	if t.closed {
+		t.lock.Unlock()
+		listener.Close()
+		return nil
+	}
+	t.listener = listener
+	t.lock.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			t.lock.Lock()
+			closed := t.closed
+			t.lock.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		go handleConnection(log, conn.(*tls.Conn))
+	}
+}
+
+// Addr returns the address the solver is listening on, or nil if it is not
+// listening yet.
+func (t *TLSALPN01Solver) Addr() net.Addr {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.listener == nil {
+		return nil
+	}
+	return t.listener.Addr()
+}
+
+// Shutdown stops the solver from accepting new validation connections.
+func (t *TLSALPN01Solver) Shutdown(_ context.Context) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.closed = true
+	if t.listener == nil {
+		return nil
+	}
+	return t.listener.Close()
+}
+
+// handleConnection completes the handshake of a validation connection and
+// closes it, as no application data is exchanged once the challenge
+// certificate has been presented.
+func handleConnection(log logr.Logger, conn *tls.Conn) {
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		log.Error(err, "failed to set handshake deadline")
+		return
+	}
+	if err := conn.Handshake(); err != nil {
+		log.Info("handshake failed", "remote_addr", conn.RemoteAddr().String(), "error", err.Error())
+	}
+}
+
+func supportsACMETLSProtocol(protos []string) bool {
+	for _, proto := range protos {
+		if proto == ACMETLSProtocol {
+			return true
+		}
+	}
+	return false
+}
+
+// ChallengeCertificate returns a self-signed certificate for the given domain
+// containing the acmeIdentifier extension for the given key authorization,
+// as defined in RFC 8737 section 3.
+func ChallengeCertificate(domain, keyAuth string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to generate challenge certificate key: %w", err)
+	}
+
+	digest := sha256.Sum256([]byte(keyAuth))
+	acmeIdentifier, err := asn1.Marshal(digest[:])
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to asn1 encode acmeIdentifier extension: %w", err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: domain},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(7 * 24 * time.Hour),
+		DNSNames:     []string{domain},
+		ExtraExtensions: []pkix.Extension{
+			{
+				Id:       OIDACMEIdentifier,
+				Critical: true,
+				Value:    acmeIdentifier,
+			},
+		},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create challenge certificate: %w", err)
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}, nil
+}
+
+// VerifyChallengeCertificate returns an error if cert is not a valid
+// TLS-ALPN-01 challenge certificate for the given domain and key
+// authorization.
+func VerifyChallengeCertificate(cert *x509.Certificate, domain, keyAuth string) error {
+	if len(cert.DNSNames) != 1 || len(cert.IPAddresses) > 0 || len(cert.EmailAddresses) > 0 || len(cert.URIs) > 0 {
+		return errors.New("challenge certificate must contain exactly one DNS name")
+	}
+	if !strings.EqualFold(cert.DNSNames[0], domain) {
+		return fmt.Errorf("challenge certificate is for %q, expected %q", cert.DNSNames[0], domain)
+	}
+
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(OIDACMEIdentifier) {
+			continue
+		}
+		if !ext.Critical {
+			return errors.New("acmeIdentifier extension of the challenge certificate is not critical")
+		}
+
+		var digest []byte
+		rest, err := asn1.Unmarshal(ext.Value, &digest)
+		if err != nil || len(rest) > 0 {
+			return errors.New("failed to decode acmeIdentifier extension of the challenge certificate")
+		}
+		expected := sha256.Sum256([]byte(keyAuth))
+		if string(digest) != string(expected[:]) {
+			return errors.New("acmeIdentifier extension of the challenge certificate does not match the key authorization")
+		}
+		return nil
+	}
+
+	return errors.New("challenge certificate does not contain the acmeIdentifier extension")
+}
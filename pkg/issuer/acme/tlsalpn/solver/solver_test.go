@@ -0,0 +1,260 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+
+	logtesting "github.com/jetstack/cert-manager/pkg/logs/testing"
+)
+
+// acmeStandIn is a minimal stand-in for the validation authority of an ACME
+// server. It validates TLS-ALPN-01 challenges as described in RFC 8737
+// section 3, independently of the helpers in this package.
+type acmeStandIn struct {
+	client *acme.Client
+}
+
+func newACMEStandIn(t *testing.T) *acmeStandIn {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &acmeStandIn{client: &acme.Client{Key: key}}
+}
+
+// keyAuthorization returns the key authorization for the given token, which
+// is what cert-manager stores in the challenge's key field.
+func (a *acmeStandIn) keyAuthorization(t *testing.T, token string) string {
+	keyAuth, err := a.client.HTTP01ChallengeResponse(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyAuth
+}
+
+// validate connects to addr and validates the TLS-ALPN-01 challenge for the
+// given domain and token.
+func (a *acmeStandIn) validate(addr, domain, token string, nextProtos []string) error {
+	keyAuth, err := a.client.HTTP01ChallengeResponse(token)
+	if err != nil {
+		return err
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", addr, &tls.Config{
+		ServerName:         domain,
+		NextProtos:         nextProtos,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	if state.NegotiatedProtocol != "acme-tls/1" {
+		return fmt.Errorf("negotiated protocol %q", state.NegotiatedProtocol)
+	}
+	if len(state.PeerCertificates) != 1 {
+		return fmt.Errorf("expected a single certificate, got %d", len(state.PeerCertificates))
+	}
+	cert := state.PeerCertificates[0]
+	if len(cert.DNSNames) != 1 || cert.DNSNames[0] != domain {
+		return fmt.Errorf("unexpected DNS names %v", cert.DNSNames)
+	}
+
+	oid := asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oid) {
+			continue
+		}
+		if !ext.Critical {
+			return errors.New("acmeIdentifier extension is not critical")
+		}
+		var digest []byte
+		if _, err := asn1.Unmarshal(ext.Value, &digest); err != nil {
+			return err
+		}
+		expected := sha256.Sum256([]byte(keyAuth))
+		if !bytes.Equal(digest, expected[:]) {
+			return errors.New("acmeIdentifier does not match the key authorization")
+		}
+		return nil
+	}
+	return errors.New("acmeIdentifier extension not found")
+}
+
+// startSolver starts a TLSALPN01Solver on a random local port and returns
+// its address.
+func startSolver(t *testing.T, domain, keyAuth string) string {
+	s := &TLSALPN01Solver{Domain: domain, Key: keyAuth}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Listen(logtesting.TestLogger{T: t}) }()
+	t.Cleanup(func() {
+		if err := s.Shutdown(context.Background()); err != nil {
+			t.Errorf("failed to shut down solver: %v", err)
+		}
+		if err := <-errCh; err != nil {
+			t.Errorf("unexpected error from Listen: %v", err)
+		}
+	})
+
+	for i := 0; i < 100; i++ {
+		if addr := s.Addr(); addr != nil {
+			_, port, err := net.SplitHostPort(addr.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			return net.JoinHostPort("127.0.0.1", port)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for the solver to listen")
+	return ""
+}
+
+func TestTLSALPN01Solver(t *testing.T) {
+	const (
+		domain = "example.com"
+		token  = "token"
+	)
+
+	tests := map[string]struct {
+		domain     string
+		token      string
+		nextProtos []string
+		expErr     string
+	}{
+		"should validate the challenge": {
+			domain:     domain,
+			token:      token,
+			nextProtos: []string{"acme-tls/1"},
+		},
+		"should fail the handshake for another domain": {
+			domain:     "example.org",
+			token:      token,
+			nextProtos: []string{"acme-tls/1"},
+			expErr:     "remote error: tls: internal error",
+		},
+		"should fail the handshake if acme-tls/1 is not offered": {
+			domain:     domain,
+			token:      token,
+			nextProtos: []string{"h2", "http/1.1"},
+			expErr:     "remote error: tls: no application protocol",
+		},
+		"should not validate the challenge for another token": {
+			domain:     domain,
+			token:      "another-token",
+			nextProtos: []string{"acme-tls/1"},
+			expErr:     "acmeIdentifier does not match the key authorization",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ca := newACMEStandIn(t)
+			addr := startSolver(t, domain, ca.keyAuthorization(t, token))
+
+			err := ca.validate(addr, test.domain, test.token, test.nextProtos)
+			if test.expErr == "" {
+				if err != nil {
+					t.Errorf("unexpected validation error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.expErr) {
+				t.Errorf("expected error containing %q, got: %v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestVerifyChallengeCertificate(t *testing.T) {
+	ca := newACMEStandIn(t)
+	keyAuth := ca.keyAuthorization(t, "token")
+
+	// certificates generated by the golang.org/x/crypto/acme client are
+	// used as a reference implementation
+	reference, err := ca.client.TLSALPN01ChallengeCert("token", "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	own, err := ChallengeCertificate("example.com", keyAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		cert    tls.Certificate
+		domain  string
+		keyAuth string
+		expErr  bool
+	}{
+		"should accept the reference challenge certificate": {
+			cert:    reference,
+			domain:  "example.com",
+			keyAuth: keyAuth,
+		},
+		"should accept our own challenge certificate": {
+			cert:    own,
+			domain:  "example.com",
+			keyAuth: keyAuth,
+		},
+		"should reject a challenge certificate for another domain": {
+			cert:    own,
+			domain:  "example.org",
+			keyAuth: keyAuth,
+			expErr:  true,
+		},
+		"should reject a challenge certificate for another key authorization": {
+			cert:    own,
+			domain:  "example.com",
+			keyAuth: ca.keyAuthorization(t, "another-token"),
+			expErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cert, err := x509.ParseCertificate(test.cert.Certificate[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = VerifyChallengeCertificate(cert, test.domain, test.keyAuth)
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%t, got: %v", test.expErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsalpn
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	gwapilisters "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1alpha1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/internal/ingress"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/tlsalpn/solver"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	// TLSALPN01Timeout is the max amount of time to wait for a TLS-ALPN-01
+	// challenge to succeed
+	TLSALPN01Timeout = time.Minute * 15
+	// acmeSolverListenPort is the port acmesolver should listen on
+	acmeSolverListenPort = 8443
+	// tlsPort is the port ACME servers connect to when validating
+	// TLS-ALPN-01 challenges, as defined in RFC 8737 section 3.
+	tlsPort = 443
+)
+
+var (
+	challengeGvk = cmacme.SchemeGroupVersion.WithKind("Challenge")
+)
+
+// Solver is an implementation of the acme tls-alpn-01 challenge solver
+// protocol
+type Solver struct {
+	*controller.Context
+
+	podLister            corev1listers.PodLister
+	serviceLister        corev1listers.ServiceLister
+	ingressLister        ingress.InternalIngressLister
+	ingressCreateUpdater ingress.InternalIngressCreateUpdater
+	tlsRouteLister       gwapilisters.TLSRouteLister
+
+	// dialContext is used to connect to the challenge domain when running
+	// the self check. It can be overridden in tests.
+	dialContext    func(ctx context.Context, network, addr string) (net.Conn, error)
+	requiredPasses int
+}
+
+// NewSolver returns a new ACME TLS-ALPN-01 solver for the given
+// *controller.Context.
+func NewSolver(ctx *controller.Context) (*Solver, error) {
+	ingressLister, _, err := ingress.NewListerInformer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ingressCreateUpdater, err := ingress.NewCreateUpdater(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Solver{
+		Context:              ctx,
+		podLister:            ctx.KubeSharedInformerFactory.Core().V1().Pods().Lister(),
+		serviceLister:        ctx.KubeSharedInformerFactory.Core().V1().Services().Lister(),
+		ingressLister:        ingressLister,
+		ingressCreateUpdater: ingressCreateUpdater,
+		tlsRouteLister:       ctx.GWShared.Networking().V1alpha1().TLSRoutes().Lister(),
+		dialContext:          (&net.Dialer{Timeout: time.Second * 10}).DialContext,
+		requiredPasses:       5,
+	}, nil
+}
+
+func tlsalpn01LogCtx(ctx context.Context) context.Context {
+	return logf.NewContext(ctx, nil, "tlsalpn01")
+}
+
+func tlsalpnConfigForChallenge(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolverTLSALPN01, error) {
+	if ch.Spec.Solver.TLSALPN01 == nil {
+		return nil, fmt.Errorf("challenge's 'solver' field is specified but no TLS-ALPN-01 config provided. " +
+			"Ensure solvers[].tlsalpn01 is specified on your issuer resource")
+	}
+	return ch.Spec.Solver.TLSALPN01, nil
+}
+
+// Present will realise the resources required to solve the given TLS-ALPN-01
+// challenge validation in the apiserver. If those resources already exist, it
+// will return nil (i.e. this function is idempotent).
+func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	ctx = tlsalpn01LogCtx(ctx)
+
+	cfg, err := tlsalpnConfigForChallenge(ch)
+	if err != nil {
+		return err
+	}
+
+	_, podErr := s.ensurePod(ctx, ch)
+	svc, svcErr := s.ensureService(ctx, ch)
+	if svcErr != nil {
+		return utilerrors.NewAggregate([]error{podErr, svcErr})
+	}
+
+	var routeErr error
+	switch {
+	case cfg.GatewayTLSRoute != nil:
+		_, routeErr = s.ensureGatewayTLSRoute(ctx, ch, svc)
+	case cfg.SNIPassthrough != nil:
+		_, routeErr = s.ensureIngress(ctx, ch, svc)
+	}
+
+	return utilerrors.NewAggregate([]error{podErr, svcErr, routeErr})
+}
+
+// Check will verify that the challenge certificate is presented to
+// connections negotiating the acme-tls/1 protocol for the challenge domain.
+func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	ctx = logf.NewContext(tlsalpn01LogCtx(ctx), nil, "selfCheck")
+	log := logf.FromContext(ctx)
+
+	// TLS-ALPN-01 Present is idempotent and the state of the system may have
+	// changed since present was called by the controllers (killed pods, drained nodes)
+	// Call present again to be certain.
+	// if the listers are nil, that means we're in the present checks
+	// test
+	if s.podLister != nil && s.serviceLister != nil && s.ingressLister != nil {
+		log.V(logf.DebugLevel).Info("calling Present function before running self check to ensure required resources exist")
+		err := s.Present(ctx, issuer, ch)
+		if err != nil {
+			log.V(logf.DebugLevel).Info("failed to call Present function", "error", err)
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, TLSALPN01Timeout)
+	defer cancel()
+	addr := net.JoinHostPort(ch.Spec.DNSName, fmt.Sprintf("%d", tlsPort))
+	log = log.WithValues("address", addr)
+	ctx = logf.NewContext(ctx, log)
+
+	log.V(logf.DebugLevel).Info("running self check multiple times to ensure challenge has propagated", "required_passes", s.requiredPasses)
+	for i := 0; i < s.requiredPasses; i++ {
+		err := s.testReachability(ctx, addr, ch.Spec.DNSName, ch.Spec.Key)
+		if err != nil {
+			return err
+		}
+		log.V(logf.DebugLevel).Info("reachability test passed, re-checking in 2s time")
+		time.Sleep(time.Second * 2)
+	}
+
+	log.V(logf.DebugLevel).Info("self check succeeded")
+
+	return nil
+}
+
+// CleanUp will ensure the created service, ingress, pod and TLSRoute are
+// clean/deleted of any cert-manager created data.
+func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	var errs []error
+	errs = append(errs, s.cleanupPods(ctx, ch))
+	errs = append(errs, s.cleanupServices(ctx, ch))
+	errs = append(errs, s.cleanupIngresses(ctx, ch))
+	errs = append(errs, s.cleanupGatewayTLSRoutes(ctx, ch))
+	return utilerrors.NewAggregate(errs)
+}
+
+// testReachability will perform a TLS handshake with 'addr' negotiating the
+// acme-tls/1 protocol for 'domain', and check that the presented certificate
+// is a valid challenge certificate for the key authorization 'key'.
+func (s *Solver) testReachability(ctx context.Context, addr, domain, key string) error {
+	log := logf.FromContext(ctx)
+	log.V(logf.DebugLevel).Info("performing TLS-ALPN-01 reachability check")
+
+	rawConn, err := s.dialContext(ctx, "tcp", addr)
+	if err != nil {
+		log.V(logf.DebugLevel).Info("failed to connect to challenge address", "error", err)
+		return fmt.Errorf("failed to perform self check connection to '%s': %v", addr, err)
+	}
+	defer rawConn.Close()
+
+	// The challenge certificate is self-signed, so it can't be verified
+	// against any trust store. Its contents are verified below instead,
+	// as described in RFC 8737 section 3.
+	conn := tls.Client(rawConn, &tls.Config{
+		ServerName:         domain,
+		NextProtos:         []string{solver.ACMETLSProtocol},
+		InsecureSkipVerify: true,
+	})
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	if err := conn.Handshake(); err != nil {
+		log.V(logf.DebugLevel).Info("failed to perform TLS handshake", "error", err)
+		return fmt.Errorf("failed to perform self check TLS handshake with '%s': %v", addr, err)
+	}
+
+	state := conn.ConnectionState()
+	if state.NegotiatedProtocol != solver.ACMETLSProtocol {
+		log.V(logf.DebugLevel).Info("server did not negotiate the acme-tls/1 protocol", "protocol", state.NegotiatedProtocol)
+		return fmt.Errorf("wrong application protocol %q negotiated, expected %q", state.NegotiatedProtocol, solver.ACMETLSProtocol)
+	}
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("no certificate presented by '%s'", addr)
+	}
+	if err := solver.VerifyChallengeCertificate(state.PeerCertificates[0], domain, key); err != nil {
+		log.V(logf.DebugLevel).Info("presented certificate is not a valid challenge certificate", "error", err)
+		return fmt.Errorf("did not get expected challenge certificate when connecting to '%s': %v", addr, err)
+	}
+
+	log.V(logf.DebugLevel).Info("reachability test succeeded")
+
+	return nil
+}
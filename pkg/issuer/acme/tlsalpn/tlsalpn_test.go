@@ -0,0 +1,294 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsalpn
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/tlsalpn/solver"
+	logtesting "github.com/jetstack/cert-manager/pkg/logs/testing"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func testChallenge(cfg *cmacme.ACMEChallengeSolverTLSALPN01) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: gen.DefaultTestNamespace,
+			UID:       "test-uid",
+		},
+		Spec: cmacme.ChallengeSpec{
+			Type:    cmacme.ACMEChallengeTypeTLSALPN01,
+			DNSName: "example.com",
+			Token:   "token",
+			Key:     "token.thumbprint",
+			Solver: cmacme.ACMEChallengeSolver{
+				TLSALPN01: cfg,
+			},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	// the key authorization is computed the same way as by the acmeorders
+	// controller when creating the challenge
+	accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyAuth, err := (&acme.Client{Key: accountKey}).HTTP01ChallengeResponse("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// run the acmesolver TLS server locally, standing in for the solver pod
+	// exposed on port 443 of the challenge domain
+	server := &solver.TLSALPN01Solver{Domain: "example.com", Key: keyAuth}
+	go server.Listen(logtesting.TestLogger{T: t})
+	defer server.Shutdown(context.Background())
+	var addr net.Addr
+	for i := 0; i < 100 && addr == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+		addr = server.Addr()
+	}
+	if addr == nil {
+		t.Fatal("timed out waiting for the solver to listen")
+	}
+	_, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		key         string
+		expectedErr bool
+	}{
+		"should pass if the challenge certificate is presented": {
+			key: keyAuth,
+		},
+		"should error if the challenge certificate is for another key": {
+			key:         "another-token.thumbprint",
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var dialed []string
+			s := Solver{
+				dialContext: func(ctx context.Context, network, dialAddr string) (net.Conn, error) {
+					dialed = append(dialed, dialAddr)
+					return (&net.Dialer{}).DialContext(ctx, network, net.JoinHostPort("127.0.0.1", port))
+				},
+				requiredPasses: 2,
+			}
+			ch := testChallenge(&cmacme.ACMEChallengeSolverTLSALPN01{})
+			ch.Spec.Key = test.key
+
+			err := s.Check(context.Background(), nil, ch)
+			if err != nil && !test.expectedErr {
+				t.Errorf("unexpected error from Check: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected error from Check, but got none")
+			}
+			if !test.expectedErr && len(dialed) != 2 {
+				t.Errorf("expected Check to verify reachability 2 times, but only checked %d", len(dialed))
+			}
+			for _, a := range dialed {
+				if a != "example.com:443" {
+					t.Errorf("expected Check to connect to example.com:443, but connected to %s", a)
+				}
+			}
+		})
+	}
+}
+
+func TestPresentAndCleanUp(t *testing.T) {
+	tests := map[string]struct {
+		cfg           *cmacme.ACMEChallengeSolverTLSALPN01
+		expIngresses  int
+		expTLSRoutes  int
+		expSvcType    corev1.ServiceType
+		expSvcPort    int32
+		expSvcLabels  map[string]string
+		expRouteLabel string
+	}{
+		"should create a NodePort service by default": {
+			cfg:        &cmacme.ACMEChallengeSolverTLSALPN01{},
+			expSvcType: corev1.ServiceTypeNodePort,
+			expSvcPort: 443,
+		},
+		"should create a service with the configured type, port and labels": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				Service: &cmacme.ACMEChallengeSolverTLSALPN01Service{
+					Type:   corev1.ServiceTypeLoadBalancer,
+					Port:   8443,
+					Labels: map[string]string{"edge": "true"},
+				},
+			},
+			expSvcType:   corev1.ServiceTypeLoadBalancer,
+			expSvcPort:   8443,
+			expSvcLabels: map[string]string{"edge": "true"},
+		},
+		"should create a TLSRoute for the gateway solver": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				GatewayTLSRoute: &cmacme.ACMEChallengeSolverTLSALPN01GatewayTLSRoute{
+					Labels: map[string]string{"gateway": "edge"},
+				},
+			},
+			expTLSRoutes:  1,
+			expSvcType:    corev1.ServiceTypeNodePort,
+			expSvcPort:    443,
+			expRouteLabel: "gateway",
+		},
+		"should create an Ingress for the SNI passthrough solver": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				SNIPassthrough: &cmacme.ACMEChallengeSolverTLSALPN01SNIPassthrough{
+					Class: strPtr("nginx"),
+					IngressTemplate: &cmacme.ACMEChallengeSolverTLSALPN01IngressTemplate{
+						ACMEChallengeSolverTLSALPN01IngressObjectMeta: cmacme.ACMEChallengeSolverTLSALPN01IngressObjectMeta{
+							Annotations: map[string]string{"nginx.ingress.kubernetes.io/ssl-passthrough": "true"},
+						},
+					},
+				},
+			},
+			expIngresses: 1,
+			expSvcType:   corev1.ServiceTypeNodePort,
+			expSvcPort:   443,
+		},
+	}
+
+	for name, scenario := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := &test.Builder{T: t}
+			b.Init()
+			s, err := NewSolver(b.Context)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.Start()
+			defer b.Stop()
+
+			ch := testChallenge(scenario.cfg)
+			ctx := context.Background()
+			if err := s.Present(ctx, nil, ch); err != nil {
+				t.Fatalf("unexpected error from Present: %v", err)
+			}
+			b.Sync()
+			// Present is idempotent and should not create any more resources
+			if err := s.Present(ctx, nil, ch); err != nil {
+				t.Fatalf("unexpected error from second call to Present: %v", err)
+			}
+			b.Sync()
+
+			pods, err := s.getPodsForChallenge(ctx, ch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pods) != 1 {
+				t.Fatalf("expected 1 pod, got %d", len(pods))
+			}
+			args := pods[0].Spec.Containers[0].Args
+			if args[0] != "--challenge-type=tls-alpn-01" || args[3] != "--key=token.thumbprint" {
+				t.Errorf("unexpected solver pod args %v", args)
+			}
+
+			svcs, err := s.getServicesForChallenge(ctx, ch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(svcs) != 1 {
+				t.Fatalf("expected 1 service, got %d", len(svcs))
+			}
+			svc := svcs[0]
+			if svc.Spec.Type != scenario.expSvcType {
+				t.Errorf("expected service type %q, got %q", scenario.expSvcType, svc.Spec.Type)
+			}
+			if port := svc.Spec.Ports[0]; port.Port != scenario.expSvcPort || port.TargetPort.IntValue() != acmeSolverListenPort {
+				t.Errorf("unexpected service port %v", port)
+			}
+			if !labels.SelectorFromSet(scenario.expSvcLabels).Matches(labels.Set(svc.Labels)) {
+				t.Errorf("expected service labels to contain %v, got %v", scenario.expSvcLabels, svc.Labels)
+			}
+
+			ingresses, err := s.getIngressesForChallenge(ctx, ch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ingresses) != scenario.expIngresses {
+				t.Fatalf("expected %d ingresses, got %d", scenario.expIngresses, len(ingresses))
+			}
+			for _, ing := range ingresses {
+				if ing.Spec.Rules[0].Host != "example.com" || ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name != svc.Name {
+					t.Errorf("unexpected ingress rules %v", ing.Spec.Rules)
+				}
+				if ing.Annotations["nginx.ingress.kubernetes.io/ssl-passthrough"] != "true" {
+					t.Errorf("expected ingress template annotations to be set, got %v", ing.Annotations)
+				}
+			}
+
+			tlsRoutes, err := s.getGatewayTLSRoutesForChallenge(ctx, ch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tlsRoutes) != scenario.expTLSRoutes {
+				t.Fatalf("expected %d TLSRoutes, got %d", scenario.expTLSRoutes, len(tlsRoutes))
+			}
+			for _, route := range tlsRoutes {
+				if _, ok := route.Labels[scenario.expRouteLabel]; !ok {
+					t.Errorf("expected TLSRoute labels to contain %q, got %v", scenario.expRouteLabel, route.Labels)
+				}
+				rule := route.Spec.Rules[0]
+				if string(rule.Matches[0].SNIs[0]) != "example.com" || *rule.ForwardTo[0].ServiceName != svc.Name {
+					t.Errorf("unexpected TLSRoute rule %v", rule)
+				}
+			}
+
+			if err := s.CleanUp(ctx, nil, ch); err != nil {
+				t.Fatalf("unexpected error from CleanUp: %v", err)
+			}
+			b.Sync()
+
+			pods, _ = s.getPodsForChallenge(ctx, ch)
+			svcs, _ = s.getServicesForChallenge(ctx, ch)
+			ingresses, _ = s.getIngressesForChallenge(ctx, ch)
+			tlsRoutes, _ = s.getGatewayTLSRoutesForChallenge(ctx, ch)
+			if len(pods)+len(svcs)+len(ingresses)+len(tlsRoutes) > 0 {
+				t.Errorf("expected all resources to be cleaned up, got %d pods, %d services, %d ingresses and %d TLSRoutes",
+					len(pods), len(svcs), len(ingresses), len(tlsRoutes))
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsalpn
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/pointer"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1alpha1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// ensureGatewayTLSRoute ensures that the TLSRoute needed to solve a challenge exists.
+func (s *Solver) ensureGatewayTLSRoute(ctx context.Context, ch *cmacme.Challenge, svc *corev1.Service) (*gwapi.TLSRoute, error) {
+	log := logf.FromContext(ctx).WithName("ensureGatewayTLSRoute")
+
+	tlsRoute, err := s.getGatewayTLSRoute(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsRoute == nil {
+		log.Info("creating TLSRoute for challenge", "name", ch.Name, "namespace", ch.Namespace)
+		return s.GWClient.NetworkingV1alpha1().TLSRoutes(ch.Namespace).Create(ctx, buildTLSRoute(ch, svc), metav1.CreateOptions{})
+	}
+
+	log.Info("found existing TLSRoute for challenge", "name", ch.Name, "namespace", ch.Namespace)
+
+	return s.checkAndUpdateGatewayTLSRoute(ctx, ch, svc, tlsRoute)
+}
+
+func (s *Solver) getGatewayTLSRoute(ctx context.Context, ch *cmacme.Challenge) (*gwapi.TLSRoute, error) {
+	log := logf.FromContext(ctx).WithName("getGatewayTLSRoute")
+
+	tlsRoutes, err := s.getGatewayTLSRoutesForChallenge(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	switch len(tlsRoutes) {
+	case 0:
+		return nil, nil
+	case 1:
+		return tlsRoutes[0], nil
+	default:
+		for _, tlsRoute := range tlsRoutes[1:] {
+			log.Info("deleting extra TLSRoute", "name", tlsRoute.Name, "namespace", tlsRoute.Namespace)
+			err := s.GWClient.NetworkingV1alpha1().TLSRoutes(tlsRoute.Namespace).Delete(ctx, tlsRoute.Name, metav1.DeleteOptions{})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, fmt.Errorf("multiple TLSRoutes found")
+	}
+}
+
+// getGatewayTLSRoutesForChallenge returns a list of TLSRoutes that were
+// created to solve the given challenge
+func (s *Solver) getGatewayTLSRoutesForChallenge(_ context.Context, ch *cmacme.Challenge) ([]*gwapi.TLSRoute, error) {
+	tlsRoutes, err := s.tlsRouteLister.TLSRoutes(ch.Namespace).List(labels.Set(podLabels(ch)).AsSelector())
+	if err != nil {
+		return nil, err
+	}
+
+	var relevantRoutes []*gwapi.TLSRoute
+	for _, tlsRoute := range tlsRoutes {
+		if metav1.IsControlledBy(tlsRoute, ch) {
+			relevantRoutes = append(relevantRoutes, tlsRoute)
+		}
+	}
+	return relevantRoutes, nil
+}
+
+func (s *Solver) checkAndUpdateGatewayTLSRoute(ctx context.Context, ch *cmacme.Challenge, svc *corev1.Service, tlsRoute *gwapi.TLSRoute) (*gwapi.TLSRoute, error) {
+	log := logf.FromContext(ctx, "checkAndUpdateGatewayTLSRoute")
+
+	expected := buildTLSRoute(ch, svc)
+	if reflect.DeepEqual(expected.Spec, tlsRoute.Spec) && reflect.DeepEqual(expected.Labels, tlsRoute.Labels) {
+		return tlsRoute, nil
+	}
+
+	log.Info("TLSRoute is out of date, updating", "name", tlsRoute.Name, "namespace", tlsRoute.Namespace)
+	var ret *gwapi.TLSRoute
+	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		oldTLSRoute, err := s.GWClient.NetworkingV1alpha1().TLSRoutes(tlsRoute.Namespace).Get(ctx, tlsRoute.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		newTLSRoute := oldTLSRoute.DeepCopy()
+		newTLSRoute.Spec = expected.Spec
+		newTLSRoute.Labels = expected.Labels
+		ret, err = s.GWClient.NetworkingV1alpha1().TLSRoutes(newTLSRoute.Namespace).Update(ctx, newTLSRoute, metav1.UpdateOptions{})
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// buildTLSRoute returns a TLSRoute forwarding TLS connections for the
+// challenge domain to the given solver Service.
+func buildTLSRoute(ch *cmacme.Challenge, svc *corev1.Service) *gwapi.TLSRoute {
+	labels := podLabels(ch)
+	for k, v := range ch.Spec.Solver.TLSALPN01.GatewayTLSRoute.Labels {
+		labels[k] = v
+	}
+	return &gwapi.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName:    "cm-acme-tls-alpn-solver-",
+			Namespace:       ch.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)},
+		},
+		Spec: gwapi.TLSRouteSpec{
+			Gateways: &gwapi.RouteGateways{
+				Allow: func() *gwapi.GatewayAllowType { a := gwapi.GatewayAllowAll; return &a }(),
+			},
+			Rules: []gwapi.TLSRouteRule{
+				{
+					Matches: []gwapi.TLSRouteMatch{
+						{
+							SNIs: []gwapi.Hostname{gwapi.Hostname(ch.Spec.DNSName)},
+						},
+					},
+					ForwardTo: []gwapi.RouteForwardTo{
+						{
+							ServiceName: pointer.String(svc.Name),
+							Port:        func() *gwapi.PortNumber { p := gwapi.PortNumber(servicePort(ch)); return &p }(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *Solver) cleanupGatewayTLSRoutes(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupGatewayTLSRoutes")
+
+	if ch.Spec.Solver.TLSALPN01 == nil || ch.Spec.Solver.TLSALPN01.GatewayTLSRoute == nil {
+		return nil
+	}
+
+	tlsRoutes, err := s.getGatewayTLSRoutesForChallenge(ctx, ch)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, tlsRoute := range tlsRoutes {
+		log := logf.WithRelatedResource(log, tlsRoute)
+		log.V(logf.DebugLevel).Info("deleting TLSRoute resource")
+
+		err := s.GWClient.NetworkingV1alpha1().TLSRoutes(tlsRoute.Namespace).Delete(ctx, tlsRoute.Name, metav1.DeleteOptions{})
+		if err != nil {
+			log.V(logf.WarnLevel).Info("failed to delete TLSRoute resource", "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		log.V(logf.DebugLevel).Info("successfully deleted TLSRoute resource")
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -23,6 +23,7 @@ import (
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/revocation"
 )
 
 // CA is a simple CA implementation backed by the Kubernetes API server.
@@ -33,6 +34,10 @@ type CA struct {
 	issuer        v1.GenericIssuer
 	secretsLister corelisters.SecretLister
 
+	// revocationStore records revocations in the issuer's revocation
+	// database.
+	revocationStore *revocation.Store
+
 	// Namespace in which to read resources related to this Issuer from.
 	// For Issuers, this will be the namespace of the Issuer.
 	// For ClusterIssuers, this will be the cluster resource namespace.
@@ -46,6 +51,7 @@ func NewCA(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface,
 		Context:           ctx,
 		issuer:            issuer,
 		secretsLister:     secretsLister,
+		revocationStore:   revocation.NewStore(ctx.Client, ctx.Clock),
 		resourceNamespace: ctx.IssuerOptions.ResourceNamespace(issuer),
 	}, nil
 }
@@ -0,0 +1,304 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ocspresponder implements an RFC 6960 OCSP responder for
+// certificates signed by CA issuers, answering requests from the issuer's
+// revocation database.
+package ocspresponder
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ocsp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/revocation"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// DefaultResponseDuration is the validity period of OCSP responses for CA
+// issuers that do not specify `spec.ca.revocation.ocsp.responseDuration`.
+const DefaultResponseDuration = time.Hour
+
+// maxRequestSize is the maximum size of an OCSP request accepted by the
+// responder. Requests for a single certificate are well under 1KiB.
+const maxRequestSize = 10 * 1024
+
+// Responder answers OCSP requests for certificates signed by CA issuers that
+// have `spec.ca.revocation.ocsp` set. Requests are accepted using both the
+// POST and GET methods defined in RFC 6960, appendix A.1, at:
+//
+//   /issuers/<namespace>/<name>
+//   /clusterissuers/<name>
+//
+// Responses are signed using the issuer's delegated OCSP responder
+// certificate.
+type Responder struct {
+	log                 logr.Logger
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	secretLister        corelisters.SecretLister
+	issuerOptions       controllerpkg.IssuerOptions
+	clock               clock.Clock
+}
+
+// NewResponder returns a Responder which reads issuers and Secrets using the
+// listers of the given controller context. It must be called before the
+// context's informer factories are started.
+func NewResponder(ctx *controllerpkg.Context) *Responder {
+	r := &Responder{
+		log:           logf.FromContext(ctx.RootContext, "ocsp-responder"),
+		issuerLister:  ctx.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
+		secretLister:  ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		issuerOptions: ctx.IssuerOptions,
+		clock:         ctx.Clock,
+	}
+	// ClusterIssuers are not watched when scoped to a single namespace
+	if ctx.Namespace == "" {
+		r.clusterIssuerLister = ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers().Lister()
+	}
+	return r
+}
+
+func (r *Responder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	log := r.log.WithValues("path", req.URL.Path)
+
+	var ref, encodedRequest string
+	switch req.Method {
+	case http.MethodPost:
+		ref = req.URL.EscapedPath()
+	case http.MethodGet:
+		ref, encodedRequest = splitGETPath(req.URL.EscapedPath())
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	issuer, err := r.issuerForPath(ref)
+	if apierrors.IsNotFound(err) || (err == nil && issuer == nil) {
+		http.NotFound(w, req)
+		return
+	}
+	if err != nil {
+		log.Error(err, "failed to get issuer")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	ca := issuer.GetSpec().CA
+	if ca == nil || ca.Revocation == nil || ca.Revocation.OCSP == nil {
+		http.NotFound(w, req)
+		return
+	}
+
+	var requestDER []byte
+	if req.Method == http.MethodPost {
+		requestDER, err = ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxRequestSize))
+	} else {
+		requestDER, err = decodeGETRequest(encodedRequest)
+	}
+	if err != nil {
+		writeResponse(w, ocsp.MalformedRequestErrorResponse)
+		return
+	}
+
+	log = logf.WithResource(log, issuer)
+	response, err := r.respond(logf.NewContext(req.Context(), log), issuer, requestDER)
+	if err != nil {
+		log.Error(err, "failed to answer OCSP request")
+		writeResponse(w, ocsp.InternalErrorErrorResponse)
+		return
+	}
+	writeResponse(w, response)
+}
+
+// respond returns the signed OCSP response to the given DER encoded request.
+// An error is only returned if a response could not be produced due to an
+// issue with the issuer or its Secrets.
+func (r *Responder) respond(ctx context.Context, issuer cmapi.GenericIssuer, requestDER []byte) ([]byte, error) {
+	log := logf.FromContext(ctx)
+	ca := issuer.GetSpec().CA
+	resourceNamespace := r.issuerOptions.ResourceNamespace(issuer)
+
+	request, err := ocsp.ParseRequest(requestDER)
+	if err != nil {
+		log.V(logf.DebugLevel).Info("malformed OCSP request", "error", err.Error())
+		return ocsp.MalformedRequestErrorResponse, nil
+	}
+
+	caCert, err := kube.SecretTLSCert(ctx, r.secretLister, resourceNamespace, ca.SecretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CA certificate: %w", err)
+	}
+	if !requestMatchesIssuer(request, caCert) {
+		// RFC 6960, 2.3: the responder is not authoritative for requests
+		// about certificates signed by other CAs.
+		return ocsp.UnauthorizedErrorResponse, nil
+	}
+
+	responderCerts, responderKey, err := kube.SecretTLSKeyPair(ctx, r.secretLister, resourceNamespace, ca.Revocation.OCSP.ResponderSecretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OCSP responder key pair: %w", err)
+	}
+	responderCert := responderCerts[0]
+	if err := r.validateResponderCertificate(responderCert, caCert); err != nil {
+		return nil, err
+	}
+
+	db, err := revocation.ReadShard(r.secretLister.Secrets(resourceNamespace), ca.Revocation.SecretName, request.SerialNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revocation database: %w", err)
+	}
+
+	duration := DefaultResponseDuration
+	if ca.Revocation.OCSP.ResponseDuration != nil {
+		duration = ca.Revocation.OCSP.ResponseDuration.Duration
+	}
+	now := r.clock.Now().UTC().Truncate(time.Second)
+	template := ocsp.Response{
+		Status:       ocsp.Unknown,
+		SerialNumber: request.SerialNumber,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(duration),
+		Certificate:  responderCert,
+		IssuerHash:   request.HashAlgorithm,
+	}
+
+	if entry := db.Lookup(request.SerialNumber); entry != nil {
+		template.Status = ocsp.Good
+		if entry.Revoked() {
+			reason, err := pki.ParseRevocationReason(entry.RevocationReason)
+			if err != nil {
+				return nil, fmt.Errorf("invalid revocation database entry for serial number %s: %w", entry.SerialNumber, err)
+			}
+			template.Status = ocsp.Revoked
+			template.RevokedAt = *entry.RevocationTime
+			template.RevocationReason = int(reason)
+		}
+	}
+
+	log.V(logf.DebugLevel).Info("answering OCSP request", "serial_number", fmt.Sprintf("%x", request.SerialNumber), "status", template.Status)
+
+	return ocsp.CreateResponse(caCert, responderCert, template, responderKey)
+}
+
+// validateResponderCertificate checks that the given certificate is
+// authorised to sign OCSP responses on behalf of the CA, as required by
+// RFC 6960, 4.2.2.2.
+func (r *Responder) validateResponderCertificate(cert, caCert *x509.Certificate) error {
+	if err := cert.CheckSignatureFrom(caCert); err != nil {
+		return fmt.Errorf("OCSP responder certificate was not signed by the CA: %w", err)
+	}
+
+	now := r.clock.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return fmt.Errorf("OCSP responder certificate is not valid at %s", now.Format(time.RFC3339))
+	}
+
+	for _, usage := range cert.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageOCSPSigning {
+			return nil
+		}
+	}
+	return fmt.Errorf("OCSP responder certificate does not have the 'ocsp signing' usage")
+}
+
+// issuerForPath returns the issuer named by the escaped request path, or nil
+// if the path does not name an issuer.
+func (r *Responder) issuerForPath(path string) (cmapi.GenericIssuer, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) == 3 && parts[0] == "issuers" && parts[1] != "" && parts[2] != "":
+		return r.issuerLister.Issuers(parts[1]).Get(parts[2])
+	case len(parts) == 2 && parts[0] == "clusterissuers" && parts[1] != "" && r.clusterIssuerLister != nil:
+		return r.clusterIssuerLister.Get(parts[1])
+	}
+	return nil, nil
+}
+
+// splitGETPath splits the escaped path of a GET request into the path
+// naming the issuer and the URL encoded OCSP request. The encoded request
+// may itself contain unescaped '/' characters from its base64 encoding.
+func splitGETPath(path string) (string, string) {
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 4)
+	switch {
+	case len(parts) >= 3 && parts[0] == "clusterissuers":
+		return "/" + strings.Join(parts[:2], "/"), strings.Join(parts[2:], "/")
+	case len(parts) == 4 && parts[0] == "issuers":
+		return "/" + strings.Join(parts[:3], "/"), parts[3]
+	}
+	return path, ""
+}
+
+// decodeGETRequest decodes an OCSP request sent using the GET method, which
+// is the URL encoding of the base64 encoding of the DER encoded request.
+func decodeGETRequest(encoded string) ([]byte, error) {
+	unescaped, err := url.PathUnescape(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(unescaped)
+}
+
+// requestMatchesIssuer returns true if the issuer name and key hashes in the
+// request identify the given CA certificate.
+func requestMatchesIssuer(request *ocsp.Request, caCert *x509.Certificate) bool {
+	if !request.HashAlgorithm.Available() {
+		return false
+	}
+
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(caCert.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return false
+	}
+
+	h := request.HashAlgorithm.New()
+	h.Write(caCert.RawSubject)
+	nameHash := h.Sum(nil)
+
+	h = request.HashAlgorithm.New()
+	h.Write(spki.PublicKey.RightAlign())
+	keyHash := h.Sum(nil)
+
+	return bytes.Equal(nameHash, request.IssuerNameHash) && bytes.Equal(keyHash, request.IssuerKeyHash)
+}
+
+func writeResponse(w http.ResponseWriter, response []byte) {
+	w.Header().Set("Content-Type", "application/ocsp-response")
+	_, _ = w.Write(response)
+}
@@ -0,0 +1,310 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocspresponder
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/revocation"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var fixedClockStart = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+type keyPair struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func (k *keyPair) secret(t *testing.T, name string) *corev1.Secret {
+	certPEM, err := pki.EncodeX509(k.cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodeECPrivateKey(k.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: gen.DefaultTestNamespace},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+}
+
+func mustCreateKeyPair(t *testing.T, issuer *keyPair, template *x509.Certificate) *keyPair {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.NotBefore = fixedClockStart.Add(-time.Hour)
+	template.NotAfter = fixedClockStart.Add(24 * time.Hour)
+
+	issuerCert, issuerKey := template, key
+	if issuer != nil {
+		issuerCert, issuerKey = issuer.cert, issuer.key
+	}
+	_, cert, err := pki.SignCertificate(template, issuerCert, key.Public(), issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &keyPair{cert: cert, key: key}
+}
+
+func mustCreateCA(t *testing.T, cn string) *keyPair {
+	return mustCreateKeyPair(t, nil, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	})
+}
+
+func mustCreateLeaf(t *testing.T, ca *keyPair, serial int64, extKeyUsage ...x509.ExtKeyUsage) *keyPair {
+	return mustCreateKeyPair(t, ca, &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  extKeyUsage,
+	})
+}
+
+func TestResponder(t *testing.T) {
+	ca := mustCreateCA(t, "ca")
+	otherCA := mustCreateCA(t, "other-ca")
+	responder := mustCreateLeaf(t, ca, 0x99, x509.ExtKeyUsageOCSPSigning)
+	invalidResponder := mustCreateLeaf(t, ca, 0x98, x509.ExtKeyUsageServerAuth)
+
+	good := mustCreateLeaf(t, ca, 0x10)
+	revoked := mustCreateLeaf(t, ca, 0x20)
+	unknown := mustCreateLeaf(t, ca, 0x30)
+	foreign := mustCreateLeaf(t, otherCA, 0x10)
+
+	goodDB := &revocation.Database{}
+	goodDB.AddIssued(good.cert)
+	goodDBData, err := goodDB.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	revokedDB := &revocation.Database{}
+	revokedDB.Revoke(revoked.cert, pki.RevocationReasonKeyCompromise, fixedClockStart.Add(-time.Minute))
+	revokedDBData, err := revokedDB.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ocspIssuer := func(responderSecretName string) cmapi.CAIssuer {
+		return cmapi.CAIssuer{
+			SecretName: "ca",
+			Revocation: &cmapi.CARevocation{
+				SecretName: "revocations",
+				OCSP:       &cmapi.CAOCSP{ResponderSecretName: responderSecretName},
+			},
+		}
+	}
+
+	builder := &testpkg.Builder{
+		T:     t,
+		Clock: fakeclock.NewFakeClock(fixedClockStart),
+		KubeObjects: []runtime.Object{
+			ca.secret(t, "ca"),
+			responder.secret(t, "responder"),
+			invalidResponder.secret(t, "invalid-responder"),
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: revocation.ShardSecretName("revocations", good.cert.SerialNumber), Namespace: gen.DefaultTestNamespace},
+				Data:       map[string][]byte{revocation.DatabaseKey: goodDBData},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: revocation.ShardSecretName("revocations", revoked.cert.SerialNumber), Namespace: gen.DefaultTestNamespace},
+				Data:       map[string][]byte{revocation.DatabaseKey: revokedDBData},
+			},
+		},
+		CertManagerObjects: []runtime.Object{
+			gen.Issuer("ocsp", gen.SetIssuerCA(ocspIssuer("responder"))),
+			gen.Issuer("invalid-responder", gen.SetIssuerCA(ocspIssuer("invalid-responder"))),
+			gen.Issuer("no-ocsp", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"})),
+		},
+	}
+	builder.Init()
+
+	r := &Responder{
+		log:           logf.Log,
+		issuerLister:  builder.SharedInformerFactory.Certmanager().V1().Issuers().Lister(),
+		secretLister:  builder.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		issuerOptions: controllerpkg.IssuerOptions{},
+		clock:         builder.Clock,
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	mustCreateRequest := func(leaf, issuer *keyPair) []byte {
+		req, err := ocsp.CreateRequest(leaf.cert, issuer.cert, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+
+	tests := map[string]struct {
+		method  string
+		path    string
+		request []byte
+
+		expCode          int
+		expStatus        int
+		expReason        int
+		expResponseError error
+	}{
+		"answers good for a certificate that has not been revoked": {
+			method:    http.MethodPost,
+			path:      "/issuers/" + gen.DefaultTestNamespace + "/ocsp",
+			request:   mustCreateRequest(good, ca),
+			expCode:   http.StatusOK,
+			expStatus: ocsp.Good,
+		},
+		"answers revoked for a revoked certificate using GET": {
+			method:    http.MethodGet,
+			path:      "/issuers/" + gen.DefaultTestNamespace + "/ocsp",
+			request:   mustCreateRequest(revoked, ca),
+			expCode:   http.StatusOK,
+			expStatus: ocsp.Revoked,
+			expReason: ocsp.KeyCompromise,
+		},
+		"answers unknown for a certificate that is not in the revocation database": {
+			method:    http.MethodPost,
+			path:      "/issuers/" + gen.DefaultTestNamespace + "/ocsp",
+			request:   mustCreateRequest(unknown, ca),
+			expCode:   http.StatusOK,
+			expStatus: ocsp.Unknown,
+		},
+		"answers unauthorized for a certificate signed by another CA": {
+			method:           http.MethodPost,
+			path:             "/issuers/" + gen.DefaultTestNamespace + "/ocsp",
+			request:          mustCreateRequest(foreign, otherCA),
+			expCode:          http.StatusOK,
+			expResponseError: ocsp.ResponseError{Status: ocsp.Unauthorized},
+		},
+		"answers malformed for an invalid request": {
+			method:           http.MethodPost,
+			path:             "/issuers/" + gen.DefaultTestNamespace + "/ocsp",
+			request:          []byte("not a request"),
+			expCode:          http.StatusOK,
+			expResponseError: ocsp.ResponseError{Status: ocsp.Malformed},
+		},
+		"answers internal error if the responder certificate cannot sign OCSP responses": {
+			method:           http.MethodPost,
+			path:             "/issuers/" + gen.DefaultTestNamespace + "/invalid-responder",
+			request:          mustCreateRequest(good, ca),
+			expCode:          http.StatusOK,
+			expResponseError: ocsp.ResponseError{Status: ocsp.InternalError},
+		},
+		"not found if the issuer does not have an OCSP responder": {
+			method:  http.MethodPost,
+			path:    "/issuers/" + gen.DefaultTestNamespace + "/no-ocsp",
+			request: mustCreateRequest(good, ca),
+			expCode: http.StatusNotFound,
+		},
+		"not found if the issuer does not exist": {
+			method:  http.MethodPost,
+			path:    "/issuers/" + gen.DefaultTestNamespace + "/missing",
+			request: mustCreateRequest(good, ca),
+			expCode: http.StatusNotFound,
+		},
+		"not found for ClusterIssuers when they are not watched": {
+			method:  http.MethodPost,
+			path:    "/clusterissuers/ocsp",
+			request: mustCreateRequest(good, ca),
+			expCode: http.StatusNotFound,
+		},
+		"method not allowed for PUT requests": {
+			method:  http.MethodPut,
+			path:    "/issuers/" + gen.DefaultTestNamespace + "/ocsp",
+			expCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var req *http.Request
+			if test.method == http.MethodGet {
+				encoded := url.PathEscape(base64.StdEncoding.EncodeToString(test.request))
+				req = httptest.NewRequest(test.method, test.path+"/"+encoded, nil)
+			} else {
+				req = httptest.NewRequest(test.method, test.path, bytes.NewReader(test.request))
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != test.expCode {
+				t.Fatalf("unexpected status code, exp=%d got=%d", test.expCode, rec.Code)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			resp, err := ocsp.ParseResponse(rec.Body.Bytes(), ca.cert)
+			if test.expResponseError != nil {
+				var respErr ocsp.ResponseError
+				if !errors.As(err, &respErr) || respErr != test.expResponseError {
+					t.Fatalf("unexpected error, exp=%v got=%v", test.expResponseError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.Status != test.expStatus {
+				t.Errorf("unexpected OCSP status, exp=%d got=%d", test.expStatus, resp.Status)
+			}
+			if resp.Status == ocsp.Revoked && resp.RevocationReason != test.expReason {
+				t.Errorf("unexpected revocation reason, exp=%d got=%d", test.expReason, resp.RevocationReason)
+			}
+			if resp.Certificate == nil || !resp.Certificate.Equal(responder.cert) {
+				t.Errorf("expected response to be signed by the delegated responder certificate")
+			}
+			if !resp.ThisUpdate.Equal(fixedClockStart) || !resp.NextUpdate.Equal(fixedClockStart.Add(DefaultResponseDuration)) {
+				t.Errorf("unexpected response validity %s - %s", resp.ThisUpdate, resp.NextUpdate)
+			}
+		})
+	}
+}
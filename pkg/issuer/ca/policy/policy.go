@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy applies the signing policy configured on CA issuers to the
+// templates of the certificates they sign.
+package policy
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"time"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var signatureAlgorithms = map[cmapi.SignatureAlgorithm]x509.SignatureAlgorithm{
+	cmapi.SHA256WithRSA:    x509.SHA256WithRSA,
+	cmapi.SHA384WithRSA:    x509.SHA384WithRSA,
+	cmapi.SHA512WithRSA:    x509.SHA512WithRSA,
+	cmapi.SHA256WithRSAPSS: x509.SHA256WithRSAPSS,
+	cmapi.SHA384WithRSAPSS: x509.SHA384WithRSAPSS,
+	cmapi.SHA512WithRSAPSS: x509.SHA512WithRSAPSS,
+	cmapi.ECDSAWithSHA256:  x509.ECDSAWithSHA256,
+	cmapi.ECDSAWithSHA384:  x509.ECDSAWithSHA384,
+	cmapi.ECDSAWithSHA512:  x509.ECDSAWithSHA512,
+	cmapi.PureEd25519:      x509.PureEd25519,
+}
+
+// SignatureAlgorithm returns the X.509 signature algorithm corresponding to
+// the given issuer signature algorithm, after checking that it can be used
+// with the given CA public key. It returns x509.UnknownSignatureAlgorithm if
+// alg is empty, to let the algorithm be chosen based on the CA key.
+func SignatureAlgorithm(alg cmapi.SignatureAlgorithm, caPublicKey crypto.PublicKey) (x509.SignatureAlgorithm, error) {
+	if alg == "" {
+		return x509.UnknownSignatureAlgorithm, nil
+	}
+
+	sigAlg, ok := signatureAlgorithms[alg]
+	if !ok {
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported signature algorithm %q", alg)
+	}
+
+	var keyAlg x509.PublicKeyAlgorithm
+	switch caPublicKey.(type) {
+	case *rsa.PublicKey:
+		keyAlg = x509.RSA
+	case *ecdsa.PublicKey:
+		keyAlg = x509.ECDSA
+	case ed25519.PublicKey:
+		keyAlg = x509.Ed25519
+	default:
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported CA public key type %T", caPublicKey)
+	}
+
+	if signatureKeyAlgorithm(sigAlg) != keyAlg {
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("signature algorithm %q cannot be used with a %s CA key", alg, keyAlg)
+	}
+
+	return sigAlg, nil
+}
+
+// signatureKeyAlgorithm returns the type of key required by one of the
+// supported signature algorithms.
+func signatureKeyAlgorithm(sigAlg x509.SignatureAlgorithm) x509.PublicKeyAlgorithm {
+	switch sigAlg {
+	case x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.ECDSAWithSHA512:
+		return x509.ECDSA
+	case x509.PureEd25519:
+		return x509.Ed25519
+	default:
+		return x509.RSA
+	}
+}
+
+// Apply applies the signing policy of the given CA issuer to the template of
+// a certificate to be signed by caCert: the signature algorithm, serial
+// number, duration and backdating. durationRequested must be false if the
+// request did not specify a duration, so that the default duration of the
+// issuer is used. The validity of the template, including any backdating, is
+// reduced to the maximum duration of the issuer and clamped to the expiry of
+// the CA certificate. Apply returns a warning describing each reduction, to
+// be reported on the request.
+func Apply(ca *cmapi.CAIssuer, caCert *x509.Certificate, template *x509.Certificate, durationRequested bool) ([]string, error) {
+	sigAlg, err := SignatureAlgorithm(ca.SignatureAlgorithm, caCert.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	template.SignatureAlgorithm = sigAlg
+
+	if ca.SerialNumberLength != 0 {
+		template.SerialNumber, err = serialNumber(ca.SerialNumberLength)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !durationRequested && ca.DefaultDuration != nil {
+		template.NotAfter = template.NotBefore.Add(ca.DefaultDuration.Duration)
+	}
+
+	if ca.Backdate != nil {
+		template.NotBefore = template.NotBefore.Add(-ca.Backdate.Duration)
+	}
+	if template.NotBefore.Before(caCert.NotBefore) {
+		template.NotBefore = caCert.NotBefore
+	}
+
+	var warnings []string
+
+	// The maximum duration bounds the whole validity of the certificate, so
+	// it is enforced after backdating.
+	if ca.MaxDuration != nil && template.NotAfter.Sub(template.NotBefore) > ca.MaxDuration.Duration {
+		template.NotAfter = template.NotBefore.Add(ca.MaxDuration.Duration)
+		warnings = append(warnings, fmt.Sprintf("Certificate expiry reduced to %s to not exceed the maximum duration %s of the issuer",
+			template.NotAfter.UTC().Format(time.RFC3339), ca.MaxDuration.Duration))
+	}
+
+	if template.NotAfter.After(caCert.NotAfter) {
+		template.NotAfter = caCert.NotAfter
+		warnings = append(warnings, fmt.Sprintf("Certificate expiry reduced to %s to not outlive the CA certificate",
+			template.NotAfter.UTC().Format(time.RFC3339)))
+	}
+
+	return warnings, nil
+}
+
+// serialNumber returns a random positive serial number whose DER encoding is
+// at most length bytes long.
+func serialNumber(length int) (*big.Int, error) {
+	if length < cmapi.MinimumCASerialNumberLength || length > cmapi.MaximumCASerialNumberLength {
+		return nil, fmt.Errorf("serial number length must be between %d and %d bytes, got %d", cmapi.MinimumCASerialNumberLength, cmapi.MaximumCASerialNumberLength, length)
+	}
+
+	// The most significant bit is never set, as it would require a leading
+	// zero byte in the DER encoding to keep the serial number positive.
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(8*length-1))
+	for {
+		serial, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate serial number: %s", err)
+		}
+		if serial.Sign() > 0 {
+			return serial, nil
+		}
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"crypto/x509"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+func TestSignatureAlgorithm(t *testing.T) {
+	rsaKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		alg    cmapi.SignatureAlgorithm
+		key    interface{}
+		exp    x509.SignatureAlgorithm
+		expErr bool
+	}{
+		"no algorithm should let it be chosen based on the key": {
+			key: rsaKey.Public(),
+			exp: x509.UnknownSignatureAlgorithm,
+		},
+		"RSA-PSS with an RSA key": {
+			alg: cmapi.SHA384WithRSAPSS,
+			key: rsaKey.Public(),
+			exp: x509.SHA384WithRSAPSS,
+		},
+		"PKCS#1 v1.5 with an RSA key": {
+			alg: cmapi.SHA512WithRSA,
+			key: rsaKey.Public(),
+			exp: x509.SHA512WithRSA,
+		},
+		"ECDSA with an ECDSA key": {
+			alg: cmapi.ECDSAWithSHA384,
+			key: ecKey.Public(),
+			exp: x509.ECDSAWithSHA384,
+		},
+		"RSA-PSS with an ECDSA key should fail": {
+			alg:    cmapi.SHA256WithRSAPSS,
+			key:    ecKey.Public(),
+			expErr: true,
+		},
+		"ECDSA with an RSA key should fail": {
+			alg:    cmapi.ECDSAWithSHA256,
+			key:    rsaKey.Public(),
+			expErr: true,
+		},
+		"unknown algorithm should fail": {
+			alg:    "MD5WithRSA",
+			key:    rsaKey.Public(),
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			alg, err := SignatureAlgorithm(test.alg, test.key)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, expected error=%t, got: %v", test.expErr, err)
+			}
+			if alg != test.exp {
+				t.Errorf("unexpected signature algorithm, exp=%s got=%s", test.exp, alg)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	caKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert := &x509.Certificate{
+		PublicKey: caKey.Public(),
+		NotBefore: now.Add(-24 * time.Hour),
+		NotAfter:  now.Add(365 * 24 * time.Hour),
+	}
+
+	duration := func(d time.Duration) *metav1.Duration {
+		return &metav1.Duration{Duration: d}
+	}
+
+	tests := map[string]struct {
+		ca                cmapi.CAIssuer
+		requested         time.Duration
+		durationRequested bool
+
+		expNotBefore time.Time
+		expNotAfter  time.Time
+		expWarnings  []string
+		expErr       bool
+	}{
+		"no policy should keep the requested validity": {
+			requested:         30 * 24 * time.Hour,
+			durationRequested: true,
+			expNotBefore:      now,
+			expNotAfter:       now.Add(30 * 24 * time.Hour),
+		},
+		"the default duration should be used if no duration was requested": {
+			ca:           cmapi.CAIssuer{DefaultDuration: duration(7 * 24 * time.Hour)},
+			requested:    cmapi.DefaultCertificateDuration,
+			expNotBefore: now,
+			expNotAfter:  now.Add(7 * 24 * time.Hour),
+		},
+		"the default duration should not override a requested duration": {
+			ca:                cmapi.CAIssuer{DefaultDuration: duration(7 * 24 * time.Hour)},
+			requested:         2 * time.Hour,
+			durationRequested: true,
+			expNotBefore:      now,
+			expNotAfter:       now.Add(2 * time.Hour),
+		},
+		"a requested duration longer than the maximum should be reduced": {
+			ca:                cmapi.CAIssuer{MaxDuration: duration(24 * time.Hour)},
+			requested:         30 * 24 * time.Hour,
+			durationRequested: true,
+			expNotBefore:      now,
+			expNotAfter:       now.Add(24 * time.Hour),
+			expWarnings: []string{
+				"Certificate expiry reduced to 2021-06-02T12:00:00Z to not exceed the maximum duration 24h0m0s of the issuer",
+			},
+		},
+		"the maximum duration should include backdating": {
+			ca: cmapi.CAIssuer{
+				MaxDuration: duration(24 * time.Hour),
+				Backdate:    duration(5 * time.Minute),
+			},
+			requested:         24 * time.Hour,
+			durationRequested: true,
+			expNotBefore:      now.Add(-5 * time.Minute),
+			expNotAfter:       now.Add(24*time.Hour - 5*time.Minute),
+			expWarnings: []string{
+				"Certificate expiry reduced to 2021-06-02T11:55:00Z to not exceed the maximum duration 24h0m0s of the issuer",
+			},
+		},
+		"backdating should not extend NotAfter": {
+			ca:                cmapi.CAIssuer{Backdate: duration(5 * time.Minute)},
+			requested:         time.Hour,
+			durationRequested: true,
+			expNotBefore:      now.Add(-5 * time.Minute),
+			expNotAfter:       now.Add(time.Hour),
+		},
+		"backdating should not go before the CA NotBefore": {
+			ca:                cmapi.CAIssuer{Backdate: duration(48 * time.Hour)},
+			requested:         time.Hour,
+			durationRequested: true,
+			expNotBefore:      now.Add(-24 * time.Hour),
+			expNotAfter:       now.Add(time.Hour),
+		},
+		"a certificate outliving the CA should be clamped to the CA expiry": {
+			requested:         2 * 365 * 24 * time.Hour,
+			durationRequested: true,
+			expNotBefore:      now,
+			expNotAfter:       caCert.NotAfter,
+			expWarnings: []string{
+				"Certificate expiry reduced to 2022-06-01T12:00:00Z to not outlive the CA certificate",
+			},
+		},
+		"a signature algorithm incompatible with the CA key should fail": {
+			ca:                cmapi.CAIssuer{SignatureAlgorithm: cmapi.SHA256WithRSA},
+			requested:         time.Hour,
+			durationRequested: true,
+			expErr:            true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				NotBefore:    now,
+				NotAfter:     now.Add(test.requested),
+			}
+
+			warnings, err := Apply(&test.ca, caCert, template, test.durationRequested)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, expected error=%t, got: %v", test.expErr, err)
+			}
+			if test.expErr {
+				return
+			}
+			if !reflect.DeepEqual(warnings, test.expWarnings) {
+				t.Errorf("unexpected warnings, exp=%q got=%q", test.expWarnings, warnings)
+			}
+			if !template.NotBefore.Equal(test.expNotBefore) {
+				t.Errorf("unexpected NotBefore, exp=%s got=%s", test.expNotBefore, template.NotBefore)
+			}
+			if !template.NotAfter.Equal(test.expNotAfter) {
+				t.Errorf("unexpected NotAfter, exp=%s got=%s", test.expNotAfter, template.NotAfter)
+			}
+		})
+	}
+}
+
+func TestApplySerialNumberLength(t *testing.T) {
+	caKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert := &x509.Certificate{
+		PublicKey: caKey.Public(),
+		NotAfter:  time.Now().Add(time.Hour),
+	}
+
+	for _, length := range []int{cmapi.MinimumCASerialNumberLength, 16, cmapi.MaximumCASerialNumberLength} {
+		for i := 0; i < 100; i++ {
+			template := &x509.Certificate{NotBefore: time.Now(), NotAfter: time.Now().Add(time.Minute)}
+			if _, err := Apply(&cmapi.CAIssuer{SerialNumberLength: length}, caCert, template, true); err != nil {
+				t.Fatal(err)
+			}
+			if template.SerialNumber.Sign() <= 0 {
+				t.Fatalf("serial number must be positive, got %s", template.SerialNumber)
+			}
+			// the DER encoding of a positive integer needs an extra byte
+			// when its most significant bit is set
+			if encodedLength := template.SerialNumber.BitLen()/8 + 1; encodedLength > length {
+				t.Fatalf("serial number %x is encoded in %d bytes, more than %d", template.SerialNumber, encodedLength, length)
+			}
+		}
+	}
+
+	template := &x509.Certificate{NotBefore: time.Now(), NotAfter: time.Now().Add(time.Minute)}
+	if _, err := Apply(&cmapi.CAIssuer{SerialNumberLength: 21}, caCert, template, true); err == nil {
+		t.Errorf("expected an error for a serial number length of 21 bytes")
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package revocation keeps track of the certificates signed by CA issuers so
+// that they can be revoked, and builds certificate revocation lists from them.
+package revocation
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/jetstack/cert-manager/pkg/util/errors"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	// DatabaseKey is the key of the revocation Secret under which the
+	// database of signed certificates is stored.
+	DatabaseKey = "certificates.json"
+
+	// CRLKey is the key of the Secret or ConfigMap under which a PEM encoded
+	// CRL is published.
+	CRLKey = "ca.crl"
+)
+
+// Entry records a single certificate signed by a CA issuer.
+type Entry struct {
+	// SerialNumber is the hex encoded serial number of the certificate.
+	SerialNumber string `json:"serialNumber"`
+
+	// NotAfter is the expiry time of the certificate. Entries are removed
+	// from the database once the certificate has expired.
+	NotAfter time.Time `json:"notAfter"`
+
+	// RevocationTime is set once the certificate has been revoked.
+	RevocationTime *time.Time `json:"revocationTime,omitempty"`
+
+	// RevocationReason is the RFC 5280 name of the reason the certificate
+	// was revoked for.
+	RevocationReason string `json:"revocationReason,omitempty"`
+}
+
+// Revoked returns true if the certificate has been revoked.
+func (e *Entry) Revoked() bool {
+	return e.RevocationTime != nil
+}
+
+// Database is the list of certificates signed by a CA issuer.
+type Database struct {
+	Certificates []Entry `json:"certificates"`
+}
+
+// FromSecret decodes the Database stored in the given Secret. An empty
+// Database is returned if the Secret does not contain one yet.
+func FromSecret(secret *corev1.Secret) (*Database, error) {
+	db := &Database{}
+	data := secret.Data[DatabaseKey]
+	if len(data) == 0 {
+		return db, nil
+	}
+
+	if err := json.Unmarshal(data, db); err != nil {
+		return nil, errors.NewInvalidData("error decoding revocation database in secret %s/%s: %s",
+			secret.Namespace, secret.Name, err.Error())
+	}
+	return db, nil
+}
+
+// Encode returns the JSON representation of the Database, with entries
+// sorted by serial number to keep the output stable.
+func (d *Database) Encode() ([]byte, error) {
+	sort.Slice(d.Certificates, func(i, j int) bool {
+		return d.Certificates[i].SerialNumber < d.Certificates[j].SerialNumber
+	})
+	return json.Marshal(d)
+}
+
+// Lookup returns the entry for the given serial number, or nil if the
+// certificate is not known.
+func (d *Database) Lookup(serialNumber *big.Int) *Entry {
+	serial := formatSerial(serialNumber)
+	for i := range d.Certificates {
+		if d.Certificates[i].SerialNumber == serial {
+			return &d.Certificates[i]
+		}
+	}
+	return nil
+}
+
+// AddIssued records that the given certificate has been signed.
+func (d *Database) AddIssued(cert *x509.Certificate) {
+	if d.Lookup(cert.SerialNumber) != nil {
+		return
+	}
+	d.Certificates = append(d.Certificates, Entry{
+		SerialNumber: formatSerial(cert.SerialNumber),
+		NotAfter:     cert.NotAfter.UTC(),
+	})
+}
+
+// Revoke records the revocation of the given certificate, adding it to the
+// database if it was signed before it was being tracked. Certificates which
+// have already been revoked keep their original revocation time and reason.
+func (d *Database) Revoke(cert *x509.Certificate, reason pki.RevocationReason, now time.Time) {
+	d.AddIssued(cert)
+	entry := d.Lookup(cert.SerialNumber)
+	if entry.Revoked() {
+		return
+	}
+
+	revocationTime := now.UTC().Truncate(time.Second)
+	entry.RevocationTime = &revocationTime
+	entry.RevocationReason = reason.String()
+}
+
+// Prune removes all entries for certificates that expired before now.
+func (d *Database) Prune(now time.Time) {
+	var certs []Entry
+	for _, entry := range d.Certificates {
+		if entry.NotAfter.Before(now) {
+			continue
+		}
+		certs = append(certs, entry)
+	}
+	d.Certificates = certs
+}
+
+// RevokedCertificates returns the CRL entries for every certificate that is
+// revoked and has not yet expired, sorted by serial number.
+func (d *Database) RevokedCertificates(now time.Time) ([]pkix.RevokedCertificate, error) {
+	var revoked []pkix.RevokedCertificate
+	for _, entry := range d.Certificates {
+		if !entry.Revoked() || entry.NotAfter.Before(now) {
+			continue
+		}
+
+		serialNumber, ok := new(big.Int).SetString(entry.SerialNumber, 16)
+		if !ok {
+			return nil, errors.NewInvalidData("invalid serial number %q in revocation database", entry.SerialNumber)
+		}
+		reason, err := pki.ParseRevocationReason(entry.RevocationReason)
+		if err != nil {
+			return nil, errors.NewInvalidData("invalid revocation reason for serial number %s: %s", entry.SerialNumber, err.Error())
+		}
+		revokedCert, err := pki.RevokedCertificate(serialNumber, *entry.RevocationTime, reason)
+		if err != nil {
+			return nil, err
+		}
+		revoked = append(revoked, revokedCert)
+	}
+
+	sort.Slice(revoked, func(i, j int) bool {
+		return revoked[i].SerialNumber.Cmp(revoked[j].SerialNumber) < 0
+	})
+	return revoked, nil
+}
+
+func formatSerial(serialNumber *big.Int) string {
+	return fmt.Sprintf("%x", serialNumber)
+}
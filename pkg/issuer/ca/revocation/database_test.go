@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocation
+
+import (
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+func certWithSerial(serial int64, notAfter time.Time) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotAfter:     notAfter,
+	}
+}
+
+func TestDatabase(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	db := &Database{}
+	db.AddIssued(certWithSerial(0x10, now.Add(time.Hour)))
+	db.AddIssued(certWithSerial(0x10, now.Add(time.Hour)))
+	db.AddIssued(certWithSerial(0x20, now.Add(time.Hour)))
+	db.AddIssued(certWithSerial(0x30, now.Add(-time.Hour)))
+	if len(db.Certificates) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(db.Certificates))
+	}
+
+	db.Revoke(certWithSerial(0x20, now.Add(time.Hour)), pki.RevocationReasonKeyCompromise, now)
+	// revoking twice must keep the original reason
+	db.Revoke(certWithSerial(0x20, now.Add(time.Hour)), pki.RevocationReasonSuperseded, now.Add(time.Minute))
+	// certificates signed before tracking was enabled can still be revoked
+	db.Revoke(certWithSerial(0x40, now.Add(time.Hour)), pki.RevocationReasonUnspecified, now)
+
+	entry := db.Lookup(big.NewInt(0x20))
+	if entry == nil || !entry.Revoked() {
+		t.Fatalf("expected serial 20 to be revoked, got %+v", entry)
+	}
+	if entry.RevocationReason != "keyCompromise" || !entry.RevocationTime.Equal(now) {
+		t.Errorf("unexpected revocation of serial 20: %+v", entry)
+	}
+	if db.Lookup(big.NewInt(0x10)).Revoked() {
+		t.Errorf("expected serial 10 to not be revoked")
+	}
+
+	revoked, err := db.RevokedCertificates(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revoked) != 2 || revoked[0].SerialNumber.Int64() != 0x20 || revoked[1].SerialNumber.Int64() != 0x40 {
+		t.Errorf("unexpected revoked certificates: %+v", revoked)
+	}
+
+	db.Prune(now)
+	if len(db.Certificates) != 3 || db.Lookup(big.NewInt(0x30)) != nil {
+		t.Errorf("expected expired serial 30 to be pruned, got %+v", db.Certificates)
+	}
+}
+
+func TestDatabaseEncoding(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	db := &Database{}
+	db.AddIssued(certWithSerial(0xff, now))
+	db.Revoke(certWithSerial(0x0a, now), pki.RevocationReasonCACompromise, now)
+
+	data, err := db.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const exp = `{"certificates":[{"serialNumber":"a","notAfter":"2021-06-01T12:00:00Z","revocationTime":"2021-06-01T12:00:00Z","revocationReason":"cACompromise"},{"serialNumber":"ff","notAfter":"2021-06-01T12:00:00Z"}]}`
+	if string(data) != exp {
+		t.Errorf("unexpected encoding:\nexp=%s\ngot=%s", exp, data)
+	}
+
+	decoded, err := FromSecret(&corev1.Secret{Data: map[string][]byte{DatabaseKey: data}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Certificates) != 2 || decoded.Lookup(big.NewInt(0x0a)).RevocationReason != "cACompromise" {
+		t.Errorf("unexpected decoded database: %+v", decoded)
+	}
+
+	empty, err := FromSecret(&corev1.Secret{})
+	if err != nil || len(empty.Certificates) != 0 {
+		t.Errorf("expected empty database from empty secret, got %+v (%v)", empty, err)
+	}
+
+	if _, err := FromSecret(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "db"},
+		Data:       map[string][]byte{DatabaseKey: []byte("{")},
+	}); err == nil {
+		t.Errorf("expected error decoding invalid database")
+	}
+}
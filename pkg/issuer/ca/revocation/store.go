@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocation
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
+
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// Shards is the number of Secrets the revocation Database of an issuer is
+// split across. Certificates are assigned to a shard by the last byte of
+// their serial number, which is random for certificates signed by CA
+// issuers, so that neither the size limit of a single Secret nor conflicting
+// writes to it limit how many certificates an issuer can sign.
+const Shards = 256
+
+// ShardSecretName returns the name of the Secret that the entry for the
+// certificate with the given serial number is stored in, for the revocation
+// Database with the given name.
+func ShardSecretName(name string, serialNumber *big.Int) string {
+	shard := new(big.Int).Mod(serialNumber, big.NewInt(Shards))
+	return shardSecretName(name, int(shard.Int64()))
+}
+
+func shardSecretName(name string, shard int) string {
+	return fmt.Sprintf("%s-%02x", name, shard)
+}
+
+// IsShardSecretName returns true if secretName names one of the Secrets that
+// the revocation Database with the given name is stored in.
+func IsShardSecretName(name, secretName string) bool {
+	if !strings.HasPrefix(secretName, name+"-") {
+		return false
+	}
+	shard, err := strconv.ParseUint(strings.TrimPrefix(secretName, name+"-"), 16, 8)
+	return err == nil && shardSecretName(name, int(shard)) == secretName
+}
+
+// ReadDatabase reads all shards of the revocation Database with the given
+// name using the lister, merging them into a single Database. Shards that do
+// not exist are treated as empty, since they are only created once the first
+// certificate assigned to them has been signed.
+func ReadDatabase(secrets corelisters.SecretNamespaceLister, name string) (*Database, error) {
+	db := &Database{}
+	for shard := 0; shard < Shards; shard++ {
+		shardDB, err := readShard(secrets, shardSecretName(name, shard))
+		if err != nil {
+			return nil, err
+		}
+		db.Certificates = append(db.Certificates, shardDB.Certificates...)
+	}
+	return db, nil
+}
+
+// ReadShard reads the shard of the revocation Database with the given name
+// that holds the entry for the given serial number.
+func ReadShard(secrets corelisters.SecretNamespaceLister, name string, serialNumber *big.Int) (*Database, error) {
+	return readShard(secrets, ShardSecretName(name, serialNumber))
+}
+
+func readShard(secrets corelisters.SecretNamespaceLister, secretName string) (*Database, error) {
+	secret, err := secrets.Get(secretName)
+	if apierrors.IsNotFound(err) {
+		return &Database{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return FromSecret(secret)
+}
+
+// Store persists revocation Databases in Secrets, split into Shards.
+// Every write reads the latest version of the shard's Secret from the API
+// server and is retried on conflict, so that concurrent signing and
+// revocation of certificates by the same issuer do not lose updates.
+type Store struct {
+	client kubernetes.Interface
+	clock  clock.Clock
+}
+
+// NewStore returns a Store which reads and writes Secrets using the given
+// client.
+func NewStore(client kubernetes.Interface, clock clock.Clock) *Store {
+	return &Store{client: client, clock: clock}
+}
+
+// RecordIssued records that the given certificate has been signed by the
+// issuer whose revocation Database is named namespace/name.
+func (s *Store) RecordIssued(ctx context.Context, namespace, name string, cert *x509.Certificate) error {
+	return s.update(ctx, namespace, ShardSecretName(name, cert.SerialNumber), func(db *Database) {
+		db.AddIssued(cert)
+	})
+}
+
+// RecordRevoked records the revocation of the given certificate in the
+// Database named namespace/name.
+func (s *Store) RecordRevoked(ctx context.Context, namespace, name string, cert *x509.Certificate, reason pki.RevocationReason) error {
+	return s.update(ctx, namespace, ShardSecretName(name, cert.SerialNumber), func(db *Database) {
+		db.Revoke(cert, reason, s.clock.Now())
+	})
+}
+
+// update applies mutate to the Database shard stored in the Secret
+// namespace/name, creating the Secret if it does not exist. Expired entries
+// are pruned on every write.
+func (s *Store) update(ctx context.Context, namespace, name string, mutate func(*Database)) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		secret, err := s.client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		exists := !apierrors.IsNotFound(err)
+		if !exists {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Type: corev1.SecretTypeOpaque,
+			}
+		} else if err != nil {
+			return err
+		}
+
+		db, err := FromSecret(secret)
+		if err != nil {
+			return err
+		}
+		mutate(db)
+		db.Prune(s.clock.Now())
+
+		data, err := db.Encode()
+		if err != nil {
+			return err
+		}
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[DatabaseKey] = data
+
+		if !exists {
+			_, err = s.client.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// Another worker created the Secret first; retry as an update.
+				return apierrors.NewConflict(corev1.Resource("secrets"), name, err)
+			}
+			return err
+		}
+
+		_, err = s.client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
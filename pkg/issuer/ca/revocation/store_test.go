@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revocation
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset()
+	store := NewStore(client, fakeclock.NewFakeClock(now))
+
+	for _, serial := range []int64{1, 2, 0x101} {
+		if err := store.RecordIssued(ctx, "ns", "revocations", certWithSerial(serial, now.Add(time.Hour))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.RecordRevoked(ctx, "ns", "revocations", certWithSerial(1, now.Add(time.Hour)), pki.RevocationReasonSuperseded); err != nil {
+		t.Fatal(err)
+	}
+
+	// Certificates are spread across shards by the last byte of their
+	// serial number.
+	shards := map[string][]int64{
+		"revocations-01": {1, 0x101},
+		"revocations-02": {2},
+	}
+	secrets, err := client.CoreV1().Secrets("ns").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secrets.Items) != len(shards) {
+		t.Fatalf("expected %d shards, got %d", len(shards), len(secrets.Items))
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if err := indexer.Add(secret); err != nil {
+			t.Fatal(err)
+		}
+
+		db, err := FromSecret(secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		serials, ok := shards[secret.Name]
+		if !ok {
+			t.Fatalf("unexpected shard %s", secret.Name)
+		}
+		if len(db.Certificates) != len(serials) {
+			t.Errorf("expected %d entries in shard %s, got %+v", len(serials), secret.Name, db.Certificates)
+		}
+		for _, serial := range serials {
+			if db.Lookup(big.NewInt(serial)) == nil {
+				t.Errorf("expected serial %x in shard %s", serial, secret.Name)
+			}
+		}
+	}
+
+	lister := corelisters.NewSecretLister(indexer).Secrets("ns")
+	db, err := ReadDatabase(lister, "revocations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(db.Certificates) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", db.Certificates)
+	}
+	if entry := db.Lookup(big.NewInt(1)); !entry.Revoked() || entry.RevocationReason != "superseded" {
+		t.Errorf("expected serial 1 to be revoked as superseded, got %+v", entry)
+	}
+	if db.Lookup(big.NewInt(2)).Revoked() {
+		t.Errorf("expected serial 2 to not be revoked")
+	}
+
+	shard, err := ReadShard(lister, "revocations", big.NewInt(0x201))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shard.Certificates) != 2 {
+		t.Errorf("expected 2 entries in the shard of serial 201, got %+v", shard.Certificates)
+	}
+}
+
+func TestIsShardSecretName(t *testing.T) {
+	tests := map[string]bool{
+		"revocations-00":   true,
+		"revocations-a1":   true,
+		"revocations-ff":   true,
+		"revocations":      false,
+		"revocations-":     false,
+		"revocations-1":    false,
+		"revocations-A1":   false,
+		"revocations-100":  false,
+		"revocations-zz":   false,
+		"revocations-x-01": false,
+		"other-01":         false,
+	}
+	for name, expected := range tests {
+		if got := IsShardSecretName("revocations", name); got != expected {
+			t.Errorf("IsShardSecretName(%q) = %v, expected %v", name, got, expected)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+var _ issuer.Revoker = &CA{}
+
+// Revoke records the revocation of the given DER encoded certificate in the
+// issuer's revocation database, from which CRLs are generated. Only
+// certificates signed by the issuer's current CA can be revoked.
+func (c *CA) Revoke(ctx context.Context, certDER []byte, reason pki.RevocationReason) error {
+	log := logf.FromContext(ctx, "revoke")
+
+	revocation := c.issuer.GetSpec().CA.Revocation
+	if revocation == nil {
+		return issuer.ErrRevocationNotSupported
+	}
+
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return &issuer.RevocationRejectedError{Err: fmt.Errorf("failed to parse certificate: %w", err)}
+	}
+
+	caCert, err := kube.SecretTLSCert(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	if err != nil {
+		return err
+	}
+
+	if err := cert.CheckSignatureFrom(caCert); err != nil {
+		return &issuer.RevocationRejectedError{Err: fmt.Errorf("certificate was not signed by the CA in secret %s/%s: %w",
+			c.resourceNamespace, c.issuer.GetSpec().CA.SecretName, err)}
+	}
+
+	log.V(logf.DebugLevel).Info("revoking certificate", "serial_number", fmt.Sprintf("%x", cert.SerialNumber), "reason", reason.String())
+
+	return c.revocationStore.RecordRevoked(ctx, c.resourceNamespace, revocation.SecretName, cert, reason)
+}
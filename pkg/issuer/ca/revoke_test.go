@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/revocation"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+	testlisters "github.com/jetstack/cert-manager/test/unit/listers"
+)
+
+func mustSelfSign(t *testing.T, cn string) (*x509.Certificate, []byte, *ecdsa.PrivateKey) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		BasicConstraintsValid: true,
+		SerialNumber:          big.NewInt(1),
+		IsCA:                  true,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	certPEM, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, certPEM, key
+}
+
+func mustSignLeaf(t *testing.T, caCert *x509.Certificate, caKey *ecdsa.PrivateKey, serial int64) *x509.Certificate {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	_, cert, err := pki.SignCertificate(template, caCert, key.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestRevoke(t *testing.T) {
+	caCert, caCertPEM, caKey := mustSelfSign(t, "ca")
+	otherCACert, _, otherCAKey := mustSelfSign(t, "other-ca")
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: gen.DefaultTestNamespace},
+		Data:       map[string][]byte{corev1.TLSCertKey: caCertPEM},
+	}
+	revocationIssuer := gen.Issuer("test", gen.SetIssuerCA(cmapi.CAIssuer{
+		SecretName: "ca",
+		Revocation: &cmapi.CARevocation{SecretName: "revocations"},
+	}))
+
+	tests := map[string]struct {
+		issuer      cmapi.GenericIssuer
+		cert        *x509.Certificate
+		wantsErr    error
+		wantsReject bool
+		wantsRecord bool
+	}{
+		"records the revocation of a certificate signed by the CA": {
+			issuer:      revocationIssuer,
+			cert:        mustSignLeaf(t, caCert, caKey, 0x42),
+			wantsRecord: true,
+		},
+		"revocation is not supported if not enabled on the issuer": {
+			issuer:   gen.Issuer("test", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"})),
+			cert:     mustSignLeaf(t, caCert, caKey, 0x42),
+			wantsErr: issuer.ErrRevocationNotSupported,
+		},
+		"rejects certificates signed by another CA": {
+			issuer:      revocationIssuer,
+			cert:        mustSignLeaf(t, otherCACert, otherCAKey, 0x42),
+			wantsReject: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			c := &CA{
+				issuer: test.issuer,
+				secretsLister: testlisters.FakeSecretListerFrom(testlisters.NewFakeSecretLister(),
+					testlisters.SetFakeSecretNamespaceListerGet(caSecret, nil),
+				),
+				revocationStore:   revocation.NewStore(client, fakeclock.NewFakeClock(time.Now())),
+				resourceNamespace: gen.DefaultTestNamespace,
+			}
+
+			err := c.Revoke(context.Background(), test.cert.Raw, pki.RevocationReasonKeyCompromise)
+			if test.wantsErr != nil && !errors.Is(err, test.wantsErr) {
+				t.Errorf("unexpected error, exp=%v got=%v", test.wantsErr, err)
+			}
+			var rejectedErr *issuer.RevocationRejectedError
+			if errors.As(err, &rejectedErr) != test.wantsReject {
+				t.Errorf("unexpected rejection, exp=%t got=%v", test.wantsReject, err)
+			}
+			if test.wantsErr == nil && !test.wantsReject && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			secret, err := client.CoreV1().Secrets(gen.DefaultTestNamespace).Get(context.Background(), "revocations-42", metav1.GetOptions{})
+			if !test.wantsRecord {
+				if err == nil {
+					t.Errorf("expected revocation database to not be written")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			db, err := revocation.FromSecret(secret)
+			if err != nil {
+				t.Fatal(err)
+			}
+			entry := db.Lookup(big.NewInt(0x42))
+			if entry == nil || !entry.Revoked() || entry.RevocationReason != "keyCompromise" {
+				t.Errorf("expected certificate to be recorded as revoked, got %+v", entry)
+			}
+		})
+	}
+}
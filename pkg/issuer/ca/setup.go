@@ -24,8 +24,10 @@ import (
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer/ca/policy"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util/kube"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
 const (
@@ -42,18 +44,9 @@ const (
 func (c *CA) Setup(ctx context.Context) error {
 	log := logf.FromContext(ctx, "setup")
 
-	cert, err := kube.SecretTLSCert(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	certs, key, err := kube.SecretCAIssuerKeyPair(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA)
 	if err != nil {
-		log.Error(err, "error getting signing CA TLS certificate")
-		s := messageErrorGetKeyPair + err.Error()
-		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorGetKeyPair, s)
-		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorGetKeyPair, s)
-		return err
-	}
-
-	_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
-	if err != nil {
-		log.Error(err, "error getting signing CA private key")
+		log.Error(err, "error getting signing CA key pair")
 		s := messageErrorGetKeyPair + err.Error()
 		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorGetKeyPair, s)
 		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorGetKeyPair, s)
@@ -61,7 +54,7 @@ func (c *CA) Setup(ctx context.Context) error {
 	}
 
 	log = logf.WithRelatedResourceName(log, c.issuer.GetSpec().CA.SecretName, c.resourceNamespace, "Secret")
-	if !cert.IsCA {
+	if !certs[0].IsCA {
 		s := messageErrorGetKeyPair + "certificate is not a CA"
 		log.Error(nil, "signing certificate is not a CA")
 		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorInvalidKeyPair, s)
@@ -70,6 +63,24 @@ func (c *CA) Setup(ctx context.Context) error {
 		return nil
 	}
 
+	// The chain is validated here, rather than only when signing, so that
+	// a misconfigured chain or root is reported on the issuer.
+	if _, err := pki.ParseSingleCertificateChain(certs); err != nil {
+		s := messageErrorGetKeyPair + "invalid certificate chain: " + err.Error()
+		log.Error(err, "signing CA certificate chain is invalid")
+		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorInvalidKeyPair, s)
+		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorInvalidKeyPair, s)
+		return nil
+	}
+
+	if _, err := policy.SignatureAlgorithm(c.issuer.GetSpec().CA.SignatureAlgorithm, key.Public()); err != nil {
+		s := messageErrorGetKeyPair + err.Error()
+		log.Error(err, "signature algorithm cannot be used with the signing CA private key")
+		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorInvalidKeyPair, s)
+		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorInvalidKeyPair, s)
+		return nil
+	}
+
 	log.V(logf.DebugLevel).Info("signing CA verified")
 	c.Recorder.Event(c.issuer, corev1.EventTypeNormal, successKeyPairVerified, messageKeyPairVerified)
 	apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successKeyPairVerified, messageKeyPairVerified)
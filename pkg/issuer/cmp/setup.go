@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmp
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// Setup verifies that a CMP client can be built from the issuer
+// configuration. CMP has no operation that can be used to verify the server
+// without requesting a certificate, so the server is not contacted.
+func (c *CMP) Setup(ctx context.Context) (err error) {
+	defer func() {
+		if err != nil {
+			errorMessage := "Failed to setup CMP issuer"
+			c.log.Error(err, errorMessage)
+			apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, "ErrorSetup", fmt.Sprintf("%s: %v", errorMessage, err))
+			err = fmt.Errorf("%s: %v", errorMessage, err)
+		}
+	}()
+
+	if _, err := c.clientBuilder(c.resourceNamespace, c.secretsLister, c.issuer); err != nil {
+		return fmt.Errorf("error building client: %v", err)
+	}
+
+	// If it does not already have a 'ready' condition, we'll also log an event
+	// to make it really clear to users that this Issuer is ready.
+	if !apiutil.IssuerHasCondition(c.issuer, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		c.Recorder.Eventf(c.issuer, corev1.EventTypeNormal, "Ready", "Configured issuer for CMP server")
+	}
+	c.log.V(logf.DebugLevel).Info("CMP issuer started")
+	apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionTrue, "CMPConfigured", "CMP client configured")
+
+	return nil
+}
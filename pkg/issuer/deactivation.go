@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/util/errors"
+)
+
+const errorDeactivateAccount = "ErrDeactivateAccount"
+
+// WantsAccountDeactivation returns true if the account of the given issuer
+// should be deactivated when the issuer is deleted.
+func WantsAccountDeactivation(iss cmapi.GenericIssuer) bool {
+	return iss.GetSpec().ACME != nil && iss.GetSpec().ACME.DeactivateAccountOnDeletion
+}
+
+// HasAccountDeactivationFinalizer returns true if the given issuer has the
+// account deactivation finalizer.
+func HasAccountDeactivationFinalizer(iss cmapi.GenericIssuer) bool {
+	for _, f := range iss.GetObjectMeta().Finalizers {
+		if f == cmacme.AccountDeactivationFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// SetAccountDeactivationFinalizer adds the account deactivation finalizer to
+// the given issuer if present is true, and removes it otherwise.
+func SetAccountDeactivationFinalizer(iss cmapi.GenericIssuer, present bool) {
+	meta := iss.GetObjectMeta()
+	var finalizers []string
+	for _, f := range meta.Finalizers {
+		if f != cmacme.AccountDeactivationFinalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	if present {
+		finalizers = append(finalizers, cmacme.AccountDeactivationFinalizer)
+	}
+	meta.Finalizers = finalizers
+}
+
+// DeactivateAccount deactivates the account of the given issuer if its
+// implementation supports it, and records an event if that fails. An error
+// is only returned if deactivating the account should be retried.
+func DeactivateAccount(ctx context.Context, factory Factory, recorder record.EventRecorder, iss cmapi.GenericIssuer) error {
+	impl, err := factory.IssuerFor(iss)
+	if err != nil {
+		return err
+	}
+
+	deactivator, ok := impl.(AccountDeactivator)
+	if !ok {
+		return nil
+	}
+
+	if err := deactivator.DeactivateAccount(ctx); err != nil {
+		recorder.Eventf(iss, corev1.EventTypeWarning, errorDeactivateAccount, "Failed to deactivate account: %v", err)
+		if errors.IsInvalidData(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
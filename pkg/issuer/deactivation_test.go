@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"reflect"
+	"testing"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestSetAccountDeactivationFinalizer(t *testing.T) {
+	tests := map[string]struct {
+		finalizers []string
+		present    bool
+		expected   []string
+	}{
+		"adds the finalizer": {
+			finalizers: []string{"other"},
+			present:    true,
+			expected:   []string{"other", cmacme.AccountDeactivationFinalizer},
+		},
+		"does not add the finalizer twice": {
+			finalizers: []string{cmacme.AccountDeactivationFinalizer, "other"},
+			present:    true,
+			expected:   []string{"other", cmacme.AccountDeactivationFinalizer},
+		},
+		"removes the finalizer and keeps other finalizers": {
+			finalizers: []string{"other", cmacme.AccountDeactivationFinalizer},
+			present:    false,
+			expected:   []string{"other"},
+		},
+		"removes the only finalizer": {
+			finalizers: []string{cmacme.AccountDeactivationFinalizer},
+			present:    false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := gen.Issuer("test-issuer")
+			iss.Finalizers = test.finalizers
+
+			SetAccountDeactivationFinalizer(iss, test.present)
+			if !reflect.DeepEqual(iss.Finalizers, test.expected) {
+				t.Errorf("unexpected finalizers, exp=%v got=%v", test.expected, iss.Finalizers)
+			}
+			if HasAccountDeactivationFinalizer(iss) != test.present {
+				t.Errorf("unexpected finalizer presence, exp=%t", test.present)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package est
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// Setup verifies that the CA certificates of the EST server can be retrieved.
+func (e *EST) Setup(ctx context.Context) (err error) {
+	defer func() {
+		if err != nil {
+			errorMessage := "Failed to setup EST issuer"
+			e.log.Error(err, errorMessage)
+			apiutil.SetIssuerCondition(e.issuer, e.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, "ErrorSetup", fmt.Sprintf("%s: %v", errorMessage, err))
+			err = fmt.Errorf("%s: %v", errorMessage, err)
+		}
+	}()
+
+	client, err := e.clientBuilder(e.resourceNamespace, e.secretsLister, e.issuer)
+	if err != nil {
+		return fmt.Errorf("error building client: %v", err)
+	}
+	if _, err := client.CACerts(ctx); err != nil {
+		return err
+	}
+
+	// If it does not already have a 'ready' condition, we'll also log an event
+	// to make it really clear to users that this Issuer is ready.
+	if !apiutil.IssuerHasCondition(e.issuer, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		e.Recorder.Eventf(e.issuer, corev1.EventTypeNormal, "Ready", "Verified issuer with EST server")
+	}
+	e.log.V(logf.DebugLevel).Info("EST issuer started")
+	apiutil.SetIssuerCondition(e.issuer, e.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionTrue, "ESTVerified", "EST server verified")
+
+	return nil
+}
@@ -18,9 +18,11 @@ package fake
 
 import (
 	"context"
+	"crypto/x509"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
 type Issuer struct {
@@ -42,3 +44,31 @@ func (i *Issuer) Setup(ctx context.Context) error {
 func (i *Issuer) Issue(ctx context.Context, crt *cmapi.Certificate) (*issuer.IssueResponse, error) {
 	return i.IssueFunc(ctx, crt)
 }
+
+// Revoker is a fake Issuer that is also able to revoke certificates.
+type Revoker struct {
+	Issuer
+	RevokeFunc func(context.Context, []byte, pki.RevocationReason) error
+}
+
+var _ issuer.Revoker = &Revoker{}
+
+// Revoke asks the issuer to revoke the given DER encoded certificate.
+func (r *Revoker) Revoke(ctx context.Context, cert []byte, reason pki.RevocationReason) error {
+	return r.RevokeFunc(ctx, cert, reason)
+}
+
+// RenewalInfoProvider is a fake Issuer that is also able to provide renewal
+// information for the certificates it issued.
+type RenewalInfoProvider struct {
+	Issuer
+	RenewalInfoFunc func(context.Context, *x509.Certificate) (*issuer.RenewalInfo, error)
+}
+
+var _ issuer.RenewalInfoProvider = &RenewalInfoProvider{}
+
+// RenewalInfo returns the renewal window suggested by the issuer for the
+// given certificate.
+func (r *RenewalInfoProvider) RenewalInfo(ctx context.Context, cert *x509.Certificate) (*issuer.RenewalInfo, error) {
+	return r.RenewalInfoFunc(ctx, cert)
+}
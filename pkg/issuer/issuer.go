@@ -18,6 +18,11 @@ package issuer
 
 import (
 	"context"
+	"crypto/x509"
+	"errors"
+	"time"
+
+	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
 type Interface interface {
@@ -27,6 +32,88 @@ type Interface interface {
 	Setup(ctx context.Context) error
 }
 
+// Revoker is implemented by issuers that are able to revoke certificates
+// that they have previously issued.
+type Revoker interface {
+	// Revoke asks the issuer to revoke the given DER encoded certificate for
+	// the given reason. Revoking a certificate that has already been revoked
+	// must not return an error.
+	Revoke(ctx context.Context, cert []byte, reason pki.RevocationReason) error
+}
+
+// ErrRevocationNotSupported is returned by a Revoker when the issuer is not
+// configured to revoke certificates.
+var ErrRevocationNotSupported = errors.New("issuer does not support certificate revocation")
+
+// RevocationRejectedError is returned by a Revoker when the issuer has
+// rejected a revocation request and retrying the request will not succeed.
+type RevocationRejectedError struct {
+	Err error
+}
+
+func (e *RevocationRejectedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RevocationRejectedError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfterError is returned when signing by issuers that have accepted a
+// request but not issued the certificate yet, and have said when to ask
+// again. The request is retried after RetryAfter instead of the controller's
+// default backoff.
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// RenewalInfoProvider is implemented by issuers that are able to suggest
+// when certificates they have previously issued should be renewed.
+type RenewalInfoProvider interface {
+	// RenewalInfo asks the issuer when the given certificate should be
+	// renewed.
+	RenewalInfo(ctx context.Context, cert *x509.Certificate) (*RenewalInfo, error)
+}
+
+// RenewalInfo is the renewal window suggested by an issuer for a
+// certificate.
+type RenewalInfo struct {
+	// SuggestedWindowStart and SuggestedWindowEnd delimit the window in
+	// which the certificate should be renewed.
+	SuggestedWindowStart time.Time
+	SuggestedWindowEnd   time.Time
+
+	// ExplanationURL optionally points to a page explaining why the
+	// suggested window was chosen.
+	ExplanationURL string
+
+	// RetryAfter is the time the issuer asked to wait before asking for the
+	// renewal information of the certificate again. Zero if not specified.
+	RetryAfter time.Duration
+}
+
+// ErrRenewalInfoNotSupported is returned by a RenewalInfoProvider when the
+// issuer does not provide renewal information.
+var ErrRenewalInfoNotSupported = errors.New("issuer does not provide renewal information")
+
+// AccountDeactivator is implemented by issuers that hold an account with a
+// remote service, which can be deactivated when the issuer is deleted.
+type AccountDeactivator interface {
+	// DeactivateAccount permanently deactivates the issuer's account. It
+	// returns nil if the issuer does not have an account. Errors that will
+	// not be resolved by retrying are InvalidData errors.
+	DeactivateAccount(ctx context.Context) error
+}
+
 type IssueResponse struct {
 	// Certificate is the certificate resource that should be stored in the
 	// target secret.
@@ -44,4 +131,9 @@ type IssueResponse struct {
 	// This field should only be set if the private key field is set, similar
 	// to the Certificate field.
 	CA []byte
+
+	// Warnings are appended to the message of the Ready condition of the
+	// CertificateRequest, for example if the certificate could not be issued
+	// exactly as requested.
+	Warnings []string
 }
@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scep
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// Setup verifies that the capabilities and CA certificates of the SCEP server
+// can be retrieved.
+func (s *SCEP) Setup(ctx context.Context) (err error) {
+	defer func() {
+		if err != nil {
+			errorMessage := "Failed to setup SCEP issuer"
+			s.log.Error(err, errorMessage)
+			apiutil.SetIssuerCondition(s.issuer, s.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, "ErrorSetup", fmt.Sprintf("%s: %v", errorMessage, err))
+			err = fmt.Errorf("%s: %v", errorMessage, err)
+		}
+	}()
+
+	client, err := s.clientBuilder(s.resourceNamespace, s.secretsLister, s.issuer)
+	if err != nil {
+		return fmt.Errorf("error building client: %v", err)
+	}
+	if _, err := client.GetCACaps(ctx); err != nil {
+		return err
+	}
+	if _, err := client.GetCACert(ctx); err != nil {
+		return err
+	}
+
+	// If it does not already have a 'ready' condition, we'll also log an event
+	// to make it really clear to users that this Issuer is ready.
+	if !apiutil.IssuerHasCondition(s.issuer, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		s.Recorder.Eventf(s.issuer, corev1.EventTypeNormal, "Ready", "Verified issuer with SCEP server")
+	}
+	s.log.V(logf.DebugLevel).Info("SCEP issuer started")
+	apiutil.SetIssuerCondition(s.issuer, s.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionTrue, "SCEPVerified", "SCEP server verified")
+
+	return nil
+}
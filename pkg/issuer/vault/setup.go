@@ -23,7 +23,6 @@ import (
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
-	vaultinternal "github.com/jetstack/cert-manager/pkg/internal/vault"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
 
@@ -37,10 +36,12 @@ const (
 	messageVaultStatusVerificationFailed = "Vault is not initialized or is sealed"
 	messageVaultConfigRequired           = "Vault config cannot be empty"
 	messageServerAndPathRequired         = "Vault server and path are required fields"
-	messageAuthFieldsRequired            = "Vault tokenSecretRef, appRole, or kubernetes is required"
+	messageAuthFieldsRequired            = "Vault tokenSecretRef, appRole, kubernetes, jwt, or clientCertificate is required"
 	messageMultipleAuthFieldsSet         = "Multiple auth methods cannot be set on the same Vault issuer"
 
 	messageKubeAuthFieldsRequired    = "Vault Kubernetes auth requires both role and secretRef.name"
+	messageJWTAuthFieldsRequired     = "Vault JWT auth requires both role and serviceAccountRef.name"
+	messageClientCertAuthRequired    = "Vault client certificate auth requires clientCertificate.secretName"
 	messageTokenAuthNameRequired     = "Vault Token auth requires tokenSecretRef.name"
 	messageAppRoleAuthFieldsRequired = "Vault AppRole auth requires both roleId and tokenSecretRef.name"
 )
@@ -63,18 +64,25 @@ func (v *Vault) Setup(ctx context.Context) error {
 	tokenAuth := v.issuer.GetSpec().Vault.Auth.TokenSecretRef
 	appRoleAuth := v.issuer.GetSpec().Vault.Auth.AppRole
 	kubeAuth := v.issuer.GetSpec().Vault.Auth.Kubernetes
+	jwtAuth := v.issuer.GetSpec().Vault.Auth.JWT
+	clientCertAuth := v.issuer.GetSpec().Vault.Auth.ClientCertificate
+
+	authMethods := 0
+	for _, set := range []bool{tokenAuth != nil, appRoleAuth != nil, kubeAuth != nil, jwtAuth != nil, clientCertAuth != nil} {
+		if set {
+			authMethods++
+		}
+	}
 
 	// check if at least one auth method is specified.
-	if tokenAuth == nil && appRoleAuth == nil && kubeAuth == nil {
+	if authMethods == 0 {
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageAuthFieldsRequired)
 		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageAuthFieldsRequired)
 		return nil
 	}
 
 	// check only one auth method set
-	if (tokenAuth != nil && appRoleAuth != nil) ||
-		(tokenAuth != nil && kubeAuth != nil) ||
-		(appRoleAuth != nil && kubeAuth != nil) {
+	if authMethods > 1 {
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageMultipleAuthFieldsSet)
 		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageMultipleAuthFieldsSet)
 		return nil
@@ -101,7 +109,22 @@ func (v *Vault) Setup(ctx context.Context) error {
 		return nil
 	}
 
-	client, err := vaultinternal.New(v.resourceNamespace, v.secretsLister, v.issuer)
+	// check if all mandatory Vault JWT fields are set.
+	if jwtAuth != nil && (len(jwtAuth.ServiceAccountRef.Name) == 0 || len(jwtAuth.Role) == 0) {
+		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageJWTAuthFieldsRequired)
+		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageJWTAuthFieldsRequired)
+		return nil
+	}
+
+	// check if all mandatory Vault client certificate fields are set.
+	if clientCertAuth != nil && len(clientCertAuth.SecretName) == 0 {
+		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageClientCertAuthRequired)
+		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageClientCertAuthRequired)
+		return nil
+	}
+
+	createToken := v.Client.CoreV1().ServiceAccounts(v.resourceNamespace).CreateToken
+	client, err := v.VaultTokenCache.New(ctx, v.resourceNamespace, createToken, v.secretsLister, v.issuer)
 	if err != nil {
 		s := messageVaultClientInitFailed + err.Error()
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, s)
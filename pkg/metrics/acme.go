@@ -20,7 +20,10 @@ limitations under the License.
 // certificate_ready_status{name, namespace, condition}
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
+// acme_client_rate_limit_expiration_timestamp_seconds{"host", "account"}
 // controller_sync_call_count{"controller"}
+// vault_login_count{"method", "status"}
+// vault_token_ttl_seconds{"name", "namespace", "kind"}
 package metrics
 
 import (
@@ -36,3 +39,10 @@ func (m *Metrics) ObserveACMERequestDuration(duration time.Duration, labels ...s
 func (m *Metrics) IncrementACMERequestCount(labels ...string) {
 	m.acmeClientRequestCount.WithLabelValues(labels...).Inc()
 }
+
+// SetACMERateLimitExpiration records the time until which requests to the
+// given ACME server host, made by the given account, are held back due to
+// rate limiting.
+func (m *Metrics) SetACMERateLimitExpiration(until time.Time, host, account string) {
+	m.acmeClientRateLimitExpiration.WithLabelValues(host, account).Set(float64(until.Unix()))
+}
@@ -21,6 +21,8 @@ limitations under the License.
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // controller_sync_call_count{"controller"}
+// vault_login_count{"method", "status"}
+// vault_token_ttl_seconds{"name", "namespace", "kind"}
 package metrics
 
 import (
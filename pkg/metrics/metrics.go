@@ -20,7 +20,10 @@ limitations under the License.
 // certificate_ready_status{name, namespace, condition}
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
+// acme_client_rate_limit_expiration_timestamp_seconds{"host", "account"}
 // controller_sync_call_count{"controller"}
+// vault_login_count{"method", "status"}
+// vault_token_ttl_seconds{"name", "namespace", "kind"}
 package metrics
 
 import (
@@ -58,7 +61,10 @@ type Metrics struct {
 	certificateReadyStatus           *prometheus.GaugeVec
 	acmeClientRequestDurationSeconds *prometheus.SummaryVec
 	acmeClientRequestCount           *prometheus.CounterVec
+	acmeClientRateLimitExpiration    *prometheus.GaugeVec
 	controllerSyncCallCount          *prometheus.CounterVec
+	vaultLoginCount                  *prometheus.CounterVec
+	vaultTokenTTLSeconds             *prometheus.GaugeVec
 }
 
 var readyConditionStatuses = [...]cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
@@ -119,6 +125,20 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"scheme", "host", "path", "method", "status"},
 		)
 
+		// acmeClientRateLimitExpiration is a Prometheus gauge of the time until
+		// which requests to an ACME server are held back due to rate limiting.
+		// The account label is the URI of the ACME account, and is empty for
+		// rate limits applying to all accounts.
+		acmeClientRateLimitExpiration = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "acme_client_rate_limit_expiration_timestamp_seconds",
+				Help:      "The date after which requests rate limited by the ACME server will be retried, expressed in Unix Epoch Time.",
+				Subsystem: "http",
+			},
+			[]string{"host", "account"},
+		)
+
 		controllerSyncCallCount = prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: namespace,
@@ -127,6 +147,28 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			},
 			[]string{"controller"},
 		)
+
+		// vaultLoginCount is a Prometheus counter of the logins made to Vault
+		// by Vault issuers, by auth method and result.
+		vaultLoginCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "vault_login_count",
+				Help:      "The number of logins made to Vault by Vault issuers.",
+			},
+			[]string{"method", "status"},
+		)
+
+		// vaultTokenTTLSeconds is a Prometheus gauge of the TTL that the Vault
+		// token cached for each Vault issuer was granted with.
+		vaultTokenTTLSeconds = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "vault_token_ttl_seconds",
+				Help:      "The TTL in seconds that the cached Vault token of the issuer was granted with.",
+			},
+			[]string{"name", "namespace", "kind"},
+		)
 	)
 
 	// Create server and register Prometheus metrics handler
@@ -139,7 +181,10 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 		certificateReadyStatus:           certificateReadyStatus,
 		acmeClientRequestCount:           acmeClientRequestCount,
 		acmeClientRequestDurationSeconds: acmeClientRequestDurationSeconds,
+		acmeClientRateLimitExpiration:    acmeClientRateLimitExpiration,
 		controllerSyncCallCount:          controllerSyncCallCount,
+		vaultLoginCount:                  vaultLoginCount,
+		vaultTokenTTLSeconds:             vaultTokenTTLSeconds,
 	}
 
 	return m
@@ -152,7 +197,10 @@ func (m *Metrics) NewServer(ln net.Listener, enablePprof bool) *http.Server {
 	m.registry.MustRegister(m.certificateReadyStatus)
 	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
 	m.registry.MustRegister(m.acmeClientRequestCount)
+	m.registry.MustRegister(m.acmeClientRateLimitExpiration)
 	m.registry.MustRegister(m.controllerSyncCallCount)
+	m.registry.MustRegister(m.vaultLoginCount)
+	m.registry.MustRegister(m.vaultTokenTTLSeconds)
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
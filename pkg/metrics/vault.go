@@ -0,0 +1,48 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains global structures related to metrics collection
+// cert-manager exposes the following metrics:
+// certificate_expiration_timestamp_seconds{name, namespace}
+// certificate_ready_status{name, namespace, condition}
+// acme_client_request_count{"scheme", "host", "path", "method", "status"}
+// acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
+// controller_sync_call_count{"controller"}
+// vault_login_count{"method", "status"}
+// vault_token_ttl_seconds{"name", "namespace", "kind"}
+package metrics
+
+import (
+	"time"
+)
+
+// IncrementVaultLoginCount increases the counter of logins made to Vault with
+// the given auth method and status.
+func (m *Metrics) IncrementVaultLoginCount(method, status string) {
+	m.vaultLoginCount.WithLabelValues(method, status).Inc()
+}
+
+// UpdateVaultTokenTTL sets the TTL of the Vault token cached for the issuer
+// with the given name, namespace and kind.
+func (m *Metrics) UpdateVaultTokenTTL(name, namespace, kind string, ttl time.Duration) {
+	m.vaultTokenTTLSeconds.WithLabelValues(name, namespace, kind).Set(ttl.Seconds())
+}
+
+// RemoveVaultTokenTTL removes the Vault token TTL metric of the issuer with
+// the given name, namespace and kind.
+func (m *Metrics) RemoveVaultTokenTTL(name, namespace, kind string) {
+	m.vaultTokenTTLSeconds.DeleteLabelValues(name, namespace, kind)
+}
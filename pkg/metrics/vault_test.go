@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/clock"
+
+	logtesting "github.com/jetstack/cert-manager/pkg/logs/testing"
+)
+
+func TestVaultMetrics(t *testing.T) {
+	m := New(logtesting.TestLogger{T: t}, clock.RealClock{})
+
+	m.IncrementVaultLoginCount("appRole", "success")
+	m.IncrementVaultLoginCount("appRole", "success")
+	m.IncrementVaultLoginCount("kubernetes", "error")
+
+	if err := testutil.CollectAndCompare(m.vaultLoginCount,
+		strings.NewReader(`
+	# HELP certmanager_vault_login_count The number of logins made to Vault by Vault issuers.
+	# TYPE certmanager_vault_login_count counter
+	certmanager_vault_login_count{method="appRole",status="success"} 2
+	certmanager_vault_login_count{method="kubernetes",status="error"} 1
+`),
+		"certmanager_vault_login_count",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	m.UpdateVaultTokenTTL("vault-issuer", "default", "Issuer", time.Hour)
+	m.UpdateVaultTokenTTL("vault-cluster-issuer", "", "ClusterIssuer", 10*time.Minute)
+	m.RemoveVaultTokenTTL("vault-cluster-issuer", "", "ClusterIssuer")
+
+	if err := testutil.CollectAndCompare(m.vaultTokenTTLSeconds,
+		strings.NewReader(`
+	# HELP certmanager_vault_token_ttl_seconds The TTL in seconds that the cached Vault token of the issuer was granted with.
+	# TYPE certmanager_vault_token_ttl_seconds gauge
+	certmanager_vault_token_ttl_seconds{kind="Issuer",name="vault-issuer",namespace="default"} 3600
+`),
+		"certmanager_vault_token_ttl_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+
+	corev1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/util/errors"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// CAIssuerUsesSecret returns true if the given CA issuer reads the Secret
+// with the given name, in its resource namespace, to sign certificates.
+func CAIssuerUsesSecret(ca *cmapi.CAIssuer, name string) bool {
+	if ca.SecretName == name {
+		return true
+	}
+	for _, ref := range []*cmmeta.SecretKeySelector{ca.PrivateKeyPasswordSecretRef, ca.ChainSecretRef, ca.RootSecretRef} {
+		if ref != nil && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretCAIssuerKeyPair returns the X.509 certificate chain and private key
+// of the given CA issuer, read from Secrets in the given namespace. The
+// private key is decrypted using the password referenced by the issuer, if
+// any. The chain is built as described by SecretCAIssuerChain.
+func SecretCAIssuerKeyPair(ctx context.Context, secretLister corelisters.SecretLister, namespace string, ca *cmapi.CAIssuer) ([]*x509.Certificate, crypto.Signer, error) {
+	certs, err := SecretCAIssuerChain(ctx, secretLister, namespace, ca)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secret, err := secretLister.Secrets(namespace).Get(ca.SecretName)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBytes, ok := secret.Data[corev1.TLSPrivateKeyKey]
+	if !ok {
+		return nil, nil, errors.NewInvalidData("no private key data for %q in secret '%s/%s'", corev1.TLSPrivateKeyKey, namespace, ca.SecretName)
+	}
+
+	var key crypto.Signer
+	if ca.PrivateKeyPasswordSecretRef != nil {
+		password, err := secretKeyRefData(secretLister, namespace, ca.PrivateKeyPasswordSecretRef)
+		if err != nil {
+			return nil, nil, err
+		}
+		key, err = pki.DecodePrivateKeyBytesWithPassword(keyBytes, password)
+		if err != nil {
+			return nil, nil, errors.NewInvalidData(err.Error())
+		}
+	} else {
+		key, err = pki.DecodePrivateKeyBytes(keyBytes)
+		if err != nil {
+			return nil, nil, errors.NewInvalidData(err.Error())
+		}
+	}
+
+	return certs, key, nil
+}
+
+// SecretCAIssuerChain returns the X.509 certificate chain of the given CA
+// issuer, read from Secrets in the given namespace, starting with the signing
+// CA certificate. The signing CA certificate is followed by the certificates
+// referenced by ChainSecretRef if set, or else by the rest of `tls.crt`, and
+// then by the certificate referenced by RootSecretRef if set, or else by
+// `ca.crt` if present.
+func SecretCAIssuerChain(ctx context.Context, secretLister corelisters.SecretLister, namespace string, ca *cmapi.CAIssuer) ([]*x509.Certificate, error) {
+	certs, err := SecretTLSCertChain(ctx, secretLister, namespace, ca.SecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	if ca.ChainSecretRef != nil {
+		chain, err := secretKeyRefCertificates(secretLister, namespace, ca.ChainSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs[:1], chain...)
+	}
+
+	if ca.RootSecretRef != nil {
+		root, err := secretKeyRefCertificates(secretLister, namespace, ca.RootSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		return append(certs, root...), nil
+	}
+
+	secret, err := secretLister.Secrets(namespace).Get(ca.SecretName)
+	if err != nil {
+		return nil, err
+	}
+	caBytes, ok := secret.Data[cmmeta.TLSCAKey]
+	if !ok || len(caBytes) == 0 {
+		return certs, nil
+	}
+	root, err := pki.DecodeX509CertificateBytes(caBytes)
+	if err != nil {
+		return nil, errors.NewInvalidData(err.Error())
+	}
+
+	return append(certs, root), nil
+}
+
+// secretKeyRefCertificates returns the PEM encoded certificates stored in the
+// referenced key of a Secret.
+func secretKeyRefCertificates(secretLister corelisters.SecretLister, namespace string, ref *cmmeta.SecretKeySelector) ([]*x509.Certificate, error) {
+	data, err := secretKeyRefData(secretLister, namespace, ref)
+	if err != nil {
+		return nil, err
+	}
+	certs, err := pki.DecodeX509CertificateChainBytes(data)
+	if err != nil {
+		return nil, errors.NewInvalidData("failed to decode certificates from key %q in secret '%s/%s': %s", ref.Key, namespace, ref.Name, err)
+	}
+	return certs, nil
+}
+
+// secretKeyRefData returns the data stored in the referenced key of a Secret.
+func secretKeyRefData(secretLister corelisters.SecretLister, namespace string, ref *cmmeta.SecretKeySelector) ([]byte, error) {
+	secret, err := secretLister.Secrets(namespace).Get(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, errors.NewInvalidData("no data for %q in secret '%s/%s'", ref.Key, namespace, ref.Name)
+	}
+	return data, nil
+}
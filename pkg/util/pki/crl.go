@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/jetstack/cert-manager/pkg/util/errors"
+)
+
+var (
+	// RFC 5280, 5.2.3  CRL Number
+	oidExtensionCRLNumber = asn1.ObjectIdentifier{2, 5, 29, 20}
+	// RFC 5280, 5.3.1  Reason Code
+	oidExtensionReasonCode = asn1.ObjectIdentifier{2, 5, 29, 21}
+)
+
+// RevokedCertificate returns a CRL entry for the certificate with the given
+// serial number. The reason code extension is omitted when the reason is
+// `unspecified`, as recommended by RFC 5280.
+func RevokedCertificate(serialNumber *big.Int, revocationTime time.Time, reason RevocationReason) (pkix.RevokedCertificate, error) {
+	entry := pkix.RevokedCertificate{
+		SerialNumber:   serialNumber,
+		RevocationTime: revocationTime.UTC(),
+	}
+	if reason == RevocationReasonUnspecified {
+		return entry, nil
+	}
+
+	value, err := asn1.Marshal(asn1.Enumerated(reason))
+	if err != nil {
+		return pkix.RevokedCertificate{}, err
+	}
+	entry.Extensions = []pkix.Extension{{Id: oidExtensionReasonCode, Value: value}}
+	return entry, nil
+}
+
+// SignCRL signs the given CRL template using the CA certificate and key, and
+// returns the PEM encoded result. The CA certificate must have the `crl sign`
+// key usage and a subject key identifier.
+func SignCRL(template *x509.RevocationList, caCert *x509.Certificate, caKey crypto.Signer) ([]byte, error) {
+	derBytes, err := x509.CreateRevocationList(rand.Reader, template, caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: derBytes}), nil
+}
+
+// DecodeX509CRLBytes will decode a PEM encoded x509 certificate revocation
+// list.
+func DecodeX509CRLBytes(crlBytes []byte) (*pkix.CertificateList, error) {
+	block, _ := pem.Decode(crlBytes)
+	if block == nil {
+		return nil, errors.NewInvalidData("error decoding CRL PEM block")
+	}
+
+	crl, err := x509.ParseDERCRL(block.Bytes)
+	if err != nil {
+		return nil, errors.NewInvalidData("error parsing CRL: %s", err.Error())
+	}
+
+	return crl, nil
+}
+
+// CRLNumber returns the value of the CRL number extension of the given CRL,
+// or nil if it has none.
+func CRLNumber(crl *pkix.CertificateList) (*big.Int, error) {
+	for _, ext := range crl.TBSCertList.Extensions {
+		if !ext.Id.Equal(oidExtensionCRLNumber) {
+			continue
+		}
+		number := new(big.Int)
+		if _, err := asn1.Unmarshal(ext.Value, &number); err != nil {
+			return nil, errors.NewInvalidData("error parsing CRL number: %s", err.Error())
+		}
+		return number, nil
+	}
+	return nil, nil
+}
+
+// CRLEntryRevocationReason returns the reason given by the reason code
+// extension of a CRL entry, or `unspecified` if it has none.
+func CRLEntryRevocationReason(entry pkix.RevokedCertificate) (RevocationReason, error) {
+	for _, ext := range entry.Extensions {
+		if !ext.Id.Equal(oidExtensionReasonCode) {
+			continue
+		}
+		var reason asn1.Enumerated
+		if _, err := asn1.Unmarshal(ext.Value, &reason); err != nil {
+			return 0, errors.NewInvalidData("error parsing CRL entry reason code: %s", err.Error())
+		}
+		return RevocationReason(reason), nil
+	}
+	return RevocationReasonUnspecified, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func mustCreateCRLSigningCA(t *testing.T, keyUsage x509.KeyUsage) (*x509.Certificate, *ecdsa.PrivateKey) {
+	pk, err := GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		Version:               3,
+		BasicConstraintsValid: true,
+		SerialNumber:          big.NewInt(1),
+		PublicKeyAlgorithm:    x509.ECDSA,
+		PublicKey:             pk.Public(),
+		IsCA:                  true,
+		Subject: pkix.Name{
+			CommonName: "crl-ca",
+		},
+		NotBefore: time.Now(),
+		NotAfter:  time.Now().Add(time.Hour),
+		KeyUsage:  keyUsage,
+	}
+
+	_, cert, err := SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, pk
+}
+
+func TestSignCRL(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	caCert, caKey := mustCreateCRLSigningCA(t, x509.KeyUsageCertSign|x509.KeyUsageCRLSign)
+
+	unspecified, err := RevokedCertificate(big.NewInt(10), now, RevocationReasonUnspecified)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyCompromise, err := RevokedCertificate(big.NewInt(11), now, RevocationReasonKeyCompromise)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crlPEM, err := SignCRL(&x509.RevocationList{
+		Number:              big.NewInt(5),
+		ThisUpdate:          now,
+		NextUpdate:          now.Add(time.Hour),
+		RevokedCertificates: []pkix.RevokedCertificate{unspecified, keyCompromise},
+	}, caCert, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crl, err := DecodeX509CRLBytes(crlPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := caCert.CheckCRLSignature(crl); err != nil {
+		t.Errorf("CRL signature is not valid: %v", err)
+	}
+
+	number, err := CRLNumber(crl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number == nil || number.Int64() != 5 {
+		t.Errorf("unexpected CRL number, exp=5 got=%v", number)
+	}
+
+	revoked := crl.TBSCertList.RevokedCertificates
+	if len(revoked) != 2 {
+		t.Fatalf("expected 2 revoked certificates, got %d", len(revoked))
+	}
+	if len(revoked[0].Extensions) != 0 {
+		t.Errorf("expected no reason code for unspecified reason, got %v", revoked[0].Extensions)
+	}
+	if len(revoked[1].Extensions) != 1 || !revoked[1].Extensions[0].Id.Equal(oidExtensionReasonCode) {
+		t.Fatalf("expected a reason code extension, got %v", revoked[1].Extensions)
+	}
+	var reason asn1.Enumerated
+	if _, err := asn1.Unmarshal(revoked[1].Extensions[0].Value, &reason); err != nil {
+		t.Fatal(err)
+	}
+	if RevocationReason(reason) != RevocationReasonKeyCompromise {
+		t.Errorf("unexpected reason code, exp=%s got=%s", RevocationReasonKeyCompromise, RevocationReason(reason))
+	}
+}
+
+func TestCRLEntryRevocationReason(t *testing.T) {
+	now := time.Now()
+	for _, exp := range []RevocationReason{RevocationReasonUnspecified, RevocationReasonKeyCompromise, RevocationReasonSuperseded} {
+		entry, err := RevokedCertificate(big.NewInt(1), now, exp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reason, err := CRLEntryRevocationReason(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reason != exp {
+			t.Errorf("unexpected reason, exp=%s got=%s", exp, reason)
+		}
+	}
+
+	_, err := CRLEntryRevocationReason(pkix.RevokedCertificate{
+		Extensions: []pkix.Extension{{Id: oidExtensionReasonCode, Value: []byte("invalid")}},
+	})
+	if err == nil {
+		t.Errorf("expected an error for an invalid reason code")
+	}
+}
+
+func TestSignCRLWithoutCRLSignUsage(t *testing.T) {
+	caCert, caKey := mustCreateCRLSigningCA(t, x509.KeyUsageCertSign)
+
+	_, err := SignCRL(&x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now(),
+		NextUpdate: time.Now().Add(time.Hour),
+	}, caCert, caKey)
+	if err == nil {
+		t.Errorf("expected an error signing a CRL with a CA that lacks the crl sign key usage")
+	}
+}
+
+func TestDecodeX509CRLBytesInvalid(t *testing.T) {
+	if _, err := DecodeX509CRLBytes([]byte("not a crl")); err == nil {
+		t.Errorf("expected an error decoding invalid CRL bytes")
+	}
+}
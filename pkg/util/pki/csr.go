@@ -210,6 +210,14 @@ func GenerateCSR(crt *v1.Certificate) (*x509.CertificateRequest, error) {
 		}
 	}
 
+	nameConstraints, err := NameConstraintsExtension(crt.Spec.NameConstraints)
+	if err != nil {
+		return nil, err
+	}
+	if nameConstraints != nil {
+		extraExtensions = append(extraExtensions, *nameConstraints)
+	}
+
 	return &x509.CertificateRequest{
 		Version:            3,
 		SignatureAlgorithm: sigAlgo,
@@ -300,7 +308,7 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 		return nil, err
 	}
 
-	return &x509.Certificate{
+	template := &x509.Certificate{
 		Version:               3,
 		BasicConstraintsValid: true,
 		SerialNumber:          serialNumber,
@@ -326,7 +334,13 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 		IPAddresses:    ipAddresses,
 		URIs:           uris,
 		EmailAddresses: crt.Spec.EmailAddresses,
-	}, nil
+	}
+
+	if err := setNameConstraints(template, crt.Spec.NameConstraints); err != nil {
+		return nil, err
+	}
+
+	return template, nil
 }
 
 // GenerateTemplate will create a x509.Certificate for the given
@@ -368,7 +382,7 @@ func GenerateTemplateFromCSRPEMWithUsages(csrPEM []byte, duration time.Duration,
 		return nil, fmt.Errorf("failed to generate serial number: %s", err.Error())
 	}
 
-	return &x509.Certificate{
+	template := &x509.Certificate{
 		Version:               csr.Version,
 		BasicConstraintsValid: true,
 		SerialNumber:          serialNumber,
@@ -385,7 +399,21 @@ func GenerateTemplateFromCSRPEMWithUsages(csrPEM []byte, duration time.Duration,
 		IPAddresses:    csr.IPAddresses,
 		EmailAddresses: csr.EmailAddresses,
 		URIs:           csr.URIs,
-	}, nil
+	}
+
+	// Name constraints are only meaningful in CA certificates, so are
+	// ignored when the request is not for a CA.
+	if isCA {
+		for _, ext := range csr.Extensions {
+			if ext.Id.Equal(OIDExtensionNameConstraints) {
+				if err := setNameConstraintsFromExtension(template, ext); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return template, nil
 }
 
 // SignCertificate returns a signed *x509.Certificate given a template
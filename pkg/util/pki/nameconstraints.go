@@ -0,0 +1,367 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// OIDExtensionNameConstraints is the OID of the X.509 Name Constraints
+// extension.
+var OIDExtensionNameConstraints = []int{2, 5, 29, 30}
+
+// RFC 5280, 4.2.1.10  Name Constraints
+//
+//	NameConstraints ::= SEQUENCE {
+//	     permittedSubtrees       [0]     GeneralSubtrees OPTIONAL,
+//	     excludedSubtrees        [1]     GeneralSubtrees OPTIONAL }
+//
+//	GeneralSubtrees ::= SEQUENCE SIZE (1..MAX) OF GeneralSubtree
+//
+//	GeneralSubtree ::= SEQUENCE {
+//	     base                    GeneralName,
+//	     minimum         [0]     BaseDistance DEFAULT 0,
+//	     maximum         [1]     BaseDistance OPTIONAL }
+type nameConstraints struct {
+	Permitted []generalSubtree `asn1:"optional,tag:0"`
+	Excluded  []generalSubtree `asn1:"optional,tag:1"`
+}
+
+type generalSubtree struct {
+	Base    asn1.RawValue
+	Minimum int `asn1:"optional,tag:0,default:0"`
+	Maximum int `asn1:"optional,tag:1"`
+}
+
+// GeneralName tags, RFC 5280, 4.2.1.6.
+const (
+	nameTypeEmail = 1
+	nameTypeDNS   = 2
+	nameTypeURI   = 6
+	nameTypeIP    = 7
+)
+
+// nameConstraintSubtrees holds the names of one of the permitted or excluded
+// subtrees of a Name Constraints extension, as stored on an x509.Certificate.
+type nameConstraintSubtrees struct {
+	dnsDomains     []string
+	ipRanges       []*net.IPNet
+	emailAddresses []string
+	uriDomains     []string
+}
+
+func (s nameConstraintSubtrees) empty() bool {
+	return len(s.dnsDomains) == 0 && len(s.ipRanges) == 0 && len(s.emailAddresses) == 0 && len(s.uriDomains) == 0
+}
+
+func permittedSubtrees(cert *x509.Certificate) nameConstraintSubtrees {
+	return nameConstraintSubtrees{
+		dnsDomains:     cert.PermittedDNSDomains,
+		ipRanges:       cert.PermittedIPRanges,
+		emailAddresses: cert.PermittedEmailAddresses,
+		uriDomains:     cert.PermittedURIDomains,
+	}
+}
+
+func excludedSubtrees(cert *x509.Certificate) nameConstraintSubtrees {
+	return nameConstraintSubtrees{
+		dnsDomains:     cert.ExcludedDNSDomains,
+		ipRanges:       cert.ExcludedIPRanges,
+		emailAddresses: cert.ExcludedEmailAddresses,
+		uriDomains:     cert.ExcludedURIDomains,
+	}
+}
+
+// setNameConstraints sets the name constraints fields of the given
+// certificate template to those described by nc.
+func setNameConstraints(template *x509.Certificate, nc *v1.NameConstraints) error {
+	if nc == nil {
+		return nil
+	}
+
+	template.PermittedDNSDomainsCritical = nc.Critical
+	if nc.Permitted != nil {
+		ipRanges, err := parseIPRanges(nc.Permitted.IPRanges)
+		if err != nil {
+			return err
+		}
+		template.PermittedDNSDomains = nc.Permitted.DNSDomains
+		template.PermittedIPRanges = ipRanges
+		template.PermittedEmailAddresses = nc.Permitted.EmailAddresses
+		template.PermittedURIDomains = nc.Permitted.URIDomains
+	}
+	if nc.Excluded != nil {
+		ipRanges, err := parseIPRanges(nc.Excluded.IPRanges)
+		if err != nil {
+			return err
+		}
+		template.ExcludedDNSDomains = nc.Excluded.DNSDomains
+		template.ExcludedIPRanges = ipRanges
+		template.ExcludedEmailAddresses = nc.Excluded.EmailAddresses
+		template.ExcludedURIDomains = nc.Excluded.URIDomains
+	}
+
+	return nil
+}
+
+func parseIPRanges(ranges []string) ([]*net.IPNet, error) {
+	var ipNets []*net.IPNet
+	for _, r := range ranges {
+		_, ipNet, err := net.ParseCIDR(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP range in name constraints: %w", err)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+// NameConstraintsExtension returns the X.509 Name Constraints extension
+// described by nc, or nil if nc does not contain any constraints.
+func NameConstraintsExtension(nc *v1.NameConstraints) (*pkix.Extension, error) {
+	var template x509.Certificate
+	if err := setNameConstraints(&template, nc); err != nil {
+		return nil, err
+	}
+
+	permitted, excluded := permittedSubtrees(&template), excludedSubtrees(&template)
+	if permitted.empty() && excluded.empty() {
+		return nil, nil
+	}
+
+	value, err := asn1.Marshal(nameConstraints{
+		Permitted: marshalGeneralSubtrees(permitted),
+		Excluded:  marshalGeneralSubtrees(excluded),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to asn1 encode name constraints: %w", err)
+	}
+
+	return &pkix.Extension{
+		Id:       OIDExtensionNameConstraints,
+		Critical: nc.Critical,
+		Value:    value,
+	}, nil
+}
+
+func marshalGeneralSubtrees(s nameConstraintSubtrees) []generalSubtree {
+	var subtrees []generalSubtree
+	add := func(tag int, value []byte) {
+		subtrees = append(subtrees, generalSubtree{
+			Base: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: tag, Bytes: value},
+		})
+	}
+
+	for _, domain := range s.dnsDomains {
+		add(nameTypeDNS, []byte(domain))
+	}
+	for _, ipNet := range s.ipRanges {
+		add(nameTypeIP, append(ipNet.IP.Mask(ipNet.Mask), ipNet.Mask...))
+	}
+	for _, email := range s.emailAddresses {
+		add(nameTypeEmail, []byte(email))
+	}
+	for _, domain := range s.uriDomains {
+		add(nameTypeURI, []byte(domain))
+	}
+
+	return subtrees
+}
+
+// setNameConstraintsFromExtension sets the name constraints fields of the
+// given certificate template to those encoded in a Name Constraints
+// extension, such as one found in a certificate signing request.
+func setNameConstraintsFromExtension(template *x509.Certificate, ext pkix.Extension) error {
+	var nc nameConstraints
+	rest, err := asn1.Unmarshal(ext.Value, &nc)
+	if err != nil {
+		return fmt.Errorf("failed to asn1 decode name constraints: %w", err)
+	}
+	if len(rest) != 0 {
+		return errors.New("trailing data after name constraints")
+	}
+
+	permitted, err := unmarshalGeneralSubtrees(nc.Permitted)
+	if err != nil {
+		return err
+	}
+	excluded, err := unmarshalGeneralSubtrees(nc.Excluded)
+	if err != nil {
+		return err
+	}
+
+	template.PermittedDNSDomainsCritical = ext.Critical
+	template.PermittedDNSDomains = permitted.dnsDomains
+	template.PermittedIPRanges = permitted.ipRanges
+	template.PermittedEmailAddresses = permitted.emailAddresses
+	template.PermittedURIDomains = permitted.uriDomains
+	template.ExcludedDNSDomains = excluded.dnsDomains
+	template.ExcludedIPRanges = excluded.ipRanges
+	template.ExcludedEmailAddresses = excluded.emailAddresses
+	template.ExcludedURIDomains = excluded.uriDomains
+
+	return nil
+}
+
+func unmarshalGeneralSubtrees(subtrees []generalSubtree) (nameConstraintSubtrees, error) {
+	var s nameConstraintSubtrees
+	for _, subtree := range subtrees {
+		base := subtree.Base
+		if base.Class != asn1.ClassContextSpecific {
+			return s, fmt.Errorf("invalid name in name constraints with class %d", base.Class)
+		}
+
+		switch base.Tag {
+		case nameTypeDNS:
+			s.dnsDomains = append(s.dnsDomains, string(base.Bytes))
+		case nameTypeIP:
+			n := len(base.Bytes) / 2
+			if n != net.IPv4len && n != net.IPv6len {
+				return s, fmt.Errorf("invalid IP range in name constraints with length %d", len(base.Bytes))
+			}
+			s.ipRanges = append(s.ipRanges, &net.IPNet{IP: base.Bytes[:n], Mask: base.Bytes[n:]})
+		case nameTypeEmail:
+			s.emailAddresses = append(s.emailAddresses, string(base.Bytes))
+		case nameTypeURI:
+			s.uriDomains = append(s.uriDomains, string(base.Bytes))
+		default:
+			return s, fmt.Errorf("unsupported name type %d in name constraints", base.Tag)
+		}
+	}
+	return s, nil
+}
+
+// CheckNameConstraints returns an error if any of the subject alternative
+// names of cert are excluded by, or not permitted by, the name constraints of
+// the CA certificate ca.
+func CheckNameConstraints(ca, cert *x509.Certificate) error {
+	permitted, excluded := permittedSubtrees(ca), excludedSubtrees(ca)
+	if permitted.empty() && excluded.empty() {
+		return nil
+	}
+
+	var violations []string
+	for _, name := range cert.DNSNames {
+		if !nameAllowed(name, permitted.dnsDomains, excluded.dnsDomains, matchDomainConstraint) {
+			violations = append(violations, fmt.Sprintf("DNS name %q", name))
+		}
+	}
+	for _, ip := range cert.IPAddresses {
+		if !ipAllowed(ip, permitted.ipRanges, excluded.ipRanges) {
+			violations = append(violations, fmt.Sprintf("IP address %q", ip))
+		}
+	}
+	for _, email := range cert.EmailAddresses {
+		if !nameAllowed(email, permitted.emailAddresses, excluded.emailAddresses, matchEmailConstraint) {
+			violations = append(violations, fmt.Sprintf("email address %q", email))
+		}
+	}
+	for _, uri := range cert.URIs {
+		// URIs without a DNS host name cannot be checked against the
+		// constraints, so are only allowed if there are none.
+		host := uri.Hostname()
+		allowed := len(permitted.uriDomains) == 0 && len(excluded.uriDomains) == 0
+		if host != "" && net.ParseIP(host) == nil {
+			allowed = nameAllowed(host, permitted.uriDomains, excluded.uriDomains, matchDomainConstraint)
+		}
+		if !allowed {
+			violations = append(violations, fmt.Sprintf("URI %q", uri))
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("not permitted by the name constraints of the CA certificate: %s", strings.Join(violations, ", "))
+	}
+
+	return nil
+}
+
+func nameAllowed(name string, permitted, excluded []string, match func(name, constraint string) bool) bool {
+	for _, constraint := range excluded {
+		if match(name, constraint) {
+			return false
+		}
+	}
+	if len(permitted) == 0 {
+		return true
+	}
+	for _, constraint := range permitted {
+		if match(name, constraint) {
+			return true
+		}
+	}
+	return false
+}
+
+func ipAllowed(ip net.IP, permitted, excluded []*net.IPNet) bool {
+	for _, ipNet := range excluded {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+	if len(permitted) == 0 {
+		return true
+	}
+	for _, ipNet := range permitted {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDomainConstraint returns true if domain is the constraint or one of its
+// subdomains. A constraint with a leading period only matches subdomains.
+func matchDomainConstraint(domain, constraint string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	constraint = strings.ToLower(constraint)
+	if constraint == "" {
+		return true
+	}
+	if strings.HasPrefix(constraint, ".") {
+		return strings.HasSuffix(domain, constraint)
+	}
+	return domain == constraint || strings.HasSuffix(domain, "."+constraint)
+}
+
+// matchEmailConstraint returns true if email is the mailbox named by the
+// constraint, or is hosted on the domain named by the constraint. A domain
+// with a leading period only matches mailboxes on its subdomains.
+func matchEmailConstraint(email, constraint string) bool {
+	if strings.Contains(constraint, "@") {
+		return strings.EqualFold(email, constraint)
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	host := strings.ToLower(email[at+1:])
+	constraint = strings.ToLower(constraint)
+	if strings.HasPrefix(constraint, ".") {
+		return strings.HasSuffix(host, constraint)
+	}
+	return host == constraint
+}
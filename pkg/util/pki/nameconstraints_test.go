@@ -0,0 +1,251 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ipNet
+}
+
+func testNameConstraints() *cmapi.NameConstraints {
+	return &cmapi.NameConstraints{
+		Critical: true,
+		Permitted: &cmapi.NameConstraintItem{
+			DNSDomains:     []string{"example.com", ".example.org"},
+			IPRanges:       []string{"10.0.0.0/8", "2001:db8::/32"},
+			EmailAddresses: []string{"admin@example.net", "example.com"},
+			URIDomains:     []string{".example.com"},
+		},
+		Excluded: &cmapi.NameConstraintItem{
+			DNSDomains: []string{"secret.example.com"},
+			IPRanges:   []string{"10.10.0.0/16"},
+		},
+	}
+}
+
+func assertNameConstraints(t *testing.T, cert *x509.Certificate) {
+	if !cert.PermittedDNSDomainsCritical {
+		t.Errorf("expected name constraints to be critical")
+	}
+	checks := []struct {
+		name     string
+		got, exp interface{}
+	}{
+		{"PermittedDNSDomains", cert.PermittedDNSDomains, []string{"example.com", ".example.org"}},
+		{"PermittedIPRanges", cert.PermittedIPRanges, []*net.IPNet{mustParseCIDR(t, "10.0.0.0/8"), mustParseCIDR(t, "2001:db8::/32")}},
+		{"PermittedEmailAddresses", cert.PermittedEmailAddresses, []string{"admin@example.net", "example.com"}},
+		{"PermittedURIDomains", cert.PermittedURIDomains, []string{".example.com"}},
+		{"ExcludedDNSDomains", cert.ExcludedDNSDomains, []string{"secret.example.com"}},
+		{"ExcludedIPRanges", cert.ExcludedIPRanges, []*net.IPNet{mustParseCIDR(t, "10.10.0.0/16")}},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.exp) {
+			t.Errorf("unexpected %s, exp=%v got=%v", c.name, c.exp, c.got)
+		}
+	}
+}
+
+func TestNameConstraintsExtension(t *testing.T) {
+	ext, err := NameConstraintsExtension(nil)
+	if err != nil || ext != nil {
+		t.Fatalf("expected no extension without name constraints, got %v, %v", ext, err)
+	}
+
+	ext, err = NameConstraintsExtension(&cmapi.NameConstraints{Critical: true})
+	if err != nil || ext != nil {
+		t.Fatalf("expected no extension for empty name constraints, got %v, %v", ext, err)
+	}
+
+	_, err = NameConstraintsExtension(&cmapi.NameConstraints{
+		Permitted: &cmapi.NameConstraintItem{IPRanges: []string{"10.0.0.1"}},
+	})
+	if err == nil {
+		t.Fatalf("expected an error for an invalid IP range")
+	}
+
+	ext, err = NameConstraintsExtension(testNameConstraints())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ext.Id.Equal(OIDExtensionNameConstraints) || !ext.Critical {
+		t.Fatalf("unexpected extension %v", ext)
+	}
+
+	var template x509.Certificate
+	if err := setNameConstraintsFromExtension(&template, *ext); err != nil {
+		t.Fatal(err)
+	}
+	assertNameConstraints(t, &template)
+}
+
+func TestGenerateNameConstraints(t *testing.T) {
+	crt := &cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			CommonName:      "intermediate",
+			IsCA:            true,
+			PrivateKey:      &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+			NameConstraints: testNameConstraints(),
+		},
+	}
+
+	t.Run("GenerateTemplate sets the constraints of the certificate", func(t *testing.T) {
+		key, err := GenerateECPrivateKey(256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template, err := GenerateTemplate(crt)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Round trip through x509.CreateCertificate to ensure the extension
+		// is encoded in the signed certificate.
+		_, cert, err := SignCertificate(template, template, key.Public(), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertNameConstraints(t, cert)
+	})
+
+	t.Run("GenerateCSR encodes the constraints in the request", func(t *testing.T) {
+		key, err := GenerateECPrivateKey(256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		csr, err := GenerateCSR(crt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		csrDER, err := EncodeCSR(csr, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+		template, err := GenerateTemplateFromCSRPEM(csrPEM, cmapi.DefaultCertificateDuration, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertNameConstraints(t, template)
+
+		template, err = GenerateTemplateFromCSRPEM(csrPEM, cmapi.DefaultCertificateDuration, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(template.PermittedDNSDomains) > 0 || len(template.ExcludedDNSDomains) > 0 {
+			t.Errorf("expected name constraints to be ignored for non-CA certificates")
+		}
+	})
+}
+
+func TestCheckNameConstraints(t *testing.T) {
+	ca := &x509.Certificate{}
+	if err := setNameConstraints(ca, testNameConstraints()); err != nil {
+		t.Fatal(err)
+	}
+
+	mustParseURL := func(s string) *url.URL {
+		u, err := url.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+
+	tests := map[string]struct {
+		ca     *x509.Certificate
+		cert   *x509.Certificate
+		expErr bool
+	}{
+		"a CA without name constraints allows any name": {
+			ca:   &x509.Certificate{},
+			cert: &x509.Certificate{DNSNames: []string{"example.io"}},
+		},
+		"permitted names": {
+			ca: ca,
+			cert: &x509.Certificate{
+				DNSNames:       []string{"example.com", "www.example.com", "www.example.org"},
+				IPAddresses:    []net.IP{net.ParseIP("10.1.2.3"), net.ParseIP("2001:db8::1")},
+				EmailAddresses: []string{"admin@example.net", "user@example.com"},
+				URIs:           []*url.URL{mustParseURL("spiffe://www.example.com/workload")},
+			},
+		},
+		"DNS name outside the permitted domains": {
+			ca:     ca,
+			cert:   &x509.Certificate{DNSNames: []string{"example.io"}},
+			expErr: true,
+		},
+		"DNS name must be a subdomain of a domain with a leading period": {
+			ca:     ca,
+			cert:   &x509.Certificate{DNSNames: []string{"example.org"}},
+			expErr: true,
+		},
+		"excluded DNS name": {
+			ca:     ca,
+			cert:   &x509.Certificate{DNSNames: []string{"www.secret.example.com"}},
+			expErr: true,
+		},
+		"excluded IP address": {
+			ca:     ca,
+			cert:   &x509.Certificate{IPAddresses: []net.IP{net.ParseIP("10.10.0.1")}},
+			expErr: true,
+		},
+		"IP address outside the permitted ranges": {
+			ca:     ca,
+			cert:   &x509.Certificate{IPAddresses: []net.IP{net.ParseIP("192.168.0.1")}},
+			expErr: true,
+		},
+		"email address on a subdomain of a domain without a leading period": {
+			ca:     ca,
+			cert:   &x509.Certificate{EmailAddresses: []string{"user@mail.example.com"}},
+			expErr: true,
+		},
+		"email address other than a permitted mailbox": {
+			ca:     ca,
+			cert:   &x509.Certificate{EmailAddresses: []string{"user@example.net"}},
+			expErr: true,
+		},
+		"URI without a host": {
+			ca:     ca,
+			cert:   &x509.Certificate{URIs: []*url.URL{mustParseURL("urn:example:workload")}},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := CheckNameConstraints(test.ca, test.cert)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error, expected error=%t, got: %v", test.expErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"hash"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/jetstack/cert-manager/pkg/util/errors"
+)
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+// encryptedPrivateKeyInfo is the ASN.1 structure of an encrypted PKCS#8
+// private key, as defined in RFC 5208, section 6.
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// pbes2Params are the parameters of the PBES2 encryption scheme, as defined
+// in RFC 8018, appendix A.4.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params are the parameters of the PBKDF2 key derivation function, as
+// defined in RFC 8018, appendix A.2.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// DecodePrivateKeyBytesWithPassword will decode a PEM encoded private key into
+// a crypto.Signer. Password protected PKCS#8 private keys
+// ("ENCRYPTED PRIVATE KEY") are decrypted using the given password; only the
+// PBES2 scheme with PBKDF2 and AES-CBC or DES-EDE3-CBC is supported, which is
+// what OpenSSL produces by default. Other private keys are decoded as with
+// DecodePrivateKeyBytes.
+func DecodePrivateKeyBytesWithPassword(keyBytes, password []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return nil, errors.NewInvalidData("error decoding private key PEM block")
+	}
+
+	if block.Type != "ENCRYPTED PRIVATE KEY" {
+		return DecodePrivateKeyBytes(keyBytes)
+	}
+
+	der, err := decryptPKCS8PrivateKey(block.Bytes, password)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		// A wrong password usually fails when removing the padding, but
+		// can also result in garbage that happens to be correctly padded.
+		return nil, errors.NewInvalidData("error parsing encrypted pkcs#8 private key, the password may be incorrect: %s", err.Error())
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.NewInvalidData("error parsing encrypted pkcs#8 private key: invalid key type")
+	}
+	return signer, nil
+}
+
+// decryptPKCS8PrivateKey decrypts the DER encoded EncryptedPrivateKeyInfo
+// and returns the DER encoded PrivateKeyInfo it contains.
+func decryptPKCS8PrivateKey(der, password []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, errors.NewInvalidData("error parsing encrypted pkcs#8 private key: %s", err.Error())
+	}
+
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, errors.NewInvalidData("unsupported encrypted pkcs#8 private key encryption algorithm %s, only PBES2 is supported", info.EncryptionAlgorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, errors.NewInvalidData("error parsing PBES2 parameters: %s", err.Error())
+	}
+
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, errors.NewInvalidData("unsupported PBES2 key derivation function %s, only PBKDF2 is supported", params.KeyDerivationFunc.Algorithm)
+	}
+
+	var kdfParams pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil {
+		return nil, errors.NewInvalidData("error parsing PBKDF2 parameters: %s", err.Error())
+	}
+
+	var prf func() hash.Hash
+	switch alg := kdfParams.PRF.Algorithm; {
+	// hmacWithSHA1 is the default if no PRF is specified
+	case len(alg) == 0, alg.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case alg.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	case alg.Equal(oidHMACWithSHA384):
+		prf = sha512.New384
+	case alg.Equal(oidHMACWithSHA512):
+		prf = sha512.New
+	default:
+		return nil, errors.NewInvalidData("unsupported PBKDF2 pseudorandom function %s", alg)
+	}
+
+	var newCipher func([]byte) (cipher.Block, error)
+	var keyLength int
+	switch alg := params.EncryptionScheme.Algorithm; {
+	case alg.Equal(oidAES128CBC):
+		newCipher, keyLength = aes.NewCipher, 16
+	case alg.Equal(oidAES192CBC):
+		newCipher, keyLength = aes.NewCipher, 24
+	case alg.Equal(oidAES256CBC):
+		newCipher, keyLength = aes.NewCipher, 32
+	case alg.Equal(oidDESEDE3CBC):
+		newCipher, keyLength = des.NewTripleDESCipher, 24
+	default:
+		return nil, errors.NewInvalidData("unsupported PBES2 encryption scheme %s", alg)
+	}
+	if kdfParams.KeyLength != 0 && kdfParams.KeyLength != keyLength {
+		return nil, errors.NewInvalidData("invalid PBKDF2 key length %d for encryption scheme %s", kdfParams.KeyLength, params.EncryptionScheme.Algorithm)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, errors.NewInvalidData("error parsing encryption scheme parameters: %s", err.Error())
+	}
+
+	block, err := newCipher(pbkdf2.Key(password, kdfParams.Salt, kdfParams.IterationCount, keyLength, prf))
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, errors.NewInvalidData("invalid initialisation vector length %d for encryption scheme %s", len(iv), params.EncryptionScheme.Algorithm)
+	}
+	if len(info.EncryptedData) == 0 || len(info.EncryptedData)%block.BlockSize() != 0 {
+		return nil, errors.NewInvalidData("encrypted pkcs#8 private key data is not a multiple of the block size")
+	}
+
+	plaintext := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, info.EncryptedData)
+
+	// remove the PKCS#7 padding, which is only valid if the password
+	// was correct
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > block.BlockSize() ||
+		!bytes.Equal(plaintext[len(plaintext)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, errors.NewInvalidData("error decrypting pkcs#8 private key: incorrect password")
+	}
+
+	return plaintext[:len(plaintext)-padding], nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"testing"
+)
+
+func TestParseRevocationReason(t *testing.T) {
+	tests := map[string]struct {
+		name   string
+		reason RevocationReason
+		expErr bool
+	}{
+		"empty name should be unspecified": {
+			name:   "",
+			reason: RevocationReasonUnspecified,
+		},
+		"keyCompromise should be parsed": {
+			name:   "keyCompromise",
+			reason: RevocationReasonKeyCompromise,
+		},
+		"superseded should be parsed": {
+			name:   "superseded",
+			reason: RevocationReasonSuperseded,
+		},
+		"names are case sensitive": {
+			name:   "KeyCompromise",
+			expErr: true,
+		},
+		"removeFromCRL cannot be used to revoke": {
+			name:   "removeFromCRL",
+			expErr: true,
+		},
+		"unknown name should error": {
+			name:   "foo",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reason, err := ParseRevocationReason(test.name)
+			if (err != nil) != test.expErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if reason != test.reason {
+				t.Errorf("unexpected reason, exp=%s got=%s", test.reason, reason)
+			}
+		})
+	}
+}
+
+func TestRevocationReasonString(t *testing.T) {
+	if s := RevocationReasonCessationOfOperation.String(); s != "cessationOfOperation" {
+		t.Errorf("unexpected name, exp=cessationOfOperation got=%s", s)
+	}
+	if s := RevocationReason(7).String(); s != "RevocationReason(7)" {
+		t.Errorf("unexpected name, exp=RevocationReason(7) got=%s", s)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	jks "github.com/pavel-v-chernykh/keystore-go"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// EncodePKCS12Truststore encodes the PEM encoded CA certificates in caPem as
+// a PKCS#12 truststore, using the given password.
+func EncodePKCS12Truststore(password string, caPem []byte) ([]byte, error) {
+	cas, err := DecodeX509CertificateChainBytes(caPem)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkcs12.EncodeTrustStore(rand.Reader, cas, password)
+}
+
+// EncodeJKSTruststore encodes the PEM encoded CA certificates in caPem as a
+// JKS truststore, using the given password.
+// The first certificate is stored under the alias "ca", and any further
+// certificates under the aliases "ca-1", "ca-2" and so on.
+func EncodeJKSTruststore(password []byte, caPem []byte) ([]byte, error) {
+	cas, err := DecodeX509CertificateChainBytes(caPem)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := jks.KeyStore{}
+	for i, ca := range cas {
+		alias := "ca"
+		if i > 0 {
+			alias = fmt.Sprintf("ca-%d", i)
+		}
+		ks[alias] = &jks.TrustedCertificateEntry{
+			Entry: jks.Entry{
+				CreationDate: time.Now(),
+			},
+			Certificate: jks.Certificate{
+				Type:    "X509",
+				Content: ca.Raw,
+			},
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := jks.Encode(buf, ks, password); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"bytes"
+	"testing"
+
+	jks "github.com/pavel-v-chernykh/keystore-go"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestEncodePKCS12Truststore(t *testing.T) {
+	root1 := mustCreateBundle(t, nil, "root-1")
+	root2 := mustCreateBundle(t, nil, "root-2")
+
+	tests := map[string]struct {
+		caPEM []byte
+		cas   []*testBundle
+	}{
+		"encode a PKCS12 truststore for a CA": {
+			caPEM: root1.pem,
+			cas:   []*testBundle{root1},
+		},
+		"encode a PKCS12 truststore for multiple CAs": {
+			caPEM: joinPEM(root1.pem, root2.pem),
+			cas:   []*testBundle{root1, root2},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := EncodePKCS12Truststore("password", test.caPEM)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			certs, err := pkcs12.DecodeTrustStore(out, "password")
+			if err != nil {
+				t.Fatalf("error decoding truststore: %v", err)
+			}
+			if len(certs) != len(test.cas) {
+				t.Fatalf("expected %d certificates in truststore, got %d", len(test.cas), len(certs))
+			}
+			for i, ca := range test.cas {
+				if !certs[i].Equal(ca.cert) {
+					t.Errorf("certificate %d in truststore does not match", i)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeJKSTruststore(t *testing.T) {
+	root1 := mustCreateBundle(t, nil, "root-1")
+	root2 := mustCreateBundle(t, nil, "root-2")
+
+	out, err := EncodeJKSTruststore([]byte("password"), joinPEM(root1.pem, root2.pem))
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	ks, err := jks.Decode(bytes.NewReader(out), []byte("password"))
+	if err != nil {
+		t.Fatalf("error decoding truststore: %v", err)
+	}
+
+	for alias, ca := range map[string]*testBundle{"ca": root1, "ca-1": root2} {
+		entry, ok := ks[alias].(*jks.TrustedCertificateEntry)
+		if !ok {
+			t.Errorf("expected trusted certificate entry %q in truststore", alias)
+			continue
+		}
+		if !bytes.Equal(entry.Certificate.Content, ca.cert.Raw) {
+			t.Errorf("certificate %q in truststore does not match", alias)
+		}
+	}
+	if len(ks) != 2 {
+		t.Errorf("expected 2 entries in truststore, got %d", len(ks))
+	}
+}
@@ -22,6 +22,8 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/client-go/kubernetes"
+
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 )
 
 type ValidatingAdmissionHook interface {
@@ -31,7 +33,7 @@ type ValidatingAdmissionHook interface {
 
 	// InitPlugins will initialise all plugins which are registered for this
 	// validating admission hook.
-	InitPlugins(client kubernetes.Interface)
+	InitPlugins(client kubernetes.Interface, cmClient cmclient.Interface)
 }
 
 type MutatingAdmissionHook interface {
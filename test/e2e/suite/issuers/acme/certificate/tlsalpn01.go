@@ -0,0 +1,170 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/test/e2e/framework"
+	"github.com/jetstack/cert-manager/test/e2e/framework/helper/featureset"
+	"github.com/jetstack/cert-manager/test/e2e/framework/helper/validation"
+	"github.com/jetstack/cert-manager/test/e2e/framework/log"
+	"github.com/jetstack/cert-manager/test/e2e/util"
+	e2eutil "github.com/jetstack/cert-manager/test/e2e/util"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var _ = framework.CertManagerDescribe("ACME Certificate (TLS-ALPN-01)", func() {
+	f := framework.NewDefaultFramework("create-acme-certificate-tlsalpn01")
+
+	var acmeIngressDomain string
+	issuerName := "test-acme-issuer"
+	certificateName := "test-acme-certificate"
+	certificateSecretName := "test-acme-certificate"
+	// solverPodLabel is added to the solver pods through the pod template of
+	// the TLS-ALPN-01 solver.
+	solverPodLabel := "testing.cert-manager.io/tlsalpn01-solver"
+
+	// ACME Issuer does not return a ca.crt. See:
+	// https://github.com/jetstack/cert-manager/issues/1571
+	unsupportedFeatures := featureset.NewFeatureSet(featureset.SaveCAToSecret)
+	validations := validation.CertificateSetForUnsupportedFeatureSet(unsupportedFeatures)
+
+	BeforeEach(func() {
+		solvers := []cmacme.ACMEChallengeSolver{
+			{
+				TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{
+					// The ingress controller passes the TLS connections of
+					// the ACME server through to the solver pods based on
+					// their SNI.
+					SNIPassthrough: &cmacme.ACMEChallengeSolverTLSALPN01SNIPassthrough{
+						Class: &f.Config.Addons.IngressController.IngressClass,
+						IngressTemplate: &cmacme.ACMEChallengeSolverTLSALPN01IngressTemplate{
+							ACMEChallengeSolverTLSALPN01IngressObjectMeta: cmacme.ACMEChallengeSolverTLSALPN01IngressObjectMeta{
+								Annotations: map[string]string{
+									"nginx.ingress.kubernetes.io/ssl-passthrough": "true",
+								},
+							},
+						},
+					},
+					PodTemplate: &cmacme.ACMEChallengeSolverTLSALPN01PodTemplate{
+						ACMEChallengeSolverTLSALPN01PodObjectMeta: cmacme.ACMEChallengeSolverTLSALPN01PodObjectMeta{
+							Labels: map[string]string{solverPodLabel: "true"},
+						},
+					},
+				},
+			},
+		}
+		acmeIssuer := gen.Issuer(issuerName,
+			gen.SetIssuerNamespace(f.Namespace.Name),
+			gen.SetIssuerACMEEmail(f.Config.Addons.ACMEServer.TestingACMEEmail),
+			gen.SetIssuerACMEURL(f.Config.Addons.ACMEServer.URL),
+			gen.SetIssuerACMEPrivKeyRef(f.Config.Addons.ACMEServer.TestingACMEPrivateKey),
+			gen.SetIssuerACMESkipTLSVerify(true),
+			gen.SetIssuerACMESolvers(solvers))
+		By("Creating an Issuer")
+		_, err := f.CertManagerClientSet.CertmanagerV1().Issuers(f.Namespace.Name).Create(context.TODO(), acmeIssuer, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		By("Waiting for Issuer to become Ready")
+		err = util.WaitForIssuerCondition(f.CertManagerClientSet.CertmanagerV1().Issuers(f.Namespace.Name),
+			issuerName,
+			v1.IssuerCondition{
+				Type:   v1.IssuerConditionReady,
+				Status: cmmeta.ConditionTrue,
+			})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		acmeIngressDomain = e2eutil.RandomSubdomain(f.Config.Addons.IngressController.Domain)
+	})
+
+	AfterEach(func() {
+		By("Cleaning up")
+		f.CertManagerClientSet.CertmanagerV1().Issuers(f.Namespace.Name).Delete(context.TODO(), issuerName, metav1.DeleteOptions{})
+		f.KubeClientSet.CoreV1().Secrets(f.Namespace.Name).Delete(context.TODO(), f.Config.Addons.ACMEServer.TestingACMEPrivateKey, metav1.DeleteOptions{})
+	})
+
+	It("should obtain a signed certificate with a single CN from the ACME server", func() {
+		certClient := f.CertManagerClientSet.CertmanagerV1().Certificates(f.Namespace.Name)
+
+		By("Creating a Certificate")
+		cert := gen.Certificate(certificateName,
+			gen.SetCertificateSecretName(certificateSecretName),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuerName}),
+			gen.SetCertificateDNSNames(acmeIngressDomain),
+		)
+		cert.Namespace = f.Namespace.Name
+		cert, err := certClient.Create(context.TODO(), cert, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Waiting for a solver pod created from the pod template")
+		err = wait.PollImmediate(time.Second, time.Minute, func() (bool, error) {
+			pods, err := f.KubeClientSet.CoreV1().Pods(f.Namespace.Name).List(context.TODO(), metav1.ListOptions{
+				LabelSelector: solverPodLabel + "=true",
+			})
+			if err != nil {
+				return false, err
+			}
+			if len(pods.Items) == 0 {
+				log.Logf("Waiting for a TLS-ALPN-01 solver pod labelled %s", solverPodLabel)
+				return false, nil
+			}
+			return true, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Waiting for the Certificate to be issued...")
+		cert, err = f.Helper().WaitForCertificateReadyAndDoneIssuing(cert, time.Minute*5)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Validating the issued Certificate...")
+		err = f.Helper().ValidateCertificate(cert, validations...)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should obtain a signed certificate for multiple domains from the ACME server", func() {
+		certClient := f.CertManagerClientSet.CertmanagerV1().Certificates(f.Namespace.Name)
+
+		By("Creating a Certificate")
+		cert := gen.Certificate(certificateName,
+			gen.SetCertificateSecretName(certificateSecretName),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuerName}),
+			gen.SetCertificateDNSNames(acmeIngressDomain, e2eutil.RandomSubdomain(acmeIngressDomain)),
+		)
+		cert.Namespace = f.Namespace.Name
+		cert, err := certClient.Create(context.TODO(), cert, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Waiting for the Certificate to be issued...")
+		cert, err = f.Helper().WaitForCertificateReadyAndDoneIssuing(cert, time.Minute*5)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Validating the issued Certificate...")
+		err = f.Helper().ValidateCertificate(cert, validations...)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
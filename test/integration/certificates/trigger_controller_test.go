@@ -65,7 +65,7 @@ func TestTriggerController(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	shouldReissue := policies.NewTriggerPolicyChain(fakeClock).Evaluate
+	shouldReissue := policies.NewTriggerPolicyChain(fakeClock, nil, nil).Evaluate
 	ctrl, queue, mustSync := trigger.NewController(logf.Log, cmCl, factory, cmFactory, framework.NewEventRecorder(t), fakeClock, shouldReissue)
 	c := controllerpkg.NewController(
 		ctx,
@@ -120,6 +120,12 @@ func SetCertificateSecretTemplate(annotations, labels map[string]string) Certifi
 	}
 }
 
+func SetCertificateOCSPStapling(enabled bool) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.OCSPStapling = &v1.CertificateOCSPStapling{Enabled: enabled}
+	}
+}
+
 func SetCertificateDuration(duration time.Duration) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Spec.Duration = &metav1.Duration{Duration: duration}
@@ -261,3 +267,33 @@ func SetCertificateRevisionHistoryLimit(limit int32) CertificateModifier {
 		crt.Spec.RevisionHistoryLimit = &limit
 	}
 }
+
+func SetCertificateRevocationPolicy(policy v1.CertificateRevocationPolicy) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.RevocationPolicy = policy
+	}
+}
+
+func SetCertificateFinalizers(finalizers ...string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Finalizers = finalizers
+	}
+}
+
+func SetCertificateDeletionTimestamp(ts metav1.Time) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.DeletionTimestamp = &ts
+	}
+}
+
+func SetCertificateLastRevocation(revocation v1.CertificateRevocation) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.LastRevocation = &revocation
+	}
+}
+
+func SetCertificateRenewalInfo(info v1.CertificateRenewalInfo) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.RenewalInfo = &info
+	}
+}
@@ -242,6 +242,24 @@ func SetIssuerCASecretName(secretName string) IssuerModifier {
 	}
 }
 
+func SetIssuerEST(e v1.ESTIssuer) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().EST = &e
+	}
+}
+
+func SetIssuerSCEP(s v1.SCEPIssuer) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().SCEP = &s
+	}
+}
+
+func SetIssuerCMP(c v1.CMPIssuer) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().CMP = &c
+	}
+}
+
 func SetIssuerVault(v v1.VaultIssuer) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetSpec().Vault = &v
@@ -328,6 +346,38 @@ func SetIssuerVaultKubernetesAuth(keyName, secretServiceAccount, role, path stri
 
 	}
 }
+
+func SetIssuerVaultJWTAuth(serviceAccount, role, path string, audiences ...string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		spec := iss.GetSpec()
+		if spec.Vault == nil {
+			spec.Vault = &v1.VaultIssuer{}
+		}
+		spec.Vault.Auth.JWT = &v1.VaultJWTAuth{
+			Path: path,
+			Role: role,
+			ServiceAccountRef: v1.ServiceAccountRef{
+				Name: serviceAccount,
+			},
+			Audiences: audiences,
+		}
+	}
+}
+
+func SetIssuerVaultClientCertificateAuth(secretName, name, path string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		spec := iss.GetSpec()
+		if spec.Vault == nil {
+			spec.Vault = &v1.VaultIssuer{}
+		}
+		spec.Vault.Auth.ClientCertificate = &v1.VaultClientCertificateAuth{
+			Path:       path,
+			SecretName: secretName,
+			Name:       name,
+		}
+	}
+}
+
 func SetIssuerSelfSigned(a v1.SelfSignedIssuer) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetSpec().SelfSigned = &a
@@ -340,6 +390,12 @@ func SetIssuerVenafi(a v1.VenafiIssuer) IssuerModifier {
 	}
 }
 
+func SetIssuerNamespaceAccess(a v1.IssuerNamespaceAccess) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().NamespaceAccess = &a
+	}
+}
+
 func AddIssuerCondition(c v1.IssuerCondition) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetStatus().Conditions = append(iss.GetStatus().Conditions, c)
@@ -129,3 +129,9 @@ func SetOrderOwnerReference(ref metav1.OwnerReference) OrderModifier {
 		order.OwnerReferences = []metav1.OwnerReference{ref}
 	}
 }
+
+func SetOrderRateLimitedUntil(until metav1.Time) OrderModifier {
+	return func(order *cmacme.Order) {
+		order.Status.RateLimitedUntil = &until
+	}
+}